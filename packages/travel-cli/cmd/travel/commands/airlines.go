@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/beetlebot/travel-cli/internal/baggage"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func AirlinesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "airlines",
+		Aliases: []string{"airline"},
+		Short:   "Look up airline policies in the bundled reference lists",
+	}
+	cmd.AddCommand(airlinesBaggageCmd())
+	return cmd
+}
+
+func airlinesBaggageCmd() *cobra.Command {
+	var airline, cabin string
+
+	cmd := &cobra.Command{
+		Use:   "baggage",
+		Short: "Show the carry-on and checked bags an airline's fares include in a cabin",
+		Long: `Answers come from a list bundled with the CLI covering major airlines,
+checked as of its asOf date. Allowances are for standard fares on
+international routes; basic fares and some routes include less, as the
+note says. The url is the airline's own policy, which has the final word.`,
+		Example: `  travel airlines baggage --airline AC
+  travel airlines baggage --airline "British Airways" --cabin business`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if airline == "" {
+				return invalidInputf("--airline is required")
+			}
+			p, err := baggage.Lookup(airline, cabin)
+			if errors.Is(err, baggage.ErrNotFound) {
+				return reportError(ExitNotFound, "no baggage policy", err)
+			}
+			if err != nil {
+				return invalidInput(err)
+			}
+			return output.JSON(p)
+		},
+	}
+
+	cmd.Flags().StringVar(&airline, "airline", "", "Airline IATA code or name, e.g. AC (required)")
+	cmd.Flags().StringVar(&cabin, "cabin", "economy", "Cabin class: economy, premium_economy, business, first")
+	_ = cmd.RegisterFlagCompletionFunc("airline", cobra.FixedCompletions(baggage.Airlines(), cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("cabin", cobra.FixedCompletions(baggage.Cabins, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func AirportsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "airports",
+		Aliases: []string{"airport"},
+		Short:   "Look up airports in the bundled IATA reference list",
+	}
+	cmd.AddCommand(airportsLookupCmd())
+	cmd.AddCommand(airportsNearCmd())
+	return cmd
+}
+
+func airportsLookupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "lookup <code|city>...",
+		Short: "Show the name, city, country, coordinates, and timezone of airports",
+		Long: `Each argument is an airport code, a metropolitan code such as PAR, or a
+city name; codes and cities list every airport they cover.`,
+		Example: `  travel airports lookup CDG
+  travel airports lookup LON YUL "Mexico City"`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeAirports,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var found []airports.Airport
+			for _, arg := range args {
+				if a, err := airports.Lookup(arg); err == nil {
+					found = append(found, *a)
+					continue
+				}
+				inCity, err := airports.InCity(arg)
+				if err != nil {
+					return reportError(ExitNotFound, "airport not found", err)
+				}
+				found = append(found, inCity...)
+			}
+			return output.JSON(found)
+		},
+	}
+}
+
+func airportsNearCmd() *cobra.Command {
+	var (
+		radius float64
+		max    int
+	)
+
+	cmd := &cobra.Command{
+		Use:               "near <code|city>",
+		Short:             "List the airports within a radius of an airport or city, nearest first",
+		Example:           `  travel airports near "Montreal" --radius 150`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeAirports,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if radius <= 0 {
+				return invalidInputf("--radius must be above zero")
+			}
+			origin, err := airports.Find(strings.Join(args, " "))
+			if err != nil {
+				return reportError(ExitNotFound, "airport not found", err)
+			}
+			near, err := airports.Near(origin, radius)
+			if err != nil {
+				return err
+			}
+			if max > 0 && len(near) > max {
+				near = near[:max]
+			}
+			return output.JSON(near)
+		},
+	}
+
+	cmd.Flags().Float64Var(&radius, "radius", 150, "Distance in km to search within")
+	cmd.Flags().IntVar(&max, "max", 10, "Maximum airports to list")
+
+	return cmd
+}
+
+// maxNearbyAirports caps how many airports each end of a --nearby flight
+// search widens to, the one given included.
+const maxNearbyAirports = 3
+
+// nearbyAirports lists the codes of the airports within km of code,
+// nearest first, or just code when it isn't in the bundled list.
+func nearbyAirports(code string, km float64) []string {
+	a, err := airports.Find(code)
+	if err != nil {
+		return []string{code}
+	}
+	near, err := airports.Near(a, km)
+	if err != nil {
+		return []string{code}
+	}
+	codes := []string{code}
+	for _, n := range near {
+		if len(codes) == maxNearbyAirports {
+			break
+		}
+		if n.Code != code && n.Code != a.Code && n.CityCode != code {
+			codes = append(codes, n.Code)
+		}
+	}
+	return codes
+}
+
+// completeAirportFlags completes cmd's airport flags from the bundled
+// list.
+func completeAirportFlags(cmd *cobra.Command, flags ...string) {
+	for _, name := range flags {
+		_ = cmd.RegisterFlagCompletionFunc(name, completeAirports)
+	}
+}
+
+func completeAirports(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	matches, err := airports.Complete(toComplete)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	completions := make([]string, len(matches))
+	for i, a := range matches {
+		completions[i] = a.Code + "\t" + a.Name + ", " + a.City
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
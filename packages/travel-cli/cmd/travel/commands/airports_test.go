@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNearbyAirports(t *testing.T) {
+	if got := nearbyAirports("YUL", 150); !slices.Equal(got, []string{"YUL", "YHU", "PBG"}) {
+		t.Errorf("nearbyAirports(YUL) = %v", got)
+	}
+	// A metropolitan code already covers its own airports.
+	if got := nearbyAirports("PAR", 60); !slices.Equal(got, []string{"PAR"}) {
+		t.Errorf("nearbyAirports(PAR) = %v", got)
+	}
+	if got := nearbyAirports("ZZZ", 150); !slices.Equal(got, []string{"ZZZ"}) {
+		t.Errorf("nearbyAirports(ZZZ) = %v", got)
+	}
+}
@@ -0,0 +1,265 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/notify"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func AlertsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Track routes and stays and get alerted when prices drop",
+	}
+	cmd.AddCommand(alertsAddCmd())
+	cmd.AddCommand(alertsListCmd())
+	cmd.AddCommand(alertsRemoveCmd())
+	cmd.AddCommand(alertsCheckCmd())
+	cmd.AddCommand(alertsDigestCmd())
+	return cmd
+}
+
+func alertsAddCmd() *cobra.Command {
+	var (
+		flight   core.FlightSearchRequest
+		stay     core.StaySearchRequest
+		maxPrice float64
+		targets  alerts.Targets
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a price alert for a flight route or a stay",
+		Example: `  travel alerts add --from YUL --to CDG --depart 2026-06-12 --max-price 600
+  travel alerts add --city Paris --checkin 2026-06-12 --checkout 2026-06-15 --max-price 150
+  travel alerts add --from YUL --to NRT --depart 2026-10-02 --max-price 900 --slack '${TRIP_SLACK_WEBHOOK}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if maxPrice <= 0 {
+				return invalidInputf("--max-price must be greater than zero")
+			}
+			a := &alerts.Alert{MaxPriceUSD: maxPrice}
+			if targets != (alerts.Targets{}) {
+				a.Notify = &targets
+			}
+			switch {
+			case flight.From != "" || flight.To != "":
+				if err := flight.Validate(); err != nil {
+					return invalidInput(err)
+				}
+				flight.ApplyDefaults()
+				a.Kind, a.Flight = alerts.KindFlights, &flight
+			case stay.City != "":
+				if err := stay.Validate(); err != nil {
+					return invalidInput(err)
+				}
+				stay.ApplyDefaults()
+				a.Kind, a.Stay = alerts.KindStays, &stay
+			default:
+				return invalidInputf("give a flight (--from, --to, --depart) or a stay (--city, --checkin, --checkout)")
+			}
+
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.Add(a); err != nil {
+				return err
+			}
+			return output.JSON(a)
+		},
+	}
+
+	cmd.Flags().StringVar(&flight.From, "from", "", "Origin airport code")
+	cmd.Flags().StringVar(&flight.To, "to", "", "Destination airport code")
+	cmd.Flags().StringVar(&flight.DepartDate, "depart", "", "Departure date YYYY-MM-DD")
+	cmd.Flags().StringVar(&flight.ReturnDate, "return", "", "Return date YYYY-MM-DD (optional)")
+	cmd.Flags().IntVar(&flight.Adults, "adults", 1, "Number of adults")
+	cmd.Flags().StringVar(&flight.CabinClass, "cabin", "economy", "Cabin class: economy, business, first")
+	cmd.Flags().StringVar(&stay.City, "city", "", "Stay city")
+	cmd.Flags().StringVar(&stay.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD")
+	cmd.Flags().StringVar(&stay.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD")
+	cmd.Flags().IntVar(&stay.Guests, "guests", 2, "Number of guests")
+	cmd.Flags().StringVar(&stay.StayType, "type", "any", "Stay type: hotel, apartment, cabin, campsite, any")
+	cmd.Flags().Float64Var(&maxPrice, "max-price", 0, "Trigger at or below this price in USD (per night for stays)")
+	cmd.Flags().StringVar(&targets.Slack, "slack", "", "Also post this alert's triggers to a Slack incoming webhook URL (may be ${ENV_VAR})")
+	cmd.Flags().StringVar(&targets.Discord, "discord", "", "Also post this alert's triggers to a Discord webhook URL (may be ${ENV_VAR})")
+	completeAirportFlags(cmd, "from", "to")
+
+	return cmd
+}
+
+func alertsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List saved alerts and their last observed prices",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			all, err := store.List()
+			if err != nil {
+				return err
+			}
+			if all == nil {
+				all = []alerts.Alert{}
+			}
+			return output.JSON(all)
+		},
+	}
+}
+
+func alertsRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <alert-id>",
+		Short: "Remove a saved alert",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.Remove(args[0]); err != nil {
+				return err
+			}
+			return output.JSON(map[string]string{"removed": args[0]})
+		},
+	}
+}
+
+func alertsCheckCmd() *cobra.Command {
+	var (
+		id         string
+		notifyFlag bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Re-run alert searches and print the alerts that triggered",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			all, err := store.List()
+			if err != nil {
+				return err
+			}
+			if id != "" {
+				all = filterAlert(all, id)
+				if len(all) == 0 {
+					return fmt.Errorf("%w: %s", alerts.ErrNotFound, id)
+				}
+			}
+
+			cfg := effectiveConfig(cmd)
+			if notifyFlag {
+				cfg.Notify.Desktop = true
+			}
+			notifier, err := buildNotifier(cfg)
+			if err != nil {
+				return err
+			}
+
+			report, checked := alerts.Check(recordingSearcher{buildOrchestrator(cfg)}, all)
+			if err := store.Update(checked); err != nil {
+				return err
+			}
+			notifier.Triggers(context.Background(), report)
+			return output.JSON(report)
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Check only this alert")
+	cmd.Flags().BoolVar(&notifyFlag, "notify", false, "Show a desktop notification for each newly triggered alert (same as notify.desktop)")
+
+	return cmd
+}
+
+func alertsDigestCmd() *cobra.Command {
+	var (
+		send   bool
+		period time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Print the alerts digest: tracked routes, their price changes, and the deals under target",
+		Long: `Builds the digest travel daemon sends on notify.digest from each alert's
+latest check, with price changes over --period from the search history.
+With --send it is also delivered to the sinks under notify:, for running
+the digest from an external scheduler.`,
+		Example: `  travel alerts digest
+  travel alerts check && travel alerts digest --period 168h --send`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			all, err := store.List()
+			if err != nil {
+				return err
+			}
+			var entries []history.Entry
+			if h, err := history.Open(); err == nil {
+				entries, _ = h.List(0)
+			}
+			now := time.Now().UTC()
+			n := notify.Digest(all, entries, now.Add(-period), now)
+			if send {
+				notifier, err := buildNotifier(config.Load())
+				if err != nil {
+					return err
+				}
+				if err := notifier.Send(context.Background(), n); err != nil {
+					return err
+				}
+			}
+			return output.JSON(n)
+		},
+	}
+
+	cmd.Flags().BoolVar(&send, "send", false, "Deliver the digest to the configured notify sinks")
+	cmd.Flags().DurationVar(&period, "period", 24*time.Hour, "How far back price changes are measured (168h for a weekly digest)")
+
+	return cmd
+}
+
+func filterAlert(all []alerts.Alert, id string) []alerts.Alert {
+	for _, a := range all {
+		if a.ID == id {
+			return []alerts.Alert{a}
+		}
+	}
+	return nil
+}
+
+// recordingSearcher records searches run on behalf of other commands in
+// the history, so alert checks feed history diff like manual searches.
+type recordingSearcher struct {
+	orch *core.Orchestrator
+}
+
+func (r recordingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	result, err := r.orch.SearchFlights(req)
+	if err == nil {
+		recordSearch(history.KindFlights, result)
+	}
+	return result, err
+}
+
+func (r recordingSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	result, err := r.orch.SearchStays(req)
+	if err == nil {
+		recordSearch(history.KindStays, result)
+	}
+	return result, err
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/ask"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// askResult is what travel ask prints: the question as it was read and
+// the results of the searches it asked for.
+type askResult struct {
+	Query   *ask.Query         `json:"query"`
+	Flights *core.SearchResult `json:"flights,omitempty"`
+	Stays   *core.SearchResult `json:"stays,omitempty"`
+}
+
+func AskCmd() *cobra.Command {
+	var (
+		noLLM bool
+		max   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ask <question>",
+		Short: "Search flights and stays from a question in plain words",
+		Long: `Reads the question as a flight search, a stay search, or both, runs
+them, and prints the query it read alongside the results, with whatever
+it had to assume.
+
+Questions name a route ("YUL to Lisbon"), dates ("July 3-10", "first week
+of July", "for 5 nights"), and optionally travelers ("2 adults"), stays
+("hotel", "apartment"), "nonstop", "business", and price limits ("under
+$800", "hotel under $150"). With ask.endpoint set to an OpenAI-compatible
+chat completions URL, a model reads the question first and the rules are
+the fallback.`,
+		Example: `  travel ask "cheap nonstop YUL to Lisbon first week of July, hotel under $150"
+  travel ask "hotel in Paris March 3-7 for 2 adults"
+  travel ask --no-llm "JFK to LAX one way 2026-07-01"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := effectiveConfig(cmd)
+
+			var llm ask.Interpreter
+			if cfg.Ask.Endpoint != "" && !noLLM {
+				llm = askLLM(cfg.Ask)
+			}
+			now := time.Now()
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+			q, err := ask.Interpret(runContext, llm, strings.Join(args, " "), today)
+			if err != nil {
+				return invalidInput(err)
+			}
+
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			out := askResult{Query: q}
+			var codes []int
+			if q.Flight != nil {
+				req := *q.Flight
+				req.MaxResults = max
+				req.ApplyDefaults()
+				if out.Flights, err = orch.SearchFlights(req); err != nil {
+					return searchError("flight search failed", err)
+				}
+				recordSearch(history.KindFlights, out.Flights)
+				core.AnnotateFreshness(out.Flights, time.Now())
+				codes = append(codes, ExitCode(searchExit(out.Flights, nil)))
+			}
+			if q.Stay != nil {
+				req := *q.Stay
+				req.MaxResults = max
+				req.ApplyDefaults()
+				if out.Stays, err = orch.SearchStays(req); err != nil {
+					return searchError("stay search failed", err)
+				}
+				recordSearch(history.KindStays, out.Stays)
+				core.AnnotateFreshness(out.Stays, time.Now())
+				codes = append(codes, ExitCode(searchExit(out.Stays, nil)))
+			}
+			if err := output.JSON(out); err != nil {
+				return err
+			}
+			return sharedExit(codes)
+		},
+	}
+
+	cmd.Flags().BoolVar(&noLLM, "no-llm", false, "Read the question with the built-in rules even when ask.endpoint is set")
+	cmd.Flags().IntVar(&max, "max", 10, "Maximum results to return per search")
+	addCacheFlags(cmd)
+
+	return cmd
+}
+
+// askLLM is the model interpreter the ask config describes.
+func askLLM(c config.AskConfig) *ask.LLM {
+	return &ask.LLM{Endpoint: c.Endpoint, Model: c.Model, APIKey: os.ExpandEnv(c.APIKey), Timeout: c.Timeout}
+}
@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/credentials"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// validateTimeout bounds each provider's credential check on import.
+const validateTimeout = 15 * time.Second
+
+const (
+	credStored     = "stored"
+	credUnverified = "unverified"
+	credInvalid    = "invalid"
+)
+
+func AuthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Import and export provider credentials kept in the OS keyring",
+	}
+	cmd.AddCommand(authImportCmd())
+	cmd.AddCommand(authExportCmd())
+	return cmd
+}
+
+// loaded records where loadCredentials found keys, and what it couldn't
+// read, for doctor to report.
+var loaded struct {
+	once       sync.Once
+	envFile    string
+	envErr     error
+	envSkipped []string
+	sealedErr  error
+}
+
+// loadCredentials exports the provider keys in the project's .env file,
+// keys encrypted in the config file, then credentials saved by `travel
+// auth import`, as environment variables, so adapters see them like keys
+// set in the shell. Variables already set win over each later source.
+// Decrypting keys and asking the keyring are slow, so it runs once, when
+// a command first builds its providers, and never for commands that
+// don't.
+func loadCredentials() {
+	loaded.once.Do(readCredentials)
+}
+
+func readCredentials() {
+	cfg := config.Load()
+	if wd, err := os.Getwd(); err == nil {
+		if path := credentials.FindEnvFile(wd); path != "" {
+			if _, loaded.envSkipped, loaded.envErr = credentials.LoadEnvFile(path, cfg.KeyNames()); loaded.envErr == nil {
+				loaded.envFile = path
+			}
+		}
+	}
+	sealed := map[string]string{}
+	for _, pc := range cfg.Providers {
+		maps.Copy(sealed, pc.Secrets)
+	}
+	loaded.sealedErr = credentials.ApplySealed(sealed)
+	if store, err := credentials.Open(); err == nil {
+		_ = store.ApplyEnv()
+	}
+}
+
+type importResult struct {
+	Provider string   `json:"provider"`
+	Keys     []string `json:"keys"`
+	Status   string   `json:"status"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+type importReport struct {
+	Backend   string         `json:"backend"`
+	Providers []importResult `json:"providers"`
+}
+
+func authImportCmd() *cobra.Command {
+	var skipValidate bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Save provider credentials from a YAML file (- for stdin), checking each against its provider",
+		Long: `Reads a file of the form
+
+  providers:
+    duffel:
+      DUFFEL_API_TOKEN: duffel_live_...
+
+and saves every key in the OS keyring (macOS Keychain, or Secret Service
+via secret-tool), or a private file when neither is available. Keys are
+first checked against providers that support it; a provider that rejects
+its keys is not saved. Saved keys are used whenever the variable is not
+already set in the environment.`,
+		Example: `  travel auth import creds.yaml
+  travel auth export | ssh other-host travel auth import -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				data []byte
+				err  error
+			)
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			bundle, err := credentials.ParseBundle(data)
+			if err != nil {
+				return err
+			}
+			store, err := credentials.Open()
+			if err != nil {
+				return err
+			}
+
+			router := buildRouter(effectiveConfig(cmd))
+			report := importReport{Backend: store.Backend()}
+			for _, provider := range bundle.ProviderNames() {
+				keys := bundle.Providers[provider]
+				res := importResult{Provider: provider, Keys: slices.Sorted(maps.Keys(keys)), Status: credStored}
+				if !skipValidate {
+					res.Status, res.Reason = validateProvider(router, provider, keys)
+				}
+				if res.Status != credInvalid {
+					for _, key := range res.Keys {
+						if err := store.Set(provider, key, keys[key]); err != nil {
+							return err
+						}
+					}
+				}
+				report.Providers = append(report.Providers, res)
+			}
+			return output.JSON(report)
+		},
+	}
+
+	cmd.Flags().BoolVar(&skipValidate, "skip-validate", false, "Save without checking keys against providers (e.g. offline)")
+
+	return cmd
+}
+
+// validateProvider checks keys with the provider's adapter. Keys that
+// can't be checked, because the adapter has no validator or the provider
+// couldn't be reached, are saved as unverified.
+func validateProvider(router *core.Router, provider string, keys map[string]string) (string, string) {
+	v, ok := router.Validator(provider)
+	if !ok {
+		return credUnverified, "provider does not support credential checks"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	err := v.ValidateCredentials(ctx, keys)
+	switch {
+	case err == nil:
+		return credStored, ""
+	case errors.Is(err, core.ErrInvalidCredentials):
+		return credInvalid, err.Error()
+	default:
+		return credUnverified, err.Error()
+	}
+}
+
+func authExportCmd() *cobra.Command {
+	var redact, env bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Print saved credentials as YAML for auth import, or as KEY=value lines for CI secrets",
+		Example: `  travel auth export > creds.yaml
+  travel auth export --redact
+  travel auth export --env | gh secret set -f -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := credentials.Open()
+			if err != nil {
+				return err
+			}
+			entries, err := store.Entries()
+			if err != nil {
+				return err
+			}
+			return writeBundle(cmd.OutOrStdout(), credentials.FromEntries(entries), redact, env)
+		},
+	}
+
+	cmd.Flags().BoolVar(&redact, "redact", false, "Mask values, keeping the last four characters")
+	cmd.Flags().BoolVar(&env, "env", false, "Print KEY=value lines instead of YAML")
+
+	return cmd
+}
+
+// writeBundle prints credentials as YAML for auth import, or as KEY=value
+// lines with env, masking values with redact.
+func writeBundle(w io.Writer, bundle *credentials.Bundle, redact, env bool) error {
+	if redact {
+		bundle = bundle.Redacted()
+	}
+	if env {
+		if len(bundle.Providers) == 0 {
+			return nil
+		}
+		_, err := fmt.Fprint(w, bundle.Env())
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(bundle); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// defaultBatchConcurrency is how many searches of an --input batch run at
+// once unless --concurrency says otherwise.
+const defaultBatchConcurrency = 4
+
+// batchResult is the outcome of one search of an --input batch: its
+// result, or why it could not run.
+type batchResult struct {
+	Request interface{}        `json:"request"`
+	Result  *core.SearchResult `json:"result,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// readInput reads the file at path, or stdin for "-".
+func readInput(cmd *cobra.Command, path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(cmd.InOrStdin())
+	}
+	return os.ReadFile(path)
+}
+
+// flightBatch decodes a JSON array of flight search requests, validating
+// each and filling in its defaults, and returns the city names each gave
+// for its airports.
+func flightBatch(data []byte) ([]core.FlightSearchRequest, [][]core.ResolvedPlace, error) {
+	var reqs []core.FlightSearchRequest
+	if err := json.Unmarshal(data, &reqs); err != nil {
+		return nil, nil, fmt.Errorf("--input must be a JSON array of flight search requests: %w", err)
+	}
+	if len(reqs) == 0 {
+		return nil, nil, fmt.Errorf("--input has no requests")
+	}
+	resolved := make([][]core.ResolvedPlace, len(reqs))
+	for i := range reqs {
+		if err := reqs[i].Validate(); err != nil {
+			return nil, nil, fmt.Errorf("request %d: %w", i, err)
+		}
+		var err error
+		if resolved[i], err = reqs[i].ResolveAirports(); err != nil {
+			return nil, nil, fmt.Errorf("request %d: %w", i, err)
+		}
+		reqs[i].ApplyDefaults()
+	}
+	return reqs, resolved, nil
+}
+
+// changedFlags returns the flags bind defines that were set on cmd's
+// command line.
+func changedFlags(cmd *cobra.Command, bind func(*pflag.FlagSet)) []string {
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	bind(fs)
+	var changed []string
+	fs.VisitAll(func(f *pflag.Flag) {
+		if cmd.Flags().Changed(f.Name) {
+			changed = append(changed, "--"+f.Name)
+		}
+	})
+	return changed
+}
+
+// searchBatch runs search for each request, at most concurrency at once,
+// and returns their outcomes in request order, annotated with their
+// freshness.
+func searchBatch[R any](reqs []R, concurrency int, search func(R) (*core.SearchResult, error)) []batchResult {
+	results := make([]batchResult, len(reqs))
+	slots := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i].Request = req
+			result, err := search(req)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			core.AnnotateFreshness(result, time.Now())
+			results[i].Result = result
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// batchExit returns the exit code a batch's searches share, as
+// searchExit gives it for one, or ExitPartial when they differ. A search
+// that could not run counts as ExitFailure.
+func batchExit(results []batchResult, err error) error {
+	if err != nil {
+		return err
+	}
+	codes := make([]int, len(results))
+	for i, r := range results {
+		codes[i] = ExitFailure
+		if r.Result != nil {
+			codes[i] = ExitCode(searchExit(r.Result, nil))
+		}
+	}
+	return sharedExit(codes)
+}
+
+// sharedExit returns the exit code in codes when they all agree, and
+// ExitPartial when they don't.
+func sharedExit(codes []int) error {
+	code := -1
+	for _, c := range codes {
+		switch {
+		case code < 0:
+			code = c
+		case c != code:
+			code = ExitPartial
+		}
+	}
+	if code <= ExitOK {
+		return nil
+	}
+	return &ExitError{Code: code}
+}
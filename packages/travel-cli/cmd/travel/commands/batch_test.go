@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestFlightBatch(t *testing.T) {
+	reqs, _, err := flightBatch([]byte(`[{"from":"YUL","to":"CDG","departDate":"2026-06-12"},{"from":"JFK","to":"LAX","departDate":"2026-07-01","maxResults":3}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 || reqs[0].MaxResults != 10 || reqs[1].MaxResults != 3 {
+		t.Errorf("requests = %+v", reqs)
+	}
+
+	for input, want := range map[string]string{
+		`{"from":"YUL"}`: "JSON array",
+		`[]`:             "no requests",
+		`[{"from":"YUL","to":"CDG","departDate":"2026-06-12"},{"from":"YUL"}]`: "request 1:",
+	} {
+		if _, _, err := flightBatch([]byte(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("flightBatch(%s) error = %v, want %q", input, err, want)
+		}
+	}
+}
+
+func TestSearchBatch(t *testing.T) {
+	var running, peak atomic.Int32
+	search := func(n int) (*core.SearchResult, error) {
+		if r := running.Add(1); r > peak.Load() {
+			peak.Store(r)
+		}
+		defer running.Add(-1)
+		if n == 2 {
+			return nil, errors.New("no fares")
+		}
+		return &core.SearchResult{Query: n, Providers: []string{"mock"}}, nil
+	}
+
+	results := searchBatch([]int{0, 1, 2, 3, 4}, 2, search)
+	for i, r := range results {
+		if r.Request != i {
+			t.Errorf("results[%d] is for request %v", i, r.Request)
+		}
+		if (r.Result == nil) != (i == 2) || (r.Error != "") != (i == 2) {
+			t.Errorf("results[%d] = %+v", i, r)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Errorf("%d searches ran at once, want at most 2", peak.Load())
+	}
+	if code := ExitCode(batchExit(results, nil)); code != ExitPartial {
+		t.Errorf("exit with a failed search = %d, want %d", code, ExitPartial)
+	}
+	if code := ExitCode(batchExit(results[:2], nil)); code != ExitOK {
+		t.Errorf("exit = %d, want %d", code, ExitOK)
+	}
+}
@@ -0,0 +1,406 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func ConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Set up, inspect, and check the config file",
+	}
+	cmd.AddCommand(configInitCmd())
+	cmd.AddCommand(configViewCmd())
+	cmd.AddCommand(configGetCmd())
+	cmd.AddCommand(configSetCmd())
+	cmd.AddCommand(configValidateCmd())
+	cmd.AddCommand(configMigrateCmd())
+	return cmd
+}
+
+func configViewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view",
+		Short: "Print the effective config: the file merged with environment variables and flags",
+		Long: `Prints every setting commands run with: the defaults, overridden by the
+config file, then TRAVEL_* variables (such as TRAVEL_MAX_CONCURRENCY for
+maxConcurrency), then --mode, --prefer, --verbose, and --quiet.
+Passwords, API keys, webhook secrets, and notification webhook URLs
+written into the file are masked; ones referencing an environment variable (${NAME}) are shown as written.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, err := effectiveConfig(cmd).Map()
+			if err != nil {
+				return err
+			}
+			maskSecrets(m)
+			return output.JSON(m)
+		},
+	}
+	return cmd
+}
+
+// maskSecrets replaces literal password, API key, and secret values in a
+// config map with asterisks, along with the URLs of notification webhooks,
+// which carry their own tokens.
+func maskSecrets(v interface{}) {
+	maskValues(v, false)
+}
+
+// maskValues masks v's secrets; webhook is set below the webhooks, slack,
+// and discord settings, where a url is a secret.
+func maskValues(v interface{}, webhook bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			secret := k == "password" || k == "secret" || k == "apiKey" || webhook && k == "url"
+			if s, ok := child.(string); ok && secret && s != "" && !strings.HasPrefix(s, "${") {
+				v[k] = "********"
+				continue
+			}
+			maskValues(child, webhook || k == "webhooks" || k == "slack" || k == "discord")
+		}
+	case []interface{}:
+		for _, child := range v {
+			maskValues(child, webhook)
+		}
+	}
+}
+
+func configGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print one effective setting, by dotted key",
+		Long: `Prints the setting at a dotted key, such as mode, providers.duffel.priority,
+or schedules.0.cron, after environment variables and flags are applied.
+A setting that isn't set prints null (false for switches). Secrets are
+masked as in config view.`,
+		Example: `  travel config get mode
+  travel config get providers.duffel --format markdown`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			v, err := effectiveConfig(cmd).Get(key)
+			if errors.Is(err, config.ErrUnknownSetting) {
+				return invalidInput(err)
+			}
+			if err != nil {
+				return err
+			}
+			// Nested under its whole key, so maskSecrets knows what the
+			// value belongs to, as for notify.slack.url.
+			parts := strings.Split(key, ".")
+			wrapped := map[string]interface{}{}
+			leaf := wrapped
+			for _, p := range parts[:len(parts)-1] {
+				next := map[string]interface{}{}
+				leaf[p] = next
+				leaf = next
+			}
+			name := parts[len(parts)-1]
+			leaf[name] = v
+			maskSecrets(wrapped)
+			return output.JSON(leaf[name])
+		},
+	}
+	return cmd
+}
+
+type setResult struct {
+	Path  string      `json:"path"`
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+func configSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Write one setting to the config file",
+		Long: `Writes a setting to the config file, keeping its comments and every other
+setting. The value is read as YAML: true, 80, and 30m are a switch, a
+number, and a duration, and lists and maps can be written inline, as in
+'[deepLink, score]'. A value the setting can't hold is refused before the
+file is touched.`,
+		Example: `  travel config set mode hybrid
+  travel config set providers.duffel.enabled false
+  travel config set output.drop '[deepLink, score]'`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			var problem config.Problem
+			if err := config.Set(key, value); errors.Is(err, config.ErrUnknownSetting) || errors.As(err, &problem) {
+				return invalidInput(err)
+			} else if err != nil {
+				return err
+			}
+			path, err := config.Path()
+			if err != nil {
+				return err
+			}
+			result := setResult{Path: path, Key: key, Value: value}
+			_ = yaml.Unmarshal([]byte(value), &result.Value)
+			return output.JSON(result)
+		},
+	}
+	return cmd
+}
+
+type validation struct {
+	Path     string           `json:"path"`
+	Valid    bool             `json:"valid"`
+	Problems []config.Problem `json:"problems"`
+}
+
+func configValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Check a config file for unknown settings and invalid values",
+		Long: `Checks the config file, or the given file, against the settings the CLI
+knows and lists each problem with its line: unknown keys (with the
+closest known one), values of the wrong type, and values outside a
+setting's choices. Other commands skip what they can't read and use the
+default, so this finds settings that silently aren't taking effect.
+
+Exits with 1 when there are problems. A missing config file is valid,
+since the defaults apply.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := config.Path()
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				path = args[0]
+			}
+			data, err := os.ReadFile(path)
+			if err != nil && !(os.IsNotExist(err) && len(args) == 0) {
+				return err
+			}
+			problems := config.Validate(data)
+			report := validation{Path: path, Valid: len(problems) == 0, Problems: problems}
+			if report.Problems == nil {
+				report.Problems = []config.Problem{}
+			}
+			if err := output.JSON(report); err != nil {
+				return err
+			}
+			if !report.Valid {
+				return &ExitError{Code: ExitFailure}
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+type migration struct {
+	Path   string `json:"path"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Backup string `json:"backup,omitempty"`
+}
+
+func configMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite a config file from an earlier release in the current layout",
+		Long: `Commands read a config file from an earlier release as if it were
+upgraded, but leave the file as it is. This rewrites it in the current
+layout, keeping comments, and keeps the original beside it as
+travel.yaml.v<old version>.bak. A current file is left alone.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, from, err := config.Migrate()
+			var newer *config.NewerVersionError
+			if errors.As(err, &newer) {
+				return invalidInput(err)
+			}
+			if err != nil {
+				return err
+			}
+			report := migration{Path: path, From: from, To: config.CurrentVersion}
+			if from < config.CurrentVersion {
+				report.Backup = fmt.Sprintf("%s.v%d.bak", path, from)
+			}
+			return output.JSON(report)
+		},
+	}
+	return cmd
+}
+
+type setupProvider struct {
+	Name    string   `json:"name"`
+	Kind    string   `json:"kind"`
+	Enabled bool     `json:"enabled"`
+	Missing []string `json:"missingKeys,omitempty"`
+}
+
+type setupReport struct {
+	Path      string          `json:"path"`
+	Mode      config.Mode     `json:"mode"`
+	Providers []setupProvider `json:"providers"`
+}
+
+func configInitCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Choose providers and a mode, and write them to the config file",
+		Long: `Checks which live providers have their keys in the environment or the
+keyring (see travel auth import), asks which to enable and which mode to
+use, and writes the answers to travel.yaml in the config directory
+(~/.config/beetlebot by default), or the TRAVEL_CONFIG file. Other
+settings already in the file are kept.
+
+Questions go to stderr and answers are read from stdin, one per line; an
+empty answer takes the default in brackets. --yes takes every default:
+providers with keys are enabled, and the mode is hybrid when any is,
+mock otherwise.`,
+		Example: `  travel config init
+  travel config init --yes --mode live`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr(), yes: yes}
+			report := setupReport{}
+			providers := map[string]config.ProviderConfig{
+				"mock_flights": {Enabled: config.Bool(true), Priority: 100},
+				"mock_stays":   {Enabled: config.Bool(true), Priority: 100},
+			}
+
+			anyLive := false
+			for _, spec := range config.LiveProviders {
+				missing := spec.MissingKeys()
+				if len(missing) == 0 {
+					p.say("%s (%s): keys found", spec.Name, spec.Kind)
+				} else {
+					p.say("%s (%s): missing %s (sign up at %s)", spec.Name, spec.Kind, strings.Join(missing, ", "), spec.SignUp)
+				}
+				enabled, err := p.confirm(fmt.Sprintf("Enable %s?", spec.Name), len(missing) == 0)
+				if err != nil {
+					return err
+				}
+				anyLive = anyLive || enabled && len(missing) == 0
+				providers[spec.Name] = config.ProviderConfig{Enabled: config.Bool(enabled), Priority: spec.Priority, EnvKeys: spec.EnvKeys}
+				report.Providers = append(report.Providers, setupProvider{Name: spec.Name, Kind: spec.Kind, Enabled: enabled, Missing: missing})
+			}
+
+			mode := config.ModeMock
+			if anyLive {
+				mode = config.ModeHybrid
+			}
+			if modeFlag, _ := cmd.Flags().GetString("mode"); modeFlag != "" {
+				m, err := parseMode(modeFlag)
+				if err != nil {
+					return invalidInput(err)
+				}
+				mode = m
+			} else {
+				for {
+					answer, err := p.ask("Mode: mock (sample data), live (real providers only), or hybrid (live, with mock standing in)?", string(mode))
+					if err != nil {
+						return err
+					}
+					if mode, err = parseMode(answer); err == nil {
+						break
+					}
+					p.say("%v", err)
+				}
+			}
+			report.Mode = mode
+
+			path, err := config.Path()
+			if err != nil {
+				return err
+			}
+			if err := config.SaveSetup(mode, providers); err != nil {
+				return err
+			}
+			report.Path = path
+			p.say("Wrote %s", path)
+			return output.JSON(report)
+		},
+	}
+
+	cmd.Flags().BoolVar(&yes, "yes", false, "Take every default without asking")
+
+	return cmd
+}
+
+func parseMode(s string) (config.Mode, error) {
+	switch m := config.Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case config.ModeMock, config.ModeLive, config.ModeHybrid:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown mode %q (use mock, live, or hybrid)", s)
+}
+
+// prompter asks setup questions on out and reads answers from in. Without
+// more input, or with yes, every question takes its default.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	yes bool
+	// done is set once in has no more answers.
+	done bool
+}
+
+func (p *prompter) say(format string, args ...interface{}) {
+	fmt.Fprintf(p.out, format+"\n", args...)
+}
+
+// answer prints question with hint and reads one line, returning "" for
+// the default.
+func (p *prompter) answer(question, hint string) (string, error) {
+	if p.yes {
+		return "", nil
+	}
+	fmt.Fprintf(p.out, "%s [%s] ", question, hint)
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF {
+		fmt.Fprintln(p.out)
+		p.done = true
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (p *prompter) ask(question, def string) (string, error) {
+	answer, err := p.answer(question, def)
+	if answer == "" {
+		return def, err
+	}
+	return answer, err
+}
+
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := p.answer(question, hint)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		p.say("Please answer y or n.")
+	}
+}
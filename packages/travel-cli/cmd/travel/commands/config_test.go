@@ -0,0 +1,29 @@
+package commands
+
+import "testing"
+
+func TestMaskSecretsMasksWebhookURLs(t *testing.T) {
+	m := map[string]interface{}{
+		"fx": map[string]interface{}{"url": "https://rates.test"},
+		"notify": map[string]interface{}{
+			"slack":    map[string]interface{}{"url": "https://hooks.slack.com/services/T0/B0/x"},
+			"discord":  map[string]interface{}{"url": "${DISCORD_WEBHOOK}"},
+			"webhooks": []interface{}{map[string]interface{}{"url": "https://x.test/hook?token=t", "secret": "s"}},
+		},
+	}
+	maskSecrets(m)
+	notify := m["notify"].(map[string]interface{})
+	if got := notify["slack"].(map[string]interface{})["url"]; got != "********" {
+		t.Errorf("slack url = %v, want masked", got)
+	}
+	if got := notify["discord"].(map[string]interface{})["url"]; got != "${DISCORD_WEBHOOK}" {
+		t.Errorf("discord url = %v, want the variable reference kept", got)
+	}
+	hook := notify["webhooks"].([]interface{})[0].(map[string]interface{})
+	if hook["url"] != "********" || hook["secret"] != "********" {
+		t.Errorf("webhook = %v, want url and secret masked", hook)
+	}
+	if got := m["fx"].(map[string]interface{})["url"]; got != "https://rates.test" {
+		t.Errorf("fx url = %v, want it shown", got)
+	}
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/credentials"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func CredentialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Keep provider keys encrypted in the config file, for machines without a keyring",
+		Long: `Encrypts provider keys into the config file with a passphrase, for
+machines where travel auth import can't use a keyring. The passphrase
+comes from TRAVEL_PASSPHRASE, or the first line of the file
+TRAVEL_PASSPHRASE_FILE names; commands that search or check providers
+need it to use the keys.
+Keys are encrypted with AES-256-GCM under a key derived from the
+passphrase with PBKDF2-HMAC-SHA512.`,
+	}
+	cmd.AddCommand(credentialsEncryptCmd())
+	cmd.AddCommand(credentialsDecryptCmd())
+	return cmd
+}
+
+type encryptResult struct {
+	Provider string   `json:"provider"`
+	Keys     []string `json:"keys"`
+}
+
+type encryptReport struct {
+	Path      string          `json:"path"`
+	Providers []encryptResult `json:"providers"`
+}
+
+func credentialsEncryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt <file>",
+		Short: "Encrypt provider keys from a YAML file (- for stdin) into the config file",
+		Long: `Reads keys in the format travel auth import takes,
+
+  providers:
+    duffel:
+      DUFFEL_API_TOKEN: duffel_live_...
+
+encrypts each with the passphrase, and writes them under the provider's
+secrets in the config file, replacing keys already there. Encrypted keys
+are used whenever the variable isn't set in the environment or a .env
+file.`,
+		Example: `  TRAVEL_PASSPHRASE=... travel credentials encrypt creds.yaml
+  travel auth export | travel credentials encrypt -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := credentials.Passphrase()
+			if errors.Is(err, credentials.ErrNoPassphrase) {
+				return invalidInput(err)
+			}
+			if err != nil {
+				return err
+			}
+			var data []byte
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+			bundle, err := credentials.ParseBundle(data)
+			if err != nil {
+				return invalidInput(err)
+			}
+
+			c := credentials.NewCipher(passphrase)
+			report := encryptReport{}
+			for _, provider := range bundle.ProviderNames() {
+				sealed := map[string]string{}
+				for key, value := range bundle.Providers[provider] {
+					if sealed[key], err = c.Seal(value); err != nil {
+						return err
+					}
+				}
+				if err := config.SaveSecrets(provider, sealed); err != nil {
+					return err
+				}
+				report.Providers = append(report.Providers, encryptResult{Provider: provider, Keys: slices.Sorted(maps.Keys(sealed))})
+			}
+			if report.Path, err = config.Path(); err != nil {
+				return err
+			}
+			return output.JSON(report)
+		},
+	}
+	return cmd
+}
+
+func credentialsDecryptCmd() *cobra.Command {
+	var redact, env bool
+
+	cmd := &cobra.Command{
+		Use:   "decrypt [provider...]",
+		Short: "Print the keys encrypted in the config file as YAML for auth import, or as KEY=value lines",
+		Long: `Decrypts the keys under each provider's secrets in the config file, or
+only the named providers', and prints them in the format travel auth
+import and travel credentials encrypt read.`,
+		Example: `  travel credentials decrypt --redact
+  travel credentials decrypt duffel --env
+  travel credentials decrypt | travel auth import -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Load()
+			for _, provider := range args {
+				if len(cfg.Providers[provider].Secrets) == 0 {
+					return invalidInputf("provider %s has no encrypted keys", provider)
+				}
+			}
+			if len(args) == 0 {
+				args = slices.Sorted(maps.Keys(cfg.Providers))
+			}
+
+			bundle := &credentials.Bundle{Providers: map[string]map[string]string{}}
+			var c *credentials.Cipher
+			for _, provider := range args {
+				for key, sealed := range cfg.Providers[provider].Secrets {
+					if c == nil {
+						passphrase, err := credentials.Passphrase()
+						if errors.Is(err, credentials.ErrNoPassphrase) {
+							return invalidInput(err)
+						}
+						if err != nil {
+							return err
+						}
+						c = credentials.NewCipher(passphrase)
+					}
+					value, err := c.Open(sealed)
+					if err != nil {
+						return fmt.Errorf("%s %s: %w", provider, key, err)
+					}
+					if bundle.Providers[provider] == nil {
+						bundle.Providers[provider] = map[string]string{}
+					}
+					bundle.Providers[provider][key] = value
+				}
+			}
+			return writeBundle(cmd.OutOrStdout(), bundle, redact, env)
+		},
+	}
+
+	cmd.Flags().BoolVar(&redact, "redact", false, "Mask values, keeping the last four characters")
+	cmd.Flags().BoolVar(&env, "env", false, "Print KEY=value lines instead of YAML")
+
+	return cmd
+}
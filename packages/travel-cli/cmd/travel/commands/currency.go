@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func CurrencyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "currency",
+		Short: "Convert amounts between currencies at the configured exchange rates",
+	}
+	cmd.AddCommand(currencyConvertCmd())
+	return cmd
+}
+
+// conversion is the output of travel currency convert.
+type conversion struct {
+	Amount    float64 `json:"amount"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Converted float64 `json:"converted"`
+	// Rate is units of To per unit of From, as of Date.
+	Rate float64 `json:"rate"`
+	Date string  `json:"date"`
+}
+
+func currencyConvertCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert <amount> <from> <to>",
+		Short: "Convert an amount from one currency into another",
+		Long: `Rates come from fx.source: the rates bundled with the CLI, or the
+European Central Bank's through Frankfurter, fetched once per cache.ttl.fx.
+Rates set under fx.rates override either.`,
+		Example: `  travel currency convert 250 EUR USD
+  travel currency convert 12000 JPY CAD`,
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: completeCurrencies,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := strconv.ParseFloat(args[0], 64)
+			if err != nil {
+				return invalidInputf("amount %q is not a number", args[0])
+			}
+			from, to := strings.ToUpper(args[1]), strings.ToUpper(args[2])
+			rates := currentRates(config.Load())
+			rate, err := rates.Convert(1, from, to)
+			if err != nil {
+				return invalidInput(err)
+			}
+			return output.JSON(conversion{
+				Amount:    amount,
+				From:      from,
+				To:        to,
+				Converted: math.Round(amount*rate*100) / 100,
+				Rate:      rate,
+				Date:      rates.Date,
+			})
+		},
+	}
+}
+
+// currentRates returns today's exchange rates as cfg sets them up.
+func currentRates(cfg *config.Config) fx.Rates {
+	return fx.Current(cfg.FX, cfg.Cache.TTLFor(config.CacheFX))
+}
+
+// completeCurrencies offers the currencies with rates for the <from> and
+// <to> arguments.
+func completeCurrencies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 || len(args) > 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	codes := append(slices.Collect(maps.Keys(fx.Fallback().Rates)), fx.USD)
+	slices.Sort(codes)
+	return codes, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/daemon"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/metrics"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/schedule"
+	"github.com/spf13/cobra"
+)
+
+func DaemonCmd() *cobra.Command {
+	var (
+		once        bool
+		metricsAddr string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run saved alerts and scheduled searches in the background",
+		Long: `Runs saved price alerts every daemon.interval (default 30m) and each
+configured schedule whenever its cron expression matches. Every search is
+recorded in the local history, newly triggered alerts are sent to the
+sinks under notify:, a digest of deals is sent on notify.digest, and events are printed as JSON lines on stdout. Stop it with Ctrl-C or SIGTERM.
+
+With --metrics-addr, search, provider, and cache metrics are served for
+Prometheus on GET /metrics at that address.`,
+		Example: `  travel daemon
+  travel daemon --interval 15m
+  travel daemon --metrics-addr :9464
+  travel daemon --once`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := effectiveConfig(cmd)
+			if cmd.Flags().Changed("interval") {
+				cfg.Daemon.Interval, _ = cmd.Flags().GetDuration("interval")
+			}
+
+			store, err := alerts.Open()
+			if err != nil {
+				return err
+			}
+			emit := func(e daemon.Event) { _ = output.JSONCompact(e) }
+			var jobs []*schedule.Job
+			for _, sc := range cfg.Schedules {
+				job, err := schedule.FromConfig(sc)
+				if err != nil {
+					emit(daemon.Event{Time: time.Now().UTC(), Type: daemon.EventError, Error: err.Error()})
+					continue
+				}
+				jobs = append(jobs, job)
+			}
+			var digest *schedule.Cron
+			if cfg.Notify.Digest != "" {
+				digest, err = schedule.ParseCron(cfg.Notify.Digest)
+				if err != nil {
+					emit(daemon.Event{Time: time.Now().UTC(), Type: daemon.EventError, Error: "notify.digest: " + err.Error()})
+				}
+			}
+			notifier, err := buildNotifier(cfg)
+			if err != nil {
+				emit(daemon.Event{Time: time.Now().UTC(), Type: daemon.EventError, Error: err.Error()})
+			}
+			var reg *metrics.Registry
+			if metricsAddr != "" {
+				reg = startMetrics()
+			}
+			orch := buildOrchestrator(cfg)
+			var searcher alerts.Searcher = recordingSearcher{orch}
+			if reg != nil {
+				reg.WithProviderState(orch.ProviderInfos)
+				if err := serveMetrics(metricsAddr, reg, emit); err != nil {
+					return err
+				}
+				searcher = reg.Searcher(searcher)
+			}
+			hist, _ := history.Open()
+			d := daemon.New(searcher, store, cfg.Daemon.Interval).
+				WithSchedules(jobs).
+				WithDigest(digest, hist).
+				WithNotifier(notifier).
+				OnEvent(emit)
+
+			ctx := cmd.Context()
+			if once {
+				d.RunOnce(ctx)
+				return nil
+			}
+			return d.Run(ctx)
+		},
+	}
+
+	cmd.Flags().Duration("interval", daemon.DefaultInterval, "How often to check alerts (overrides daemon.interval)")
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on GET /metrics at this address (e.g. :9464)")
+	cmd.Flags().BoolVar(&once, "once", false, "Check every alert and run every schedule once, then exit (for external schedulers)")
+
+	return cmd
+}
+
+// serveMetrics listens on addr and serves reg on GET /metrics in the
+// background. A listen failure is returned; later server errors are
+// emitted as daemon errors.
+func serveMetrics(addr string, reg *metrics.Registry, emit func(daemon.Event)) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", reg.Handler())
+	go func() {
+		if err := http.Serve(ln, mux); err != nil {
+			emit(daemon.Event{Time: time.Now().UTC(), Type: daemon.EventError, Error: "metrics: " + err.Error()})
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+	"github.com/beetlebot/travel-cli/internal/geocode"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/visas"
+	"github.com/spf13/cobra"
+)
+
+func DestinationsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "destinations",
+		Aliases: []string{"destination"},
+		Short:   "Practical destination metadata from the bundled guide",
+	}
+	cmd.AddCommand(destinationsInfoCmd())
+	cmd.AddCommand(destinationsListCmd())
+	cmd.AddCommand(destinationsWeatherCmd())
+	cmd.AddCommand(destinationsVisaCmd())
+	return cmd
+}
+
+func destinationsInfoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "info <city>",
+		Short:   "Show currency, plug types, tipping norms, daily budget, and neighborhoods",
+		Example: `  travel destinations info Lisbon`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			d, err := destinations.Lookup(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+			return output.JSON(d)
+		},
+	}
+}
+
+func destinationsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List cities covered by the bundled guide",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := destinations.Names()
+			if err != nil {
+				return err
+			}
+			return output.JSON(names)
+		},
+	}
+}
+
+func destinationsWeatherCmd() *cobra.Command {
+	var city, from, to string
+
+	cmd := &cobra.Command{
+		Use:   "weather",
+		Short: "Show the daily high, low, and precipitation in a city over trip dates",
+		Long: `Dates within Open-Meteo's 16-day forecast are forecast. Later or past
+dates show each day's average over the last three years instead, as
+source "history".`,
+		Example: `  travel destinations weather --city Lisbon --from 2026-06-12 --to 2026-06-20`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if city == "" || from == "" {
+				return invalidInputf("--city and --from are required")
+			}
+			if to == "" {
+				to = from
+			}
+			for _, date := range []string{from, to} {
+				if _, err := time.Parse(time.DateOnly, date); err != nil {
+					return invalidInputf("%q is not a YYYY-MM-DD date", date)
+				}
+			}
+			cfg := effectiveConfig(cmd)
+			w, err := buildOrchestrator(cfg).Weather(city, from, to)
+			if errors.Is(err, geocode.ErrNotFound) {
+				return reportError(ExitNotFound, "city not found", err)
+			}
+			if err != nil {
+				return reportError(ExitFailure, "weather failed", err)
+			}
+			return output.JSON(w)
+		},
+	}
+
+	cmd.Flags().StringVar(&city, "city", "", "City (required)")
+	cmd.Flags().StringVar(&from, "from", "", "First day YYYY-MM-DD (required)")
+	cmd.Flags().StringVar(&to, "to", "", "Last day YYYY-MM-DD (default --from)")
+
+	return cmd
+}
+
+func destinationsVisaCmd() *cobra.Command {
+	var nationality, to string
+
+	cmd := &cobra.Command{
+		Use:   "visa",
+		Short: "Show whether a passport needs a visa or travel authorization to visit a country",
+		Long: `Answers come from a list bundled with the CLI, covering the countries in
+the destinations guide for common passports, and checked as of its asOf
+date. Entry rules change: confirm with the destination's official sources
+before traveling.`,
+		Example: `  travel destinations visa --nationality CA --to JP
+  travel destinations visa --to Lisbon`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if nationality == "" {
+				nationality = config.Load().Traveler.Nationality
+			}
+			if nationality == "" || to == "" {
+				return invalidInputf("--to and --nationality, or traveler.nationality in the config, are required")
+			}
+			country := to
+			if len(to) != 2 {
+				if d, err := destinations.Lookup(to); err == nil {
+					country = d.CountryCode
+				} else if a, err := airports.Find(to); err == nil {
+					country = a.CountryCode
+				} else {
+					return reportError(ExitNotFound, "destination not found", err)
+				}
+			}
+			r, err := visas.Lookup(nationality, country)
+			if err != nil {
+				return reportError(ExitNotFound, "no visa information", err)
+			}
+			return output.JSON(r)
+		},
+	}
+
+	cmd.Flags().StringVar(&nationality, "nationality", "", "Passport country code, e.g. CA (default traveler.nationality)")
+	cmd.Flags().StringVar(&to, "to", "", "Country code, city, or airport to visit (required)")
+	_ = cmd.RegisterFlagCompletionFunc("to", cobra.FixedCompletions(visas.Countries(), cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
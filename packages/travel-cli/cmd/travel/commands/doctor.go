@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/beetlebot/travel-cli/internal/broker"
 	"github.com/beetlebot/travel-cli/internal/config"
 	"github.com/beetlebot/travel-cli/internal/core"
 	"github.com/beetlebot/travel-cli/internal/output"
@@ -15,37 +16,66 @@ func DoctorCmd() *cobra.Command {
 		Use:   "doctor",
 		Short: "Validate configuration, credentials, and provider health",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			modeFlag, _ := cmd.Flags().GetString("mode")
-			cfg := config.Load().WithMode(modeFlag)
-
-			router := buildRouter(cfg)
-			infos := router.ProviderInfos()
-
-			active := 0
-			var issues []string
-			for _, p := range infos {
-				if p.Status == "active" {
-					active++
-				} else if p.Status == "no_credentials" {
-					issues = append(issues, fmt.Sprintf("%s: missing credentials", p.Name))
-				}
-			}
-
-			healthy := active > 0
-			summary := fmt.Sprintf("%d/%d providers active (mode=%s)", active, len(infos), cfg.Mode)
-			if len(issues) > 0 {
-				summary += " | issues: " + strings.Join(issues, "; ")
-			}
-
-			report := core.DoctorReport{
-				Mode:      cfg.Mode,
-				Providers: infos,
-				Healthy:   healthy,
-				Summary:   summary,
-			}
-
-			return output.JSON(report)
+			return output.JSON(doctorReport(effectiveConfig(cmd)))
 		},
 	}
 	return cmd
 }
+
+// doctorReport checks cfg, the credentials loaded, and the health of the
+// providers cfg's router has, for travel doctor and GET /v1/doctor.
+func doctorReport(cfg *config.Config) core.DoctorReport {
+	router := buildRouter(cfg)
+	infos := router.ProviderInfos()
+
+	active := 0
+	var issues []string
+	for _, p := range infos {
+		if p.Status == "active" {
+			active++
+		} else if p.Status == "no_credentials" {
+			issues = append(issues, fmt.Sprintf("%s: missing credentials", p.Name))
+		} else if p.Status == "maintenance" {
+			issues = append(issues, fmt.Sprintf("%s: %s", p.Name, p.Reason))
+		}
+	}
+
+	if err := broker.DedupeStrategy(cfg).Validate(); err != nil {
+		issues = append(issues, strings.ReplaceAll(err.Error(), "\n", "; "))
+	}
+
+	if v := cfg.FileVersion(); v < config.CurrentVersion {
+		issues = append(issues, fmt.Sprintf("config file is version %d, read as version %d; run travel config migrate to rewrite it", v, config.CurrentVersion))
+	}
+	if cfg.Version > config.CurrentVersion {
+		issues = append(issues, fmt.Sprintf("config file is version %d, newer than this travel reads (up to %d); upgrade travel", cfg.Version, config.CurrentVersion))
+	}
+
+	for _, p := range cfg.EnvProblems() {
+		issues = append(issues, p.Error())
+	}
+
+	if loaded.envErr != nil {
+		issues = append(issues, fmt.Sprintf("env file: %v", loaded.envErr))
+	}
+	if len(loaded.envSkipped) > 0 {
+		issues = append(issues, fmt.Sprintf("env file: ignored %s, which are not provider keys", strings.Join(loaded.envSkipped, ", ")))
+	}
+	if loaded.sealedErr != nil {
+		issues = append(issues, fmt.Sprintf("encrypted keys: %v", loaded.sealedErr))
+	}
+
+	healthy := active > 0
+	summary := fmt.Sprintf("%d/%d providers active (mode=%s)", active, len(infos), cfg.Mode)
+	if len(issues) > 0 {
+		summary += " | issues: " + strings.Join(issues, "; ")
+	}
+
+	return core.DoctorReport{
+		Mode:      cfg.Mode,
+		Providers: infos,
+		Healthy:   healthy,
+		Summary:   summary,
+		EnvFile:   loaded.envFile,
+	}
+}
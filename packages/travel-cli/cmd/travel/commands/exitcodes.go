@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+	"github.com/beetlebot/travel-cli/internal/geocode"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/planner"
+	"github.com/beetlebot/travel-cli/internal/searches"
+	"github.com/beetlebot/travel-cli/internal/trips"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, so scripts and agents can branch on how a command failed.
+// A search that printed a result exits with ExitOK, ExitNoProviders,
+// ExitProvidersFailed, or ExitPartial depending on its provider errors.
+const (
+	ExitOK = 0
+	// ExitFailure is any failure not covered below, such as unreadable
+	// local state or a failing postSearch hook.
+	ExitFailure = 1
+	// ExitInvalidInput means missing or invalid flags or arguments.
+	ExitInvalidInput = 2
+	// ExitNoProviders means no provider is active in the current mode.
+	ExitNoProviders = 3
+	// ExitProvidersFailed means every provider searched failed.
+	ExitProvidersFailed = 4
+	// ExitPartial means results were printed but some providers failed,
+	// were skipped for maintenance, or missed the soft deadline.
+	ExitPartial = 5
+	// ExitNotFound means an offer, trip, alert, destination, place, saved
+	// search, or search history the command needs doesn't exist, or no
+	// routing fits a plan.
+	ExitNotFound = 6
+	// ExitInterrupted means the command was stopped by Ctrl-C or SIGTERM.
+	// A search interrupted this way still prints the offers that had
+	// arrived, as a partial result.
+	ExitInterrupted = 130
+)
+
+// ExitError carries a command's exit code. Err is nil when the failure
+// has already been written as the command's output.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// commandStarted is set once flags and arguments have been parsed, so
+// errors before that are reported as invalid input.
+var commandStarted bool
+
+// ExitCode returns the exit code for an error returned by a command.
+func ExitCode(err error) int {
+	var exit *ExitError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exit):
+		return exit.Code
+	case errors.Is(err, context.Canceled):
+		return ExitInterrupted
+	case errors.Is(err, planner.ErrFaresUnavailable):
+		return ExitProvidersFailed
+	case errors.Is(err, core.ErrUnknownPlace):
+		return ExitInvalidInput
+	case errors.Is(err, offers.ErrNotFound), errors.Is(err, trips.ErrNotFound), errors.Is(err, alerts.ErrNotFound),
+		errors.Is(err, destinations.ErrNotFound), errors.Is(err, history.ErrNoRuns), errors.Is(err, history.ErrNotFound),
+		errors.Is(err, searches.ErrNotFound), errors.Is(err, planner.ErrNoRouting), errors.Is(err, geocode.ErrNotFound):
+		return ExitNotFound
+	case !commandStarted:
+		return ExitInvalidInput
+	}
+	return ExitFailure
+}
+
+func invalidInput(err error) error {
+	return &ExitError{Code: ExitInvalidInput, Err: err}
+}
+
+func invalidInputf(format string, args ...interface{}) error {
+	return invalidInput(fmt.Errorf(format, args...))
+}
+
+// reportError writes a failure as the command's JSON error and returns
+// its exit code without printing it again.
+func reportError(code int, msg string, err error) error {
+	output.JSONError(msg, err.Error())
+	return &ExitError{Code: code}
+}
+
+// searchError reports a search that failed without a result. Its exit
+// code follows the error, as for any command, rather than marking the
+// flags invalid: a place that can't be found exits ExitNotFound, and a
+// geocoder or cache that couldn't be reached ExitFailure.
+func searchError(msg string, err error) error {
+	return reportError(ExitCode(err), msg, err)
+}
+
+// usage prints the command's help for a call missing required flags.
+func usage(cmd *cobra.Command) error {
+	_ = cmd.Help()
+	return &ExitError{Code: ExitInvalidInput}
+}
+
+// searchExit turns a printed result's provider errors into its exit code,
+// or ExitInterrupted once the command's context is canceled.
+func searchExit(result *core.SearchResult, err error) error {
+	if err != nil {
+		return err
+	}
+	code := ExitOK
+	switch {
+	case runContext.Err() != nil:
+		code = ExitInterrupted
+	case len(result.Errors) == 0:
+	case len(result.Providers) == 0 && hasProviderError(result, "none"):
+		code = ExitNoProviders
+	case len(result.Providers) == 0:
+		code = ExitProvidersFailed
+	default:
+		code = ExitPartial
+	}
+	if code == ExitOK {
+		return nil
+	}
+	return &ExitError{Code: code}
+}
+
+func hasProviderError(result *core.SearchResult, provider string) bool {
+	for _, e := range result.Errors {
+		if e.Provider == provider {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/offers"
+)
+
+func TestExitCode(t *testing.T) {
+	started := commandStarted
+	defer func() { commandStarted = started }()
+
+	commandStarted = false
+	if got := ExitCode(errors.New(`unknown flag: --frm`)); got != ExitInvalidInput {
+		t.Errorf("flag error exit = %d, want %d", got, ExitInvalidInput)
+	}
+
+	commandStarted = true
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("disk full"), ExitFailure},
+		{invalidInputf("--date is required"), ExitInvalidInput},
+		{fmt.Errorf("%w: fl_123", offers.ErrNotFound), ExitNotFound},
+		{fmt.Errorf("%w: h_123", history.ErrNotFound), ExitNotFound},
+		{&ExitError{Code: ExitPartial}, ExitPartial},
+		{fmt.Errorf("search: %w", context.Canceled), ExitInterrupted},
+	}
+	for _, c := range cases {
+		if got := ExitCode(c.err); got != c.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", c.err, got, c.want)
+		}
+	}
+}
+
+func TestSearchExit(t *testing.T) {
+	cases := []struct {
+		name   string
+		result core.SearchResult
+		want   int
+	}{
+		{"ok", core.SearchResult{Providers: []string{"mock"}}, ExitOK},
+		{"no providers", core.SearchResult{Errors: []core.ProviderError{{Provider: "none", Reason: "no active flight providers for current mode"}}}, ExitNoProviders},
+		{"all failed", core.SearchResult{Errors: []core.ProviderError{{Provider: "amadeus", Reason: "timeout"}}}, ExitProvidersFailed},
+		{"partial", core.SearchResult{Providers: []string{"mock"}, Errors: []core.ProviderError{{Provider: "amadeus", Reason: "timeout"}}}, ExitPartial},
+	}
+	for _, c := range cases {
+		if got := ExitCode(searchExit(&c.result, nil)); got != c.want {
+			t.Errorf("%s: exit = %d, want %d", c.name, got, c.want)
+		}
+	}
+
+	writeErr := errors.New("write failed")
+	if err := searchExit(&core.SearchResult{}, writeErr); err != writeErr {
+		t.Errorf("searchExit kept %v, want the write error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	defer func(ctx context.Context) { runContext = ctx }(runContext)
+	runContext = ctx
+	if got := ExitCode(searchExit(&core.SearchResult{Providers: []string{"mock"}, Partial: true}, nil)); got != ExitInterrupted {
+		t.Errorf("interrupted: exit = %d, want %d", got, ExitInterrupted)
+	}
+}
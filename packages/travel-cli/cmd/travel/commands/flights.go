@@ -1,10 +1,18 @@
 package commands
 
 import (
-	"github.com/beetlebot/travel-cli/internal/config"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airlines"
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/offers"
 	"github.com/beetlebot/travel-cli/internal/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func FlightsCmd() *cobra.Command {
@@ -13,49 +21,231 @@ func FlightsCmd() *cobra.Command {
 		Short: "Search and manage flight offers",
 	}
 	cmd.AddCommand(flightsSearchCmd())
+	cmd.AddCommand(flightsExportCmd())
+	cmd.AddCommand(flightsStatusCmd())
 	return cmd
 }
 
 func flightsSearchCmd() *cobra.Command {
-	var req core.FlightSearchRequest
+	var (
+		req         core.FlightSearchRequest
+		stream      bool
+		input       string
+		concurrency int
+		nearby      float64
+	)
 
 	cmd := &cobra.Command{
 		Use:   "search",
 		Short: "Search for flights",
 		Example: `  travel flights search --from YUL --to CDG --depart 2026-06-12 --return 2026-06-20
-  travel flights search --from JFK --to LAX --depart 2026-07-01 --mode live`,
+  travel flights search --from JFK --to LAX --depart 2026-07-01 --mode live
+  travel flights search --from JFK --to LAX --depart 2026-07-01 --mode live --stream
+  travel flights search --from Montreal --to London --depart 2026-06-12 --nearby 150
+  travel flights search --from YUL --to LHR --depart 2026-06-12 --alliance star
+  travel flights search --from YUL --to CDG --depart 2026-06-12 --wifi
+  travel flights search --input requests.json --concurrency 8`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if req.From == "" || req.To == "" || req.DepartDate == "" {
-				return cmd.Help()
+			if outputFormat == output.FormatGeoJSON {
+				return invalidInputf("--format geojson maps stays; flight results have no single location")
+			}
+			if input != "" {
+				if nearby > 0 {
+					return invalidInputf("--nearby cannot be combined with --input")
+				}
+				return searchFlightBatch(cmd, input, concurrency, stream)
 			}
-			if req.Adults == 0 {
-				req.Adults = 1
+			if err := req.Validate(); err != nil {
+				if !interactive(cmd) {
+					return usage(cmd)
+				}
+				if err := newWizard(cmd).flights(&req); err != nil {
+					return invalidInput(err)
+				}
 			}
-			if req.MaxResults == 0 {
-				req.MaxResults = 10
+			resolved, err := req.ResolveAirports()
+			if err != nil {
+				return invalidInput(err)
 			}
+			req.ApplyDefaults()
 
-			modeFlag, _ := cmd.Flags().GetString("mode")
-			cfg := config.Load().WithMode(modeFlag)
+			cfg := effectiveConfig(cmd)
 
-			router := buildRouter(cfg)
-			orch := core.NewOrchestrator(router)
-			result, err := orch.SearchFlights(req)
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			if stream {
+				if err := streamOffers(orch); err != nil {
+					return err
+				}
+			}
+			var result *core.SearchResult
+			if nearby > 0 {
+				result, err = orch.SearchFlightsBetween(req, nearbyAirports(req.From, nearby), nearbyAirports(req.To, nearby))
+			} else {
+				result, err = orch.SearchFlights(req)
+			}
 			if err != nil {
-				output.JSONError("search failed", err.Error())
-				return nil
+				return searchError("search failed", err)
+			}
+			result.Resolved = resolved
+			recordSearch(history.KindFlights, result)
+			if stream {
+				return emitStreamResult(cfg, result)
+			}
+			return emitResult(cfg, result)
+		},
+	}
+
+	flightSearchFlags(cmd.Flags(), &req)
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write each provider's offers as JSON lines as they arrive, then the result")
+	cmd.Flags().StringVar(&input, "input", "", "Run the searches in a JSON array of requests from this file (- for stdin), printing a result for each")
+	cmd.Flags().IntVar(&concurrency, "concurrency", defaultBatchConcurrency, "Searches of --input to run at once")
+	cmd.Flags().Float64Var(&nearby, "nearby", 0, "Also search from and to up to two other airports within this many km of each end")
+	addCacheFlags(cmd)
+	completeAirportFlags(cmd, "from", "to")
+	_ = cmd.RegisterFlagCompletionFunc("alliance", cobra.FixedCompletions(airlines.Alliances, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// searchFlightBatch runs the searches in the file input names through one
+// orchestrator, so they share its cache and rate limits, and prints their
+// outcomes in order.
+func searchFlightBatch(cmd *cobra.Command, input string, concurrency int, stream bool) error {
+	if stream {
+		return invalidInputf("--stream cannot be combined with --input")
+	}
+	bind := func(fs *pflag.FlagSet) { flightSearchFlags(fs, &core.FlightSearchRequest{}) }
+	if changed := changedFlags(cmd, bind); len(changed) > 0 {
+		return invalidInputf("--input cannot be combined with %s; set them in each request", strings.Join(changed, ", "))
+	}
+	data, err := readInput(cmd, input)
+	if err != nil {
+		return invalidInput(err)
+	}
+	reqs, resolved, err := flightBatch(data)
+	if err != nil {
+		return invalidInput(err)
+	}
+
+	cfg := effectiveConfig(cmd)
+	orch := buildOrchestrator(cfg)
+	useCache(cmd, cfg, orch)
+	results := searchBatch(reqs, concurrency, orch.SearchFlights)
+	for i, r := range results {
+		if r.Result != nil {
+			r.Result.Resolved = resolved[i]
+			recordSearch(history.KindFlights, r.Result)
+		}
+	}
+	return batchExit(results, output.JSON(results))
+}
+
+// flightSearchFlags binds the flags of a flight search to req. travel
+// tools manifest describes a search's parameters by these flags too.
+func flightSearchFlags(flags *pflag.FlagSet, req *core.FlightSearchRequest) {
+	flags.StringVar(&req.From, "from", "", "Origin airport code or city (required)")
+	flags.StringVar(&req.To, "to", "", "Destination airport code or city (required)")
+	flags.StringVar(&req.DepartDate, "depart", "", "Departure date YYYY-MM-DD (required)")
+	flags.StringVar(&req.ReturnDate, "return", "", "Return date YYYY-MM-DD (optional)")
+	flags.IntVar(&req.Adults, "adults", 1, "Number of adults")
+	flags.StringVar(&req.CabinClass, "cabin", "economy", "Cabin class: economy, business, first")
+	flags.IntVar(&req.MaxResults, "max", 10, "Maximum results to return")
+	flags.StringVar(&req.GroupBy, "group-by", "", "Group results with the best offer per bucket: airline, stops, source")
+	flags.StringVar(&req.Alliance, "alliance", "", "Only offers sold by members of an airline alliance: star, oneworld, skyteam")
+	flags.BoolVar(&req.Wifi, "wifi", false, "Only flights the provider says have wifi")
+	flags.Var(stopsFlag{&req.MaxStops}, "max-stops", "Only flights with at most this many stops (0 = nonstop)")
+	flags.IntVar(&req.MaxPriceUSD, "max-price", 0, "Max price in USD (0 = no limit)")
+}
+
+// stopsFlag is a stop count flag left nil until given, since 0 means
+// nonstop.
+type stopsFlag struct{ stops **int }
+
+func (f stopsFlag) String() string {
+	if f.stops == nil || *f.stops == nil {
+		return ""
+	}
+	return strconv.Itoa(**f.stops)
+}
+
+func (f stopsFlag) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return errors.New("want a number of stops, 0 or more")
+	}
+	*f.stops = &n
+	return nil
+}
+
+func (f stopsFlag) Type() string { return "stops" }
+
+func flightsExportCmd() *cobra.Command {
+	var offerID string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a flight offer as a calendar event (--format ics, the default)",
+		Example: `  travel flights export --offer-id f_1a2b3c4d5e6f > flight.ics
+  travel flights export --offer-id f_1a2b3c4d5e6f --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if offerID == "" {
+				return invalidInputf("--offer-id is required")
+			}
+			preferFormat(cmd, output.FormatICS)
+			store, err := offers.Open()
+			if err != nil {
+				return err
+			}
+			flight, err := store.Flight(offerID)
+			if err != nil {
+				return offerLookupError(err)
+			}
+			return output.JSON(flight)
+		},
+	}
+
+	cmd.Flags().StringVar(&offerID, "offer-id", "", "Flight offer ID from a recent search")
+
+	return cmd
+}
+
+func flightsStatusCmd() *cobra.Command {
+	var req core.FlightStatusRequest
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show a flight's status on the day: gates, delays, and aircraft",
+		Long: `Asks the providers with the flights.status capability, such as
+aviationstack, highest priority first. Times are in each airport's time
+zone. In mock mode the status is made up.`,
+		Example: `  travel flights status --flight AC871
+  travel flights status --flight AC871 --date 2026-06-12`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if req.Flight == "" {
+				return invalidInputf("--flight is required")
+			}
+			if req.Date == "" {
+				req.Date = time.Now().Format(time.DateOnly)
+			}
+			if _, err := time.Parse(time.DateOnly, req.Date); err != nil {
+				return invalidInputf("%q is not a YYYY-MM-DD date", req.Date)
+			}
+			status, err := buildOrchestrator(effectiveConfig(cmd)).FlightStatus(req)
+			switch {
+			case errors.Is(err, core.ErrNoStatusProviders):
+				return reportError(ExitNoProviders, "no flight status providers", err)
+			case errors.Is(err, core.ErrFlightNotFound):
+				return reportError(ExitNotFound, "flight not found", err)
+			case err != nil:
+				return reportError(ExitFailure, "flight status failed", err)
 			}
-			return output.JSON(result)
+			return output.JSON(status)
 		},
 	}
 
-	cmd.Flags().StringVar(&req.From, "from", "", "Origin airport code (required)")
-	cmd.Flags().StringVar(&req.To, "to", "", "Destination airport code (required)")
-	cmd.Flags().StringVar(&req.DepartDate, "depart", "", "Departure date YYYY-MM-DD (required)")
-	cmd.Flags().StringVar(&req.ReturnDate, "return", "", "Return date YYYY-MM-DD (optional)")
-	cmd.Flags().IntVar(&req.Adults, "adults", 1, "Number of adults")
-	cmd.Flags().StringVar(&req.CabinClass, "cabin", "economy", "Cabin class: economy, business, first")
-	cmd.Flags().IntVar(&req.MaxResults, "max", 10, "Maximum results to return")
+	cmd.Flags().StringVar(&req.Flight, "flight", "", "Flight number, e.g. AC871 (required)")
+	cmd.Flags().StringVar(&req.Date, "date", "", "Departure date YYYY-MM-DD (default today)")
 
 	return cmd
 }
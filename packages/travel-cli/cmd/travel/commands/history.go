@@ -0,0 +1,208 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func HistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Inspect past searches and how prices moved",
+		Long: `Every flights search, stays search, and stays lookup is recorded in a
+local history: its request, mode, providers, how many offers were found,
+and the cheapest and top offers. Searches answered from the result cache
+are not recorded again.`,
+	}
+	cmd.AddCommand(historyListCmd())
+	cmd.AddCommand(historyShowCmd())
+	cmd.AddCommand(historyRerunCmd())
+	cmd.AddCommand(historyDiffCmd())
+	return cmd
+}
+
+func historyListCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recent searches, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			entries, err := store.List(limit)
+			if err != nil {
+				return err
+			}
+			if entries == nil {
+				entries = []history.Entry{}
+			}
+			return output.JSON(entries)
+		},
+	}
+
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum entries to show (0 for all)")
+
+	return cmd
+}
+
+func historyShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show one recorded search",
+		Example: `  travel history show h_1a2b3c4d5e`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			entry, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			return output.JSON(entry)
+		},
+	}
+}
+
+func historyRerunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rerun <id>",
+		Short: "Run a recorded search again, in the mode it ran in unless --mode is given",
+		Example: `  travel history rerun h_1a2b3c4d5e
+  travel history rerun h_1a2b3c4d5e --mode live --no-cache`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			entry, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg := effectiveConfig(cmd)
+			if !cmd.Flags().Changed("mode") {
+				cfg.WithMode(entry.Mode)
+			}
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			result, err := rerun(orch, entry)
+			if err != nil {
+				return searchError("rerun failed", err)
+			}
+			recordSearch(entry.Kind, result)
+			return emitResult(cfg, result)
+		},
+	}
+
+	addCacheFlags(cmd)
+
+	return cmd
+}
+
+// rerun runs entry's recorded request again on orch.
+func rerun(orch *core.Orchestrator, entry *history.Entry) (*core.SearchResult, error) {
+	switch entry.Kind {
+	case history.KindFlights:
+		var req core.FlightSearchRequest
+		if err := json.Unmarshal(entry.Query, &req); err != nil {
+			return nil, fmt.Errorf("read recorded query: %w", err)
+		}
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		req.ApplyDefaults()
+		return orch.SearchFlights(req)
+	case history.KindStays:
+		var req core.StaySearchRequest
+		if err := json.Unmarshal(entry.Query, &req); err != nil {
+			return nil, fmt.Errorf("read recorded query: %w", err)
+		}
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		req.ApplyDefaults()
+		return orch.SearchStays(req)
+	case history.KindLookup:
+		var req core.StayLookupRequest
+		if err := json.Unmarshal(entry.Query, &req); err != nil {
+			return nil, fmt.Errorf("read recorded query: %w", err)
+		}
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		req.ApplyDefaults()
+		return orch.LookupStays(req)
+	}
+	return nil, fmt.Errorf("search %s has unknown kind %q", entry.ID, entry.Kind)
+}
+
+func historyDiffCmd() *cobra.Command {
+	var (
+		q        history.DiffQuery
+		limit    int
+		currency string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show how the cheapest offer for a route or city moved since previous runs",
+		Example: `  travel history diff --route YUL-CDG --date 2026-06-12
+  travel history diff --city Paris --date 2026-06-12
+  travel history diff --route YUL-CDG --date 2026-06-12 --currency EUR`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (q.Route == "") == (q.City == "") {
+				return invalidInputf("exactly one of --route or --city is required")
+			}
+			if q.Date == "" {
+				return invalidInputf("--date is required")
+			}
+			if q.Route != "" {
+				from, to, err := history.ParseRoute(q.Route)
+				if err != nil {
+					return invalidInput(err)
+				}
+				q.Route = from + "-" + to
+			}
+
+			store, err := history.Open()
+			if err != nil {
+				return err
+			}
+			entries, err := store.List(0)
+			if err != nil {
+				return err
+			}
+			cfg := config.Load()
+			diff, err := history.ComputeIn(entries, q, limit, currency, fx.Current(cfg.FX, cfg.Cache.TTLFor(config.CacheFX)))
+			if errors.Is(err, history.ErrNoRuns) {
+				return reportError(ExitNotFound, "diff failed", err)
+			}
+			if err != nil {
+				return reportError(ExitInvalidInput, "diff failed", err)
+			}
+			return output.JSON(diff)
+		},
+	}
+
+	cmd.Flags().StringVar(&q.Route, "route", "", "Flight route as FROM-TO (e.g. YUL-CDG)")
+	cmd.Flags().StringVar(&q.City, "city", "", "Stay city (e.g. Paris)")
+	cmd.Flags().StringVar(&q.Date, "date", "", "Departure or check-in date (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum runs to list, newest first (0 for all)")
+	cmd.Flags().StringVar(&currency, "currency", "", "Also show prices in this currency, each run at the exchange rate recorded with it (e.g. EUR)")
+
+	return cmd
+}
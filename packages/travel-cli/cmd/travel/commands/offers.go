@@ -1,9 +1,15 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/beetlebot/travel-cli/internal/config"
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/offers"
 	"github.com/beetlebot/travel-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -11,13 +17,58 @@ import (
 func OffersCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "offers",
-		Short: "Combine and reprice travel offers",
+		Short: "Combine, reprice, and load travel offers",
 	}
 	cmd.AddCommand(offersCombineCmd())
 	cmd.AddCommand(offersRepriceCmd())
+	cmd.AddCommand(offersLoadCmd())
 	return cmd
 }
 
+// loadReport counts the offers travel offers load read from each file.
+type loadReport struct {
+	File    string `json:"file"`
+	Flights int    `json:"flights"`
+	Stays   int    `json:"stays"`
+}
+
+func offersLoadCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "load <file>...",
+		Short: "Add the offers of saved search results to the offer store",
+		Long: `Load reads the JSON output of flights search, stays search, or stays
+lookup, such as a file written with --out, and stores its offers as if the
+search had run here, so combine, reprice, and export work on results
+fetched on another machine or in CI. Output with fields renamed or dropped
+by output.rename or output.drop may be missing what an offer needs.`,
+		Example: `  travel flights search --from YUL --to CDG --depart 2026-06-12 --out results.json
+  travel offers load results.json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := offers.Open()
+			if err != nil {
+				return err
+			}
+			reports := make([]loadReport, 0, len(args))
+			for _, path := range args {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				result, err := core.DecodeResult(data)
+				if err != nil {
+					return invalidInputf("%s is not a search result: %v", path, err)
+				}
+				if err := store.SaveResult(result); err != nil {
+					return err
+				}
+				reports = append(reports, loadReport{File: path, Flights: len(result.Flights), Stays: len(result.Stays)})
+			}
+			return output.JSON(reports)
+		},
+	}
+}
+
 func offersCombineCmd() *cobra.Command {
 	var flightID, stayID string
 
@@ -26,13 +77,27 @@ func offersCombineCmd() *cobra.Command {
 		Short: "Combine a flight and stay offer into a trip package",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if flightID == "" || stayID == "" {
-				return fmt.Errorf("both --flight-id and --stay-id are required")
+				return invalidInputf("both --flight-id and --stay-id are required")
+			}
+			store, err := offers.Open()
+			if err != nil {
+				return err
 			}
-			combined := core.CombinedOffer{
-				FlightOfferID: flightID,
-				StayOfferID:   stayID,
-				TotalPriceUSD: 0, // will be resolved from cache in future
+			flight, err := store.Flight(flightID)
+			if err != nil {
+				return offerLookupError(err)
 			}
+			stay, err := store.Stay(stayID)
+			if err != nil {
+				return offerLookupError(err)
+			}
+
+			recordSelection(history.ActionCombine, flightID)
+			recordSelection(history.ActionCombine, stayID)
+
+			cfg := config.Load()
+			combined := core.CombineOffers(*flight, *stay, time.Now())
+			combined.CheckVisa(cfg.Traveler.Nationality, *flight, *stay)
 			return output.JSON(combined)
 		},
 	}
@@ -51,13 +116,27 @@ func offersRepriceCmd() *cobra.Command {
 		Short: "Reprice a cached offer with fresh data",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if offerID == "" {
-				return fmt.Errorf("--offer-id is required")
+				return invalidInputf("--offer-id is required")
+			}
+
+			var warnings []core.OfferWarning
+			if store, err := offers.Open(); err == nil {
+				if f, err := store.Flight(offerID); err == nil {
+					warnings = core.AppendExpiryWarning(warnings, f.CheckExpiry(time.Now()))
+				} else if s, err := store.Stay(offerID); err == nil {
+					warnings = core.AppendExpiryWarning(warnings, s.CheckExpiry(time.Now()))
+				}
 			}
-			return output.JSON(map[string]interface{}{
+			recordSelection(history.ActionReprice, offerID)
+			resp := map[string]interface{}{
 				"offerId": offerID,
 				"status":  "reprice_not_implemented",
 				"message": "Reprice requires live provider connection. Coming in a future version.",
-			})
+			}
+			if len(warnings) > 0 {
+				resp["warnings"] = warnings
+			}
+			return output.JSON(resp)
 		},
 	}
 
@@ -65,3 +144,10 @@ func offersRepriceCmd() *cobra.Command {
 
 	return cmd
 }
+
+func offerLookupError(err error) error {
+	if errors.Is(err, offers.ErrNotFound) {
+		return fmt.Errorf("%w (offers are remembered from recent searches; run a search first)", err)
+	}
+	return err
+}
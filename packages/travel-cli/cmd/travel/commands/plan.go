@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/planner"
+	"github.com/spf13/cobra"
+)
+
+func PlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Plan multi-stop trips from fare searches",
+	}
+	cmd.AddCommand(planRTWCmd())
+	return cmd
+}
+
+func planRTWCmd() *cobra.Command {
+	var (
+		req     planner.RTWRequest
+		cities  string
+		days    string
+		oneWay  bool
+		adults  int
+		cabin   string
+		maxLegs int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rtw",
+		Short: "Find the cheapest order and dates for a round-the-world or multi-stop trip",
+		Long: `Starts in the first of --cities, visits the others in whichever order is
+cheapest, spending --days-per-city in each, and flies back to the start
+unless --one-way. Every leg and date a routing could use is searched once,
+so wide day ranges with many cities need many searches; --max-searches
+caps them.`,
+		Example: `  travel plan rtw --cities YUL,LIS,CPT,BKK,NRT --start 2026-09-01 --days-per-city 4-7
+  travel plan rtw --cities YUL,LIS,ATH --start 2026-09-01 --days-per-city 5 --fixed-order --one-way`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if req.MinDays, req.MaxDays, err = planner.ParseDays(days); err != nil {
+				return invalidInput(err)
+			}
+			req.Cities = strings.Split(cities, ",")
+			req.Return = !oneWay
+			req.MaxSearches = maxLegs
+			if err := req.Validate(); err != nil {
+				return invalidInput(err)
+			}
+
+			cfg := effectiveConfig(cmd)
+
+			fares := orchestratorFares{search: newObservingSearcher(cfg, buildOrchestrator(cfg)), adults: adults, cabin: cabin}
+			plan, err := planner.PlanRTW(fares, req)
+			if errors.Is(err, planner.ErrNoRouting) {
+				return reportError(ExitNotFound, "no feasible routing", err)
+			}
+			if errors.Is(err, planner.ErrFaresUnavailable) {
+				return reportError(ExitProvidersFailed, "fare searches failed", err)
+			}
+			if err != nil {
+				return err
+			}
+			return output.JSON(plan)
+		},
+	}
+
+	cmd.Flags().StringVar(&cities, "cities", "", "Airport codes, starting city first (e.g. YUL,LIS,CPT,BKK,NRT)")
+	cmd.Flags().StringVar(&req.Start, "start", "", "Departure date from the first city YYYY-MM-DD")
+	cmd.Flags().StringVar(&days, "days-per-city", "4-7", "Days to spend in each city: a range (4-7) or exact (5)")
+	cmd.Flags().BoolVar(&oneWay, "one-way", false, "End in the last city instead of flying back to the first")
+	cmd.Flags().BoolVar(&req.FixedOrder, "fixed-order", false, "Keep the cities in the given order and only optimize dates")
+	cmd.Flags().IntVar(&req.Alternatives, "alternatives", 3, "Runner-up city orders to include")
+	cmd.Flags().IntVar(&maxLegs, "max-searches", planner.DefaultMaxSearches, "Refuse plans needing more fare searches than this")
+	cmd.Flags().IntVar(&adults, "adults", 1, "Number of adults")
+	cmd.Flags().StringVar(&cabin, "cabin", "economy", "Cabin class: economy, business, first")
+	_ = cmd.MarkFlagRequired("cities")
+	_ = cmd.MarkFlagRequired("start")
+
+	return cmd
+}
+
+// orchestratorFares prices legs with a full flight search, taking the
+// cheapest offer across providers.
+type orchestratorFares struct {
+	search observingSearcher
+	adults int
+	cabin  string
+}
+
+func (f orchestratorFares) Cheapest(from, to, date string) (*core.FlightOffer, error) {
+	result, err := f.search.SearchFlights(core.FlightSearchRequest{From: from, To: to, DepartDate: date, Adults: f.adults, CabinClass: f.cabin})
+	if err != nil {
+		return nil, err
+	}
+	var cheapest *core.FlightOffer
+	for i, offer := range result.Flights {
+		if cheapest == nil || offer.PriceUSD < cheapest.PriceUSD {
+			cheapest = &result.Flights[i]
+		}
+	}
+	return cheapest, nil
+}
@@ -1,8 +1,16 @@
 package commands
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/maintenance"
 	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/quota"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +20,10 @@ func ProvidersCmd() *cobra.Command {
 		Short: "List and inspect available travel providers",
 	}
 	cmd.AddCommand(providersListCmd())
+	cmd.AddCommand(providersResumeCmd())
+	cmd.AddCommand(providersUsageCmd())
+	cmd.AddCommand(providersToggleCmd(true))
+	cmd.AddCommand(providersToggleCmd(false))
 	return cmd
 }
 
@@ -20,8 +32,7 @@ func providersListCmd() *cobra.Command {
 		Use:   "list",
 		Short: "List all registered providers and their status",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			modeFlag, _ := cmd.Flags().GetString("mode")
-			cfg := config.Load().WithMode(modeFlag)
+			cfg := effectiveConfig(cmd)
 
 			router := buildRouter(cfg)
 			infos := router.ProviderInfos()
@@ -30,3 +41,159 @@ func providersListCmd() *cobra.Command {
 	}
 	return cmd
 }
+
+func providersResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <name>",
+		Short: "Route to a provider again before its detected maintenance window ends",
+		Long: `Clears maintenance detected from provider responses. Windows set under
+providers.<name>.maintenance in the config file are removed by editing it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := maintenance.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.Clear(args[0]); err != nil {
+				return err
+			}
+			return output.JSON(map[string]string{"resumed": args[0]})
+		},
+	}
+}
+
+type usageReport struct {
+	Month        string          `json:"month"`
+	Providers    []providerUsage `json:"providers"`
+	TotalCostUSD float64         `json:"totalCostUSD"`
+}
+
+type providerUsage struct {
+	Provider     string  `json:"provider"`
+	Calls        int     `json:"calls"`
+	CostUSD      float64 `json:"costUSD"`
+	MonthlyLimit int     `json:"monthlyLimit,omitempty"`
+	Remaining    *int    `json:"remaining,omitempty"`
+	Warning      string  `json:"warning,omitempty"`
+}
+
+func providersUsageCmd() *cobra.Command {
+	var month string
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show calls made to each provider this month and their estimated cost",
+		Long: `Lists every provider searched in the month, and every provider with a
+providers.<name>.monthlyLimit, with its calls, the cost estimated from
+providers.<name>.costPerCall, and the calls left under its limit. A
+provider past 80% of its limit gets a warning, as do searches that call it.`,
+		Example: `  travel providers usage
+  travel providers usage --month 2026-05`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if month == "" {
+				month = time.Now().UTC().Format(quota.MonthLayout)
+			} else if _, err := time.Parse(quota.MonthLayout, month); err != nil {
+				return invalidInputf("invalid --month %q (use YYYY-MM)", month)
+			}
+			cfg := effectiveConfig(cmd)
+			ledger, err := quota.Open()
+			if err != nil {
+				return err
+			}
+			counts, err := ledger.Month(month)
+			if err != nil {
+				return err
+			}
+			for name, pc := range cfg.Providers {
+				if _, ok := counts[name]; !ok && pc.MonthlyLimit > 0 {
+					counts[name] = quota.Count{}
+				}
+			}
+
+			report := usageReport{Month: month, Providers: []providerUsage{}}
+			for name, c := range counts {
+				pu := providerUsage{Provider: name, Calls: c.Calls, CostUSD: c.CostUSD}
+				if limit := cfg.Providers[name].MonthlyLimit; limit > 0 {
+					remaining := max(limit-c.Calls, 0)
+					pu.MonthlyLimit, pu.Remaining = limit, &remaining
+					switch {
+					case c.Calls >= limit:
+						pu.Warning = fmt.Sprintf("monthly limit of %d calls reached", limit)
+					case float64(c.Calls) >= core.UsageWarnAt*float64(limit):
+						pu.Warning = fmt.Sprintf("%d of %d monthly calls used", c.Calls, limit)
+					}
+				}
+				report.Providers = append(report.Providers, pu)
+				report.TotalCostUSD += c.CostUSD
+			}
+			sort.Slice(report.Providers, func(i, j int) bool { return report.Providers[i].Provider < report.Providers[j].Provider })
+			return output.JSON(report)
+		},
+	}
+
+	cmd.Flags().StringVar(&month, "month", "", "Month to report, YYYY-MM (default this month, UTC)")
+
+	return cmd
+}
+
+type toggleResult struct {
+	Path     string `json:"path"`
+	Provider string `json:"provider"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// providersToggleCmd builds `providers enable` or `providers disable`.
+func providersToggleCmd(enable bool) *cobra.Command {
+	use, short := "disable", "Leave a provider out of routing, in the config file"
+	if enable {
+		use, short = "enable", "Route to a provider again, in the config file"
+	}
+	return &cobra.Command{
+		Use:   use + " <name>",
+		Short: short,
+		Long: `Sets providers.<name>.enabled in the config file, keeping its comments and
+every other setting. A provider the file doesn't list yet is added with
+its usual priority and key variables.`,
+		Example: fmt.Sprintf("  travel providers %s duffel", use),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfg, err := config.LoadFile()
+			if err != nil {
+				return err
+			}
+			pc, ok := cfg.Providers[name]
+			if !ok {
+				var known []string
+				for _, info := range buildRouter(effectiveConfig(cmd)).ProviderInfos() {
+					if info.Name == name {
+						ok = true
+					}
+					known = append(known, info.Name)
+				}
+				if !ok {
+					return invalidInputf("unknown provider %q (known: %s)", name, strings.Join(known, ", "))
+				}
+				pc.Priority = 50
+				for _, spec := range config.LiveProviders {
+					if spec.Name == name {
+						pc.Priority, pc.EnvKeys = spec.Priority, spec.EnvKeys
+					}
+				}
+			}
+			pc.Enabled = config.Bool(enable)
+			if cfg.Providers == nil {
+				cfg.Providers = map[string]config.ProviderConfig{}
+			}
+			cfg.Providers[name] = pc
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			path, err := config.Path()
+			if err != nil {
+				return err
+			}
+			return output.JSON(toggleResult{Path: path, Provider: name, Enabled: enable})
+		},
+	}
+}
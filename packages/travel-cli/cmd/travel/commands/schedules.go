@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/schedule"
+	"github.com/beetlebot/travel-cli/internal/searches"
+	"github.com/spf13/cobra"
+)
+
+func SchedulesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedules",
+		Short: "Manage cron-scheduled searches run by travel daemon",
+	}
+	cmd.AddCommand(schedulesListCmd())
+	cmd.AddCommand(schedulesAddCmd())
+	cmd.AddCommand(schedulesRemoveCmd())
+	return cmd
+}
+
+type scheduleView struct {
+	config.ScheduleConfig
+	NextRun time.Time `json:"nextRun,omitzero"`
+	Error   string    `json:"error,omitempty"`
+}
+
+func schedulesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured schedules and when each runs next",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := config.Load()
+			now := time.Now()
+			views := []scheduleView{}
+			for _, sc := range cfg.Schedules {
+				v := scheduleView{ScheduleConfig: sc}
+				if job, err := schedule.FromConfig(sc); err != nil {
+					v.Error = err.Error()
+				} else {
+					v.NextRun = job.Next(now)
+				}
+				views = append(views, v)
+			}
+			return output.JSON(views)
+		},
+	}
+}
+
+func schedulesAddCmd() *cobra.Command {
+	var sc config.ScheduleConfig
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace a scheduled search in the config file",
+		Example: `  travel schedules add yul-cdg --cron "0 */6 * * *" --from YUL --to CDG --depart 2026-06-12
+  travel schedules add paris --cron @daily --city Paris --checkin 2026-06-12 --checkout 2026-06-15
+  travel schedules add paris-summer --cron @daily --search paris-summer`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sc.Name = args[0]
+			switch {
+			case sc.Search != "":
+				if sc.From != "" || sc.To != "" || sc.City != "" {
+					return invalidInputf("--search cannot be combined with a flight or stay")
+				}
+			case sc.From != "" || sc.To != "":
+				sc.Kind = schedule.KindFlights
+			case sc.City != "":
+				sc.Kind = schedule.KindStays
+			default:
+				return invalidInputf("give a saved search (--search), a flight (--from, --to, --depart), or a stay (--city, --checkin, --checkout)")
+			}
+			job, err := schedule.FromConfig(sc)
+			if errors.Is(err, searches.ErrNotFound) {
+				return err
+			}
+			if err != nil {
+				return invalidInput(err)
+			}
+			if err := config.AddSchedule(sc); err != nil {
+				return err
+			}
+			return output.JSON(scheduleView{ScheduleConfig: sc, NextRun: job.Next(time.Now())})
+		},
+	}
+
+	cmd.Flags().StringVar(&sc.Cron, "cron", "", "Cron expression, e.g. \"0 */6 * * *\" or @daily (required)")
+	cmd.Flags().StringVar(&sc.Search, "search", "", "Run this saved search (see travel search save)")
+	cmd.Flags().StringVar(&sc.From, "from", "", "Origin airport code")
+	cmd.Flags().StringVar(&sc.To, "to", "", "Destination airport code")
+	cmd.Flags().StringVar(&sc.Depart, "depart", "", "Departure date YYYY-MM-DD")
+	cmd.Flags().StringVar(&sc.Return, "return", "", "Return date YYYY-MM-DD (optional)")
+	cmd.Flags().IntVar(&sc.Adults, "adults", 0, "Number of adults (default 1)")
+	cmd.Flags().StringVar(&sc.Cabin, "cabin", "", "Cabin class (default economy)")
+	cmd.Flags().StringVar(&sc.City, "city", "", "Stay city")
+	cmd.Flags().StringVar(&sc.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD")
+	cmd.Flags().StringVar(&sc.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD")
+	cmd.Flags().IntVar(&sc.Guests, "guests", 0, "Number of guests (default 2)")
+	cmd.Flags().StringVar(&sc.StayType, "type", "", "Stay type (default any)")
+	_ = cmd.MarkFlagRequired("cron")
+	completeAirportFlags(cmd, "from", "to")
+
+	return cmd
+}
+
+func schedulesRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a scheduled search from the config file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RemoveSchedule(args[0]); err != nil {
+				return err
+			}
+			return output.JSON(map[string]string{"removed": args[0]})
+		},
+	}
+}
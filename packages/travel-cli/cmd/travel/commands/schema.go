@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/schema"
+	"github.com/spf13/cobra"
+)
+
+func SchemaCmd() *cobra.Command {
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "schema [type]",
+		Short: "Print JSON Schema for the CLI's output types",
+		Long:  "Print JSON Schema (draft 2020-12) generated from the Go types behind the CLI's output, for validation and code generation. Without a type, every type is printed under $defs.",
+		Example: `  travel schema SearchResult
+  travel schema --list
+  travel schema | jq .data`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return output.JSON(schema.Names())
+			}
+			if len(args) == 0 {
+				return output.JSON(schema.GenerateAll())
+			}
+			s, err := schema.Generate(args[0])
+			if err != nil {
+				return invalidInput(err)
+			}
+			return output.JSON(s)
+		},
+	}
+
+	cmd.Flags().BoolVar(&list, "list", false, "List the type names")
+
+	return cmd
+}
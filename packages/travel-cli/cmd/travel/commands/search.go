@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"github.com/beetlebot/travel-cli/internal/airlines"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/searches"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func SearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Save searches by name and run them again",
+	}
+	cmd.AddCommand(searchSaveCmd())
+	cmd.AddCommand(searchRunCmd())
+	cmd.AddCommand(searchListCmd())
+	cmd.AddCommand(searchRemoveCmd())
+	return cmd
+}
+
+func searchSaveCmd() *cobra.Command {
+	var (
+		flight core.FlightSearchRequest
+		stay   core.StaySearchRequest
+	)
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save a flight or stay search under a name, replacing any search saved under it",
+		Example: `  travel search save paris-summer --from YUL --to CDG --depart 2026-06-12 --return 2026-06-20
+  travel search save paris-hotels --city Paris --checkin 2026-06-12 --checkout 2026-06-20 --type hotel`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := &searches.Search{Name: args[0]}
+			switch {
+			case flight.From != "" || flight.To != "":
+				if err := flight.Validate(); err != nil {
+					return invalidInput(err)
+				}
+				flight.ApplyDefaults()
+				s.Kind, s.Flight = searches.KindFlights, &flight
+			case stay.City != "":
+				// --max and --group-by are bound to the flight request.
+				stay.MaxResults, stay.GroupBy = flight.MaxResults, flight.GroupBy
+				if err := stay.Validate(); err != nil {
+					return invalidInput(err)
+				}
+				stay.ApplyDefaults()
+				s.Kind, s.Stay = searches.KindStays, &stay
+			default:
+				return invalidInputf("give a flight (--from, --to, --depart) or a stay (--city, --checkin, --checkout)")
+			}
+
+			store, err := searches.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.Save(s); err != nil {
+				return invalidInput(err)
+			}
+			return output.JSON(s)
+		},
+	}
+
+	// The flags of flights search and stays search, so a saved search
+	// keeps every filter they take.
+	flightSearchFlags(cmd.Flags(), &flight)
+	stays := pflag.NewFlagSet("stays", pflag.ContinueOnError)
+	staySearchFlags(stays, &stay)
+	cmd.Flags().AddFlagSet(stays)
+	cmd.Flags().Lookup("group-by").Usage = "Group results with the best offer per bucket: airline, stops, source for flights; type, neighborhood for stays"
+	completeAirportFlags(cmd, "from", "to")
+	_ = cmd.RegisterFlagCompletionFunc("alliance", cobra.FixedCompletions(airlines.Alliances, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("neighborhood", completeNeighborhoods)
+	_ = cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(core.StaySorts, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func searchRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <name>",
+		Short: "Run a saved search",
+		Example: `  travel search run paris-summer
+  travel search run paris-summer --mode live --no-cache`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := searches.Open()
+			if err != nil {
+				return err
+			}
+			s, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg := effectiveConfig(cmd)
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			var (
+				result *core.SearchResult
+				kind   string
+			)
+			switch {
+			case s.Flight != nil:
+				kind = history.KindFlights
+				result, err = orch.SearchFlights(*s.Flight)
+			case s.Stay != nil:
+				kind = history.KindStays
+				result, err = orch.SearchStays(*s.Stay)
+			default:
+				return invalidInputf("saved search %s has no request", s.Name)
+			}
+			if err != nil {
+				return searchError("search failed", err)
+			}
+			recordSearch(kind, result)
+			return emitResult(cfg, result)
+		},
+	}
+
+	addCacheFlags(cmd)
+
+	return cmd
+}
+
+func searchListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List saved searches by name",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := searches.Open()
+			if err != nil {
+				return err
+			}
+			all, err := store.List()
+			if err != nil {
+				return err
+			}
+			if all == nil {
+				all = []searches.Search{}
+			}
+			return output.JSON(all)
+		},
+	}
+}
+
+func searchRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a saved search",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := searches.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.Remove(args[0]); err != nil {
+				return err
+			}
+			return output.JSON(map[string]string{"removed": args[0]})
+		},
+	}
+}
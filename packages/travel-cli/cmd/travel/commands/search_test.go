@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/searches"
+)
+
+func TestSearchSave_KeepsStayFilters(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	cmd := searchSaveCmd()
+	cmd.SetArgs([]string{"lisbon", "--city", "Lisbon", "--checkin", "2026-06-12", "--checkout", "2026-06-20",
+		"--near", "Rossio", "--radius", "1km", "--sort", "distance", "--min-rating", "4", "--breakfast", "--max", "5"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := searches.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := store.Get("lisbon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := s.Stay
+	if st == nil || st.Near != "Rossio" || st.RadiusKm != 1 || st.Sort != "distance" || st.MinRating != 4 || !st.Breakfast || st.MaxResults != 5 {
+		t.Errorf("saved stay = %+v, want every filter given", st)
+	}
+}
+
+func TestFlightsSearch_RejectsGeoJSON(t *testing.T) {
+	outputFormat = output.FormatGeoJSON
+	t.Cleanup(func() { outputFormat = output.FormatJSON })
+
+	cmd := flightsSearchCmd()
+	cmd.SetArgs([]string{"--from", "JFK", "--to", "LAX", "--depart", "2026-07-01"})
+	cmd.SilenceUsage, cmd.SilenceErrors = true, true
+	if code := ExitCode(cmd.Execute()); code != ExitInvalidInput {
+		t.Errorf("exit = %d, want %d", code, ExitInvalidInput)
+	}
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/grpcserver"
+	"github.com/beetlebot/travel-cli/internal/jobs"
+	"github.com/beetlebot/travel-cli/internal/logging"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/internal/server"
+	"github.com/beetlebot/travel-cli/pkg/travelv1"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+func ServeCmd() *cobra.Command {
+	var addr, grpcAddr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run an HTTP server with a REST API for searches, offers, and providers",
+		Long: `Run an HTTP server exposing the CLI's searches and checks:
+
+  POST /v1/flights/search  FlightSearchRequest -> SearchResult
+  POST /v1/stays/search    StaySearchRequest -> SearchResult
+  GET  /v1/offers/{id}     a flight or stay offer a recent search returned
+  GET  /v1/providers       registered providers and their status
+  GET  /v1/doctor          configuration, credentials, and provider health
+  POST /jobs/search        {"kind":"flights|stays","request":{...}} -> 202 {"id":...}
+  GET  /jobs/{id}          job status and, once finished, the search result
+  GET  /metrics            search, provider, and cache metrics for Prometheus
+  GET  /openapi.json       OpenAPI 3.1 document for the endpoints above
+
+A search sent with "Accept: text/event-stream" is answered with
+server-sent events, as travel flights search --stream writes lines: an
+"offers" event with each provider's new or cheaper offers as they arrive,
+then a "result" event with the ranked result.
+
+With --grpc, the travel.v1 TravelService (proto/travel/v1/travel.proto)
+is served too, on localhost:9090 or the address given with --grpc=ADDR.
+
+Every endpoint shares one orchestrator, so rate limits, usage, and
+metrics are counted together. Job state is persisted, so clients can
+poll across restarts; finished jobs are kept for a week.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := effectiveConfig(cmd)
+
+			store, err := jobs.Open()
+			if err != nil {
+				return err
+			}
+			if err := store.FailInterrupted(); err != nil {
+				return fmt.Errorf("recover jobs: %w", err)
+			}
+			_ = store.Prune()
+
+			reg := startMetrics()
+			orch := buildOrchestrator(cfg)
+			reg.WithProviderState(orch.ProviderInfos)
+			observing := newObservingSearcher(cfg, orch)
+			searcher := reg.Searcher(observing)
+			stream := func(ctx context.Context, batches func(core.OfferBatch)) server.Searcher {
+				s := observing
+				s.orch = orch.ForContext(ctx).Streaming(batches)
+				return reg.Searcher(s)
+			}
+			searcherFor := func(ctx context.Context) server.Searcher {
+				s := observing
+				s.orch = orch.ForContext(ctx)
+				return reg.Searcher(s)
+			}
+			srv := server.New(searcher, store).
+				WithSearcherFor(searcherFor).
+				WithMetrics(reg.Handler()).
+				WithProviders(orch.ProviderInfos).
+				WithDoctor(func() core.DoctorReport { return doctorReport(cfg) }).
+				WithStreaming(stream)
+			svc := grpcserver.New(searcher).
+				WithSearcherFor(searcherFor).
+				WithSlots(srv.Slots()).
+				WithProviders(orch.ProviderInfos)
+			if offerStore, err := offers.Open(); err == nil {
+				srv.WithOffers(offerStore)
+				svc.WithOffers(offerStore)
+			}
+
+			if grpcAddr != "" {
+				stop, err := serveGRPC(grpcAddr, svc)
+				if err != nil {
+					return err
+				}
+				defer stop()
+				if !quiet {
+					fmt.Fprintf(os.Stderr, "travel serve gRPC listening on %s\n", grpcAddr)
+				}
+			}
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "travel serve listening on %s (mode=%s)\n", addr, cfg.Mode)
+			}
+			return listenAndServe(cmd.Context(), &http.Server{Addr: addr, Handler: srv.Handler()})
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "localhost:8080", "Listen address (:8080 for every interface)")
+	cmd.Flags().StringVar(&grpcAddr, "grpc", "", "Also serve the travel.v1 gRPC service, at --grpc=ADDR or "+defaultGRPCAddr)
+	cmd.Flags().Lookup("grpc").NoOptDefVal = defaultGRPCAddr
+
+	return cmd
+}
+
+// shutdownTimeout bounds how long serve waits for open requests once
+// interrupted.
+const shutdownTimeout = 5 * time.Second
+
+// listenAndServe runs srv until ctx is done, then shuts it down, letting
+// open requests finish for up to shutdownTimeout.
+func listenAndServe(ctx context.Context, srv *http.Server) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdown)
+}
+
+// defaultGRPCAddr is where --grpc without an address serves.
+const defaultGRPCAddr = "localhost:9090"
+
+// serveGRPC listens on addr and serves svc in the background. stop lets
+// open calls finish before returning, for as long as shutdownTimeout.
+func serveGRPC(addr string, svc *grpcserver.Service) (stop func(), err error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("grpc: %w", err)
+	}
+	g := grpc.NewServer()
+	travelv1.RegisterTravelServiceServer(g, svc)
+	go func() {
+		if err := g.Serve(ln); err != nil {
+			logging.Logger().Error("grpc server stopped", "error", err)
+		}
+	}()
+	return func() {
+		timer := time.AfterFunc(shutdownTimeout, g.Stop)
+		defer timer.Stop()
+		g.GracefulStop()
+	}, nil
+}
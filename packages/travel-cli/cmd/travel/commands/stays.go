@@ -1,10 +1,16 @@
 package commands
 
 import (
-	"github.com/beetlebot/travel-cli/internal/config"
+	"errors"
+	"strconv"
+	"strings"
+
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+	"github.com/beetlebot/travel-cli/internal/history"
 	"github.com/beetlebot/travel-cli/internal/output"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 func StaysCmd() *cobra.Command {
@@ -13,56 +19,191 @@ func StaysCmd() *cobra.Command {
 		Short: "Search and manage accommodation offers",
 	}
 	cmd.AddCommand(staysSearchCmd())
+	cmd.AddCommand(staysLookupCmd())
 	return cmd
 }
 
 func staysSearchCmd() *cobra.Command {
 	var req core.StaySearchRequest
+	var stream bool
 
 	cmd := &cobra.Command{
 		Use:   "search",
 		Short: "Search for hotels, Airbnb, camping, and other stays",
 		Example: `  travel stays search --city Paris --checkin 2026-06-12 --checkout 2026-06-20
-  travel stays search --city "Banff" --checkin 2026-08-01 --checkout 2026-08-05 --type camping`,
+  travel stays search --city "Banff" --checkin 2026-08-01 --checkout 2026-08-05 --type camping
+  travel stays search --city Paris --checkin 2026-06-12 --checkout 2026-06-20 --format geojson
+  travel stays search --city Rome --checkin 2026-07-03 --checkout 2026-07-10 --guests 2 --children-ages 4,9
+  travel stays search --city Paris --checkin 2026-06-12 --checkout 2026-06-20 --near "Eiffel Tower" --radius 2km
+  travel stays search --city Paris --checkin 2026-06-12 --checkout 2026-06-20 --near "10 Rue de Rivoli" --sort distance
+  travel stays search --city Paris --checkin 2026-06-12 --checkout 2026-06-20 --neighborhood "Le Marais"
+  travel stays search --city Lisbon --checkin 2026-06-12 --checkout 2026-06-20 --min-rating 4.0 --min-reviews 100
+  travel stays search --city Lisbon --checkin 2026-06-12 --checkout 2026-06-20 --breakfast`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if req.City == "" || req.CheckIn == "" || req.CheckOut == "" {
-				return cmd.Help()
+				if !interactive(cmd) {
+					return usage(cmd)
+				}
+				if err := newWizard(cmd).stays(&req); err != nil {
+					return invalidInput(err)
+				}
 			}
-			if req.Guests == 0 {
-				req.Guests = 2
+			if err := req.Validate(); err != nil {
+				return invalidInput(err)
 			}
-			if req.Rooms == 0 {
-				req.Rooms = 1
+			req.ApplyDefaults()
+			if stream && outputFormat == output.FormatGeoJSON {
+				return invalidInputf("--stream cannot be combined with geojson output")
 			}
-			if req.MaxResults == 0 {
-				req.MaxResults = 10
+
+			cfg := effectiveConfig(cmd)
+
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			if stream {
+				if err := streamOffers(orch); err != nil {
+					return err
+				}
+			}
+			result, err := orch.SearchStays(req)
+			if err != nil {
+				return searchError("search failed", err)
 			}
-			if req.StayType == "" {
-				req.StayType = "any"
+			recordSearch(history.KindStays, result)
+			if stream {
+				return emitStreamResult(cfg, result)
 			}
+			return emitResult(cfg, result)
+		},
+	}
 
-			modeFlag, _ := cmd.Flags().GetString("mode")
-			cfg := config.Load().WithMode(modeFlag)
+	staySearchFlags(cmd.Flags(), &req)
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write each provider's offers as JSON lines as they arrive, then the result")
+	addCacheFlags(cmd)
+	_ = cmd.RegisterFlagCompletionFunc("neighborhood", completeNeighborhoods)
+	_ = cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(core.StaySorts, cobra.ShellCompDirectiveNoFileComp))
 
-			router := buildRouter(cfg)
-			orch := core.NewOrchestrator(router)
-			result, err := orch.SearchStays(req)
+	return cmd
+}
+
+// completeNeighborhoods offers the neighborhoods the destinations guide
+// knows for --city.
+func completeNeighborhoods(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	city, _ := cmd.Flags().GetString("city")
+	d, err := destinations.Lookup(city)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, n := range d.Neighborhoods {
+		names = append(names, n.Name+"\t"+n.Vibe)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// staySearchFlags binds the flags of a stay search to req, like
+// flightSearchFlags.
+func staySearchFlags(flags *pflag.FlagSet, req *core.StaySearchRequest) {
+	flags.StringVar(&req.City, "city", "", "City name (required)")
+	flags.StringVar(&req.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD (required)")
+	flags.StringVar(&req.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD (required)")
+	flags.IntVar(&req.Guests, "guests", 2, "Number of adult guests")
+	flags.IntSliceVar(&req.ChildrenAges, "children-ages", nil, "Ages of children staying, comma-separated (e.g. 4,9); totals include their charges")
+	flags.IntVar(&req.Rooms, "rooms", 1, "Number of rooms")
+	flags.StringVar(&req.StayType, "type", "any", "Stay type: hotel, airbnb, camping, any")
+	flags.IntVar(&req.MaxResults, "max", 10, "Maximum results to return")
+	flags.IntVar(&req.MaxPriceUSD, "max-price", 0, "Max price per night in USD (0 = no limit)")
+	flags.StringVar(&req.GroupBy, "group-by", "", "Group results with the best offer per bucket: type, neighborhood")
+	flags.StringVar(&req.Near, "near", "", `Only stays near a landmark, address, or "latitude,longitude"`)
+	flags.Var(kmFlag{&req.RadiusKm}, "radius", "Distance from --near to keep stays within, e.g. 2km, 500m, or 1mi (default 2km)")
+	flags.StringVar(&req.Neighborhood, "neighborhood", "", `Only stays in a neighborhood of --city, e.g. "Le Marais"`)
+	flags.StringVar(&req.Sort, "sort", "", "Order stays: best (default), distance from --near")
+	flags.Float64Var(&req.MinRating, "min-rating", 0, "Only stays rated at least this out of 5, e.g. 4.0")
+	flags.IntVar(&req.MinReviews, "min-reviews", 0, "Only stays with at least this many reviews")
+	flags.BoolVar(&req.Breakfast, "breakfast", false, "Only stays whose rate includes breakfast: breakfast, half-board, full-board, or all-inclusive")
+	flags.BoolVar(&req.Weather, "weather", false, "Add the weather in --city over the stay: forecast, or past years' for later dates")
+}
+
+// kmFlag is a distance flag held in km, read in km unless it ends in m or
+// mi.
+type kmFlag struct{ km *float64 }
+
+func (f kmFlag) String() string {
+	if f.km == nil || *f.km == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(*f.km, 'f', -1, 64) + "km"
+}
+
+func (f kmFlag) Set(s string) error {
+	s = strings.ToLower(strings.TrimSpace(s))
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "km"):
+		s = strings.TrimSuffix(s, "km")
+	case strings.HasSuffix(s, "mi"):
+		s, scale = strings.TrimSuffix(s, "mi"), 1.609344
+	case strings.HasSuffix(s, "m"):
+		s, scale = strings.TrimSuffix(s, "m"), 0.001
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 {
+		return errors.New("want a distance above zero, such as 2km, 500m, or 1mi")
+	}
+	*f.km = v * scale
+	return nil
+}
+
+func (f kmFlag) Type() string { return "distance" }
+
+func staysLookupCmd() *cobra.Command {
+	var req core.StayLookupRequest
+
+	cmd := &cobra.Command{
+		Use:   "lookup",
+		Short: "Price a known property or hotel chain directly across providers",
+		Example: `  travel stays lookup --property-id EXP12345 --checkin 2026-06-12 --checkout 2026-06-20
+  travel stays lookup --chain hilton --city Chicago --checkin 2026-06-12 --checkout 2026-06-14`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if req.CheckIn == "" || req.CheckOut == "" {
+				return usage(cmd)
+			}
+			if req.PropertyID == "" && (req.Chain == "" || req.City == "") {
+				return invalidInputf("either --property-id or both --chain and --city are required")
+			}
+			if req.PropertyID != "" && req.Chain != "" {
+				return invalidInputf("--property-id and --chain are mutually exclusive")
+			}
+			req.ApplyDefaults()
+
+			cfg := effectiveConfig(cmd)
+
+			orch := buildOrchestrator(cfg)
+			useCache(cmd, cfg, orch)
+			result, err := orch.LookupStays(req)
 			if err != nil {
-				output.JSONError("search failed", err.Error())
-				return nil
+				return searchError("lookup failed", err)
 			}
-			return output.JSON(result)
+			recordSearch(history.KindLookup, result)
+			return emitResult(cfg, result)
 		},
 	}
 
-	cmd.Flags().StringVar(&req.City, "city", "", "City name (required)")
-	cmd.Flags().StringVar(&req.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD (required)")
-	cmd.Flags().StringVar(&req.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD (required)")
-	cmd.Flags().IntVar(&req.Guests, "guests", 2, "Number of guests")
-	cmd.Flags().IntVar(&req.Rooms, "rooms", 1, "Number of rooms")
-	cmd.Flags().StringVar(&req.StayType, "type", "any", "Stay type: hotel, airbnb, camping, any")
-	cmd.Flags().IntVar(&req.MaxResults, "max", 10, "Maximum results to return")
-	cmd.Flags().IntVar(&req.MaxPriceUSD, "max-price", 0, "Max price per night in USD (0 = no limit)")
+	stayLookupFlags(cmd.Flags(), &req)
+	addCacheFlags(cmd)
 
 	return cmd
 }
+
+// stayLookupFlags binds the flags of a stay lookup to req, like
+// flightSearchFlags.
+func stayLookupFlags(flags *pflag.FlagSet, req *core.StayLookupRequest) {
+	flags.StringVar(&req.PropertyID, "property-id", "", "Provider property ID (e.g. EXP12345)")
+	flags.StringVar(&req.Chain, "chain", "", "Hotel chain: hilton, marriott, hyatt, ihg, accor")
+	flags.StringVar(&req.City, "city", "", "City (required with --chain)")
+	flags.StringVar(&req.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD (required)")
+	flags.StringVar(&req.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD (required)")
+	flags.IntVar(&req.Guests, "guests", 2, "Number of guests")
+	flags.IntVar(&req.Rooms, "rooms", 1, "Number of rooms")
+	flags.IntVar(&req.MaxResults, "max", 10, "Maximum results to return")
+}
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/schema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func ToolsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Describe the CLI's capabilities for LLM tool use",
+	}
+	cmd.AddCommand(toolsManifestCmd())
+	return cmd
+}
+
+func toolsManifestCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Print tool definitions for every search capability",
+		Long:  "Print a tool (function) definition for every search capability, for LLM function calling. Parameters are JSON Schema generated from the request types behind the search commands, with each flag's help text and default.",
+		Example: `  travel tools manifest | jq .data
+  travel tools manifest --for anthropic`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tools := manifestTools()
+			switch format {
+			case "openai":
+				out := make([]openAITool, len(tools))
+				for i, t := range tools {
+					out[i] = openAITool{Type: "function", Function: t}
+				}
+				return output.JSON(out)
+			case "anthropic":
+				out := make([]anthropicTool, len(tools))
+				for i, t := range tools {
+					out[i] = anthropicTool{Name: t.Name, Description: t.Description, InputSchema: t.Parameters}
+				}
+				return output.JSON(out)
+			default:
+				return invalidInputf("unsupported --for %q (use openai or anthropic)", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "for", "openai", "Definition format: openai, anthropic")
+
+	return cmd
+}
+
+// toolFunction is a tool as the OpenAI API defines a function.
+type toolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  *schema.Schema `json:"parameters"`
+}
+
+type openAITool struct {
+	Type     string       `json:"type"`
+	Function toolFunction `json:"function"`
+}
+
+type anthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema *schema.Schema `json:"input_schema"`
+}
+
+// manifestTools describes each search capability by the command that
+// runs it: its short help and the flags bound to its request.
+func manifestTools() []toolFunction {
+	var flight core.FlightSearchRequest
+	var stay core.StaySearchRequest
+	var lookup core.StayLookupRequest
+	return []toolFunction{
+		requestTool("search_flights", flightsSearchCmd(), &flight, func(fs *pflag.FlagSet) { flightSearchFlags(fs, &flight) }),
+		requestTool("search_stays", staysSearchCmd(), &stay, func(fs *pflag.FlagSet) { staySearchFlags(fs, &stay) }),
+		requestTool("lookup_stays", staysLookupCmd(), &lookup, func(fs *pflag.FlagSet) { stayLookupFlags(fs, &lookup) }),
+	}
+}
+
+// requestTool describes cmd as a tool taking the request req points to.
+// bind binds the request's flags to req on a fresh flag set, so each
+// field's flag is found by the field it sets.
+func requestTool(name string, cmd *cobra.Command, req interface{}, bind func(*pflag.FlagSet)) toolFunction {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	bind(fs)
+	v := reflect.ValueOf(req).Elem()
+	byField := map[string]*pflag.Flag{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if field, ok := flagField(f, v); ok {
+			byField[field] = f
+		}
+	})
+	params := schema.Parameters(v.Type(), func(field reflect.StructField, s *schema.Schema) {
+		f, ok := byField[field.Name]
+		if !ok {
+			return
+		}
+		s.Description = f.Usage
+		s.Default = flagDefault(f)
+	})
+	return toolFunction{Name: name, Description: cmd.Short, Parameters: params}
+}
+
+// flagProbes are values set on a flag to see which field it changes. One
+// of them parses as, and differs from the default of, every flag type the
+// search commands declare.
+var flagProbes = []string{"1", "2", "false"}
+
+// flagField returns the name of the field of struct v that f sets, found
+// by setting it and restoring v after.
+func flagField(f *pflag.Flag, v reflect.Value) (string, bool) {
+	saved := reflect.New(v.Type()).Elem()
+	saved.Set(v)
+	defer v.Set(saved)
+	for _, probe := range flagProbes {
+		if f.Value.Set(probe) != nil {
+			continue
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() && !reflect.DeepEqual(v.Field(i).Interface(), saved.Field(i).Interface()) {
+				return field.Name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// flagDefault returns f's default as a value of the type it declares, or
+// nil when the flag has none.
+func flagDefault(f *pflag.Flag) interface{} {
+	switch f.DefValue {
+	case "", "[]":
+		return nil
+	}
+	var (
+		v   interface{}
+		err error
+	)
+	switch f.Value.Type() {
+	case "int":
+		v, err = strconv.Atoi(f.DefValue)
+	case "float64":
+		v, err = strconv.ParseFloat(f.DefValue, 64)
+	case "bool":
+		v, err = strconv.ParseBool(f.DefValue)
+	default:
+		v = f.DefValue
+	}
+	if err != nil {
+		return nil
+	}
+	return v
+}
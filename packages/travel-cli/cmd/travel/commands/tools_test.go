@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestManifestTools(t *testing.T) {
+	tools := manifestTools()
+	if len(tools) != 3 {
+		t.Fatalf("got %d tools, want 3", len(tools))
+	}
+	stays := tools[1]
+	if stays.Name != "search_stays" || stays.Description == "" {
+		t.Fatalf("tool = %s %q", stays.Name, stays.Description)
+	}
+	data, err := json.Marshal(stays.Parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(data)
+	for _, want := range []string{
+		`"guests":{"description":"Number of adult guests","type":"integer","default":2}`,
+		`"childrenAges":{"description":"Ages of children staying`,
+		`"required":["city","checkIn","checkOut"]`,
+		`"radiusKm":{"description":"Distance from --near`,
+		`"breakfast":{"description":"`,
+		`"type":"boolean","default":false}`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("parameters lack %s", want)
+		}
+	}
+	if strings.Contains(doc, `"$schema"`) || strings.Contains(doc, `"title"`) {
+		t.Errorf("parameters = %s; want no $schema or title", doc)
+	}
+}
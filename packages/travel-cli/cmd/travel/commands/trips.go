@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/footprint"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/trips"
+	"github.com/spf13/cobra"
+)
+
+func TripsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trips",
+		Short: "Save itineraries and report on them",
+	}
+	cmd.AddCommand(tripsCreateCmd())
+	cmd.AddCommand(tripsAddCmd())
+	cmd.AddCommand(tripsListCmd())
+	cmd.AddCommand(tripsShowCmd())
+	cmd.AddCommand(tripsFootprintCmd())
+	cmd.AddCommand(tripsExportCmd())
+	return cmd
+}
+
+func tripsCreateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <trip>",
+		Short: "Create an empty trip",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			t, err := store.Create(args[0])
+			if err != nil {
+				return err
+			}
+			return output.JSON(t)
+		},
+	}
+}
+
+func tripsAddCmd() *cobra.Command {
+	var (
+		seg            trips.Segment
+		kind           string
+		depart, arrive string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <trip>",
+		Short: "Add a flight, rail, car, or stay segment to a trip",
+		Example: `  travel trips add paris --kind flight --from YUL --to CDG --depart 2026-06-12T08:00 --arrive 2026-06-12T20:30 --distance-km 5530
+  travel trips add paris --kind rail --from Paris --to Lyon --depart 2026-06-15T09:00 --distance-km 460
+  travel trips add paris --kind stay --name "Grand Hotel Central" --checkin 2026-06-12 --checkout 2026-06-15`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := trips.ParseKind(kind)
+			if err != nil {
+				return invalidInput(err)
+			}
+			seg.Kind = k
+			if seg.DepartTime, err = parseTripTime(depart); err != nil {
+				return invalidInputf("invalid --depart: %w", err)
+			}
+			if seg.ArriveTime, err = parseTripTime(arrive); err != nil {
+				return invalidInputf("invalid --arrive: %w", err)
+			}
+			if k == trips.KindStay {
+				if seg.Name == "" || seg.CheckIn == "" || seg.CheckOut == "" {
+					return invalidInputf("stay segments need --name, --checkin and --checkout")
+				}
+			} else if seg.From == "" || seg.To == "" {
+				return invalidInputf("%s segments need --from and --to", k)
+			}
+
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			t, err := store.AddSegment(args[0], seg)
+			if err != nil {
+				return err
+			}
+			recordSelection(history.ActionTrip, seg.OfferID)
+			return output.JSON(t)
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "flight", "Segment kind: flight, rail, car, stay")
+	cmd.Flags().StringVar(&seg.From, "from", "", "Origin airport, station, or place")
+	cmd.Flags().StringVar(&seg.To, "to", "", "Destination airport, station, or place")
+	cmd.Flags().StringVar(&depart, "depart", "", "Departure time YYYY-MM-DDTHH:MM (or RFC 3339)")
+	cmd.Flags().StringVar(&arrive, "arrive", "", "Arrival time YYYY-MM-DDTHH:MM (or RFC 3339)")
+	cmd.Flags().Float64Var(&seg.DistanceKm, "distance-km", 0, "Segment distance in km (improves footprint accuracy)")
+	cmd.Flags().StringVar(&seg.Carrier, "carrier", "", "Airline or rail operator")
+	cmd.Flags().StringVar(&seg.Number, "number", "", "Flight or train number")
+	cmd.Flags().StringVar(&seg.CabinClass, "cabin", "", "Cabin class for flights: economy, premium_economy, business, first")
+	cmd.Flags().IntVar(&seg.Passengers, "passengers", 1, "Number of travellers on this segment")
+	cmd.Flags().StringVar(&seg.Name, "name", "", "Property name for stay segments")
+	cmd.Flags().StringVar(&seg.CheckIn, "checkin", "", "Check-in date YYYY-MM-DD for stay segments")
+	cmd.Flags().StringVar(&seg.CheckOut, "checkout", "", "Check-out date YYYY-MM-DD for stay segments")
+	cmd.Flags().StringVar(&seg.OfferID, "offer-id", "", "Offer ID this segment was booked from")
+	cmd.Flags().StringVar(&seg.Confirmation, "confirmation", "", "Booking confirmation code")
+
+	return cmd
+}
+
+func tripsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List saved trips",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			list, err := store.List()
+			if err != nil {
+				return err
+			}
+			if list == nil {
+				list = []trips.Trip{}
+			}
+			return output.JSON(list)
+		},
+	}
+}
+
+func tripsShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <trip>",
+		Short: "Show a saved trip",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			t, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			return output.JSON(t)
+		},
+	}
+}
+
+func tripsFootprintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "footprint <trip>",
+		Short: "Estimate CO2 emissions for a trip, with lower-carbon alternatives",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			t, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			return output.JSON(footprint.ForTrip(t))
+		},
+	}
+}
+
+func tripsExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <trip>",
+		Short: "Export a trip's segments as calendar events (--format ics, the default)",
+		Example: `  travel trips export paris > paris.ics
+  travel trips export paris --format ics --out paris.ics`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			preferFormat(cmd, output.FormatICS)
+			store, err := trips.Open()
+			if err != nil {
+				return err
+			}
+			t, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			return output.JSON(t)
+		},
+	}
+}
+
+// parseTripTime accepts the short local form used in examples as well as
+// full RFC 3339 timestamps. Empty input yields the zero time.
+func parseTripTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", s)
+}
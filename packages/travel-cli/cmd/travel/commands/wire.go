@@ -1,21 +1,598 @@
 package commands
 
 import (
-	"github.com/beetlebot/travel-cli/internal/adapters/live"
-	"github.com/beetlebot/travel-cli/internal/adapters/mock"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/broker"
+	"github.com/beetlebot/travel-cli/internal/cache"
 	"github.com/beetlebot/travel-cli/internal/config"
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/hooks"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+	"github.com/beetlebot/travel-cli/internal/logging"
+	"github.com/beetlebot/travel-cli/internal/maintenance"
+	"github.com/beetlebot/travel-cli/internal/metrics"
+	"github.com/beetlebot/travel-cli/internal/notify"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/beetlebot/travel-cli/internal/paths"
+	"github.com/spf13/cobra"
 )
 
+// effectiveConfig is the config commands run with: the file, then
+// TRAVEL_* variables, then --mode, --prefer, --verbose, and --quiet.
+func effectiveConfig(cmd *cobra.Command) *config.Config {
+	modeFlag, _ := cmd.Flags().GetString("mode")
+	prefer, _ := cmd.Flags().GetStringSlice("prefer")
+	cfg := config.Load().WithMode(modeFlag).WithPrefer(prefer)
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	switch level, err := logging.ParseLevel(cfg.Log.Level); {
+	case quiet:
+		cfg.Log.Level = "off"
+	case verbose && (err != nil || level > slog.LevelInfo):
+		cfg.Log.Level = "info"
+	}
+	return cfg
+}
+
+// buildRouter loads provider credentials, if no command has yet, and
+// returns the router over every provider cfg sets up.
 func buildRouter(cfg *config.Config) *core.Router {
-	router := core.NewRouter(cfg)
+	loadCredentials()
+	return broker.NewRouter(cfg)
+}
+
+func buildOrchestrator(cfg *config.Config) *core.Orchestrator {
+	loadCredentials()
+	orch := broker.NewOrchestrator(cfg).WithContext(runContext)
+	var progress func(core.ProgressEvent)
+	switch progressMode {
+	case progressJSON:
+		progress = func(e core.ProgressEvent) { _ = output.Progress(e) }
+	case progressText:
+		progress = func(e core.ProgressEvent) { _ = output.ProgressText(e) }
+	case progressAuto:
+		if quiet || !output.IsTerminal(output.ProgressWriter) {
+			break
+		}
+		flights, stays := buildRouter(cfg).LiveProviders()
+		progress = func(e core.ProgressEvent) {
+			live := flights
+			if strings.HasPrefix(e.Search, "stays") {
+				live = stays
+			}
+			if live > 1 {
+				_ = output.ProgressText(e)
+			}
+		}
+	}
+	if metricsRegistry != nil {
+		progress = metricsRegistry.Progress(progress)
+	}
+	if progress != nil {
+		orch.WithProgress(progress)
+	}
+	return orch
+}
+
+// runContext is the running command's context, canceled by main on the
+// first Ctrl-C or SIGTERM so searches return what has arrived.
+var runContext = context.Background()
+
+// metricsRegistry, once set by startMetrics, counts the provider calls of
+// every orchestrator buildOrchestrator returns.
+var metricsRegistry *metrics.Registry
+
+// startMetrics sets up metricsRegistry for a long-running command. Its
+// providers' circuit state is read from the command's orchestrator, once
+// built, with WithProviderState.
+func startMetrics() *metrics.Registry {
+	metricsRegistry = metrics.NewRegistry()
+	return metricsRegistry
+}
+
+// addCacheFlags adds the flags useCache reads to a search command.
+func addCacheFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-cache", false, "Query providers even if this search ran recently, and cache the fresh result")
+	cmd.Flags().Duration("cache-ttl", 0, "Reuse the result of the same search run within this long (default from cache.ttl: 10m for flights, 2h for stays)")
+}
+
+// useCache answers orch's searches from the local result cache, unless
+// cache.disabled is set, while they are fresh by cache.ttl or
+// --cache-ttl. --no-cache queries the providers but still refreshes the
+// cache.
+func useCache(cmd *cobra.Command, cfg *config.Config, orch *core.Orchestrator) {
+	if cfg.Cache.Disabled {
+		return
+	}
+	ttl := cfg.Cache.TTLFor
+	if cmd.Flags().Changed("cache-ttl") {
+		override, _ := cmd.Flags().GetDuration("cache-ttl")
+		ttl = func(string) time.Duration { return override }
+	}
+	if noCache, _ := cmd.Flags().GetBool("no-cache"); noCache {
+		ttl = func(string) time.Duration { return 0 }
+	}
+	store, err := cache.Open(ttl)
+	if err != nil {
+		logging.Logger().Debug("result cache unavailable", "error", err)
+		return
+	}
+	orch.WithCache(store)
+}
+
+// buildNotifier returns the sinks configured under notify:, or nil when
+// none are enabled. A sink that can't be set up is left out and reported
+// in the error, alongside the notifier for the rest. Secrets and webhook
+// URLs referenced as ${NAME} are found among saved credentials as well as
+// the environment.
+func buildNotifier(cfg *config.Config) (*notify.Notifier, error) {
+	var (
+		sinks []notify.Sink
+		err   error
+	)
+	loadCredentials()
+	if cfg.Notify.Desktop {
+		sinks = append(sinks, notify.Only(notify.NewDesktop(), notify.EventAlertTriggered))
+	}
+	for _, wh := range cfg.Notify.Webhooks {
+		if wh.URL == "" {
+			continue
+		}
+		if wh.Secret != "" && !strings.Contains(wh.Secret, "${") {
+			logging.Logger().Warn("webhook secret is written in the config file; save it with travel auth import and set secret to ${NAME}", "url", wh.URL)
+		}
+		sinks = append(sinks, notify.Only(notify.NewWebhook(wh.URL, os.ExpandEnv(wh.Secret)), wh.Events...))
+	}
+	if c := cfg.Notify.Slack; c != nil && c.URL != "" {
+		sinks = append(sinks, notify.Only(notify.NewSlack(os.ExpandEnv(c.URL)), c.Events...))
+	}
+	if c := cfg.Notify.Discord; c != nil && c.URL != "" {
+		sinks = append(sinks, notify.Only(notify.NewDiscord(os.ExpandEnv(c.URL)), c.Events...))
+	}
+	if c := cfg.Notify.Email; c != nil && c.Host != "" {
+		email, emailErr := notify.NewEmail(notify.SMTP{
+			Host:     c.Host,
+			Port:     c.Port,
+			Username: c.Username,
+			Password: os.ExpandEnv(c.Password),
+			From:     c.From,
+			To:       c.To,
+		}, os.ExpandEnv(c.Template))
+		if emailErr != nil {
+			err = fmt.Errorf("notify.email: %w", emailErr)
+		} else {
+			events := c.Events
+			if len(events) == 0 {
+				events = []string{notify.EventDigest}
+			}
+			sinks = append(sinks, notify.Only(email, events...))
+		}
+	}
+	if len(sinks) == 0 {
+		return nil, err
+	}
+	return notify.New(sinks...), err
+}
+
+// recordSearch appends the search to the local history, with the day's
+// exchange rates, and remembers its offers. Both are conveniences, so failures never fail the search itself.
+// A result from the cache was recorded when it was fetched.
+func recordSearch(kind string, result *core.SearchResult) {
+	rememberOffers(result)
+	if result.FromCache {
+		return
+	}
+	rememberMaintenance(result)
+	store, err := history.Open()
+	if err != nil {
+		logging.Logger().Debug("search not recorded", "error", err)
+		return
+	}
+	entry := history.FromResult(kind, result)
+	cfg := config.Load()
+	if rates := fx.Current(cfg.FX, cfg.Cache.TTLFor(config.CacheFX)); rates.Live() {
+		entry.FX = &rates
+	}
+	if err := store.Append(entry); err != nil {
+		logging.Logger().Debug("search not recorded", "error", err)
+	}
+}
+
+// recordSelection logs that the user acted on the offer with the given
+// ID, if it is still in the offer store, so personalized ranking can
+// learn from it. Offers no longer stored are skipped.
+func recordSelection(action, offerID string) {
+	if offerID == "" {
+		return
+	}
+	cached, err := offers.Open()
+	if err != nil {
+		return
+	}
+	var sel history.Selection
+	if f, err := cached.Flight(offerID); err == nil {
+		sel = history.FlightSelection(action, *f)
+	} else if s, err := cached.Stay(offerID); err == nil {
+		sel = history.StaySelection(action, *s)
+	} else {
+		return
+	}
+	store, err := history.Open()
+	if err == nil {
+		err = store.Select(sel)
+	}
+	if err != nil {
+		logging.Logger().Debug("selection not recorded", "error", err)
+	}
+}
+
+// observingSearcher records a compact price observation for each search
+// it runs, at most once per route or city and date per
+// history.observeEvery, so searches no command records in full (API
+// requests, trip planning) still build price history.
+type observingSearcher struct {
+	cfg   *config.Config
+	orch  *core.Orchestrator
+	store *history.Store
+	every time.Duration
+}
+
+func newObservingSearcher(cfg *config.Config, orch *core.Orchestrator) observingSearcher {
+	s := observingSearcher{cfg: cfg, orch: orch, every: cfg.History.ObserveEvery}
+	if s.every <= 0 {
+		s.every = history.DefaultObserveEvery
+	}
+	if !cfg.History.DisableObservations {
+		s.store, _ = history.Open()
+	}
+	return s
+}
+
+func (s observingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	result, err := s.orch.SearchFlights(req)
+	if err == nil {
+		s.observe(history.KindFlights, result)
+	}
+	return result, err
+}
+
+func (s observingSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	result, err := s.orch.SearchStays(req)
+	if err == nil {
+		s.observe(history.KindStays, result)
+	}
+	return result, err
+}
+
+func (s observingSearcher) observe(kind string, result *core.SearchResult) {
+	if s.store == nil {
+		return
+	}
+	entry := history.Observation(kind, result)
+	if rates := fx.Current(s.cfg.FX, s.cfg.Cache.TTLFor(config.CacheFX)); rates.Live() {
+		entry.FX = &rates
+	}
+	_, _ = s.store.Observe(entry, s.every)
+}
+
+// rememberOffers saves a result's offers so offers combine and reprice can
+// resolve their IDs later.
+func rememberOffers(result *core.SearchResult) {
+	store, err := offers.Open()
+	if err == nil {
+		_ = store.Prune()
+		err = store.SaveResult(result)
+	}
+	if err != nil {
+		logging.Logger().Debug("offers not saved", "error", err)
+	}
+}
+
+// rememberMaintenance persists maintenance detected during a search so
+// the next command skips the provider too.
+func rememberMaintenance(result *core.SearchResult) {
+	var detected []core.MaintenanceWindow
+	for _, e := range result.Errors {
+		if e.Maintenance != nil && e.Maintenance.Source == core.MaintenanceDetected {
+			detected = append(detected, *e.Maintenance)
+		}
+	}
+	if len(detected) == 0 {
+		return
+	}
+	if store, err := maintenance.Open(); err == nil {
+		_ = store.Record(detected...)
+	}
+}
+
+// emitResult prints a search result, passing it through the configured
+// postSearch hook first. The hook's document is printed as-is since it may
+// add fields SearchResult does not know about. The exit code reflects the
+// result's provider errors.
+func emitResult(cfg *config.Config, result *core.SearchResult) error {
+	core.AnnotateFreshness(result, time.Now())
+	if cfg.Hooks.PostSearch == "" {
+		return searchExit(result, output.JSON(result))
+	}
+	doc, err := hooks.Run(context.Background(), cfg.Hooks.PostSearch, cfg.Hooks.Timeout, result)
+	if err != nil {
+		return reportError(ExitFailure, "postSearch hook failed", err)
+	}
+	if outputFormat != output.FormatJSON {
+		// Render the tables when the hook kept the result's shape; its
+		// extra fields only show in JSON.
+		var hooked core.SearchResult
+		if json.Unmarshal(doc, &hooked) == nil {
+			return searchExit(result, output.JSON(&hooked))
+		}
+	}
+	return searchExit(result, output.RawJSON(doc))
+}
+
+// ConfigureOutput applies the config's output field aliases to everything
+// the CLI prints. It is meant to run via cobra.OnInitialize.
+func ConfigureOutput() {
+	cfg := config.Load()
+	output.SetFieldMap(output.NewFieldMap(cfg.Output.Rename, cfg.Output.Drop))
+}
+
+// streamLine is one line of --stream output: a provider's offers, the
+// final result, or an error.
+type streamLine struct {
+	Type string `json:"type"`
+	*core.OfferBatch
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// streamOffers makes orch write each provider's offers as an NDJSON line
+// as they arrive. The search's result follows via emitStreamResult.
+func streamOffers(orch *core.Orchestrator) error {
+	switch outputFormat {
+	case output.FormatJSON:
+	case output.FormatTemplate:
+		return invalidInputf("--stream writes JSON lines and cannot be combined with --template")
+	default:
+		return invalidInputf("--stream writes JSON lines and cannot be combined with --format %s", outputFormat)
+	}
+	orch.WithBatches(func(b core.OfferBatch) {
+		_ = output.JSONCompact(streamLine{Type: "offers", OfferBatch: &b})
+	})
+	return nil
+}
+
+// emitStreamResult ends a stream with the ranked result, after the
+// postSearch hook like emitResult.
+func emitStreamResult(cfg *config.Config, result *core.SearchResult) error {
+	core.AnnotateFreshness(result, time.Now())
+	line := streamLine{Type: "result", Result: result}
+	if cfg.Hooks.PostSearch != "" {
+		doc, err := hooks.Run(context.Background(), cfg.Hooks.PostSearch, cfg.Hooks.Timeout, result)
+		if err != nil {
+			if err := output.JSONCompact(streamLine{Type: "error", Error: "postSearch hook failed: " + err.Error()}); err != nil {
+				return err
+			}
+			return &ExitError{Code: ExitFailure}
+		}
+		line.Result = json.RawMessage(doc)
+	}
+	return searchExit(result, output.JSONCompact(line))
+}
 
-	router.RegisterFlight(mock.NewMockFlightsAdapter())
-	router.RegisterStay(mock.NewMockStaysAdapter())
+// Values of --progress. Auto shows text progress on a terminal when a
+// search queries several live providers, which can take seconds.
+const (
+	progressAuto = "auto"
+	progressText = "text"
+	progressJSON = "json"
+	progressOff  = "off"
+)
+
+var (
+	outputFormat = output.FormatJSON
+	progressMode = progressAuto
+)
+
+// ApplyOutputFlags names the command for the response envelope and
+// applies the root --format, --template, --out, --no-color, and --progress
+// flags, keeping cmd's context as runContext. It is meant to run from the
+// root command's PersistentPreRunE.
+func ApplyOutputFlags(cmd *cobra.Command) error {
+	commandStarted = true
+	if ctx := cmd.Context(); ctx != nil {
+		runContext = ctx
+	}
+	progressMode, _ = cmd.Flags().GetString("progress")
+	// --progress=true and =false, from when it was a switch.
+	switch progressMode {
+	case "true":
+		progressMode = progressJSON
+	case "false":
+		progressMode = progressOff
+	}
+	switch progressMode {
+	case progressAuto, progressText, progressJSON, progressOff:
+	default:
+		return invalidInputf("unknown --progress %q (use auto, text, json, or off)", progressMode)
+	}
+	output.SetCommand(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+
+	name, _ := cmd.Flags().GetString("format")
+	f, err := output.ParseFormat(name)
+	if err != nil {
+		return invalidInput(err)
+	}
+	outputFormat = f
+	output.SetFormat(f)
+
+	if text, _ := cmd.Flags().GetString("template"); text != "" {
+		if cmd.Flags().Changed("format") {
+			return invalidInputf("--template and --format are mutually exclusive")
+		}
+		t, err := output.ParseTemplate(text)
+		if err != nil {
+			return invalidInput(err)
+		}
+		outputFormat = output.FormatTemplate
+		output.SetTemplate(t)
+	}
+
+	if path, _ := cmd.Flags().GetString("out"); path != "" {
+		out = &outFile{path: path}
+		output.Writer = out
+	}
+
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	output.SetColor(!noColor && output.ColorSupported(output.Writer))
+	return nil
+}
+
+// out is the --out file, if one was given.
+var out *outFile
+
+// outFile creates the --out file on the first write, so a command that
+// fails validation leaves an existing file untouched.
+type outFile struct {
+	path string
+	file *os.File
+	err  error
+}
+
+func (o *outFile) Write(p []byte) (int, error) {
+	if o.file == nil && o.err == nil {
+		o.file, o.err = os.Create(o.path)
+	}
+	if o.err != nil {
+		return 0, fmt.Errorf("open --out: %w", o.err)
+	}
+	return o.file.Write(p)
+}
+
+// CloseOutput closes the --out file once the command has returned,
+// reporting a write the close turned out to lose. It does nothing when
+// nothing was written.
+func CloseOutput() error {
+	if out == nil || out.file == nil {
+		return nil
+	}
+	if err := out.file.Close(); err != nil {
+		return fmt.Errorf("close --out: %w", err)
+	}
+	return nil
+}
+
+// preferFormat makes f the output format when neither --format nor
+// --template was given, for commands whose output is meant for another
+// program, like calendar exports.
+func preferFormat(cmd *cobra.Command, f output.Format) {
+	if cmd.Flags().Changed("format") || cmd.Flags().Changed("template") {
+		return
+	}
+	outputFormat = f
+	output.SetFormat(f)
+}
+
+// quiet is set by --quiet, which leaves only the command's output.
+var quiet bool
+
+// ApplyLogFlags sets the level of the stderr log from the config's
+// log.level or TRAVEL_LOG, raised to info by --verbose or turned off by
+// --quiet. It is meant to run from the root command's PersistentPreRunE.
+func ApplyLogFlags(cmd *cobra.Command) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	quiet, _ = cmd.Flags().GetBool("quiet")
+	if verbose && quiet {
+		return invalidInputf("--verbose and --quiet are mutually exclusive")
+	}
+	level, err := logging.ParseLevel(config.Load().Log.Level)
+	if err != nil {
+		// Like other config mistakes, an unknown level falls back to
+		// the default rather than failing every command.
+		level = slog.LevelWarn
+	}
+	switch {
+	case quiet:
+		level = logging.LevelOff
+	case verbose && level > slog.LevelInfo:
+		level = slog.LevelInfo
+	}
+	logging.Setup(os.Stderr, level)
+	if err != nil {
+		logging.Logger().Warn("ignoring log level", "error", err)
+	}
+	return nil
+}
+
+// debugHTTPDefault is the --debug-http value given without a directory.
+const debugHTTPDefault = "default"
+
+// ApplyDebugFlags starts writing HTTP transcripts of live provider calls
+// for --debug-http, to the directory it names or, given alone, to
+// travel-debug-http in the cache directory. It is meant to run from the
+// root command's PersistentPreRunE.
+func ApplyDebugFlags(cmd *cobra.Command) error {
+	dir, _ := cmd.Flags().GetString("debug-http")
+	if dir == "" {
+		return nil
+	}
+	if dir == debugHTTPDefault {
+		base, err := paths.CacheDir()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(base, "travel-debug-http")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create HTTP transcript dir: %w", err)
+	}
+	httpclient.SetTranscriptDir(dir)
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "writing HTTP transcripts to %s\n", dir)
+	}
+	return nil
+}
+
+// AddDebugFlags adds the flag ApplyDebugFlags reads.
+func AddDebugFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("debug-http", "", "Write a redacted transcript of each live provider HTTP call to this directory (--debug-http alone: the cache directory)")
+	cmd.PersistentFlags().Lookup("debug-http").NoOptDefVal = debugHTTPDefault
+}
 
-	router.RegisterFlight(live.NewDuffelFlightsAdapter())
-	router.RegisterStay(live.NewExpediaStaysAdapter())
-	router.RegisterStay(live.NewAirbnbStaysAdapter())
+const defaultWarmupTimeout = 5 * time.Second
 
-	return router
+// StartWarmup prepares configured live providers in the background when
+// warmup is enabled, so the first live search skips connection setup.
+// It is meant to run as the root command's PersistentPreRun.
+func StartWarmup(cmd *cobra.Command, args []string) {
+	cfg := effectiveConfig(cmd)
+	if !cfg.Warmup.Enabled || cfg.Mode == config.ModeMock {
+		return
+	}
+	timeout := cfg.Warmup.Timeout
+	if timeout <= 0 {
+		timeout = defaultWarmupTimeout
+	}
+	loadCredentials()
+	router := broker.WarmupRouter(cfg)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		for _, r := range router.Warmup(ctx) {
+			if r.Error != "" {
+				logging.Logger().Debug("warmup failed", "provider", r.Provider, "error", r.Error)
+				continue
+			}
+			logging.Logger().Debug("provider warmed", "provider", r.Provider, "elapsed", time.Duration(r.DurationMS)*time.Millisecond)
+		}
+	}()
 }
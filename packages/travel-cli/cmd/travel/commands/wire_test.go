@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutFileCreatedOnFirstWrite(t *testing.T) {
+	saved := out
+	defer func() { out = saved }()
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := os.WriteFile(path, []byte("earlier"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out = &outFile{path: path}
+	if err := CloseOutput(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "earlier" {
+		t.Errorf("file = %q before any output, want it untouched", data)
+	}
+
+	if _, err := out.Write([]byte("{}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := CloseOutput(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{}\n" {
+		t.Errorf("file = %q, want the output", data)
+	}
+}
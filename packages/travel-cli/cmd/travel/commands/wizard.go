@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// dateLayout is how search requests and flags write dates.
+const dateLayout = "2006-01-02"
+
+// errNoAnswer is returned when input ends before a required question is
+// answered.
+var errNoAnswer = errors.New("input ended before every required field was answered")
+
+// interactive reports whether a search missing required flags should ask
+// for them rather than print its help: stdin and stdout are terminals and
+// --quiet isn't set.
+func interactive(cmd *cobra.Command) bool {
+	in, ok := cmd.InOrStdin().(*os.File)
+	return ok && !quiet && output.IsTerminal(in) && output.IsTerminal(cmd.OutOrStdout())
+}
+
+// wizard asks for the search fields a command's flags left out, with
+// questions on stderr and answers read from stdin.
+type wizard struct {
+	cmd   *cobra.Command
+	p     *prompter
+	today time.Time
+}
+
+func newWizard(cmd *cobra.Command) *wizard {
+	now := time.Now()
+	return &wizard{
+		cmd:   cmd,
+		p:     &prompter{in: bufio.NewReader(cmd.InOrStdin()), out: cmd.ErrOrStderr()},
+		today: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// flights asks for the fields of req its flags didn't set, offering
+// their values as defaults, and then prints the equivalent command.
+func (w *wizard) flights(req *core.FlightSearchRequest) error {
+	var err error
+	if req.From, err = w.airport("From (airport code or city)", req.From); err != nil {
+		return err
+	}
+	if req.To, err = w.airport("To (airport code or city)", req.To); err != nil {
+		return err
+	}
+	if req.DepartDate, err = w.date("Departure date", req.DepartDate, w.today, true); err != nil {
+		return err
+	}
+	depart, _ := time.Parse(dateLayout, req.DepartDate)
+	if !w.cmd.Flags().Changed("return") {
+		if req.ReturnDate, err = w.date("Return date", req.ReturnDate, depart, false); err != nil {
+			return err
+		}
+	}
+	if !w.cmd.Flags().Changed("cabin") {
+		if req.CabinClass, err = w.choice("Cabin", req.CabinClass, "economy", "business", "first"); err != nil {
+			return err
+		}
+	}
+	if !w.cmd.Flags().Changed("adults") {
+		if req.Adults, err = w.number("Adults", req.Adults); err != nil {
+			return err
+		}
+	}
+
+	line := fmt.Sprintf("travel flights search --from %s --to %s --depart %s", shellWord(req.From), shellWord(req.To), req.DepartDate)
+	if req.ReturnDate != "" {
+		line += " --return " + req.ReturnDate
+	}
+	if req.CabinClass != "economy" {
+		line += " --cabin " + req.CabinClass
+	}
+	if req.Adults != 1 {
+		line += " --adults " + strconv.Itoa(req.Adults)
+	}
+	w.p.say("Searching: %s", line)
+	return nil
+}
+
+// stays is the stay counterpart of flights.
+func (w *wizard) stays(req *core.StaySearchRequest) error {
+	var err error
+	if req.City, err = w.required("City", req.City); err != nil {
+		return err
+	}
+	if req.CheckIn, err = w.date("Check-in date", req.CheckIn, w.today, true); err != nil {
+		return err
+	}
+	checkIn, _ := time.Parse(dateLayout, req.CheckIn)
+	if req.CheckOut, err = w.date("Check-out date", req.CheckOut, checkIn.AddDate(0, 0, 1), true); err != nil {
+		return err
+	}
+	if !w.cmd.Flags().Changed("guests") {
+		if req.Guests, err = w.number("Guests", req.Guests); err != nil {
+			return err
+		}
+	}
+
+	line := fmt.Sprintf("travel stays search --city %q --checkin %s --checkout %s", req.City, req.CheckIn, req.CheckOut)
+	if req.Guests != 2 {
+		line += " --guests " + strconv.Itoa(req.Guests)
+	}
+	w.p.say("Searching: %s", line)
+	return nil
+}
+
+// required asks question until it gets an answer, keeping value if it is
+// already set.
+func (w *wizard) required(question, value string) (string, error) {
+	for value == "" {
+		answer, err := w.p.answer(question, "required")
+		if err != nil {
+			return "", err
+		}
+		if answer == "" && w.p.done {
+			return "", errNoAnswer
+		}
+		value = answer
+	}
+	return value, nil
+}
+
+// airport asks question until it gets an airport code or a city with
+// airports, keeping value if it is already set. Codes are uppercased;
+// cities are kept as written, for the search to resolve.
+func (w *wizard) airport(question, value string) (string, error) {
+	for {
+		answer, err := w.required(question, value)
+		if err != nil {
+			return "", err
+		}
+		if core.IsAirportCode(answer) {
+			return strings.ToUpper(answer), nil
+		}
+		if _, err := airports.Cities(answer); err == nil {
+			return answer, nil
+		}
+		w.p.say("%q is not an airport code or a city with airports.", answer)
+		if w.p.done {
+			return "", errNoAnswer
+		}
+		value = ""
+	}
+}
+
+// shellWord quotes s for the command line when it has spaces.
+func shellWord(s string) string {
+	if strings.ContainsAny(s, " '\"") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// date keeps value if it is set, and otherwise shows the month of
+// earliest and asks for a day no earlier than it. Relative answers count
+// from earliest. Without required, an empty answer leaves the date empty.
+func (w *wizard) date(question, value string, earliest time.Time, required bool) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	w.p.say("%s", calendar(earliest))
+	hint := "YYYY-MM-DD, MM-DD, today, tomorrow, or +days"
+	if !required {
+		hint += "; empty to skip"
+	}
+	for {
+		answer, err := w.p.answer(question, hint)
+		if err != nil {
+			return "", err
+		}
+		if answer == "" && !required {
+			return "", nil
+		}
+		if answer != "" {
+			d, err := parseDateAnswer(answer, w.today, earliest)
+			switch {
+			case err != nil:
+				w.p.say("%v", err)
+			case d.Before(earliest):
+				w.p.say("Pick %s or later.", earliest.Format(dateLayout))
+			default:
+				return d.Format(dateLayout), nil
+			}
+		}
+		if w.p.done {
+			return "", errNoAnswer
+		}
+	}
+}
+
+// choice asks for one of options, def by default.
+func (w *wizard) choice(question, def string, options ...string) (string, error) {
+	for {
+		answer, err := w.p.ask(question+" ("+strings.Join(options, ", ")+")", def)
+		if err != nil {
+			return "", err
+		}
+		if answer = strings.ToLower(answer); slices.Contains(options, answer) {
+			return answer, nil
+		}
+		w.p.say("Please answer %s.", strings.Join(options, ", "))
+		if w.p.done {
+			return "", errNoAnswer
+		}
+	}
+}
+
+// number asks for a whole number above zero, def by default.
+func (w *wizard) number(question string, def int) (int, error) {
+	for {
+		answer, err := w.p.ask(question, strconv.Itoa(def))
+		if err != nil {
+			return 0, err
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n > 0 {
+			return n, nil
+		}
+		w.p.say("Please answer a number above zero.")
+		if w.p.done {
+			return 0, errNoAnswer
+		}
+	}
+}
+
+// parseDateAnswer reads a date written as YYYY-MM-DD, MM-DD (its next
+// occurrence from base), today, tomorrow, or +N days after base.
+func parseDateAnswer(s string, today, base time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch {
+	case s == "today":
+		return today, nil
+	case s == "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case strings.HasPrefix(s, "+"):
+		days, err := strconv.Atoi(s[1:])
+		if err != nil || days < 0 {
+			return time.Time{}, fmt.Errorf("%q is not a number of days", s)
+		}
+		return base.AddDate(0, 0, days), nil
+	}
+	if d, err := time.Parse(dateLayout, s); err == nil {
+		return d, nil
+	}
+	d, err := time.Parse("01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date (use YYYY-MM-DD)", s)
+	}
+	d = d.AddDate(base.Year(), 0, 0)
+	if d.Before(base) {
+		d = d.AddDate(1, 0, 0)
+	}
+	return d, nil
+}
+
+// calendar renders the month of day as a grid of weeks starting on
+// Monday, with the days before day shown as dots.
+func calendar(day time.Time) string {
+	var b strings.Builder
+	title := day.Format("January 2006")
+	fmt.Fprintf(&b, "%*s\n", (20+len(title))/2, title)
+	b.WriteString("Mo Tu We Th Fr Sa Su")
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	col := (int(first.Weekday()) + 6) % 7
+	if col > 0 {
+		b.WriteString("\n" + strings.Repeat("   ", col-1) + "  ")
+	}
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		switch {
+		case col == 0:
+			b.WriteString("\n")
+		default:
+			b.WriteString(" ")
+		}
+		if d.Day() < day.Day() {
+			b.WriteString(" .")
+		} else {
+			fmt.Fprintf(&b, "%2d", d.Day())
+		}
+		col = (col + 1) % 7
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func testWizard(answers string, bind func(*cobra.Command)) *wizard {
+	cmd := &cobra.Command{}
+	bind(cmd)
+	return &wizard{
+		cmd:   cmd,
+		p:     &prompter{in: bufio.NewReader(strings.NewReader(answers)), out: io.Discard},
+		today: time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWizardFlights(t *testing.T) {
+	var req core.FlightSearchRequest
+	w := testWizard("yul\n\ncdg\n06-12\n2026-06-01\n+7\nbusiness\n\n", func(cmd *cobra.Command) {
+		flightSearchFlags(cmd.Flags(), &req)
+	})
+	if err := w.flights(&req); err != nil {
+		t.Fatal(err)
+	}
+	want := core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", ReturnDate: "2026-06-19", Adults: 1, CabinClass: "business", MaxResults: 10}
+	if req != want {
+		t.Errorf("request = %+v, want %+v", req, want)
+	}
+}
+
+func TestWizardKeepsFlags(t *testing.T) {
+	var req core.FlightSearchRequest
+	w := testWizard("CDG\ntomorrow\n", func(cmd *cobra.Command) {
+		flightSearchFlags(cmd.Flags(), &req)
+		_ = cmd.Flags().Parse([]string{"--from", "YUL", "--return", "2026-06-20", "--cabin", "first", "--adults", "2"})
+	})
+	if err := w.flights(&req); err != nil {
+		t.Fatal(err)
+	}
+	if req.From != "YUL" || req.DepartDate != "2026-06-11" || req.ReturnDate != "2026-06-20" || req.CabinClass != "first" || req.Adults != 2 {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestWizardAirportCities(t *testing.T) {
+	var req core.FlightSearchRequest
+	w := testWizard("Atlantis\nMexico City\nparis\n06-12\n\n\n\n", func(cmd *cobra.Command) {
+		flightSearchFlags(cmd.Flags(), &req)
+	})
+	var out strings.Builder
+	w.p.out = &out
+	if err := w.flights(&req); err != nil {
+		t.Fatal(err)
+	}
+	// Cities are kept for the search to resolve.
+	if req.From != "Mexico City" || req.To != "paris" {
+		t.Errorf("route = %q-%q", req.From, req.To)
+	}
+	for _, want := range []string{`"Atlantis" is not an airport code`, `--from "Mexico City" --to paris`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWizardStaysEndOfInput(t *testing.T) {
+	var req core.StaySearchRequest
+	w := testWizard("Paris\ntoday\n", func(cmd *cobra.Command) {
+		staySearchFlags(cmd.Flags(), &req)
+	})
+	if err := w.stays(&req); !errors.Is(err, errNoAnswer) {
+		t.Errorf("err = %v, want errNoAnswer", err)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	want := `     June 2026
+Mo Tu We Th Fr Sa Su
+ .  .  .  .  .  .  .
+ .  . 10 11 12 13 14
+15 16 17 18 19 20 21
+22 23 24 25 26 27 28
+29 30`
+	if got := calendar(time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)); got != want {
+		t.Errorf("calendar:\n%s\nwant:\n%s", got, want)
+	}
+	want = `    August 2026
+Mo Tu We Th Fr Sa Su
+                1  2
+ 3  4  5  6  7  8  9`
+	if got := calendar(time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)); !strings.HasPrefix(got, want) {
+		t.Errorf("calendar:\n%s\nwant prefix:\n%s", got, want)
+	}
+}
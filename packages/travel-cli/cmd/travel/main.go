@@ -1,33 +1,112 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/beetlebot/travel-cli/cmd/travel/commands"
+	"github.com/beetlebot/travel-cli/internal/adapters/plugin"
+	"github.com/beetlebot/travel-cli/internal/logging"
 	"github.com/spf13/cobra"
 )
 
 func main() {
+	cobra.OnInitialize(commands.ConfigureOutput)
+
 	root := &cobra.Command{
 		Use:   "travel",
 		Short: "Beetlebot travel broker – flights, stays, and trip planning",
 		Long:  "A local-first travel search CLI that aggregates flights, hotels, and alternative stays with compact JSON output for AI consumption.",
+		// Errors and usage are printed by main, with the exit code.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := commands.ApplyOutputFlags(cmd); err != nil {
+				return err
+			}
+			if err := commands.ApplyLogFlags(cmd); err != nil {
+				return err
+			}
+			if err := commands.ApplyDebugFlags(cmd); err != nil {
+				return err
+			}
+			commands.StartWarmup(cmd, args)
+			return nil
+		},
 	}
 
 	root.PersistentFlags().String("mode", "", "Provider mode: mock, live, hybrid (default from config/env)")
+	root.PersistentFlags().StringSlice("prefer", nil, "Providers to favor: queried first and winning ranking ties, ahead of config priority")
 	root.PersistentFlags().Bool("json", true, "Output as JSON (default true)")
+	root.PersistentFlags().String("format", "json", "Output format: json, markdown, csv, ics, geojson")
+	root.PersistentFlags().String("template", "", "Render output with a Go template, or @file for one in a file")
+	root.PersistentFlags().String("out", "", "Write output to this file instead of stdout")
+	root.PersistentFlags().Bool("no-color", false, "Disable colors in Markdown output (also set by NO_COLOR)")
+	root.PersistentFlags().String("progress", "auto", "Search progress on stderr: auto (text when several live providers are queried), text, json, off")
+	// A bare --progress keeps meaning JSON lines, as when it was a switch.
+	root.PersistentFlags().Lookup("progress").NoOptDefVal = "json"
+	root.PersistentFlags().Bool("verbose", false, "Log provider timings and HTTP requests to stderr")
+	root.PersistentFlags().Bool("quiet", false, "Log nothing to stderr, leaving only the command's output")
+	commands.AddDebugFlags(root)
+
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &commands.ExitError{Code: commands.ExitInvalidInput, Err: err}
+	})
 
 	root.AddCommand(commands.FlightsCmd())
 	root.AddCommand(commands.StaysCmd())
 	root.AddCommand(commands.OffersCmd())
+	root.AddCommand(commands.HistoryCmd())
+	root.AddCommand(commands.SearchCmd())
+	root.AddCommand(commands.AskCmd())
+	root.AddCommand(commands.AlertsCmd())
+	root.AddCommand(commands.DaemonCmd())
+	root.AddCommand(commands.SchedulesCmd())
+	root.AddCommand(commands.TripsCmd())
+	root.AddCommand(commands.PlanCmd())
+	root.AddCommand(commands.DestinationsCmd())
+	root.AddCommand(commands.AirportsCmd())
+	root.AddCommand(commands.AirlinesCmd())
+	root.AddCommand(commands.CurrencyCmd())
 	root.AddCommand(commands.ProvidersCmd())
+	root.AddCommand(commands.AuthCmd())
+	root.AddCommand(commands.CredentialsCmd())
+	root.AddCommand(commands.ConfigCmd())
 	root.AddCommand(commands.DoctorCmd())
+	root.AddCommand(commands.ServeCmd())
+	root.AddCommand(commands.SchemaCmd())
+	root.AddCommand(commands.ToolsCmd())
 	root.AddCommand(versionCmd())
 
-	if err := root.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	// The first Ctrl-C or SIGTERM cancels the command's context: searches
+	// print what has arrived and exit with ExitInterrupted. A second one
+	// kills the process as usual.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+		logging.Logger().Warn("interrupted; finishing up (Ctrl-C again to quit now)")
+	}()
+
+	cmd, err := root.ExecuteContextC(ctx)
+	// go-plugin plugins run until they're stopped.
+	plugin.Close()
+	if cerr := commands.CloseOutput(); cerr != nil && err == nil {
+		err = cerr
+	}
+	if err != nil {
+		code := commands.ExitCode(err)
+		if msg := err.Error(); msg != "" {
+			fmt.Fprintln(os.Stderr, "Error:", msg)
+			if code == commands.ExitInvalidInput {
+				fmt.Fprint(os.Stderr, cmd.UsageString())
+			}
+		}
+		os.Exit(code)
 	}
 }
 
@@ -1,20 +1,26 @@
 package live
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
 )
 
+const airbnbBaseURL = "https://www.airbnb.com"
+
 // AirbnbStaysAdapter provides Airbnb listing search.
 // Airbnb does not offer a public API; this adapter uses deep-link generation
 // and optionally an affiliate/partner integration when available.
 // Set AIRBNB_AFFILIATE_ID to enable (or leave unset for deep-link-only mode).
-type AirbnbStaysAdapter struct{}
+type AirbnbStaysAdapter struct {
+	providerClient
+	getenv func(string) string
+}
 
-func NewAirbnbStaysAdapter() *AirbnbStaysAdapter {
-	return &AirbnbStaysAdapter{}
+func NewAirbnbStaysAdapter(getenv func(string) string, meter httpclient.Meter) *AirbnbStaysAdapter {
+	return &AirbnbStaysAdapter{providerClient: newProviderClient(meter, "airbnb"), getenv: getenv}
 }
 
 func (a *AirbnbStaysAdapter) Name() string            { return "airbnb" }
@@ -24,7 +30,7 @@ func (a *AirbnbStaysAdapter) Capabilities() []core.Capability {
 }
 
 func (a *AirbnbStaysAdapter) Available() (bool, string) {
-	if os.Getenv("AIRBNB_AFFILIATE_ID") == "" {
+	if !hasEnv(a.getenv, "AIRBNB_AFFILIATE_ID") {
 		return false, "set AIRBNB_AFFILIATE_ID (Airbnb affiliate or partner program required)"
 	}
 	return true, ""
@@ -32,6 +38,12 @@ func (a *AirbnbStaysAdapter) Available() (bool, string) {
 
 func (a *AirbnbStaysAdapter) SearchStays(req core.StaySearchRequest) ([]core.StayOffer, error) {
 	// TODO: implement deep-link builder or affiliate API
-	// Deep link pattern: https://www.airbnb.com/s/{city}/homes?checkin={date}&checkout={date}&adults={n}
+	// Deep link pattern: https://www.airbnb.com/s/{city}/homes?checkin={date}&checkout={date}&adults={n}&children={n}&infants={n}
+	// Infants are ChildrenAges in core.ChildBandInfant; the rest are children.
 	return nil, fmt.Errorf("airbnb adapter not yet implemented – coming soon")
 }
+
+// Warmup opens a connection to the Airbnb API ahead of the first search.
+func (a *AirbnbStaysAdapter) Warmup(ctx context.Context) error {
+	return warmConnection(ctx, airbnbBaseURL)
+}
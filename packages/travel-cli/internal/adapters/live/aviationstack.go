@@ -0,0 +1,207 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// aviationstackBaseURL is aviationstack's API over HTTPS. The free plan
+// only serves plain HTTP, which sends the key unencrypted;
+// AVIATIONSTACK_URL can name http://api.aviationstack.com/v1 to accept
+// that.
+const aviationstackBaseURL = "https://api.aviationstack.com/v1"
+
+// aviationstackHTTPSRestricted is aviationstack's error code for an HTTPS
+// request on a plan that only allows HTTP.
+const aviationstackHTTPSRestricted = "https_access_restricted"
+
+var aviationstackWarnOnce sync.Once
+
+// aviationstackURL is AVIATIONSTACK_URL, or aviationstackBaseURL. A plain
+// HTTP one is warned about once per run.
+func aviationstackURL() string {
+	u := os.Getenv("AVIATIONSTACK_URL")
+	if u == "" {
+		return aviationstackBaseURL
+	}
+	if strings.HasPrefix(u, "http://") {
+		aviationstackWarnOnce.Do(func() {
+			logging.Logger().Warn("aviationstack is reached over plain HTTP, so AVIATIONSTACK_API_KEY is sent unencrypted", "url", u)
+		})
+	}
+	return strings.TrimRight(u, "/")
+}
+
+// AviationstackStatusAdapter tells flight status from aviationstack's
+// real-time flights API: https://aviationstack.com (free tier available).
+// Set AVIATIONSTACK_API_KEY to enable.
+type AviationstackStatusAdapter struct {
+	providerClient
+	getenv func(string) string
+}
+
+func NewAviationstackStatusAdapter(getenv func(string) string, meter httpclient.Meter) *AviationstackStatusAdapter {
+	return &AviationstackStatusAdapter{providerClient: newProviderClient(meter, "aviationstack"), getenv: getenv}
+}
+
+func (a *AviationstackStatusAdapter) Name() string            { return "aviationstack" }
+func (a *AviationstackStatusAdapter) Tier() core.ProviderTier { return core.TierEasySignup }
+func (a *AviationstackStatusAdapter) Capabilities() []core.Capability {
+	return []core.Capability{core.CapFlightsStatus}
+}
+
+func (a *AviationstackStatusAdapter) Available() (bool, string) {
+	if !hasEnv(a.getenv, "AVIATIONSTACK_API_KEY") {
+		return false, "set AVIATIONSTACK_API_KEY (sign up free at https://aviationstack.com)"
+	}
+	return true, ""
+}
+
+// aviationstackEnd is one end of a flight as aviationstack gives it. Its
+// times carry a +00:00 offset but are the airport's local clock.
+type aviationstackEnd struct {
+	IATA      string `json:"iata"`
+	Terminal  string `json:"terminal"`
+	Gate      string `json:"gate"`
+	Baggage   string `json:"baggage"`
+	Delay     *int   `json:"delay"`
+	Scheduled string `json:"scheduled"`
+	Estimated string `json:"estimated"`
+	Actual    string `json:"actual"`
+}
+
+func (e aviationstackEnd) end() core.FlightStatusEnd {
+	end := core.FlightStatusEnd{
+		Airport:  e.IATA,
+		Terminal: e.Terminal,
+		Gate:     e.Gate,
+		Baggage:  e.Baggage,
+	}
+	// Without the airport's zone the clock is kept as given, in UTC.
+	loc := core.AirportLocation(e.IATA)
+	if loc == nil {
+		loc = time.UTC
+	}
+	end.Scheduled = aviationstackTime(e.Scheduled, loc)
+	end.Estimated = aviationstackTime(e.Estimated, loc)
+	end.Actual = aviationstackTime(e.Actual, loc)
+	if e.Delay != nil {
+		end.DelayMinutes = *e.Delay
+	}
+	return end
+}
+
+// aviationstackTime reads a time's clock in loc, ignoring the offset
+// aviationstack writes after it.
+func aviationstackTime(s string, loc *time.Location) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+func (a *AviationstackStatusAdapter) FlightStatus(req core.FlightStatusRequest) (*core.FlightStatus, error) {
+	q := url.Values{"access_key": {a.getenv("AVIATIONSTACK_API_KEY")}, "flight_iata": {req.Flight}}
+	// The free plan serves the latest flights only; flight_date needs a
+	// paid one, so the answer is filtered here instead.
+	httpReq, err := http.NewRequest(http.MethodGet, aviationstackURL()+"/flights?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		// The error names the URL, key included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("aviationstack: %w", err)
+	}
+	defer resp.Body.Close()
+	// aviationstack explains refusals, such as a bad key or a spent
+	// quota, in error.
+	var body struct {
+		Error *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Data []struct {
+			Date      string           `json:"flight_date"`
+			Status    string           `json:"flight_status"`
+			Departure aviationstackEnd `json:"departure"`
+			Arrival   aviationstackEnd `json:"arrival"`
+			Airline   struct {
+				Name string `json:"name"`
+			} `json:"airline"`
+			Flight struct {
+				IATA string `json:"iata"`
+			} `json:"flight"`
+			Aircraft *struct {
+				Registration string `json:"registration"`
+				IATA         string `json:"iata"`
+			} `json:"aircraft"`
+		} `json:"data"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&body)
+	if body.Error != nil && body.Error.Code == aviationstackHTTPSRestricted {
+		return nil, errors.New("aviationstack: the plan doesn't allow HTTPS; set AVIATIONSTACK_URL=http://api.aviationstack.com/v1 to send the key unencrypted, or upgrade the plan")
+	}
+	if body.Error != nil && !core.IsMaintenanceStatus(resp.StatusCode) {
+		return nil, fmt.Errorf("aviationstack: %s", body.Error.Message)
+	}
+	if err := checkStatus(a.Name(), resp); err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("decode aviationstack response: %w", decodeErr)
+	}
+	for _, f := range body.Data {
+		if f.Date != req.Date {
+			continue
+		}
+		s := &core.FlightStatus{
+			Flight:    strings.ToUpper(f.Flight.IATA),
+			Date:      f.Date,
+			Airline:   f.Airline.Name,
+			Status:    f.Status,
+			Departure: f.Departure.end(),
+			Arrival:   f.Arrival.end(),
+			FetchedAt: time.Now().UTC(),
+		}
+		if f.Aircraft != nil {
+			s.Registration = f.Aircraft.Registration
+			if f.Aircraft.IATA != "" {
+				s.Aircraft = &core.Aircraft{Code: f.Aircraft.IATA}
+			}
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: %s on %s", core.ErrFlightNotFound, req.Flight, req.Date)
+}
+
+// ValidateCredentials checks AVIATIONSTACK_API_KEY by listing one flight.
+func (a *AviationstackStatusAdapter) ValidateCredentials(ctx context.Context, creds map[string]string) error {
+	key := creds["AVIATIONSTACK_API_KEY"]
+	if key == "" {
+		return fmt.Errorf("AVIATIONSTACK_API_KEY is required")
+	}
+	q := url.Values{"access_key": {key}, "limit": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, aviationstackURL()+"/flights?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return a.verifyCredentials(a.Name(), req)
+}
@@ -0,0 +1,101 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/cassette"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+)
+
+// transport is shared by all live adapters so connections opened during
+// warm-up (DNS, TCP, TLS) are reused by the first real search. Every
+// request goes through it, so they all get its logging and cassette
+// recording and replay.
+var transport = httpclient.Transport()
+
+// warmClient sends warm-up requests, which aren't provider calls and so
+// spend no quota.
+var warmClient = httpclient.Client(transport, nil, "")
+
+// providerClient sends an adapter's requests with httpclient's timeouts
+// and retries, counting each, retries included, through the meter the
+// adapter was built with against its provider's rate limit and usage.
+type providerClient struct {
+	client  *http.Client
+	metered bool
+}
+
+func newProviderClient(meter httpclient.Meter, provider string) providerClient {
+	return providerClient{client: httpclient.Client(transport, meter, provider), metered: meter != nil}
+}
+
+// MetersRequests reports whether the adapter's requests are counted as
+// they're sent; see core.RequestMeterer.
+func (c providerClient) MetersRequests() bool { return c.metered }
+
+// hasEnv reports whether getenv has every named variable, as an adapter
+// needs its credentials to be. Replaying cassettes needs none.
+func hasEnv(getenv func(string) string, names ...string) bool {
+	if cassette.Replaying() {
+		return true
+	}
+	for _, name := range names {
+		if getenv(name) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// warmConnection issues a lightweight HEAD request so the connection to
+// baseURL is established and parked in the idle pool. Any HTTP status
+// counts as success; only transport failures are reported.
+func warmConnection(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, baseURL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := warmClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("warm %s: %w", baseURL, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// checkStatus turns a non-2xx provider response into an error. Maintenance
+// statuses become *core.MaintenanceError so routing skips the provider
+// until the time given by Retry-After.
+func checkStatus(provider string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if core.IsMaintenanceStatus(resp.StatusCode) {
+		return &core.MaintenanceError{
+			Provider:   provider,
+			StatusCode: resp.StatusCode,
+			RetryAt:    httpclient.RetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return fmt.Errorf("%s: unexpected HTTP status %s", provider, resp.Status)
+}
+
+// verifyCredentials sends an authenticated probe request and reports
+// core.ErrInvalidCredentials when the provider answers 401 or 403.
+func (c providerClient) verifyCredentials(provider string, req *http.Request) error {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("verify credentials: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w (HTTP %d)", core.ErrInvalidCredentials, resp.StatusCode)
+	}
+	return checkStatus(provider, resp)
+}
@@ -0,0 +1,47 @@
+package live
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAdaptersUseSharedClient keeps adapters on their providerClient, so
+// none skips its timeouts, retries, metering, and cassette recording.
+func TestAdaptersUseSharedClient(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.CompositeLit); ok {
+				if sel, ok := lit.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Client" {
+					t.Errorf("%s: builds its own http.Client; send requests through providerClient", fset.Position(lit.Pos()))
+				}
+			}
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "http" {
+				switch sel.Sel.Name {
+				case "DefaultClient", "Get", "Head", "Post", "PostForm", "Transport":
+					t.Errorf("%s: uses http.%s; send requests through providerClient", fset.Position(sel.Pos()), sel.Sel.Name)
+				}
+			}
+			return true
+		})
+	}
+}
@@ -1,19 +1,26 @@
 package live
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"net/http"
 
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
 )
 
+const duffelBaseURL = "https://api.duffel.com"
+
 // DuffelFlightsAdapter connects to the Duffel API for flight search.
 // Duffel is self-serve friendly: https://duffel.com (free tier available).
 // Set DUFFEL_API_TOKEN to enable.
-type DuffelFlightsAdapter struct{}
+type DuffelFlightsAdapter struct {
+	providerClient
+	getenv func(string) string
+}
 
-func NewDuffelFlightsAdapter() *DuffelFlightsAdapter {
-	return &DuffelFlightsAdapter{}
+func NewDuffelFlightsAdapter(getenv func(string) string, meter httpclient.Meter) *DuffelFlightsAdapter {
+	return &DuffelFlightsAdapter{providerClient: newProviderClient(meter, "duffel"), getenv: getenv}
 }
 
 func (a *DuffelFlightsAdapter) Name() string            { return "duffel" }
@@ -23,7 +30,7 @@ func (a *DuffelFlightsAdapter) Capabilities() []core.Capability {
 }
 
 func (a *DuffelFlightsAdapter) Available() (bool, string) {
-	if os.Getenv("DUFFEL_API_TOKEN") == "" {
+	if !hasEnv(a.getenv, "DUFFEL_API_TOKEN") {
 		return false, "set DUFFEL_API_TOKEN (sign up free at https://duffel.com)"
 	}
 	return true, ""
@@ -33,5 +40,28 @@ func (a *DuffelFlightsAdapter) SearchFlights(req core.FlightSearchRequest) ([]co
 	// TODO: implement real Duffel API call
 	// POST https://api.duffel.com/air/offer_requests
 	// Authorization: Bearer $DUFFEL_API_TOKEN
+	// Map each offer's expires_at to ExpiresAt and
+	// payment_requirements.price_guarantee_expires_at to PriceGuaranteedUntil.
 	return nil, fmt.Errorf("duffel adapter not yet implemented – coming soon")
 }
+
+// Warmup opens a connection to the Duffel API ahead of the first search.
+func (a *DuffelFlightsAdapter) Warmup(ctx context.Context) error {
+	return warmConnection(ctx, duffelBaseURL)
+}
+
+// ValidateCredentials checks DUFFEL_API_TOKEN by listing one airline, the
+// cheapest authenticated call in the API.
+func (a *DuffelFlightsAdapter) ValidateCredentials(ctx context.Context, creds map[string]string) error {
+	token := creds["DUFFEL_API_TOKEN"]
+	if token == "" {
+		return fmt.Errorf("DUFFEL_API_TOKEN is required")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, duffelBaseURL+"/air/airlines?limit=1", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Duffel-Version", "v2")
+	return a.verifyCredentials(a.Name(), req)
+}
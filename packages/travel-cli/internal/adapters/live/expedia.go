@@ -1,29 +1,40 @@
 package live
 
 import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
-	"os"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
 )
 
+const expediaBaseURL = "https://api.ean.com"
+
 // ExpediaStaysAdapter connects to Expedia Rapid API for hotel search.
 // Requires partner signup: https://developers.expediagroup.com/supply/lodging/docs/getting-started
 // Set EXPEDIA_API_KEY and EXPEDIA_API_SECRET to enable.
-type ExpediaStaysAdapter struct{}
+type ExpediaStaysAdapter struct {
+	providerClient
+	getenv func(string) string
+}
 
-func NewExpediaStaysAdapter() *ExpediaStaysAdapter {
-	return &ExpediaStaysAdapter{}
+func NewExpediaStaysAdapter(getenv func(string) string, meter httpclient.Meter) *ExpediaStaysAdapter {
+	return &ExpediaStaysAdapter{providerClient: newProviderClient(meter, "expedia"), getenv: getenv}
 }
 
 func (a *ExpediaStaysAdapter) Name() string            { return "expedia" }
 func (a *ExpediaStaysAdapter) Tier() core.ProviderTier { return core.TierPartnerRequired }
 func (a *ExpediaStaysAdapter) Capabilities() []core.Capability {
-	return []core.Capability{core.CapStaysSearch, core.CapReprice, core.CapDeepLink}
+	return []core.Capability{core.CapStaysSearch, core.CapStaysLookup, core.CapReprice, core.CapDeepLink}
 }
 
 func (a *ExpediaStaysAdapter) Available() (bool, string) {
-	if os.Getenv("EXPEDIA_API_KEY") == "" || os.Getenv("EXPEDIA_API_SECRET") == "" {
+	if !hasEnv(a.getenv, "EXPEDIA_API_KEY", "EXPEDIA_API_SECRET") {
 		return false, "set EXPEDIA_API_KEY and EXPEDIA_API_SECRET (partner signup at developers.expediagroup.com)"
 	}
 	return true, ""
@@ -32,5 +43,38 @@ func (a *ExpediaStaysAdapter) Available() (bool, string) {
 func (a *ExpediaStaysAdapter) SearchStays(req core.StaySearchRequest) ([]core.StayOffer, error) {
 	// TODO: implement Expedia Rapid API call
 	// GET https://api.ean.com/v3/properties/availability
+	// Occupancy is one param per room as adults then child ages, e.g.
+	// occupancy=2-4,9 for req.Guests 2 and req.ChildrenAges [4 9].
+	// Each rate's amenities name its board (e.g. free_breakfast); set
+	// Board to it for core.NormalizeBoard to map.
 	return nil, fmt.Errorf("expedia adapter not yet implemented – coming soon")
 }
+
+func (a *ExpediaStaysAdapter) LookupStays(req core.StayLookupRequest) ([]core.StayOffer, error) {
+	// TODO: implement Expedia Rapid property availability by ID
+	// GET https://api.ean.com/v3/properties/availability?property_id={id}
+	return nil, fmt.Errorf("expedia property lookup not yet implemented – coming soon")
+}
+
+// Warmup opens a connection to the Expedia API ahead of the first search.
+func (a *ExpediaStaysAdapter) Warmup(ctx context.Context) error {
+	return warmConnection(ctx, expediaBaseURL)
+}
+
+// ValidateCredentials checks the key pair with a signed request for a
+// single region. Rapid signs requests with SHA-512 over key, secret, and
+// a Unix timestamp.
+func (a *ExpediaStaysAdapter) ValidateCredentials(ctx context.Context, creds map[string]string) error {
+	key, secret := creds["EXPEDIA_API_KEY"], creds["EXPEDIA_API_SECRET"]
+	if key == "" || secret == "" {
+		return fmt.Errorf("EXPEDIA_API_KEY and EXPEDIA_API_SECRET are required")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, expediaBaseURL+"/v3/regions?language=en-US&limit=1", nil)
+	if err != nil {
+		return err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := sha512.Sum512([]byte(key + secret + ts))
+	req.Header.Set("Authorization", fmt.Sprintf("EAN APIKey=%s,Signature=%s,timestamp=%s", key, hex.EncodeToString(sig[:]), ts))
+	return a.verifyCredentials(a.Name(), req)
+}
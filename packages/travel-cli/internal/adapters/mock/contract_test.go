@@ -0,0 +1,14 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/adapters/sdk"
+	"github.com/beetlebot/travel-cli/pkg/travelsdk/adaptertest"
+)
+
+func TestFlightsContract(t *testing.T) {
+	adaptertest.Flights(t, sdk.FlightAdapter(NewMockFlightsAdapter()))
+}
+
+func TestStaysContract(t *testing.T) { adaptertest.Stays(t, sdk.StayAdapter(NewMockStaysAdapter())) }
@@ -16,7 +16,7 @@ func NewMockFlightsAdapter() *MockFlightsAdapter {
 
 func (a *MockFlightsAdapter) Name() string                    { return "mock_flights" }
 func (a *MockFlightsAdapter) Tier() core.ProviderTier         { return core.TierEasySignup }
-func (a *MockFlightsAdapter) Capabilities() []core.Capability { return []core.Capability{core.CapFlightsSearch} }
+func (a *MockFlightsAdapter) Capabilities() []core.Capability { return []core.Capability{core.CapFlightsSearch, core.CapFlightsStatus} }
 func (a *MockFlightsAdapter) Available() (bool, string)       { return true, "" }
 
 var mockAirlines = []struct {
@@ -35,7 +35,13 @@ var mockAirlines = []struct {
 }
 
 func (a *MockFlightsAdapter) SearchFlights(req core.FlightSearchRequest) ([]core.FlightOffer, error) {
-	depart, err := time.Parse("2006-01-02", req.DepartDate)
+	// Flights leave on the origin's clock, so arrival times land in the
+	// right place once the orchestrator puts them in the destination's.
+	loc := core.AirportLocation(req.From)
+	if loc == nil {
+		loc = time.UTC
+	}
+	depart, err := time.ParseInLocation("2006-01-02", req.DepartDate, loc)
 	if err != nil {
 		return nil, fmt.Errorf("invalid depart date: %w", err)
 	}
@@ -56,8 +62,7 @@ func (a *MockFlightsAdapter) SearchFlights(req core.FlightSearchRequest) ([]core
 			price = 150
 		}
 
-		offers = append(offers, core.FlightOffer{
-			ID:              fmt.Sprintf("f_%s_%d", al.Code, 1000+i),
+		offer := core.FlightOffer{
 			Source:          "mock_flights",
 			Airline:         al.Name,
 			FlightNumber:    fmt.Sprintf("%s%d", al.Prefix, 100+rng.Intn(900)),
@@ -71,17 +76,52 @@ func (a *MockFlightsAdapter) SearchFlights(req core.FlightSearchRequest) ([]core
 			CabinClass:      req.CabinClass,
 			PriceUSD:        float64(int(price*100)) / 100,
 			Currency:        "USD",
-			DeepLink:        fmt.Sprintf("https://example.com/book/%s_%d", al.Code, 1000+i),
 			Confidence:      0.95,
 			IsBookable:      false,
 			RepriceRequired: true,
 			FetchedAt:       time.Now().UTC(),
-		})
+		}
+		offer.ExpiresAt, offer.PriceGuaranteedUntil = mockDeadlines(offer.FetchedAt)
+		offer.Aircraft, offer.WifiAvailable = mockEquipment(offer.FlightNumber, durationMin)
+		offer.ID = core.StableFlightID(offer)
+		offer.DeepLink = "https://example.com/book/" + offer.ID
+		offers = append(offers, offer)
 	}
 
 	return offers, nil
 }
 
+// Aircraft types mock flights are given by IATA code, as Duffel gives
+// them, widebodies for long flights.
+var (
+	mockNarrowbodies = []string{"223", "320", "32N", "321", "738", "7M8"}
+	mockWidebodies   = []string{"333", "359", "77W", "788", "789"}
+)
+
+// mockEquipment picks a flight's aircraft and whether it has wifi from its
+// number, so they stay the same across searches without shifting the
+// offers' other fields.
+func mockEquipment(flightNumber string, durationMin int) (*core.Aircraft, *bool) {
+	rng := rand.New(rand.NewSource(hashSeed(flightNumber)))
+	types := mockNarrowbodies
+	if durationMin >= 420 {
+		types = mockWidebodies
+	}
+	wifi := rng.Intn(10) < 7
+	return &core.Aircraft{Code: types[rng.Intn(len(types))]}, &wifi
+}
+
+// Mock offers mimic Duffel, whose offers are typically held for about 30
+// minutes with a shorter price guarantee.
+const (
+	mockOfferTTL       = 30 * time.Minute
+	mockPriceGuarantee = 20 * time.Minute
+)
+
+func mockDeadlines(fetchedAt time.Time) (expiresAt, priceGuaranteedUntil time.Time) {
+	return fetchedAt.Add(mockOfferTTL), fetchedAt.Add(mockPriceGuarantee)
+}
+
 func hashSeed(s string) int64 {
 	var h int64
 	for _, c := range s {
@@ -0,0 +1,155 @@
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+type mockChainBrand struct {
+	Brand     string
+	BasePrice float64
+	Rating    float64
+	Reviews   int
+}
+
+var mockChains = map[string][]mockChainBrand{
+	"hilton": {
+		{"Hilton", 210, 4.4, 3120},
+		{"DoubleTree by Hilton", 170, 4.3, 1870},
+		{"Hampton Inn by Hilton", 135, 4.2, 1450},
+	},
+	"marriott": {
+		{"Marriott", 220, 4.4, 2980},
+		{"Courtyard by Marriott", 150, 4.2, 1660},
+		{"Sheraton", 190, 4.1, 2210},
+	},
+	"hyatt": {
+		{"Grand Hyatt", 260, 4.5, 2040},
+		{"Hyatt Regency", 205, 4.4, 1780},
+		{"Hyatt Place", 145, 4.3, 1120},
+	},
+	"ihg": {
+		{"InterContinental", 240, 4.5, 1930},
+		{"Crowne Plaza", 165, 4.1, 1540},
+		{"Holiday Inn", 120, 4.0, 2650},
+	},
+	"accor": {
+		{"Sofitel", 250, 4.5, 1410},
+		{"Novotel", 150, 4.2, 1980},
+		{"ibis", 85, 3.9, 3300},
+	},
+}
+
+// mockPropertyID encodes the template and city so a lookup can rebuild
+// the same property, e.g. MS03-PARIS.
+func mockPropertyID(templateIdx int, city string) string {
+	return fmt.Sprintf("MS%02d-%s", templateIdx, citySlug(city))
+}
+
+func citySlug(city string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(city), "_"))
+}
+
+var mockPropertyIDPattern = regexp.MustCompile(`^MS(\d{2})-(.+)$`)
+
+func (a *MockStaysAdapter) LookupStays(req core.StayLookupRequest) ([]core.StayOffer, error) {
+	nights, err := mockNights(req.CheckIn, req.CheckOut)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PropertyID != "" {
+		return []core.StayOffer{lookupProperty(req, nights)}, nil
+	}
+
+	brands, ok := mockChains[strings.ToLower(req.Chain)]
+	if !ok {
+		return nil, fmt.Errorf("unknown chain %q", req.Chain)
+	}
+	rng := rand.New(rand.NewSource(hashSeed(req.Chain + req.City + req.CheckIn)))
+	var offers []core.StayOffer
+	for i, b := range brands {
+		tmpl := mockStayTemplate{
+			Name:      fmt.Sprintf("%s %s", b.Brand, req.City),
+			Type:      "hotel",
+			BasePrice: b.BasePrice * (0.8 + rng.Float64()*0.4),
+			Rating:    b.Rating,
+			Reviews:   b.Reviews,
+			Amenities: []string{"wifi", "gym", "restaurant"},
+			Board:     "RO",
+		}
+		propertyID := fmt.Sprintf("MC-%s-%d-%s", strings.ToUpper(req.Chain), i, citySlug(req.City))
+		offer := mockLookupOffer(req, req.City, nights, tmpl, propertyID, rng)
+		offer.Chain = strings.ToLower(req.Chain)
+		offers = append(offers, offer)
+	}
+	return offers, nil
+}
+
+// lookupProperty rebuilds a property from a mock property ID, or derives a
+// deterministic one from any other provider-style ID (e.g. EXP12345).
+func lookupProperty(req core.StayLookupRequest, nights int) core.StayOffer {
+	city := req.City
+	var idx int
+	if m := mockPropertyIDPattern.FindStringSubmatch(req.PropertyID); m != nil {
+		idx, _ = strconv.Atoi(m[1])
+		if city == "" {
+			words := strings.Split(strings.ToLower(m[2]), "_")
+			for i, w := range words {
+				if w != "" {
+					words[i] = strings.ToUpper(w[:1]) + w[1:]
+				}
+			}
+			city = strings.Join(words, " ")
+		}
+	} else {
+		idx = int(hashSeed(req.PropertyID) % int64(len(mockStayTemplates)))
+	}
+	idx %= len(mockStayTemplates)
+	if city == "" {
+		city = "Unknown"
+	}
+
+	tmpl := mockStayTemplates[idx]
+	rng := rand.New(rand.NewSource(hashSeed(req.PropertyID + req.CheckIn)))
+	tmpl.BasePrice *= 0.7 + rng.Float64()*0.6
+	tmpl.Name = fmt.Sprintf("%s %s", tmpl.Name, city)
+	return mockLookupOffer(req, city, nights, tmpl, req.PropertyID, rng)
+}
+
+func mockLookupOffer(req core.StayLookupRequest, city string, nights int, tmpl mockStayTemplate, propertyID string, rng *rand.Rand) core.StayOffer {
+	lat, lon := mockCoordinates(city, rng)
+	offer := core.StayOffer{
+		Source:          "mock_stays",
+		Name:            tmpl.Name,
+		PropertyID:      propertyID,
+		Type:            tmpl.Type,
+		City:            city,
+		Latitude:        lat,
+		Longitude:       lon,
+		CheckIn:         req.CheckIn,
+		CheckOut:        req.CheckOut,
+		NightsCount:     nights,
+		PricePerNight:   float64(int(tmpl.BasePrice*100)) / 100,
+		TotalPriceUSD:   float64(int(tmpl.BasePrice*float64(nights)*100)) / 100,
+		Currency:        "USD",
+		Rating:          tmpl.Rating,
+		ReviewCount:     tmpl.Reviews,
+		Amenities:       tmpl.Amenities,
+		Board:           core.NormalizeBoard(tmpl.Board),
+		DeepLink:        fmt.Sprintf("https://example.com/property/%s", propertyID),
+		Confidence:      0.90,
+		IsBookable:      false,
+		RepriceRequired: true,
+		FetchedAt:       time.Now().UTC(),
+	}
+	offer.ExpiresAt, offer.PriceGuaranteedUntil = mockDeadlines(offer.FetchedAt)
+	offer.ID = core.StableStayID(offer)
+	return offer
+}
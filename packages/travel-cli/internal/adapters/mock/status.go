@@ -0,0 +1,96 @@
+package mock
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// mockStatusAirports are the airports mock flights are tracked between.
+var mockStatusAirports = []string{"YYZ", "YUL", "YVR", "JFK", "LAX", "SFO", "ORD", "LHR", "CDG", "FRA", "AMS", "LIS"}
+
+var mockFlightNumberPattern = regexp.MustCompile(`^[A-Z0-9]{2}\d{1,4}$`)
+
+// FlightStatus makes up a flight's route, schedule, gates, and delay from
+// its number and date, and its progress from the time of day, so the
+// same flight reads the same all day.
+func (a *MockFlightsAdapter) FlightStatus(req core.FlightStatusRequest) (*core.FlightStatus, error) {
+	flight := strings.ToUpper(req.Flight)
+	if !mockFlightNumberPattern.MatchString(flight) {
+		return nil, fmt.Errorf("%w: %s", core.ErrFlightNotFound, req.Flight)
+	}
+	rng := rand.New(rand.NewSource(hashSeed(flight + req.Date)))
+	from := mockStatusAirports[rng.Intn(len(mockStatusAirports))]
+	to := from
+	for to == from {
+		to = mockStatusAirports[rng.Intn(len(mockStatusAirports))]
+	}
+	loc := core.AirportLocation(from)
+	if loc == nil {
+		loc = time.UTC
+	}
+	day, err := time.ParseInLocation("2006-01-02", req.Date, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %w", err)
+	}
+
+	durationMin := mockFlightMinutes(from, to)
+	depart := day.Add(time.Duration(6+rng.Intn(15))*time.Hour + time.Duration(rng.Intn(12)*5)*time.Minute)
+	arrive := depart.Add(time.Duration(durationMin) * time.Minute)
+	delay := 0
+	if rng.Intn(10) < 4 {
+		delay = 5 + rng.Intn(86)
+	}
+	late := time.Duration(delay) * time.Minute
+	s := &core.FlightStatus{
+		Flight: flight,
+		Date:   req.Date,
+		Departure: core.FlightStatusEnd{
+			Airport:   from,
+			Terminal:  fmt.Sprint(1 + rng.Intn(3)),
+			Gate:      fmt.Sprintf("%c%d", 'A'+rng.Intn(6), 1+rng.Intn(40)),
+			Scheduled: depart,
+		},
+		Arrival: core.FlightStatusEnd{
+			Airport:   to,
+			Terminal:  fmt.Sprint(1 + rng.Intn(3)),
+			Gate:      fmt.Sprintf("%c%d", 'A'+rng.Intn(6), 1+rng.Intn(40)),
+			Baggage:   fmt.Sprint(1 + rng.Intn(12)),
+			Scheduled: arrive,
+		},
+		FetchedAt: time.Now().UTC(),
+	}
+	s.Aircraft, _ = mockEquipment(flight, durationMin)
+
+	now := time.Now()
+	switch {
+	case rng.Intn(50) == 0:
+		s.Status = core.FlightCancelled
+	case now.Before(depart.Add(late)):
+		s.Status = core.FlightScheduled
+		s.Departure.Estimated, s.Arrival.Estimated = depart.Add(late), arrive.Add(late)
+	case now.Before(arrive.Add(late)):
+		s.Status = core.FlightActive
+		s.Departure.Actual, s.Arrival.Estimated = depart.Add(late), arrive.Add(late)
+	default:
+		s.Status = core.FlightLanded
+		s.Departure.Actual, s.Arrival.Actual = depart.Add(late), arrive.Add(late)
+	}
+	return s, nil
+}
+
+// mockFlightMinutes is about how long a flight between two airports takes
+// at cruising speed, with half an hour for taxiing, climb, and descent.
+func mockFlightMinutes(from, to string) int {
+	a, errA := airports.Lookup(from)
+	b, errB := airports.Lookup(to)
+	if errA != nil || errB != nil {
+		return 180
+	}
+	return 30 + int(airports.Distance(a, b)/850*60)
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestFlightStatus(t *testing.T) {
+	a := NewMockFlightsAdapter()
+	req := core.FlightStatusRequest{Flight: "AC871", Date: "2026-06-12"}
+	first, err := a.FlightStatus(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := a.FlightStatus(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Departure != again.Departure || first.Arrival.Airport != again.Arrival.Airport {
+		t.Errorf("status changed between calls: %+v, then %+v", first.Departure, again.Departure)
+	}
+	if first.Departure.Airport == first.Arrival.Airport || first.Departure.Gate == "" {
+		t.Errorf("departure %+v, arrival %+v", first.Departure, first.Arrival)
+	}
+	if !first.Arrival.Scheduled.After(first.Departure.Scheduled) {
+		t.Errorf("arrives %s, before it departs %s", first.Arrival.Scheduled, first.Departure.Scheduled)
+	}
+	if _, err := a.FlightStatus(core.FlightStatusRequest{Flight: "NOT A FLIGHT", Date: "2026-06-12"}); !errors.Is(err, core.ErrFlightNotFound) {
+		t.Errorf("err = %v, want ErrFlightNotFound", err)
+	}
+}
@@ -2,7 +2,9 @@ package mock
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/beetlebot/travel-cli/internal/core"
@@ -16,7 +18,7 @@ func NewMockStaysAdapter() *MockStaysAdapter {
 
 func (a *MockStaysAdapter) Name() string                    { return "mock_stays" }
 func (a *MockStaysAdapter) Tier() core.ProviderTier         { return core.TierEasySignup }
-func (a *MockStaysAdapter) Capabilities() []core.Capability { return []core.Capability{core.CapStaysSearch} }
+func (a *MockStaysAdapter) Capabilities() []core.Capability { return []core.Capability{core.CapStaysSearch, core.CapStaysLookup} }
 func (a *MockStaysAdapter) Available() (bool, string)       { return true, "" }
 
 type mockStayTemplate struct {
@@ -26,61 +28,65 @@ type mockStayTemplate struct {
 	Rating    float64
 	Reviews   int
 	Amenities []string
+	// Board is the rate's board code, as a bed bank sends it.
+	Board string
 }
 
 var mockStayTemplates = []mockStayTemplate{
-	{"Grand Hotel Central", "hotel", 180, 4.5, 1234, []string{"wifi", "pool", "gym", "restaurant", "room_service"}},
-	{"City View Suites", "hotel", 140, 4.2, 890, []string{"wifi", "gym", "breakfast"}},
-	{"Cozy Downtown Apartment", "apartment", 95, 4.7, 312, []string{"wifi", "kitchen", "washer", "balcony"}},
-	{"Boutique Loft Studio", "apartment", 110, 4.6, 245, []string{"wifi", "kitchen", "workspace"}},
-	{"Riverside Cabin", "cabin", 130, 4.8, 178, []string{"wifi", "fireplace", "parking", "nature_view"}},
-	{"Mountain Campsite", "campsite", 45, 4.3, 89, []string{"fire_pit", "hiking", "parking"}},
-	{"Lakeside Glamping", "campsite", 85, 4.5, 156, []string{"tent", "lake_access", "fire_pit", "showers"}},
-	{"Heritage B&B", "hotel", 125, 4.4, 567, []string{"wifi", "breakfast", "garden", "parking"}},
-	{"Modern Penthouse", "apartment", 220, 4.9, 98, []string{"wifi", "rooftop", "kitchen", "city_view", "hot_tub"}},
-	{"Budget Hostel Central", "hotel", 35, 3.8, 2100, []string{"wifi", "shared_kitchen", "lockers"}},
+	{"Grand Hotel Central", "hotel", 180, 4.5, 1234, []string{"wifi", "pool", "gym", "restaurant", "room_service"}, "BB"},
+	{"City View Suites", "hotel", 140, 4.2, 890, []string{"wifi", "gym", "breakfast"}, "BB"},
+	{"Cozy Downtown Apartment", "apartment", 95, 4.7, 312, []string{"wifi", "kitchen", "washer", "balcony"}, "RO"},
+	{"Boutique Loft Studio", "apartment", 110, 4.6, 245, []string{"wifi", "kitchen", "workspace"}, "RO"},
+	{"Riverside Cabin", "cabin", 130, 4.8, 178, []string{"wifi", "fireplace", "parking", "nature_view"}, "RO"},
+	{"Mountain Campsite", "campsite", 45, 4.3, 89, []string{"fire_pit", "hiking", "parking"}, "RO"},
+	{"Lakeside Glamping", "campsite", 85, 4.5, 156, []string{"tent", "lake_access", "fire_pit", "showers"}, "HB"},
+	{"Heritage B&B", "hotel", 125, 4.4, 567, []string{"wifi", "breakfast", "garden", "parking"}, "BB"},
+	{"Modern Penthouse", "apartment", 220, 4.9, 98, []string{"wifi", "rooftop", "kitchen", "city_view", "hot_tub"}, "RO"},
+	{"Budget Hostel Central", "hotel", 35, 3.8, 2100, []string{"wifi", "shared_kitchen", "lockers"}, "RO"},
 }
 
 func (a *MockStaysAdapter) SearchStays(req core.StaySearchRequest) ([]core.StayOffer, error) {
-	checkin, err := time.Parse("2006-01-02", req.CheckIn)
+	nights, err := mockNights(req.CheckIn, req.CheckOut)
 	if err != nil {
-		return nil, fmt.Errorf("invalid checkin date: %w", err)
-	}
-	checkout, err := time.Parse("2006-01-02", req.CheckOut)
-	if err != nil {
-		return nil, fmt.Errorf("invalid checkout date: %w", err)
-	}
-	nights := int(checkout.Sub(checkin).Hours() / 24)
-	if nights < 1 {
-		nights = 1
+		return nil, err
 	}
 
 	rng := rand.New(rand.NewSource(hashSeed(req.City + req.CheckIn)))
 	count := 5 + rng.Intn(4)
 
 	var offers []core.StayOffer
+	// rates counts the listings of each property so far; a property drawn
+	// again is another rate for it and needs an ID of its own.
+	rates := make(map[int]int)
 	for i := 0; i < count; i++ {
-		tmpl := mockStayTemplates[rng.Intn(len(mockStayTemplates))]
+		idx := rng.Intn(len(mockStayTemplates))
+		tmpl := mockStayTemplates[idx]
 
 		if req.StayType != "any" && req.StayType != "" && req.StayType != tmpl.Type {
 			continue
 		}
 
 		priceVariance := 0.7 + rng.Float64()*0.6
-		pricePerNight := tmpl.BasePrice * priceVariance
+		childrenPerNight := mockChildrenRate(tmpl.BasePrice*priceVariance, req.ChildrenAges)
+		pricePerNight := tmpl.BasePrice*priceVariance + childrenPerNight
 		totalPrice := pricePerNight * float64(nights)
 
 		if req.MaxPriceUSD > 0 && pricePerNight > float64(req.MaxPriceUSD) {
 			continue
 		}
 
-		offers = append(offers, core.StayOffer{
-			ID:              fmt.Sprintf("s_%s_%d", tmpl.Type[:3], 2000+i),
+		lat, lon := mockCoordinates(req.City, rng)
+
+		offer := core.StayOffer{
 			Source:          "mock_stays",
 			Name:            fmt.Sprintf("%s %s", tmpl.Name, req.City),
+			PropertyID:      mockPropertyID(idx, req.City),
 			Type:            tmpl.Type,
 			City:            req.City,
 			Address:         fmt.Sprintf("%d %s Street, %s", 10+rng.Intn(990), randomStreet(rng), req.City),
+			Latitude:        lat,
+			Longitude:       lon,
+			Neighborhood:    mockNeighborhood(req.City, rng),
 			CheckIn:         req.CheckIn,
 			CheckOut:        req.CheckOut,
 			NightsCount:     nights,
@@ -90,17 +96,106 @@ func (a *MockStaysAdapter) SearchStays(req core.StaySearchRequest) ([]core.StayO
 			Rating:          tmpl.Rating,
 			ReviewCount:     tmpl.Reviews,
 			Amenities:       tmpl.Amenities,
-			DeepLink:        fmt.Sprintf("https://example.com/stay/%s_%d", tmpl.Type[:3], 2000+i),
+			Board:           core.NormalizeBoard(tmpl.Board),
 			Confidence:      0.90,
 			IsBookable:      false,
 			RepriceRequired: true,
 			FetchedAt:       time.Now().UTC(),
-		})
+		}
+		offer.ChildrenPriceUSD = math.Round(childrenPerNight*float64(nights)*100) / 100
+		offer.ExpiresAt, offer.PriceGuaranteedUntil = mockDeadlines(offer.FetchedAt)
+		offer.ID = core.StableStayID(offer)
+		if n := rates[idx]; n > 0 {
+			offer.ID = fmt.Sprintf("%s_%d", offer.ID, n)
+		}
+		rates[idx]++
+		offer.DeepLink = "https://example.com/stay/" + offer.ID
+		offers = append(offers, offer)
 	}
 
 	return offers, nil
 }
 
+// mockChildSurcharge is the share of the room's nightly rate charged per
+// child in each age band.
+var mockChildSurcharge = map[string]float64{
+	core.ChildBandInfant:  0,
+	core.ChildBandUnder6:  0.10,
+	core.ChildBandUnder12: 0.25,
+	core.ChildBandTeen:    0.40,
+}
+
+func mockChildrenRate(roomRate float64, ages []int) float64 {
+	var rate float64
+	for _, age := range ages {
+		rate += roomRate * mockChildSurcharge[core.ChildAgeBand(age)]
+	}
+	return rate
+}
+
+func mockNights(checkIn, checkOut string) (int, error) {
+	checkin, err := time.Parse("2006-01-02", checkIn)
+	if err != nil {
+		return 0, fmt.Errorf("invalid checkin date: %w", err)
+	}
+	checkout, err := time.Parse("2006-01-02", checkOut)
+	if err != nil {
+		return 0, fmt.Errorf("invalid checkout date: %w", err)
+	}
+	nights := int(checkout.Sub(checkin).Hours() / 24)
+	if nights < 1 {
+		nights = 1
+	}
+	return nights, nil
+}
+
+// cityCenters gives mock stays plausible coordinates so map-oriented output
+// formats have something to plot. Unknown cities get no coordinates.
+var cityCenters = map[string][2]float64{
+	"paris":     {48.8566, 2.3522},
+	"london":    {51.5074, -0.1278},
+	"new york":  {40.7128, -74.0060},
+	"montreal":  {45.5019, -73.5674},
+	"toronto":   {43.6532, -79.3832},
+	"lisbon":    {38.7223, -9.1393},
+	"barcelona": {41.3874, 2.1686},
+	"rome":      {41.9028, 12.4964},
+	"tokyo":     {35.6762, 139.6503},
+	"chicago":   {41.8781, -87.6298},
+	"banff":     {51.1784, -115.5708},
+	"vancouver": {49.2827, -123.1207},
+}
+
+func mockCoordinates(city string, rng *rand.Rand) (float64, float64) {
+	center, ok := cityCenters[strings.ToLower(strings.TrimSpace(city))]
+	if !ok {
+		return 0, 0
+	}
+	lat := center[0] + (rng.Float64()-0.5)*0.06
+	lon := center[1] + (rng.Float64()-0.5)*0.06
+	return float64(int(lat*1e5)) / 1e5, float64(int(lon*1e5)) / 1e5
+}
+
+var cityNeighborhoods = map[string][]string{
+	"paris":    {"Le Marais", "Saint-Germain-des-Prés", "Montmartre", "Latin Quarter", "Bastille"},
+	"london":   {"Soho", "Shoreditch", "Kensington", "Camden", "South Bank"},
+	"new york": {"Midtown", "SoHo", "Williamsburg", "Upper West Side", "Chelsea"},
+	"montreal": {"Plateau-Mont-Royal", "Old Montreal", "Mile End", "Downtown", "Griffintown"},
+	"lisbon":   {"Alfama", "Baixa", "Bairro Alto", "Chiado", "Príncipe Real"},
+	"tokyo":    {"Shinjuku", "Shibuya", "Asakusa", "Ginza", "Roppongi"},
+	"chicago":  {"The Loop", "River North", "Wicker Park", "Lincoln Park", "West Loop"},
+}
+
+var genericNeighborhoods = []string{"Downtown", "Old Town", "Waterfront", "Arts District", "University District"}
+
+func mockNeighborhood(city string, rng *rand.Rand) string {
+	names, ok := cityNeighborhoods[strings.ToLower(strings.TrimSpace(city))]
+	if !ok {
+		names = genericNeighborhoods
+	}
+	return names[rng.Intn(len(names))]
+}
+
 var streets = []string{"Main", "Oak", "Maple", "King", "Queen", "Park", "River", "Lake", "Mountain", "Forest"}
 
 func randomStreet(rng *rand.Rand) string {
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"math"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestSearchStaysPricesChildren(t *testing.T) {
+	req := core.StaySearchRequest{City: "Lisbon", CheckIn: "2026-06-12", CheckOut: "2026-06-15", StayType: "any"}
+	adults, err := NewMockStaysAdapter().SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.ChildrenAges = []int{4, 9}
+	family, err := NewMockStaysAdapter().SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(family) != len(adults) {
+		t.Fatalf("%d offers with children, %d without", len(family), len(adults))
+	}
+	for i, s := range family {
+		extra := s.TotalPriceUSD - adults[i].TotalPriceUSD
+		if s.ChildrenPriceUSD <= 0 || math.Abs(s.ChildrenPriceUSD-extra) > 0.02 {
+			t.Errorf("%s: childrenPriceUSD %v, want about %.2f", s.ID, s.ChildrenPriceUSD, extra)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// Protocol is the version of the exec protocol requests are sent in.
+const Protocol = 1
+
+// LoadDir returns the exec plugins in dir, sorted by file name.
+// Handshakes are remembered until the executable changes, so building a
+// router again doesn't start every plugin again, and kept in the cache
+// directory for ttl, so another run doesn't either. Plugins that say they
+// are unavailable are asked again each run.
+func LoadDir(dir string, ttl time.Duration) []*Adapter {
+	saved := openSavedHandshakes(ttl)
+	return load(dir, saved.open)
+}
+
+type request struct {
+	Protocol   int             `json:"protocol"`
+	Method     string          `json:"method"`
+	Capability core.Capability `json:"capability,omitempty"`
+	Request    interface{}     `json:"request,omitempty"`
+}
+
+type handshake struct {
+	modTime time.Time
+	size    int64
+	adapter *Adapter
+}
+
+// handshakes remembers the adapter for each exec plugin path.
+var handshakes sync.Map
+
+// savedHandshake is a handshake kept in the cache directory.
+type savedHandshake struct {
+	ModTime   time.Time    `json:"modTime"`
+	Size      int64        `json:"size"`
+	FetchedAt time.Time    `json:"fetchedAt"`
+	Info      capabilities `json:"info"`
+}
+
+// savedHandshakes is the cache file of handshakes, by plugin path.
+type savedHandshakes struct {
+	ttl  time.Duration
+	file string
+
+	mu      sync.Mutex
+	entries map[string]savedHandshake
+}
+
+func openSavedHandshakes(ttl time.Duration) *savedHandshakes {
+	s := &savedHandshakes{ttl: ttl, entries: map[string]savedHandshake{}}
+	if base, err := paths.CacheDir(); err == nil && ttl > 0 {
+		s.file = filepath.Join(base, "travel-plugins.json")
+		if data, err := os.ReadFile(s.file); err == nil {
+			_ = json.Unmarshal(data, &s.entries)
+		}
+	}
+	return s
+}
+
+// lookup returns the saved handshake of the plugin at path, if it was
+// made with the same executable within ttl.
+func (s *savedHandshakes) lookup(path string, info os.FileInfo) (capabilities, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.entries[path]
+	if !ok || !h.ModTime.Equal(info.ModTime()) || h.Size != info.Size() || time.Since(h.FetchedAt) >= s.ttl {
+		return capabilities{}, false
+	}
+	return h.Info, true
+}
+
+func (s *savedHandshakes) save(path string, info os.FileInfo, caps capabilities) {
+	if s.file == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[path] = savedHandshake{ModTime: info.ModTime(), Size: info.Size(), FetchedAt: time.Now().UTC(), Info: caps}
+	if raw, err := json.Marshal(s.entries); err == nil {
+		_ = os.MkdirAll(filepath.Dir(s.file), 0o755)
+		_ = os.WriteFile(s.file, raw, 0o644)
+	}
+}
+
+// open returns the adapter for the exec plugin at path, from this
+// process's or the cache file's handshakes when the executable hasn't
+// changed.
+func (s *savedHandshakes) open(path string) (*Adapter, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if h, ok := handshakes.Load(path); ok {
+		if h := h.(handshake); h.modTime.Equal(info.ModTime()) && h.size == info.Size() {
+			return h.adapter, nil
+		}
+	}
+	conn := execTransport{path: path}
+	a := &Adapter{path: path, conn: conn}
+	if caps, ok := s.lookup(path, info); ok {
+		a.info = caps
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+		defer cancel()
+		if err := conn.call(ctx, request{Protocol: Protocol, Method: "capabilities"}, &a.info); err != nil {
+			return nil, err
+		}
+		if err := a.info.validate(); err != nil {
+			return nil, err
+		}
+		if available, _ := a.Available(); available {
+			s.save(path, info, a.info)
+		}
+	}
+	handshakes.Store(path, handshake{modTime: info.ModTime(), size: info.Size(), adapter: a})
+	return a, nil
+}
+
+// execTransport runs the plugin for each search.
+type execTransport struct {
+	path string
+}
+
+func (t execTransport) search(ctx context.Context, capability core.Capability, req interface{}) (*searchResponse, error) {
+	var resp searchResponse
+	if err := t.call(ctx, request{Protocol: Protocol, Method: "search", Capability: capability, Request: req}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// call runs the plugin with req on stdin and decodes its stdout into
+// resp.
+func (t execTransport) call(ctx context.Context, req request, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.path)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s timed out", req.Method)
+		}
+		if line := lastLine(stderr.String()); line != "" {
+			return errors.New(line)
+		}
+		return err
+	}
+	if err := json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return fmt.Errorf("invalid %s response: %w", req.Method, err)
+	}
+	return nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
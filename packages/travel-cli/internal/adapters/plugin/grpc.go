@@ -0,0 +1,145 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/adapters/sdk"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/pkg/travelplugin"
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// LoadGRPCDir returns the go-plugin plugins in dir, sorted by file name,
+// starting each that isn't running yet. They keep running until Close,
+// or until the executable changes and the next load restarts them.
+func LoadGRPCDir(dir string) []*Adapter {
+	return load(dir, openGRPC)
+}
+
+type running struct {
+	modTime time.Time
+	size    int64
+	client  *goplugin.Client
+	adapter *Adapter
+}
+
+// clients holds the running go-plugin plugins by path.
+var clients sync.Map
+
+func openGRPC(path string) (*Adapter, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := clients.Load(path); ok {
+		r := r.(running)
+		if r.modTime.Equal(info.ModTime()) && r.size == info.Size() && !r.client.Exited() {
+			return r.adapter, nil
+		}
+		r.client.Kill()
+		clients.Delete(path)
+	}
+
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  travelplugin.Handshake,
+		VersionedPlugins: travelplugin.Plugins(nil),
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		StartTimeout:     handshakeTimeout,
+		Logger:           hclog.NewNullLogger(),
+	})
+	a, err := dispense(path, client)
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	clients.Store(path, running{modTime: info.ModTime(), size: info.Size(), client: client, adapter: a})
+	return a, nil
+}
+
+// dispense starts the plugin, agreeing on a protocol version, and asks
+// it what it serves.
+func dispense(path string, client *goplugin.Client) (*Adapter, error) {
+	rpc, err := client.Client()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rpc.Dispense(travelplugin.PluginName)
+	if err != nil {
+		return nil, err
+	}
+	provider, ok := raw.(travelplugin.Provider)
+	if !ok {
+		return nil, fmt.Errorf("protocol version %d has no provider", client.NegotiatedVersion())
+	}
+	return grpcAdapter(path, provider)
+}
+
+// grpcAdapter asks provider what it serves.
+func grpcAdapter(path string, provider travelplugin.Provider) (*Adapter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	pi, err := provider.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a := &Adapter{path: path, conn: grpcTransport{provider: provider}}
+	a.info = capabilities{Name: pi.Name, Tier: core.ProviderTier(pi.Tier)}
+	for _, c := range pi.Capabilities {
+		a.info.Capabilities = append(a.info.Capabilities, core.Capability(c))
+	}
+	if pi.Unavailable != "" {
+		available := false
+		a.info.Available, a.info.Reason = &available, pi.Unavailable
+	}
+	if err := a.info.validate(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Close stops the go-plugin plugins LoadGRPCDir started.
+func Close() {
+	clients.Range(func(path, r interface{}) bool {
+		r.(running).client.Kill()
+		clients.Delete(path)
+		return true
+	})
+}
+
+// grpcTransport calls a running plugin.
+type grpcTransport struct {
+	provider travelplugin.Provider
+}
+
+func (t grpcTransport) search(ctx context.Context, capability core.Capability, req interface{}) (*searchResponse, error) {
+	var resp searchResponse
+	var err error
+	switch capability {
+	case core.CapFlightsSearch:
+		var offers []travelplugin.FlightOffer
+		offers, err = t.provider.SearchFlights(ctx, sdk.FlightRequest(req.(core.FlightSearchRequest)))
+		resp.Flights = sdk.Flights(offers)
+	case core.CapStaysSearch:
+		var offers []travelplugin.StayOffer
+		offers, err = t.provider.SearchStays(ctx, sdk.StayRequest(req.(core.StaySearchRequest)))
+		resp.Stays = sdk.Stays(offers)
+	case core.CapStaysLookup:
+		var offers []travelplugin.StayOffer
+		offers, err = t.provider.LookupStays(ctx, sdk.LookupRequest(req.(core.StayLookupRequest)))
+		resp.Stays = sdk.Stays(offers)
+	default:
+		err = errors.New("unknown search " + string(capability))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/pkg/travelplugin"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+type fakeProvider struct {
+	travelplugin.Unsupported
+}
+
+func (fakeProvider) Info(context.Context) (travelplugin.Info, error) {
+	return travelplugin.Info{Name: "acme", Tier: "partnerRequired", Capabilities: []string{"stays.search"}, Unavailable: "set ACME_KEY"}, nil
+}
+
+func (fakeProvider) SearchStays(_ context.Context, req travelplugin.StaySearchRequest) ([]travelplugin.StayOffer, error) {
+	if req.City == "Atlantis" {
+		return nil, errors.New("no such city")
+	}
+	return []travelplugin.StayOffer{{Name: "Hotel Acme", City: req.City, CheckIn: req.CheckIn, CheckOut: req.CheckOut, TotalPriceUSD: 480}}, nil
+}
+
+func TestGRPCAdapter(t *testing.T) {
+	client, _ := goplugin.TestPluginGRPCConn(t, false, map[string]goplugin.Plugin{
+		travelplugin.PluginName: &travelplugin.GRPCPlugin{Impl: fakeProvider{}},
+	})
+	// Closing the client stops the server too.
+	t.Cleanup(func() { client.Close() })
+	raw, err := client.Dispense(travelplugin.PluginName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := grpcAdapter("/plugins/grpc/acme", raw.(travelplugin.Provider))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name() != "acme" || a.Tier() != core.TierPartnerRequired || a.Flights() || a.Stays() == nil {
+		t.Errorf("adapter = %s %s, flights %v", a.Name(), a.Tier(), a.Flights())
+	}
+	if ok, reason := a.Available(); ok || reason != "set ACME_KEY" {
+		t.Errorf("available = %v %q", ok, reason)
+	}
+
+	stays, err := a.SearchStays(core.StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-14"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stays) != 1 || stays[0].Source != "acme" || stays[0].ID == "" || time.Since(stays[0].FetchedAt) > time.Minute {
+		t.Errorf("stays = %+v, want the source, ID, and fetch time filled in", stays)
+	}
+
+	if _, err := a.SearchStays(core.StaySearchRequest{City: "Atlantis"}); err == nil || err.Error() != "plugin acme: no such city" {
+		t.Errorf("err = %v, want the provider's error", err)
+	}
+	if _, err := a.SearchFlights(core.FlightSearchRequest{From: "YUL"}); !errors.Is(err, travelplugin.ErrUnsupported) {
+		t.Errorf("flights err = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,265 @@
+// Package plugin runs provider adapters shipped as executables, in one
+// of two kinds.
+//
+// Exec plugins, in the plugins directory, are started once per call with
+// one JSON request on stdin and answer with one JSON response on stdout:
+//
+//	{"protocol":1,"method":"capabilities"}
+//	→ {"name":"acme","tier":"easySignup","capabilities":["flights.search"],"available":true}
+//
+//	{"protocol":1,"method":"search","capability":"flights.search","request":{...}}
+//	→ {"flights":[...]} or {"stays":[...]} or {"error":"..."}
+//
+// A search request is the FlightSearchRequest, StaySearchRequest, or
+// StayLookupRequest the CLI prints, and offers are FlightOffer or
+// StayOffer JSON. A plugin that exits non-zero fails the call with the
+// last line it wrote to stderr.
+//
+// go-plugin plugins, in its grpc subdirectory, are started once and kept
+// running, answering the travel.plugin.v1 ProviderService over gRPC; see
+// pkg/travelplugin.
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/logging"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// handshakeTimeout bounds the capabilities call.
+const handshakeTimeout = 5 * time.Second
+
+// searchTimeout stops a plugin still searching long after the
+// orchestrator has given up on it.
+const searchTimeout = time.Minute
+
+// name is what a plugin may call itself: a provider name that can't be
+// taken for a mock provider.
+var name = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Dir is where exec plugins are installed: travel/plugins under
+// paths.ConfigDir. go-plugin plugins go in its grpc subdirectory.
+func Dir() (string, error) {
+	base, err := paths.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "travel", "plugins"), nil
+}
+
+// Load returns the exec plugins in Dir, then the go-plugin plugins.
+// Plugins that fail the handshake are logged and left out. Exec plugins'
+// handshakes are reused for ttl; see LoadDir.
+func Load(ttl time.Duration) []*Adapter {
+	dir, err := Dir()
+	if err != nil {
+		return nil
+	}
+	return append(LoadDir(dir, ttl), LoadGRPCDir(filepath.Join(dir, "grpc"))...)
+}
+
+// load handshakes with the plugins in dir at once, keeping those that
+// answer in file name order.
+func load(dir string, open func(path string) (*Adapter, error)) []*Adapter {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && executable(e.Name(), info) {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	adapters := make([]*Adapter, len(files))
+	var wg sync.WaitGroup
+	for i, path := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a, err := open(path)
+			if err != nil {
+				logging.Logger().Warn("plugin not loaded", "path", path, "error", err)
+				return
+			}
+			adapters[i] = a
+		}()
+	}
+	wg.Wait()
+	out := adapters[:0]
+	for _, a := range adapters {
+		if a != nil {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// executable reports whether a directory entry is a plugin: a regular,
+// non-hidden file with an execute bit, or an .exe on Windows.
+func executable(file string, info os.FileInfo) bool {
+	if strings.HasPrefix(file, ".") || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(file), ".exe")
+	}
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// Adapter is a plugin as a flight and stay adapter. The router only
+// sees the searches its capabilities list; see Flights and Stays.
+type Adapter struct {
+	path string
+	info capabilities
+	conn transport
+}
+
+// transport carries a search to a plugin.
+type transport interface {
+	search(ctx context.Context, capability core.Capability, req interface{}) (*searchResponse, error)
+}
+
+// capabilities is what a plugin says about itself in its handshake.
+type capabilities struct {
+	Name         string            `json:"name"`
+	Tier         core.ProviderTier `json:"tier,omitempty"`
+	Capabilities []core.Capability `json:"capabilities"`
+	Available    *bool             `json:"available,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+}
+
+type searchResponse struct {
+	Flights []core.FlightOffer `json:"flights,omitempty"`
+	Stays   []core.StayOffer   `json:"stays,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+func (c *capabilities) validate() error {
+	if !name.MatchString(c.Name) || strings.HasPrefix(c.Name, "mock_") {
+		return fmt.Errorf("invalid provider name %q (use lowercase letters, digits, _ and -, not starting with mock_)", c.Name)
+	}
+	switch c.Tier {
+	case "":
+		c.Tier = core.TierEasySignup
+	case core.TierEasySignup, core.TierPartnerRequired, core.TierEnterpriseOnly:
+	default:
+		return fmt.Errorf("unknown tier %q", c.Tier)
+	}
+	if !slices.Contains(c.Capabilities, core.CapFlightsSearch) && !slices.Contains(c.Capabilities, core.CapStaysSearch) {
+		return fmt.Errorf("%s lists neither %s nor %s", c.Name, core.CapFlightsSearch, core.CapStaysSearch)
+	}
+	return nil
+}
+
+func (a *Adapter) Name() string                    { return a.info.Name }
+func (a *Adapter) Tier() core.ProviderTier         { return a.info.Tier }
+func (a *Adapter) Capabilities() []core.Capability { return a.info.Capabilities }
+
+// Path is the plugin's executable.
+func (a *Adapter) Path() string { return a.path }
+
+// Available is what the plugin reported in its handshake; plugins that
+// don't say are available.
+func (a *Adapter) Available() (bool, string) {
+	if a.info.Available != nil && !*a.info.Available {
+		return false, a.info.Reason
+	}
+	return true, ""
+}
+
+// Flights reports whether the plugin searches flights.
+func (a *Adapter) Flights() bool {
+	return slices.Contains(a.info.Capabilities, core.CapFlightsSearch)
+}
+
+// Stays returns the plugin as a stay adapter, one that looks up
+// properties if it lists stays.lookup, or nil if it doesn't search stays.
+func (a *Adapter) Stays() core.StayAdapter {
+	switch {
+	case slices.Contains(a.info.Capabilities, core.CapStaysLookup) && slices.Contains(a.info.Capabilities, core.CapStaysSearch):
+		return lookupAdapter{a}
+	case slices.Contains(a.info.Capabilities, core.CapStaysSearch):
+		return a
+	}
+	return nil
+}
+
+func (a *Adapter) SearchFlights(req core.FlightSearchRequest) ([]core.FlightOffer, error) {
+	resp, err := a.search(core.CapFlightsSearch, req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for i := range resp.Flights {
+		f := &resp.Flights[i]
+		f.Source = a.Name()
+		if f.FetchedAt.IsZero() {
+			f.FetchedAt = now
+		}
+		if f.ID == "" {
+			f.ID = core.StableFlightID(*f)
+		}
+	}
+	return resp.Flights, nil
+}
+
+func (a *Adapter) SearchStays(req core.StaySearchRequest) ([]core.StayOffer, error) {
+	resp, err := a.search(core.CapStaysSearch, req)
+	if err != nil {
+		return nil, err
+	}
+	return a.stays(resp.Stays), nil
+}
+
+type lookupAdapter struct{ *Adapter }
+
+func (a lookupAdapter) LookupStays(req core.StayLookupRequest) ([]core.StayOffer, error) {
+	resp, err := a.search(core.CapStaysLookup, req)
+	if err != nil {
+		return nil, err
+	}
+	return a.stays(resp.Stays), nil
+}
+
+// stays fills in what the protocol lets a plugin leave out of its offers:
+// the source, the fetch time, and the ID.
+func (a *Adapter) stays(offers []core.StayOffer) []core.StayOffer {
+	now := time.Now().UTC()
+	for i := range offers {
+		s := &offers[i]
+		s.Source = a.Name()
+		if s.FetchedAt.IsZero() {
+			s.FetchedAt = now
+		}
+		if s.ID == "" {
+			s.ID = core.StableStayID(*s)
+		}
+	}
+	return offers
+}
+
+func (a *Adapter) search(capability core.Capability, req interface{}) (*searchResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), searchTimeout)
+	defer cancel()
+	resp, err := a.conn.search(ctx, capability, req)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", filepath.Base(a.path), err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
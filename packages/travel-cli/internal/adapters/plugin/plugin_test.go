@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// writePlugin installs a shell script answering capabilities with caps
+// and searches with search.
+func writePlugin(t *testing.T, dir, file, caps, search string) {
+	t.Helper()
+	script := "#!/bin/sh\nreq=$(cat)\ncase \"$req\" in\n" +
+		"*'\"capabilities\"'*) echo '" + caps + "' ;;\n" +
+		"*) " + search + " ;;\nesac\n"
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme", `{"name":"acme","capabilities":["flights.search","stays.search","stays.lookup"]}`,
+		`echo '{"flights":[{"airline":"Acme Air","from":"YUL","to":"CDG","departTime":"2026-06-12T08:00:00Z","priceUSD":410}]}'`)
+	writePlugin(t, dir, "broken", `{"name":"broken","capabilities":["stays.search"],"available":false,"reason":"set BROKEN_KEY"}`,
+		`echo 'no such city' >&2; exit 1`)
+	writePlugin(t, dir, "impostor", `{"name":"mock_flights","capabilities":["flights.search"]}`, `true`)
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	adapters := LoadDir(dir, 0)
+	if len(adapters) != 2 || adapters[0].Name() != "acme" || adapters[1].Name() != "broken" {
+		t.Fatalf("loaded %d adapters, want acme and broken", len(adapters))
+	}
+	acme, broken := adapters[0], adapters[1]
+	if acme.Tier() != core.TierEasySignup || !acme.Flights() {
+		t.Errorf("acme: tier %s, flights %v", acme.Tier(), acme.Flights())
+	}
+	if _, ok := acme.Stays().(core.PropertyLookupAdapter); !ok {
+		t.Error("acme lists stays.lookup but can't look up properties")
+	}
+	if _, ok := broken.Stays().(core.PropertyLookupAdapter); ok || broken.Flights() {
+		t.Error("broken only searches stays")
+	}
+	if ok, reason := broken.Available(); ok || reason != "set BROKEN_KEY" {
+		t.Errorf("broken available = %v %q", ok, reason)
+	}
+
+	flights, err := acme.SearchFlights(core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flights) != 1 || flights[0].Source != "acme" || flights[0].ID == "" || flights[0].FetchedAt.IsZero() {
+		t.Errorf("flights = %+v, want the source, ID, and fetch time filled in", flights)
+	}
+
+	_, err = broken.SearchStays(core.StaySearchRequest{City: "Atlantis"})
+	if err == nil || err.Error() != "plugin broken: no such city" {
+		t.Errorf("err = %v, want the plugin's stderr", err)
+	}
+}
+
+func TestLoadDirReusesSavedHandshakes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "asked")
+	script := "#!/bin/sh\ntouch " + marker + "\necho '{\"name\":\"acme\",\"capabilities\":[\"flights.search\"]}'\n"
+	if err := os.WriteFile(filepath.Join(dir, "acme"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writePlugin(t, dir, "off", `{"name":"off","capabilities":["flights.search"],"available":false}`, `true`)
+
+	if got := LoadDir(dir, time.Hour); len(got) != 2 {
+		t.Fatalf("loaded %d adapters, want 2", len(got))
+	}
+	if err := os.Remove(marker); err != nil {
+		t.Fatal("acme wasn't asked for its capabilities:", err)
+	}
+
+	// A new process has no handshakes in memory.
+	handshakes.Clear()
+	got := LoadDir(dir, time.Hour)
+	if len(got) != 2 || got[0].Name() != "acme" {
+		t.Fatalf("loaded %v, want acme from the cache file", got)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("acme was asked again within the ttl")
+	}
+
+	handshakes.Clear()
+	LoadDir(dir, 0)
+	if _, err := os.Stat(marker); err != nil {
+		t.Error("acme wasn't asked again with no ttl")
+	}
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+)
+
+// FlightAdapter presents one of the broker's flight adapters as an SDK
+// one, so pkg/travelsdk/adaptertest can check it.
+func FlightAdapter(a core.FlightAdapter) travelsdk.FlightAdapter { return flightAdapter{a} }
+
+// StayAdapter is the stay counterpart of FlightAdapter.
+func StayAdapter(a core.StayAdapter) travelsdk.StayAdapter { return stayAdapter{a} }
+
+type flightAdapter struct{ a core.FlightAdapter }
+
+func (f flightAdapter) Name() string                         { return f.a.Name() }
+func (f flightAdapter) Tier() travelsdk.ProviderTier         { return travelsdk.ProviderTier(f.a.Tier()) }
+func (f flightAdapter) Capabilities() []travelsdk.Capability { return capabilities(f.a.Capabilities()) }
+func (f flightAdapter) Available() (bool, string)            { return f.a.Available() }
+
+func (f flightAdapter) SearchFlights(req travelsdk.FlightSearchRequest) ([]travelsdk.FlightOffer, error) {
+	offers, err := f.a.SearchFlights(core.FlightSearchRequest{
+		From:        req.From,
+		To:          req.To,
+		DepartDate:  req.DepartDate,
+		ReturnDate:  req.ReturnDate,
+		Adults:      req.Adults,
+		CabinClass:  req.CabinClass,
+		MaxResults:  req.MaxResults,
+		Alliance:    req.Alliance,
+		Wifi:        req.Wifi,
+		MaxStops:    req.MaxStops,
+		MaxPriceUSD: req.MaxPriceUSD,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]travelsdk.FlightOffer, len(offers))
+	for i, o := range offers {
+		out[i] = travelsdk.FlightOffer{
+			ID:                   o.ID,
+			Source:               o.Source,
+			Airline:              o.Airline,
+			FlightNumber:         o.FlightNumber,
+			From:                 o.From,
+			To:                   o.To,
+			DepartTime:           o.DepartTime,
+			ArriveTime:           o.ArriveTime,
+			DurationMinutes:      o.DurationMinutes,
+			Stops:                o.Stops,
+			CabinClass:           o.CabinClass,
+			PriceUSD:             o.PriceUSD,
+			Currency:             o.Currency,
+			DeepLink:             o.DeepLink,
+			Confidence:           o.Confidence,
+			IsBookable:           o.IsBookable,
+			RepriceRequired:      o.RepriceRequired,
+			FetchedAt:            o.FetchedAt,
+			ExpiresAt:            o.ExpiresAt,
+			PriceGuaranteedUntil: o.PriceGuaranteedUntil,
+			WifiAvailable:        o.WifiAvailable,
+			SeatPitch:            o.SeatPitch,
+		}
+		if o.OperatedBy != nil {
+			out[i].OperatedBy = &travelsdk.Carrier{Code: o.OperatedBy.Code, Name: o.OperatedBy.Name}
+		}
+		if o.Aircraft != nil {
+			out[i].Aircraft = &travelsdk.Aircraft{Code: o.Aircraft.Code, Name: o.Aircraft.Name}
+		}
+	}
+	return out, nil
+}
+
+type stayAdapter struct{ a core.StayAdapter }
+
+func (s stayAdapter) Name() string                         { return s.a.Name() }
+func (s stayAdapter) Tier() travelsdk.ProviderTier         { return travelsdk.ProviderTier(s.a.Tier()) }
+func (s stayAdapter) Capabilities() []travelsdk.Capability { return capabilities(s.a.Capabilities()) }
+func (s stayAdapter) Available() (bool, string)            { return s.a.Available() }
+
+func (s stayAdapter) SearchStays(req travelsdk.StaySearchRequest) ([]travelsdk.StayOffer, error) {
+	offers, err := s.a.SearchStays(core.StaySearchRequest{
+		City:         req.City,
+		CheckIn:      req.CheckIn,
+		CheckOut:     req.CheckOut,
+		Guests:       req.Guests,
+		ChildrenAges: req.ChildrenAges,
+		Rooms:        req.Rooms,
+		MaxResults:   req.MaxResults,
+		StayType:     req.StayType,
+		MaxPriceUSD:  req.MaxPriceUSD,
+		Near:         req.Near,
+		RadiusKm:     req.RadiusKm,
+		Neighborhood: req.Neighborhood,
+		MinRating:    req.MinRating,
+		MinReviews:   req.MinReviews,
+		Breakfast:    req.Breakfast,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]travelsdk.StayOffer, len(offers))
+	for i, o := range offers {
+		out[i] = travelsdk.StayOffer{
+			ID:                   o.ID,
+			Source:               o.Source,
+			Name:                 o.Name,
+			PropertyID:           o.PropertyID,
+			Chain:                o.Chain,
+			Type:                 o.Type,
+			City:                 o.City,
+			Address:              o.Address,
+			Latitude:             o.Latitude,
+			Longitude:            o.Longitude,
+			Neighborhood:         o.Neighborhood,
+			CheckIn:              o.CheckIn,
+			CheckOut:             o.CheckOut,
+			NightsCount:          o.NightsCount,
+			PricePerNight:        o.PricePerNight,
+			TotalPriceUSD:        o.TotalPriceUSD,
+			Currency:             o.Currency,
+			Rating:               o.Rating,
+			ReviewCount:          o.ReviewCount,
+			Amenities:            o.Amenities,
+			DeepLink:             o.DeepLink,
+			Confidence:           o.Confidence,
+			IsBookable:           o.IsBookable,
+			RepriceRequired:      o.RepriceRequired,
+			FetchedAt:            o.FetchedAt,
+			Board:                o.Board,
+			ChildrenPriceUSD:     o.ChildrenPriceUSD,
+			ExpiresAt:            o.ExpiresAt,
+			PriceGuaranteedUntil: o.PriceGuaranteedUntil,
+		}
+	}
+	return out, nil
+}
+
+func capabilities(caps []core.Capability) []travelsdk.Capability {
+	out := make([]travelsdk.Capability, len(caps))
+	for i, c := range caps {
+		out[i] = travelsdk.Capability(c)
+	}
+	return out
+}
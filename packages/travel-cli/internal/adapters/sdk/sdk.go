@@ -0,0 +1,140 @@
+// Package sdk converts between the adapter SDK's types in pkg/travelsdk
+// and the broker's own, where the broker calls an adapter written
+// against the SDK.
+package sdk
+
+import (
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+)
+
+// FlightRequest is the SDK form of a flight search.
+func FlightRequest(r core.FlightSearchRequest) travelsdk.FlightSearchRequest {
+	return travelsdk.FlightSearchRequest{
+		From:        r.From,
+		To:          r.To,
+		DepartDate:  r.DepartDate,
+		ReturnDate:  r.ReturnDate,
+		Adults:      r.Adults,
+		CabinClass:  r.CabinClass,
+		MaxResults:  r.MaxResults,
+		Alliance:    r.Alliance,
+		Wifi:        r.Wifi,
+		MaxStops:    r.MaxStops,
+		MaxPriceUSD: r.MaxPriceUSD,
+	}
+}
+
+// StayRequest is the SDK form of a stay search.
+func StayRequest(r core.StaySearchRequest) travelsdk.StaySearchRequest {
+	return travelsdk.StaySearchRequest{
+		City:         r.City,
+		CheckIn:      r.CheckIn,
+		CheckOut:     r.CheckOut,
+		Guests:       r.Guests,
+		ChildrenAges: r.ChildrenAges,
+		Rooms:        r.Rooms,
+		MaxResults:   r.MaxResults,
+		StayType:     r.StayType,
+		MaxPriceUSD:  r.MaxPriceUSD,
+		Near:         r.Near,
+		RadiusKm:     r.RadiusKm,
+		Neighborhood: r.Neighborhood,
+		MinRating:    r.MinRating,
+		MinReviews:   r.MinReviews,
+		Breakfast:    r.Breakfast,
+	}
+}
+
+// LookupRequest is the SDK form of a property lookup.
+func LookupRequest(r core.StayLookupRequest) travelsdk.StayLookupRequest {
+	return travelsdk.StayLookupRequest(r)
+}
+
+// Flights converts the offers an SDK adapter returned.
+func Flights(offers []travelsdk.FlightOffer) []core.FlightOffer {
+	if offers == nil {
+		return nil
+	}
+	out := make([]core.FlightOffer, len(offers))
+	for i, f := range offers {
+		out[i] = core.FlightOffer{
+			ID:                   f.ID,
+			Source:               f.Source,
+			Airline:              f.Airline,
+			FlightNumber:         f.FlightNumber,
+			From:                 f.From,
+			To:                   f.To,
+			DepartTime:           f.DepartTime,
+			ArriveTime:           f.ArriveTime,
+			DurationMinutes:      f.DurationMinutes,
+			Stops:                f.Stops,
+			CabinClass:           f.CabinClass,
+			PriceUSD:             f.PriceUSD,
+			Currency:             f.Currency,
+			DeepLink:             f.DeepLink,
+			Confidence:           f.Confidence,
+			IsBookable:           f.IsBookable,
+			RepriceRequired:      f.RepriceRequired,
+			FetchedAt:            f.FetchedAt,
+			ExpiresAt:            f.ExpiresAt,
+			PriceGuaranteedUntil: f.PriceGuaranteedUntil,
+			Aircraft:             aircraft(f.Aircraft),
+			WifiAvailable:        f.WifiAvailable,
+			SeatPitch:            f.SeatPitch,
+		}
+		if f.OperatedBy != nil {
+			out[i].OperatedBy = &core.Carrier{Code: f.OperatedBy.Code, Name: f.OperatedBy.Name}
+		}
+	}
+	return out
+}
+
+// Stays converts the offers an SDK adapter returned.
+func Stays(offers []travelsdk.StayOffer) []core.StayOffer {
+	if offers == nil {
+		return nil
+	}
+	out := make([]core.StayOffer, len(offers))
+	for i, s := range offers {
+		out[i] = core.StayOffer{
+			ID:                   s.ID,
+			Source:               s.Source,
+			Name:                 s.Name,
+			PropertyID:           s.PropertyID,
+			Chain:                s.Chain,
+			Type:                 s.Type,
+			City:                 s.City,
+			Address:              s.Address,
+			Latitude:             s.Latitude,
+			Longitude:            s.Longitude,
+			Neighborhood:         s.Neighborhood,
+			CheckIn:              s.CheckIn,
+			CheckOut:             s.CheckOut,
+			NightsCount:          s.NightsCount,
+			PricePerNight:        s.PricePerNight,
+			TotalPriceUSD:        s.TotalPriceUSD,
+			Currency:             s.Currency,
+			Rating:               s.Rating,
+			ReviewCount:          s.ReviewCount,
+			Amenities:            s.Amenities,
+			DeepLink:             s.DeepLink,
+			Confidence:           s.Confidence,
+			IsBookable:           s.IsBookable,
+			RepriceRequired:      s.RepriceRequired,
+			FetchedAt:            s.FetchedAt,
+			Board:                s.Board,
+			ChildrenPriceUSD:     s.ChildrenPriceUSD,
+			ExpiresAt:            s.ExpiresAt,
+			PriceGuaranteedUntil: s.PriceGuaranteedUntil,
+		}
+	}
+	return out
+}
+
+func aircraft(a *travelsdk.Aircraft) *core.Aircraft {
+	if a == nil {
+		return nil
+	}
+	return &core.Aircraft{Code: a.Code, Name: a.Name}
+}
@@ -0,0 +1,81 @@
+// Package aircraft serves a reference list of the aircraft types airlines
+// fly most (IATA and ICAO type codes, name, widebody flag, typical
+// economy seat pitch) embedded in the binary, so offers from different
+// providers name aircraft the same way.
+package aircraft
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed aircraft.json
+var rawDataset []byte
+
+type Aircraft struct {
+	// Code is the three-character IATA type code, and ICAO the ICAO
+	// type designator.
+	Code     string `json:"code"`
+	ICAO     string `json:"icao"`
+	Name     string `json:"name"`
+	Widebody bool   `json:"widebody,omitempty"`
+	// SeatPitch is the typical economy seat pitch in inches; airlines
+	// fit their cabins differently.
+	SeatPitch int `json:"seatPitch"`
+	// Aliases are other names providers use for the type.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+var ErrNotFound = errors.New("aircraft not found")
+
+var (
+	loadOnce sync.Once
+	all      []Aircraft
+	byCode   map[string]*Aircraft
+	byName   map[string]*Aircraft
+	loadErr  error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &all); err != nil {
+			loadErr = fmt.Errorf("decode embedded aircraft: %w", err)
+			return
+		}
+		byCode = make(map[string]*Aircraft, len(all)*2)
+		byName = make(map[string]*Aircraft, len(all)*2)
+		for i := range all {
+			a := &all[i]
+			byCode[a.Code] = a
+			byCode[a.ICAO] = a
+			byName[key(a.Name)] = a
+			for _, alias := range a.Aliases {
+				byName[key(alias)] = a
+			}
+		}
+	})
+	return loadErr
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Lookup finds an aircraft type by IATA code, ICAO designator, name, or
+// alias, ignoring case.
+func Lookup(s string) (*Aircraft, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	if a, ok := byCode[strings.ToUpper(strings.TrimSpace(s))]; ok {
+		return a, nil
+	}
+	if a, ok := byName[key(s)]; ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrNotFound, s)
+}
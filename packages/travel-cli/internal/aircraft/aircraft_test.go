@@ -0,0 +1,41 @@
+package aircraft
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	for _, s := range []string{"789", "b789", "Boeing 787-9", " boeing  787-9 "} {
+		a, err := Lookup(s)
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", s, err)
+		}
+		if a.Code != "789" || !a.Widebody || a.SeatPitch != 31 {
+			t.Errorf("Lookup(%q) = %+v", s, a)
+		}
+	}
+	if a, err := Lookup("Q400"); err != nil || a.Code != "DH4" || a.Widebody {
+		t.Errorf("Lookup(Q400) = %v, %v", a, err)
+	}
+	if _, err := Lookup("Concorde"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDatasetComplete(t *testing.T) {
+	if err := load(); err != nil {
+		t.Fatal(err)
+	}
+	iata := regexp.MustCompile(`^[A-Z0-9]{3}$`)
+	icao := regexp.MustCompile(`^[A-Z][A-Z0-9]{2,3}$`)
+	for _, a := range all {
+		if !iata.MatchString(a.Code) || !icao.MatchString(a.ICAO) || a.Name == "" || a.SeatPitch < 28 || a.SeatPitch > 34 {
+			t.Errorf("%s is missing required fields: %+v", a.Code, a)
+		}
+	}
+	if len(byCode) != 2*len(all) {
+		t.Errorf("%d codes for %d aircraft; codes repeat", len(byCode), len(all))
+	}
+}
@@ -0,0 +1,125 @@
+// Package airlines serves a reference list of airlines (IATA and ICAO
+// codes, name, alliance, low-cost flag) embedded in the binary, so offers
+// from different providers name carriers the same way and can be
+// filtered by alliance offline.
+package airlines
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed airlines.json
+var rawDataset []byte
+
+// The alliances airlines belong to, as --alliance takes them.
+const (
+	StarAlliance = "star"
+	Oneworld     = "oneworld"
+	SkyTeam      = "skyteam"
+)
+
+// Alliances lists every alliance in the dataset.
+var Alliances = []string{StarAlliance, Oneworld, SkyTeam}
+
+type Airline struct {
+	// Code is the two-character IATA designator flight numbers start
+	// with, and ICAO the three-letter code.
+	Code string `json:"code"`
+	ICAO string `json:"icao"`
+	Name string `json:"name"`
+	// Alliance is StarAlliance, Oneworld, or SkyTeam, or empty for
+	// airlines outside all three.
+	Alliance string `json:"alliance,omitempty"`
+	LowCost  bool   `json:"lowCost,omitempty"`
+	// Aliases are other names providers use for the airline.
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+var ErrNotFound = errors.New("airline not found")
+
+var (
+	loadOnce sync.Once
+	all      []Airline
+	byCode   map[string]*Airline
+	byICAO   map[string]*Airline
+	byName   map[string]*Airline
+	loadErr  error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &all); err != nil {
+			loadErr = fmt.Errorf("decode embedded airlines: %w", err)
+			return
+		}
+		byCode = make(map[string]*Airline, len(all))
+		byICAO = make(map[string]*Airline, len(all))
+		byName = make(map[string]*Airline, len(all)*2)
+		for i := range all {
+			a := &all[i]
+			byCode[a.Code] = a
+			byICAO[a.ICAO] = a
+			byName[key(a.Name)] = a
+			for _, alias := range a.Aliases {
+				byName[key(alias)] = a
+			}
+		}
+	})
+	return loadErr
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Lookup finds an airline by IATA code, ICAO code, name, or alias,
+// ignoring case.
+func Lookup(s string) (*Airline, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if a, ok := byCode[code]; ok {
+		return a, nil
+	}
+	if a, ok := byICAO[code]; ok {
+		return a, nil
+	}
+	if a, ok := byName[key(s)]; ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrNotFound, s)
+}
+
+// ByFlightNumber finds the airline whose designator a flight number such
+// as AC870 or U2 8512 starts with.
+func ByFlightNumber(number string) (*Airline, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	number = strings.ToUpper(strings.TrimSpace(number))
+	if len(number) > 2 {
+		if a, ok := byCode[number[:2]]; ok {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no airline for flight %q", ErrNotFound, number)
+}
+
+// ParseAlliance reads an alliance name as --alliance takes it; "Star
+// Alliance" and "SkyTeam" are read too.
+func ParseAlliance(s string) (string, error) {
+	name := strings.ReplaceAll(key(s), " ", "")
+	name = strings.TrimSuffix(name, "alliance")
+	for _, a := range Alliances {
+		if name == a {
+			return a, nil
+		}
+	}
+	return "", fmt.Errorf("unknown alliance %q (use %s)", s, strings.Join(Alliances, ", "))
+}
@@ -0,0 +1,70 @@
+package airlines
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	for _, s := range []string{"AC", "aca", "Air Canada", " AIR  CANADA "} {
+		a, err := Lookup(s)
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", s, err)
+		}
+		if a.Code != "AC" || a.Alliance != StarAlliance || a.LowCost {
+			t.Errorf("Lookup(%q) = %+v", s, a)
+		}
+	}
+	if a, err := Lookup("Delta"); err != nil || a.Name != "Delta Air Lines" {
+		t.Errorf("Lookup(Delta) = %v, %v", a, err)
+	}
+	if _, err := Lookup("Acme Air"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestByFlightNumber(t *testing.T) {
+	for number, want := range map[string]string{"AC870": "AC", "u2 8512": "U2", "6E2041": "6E"} {
+		a, err := ByFlightNumber(number)
+		if err != nil || a.Code != want {
+			t.Errorf("ByFlightNumber(%q) = %v, %v; want %s", number, a, err, want)
+		}
+	}
+	for _, number := range []string{"870", "ZZ12", "AC"} {
+		if _, err := ByFlightNumber(number); !errors.Is(err, ErrNotFound) {
+			t.Errorf("ByFlightNumber(%q): err = %v, want ErrNotFound", number, err)
+		}
+	}
+}
+
+func TestParseAlliance(t *testing.T) {
+	for s, want := range map[string]string{"star": StarAlliance, "Star Alliance": StarAlliance, "oneworld": Oneworld, "SkyTeam": SkyTeam} {
+		if got, err := ParseAlliance(s); err != nil || got != want {
+			t.Errorf("ParseAlliance(%q) = %q, %v; want %q", s, got, err, want)
+		}
+	}
+	if _, err := ParseAlliance("alliance"); err == nil {
+		t.Error("ParseAlliance(alliance) succeeded")
+	}
+}
+
+func TestDatasetComplete(t *testing.T) {
+	if err := load(); err != nil {
+		t.Fatal(err)
+	}
+	iata := regexp.MustCompile(`^[A-Z0-9]{2}$`)
+	icao := regexp.MustCompile(`^[A-Z]{3}$`)
+	for _, a := range all {
+		if !iata.MatchString(a.Code) || !icao.MatchString(a.ICAO) || a.Name == "" {
+			t.Errorf("%s is missing required fields: %+v", a.Code, a)
+		}
+		if a.Alliance != "" && !slices.Contains(Alliances, a.Alliance) {
+			t.Errorf("%s: unknown alliance %q", a.Code, a.Alliance)
+		}
+	}
+	if len(byCode) != len(all) || len(byICAO) != len(all) {
+		t.Errorf("%d IATA and %d ICAO codes for %d airlines; codes repeat", len(byCode), len(byICAO), len(all))
+	}
+}
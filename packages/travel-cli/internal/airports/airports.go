@@ -0,0 +1,240 @@
+// Package airports serves a reference list of the major IATA airports
+// (name, city, country, coordinates, timezone) embedded in the binary, so
+// searches can check codes, read city names as airports, and widen to
+// nearby airports offline.
+package airports
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/destinations"
+)
+
+//go:embed airports.json
+var rawDataset []byte
+
+type Airport struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	City string `json:"city"`
+	// CityCode is the IATA code of the metropolitan area the airport
+	// serves, such as PAR for CDG and ORY, when it has several airports.
+	CityCode    string  `json:"cityCode,omitempty"`
+	CountryCode string  `json:"countryCode"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Timezone    string  `json:"timezone"`
+}
+
+// Nearby is an airport and its distance from where a search for nearby
+// airports started.
+type Nearby struct {
+	Airport
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+// Match is a city a place name may mean.
+type Match struct {
+	// Code is what to search the city as: its metropolitan code, or its
+	// main airport's when it has none.
+	Code        string `json:"code"`
+	City        string `json:"city"`
+	CountryCode string `json:"countryCode"`
+	// Airports are the airports Code covers, main airport first.
+	Airports []string `json:"airports"`
+}
+
+var ErrNotFound = errors.New("airport not found")
+
+var (
+	loadOnce sync.Once
+	all      []Airport
+	byCode   map[string]*Airport
+	byCity   map[string][]*Airport
+	byMetro  map[string][]*Airport
+	loadErr  error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &all); err != nil {
+			loadErr = fmt.Errorf("decode embedded airports: %w", err)
+			return
+		}
+		byCode = make(map[string]*Airport, len(all))
+		byCity = make(map[string][]*Airport)
+		byMetro = make(map[string][]*Airport)
+		for i := range all {
+			a := &all[i]
+			byCode[a.Code] = a
+			byCity[key(a.City)] = append(byCity[key(a.City)], a)
+			if a.CityCode != "" {
+				byMetro[a.CityCode] = append(byMetro[a.CityCode], a)
+			}
+		}
+	})
+	return loadErr
+}
+
+// accents folds the accented letters of city names, so "Montréal" and
+// "Montreal" are the same city.
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func key(s string) string {
+	return accents.Replace(strings.ToLower(strings.Join(strings.Fields(s), " ")))
+}
+
+// Lookup finds an airport by IATA code, ignoring case.
+func Lookup(code string) (*Airport, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	a, ok := byCode[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, code)
+	}
+	return a, nil
+}
+
+// InCity lists the airports of a metropolitan code, or of every city
+// with a name, likeliest city and main airports first.
+func InCity(city string) ([]Airport, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	var airports []Airport
+	if metro := byMetro[strings.ToUpper(strings.TrimSpace(city))]; len(metro) > 0 {
+		for _, a := range metro {
+			airports = append(airports, *a)
+		}
+		return airports, nil
+	}
+	matches, err := Cities(city)
+	if err != nil {
+		return nil, fmt.Errorf("%w: no airports in %q", ErrNotFound, city)
+	}
+	for _, m := range matches {
+		for _, code := range m.Airports {
+			airports = append(airports, *byCode[code])
+		}
+	}
+	return airports, nil
+}
+
+// named returns the airports of the cities called name.
+func named(name string) []*Airport {
+	found := byCity[key(name)]
+	if len(found) == 0 {
+		// The destinations guide knows other spellings of its cities.
+		if d, err := destinations.Lookup(name); err == nil {
+			found = byCity[key(d.Name)]
+		}
+	}
+	return found
+}
+
+// Cities lists the cities called name, likeliest first: those with more
+// airports, then in dataset order. Case, accents, and the spellings the
+// destinations guide knows don't matter.
+func Cities(name string) ([]Match, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	var matches []Match
+	for _, a := range named(name) {
+		i := slices.IndexFunc(matches, func(m Match) bool { return m.CountryCode == a.CountryCode })
+		if i >= 0 {
+			if a.CityCode == "" {
+				matches[i].Airports = append(matches[i].Airports, a.Code)
+			}
+			continue
+		}
+		m := Match{Code: a.Code, City: a.City, CountryCode: a.CountryCode, Airports: []string{a.Code}}
+		if a.CityCode != "" {
+			m.Code, m.Airports = a.CityCode, nil
+			for _, b := range byMetro[a.CityCode] {
+				m.Airports = append(m.Airports, b.Code)
+			}
+		}
+		matches = append(matches, m)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: no city called %q", ErrNotFound, name)
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return len(matches[i].Airports) > len(matches[j].Airports) })
+	return matches, nil
+}
+
+// Find reads place as an airport code, a metropolitan code, or a city,
+// and returns the main airport of the latter two.
+func Find(place string) (*Airport, error) {
+	if a, err := Lookup(place); err == nil || !errors.Is(err, ErrNotFound) {
+		return a, err
+	}
+	if metro := byMetro[strings.ToUpper(strings.TrimSpace(place))]; len(metro) > 0 {
+		return metro[0], nil
+	}
+	matches, err := Cities(place)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q is neither an airport code nor a city with airports", ErrNotFound, place)
+	}
+	return Lookup(matches[0].Airports[0])
+}
+
+// Near lists the airports within radiusKm of a, a itself included,
+// nearest first.
+func Near(a *Airport, radiusKm float64) ([]Nearby, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	var near []Nearby
+	for _, b := range all {
+		if d := Distance(a, &b); d <= radiusKm {
+			near = append(near, Nearby{Airport: b, DistanceKm: math.Round(d*10) / 10})
+		}
+	}
+	sort.SliceStable(near, func(i, j int) bool { return near[i].DistanceKm < near[j].DistanceKm })
+	return near, nil
+}
+
+// Distance is the great-circle distance between two airports in km.
+func Distance(a, b *Airport) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// Complete lists the airports whose code or city starts with prefix,
+// ignoring case, in dataset order.
+func Complete(prefix string) ([]Airport, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	prefix = key(prefix)
+	var matches []Airport
+	for _, a := range all {
+		if strings.HasPrefix(strings.ToLower(a.Code), prefix) || strings.HasPrefix(key(a.City), prefix) {
+			matches = append(matches, a)
+		}
+	}
+	return matches, nil
+}
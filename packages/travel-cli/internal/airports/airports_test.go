@@ -0,0 +1,171 @@
+package airports
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/destinations"
+)
+
+func TestLookup(t *testing.T) {
+	for _, code := range []string{"CDG", " cdg "} {
+		a, err := Lookup(code)
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", code, err)
+		}
+		if a.City != "Paris" || a.CountryCode != "FR" || a.Timezone != "Europe/Paris" {
+			t.Errorf("Lookup(%q) = %+v", code, a)
+		}
+	}
+	if _, err := Lookup("XXX"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestInCity(t *testing.T) {
+	for city, want := range map[string][]string{
+		"LON":      {"LHR", "LGW", "STN", "LTN", "LCY"},
+		"London":   {"LHR", "LGW", "STN", "LTN", "LCY", "YXU"},
+		"nyc":      {"JFK", "LGA", "EWR"},
+		"Montreal": {"YUL", "YHU"},
+		"Lisboa":   {"LIS"}, // a spelling the destinations guide knows
+	} {
+		found, err := InCity(city)
+		if err != nil {
+			t.Fatalf("InCity(%q): %v", city, err)
+		}
+		var codes []string
+		for _, a := range found {
+			codes = append(codes, a.Code)
+		}
+		if len(codes) != len(want) || codes[0] != want[0] {
+			t.Errorf("InCity(%q) = %v, want %v", city, codes, want)
+		}
+	}
+}
+
+func TestCities(t *testing.T) {
+	for name, want := range map[string][]Match{
+		"Paris":    {{Code: "PAR", City: "Paris", CountryCode: "FR", Airports: []string{"CDG", "ORY", "BVA"}}},
+		"montréal": {{Code: "YMQ", City: "Montreal", CountryCode: "CA", Airports: []string{"YUL", "YHU"}}},
+		"Bangkok":  {{Code: "BKK", City: "Bangkok", CountryCode: "TH", Airports: []string{"BKK", "DMK"}}},
+		"London": {
+			{Code: "LON", City: "London", CountryCode: "GB", Airports: []string{"LHR", "LGW", "STN", "LTN", "LCY"}},
+			{Code: "YXU", City: "London", CountryCode: "CA", Airports: []string{"YXU"}},
+		},
+		"San Jose": {
+			{Code: "SJC", City: "San Jose", CountryCode: "US", Airports: []string{"SJC"}},
+			{Code: "SJO", City: "San José", CountryCode: "CR", Airports: []string{"SJO"}},
+		},
+	} {
+		got, err := Cities(name)
+		if err != nil {
+			t.Fatalf("Cities(%q): %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Cities(%q) = %+v, want %+v", name, got, want)
+		}
+	}
+	if _, err := Cities("Atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	for place, want := range map[string]string{"ORY": "ORY", "Montreal": "YUL", "PAR": "CDG", "mexico city": "MEX"} {
+		a, err := Find(place)
+		if err != nil || a.Code != want {
+			t.Errorf("Find(%q) = %v, %v; want %s", place, a, err, want)
+		}
+	}
+	if _, err := Find("Atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestNear(t *testing.T) {
+	yul, _ := Lookup("YUL")
+	near, err := Near(yul, 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, n := range near {
+		codes = append(codes, n.Code)
+	}
+	want := []string{"YUL", "YHU", "PBG", "BTV"}
+	if len(codes) != len(want) {
+		t.Fatalf("Near(YUL, 150) = %v, want %v", codes, want)
+	}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Fatalf("Near(YUL, 150) = %v, want %v", codes, want)
+		}
+	}
+	if near[0].DistanceKm != 0 || near[1].DistanceKm < 20 || near[1].DistanceKm > 30 {
+		t.Errorf("distances = %v, %v", near[0].DistanceKm, near[1].DistanceKm)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	jfk, _ := Lookup("JFK")
+	lhr, _ := Lookup("LHR")
+	// The great-circle distance from JFK to Heathrow is about 5540 km.
+	if d := Distance(jfk, lhr); math.Abs(d-5540) > 20 {
+		t.Errorf("Distance(JFK, LHR) = %.0f km", d)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	matches, err := Complete("lo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range matches {
+		if a.City != "London" && a.City != "Los Angeles" && a.City != "Long Beach" && a.Code != "LOS" {
+			t.Errorf("Complete(lo) matched %s (%s)", a.Code, a.City)
+		}
+	}
+	if len(matches) < 7 {
+		t.Errorf("Complete(lo) = %d airports", len(matches))
+	}
+}
+
+func TestDatasetComplete(t *testing.T) {
+	if err := load(); err != nil {
+		t.Fatal(err)
+	}
+	code := regexp.MustCompile(`^[A-Z]{3}$`)
+	for _, a := range all {
+		if !code.MatchString(a.Code) || a.Name == "" || a.City == "" || len(a.CountryCode) != 2 {
+			t.Errorf("%s is missing required fields: %+v", a.Code, a)
+		}
+		if a.Latitude < -90 || a.Latitude > 90 || a.Longitude < -180 || a.Longitude > 180 || a.Latitude == 0 && a.Longitude == 0 {
+			t.Errorf("%s has coordinates out of range", a.Code)
+		}
+		if _, err := time.LoadLocation(a.Timezone); err != nil {
+			t.Errorf("%s: %v", a.Code, err)
+		}
+	}
+	if len(byCode) != len(all) {
+		t.Errorf("%d codes for %d airports; codes repeat", len(byCode), len(all))
+	}
+
+	// Every airport the destinations guide names is here.
+	names, err := destinations.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range names {
+		d, _ := destinations.Lookup(n)
+		for _, c := range d.Airports {
+			if _, err := Lookup(c); err != nil {
+				t.Errorf("%s: %v", n, err)
+			}
+		}
+	}
+}
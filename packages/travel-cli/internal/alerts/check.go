@@ -0,0 +1,114 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// Searcher is the subset of the orchestrator alert checks need.
+type Searcher interface {
+	SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error)
+	SearchStays(req core.StaySearchRequest) (*core.SearchResult, error)
+}
+
+// Trigger is an alert whose search came in at or below its threshold.
+type Trigger struct {
+	AlertID          string      `json:"alertId"`
+	Kind             string      `json:"kind"`
+	Query            interface{} `json:"query"`
+	MaxPriceUSD      float64     `json:"maxPriceUSD"`
+	PriceUSD         float64     `json:"priceUSD"`
+	PreviousPriceUSD float64     `json:"previousPriceUSD,omitempty"`
+	// New is false when the previous check had already triggered, so
+	// notifiers can skip repeats.
+	New    bool              `json:"new"`
+	Flight *core.FlightOffer `json:"flight,omitempty"`
+	Stay   *core.StayOffer   `json:"stay,omitempty"`
+	// Notify carries the alert's own destinations to notifiers.
+	Notify *Targets `json:"-"`
+}
+
+// DeepLink books the triggering offer.
+func (t Trigger) DeepLink() string {
+	switch {
+	case t.Flight != nil:
+		return t.Flight.DeepLink
+	case t.Stay != nil:
+		return t.Stay.DeepLink
+	}
+	return ""
+}
+
+type CheckError struct {
+	AlertID string `json:"alertId"`
+	Reason  string `json:"reason"`
+}
+
+// Report is the outcome of checking a set of alerts.
+type Report struct {
+	CheckedAt time.Time    `json:"checkedAt"`
+	Checked   int          `json:"checked"`
+	Triggered []Trigger    `json:"triggered"`
+	Errors    []CheckError `json:"errors,omitempty"`
+}
+
+// Check re-runs each alert's search. It returns the report and the checked
+// alerts with their Last* fields updated, ready to be stored.
+func Check(searcher Searcher, alerts []Alert) (*Report, []Alert) {
+	now := time.Now().UTC()
+	report := &Report{CheckedAt: now, Triggered: []Trigger{}}
+	var checked []Alert
+
+	for _, a := range alerts {
+		result, err := run(searcher, a)
+		if err != nil {
+			report.Errors = append(report.Errors, CheckError{AlertID: a.ID, Reason: err.Error()})
+			continue
+		}
+		report.Checked++
+
+		trigger, ok := evaluate(a, result)
+		wasTriggered, previous := a.Triggered, a.LastPriceUSD
+		a.LastCheckedAt = now
+		a.Triggered = ok
+		if trigger.PriceUSD > 0 {
+			a.LastPriceUSD = trigger.PriceUSD
+			a.LastDeepLink = trigger.DeepLink()
+		}
+		if ok {
+			a.LastTriggeredAt = now
+			trigger.New = !wasTriggered
+			trigger.PreviousPriceUSD = previous
+			report.Triggered = append(report.Triggered, trigger)
+		}
+		checked = append(checked, a)
+	}
+	return report, checked
+}
+
+func run(searcher Searcher, a Alert) (*core.SearchResult, error) {
+	switch {
+	case a.Kind == KindFlights && a.Flight != nil:
+		return searcher.SearchFlights(*a.Flight)
+	case a.Kind == KindStays && a.Stay != nil:
+		return searcher.SearchStays(*a.Stay)
+	}
+	return nil, fmt.Errorf("alert %s has no %s search", a.ID, a.Kind)
+}
+
+// evaluate finds the cheapest offer the search found, including any its
+// maxResults left out, and reports whether it meets the alert's
+// threshold. The returned trigger is filled in either way so the observed
+// price can be recorded.
+func evaluate(a Alert, result *core.SearchResult) (Trigger, bool) {
+	t := Trigger{AlertID: a.ID, Kind: a.Kind, Query: result.Query, MaxPriceUSD: a.MaxPriceUSD, Notify: a.Notify}
+	if f := result.LowestFlight(); f != nil {
+		t.Flight, t.PriceUSD = f, f.PriceUSD
+	}
+	if s := result.LowestStay(); s != nil {
+		t.Stay, t.PriceUSD = s, s.PricePerNight
+	}
+	return t, t.PriceUSD > 0 && t.PriceUSD <= a.MaxPriceUSD
+}
@@ -0,0 +1,98 @@
+package alerts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+type fakeSearcher struct {
+	flights  []core.FlightOffer
+	cheapest *core.FlightOffer
+	err      error
+}
+
+func (f *fakeSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &core.SearchResult{Query: req, Flights: f.flights, CheapestFlight: f.cheapest}, nil
+}
+
+func (f *fakeSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{Query: req}, nil
+}
+
+func flightAlert(max float64) Alert {
+	return Alert{ID: "a_1", Kind: KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: max}
+}
+
+func TestCheckTriggersOnCheapestOffer(t *testing.T) {
+	s := &fakeSearcher{flights: []core.FlightOffer{{ID: "f_a", PriceUSD: 720}, {ID: "f_b", PriceUSD: 580}}}
+
+	report, checked := Check(s, []Alert{flightAlert(600)})
+	if report.Checked != 1 || len(report.Triggered) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	tr := report.Triggered[0]
+	if tr.PriceUSD != 580 || tr.Flight.ID != "f_b" || !tr.New {
+		t.Errorf("trigger = %+v", tr)
+	}
+	if !checked[0].Triggered || checked[0].LastPriceUSD != 580 || checked[0].LastTriggeredAt.IsZero() {
+		t.Errorf("alert not updated: %+v", checked[0])
+	}
+
+	// A second check below the threshold is reported but no longer new.
+	report, _ = Check(s, checked)
+	if len(report.Triggered) != 1 || report.Triggered[0].New || report.Triggered[0].PreviousPriceUSD != 580 {
+		t.Errorf("repeat trigger = %+v", report.Triggered)
+	}
+}
+
+func TestCheckSeesOffersBeyondMaxResults(t *testing.T) {
+	s := &fakeSearcher{flights: []core.FlightOffer{{ID: "f_a", PriceUSD: 720}}, cheapest: &core.FlightOffer{ID: "f_b", PriceUSD: 580}}
+
+	report, _ := Check(s, []Alert{flightAlert(600)})
+	if len(report.Triggered) != 1 || report.Triggered[0].Flight.ID != "f_b" {
+		t.Errorf("report = %+v, want a trigger on the cheaper offer left out of the result", report)
+	}
+}
+
+func TestCheckAboveThresholdAndErrors(t *testing.T) {
+	s := &fakeSearcher{flights: []core.FlightOffer{{ID: "f_a", PriceUSD: 720}}}
+	report, checked := Check(s, []Alert{flightAlert(600)})
+	if len(report.Triggered) != 0 || checked[0].Triggered || checked[0].LastPriceUSD != 720 {
+		t.Errorf("report = %+v, alert = %+v", report, checked[0])
+	}
+
+	report, checked = Check(&fakeSearcher{err: errors.New("boom")}, []Alert{flightAlert(600)})
+	if len(report.Errors) != 1 || report.Checked != 0 || len(checked) != 0 {
+		t.Errorf("errors should be reported and alert left untouched: %+v", report)
+	}
+}
+
+func TestStoreAddListRemove(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := flightAlert(600)
+	a.ID = ""
+	if err := store.Add(&a); err != nil || a.ID == "" {
+		t.Fatalf("Add: %v (id %q)", err, a.ID)
+	}
+	all, _ := store.List()
+	if len(all) != 1 || all[0].Flight.To != "CDG" {
+		t.Fatalf("List = %+v", all)
+	}
+	if err := store.Remove("a_missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove missing: %v", err)
+	}
+	if err := store.Remove(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := store.List(); len(all) != 0 {
+		t.Errorf("alert not removed: %+v", all)
+	}
+}
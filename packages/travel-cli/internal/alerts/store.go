@@ -0,0 +1,171 @@
+// Package alerts stores price alerts and checks them against fresh
+// searches.
+package alerts
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+const (
+	KindFlights = "flights"
+	KindStays   = "stays"
+)
+
+// Alert watches one search and triggers when its cheapest offer is at or
+// below MaxPriceUSD. For stays the threshold is per night, like
+// StaySearchRequest.MaxPriceUSD.
+type Alert struct {
+	ID          string                    `json:"id"`
+	Kind        string                    `json:"kind"`
+	Flight      *core.FlightSearchRequest `json:"flight,omitempty"`
+	Stay        *core.StaySearchRequest   `json:"stay,omitempty"`
+	MaxPriceUSD float64                   `json:"maxPriceUSD"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+
+	// Notify sends this alert's triggers to chat webhooks in addition to
+	// the globally configured sinks.
+	Notify *Targets `json:"notify,omitempty"`
+
+	LastCheckedAt time.Time `json:"lastCheckedAt,omitzero"`
+	LastPriceUSD  float64   `json:"lastPriceUSD,omitempty"`
+	// LastDeepLink books the cheapest offer seen by the latest check.
+	LastDeepLink    string    `json:"lastDeepLink,omitempty"`
+	LastTriggeredAt time.Time `json:"lastTriggeredAt,omitzero"`
+	// Triggered is whether the latest check was at or below the threshold.
+	Triggered bool `json:"triggered"`
+}
+
+// Targets are per-alert notification destinations: Slack and Discord
+// incoming-webhook URLs.
+type Targets struct {
+	Slack   string `json:"slack,omitempty"`
+	Discord string `json:"discord,omitempty"`
+}
+
+var ErrNotFound = errors.New("alert not found")
+
+// Store keeps all alerts in a single JSON file; alert counts are small.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create alerts dir: %w", err)
+	}
+	return &Store{path: filepath.Join(dir, "alerts.json")}, nil
+}
+
+func NewID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return "a_" + hex.EncodeToString(b)
+}
+
+func (s *Store) Add(a *Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if a.ID == "" {
+		a.ID = NewID()
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now().UTC()
+	}
+	return s.save(append(all, *a))
+}
+
+func (s *Store) List() ([]Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+func (s *Store) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	for i, a := range all {
+		if a.ID == id {
+			return s.save(append(all[:i], all[i+1:]...))
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Update replaces stored alerts with the same IDs, e.g. after a check.
+// Alerts removed in the meantime are not re-added.
+func (s *Store) Update(updated []Alert) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]Alert, len(updated))
+	for _, a := range updated {
+		byID[a.ID] = a
+	}
+	for i, a := range all {
+		if u, ok := byID[a.ID]; ok {
+			all[i] = u
+		}
+	}
+	return s.save(all)
+}
+
+func (s *Store) load() ([]Alert, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var all []Alert
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode alerts: %w", err)
+	}
+	return all, nil
+}
+
+func (s *Store) save(all []Alert) error {
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
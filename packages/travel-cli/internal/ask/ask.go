@@ -0,0 +1,92 @@
+// Package ask turns a travel question in plain words, such as "cheap
+// nonstop YUL to Lisbon first week of July, hotel under $150", into the
+// flight and stay searches that answer it. Questions are read by rules,
+// or by a model behind a configured chat endpoint with the rules as its
+// fallback.
+package ask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// Interpreters, as Query.Interpreter names them.
+const (
+	InterpreterRules = "rules"
+	InterpreterLLM   = "llm"
+)
+
+// Query is a question read as searches: a flight search, a stay search,
+// or both.
+type Query struct {
+	Text string `json:"text"`
+	// Interpreter is what read the question: rules or llm.
+	Interpreter string                    `json:"interpreter"`
+	Flight      *core.FlightSearchRequest `json:"flight,omitempty"`
+	Stay        *core.StaySearchRequest   `json:"stay,omitempty"`
+	// Assumptions are what the question left out and was filled in.
+	Assumptions []string `json:"assumptions,omitempty"`
+}
+
+// Validate checks that q asks for at least one complete search with dates
+// written as YYYY-MM-DD.
+func (q *Query) Validate() error {
+	if q.Flight == nil && q.Stay == nil {
+		return errors.New("found neither a flight nor a stay to search for")
+	}
+	if q.Flight != nil {
+		if err := q.Flight.Validate(); err != nil {
+			return fmt.Errorf("flight: %w", err)
+		}
+		if err := validDates(q.Flight.DepartDate, q.Flight.ReturnDate); err != nil {
+			return fmt.Errorf("flight: %w", err)
+		}
+	}
+	if q.Stay != nil {
+		if err := q.Stay.Validate(); err != nil {
+			return fmt.Errorf("stay: %w", err)
+		}
+		if err := validDates(q.Stay.CheckIn, q.Stay.CheckOut); err != nil {
+			return fmt.Errorf("stay: %w", err)
+		}
+	}
+	return nil
+}
+
+func validDates(dates ...string) error {
+	for _, d := range dates {
+		if _, err := time.Parse(dateLayout, d); d != "" && err != nil {
+			return fmt.Errorf("date %q is not YYYY-MM-DD", d)
+		}
+	}
+	return nil
+}
+
+// Interpreter reads questions as of today.
+type Interpreter interface {
+	Interpret(ctx context.Context, text string, today time.Time) (*Query, error)
+}
+
+// Interpret reads text with llm, or with the rules when llm is nil or
+// fails, noting why among the query's assumptions.
+func Interpret(ctx context.Context, llm Interpreter, text string, today time.Time) (*Query, error) {
+	if llm != nil {
+		q, err := llm.Interpret(ctx, text, today)
+		if err == nil {
+			return q, nil
+		}
+		logging.Logger().Warn("model could not read the question; using rules", "error", err)
+		q, rulesErr := Parse(text, today)
+		if rulesErr != nil {
+			return nil, rulesErr
+		}
+		q.Assumptions = append([]string{"read by rules: the model failed: " + err.Error()}, q.Assumptions...)
+		return q, nil
+	}
+	return Parse(text, today)
+}
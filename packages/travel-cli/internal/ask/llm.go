@@ -0,0 +1,141 @@
+package ask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/schema"
+)
+
+// defaultLLMTimeout bounds a model's answer when LLM.Timeout is unset.
+const defaultLLMTimeout = 20 * time.Second
+
+// LLM reads questions with a model behind an OpenAI-compatible chat
+// completions endpoint, asking it to answer with the searches as JSON.
+type LLM struct {
+	// Endpoint is the full chat completions URL, such as
+	// https://api.openai.com/v1/chat/completions.
+	Endpoint string
+	Model    string
+	// APIKey, when set, is sent as a bearer token.
+	APIKey  string
+	Timeout time.Duration
+}
+
+// answer is what the model is asked to reply with: a Query without the
+// fields the caller fills in.
+type answer struct {
+	Flight      *core.FlightSearchRequest `json:"flight,omitempty"`
+	Stay        *core.StaySearchRequest   `json:"stay,omitempty"`
+	Assumptions []string                  `json:"assumptions,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model,omitempty"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Interpret asks the model to read text and checks that its answer is a
+// query the searches can run.
+func (l *LLM) Interpret(ctx context.Context, text string, today time.Time) (*Query, error) {
+	prompt, err := systemPrompt(today)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(chatRequest{
+		Model:          l.Model,
+		Messages:       []chatMessage{{Role: "system", Content: prompt}, {Role: "user", Content: text}},
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	timeout := l.Timeout
+	if timeout <= 0 {
+		timeout = defaultLLMTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beetlebot-travel")
+	if l.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.APIKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s answered %s: %s", req.URL.Host, resp.Status, bytes.TrimSpace(msg))
+	}
+	var chat chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chat); err != nil {
+		return nil, fmt.Errorf("decode chat completion: %w", err)
+	}
+	if len(chat.Choices) == 0 {
+		return nil, errors.New("chat completion has no choices")
+	}
+
+	var a answer
+	content := strings.TrimSpace(chat.Choices[0].Message.Content)
+	content = strings.TrimSuffix(strings.TrimPrefix(content, "```json"), "```")
+	if err := json.Unmarshal([]byte(content), &a); err != nil {
+		return nil, fmt.Errorf("model answer is not the requested JSON: %w", err)
+	}
+	q := &Query{
+		Text:        text,
+		Interpreter: InterpreterLLM,
+		Flight:      a.Flight,
+		Stay:        a.Stay,
+		Assumptions: a.Assumptions,
+	}
+	if err := q.Validate(); err != nil {
+		return nil, fmt.Errorf("model answer: %w", err)
+	}
+	return q, nil
+}
+
+// systemPrompt tells the model the date and the JSON Schema of answer.
+func systemPrompt(today time.Time) (string, error) {
+	s, err := json.Marshal(schema.Parameters(reflect.TypeFor[answer](), nil))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(`You turn travel questions into searches. Today is %s (%s).
+Reply with one JSON object matching this JSON Schema, and nothing else:
+%s
+Include "flight" when the question asks to fly somewhere, with IATA airport codes in "from" and "to".
+Include "stay" when it asks for a hotel or other lodging, with the city name in "city".
+Write dates as YYYY-MM-DD, never earlier than today. Set "nonstop" for nonstop or direct flights.
+Put price limits in "maxFlightPriceUSD" for flights and in the stay's "maxPriceUSD" per night.
+List in "assumptions" anything the question left out that you filled in.`,
+		today.Format(dateLayout), today.Weekday(), s), nil
+}
@@ -0,0 +1,73 @@
+package ask
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// chatServer answers chat completions with content, recording the last
+// request.
+func chatServer(t *testing.T, content string, got *chatRequest) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad key", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Error(err)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []interface{}{map[string]interface{}{"message": map[string]string{"role": "assistant", "content": content}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLLMInterpret(t *testing.T) {
+	var got chatRequest
+	srv := chatServer(t, `{"flight":{"from":"YUL","to":"LIS","departDate":"2026-07-01","returnDate":"2026-07-08","maxStops":0},"assumptions":["economy"]}`, &got)
+	llm := &LLM{Endpoint: srv.URL, Model: "small", APIKey: "sk-test"}
+
+	q, err := llm.Interpret(context.Background(), "nonstop YUL to Lisbon first week of July", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Interpreter != InterpreterLLM || q.Flight.To != "LIS" || q.Flight.MaxStops == nil || *q.Flight.MaxStops != 0 || q.Stay != nil {
+		t.Errorf("query = %+v", q)
+	}
+	if got.Model != "small" || len(got.Messages) != 2 || got.Messages[1].Content != "nonstop YUL to Lisbon first week of July" {
+		t.Errorf("request = %+v", got)
+	}
+	if !strings.Contains(got.Messages[0].Content, "Today is 2026-03-10") || !strings.Contains(got.Messages[0].Content, `"maxStops"`) {
+		t.Errorf("system prompt lacks the date or schema:\n%s", got.Messages[0].Content)
+	}
+}
+
+func TestInterpret_FallsBackToRules(t *testing.T) {
+	var got chatRequest
+	srv := chatServer(t, `{"flight":{"from":"YUL","to":"LIS","departDate":"July 1"}}`, &got)
+	llm := &LLM{Endpoint: srv.URL, APIKey: "sk-test"}
+
+	q, err := Interpret(context.Background(), llm, "YUL to Lisbon July 1-8", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Interpreter != InterpreterRules || q.Flight.DepartDate != "2026-07-01" {
+		t.Errorf("query = %+v", q)
+	}
+	if len(q.Assumptions) == 0 || !strings.Contains(q.Assumptions[0], "not YYYY-MM-DD") {
+		t.Errorf("assumptions = %q, want the model's failure first", q.Assumptions)
+	}
+
+	// Rejected keys fall back too.
+	llm.APIKey = "wrong"
+	if q, err := Interpret(context.Background(), llm, "YUL to Lisbon July 1-8", today); err != nil || q.Interpreter != InterpreterRules {
+		t.Errorf("Interpret = %+v, %v", q, err)
+	}
+}
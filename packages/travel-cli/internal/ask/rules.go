@@ -0,0 +1,506 @@
+package ask
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+)
+
+// dateLayout is how search requests write dates.
+const dateLayout = "2006-01-02"
+
+// ErrNoDates is returned for questions without a date the rules can read.
+var ErrNoDates = errors.New(`no travel dates found; add one such as "July 3", "July 3-10", or "first week of July"`)
+
+// ErrInvalidDate is returned for a date the calendar doesn't have, such
+// as February 30.
+var ErrInvalidDate = errors.New("no such date")
+
+const month = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)`
+
+// number is a count in digits or words up to ten.
+const number = `(\d+|a|an|one|two|three|four|five|six|seven|eight|nine|ten)`
+
+var (
+	isoDate   = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+	weekOf    = regexp.MustCompile(`\b(first|1st|second|2nd|third|3rd|fourth|4th|last) week (?:of|in) ` + month + `\b`)
+	partOf    = regexp.MustCompile(`\b(early|mid|late)[ -]` + month + `\b`)
+	monthDay  = regexp.MustCompile(`\b` + month + `\.? (\d{1,2})(?:st|nd|rd|th)?\b(?:\s*(?:-|–|to|until|through)\s*(?:` + month + `\.? )?(\d{1,2})(?:st|nd|rd|th)?\b)?`)
+	dayMonth  = regexp.MustCompile(`\b(\d{1,2})(?:st|nd|rd|th)?(?:\s*(?:-|–|to|until|through)\s*(\d{1,2})(?:st|nd|rd|th)?)? (?:of )?` + month + `\b`)
+	weekend   = regexp.MustCompile(`\b(this|next) weekend\b`)
+	relative  = regexp.MustCompile(`\b(today|tonight|tomorrow)\b`)
+	inMonth   = regexp.MustCompile(`\b(?:in|during) ` + month + `\b`)
+	length    = regexp.MustCompile(`\b` + number + ` (nights?|days?|weeks?)\b`)
+	maxPrice  = regexp.MustCompile(`(?:under|below|less than|cheaper than|max(?:imum)?|up to|at most|<)\s*\$?\s*(\d+(?:\.\d+)?)`)
+	travelers = regexp.MustCompile(`\b` + number + ` (?:adults?|people|persons|travell?ers|passengers|guests|of us)\b`)
+	forCount  = regexp.MustCompile(`\bfor ` + number + `\b( nights?| days?| weeks?)?`)
+	clauses   = regexp.MustCompile(`[,;]| and | with | plus | then `)
+	words     = regexp.MustCompile(`[\p{L}]+`)
+)
+
+var numberWords = map[string]int{"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10}
+
+// stayWords map what a question may call a stay to its stay type.
+var stayWords = map[string]string{
+	"hotel": "hotel", "hotels": "hotel", "motel": "hotel",
+	"airbnb": "airbnb", "apartment": "airbnb", "flat": "airbnb", "rental": "airbnb",
+	"camping": "camping", "campsite": "camping", "campground": "camping",
+	"stay": "any", "stays": "any", "accommodation": "any", "lodging": "any", "hostel": "any", "room": "any", "place": "any",
+}
+
+var flightWords = []string{"flight", "flights", "fly", "flying", "nonstop", "direct", "airfare", "fare", "fares", "plane", "one way", "round trip", "return"}
+
+// Parse reads text by rules: a route written "YUL to Lisbon" or "to
+// Lisbon from Montreal", dates such as "July 3-10", "first week of July",
+// or "2026-07-03" with an optional length ("for 5 nights"), and stays
+// named by kind ("hotel under $150", "airbnb in Porto"). City names in
+// the destinations dataset stand for their main airport.
+func Parse(text string, today time.Time) (*Query, error) {
+	p := parser{text: text, norm: normalize(text), today: today}
+	p.tokens = words.FindAllString(text, -1)
+	return p.parse()
+}
+
+type parser struct {
+	text   string
+	norm   string
+	tokens []string
+	today  time.Time
+	notes  []string
+}
+
+// normalize lowercases s and spells its variants one way.
+func normalize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.NewReplacer("non-stop", "nonstop", "one-way", "one way", "round-trip", "round trip", "first-class", "first class").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (p *parser) note(format string, args ...interface{}) {
+	p.notes = append(p.notes, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parse() (*Query, error) {
+	q := &Query{Text: p.text, Interpreter: InterpreterRules}
+	origin, dest, routed := p.route()
+	stayType, wantsStay := p.stayType()
+	wantsFlight := routed || p.has(flightWords...)
+	if !wantsFlight && !wantsStay {
+		return nil, errors.New(`found neither a flight nor a stay to search for; write a route such as "YUL to Lisbon" or a stay such as "hotel in Lisbon"`)
+	}
+	if wantsFlight && !routed {
+		return nil, errors.New(`found no route; write it as "YUL to Lisbon" or "to Lisbon from Montreal"`)
+	}
+
+	start, end, err := p.dates()
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := p.length(); ok {
+		end = start.AddDate(0, 0, n)
+	}
+	adults := p.travelers()
+
+	if wantsFlight {
+		q.Flight = &core.FlightSearchRequest{From: origin.code, To: dest.code, DepartDate: start.Format(dateLayout), Adults: adults}
+		switch {
+		case p.has("one way"):
+		case end.IsZero():
+			p.note("one way: no return date or trip length given")
+		default:
+			q.Flight.ReturnDate = end.Format(dateLayout)
+		}
+		switch {
+		case p.has("business"):
+			q.Flight.CabinClass = "business"
+		case p.has("first class"):
+			q.Flight.CabinClass = "first"
+		}
+		if p.has("nonstop", "direct") {
+			q.Flight.MaxStops = new(int)
+		}
+	}
+	if wantsStay {
+		city := p.stayCity()
+		if city == "" && wantsFlight {
+			if city = dest.city; city == "" {
+				return nil, fmt.Errorf(`found no city for the stay; add "in <city>"`)
+			}
+			p.note("stay in %s, where the flight lands", city)
+		}
+		if city == "" {
+			return nil, fmt.Errorf(`found no city for the stay; add "in <city>"`)
+		}
+		if end.IsZero() {
+			end = start.AddDate(0, 0, 1)
+			p.note("1 night: no check-out date or length given")
+		}
+		q.Stay = &core.StaySearchRequest{City: city, CheckIn: start.Format(dateLayout), CheckOut: end.Format(dateLayout), Guests: adults, StayType: stayType}
+	}
+	p.prices(q)
+	q.Assumptions = p.notes
+	return q, nil
+}
+
+// has reports whether the question uses any of phrases as whole words.
+func (p *parser) has(phrases ...string) bool {
+	padded := " " + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, p.norm) + " "
+	for _, phrase := range phrases {
+		if strings.Contains(padded, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// place is where a question says it flies from or to.
+type place struct {
+	code string
+	// city is the destinations dataset's name for it, if it has one.
+	city string
+}
+
+// route finds "A to B", or "to B from A".
+func (p *parser) route() (origin, dest place, ok bool) {
+	for i, t := range p.tokens {
+		if !strings.EqualFold(t, "to") {
+			continue
+		}
+		d, found := p.placeAt(i + 1)
+		if !found {
+			continue
+		}
+		if o, found := p.placeBefore(i); found {
+			return o, d, true
+		}
+		for j, t := range p.tokens {
+			if strings.EqualFold(t, "from") {
+				if o, found := p.placeAt(j + 1); found {
+					return o, d, true
+				}
+			}
+		}
+	}
+	return place{}, place{}, false
+}
+
+// placeAt resolves the longest run of up to three words starting at i.
+func (p *parser) placeAt(i int) (place, bool) {
+	for n := 3; n >= 1; n-- {
+		if i < 0 || i+n > len(p.tokens) {
+			continue
+		}
+		if pl, ok := resolve(p.tokens[i : i+n]); ok {
+			return pl, true
+		}
+	}
+	return place{}, false
+}
+
+// placeBefore resolves the longest run of up to three words ending
+// before i.
+func (p *parser) placeBefore(i int) (place, bool) {
+	for n := 3; n >= 1; n-- {
+		if i-n < 0 {
+			continue
+		}
+		if pl, ok := resolve(p.tokens[i-n : i]); ok {
+			return pl, true
+		}
+	}
+	return place{}, false
+}
+
+// resolve reads words as a city in the destinations dataset, or an
+// airport code: one the dataset knows, or any three capital letters.
+func resolve(words []string) (place, bool) {
+	name := strings.Join(words, " ")
+	if d, err := destinations.Lookup(name); err == nil && len(d.Airports) > 0 {
+		return place{code: d.Airports[0], city: d.Name}, true
+	}
+	if len(words) != 1 || len([]rune(name)) != 3 {
+		return place{}, false
+	}
+	if d, err := destinations.ByAirport(name); err == nil {
+		return place{code: strings.ToUpper(name), city: d.Name}, true
+	}
+	if strings.ToUpper(name) == name && !strings.ContainsFunc(name, func(r rune) bool { return r < 'A' || r > 'Z' }) {
+		return place{code: name}, true
+	}
+	return place{}, false
+}
+
+// stayType returns the kind of stay the question names, if any.
+func (p *parser) stayType() (string, bool) {
+	kind, found := "", false
+	for _, t := range p.tokens {
+		if k, ok := stayWords[strings.ToLower(t)]; ok {
+			if !found || kind == "any" {
+				kind = k
+			}
+			found = true
+		}
+	}
+	return kind, found
+}
+
+// stayCity returns the city after "in", "at", or "near": one the
+// destinations dataset knows, or any capitalized words.
+func (p *parser) stayCity() string {
+	for i, t := range p.tokens {
+		switch strings.ToLower(t) {
+		case "in", "at", "near":
+		default:
+			continue
+		}
+		if pl, ok := p.placeAt(i + 1); ok && pl.city != "" {
+			return pl.city
+		}
+		var name []string
+		for _, w := range p.tokens[i+1:] {
+			if r := []rune(w); !unicode.IsUpper(r[0]) || monthName(w) {
+				break
+			}
+			name = append(name, w)
+		}
+		if len(name) > 0 {
+			return strings.Join(name, " ")
+		}
+	}
+	return ""
+}
+
+func monthName(w string) bool {
+	_, ok := monthOf(w)
+	return ok && len(w) >= 3
+}
+
+// monthOf reads a month name or its abbreviation.
+func monthOf(s string) (time.Month, bool) {
+	s = strings.ToLower(s)
+	if len(s) < 3 {
+		return 0, false
+	}
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), s[:3]) && strings.HasPrefix(strings.ToLower(m.String()), s) {
+			return m, true
+		}
+	}
+	if strings.HasPrefix(s, "sept") {
+		return time.September, true
+	}
+	return 0, false
+}
+
+// day returns the next m/d on or after today.
+func (p *parser) day(m time.Month, d int) time.Time {
+	t := time.Date(p.today.Year(), m, d, 0, 0, 0, 0, time.UTC)
+	if t.Before(p.today) {
+		t = t.AddDate(1, 0, 0)
+	}
+	return t
+}
+
+// date returns day d of month m in year, or an error when the month
+// has no such day; time.Date would roll February 30 into March.
+func date(year int, m time.Month, d int) (time.Time, error) {
+	t := time.Date(year, m, d, 0, 0, 0, 0, time.UTC)
+	if t.Month() != m {
+		return t, fmt.Errorf("%w: %s %d", ErrInvalidDate, m, d)
+	}
+	return t, nil
+}
+
+// startDay is the next month m, day d on or after today.
+func (p *parser) startDay(m time.Month, d int) (time.Time, error) {
+	if _, err := date(2024, m, d); err != nil { // a leap year, so February 29 passes
+		return time.Time{}, err
+	}
+	t, err := date(p.today.Year(), m, d)
+	for year := p.today.Year(); err != nil || t.Before(p.today); {
+		year++
+		t, err = date(year, m, d)
+	}
+	return t, nil
+}
+
+// rangeEnd returns day d ending a range that begins at start. With a
+// month named, the end is in that month, a year on if that isn't after
+// start ("December 28 to January 3"); without one it's in start's month,
+// or the next when d isn't after start ("July 31-1").
+func rangeEnd(start time.Time, m time.Month, named bool, d int) (time.Time, error) {
+	if named {
+		end, err := date(start.Year(), m, d)
+		if err == nil && !end.After(start) {
+			end, err = date(start.Year()+1, m, d)
+		}
+		return end, err
+	}
+	end, err := date(start.Year(), start.Month(), d)
+	if err != nil || end.After(start) {
+		return end, err
+	}
+	next := start.AddDate(0, 0, 1-start.Day()).AddDate(0, 1, 0)
+	return date(next.Year(), next.Month(), d)
+}
+
+// dates finds the first date or date range the question gives. end is
+// zero when it gives a single day.
+func (p *parser) dates() (start, end time.Time, err error) {
+	if m := isoDate.FindAllString(p.norm, 2); m != nil {
+		start, err := time.Parse(dateLayout, m[0])
+		if err != nil {
+			return start, end, fmt.Errorf("%w: %s", ErrInvalidDate, m[0])
+		}
+		if len(m) == 2 {
+			end, _ = time.Parse(dateLayout, m[1])
+		}
+		return start, end, nil
+	}
+	if m := weekOf.FindStringSubmatch(p.norm); m != nil {
+		mo, _ := monthOf(m[2])
+		first := p.day(mo, 1)
+		switch m[1] {
+		case "last":
+			start = first.AddDate(0, 1, -7)
+		default:
+			nth := map[string]int{"first": 0, "1st": 0, "second": 1, "2nd": 1, "third": 2, "3rd": 2, "fourth": 3, "4th": 3}[m[1]]
+			start = first.AddDate(0, 0, 7*nth)
+		}
+		return start, start.AddDate(0, 0, 7), nil
+	}
+	if m := partOf.FindStringSubmatch(p.norm); m != nil {
+		mo, _ := monthOf(m[2])
+		start = p.day(mo, map[string]int{"early": 1, "mid": 11, "late": 21}[m[1]])
+		return start, start.AddDate(0, 0, 7), nil
+	}
+	if m := monthDay.FindStringSubmatch(p.norm); m != nil {
+		mo, _ := monthOf(m[1])
+		d, _ := strconv.Atoi(m[2])
+		if start, err = p.startDay(mo, d); err != nil {
+			return start, end, err
+		}
+		if m[4] != "" {
+			endMonth, named := monthOf(m[3])
+			d, _ := strconv.Atoi(m[4])
+			end, err = rangeEnd(start, endMonth, named, d)
+		}
+		return start, end, err
+	}
+	if m := dayMonth.FindStringSubmatch(p.norm); m != nil {
+		mo, _ := monthOf(m[3])
+		d, _ := strconv.Atoi(m[1])
+		if start, err = p.startDay(mo, d); err != nil {
+			return start, end, err
+		}
+		if m[2] != "" {
+			d, _ := strconv.Atoi(m[2])
+			end, err = rangeEnd(start, 0, false, d)
+		}
+		return start, end, err
+	}
+	if m := weekend.FindStringSubmatch(p.norm); m != nil {
+		start = p.today.AddDate(0, 0, (int(time.Friday)-int(p.today.Weekday())+7)%7)
+		if m[1] == "next" {
+			start = start.AddDate(0, 0, 7)
+		}
+		return start, start.AddDate(0, 0, 2), nil
+	}
+	if m := relative.FindStringSubmatch(p.norm); m != nil {
+		start = p.today
+		if m[1] == "tomorrow" {
+			start = start.AddDate(0, 0, 1)
+		}
+		return start, end, nil
+	}
+	if m := inMonth.FindStringSubmatch(p.norm); m != nil {
+		mo, _ := monthOf(m[1])
+		start = p.day(mo, 1)
+		if start.Month() == p.today.Month() && start.Year() > p.today.Year() {
+			start = p.today
+		}
+		p.note("dates: %q read as from %s", m[0], start.Format(dateLayout))
+		return start, end, nil
+	}
+	return start, end, ErrNoDates
+}
+
+// length returns the trip length in nights the question gives.
+func (p *parser) length() (int, bool) {
+	m := length.FindStringSubmatch(p.norm)
+	if m == nil {
+		return 0, false
+	}
+	n := count(m[1])
+	if strings.HasPrefix(m[2], "week") {
+		n *= 7
+	}
+	return n, n > 0
+}
+
+// travelers returns how many adults the question counts, or zero.
+func (p *parser) travelers() int {
+	if m := travelers.FindStringSubmatch(p.norm); m != nil {
+		return count(m[1])
+	}
+	for _, m := range forCount.FindAllStringSubmatch(p.norm, -1) {
+		if m[2] == "" && m[1] != "a" && m[1] != "an" {
+			return count(m[1])
+		}
+	}
+	switch {
+	case p.has("solo", "alone", "just me"):
+		return 1
+	case p.has("couple", "the two of us"):
+		return 2
+	}
+	return 0
+}
+
+func count(s string) int {
+	if n, ok := numberWords[s]; ok {
+		return n
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// prices applies each price limit to the stay when its clause names one
+// or a night, and otherwise to flights.
+func (p *parser) prices(q *Query) {
+	for _, clause := range clauses.Split(p.norm, -1) {
+		m := maxPrice.FindStringSubmatch(clause)
+		if m == nil {
+			continue
+		}
+		limit, _ := strconv.ParseFloat(m[1], 64)
+		toStay := q.Stay != nil && (q.Flight == nil || strings.Contains(clause, "night") || clauseNamesStay(clause))
+		switch {
+		case toStay:
+			q.Stay.MaxPriceUSD = int(limit)
+		case q.Flight != nil:
+			q.Flight.MaxPriceUSD = int(limit)
+		}
+	}
+}
+
+func clauseNamesStay(clause string) bool {
+	for _, w := range words.FindAllString(clause, -1) {
+		if _, ok := stayWords[w]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package ask
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+var today = time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text   string
+		flight *core.FlightSearchRequest
+		stay   *core.StaySearchRequest
+	}{
+		{
+			text:   "cheap nonstop YUL to Lisbon first week of July, hotel under $150",
+			flight: &core.FlightSearchRequest{From: "YUL", To: "LIS", DepartDate: "2026-07-01", ReturnDate: "2026-07-08", MaxStops: new(int)},
+			stay:   &core.StaySearchRequest{City: "Lisbon", CheckIn: "2026-07-01", CheckOut: "2026-07-08", StayType: "hotel", MaxPriceUSD: 150},
+		},
+		{
+			text:   "flights to Paris from Montreal June 12-20 under $900 for 2 adults",
+			flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", ReturnDate: "2026-06-20", Adults: 2, MaxPriceUSD: 900},
+		},
+		{
+			text:   "JFK to LAX one way 2026-07-01 business",
+			flight: &core.FlightSearchRequest{From: "JFK", To: "LAX", DepartDate: "2026-07-01", CabinClass: "business"},
+		},
+		{
+			text: "airbnb in Porto May 3 for 4 nights under $120 a night",
+			stay: &core.StaySearchRequest{City: "Porto", CheckIn: "2026-05-03", CheckOut: "2026-05-07", StayType: "airbnb", MaxPriceUSD: 120},
+		},
+		{
+			// Dates already past this year fall in the next.
+			text: "hotel in Rome 2-5 February",
+			stay: &core.StaySearchRequest{City: "Rome", CheckIn: "2027-02-02", CheckOut: "2027-02-05", StayType: "hotel"},
+		},
+		{
+			// A range past the month's end runs into the next month.
+			text: "hotel in Rome December 30-2",
+			stay: &core.StaySearchRequest{City: "Rome", CheckIn: "2026-12-30", CheckOut: "2027-01-02", StayType: "hotel"},
+		},
+		{
+			text: "hotel in Rome July 31-1",
+			stay: &core.StaySearchRequest{City: "Rome", CheckIn: "2026-07-31", CheckOut: "2026-08-01", StayType: "hotel"},
+		},
+		{
+			text: "hotel in Rome 30-2 December",
+			stay: &core.StaySearchRequest{City: "Rome", CheckIn: "2026-12-30", CheckOut: "2027-01-02", StayType: "hotel"},
+		},
+		{
+			text: "hotel in Rome December 28 to January 3",
+			stay: &core.StaySearchRequest{City: "Rome", CheckIn: "2026-12-28", CheckOut: "2027-01-03", StayType: "hotel"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			q, err := Parse(tt.text, today)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.Interpreter != InterpreterRules || q.Text != tt.text {
+				t.Errorf("interpreter %q, text %q", q.Interpreter, q.Text)
+			}
+			if !reflect.DeepEqual(q.Flight, tt.flight) {
+				t.Errorf("flight = %+v, want %+v", q.Flight, tt.flight)
+			}
+			if !reflect.DeepEqual(q.Stay, tt.stay) {
+				t.Errorf("stay = %+v, want %+v", q.Stay, tt.stay)
+			}
+			if err := q.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestParse_NotesAssumptions(t *testing.T) {
+	q, err := Parse("YUL to Lisbon May 3 with a hotel", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"one way: no return date or trip length given", "stay in Lisbon, where the flight lands", "1 night: no check-out date or length given"}
+	if !reflect.DeepEqual(q.Assumptions, want) {
+		t.Errorf("assumptions = %q, want %q", q.Assumptions, want)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := Parse("YUL to Lisbon sometime", today); !errors.Is(err, ErrNoDates) {
+		t.Errorf("no dates: err = %v, want ErrNoDates", err)
+	}
+	for _, text := range []string{"hotel in Rome February 30", "hotel in Rome April 30-31", "hotel in Rome 2026-02-30"} {
+		if _, err := Parse(text, today); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("Parse(%q): err = %v, want ErrInvalidDate", text, err)
+		}
+	}
+	for _, text := range []string{"something nice in July", "a flight in July"} {
+		if _, err := Parse(text, today); err == nil {
+			t.Errorf("Parse(%q) succeeded", text)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Package baggage serves the cabin and checked baggage airlines include
+// with their fares, from a list embedded in the binary covering major
+// airlines by cabin, with a link to each airline's own policy. Allowances
+// vary by route and fare; the list gives the usual standard fare on
+// international routes and notes the common exceptions.
+package baggage
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/airlines"
+)
+
+//go:embed baggage.json
+var rawDataset []byte
+
+// The cabins the list gives allowances for, lowest first.
+var Cabins = []string{"economy", "premium_economy", "business", "first"}
+
+// Allowance is the bags of a kind a fare includes. WeightKg is the limit
+// per bag, and TotalKg the limit across bags for airlines that count
+// checked baggage by weight instead. FeeUSD is about what the first bag
+// costs when none is included.
+type Allowance struct {
+	Pieces   int     `json:"pieces"`
+	WeightKg int     `json:"weightKg,omitempty"`
+	TotalKg  int     `json:"totalKg,omitempty"`
+	FeeUSD   float64 `json:"feeUSD,omitempty"`
+}
+
+// Policy is what an airline's fares in a cabin include.
+type Policy struct {
+	Airline     string `json:"airline"`
+	AirlineName string `json:"airlineName"`
+	// Cabin is the cabin the allowance is for: the one asked for, or the
+	// nearest one below it the airline lists.
+	Cabin        string    `json:"cabin"`
+	CarryOn      Allowance `json:"carryOn"`
+	PersonalItem bool      `json:"personalItem"`
+	Checked      Allowance `json:"checked"`
+	// CheckedBagIncluded answers whether a checked bag comes with the
+	// fare at no extra cost.
+	CheckedBagIncluded bool   `json:"checkedBagIncluded"`
+	Note               string `json:"note,omitempty"`
+	URL                string `json:"url"`
+	// AsOf is when the list was last checked.
+	AsOf string `json:"asOf"`
+}
+
+type cabin struct {
+	CarryOn      Allowance `json:"carryOn"`
+	PersonalItem bool      `json:"personalItem,omitempty"`
+	Checked      Allowance `json:"checked"`
+}
+
+type airline struct {
+	Airline string           `json:"airline"`
+	URL     string           `json:"url"`
+	Note    string           `json:"note,omitempty"`
+	Cabins  map[string]cabin `json:"cabins"`
+}
+
+type dataset struct {
+	AsOf     string    `json:"asOf"`
+	Airlines []airline `json:"airlines"`
+}
+
+var ErrNotFound = errors.New("no baggage policy")
+
+var (
+	loadOnce  sync.Once
+	data      dataset
+	byAirline map[string]*airline
+	loadErr   error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &data); err != nil {
+			loadErr = fmt.Errorf("decode embedded baggage policies: %w", err)
+			return
+		}
+		byAirline = make(map[string]*airline, len(data.Airlines))
+		for i := range data.Airlines {
+			a := &data.Airlines[i]
+			byAirline[a.Airline] = a
+		}
+	})
+	return loadErr
+}
+
+// find returns the list's entry for an airline by IATA code or any name
+// the airline list knows.
+func find(name string) (*airline, *airlines.Airline, error) {
+	if err := load(); err != nil {
+		return nil, nil, err
+	}
+	known, err := airlines.Lookup(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w for %q", ErrNotFound, name)
+	}
+	a, ok := byAirline[known.Code]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w for %s (covered: %s)", ErrNotFound, known.Name, strings.Join(Airlines(), ", "))
+	}
+	return a, known, nil
+}
+
+// Lookup returns what an airline, by IATA code or name, includes in a
+// cabin, economy when cabin is empty. A cabin the airline's entry lacks
+// gets the nearest one below it.
+func Lookup(name, cabinClass string) (*Policy, error) {
+	cabinClass = strings.ToLower(strings.TrimSpace(cabinClass))
+	if cabinClass == "" {
+		cabinClass = Cabins[0]
+	}
+	rank := slices.Index(Cabins, cabinClass)
+	if rank < 0 {
+		return nil, fmt.Errorf("unknown cabin %q (use %s)", cabinClass, strings.Join(Cabins, ", "))
+	}
+	a, known, err := find(name)
+	if err != nil {
+		return nil, err
+	}
+	for ; rank >= 0; rank-- {
+		c, ok := a.Cabins[Cabins[rank]]
+		if !ok {
+			continue
+		}
+		return &Policy{
+			Airline:            known.Code,
+			AirlineName:        known.Name,
+			Cabin:              Cabins[rank],
+			CarryOn:            c.CarryOn,
+			PersonalItem:       c.PersonalItem,
+			Checked:            c.Checked,
+			CheckedBagIncluded: c.Checked.Pieces > 0 || c.Checked.TotalKg > 0,
+			Note:               a.Note,
+			URL:                a.URL,
+			AsOf:               data.AsOf,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s in %s", ErrNotFound, known.Name, cabinClass)
+}
+
+// URL returns the airline's baggage policy page, or "" when the list
+// lacks the airline.
+func URL(name string) string {
+	if err := load(); err != nil {
+		return ""
+	}
+	known, err := airlines.Lookup(name)
+	if err != nil {
+		return ""
+	}
+	if a, ok := byAirline[known.Code]; ok {
+		return a.URL
+	}
+	return ""
+}
+
+// Airlines lists the IATA codes of the airlines covered, sorted.
+func Airlines() []string {
+	if err := load(); err != nil {
+		return nil
+	}
+	codes := make([]string, 0, len(byAirline))
+	for code := range byAirline {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
@@ -0,0 +1,83 @@
+package baggage
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/airlines"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"AC", "aca", "Air Canada"} {
+		p, err := Lookup(name, "")
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", name, err)
+		}
+		if p.Airline != "AC" || p.Cabin != "economy" || !p.CheckedBagIncluded || p.Checked.Pieces != 1 || p.URL == "" || p.AsOf == "" {
+			t.Errorf("Lookup(%q) = %+v", name, p)
+		}
+	}
+	if p, err := Lookup("LH", "Business"); err != nil || p.Checked != (Allowance{Pieces: 2, WeightKg: 32}) {
+		t.Errorf("Lookup(LH, Business) = %+v, %v", p, err)
+	}
+}
+
+func TestLookupFallsBackToLowerCabin(t *testing.T) {
+	p, err := Lookup("FR", "business")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Cabin != "economy" || p.CheckedBagIncluded || p.Checked.FeeUSD == 0 {
+		t.Errorf("Lookup(FR, business) = %+v, want economy without a checked bag", p)
+	}
+	if p, err := Lookup("AC", "first"); err != nil || p.Cabin != "business" {
+		t.Errorf("Lookup(AC, first) = %+v, %v; want business", p, err)
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	for _, name := range []string{"Acme Air", "SN"} {
+		if _, err := Lookup(name, "economy"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Lookup(%q): err = %v, want ErrNotFound", name, err)
+		}
+	}
+	if _, err := Lookup("AC", "steerage"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want an unknown cabin", err)
+	}
+}
+
+func TestURL(t *testing.T) {
+	if u := URL("Air France"); !strings.Contains(u, "airfrance") {
+		t.Errorf("URL(Air France) = %q", u)
+	}
+	if u := URL("SN"); u != "" {
+		t.Errorf("URL(SN) = %q, want none", u)
+	}
+}
+
+func TestDatasetComplete(t *testing.T) {
+	if err := load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range data.Airlines {
+		if _, err := airlines.Lookup(a.Airline); err != nil {
+			t.Errorf("%s: not in the airline list", a.Airline)
+		}
+		if !strings.HasPrefix(a.URL, "https://") {
+			t.Errorf("%s: url %q", a.Airline, a.URL)
+		}
+		if _, ok := a.Cabins["economy"]; !ok {
+			t.Errorf("%s: no economy allowance", a.Airline)
+		}
+		for name := range a.Cabins {
+			if !slices.Contains(Cabins, name) {
+				t.Errorf("%s: unknown cabin %q", a.Airline, name)
+			}
+		}
+	}
+	if len(byAirline) != len(data.Airlines) {
+		t.Errorf("%d codes for %d airlines; codes repeat", len(byAirline), len(data.Airlines))
+	}
+}
@@ -0,0 +1,182 @@
+// Package broker assembles the provider router and search orchestrator
+// from a config. The CLI and the pkg/travel library share it, so both
+// search the same providers the same way.
+package broker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/adapters/live"
+	"github.com/beetlebot/travel-cli/internal/adapters/mock"
+	"github.com/beetlebot/travel-cli/internal/adapters/plugin"
+	"github.com/beetlebot/travel-cli/internal/cache"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/geocode"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+	"github.com/beetlebot/travel-cli/internal/logging"
+	"github.com/beetlebot/travel-cli/internal/maintenance"
+	"github.com/beetlebot/travel-cli/internal/ontime"
+	"github.com/beetlebot/travel-cli/internal/quota"
+	"github.com/beetlebot/travel-cli/internal/ratelimit"
+	"github.com/beetlebot/travel-cli/internal/weather"
+)
+
+// NewRouter registers every adapter, the installed plugins, and the
+// maintenance windows currently in effect. Its live adapters' requests
+// are not metered; NewOrchestrator meters its own router's.
+func NewRouter(cfg *config.Config) *core.Router {
+	return newRouter(cfg, nil)
+}
+
+// newRouter is NewRouter with the live adapters' HTTP requests counted
+// through meter.
+func newRouter(cfg *config.Config, meter httpclient.Meter) *core.Router {
+	router := builtinRouter(cfg, meter)
+	registerPlugins(router, plugin.Load(cfg.Cache.TTLFor(config.CacheProviders)))
+	return router
+}
+
+// WarmupRouter is NewRouter without the plugins, which have nothing to
+// warm, so warming up doesn't start them for commands that never search.
+func WarmupRouter(cfg *config.Config) *core.Router {
+	return builtinRouter(cfg, nil)
+}
+
+// builtinRouter registers the adapters built into the CLI and the
+// maintenance windows currently in effect.
+func builtinRouter(cfg *config.Config, meter httpclient.Meter) *core.Router {
+	router := core.NewRouter(cfg)
+
+	router.RegisterFlight(mock.NewMockFlightsAdapter())
+	router.RegisterStay(mock.NewMockStaysAdapter())
+
+	router.RegisterFlight(live.NewDuffelFlightsAdapter(cfg.Getenv, meter))
+	router.RegisterStay(live.NewExpediaStaysAdapter(cfg.Getenv, meter))
+	router.RegisterStay(live.NewAirbnbStaysAdapter(cfg.Getenv, meter))
+	router.RegisterStatus(live.NewAviationstackStatusAdapter(cfg.Getenv, meter))
+
+	if store, err := openStore(cfg, maintenance.Open, maintenance.OpenDir); err == nil {
+		if windows, err := store.Active(time.Now()); err == nil {
+			router.WithMaintenance(windows)
+		}
+	}
+	return router
+}
+
+// registerPlugins registers each plugin for the searches it lists. A
+// plugin can't replace a built-in adapter or an earlier plugin of the
+// same name.
+func registerPlugins(router *core.Router, plugins []*plugin.Adapter) {
+	for _, p := range plugins {
+		if router.Registered(p.Name()) {
+			logging.Logger().Warn("plugin not loaded", "path", p.Path(), "error", "provider "+p.Name()+" already exists")
+			continue
+		}
+		if p.Flights() {
+			router.RegisterFlight(p)
+		}
+		if stays := p.Stays(); stays != nil {
+			router.RegisterStay(stays)
+		}
+	}
+}
+
+// historyWindow bounds how many past selections feed personalization.
+const historyWindow = 50
+
+// NewOrchestrator returns an orchestrator over NewRouter with the config's
+// dedupe strategies, deep-link parameters, personalized ranking, provider
+// rate limits, exchange rates, geocoder, weather source, and on-time
+// source, counting provider calls in the usage ledger. The live
+// providers' HTTP requests are metered through the returned orchestrator
+// alone, so orchestrators built for separate clients spend their own
+// quotas.
+func NewOrchestrator(cfg *config.Config) *core.Orchestrator {
+	var orch *core.Orchestrator
+	meter := func(ctx context.Context, provider string) error { return orch.Spend(ctx, provider) }
+	orch = core.NewOrchestrator(newRouter(cfg, meter)).WithDedupe(DedupeStrategy(cfg))
+	if len(cfg.Links) > 0 {
+		orch.WithLinkDecorator(core.NewLinkDecorator(cfg.Links))
+	}
+	if cfg.Ranking.Personalized {
+		if store, err := openStore(cfg, history.Open, history.OpenDir); err == nil {
+			if sels, err := store.Selections(historyWindow); err == nil {
+				prefs := history.Learn(sels)
+				orch.WithPreferences(&prefs)
+			}
+		}
+	}
+	if ledger, err := openStore(cfg, quota.Open, quota.OpenDir); err == nil {
+		orch.WithUsage(ledger)
+	}
+	if rateLimited(cfg) {
+		if store, err := openStore(cfg, ratelimit.Open, ratelimit.OpenDir); err == nil {
+			orch.WithRateLimiter(store)
+		}
+	}
+	orch.WithRates(sync.OnceValue(func() fx.Rates { return fx.Current(cfg.FX, cfg.Cache.TTLFor(config.CacheFX)) }))
+	lookups := lookupCache(cfg)
+	orch.WithGeocoder(geocode.NewCache(geocode.NewNominatim(cfg.Geocode.URL), lookups))
+	orch.WithWeather(weather.NewOpenMeteo(cfg.Weather.URL, cfg.Weather.ArchiveURL))
+	if src := onTimeSource(cfg, lookups); src != nil {
+		orch.WithOnTime(src, cfg.Ranking.OnTimeWeight)
+	}
+	return orch
+}
+
+// openStore opens a store in cfg.DataDir when it is set, and in the data
+// directory otherwise.
+func openStore[S any](cfg *config.Config, open func() (S, error), openDir func(string) (S, error)) (S, error) {
+	if cfg.DataDir != "" {
+		return openDir(cfg.DataDir)
+	}
+	return open()
+}
+
+// lookupCache returns the cache geocoding and on-time answers are kept
+// in, each for its cache: TTL, or nil when there is none.
+func lookupCache(cfg *config.Config) core.ResultCache {
+	open := func() (*cache.Store, error) { return cache.Open(cfg.Cache.TTLFor) }
+	openDir := func(dir string) (*cache.Store, error) {
+		return cache.OpenDir(filepath.Join(dir, "cache"), cfg.Cache.TTLFor)
+	}
+	store, err := openStore(cfg, open, openDir)
+	if err != nil {
+		return nil
+	}
+	return store
+}
+
+// onTimeSource returns the on-time source the onTime: section selects, or
+// nil when it selects none.
+func onTimeSource(cfg *config.Config, lookups core.ResultCache) core.OnTimeSource {
+	switch cfg.OnTime.Source {
+	case ontime.SourceBTS:
+		return ontime.NewBTS(cfg.OnTime.File)
+	case ontime.SourceAviationstack:
+		api := ontime.NewAviationstack(cfg.OnTime.URL, os.Expand(cfg.OnTime.APIKey, cfg.Getenv))
+		return ontime.NewCache(api, lookups)
+	}
+	return nil
+}
+
+func rateLimited(cfg *config.Config) bool {
+	for _, p := range cfg.Providers {
+		if p.RequestsPerMinute > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DedupeStrategy reads the dedupe: section of cfg.
+func DedupeStrategy(cfg *config.Config) core.DedupeStrategy {
+	return core.DedupeStrategy{Flights: cfg.Dedupe.Flights, Stays: cfg.Dedupe.Stays}
+}
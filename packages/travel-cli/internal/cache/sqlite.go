@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/beetlebot/travel-cli/internal/paths"
 )
 
 type Entry struct {
@@ -26,11 +28,11 @@ type FileCache struct {
 }
 
 func New() (*FileCache, error) {
-	home, err := os.UserHomeDir()
+	base, err := paths.CacheDir()
 	if err != nil {
 		return nil, err
 	}
-	dir := filepath.Join(home, ".cache", "beetlebot", "travel")
+	dir := filepath.Join(base, "travel")
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("create cache dir: %w", err)
 	}
@@ -53,6 +55,7 @@ func (c *FileCache) Get(key string, ttl time.Duration) ([]byte, bool) {
 	}
 
 	if time.Since(entry.CreatedAt) > ttl {
+		_ = os.Remove(path)
 		return nil, false
 	}
 
@@ -74,7 +77,21 @@ func (c *FileCache) Set(key string, data []byte) error {
 		return err
 	}
 
-	return os.WriteFile(c.path(key), raw, 0o644)
+	// Write beside the entry and rename over it, so a reader in another
+	// process never sees half an entry.
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
 }
 
 func (c *FileCache) Clear() error {
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Store is a FileCache that knows how long each resource it holds stays
+// fresh, so callers name what they cache rather than pick a TTL.
+type Store struct {
+	files *FileCache
+	ttl   func(resource string) time.Duration
+}
+
+// Open returns a Store in the cache directory, with ttl giving each
+// resource's freshness (config.CacheConfig.TTLFor).
+func Open(ttl func(resource string) time.Duration) (*Store, error) {
+	files, err := New()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{files: files, ttl: ttl}, nil
+}
+
+// OpenDir is Open with the cache kept in dir.
+func OpenDir(dir string, ttl func(resource string) time.Duration) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &Store{files: &FileCache{dir: dir}, ttl: ttl}, nil
+}
+
+// Get returns the resource's data under key if it was stored within the
+// resource's TTL.
+func (s *Store) Get(resource, key string) ([]byte, bool) {
+	return s.files.Get(resource+"|"+key, s.ttl(resource))
+}
+
+func (s *Store) Set(resource, key string, data []byte) error {
+	return s.files.Set(resource+"|"+key, data)
+}
@@ -0,0 +1,337 @@
+// Package cassette records live provider HTTP traffic to files and plays
+// it back, so adapter tests and demos run without credentials or network.
+// Secrets (auth headers, cookies, and key, token, secret, or password
+// fields in queries and bodies) are redacted before anything is written,
+// and requests are matched on the redacted form.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+const redacted = "REDACTED"
+
+// ErrNotRecorded means replay found no recorded response for a request.
+var ErrNotRecorded = errors.New("no recorded response")
+
+// Interaction is one request and the response it got.
+type Interaction struct {
+	Request    Request   `json:"request"`
+	Response   Response  `json:"response"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// file is a cassette: the interactions with one host, in order.
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Wrap returns next, or a transport recording through it when
+// TRAVEL_RECORD=1, or one replaying instead of it when TRAVEL_REPLAY=1.
+// Cassettes live in TRAVEL_CASSETTES, by default the cassettes directory
+// beside the CLI's other data.
+func Wrap(next http.RoundTripper) http.RoundTripper {
+	switch {
+	case os.Getenv("TRAVEL_REPLAY") == "1":
+		return Replayer(Dir())
+	case os.Getenv("TRAVEL_RECORD") == "1":
+		return Recorder(next, Dir())
+	}
+	return next
+}
+
+// Replaying reports whether Wrap replays cassettes, in which case live
+// adapters need no credentials.
+func Replaying() bool {
+	return os.Getenv("TRAVEL_REPLAY") == "1"
+}
+
+// Dir is the cassette directory Wrap uses.
+func Dir() string {
+	if dir := os.Getenv("TRAVEL_CASSETTES"); dir != "" {
+		return dir
+	}
+	base, err := paths.DataDir()
+	if err != nil {
+		return "cassettes"
+	}
+	return filepath.Join(base, "travel", "cassettes")
+}
+
+// Recorder sends requests through next and writes each exchange to the
+// cassette for its host in dir, <host>.json. A host's cassette is started
+// afresh by the first request to it, so a recording run replaces what an
+// earlier one captured.
+func Recorder(next http.RoundTripper, dir string) http.RoundTripper {
+	return &recorder{next: next, dir: dir, cassettes: map[string]*file{}}
+}
+
+type recorder struct {
+	next      http.RoundTripper
+	dir       string
+	mu        sync.Mutex
+	cassettes map[string]*file
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	in := Interaction{
+		Request:    RedactRequest(req, body),
+		Response:   Response{Status: resp.StatusCode, Header: RedactHeader(resp.Header), Body: RedactBody(resp.Header.Get("Content-Type"), respBody)},
+		RecordedAt: time.Now().UTC(),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.cassettes[req.URL.Host]
+	if c == nil {
+		c = &file{}
+		r.cassettes[req.URL.Host] = c
+	}
+	c.Interactions = append(c.Interactions, in)
+	if err := c.write(filepath.Join(r.dir, cassetteName(req.URL.Host))); err != nil {
+		return nil, fmt.Errorf("record %s: %w", req.URL.Host, err)
+	}
+	return resp, nil
+}
+
+// Replayer answers requests from the cassettes in dir without touching
+// the network. Requests are matched by method, URL, and body once
+// redacted; a request recorded several times gets its responses in
+// order, the last one repeating.
+func Replayer(dir string) http.RoundTripper {
+	return &replayer{dir: dir, cassettes: map[string]*file{}, played: map[string]int{}}
+}
+
+type replayer struct {
+	dir       string
+	mu        sync.Mutex
+	cassettes map[string]*file
+	played    map[string]int
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	want := RedactRequest(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, err := r.cassette(req.URL.Host)
+	if err != nil {
+		return nil, err
+	}
+	key := want.key()
+	var matches []Interaction
+	for _, in := range c.Interactions {
+		if in.Request.key() == key {
+			matches = append(matches, in)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNotRecorded, want.Method, want.URL)
+	}
+	n := min(r.played[key], len(matches)-1)
+	r.played[key]++
+
+	rec := matches[n].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *replayer) cassette(host string) (*file, error) {
+	if c, ok := r.cassettes[host]; ok {
+		return c, nil
+	}
+	data, err := os.ReadFile(filepath.Join(r.dir, cassetteName(host)))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w for %s: no cassette in %s", ErrNotRecorded, host, r.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c := &file{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("decode cassette for %s: %w", host, err)
+	}
+	r.cassettes[host] = c
+	return c, nil
+}
+
+func (c *file) write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(c); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// key identifies a redacted request for matching.
+func (r Request) key() string {
+	sum := sha256.Sum256([]byte(r.Body))
+	return r.Method + " " + r.URL + " " + hex.EncodeToString(sum[:8])
+}
+
+func cassetteName(host string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(host) + ".json"
+}
+
+// readBody reads *body and replaces it with a copy, so it can still be
+// sent or read by the caller.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// secretName matches header, query, form, and JSON field names whose
+// values are credentials.
+var secretName = regexp.MustCompile(`(?i)authorization|^auth$|token|secret|passw(or)?d|api[-_]?key|access[-_]?key|signature|cookie|session|credential`)
+
+// RedactRequest is req, with the given body, as it is written to a
+// cassette: secrets in its URL, headers, and body replaced by REDACTED.
+func RedactRequest(req *http.Request, body []byte) Request {
+	u := *req.URL
+	u.RawQuery = redactQuery(u.Query()).Encode()
+	u.User = nil
+	return Request{
+		Method: req.Method,
+		URL:    u.String(),
+		Header: RedactHeader(req.Header),
+		Body:   RedactBody(req.Header.Get("Content-Type"), body),
+	}
+}
+
+// RedactHeader returns a copy of h with credential headers redacted.
+func RedactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for name, values := range out {
+		if secretName.MatchString(name) {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+	return out
+}
+
+func redactQuery(q url.Values) url.Values {
+	for name, values := range q {
+		if secretName.MatchString(name) {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+	return q
+}
+
+// RedactBody redacts secret fields of JSON and form bodies. Other bodies
+// are kept as they are.
+func RedactBody(contentType string, body []byte) string {
+	switch {
+	case len(body) == 0:
+		return ""
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		if q, err := url.ParseQuery(string(body)); err == nil {
+			return redactQuery(q).Encode()
+		}
+	case strings.Contains(contentType, "json") || json.Valid(body):
+		var v interface{}
+		if json.Unmarshal(body, &v) == nil {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			if enc.Encode(redactJSON(v)) == nil {
+				return strings.TrimSuffix(buf.String(), "\n")
+			}
+		}
+	}
+	return string(body)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if _, nested := item.(map[string]interface{}); !nested && secretName.MatchString(k) {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(item)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSON(v[i])
+		}
+	}
+	return v
+}
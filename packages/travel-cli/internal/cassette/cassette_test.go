@@ -0,0 +1,65 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = io.WriteString(w, `{"access_token":"tok-123","offers":[{"price":"412.50"}]}`)
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	get := func(rt http.RoundTripper, key string) (*http.Response, string, error) {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/offers?from=YUL&api_key="+key+"&access_key="+key, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		resp, err := (&http.Client{Transport: rt}).Do(req)
+		if err != nil {
+			return nil, "", err
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body), nil
+	}
+
+	if _, body, err := get(Recorder(http.DefaultTransport, dir), "live-key"); err != nil || !strings.Contains(body, "tok-123") {
+		t.Fatalf("recorded request = %q, %v", body, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("cassettes = %v", entries)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	for _, secret := range []string{"live-key", "tok-123", "session=abc"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette keeps %q:\n%s", secret, data)
+		}
+	}
+
+	// Replay matches whatever credentials the request carries, and never
+	// reaches the server.
+	resp, body, err := get(Replayer(dir), "other-key")
+	if err != nil || resp.StatusCode != http.StatusOK || !strings.Contains(body, `"price":"412.50"`) {
+		t.Fatalf("replayed = %q, %v", body, err)
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times", calls)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/elsewhere", nil)
+	if _, err := Replayer(dir).RoundTrip(req); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("unrecorded request = %v", err)
+	}
+}
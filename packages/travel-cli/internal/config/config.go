@@ -5,8 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"gopkg.in/yaml.v3"
+	"github.com/beetlebot/travel-cli/internal/paths"
 )
 
 type Mode string
@@ -18,44 +19,383 @@ const (
 )
 
 type ProviderConfig struct {
-	Enabled  bool              `yaml:"enabled"`
+	// Enabled false takes the provider out of routing. A provider whose
+	// entry leaves it out, say to set only a timeout, stays enabled.
+	Enabled  *bool             `yaml:"enabled,omitempty"`
 	Priority int               `yaml:"priority"`
 	EnvKeys  map[string]string `yaml:"envKeys,omitempty"`
+	// Maintenance takes the provider out of routing for a planned window.
+	Maintenance *MaintenanceConfig `yaml:"maintenance,omitempty"`
+	// Timeout bounds each search of the provider (15s when unset).
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// RequestsPerMinute caps searches of the provider across every run
+	// of the CLI and daemon (no limit when unset).
+	RequestsPerMinute int `yaml:"requestsPerMinute,omitempty"`
+	// MonthlyLimit is the provider's monthly call quota; calls past 80%
+	// of it are warned about (no warnings when unset).
+	MonthlyLimit int `yaml:"monthlyLimit,omitempty"`
+	// CostPerCall estimates what each call costs, in USD, for
+	// `travel providers usage`.
+	CostPerCall float64 `yaml:"costPerCall,omitempty"`
+	// Secrets holds provider keys encrypted by `travel credentials
+	// encrypt`, by environment variable, for machines without a keyring.
+	Secrets map[string]string `yaml:"secrets,omitempty"`
+}
+
+// MaintenanceConfig is a planned provider outage. Either bound may be
+// omitted: no From means it has already started, no Until means it lasts
+// until removed from the config.
+type MaintenanceConfig struct {
+	From   time.Time `yaml:"from,omitempty"`
+	Until  time.Time `yaml:"until,omitempty"`
+	Reason string    `yaml:"reason,omitempty"`
+}
+
+// RankingConfig tunes how offers are ordered after normalization.
+type RankingConfig struct {
+	// Personalized boosts offers that match preferences learned from
+	// the offers the user repriced, combined or added to trips
+	// (preferred airlines, stop tolerance, hotel rating floor).
+	Personalized bool `yaml:"personalized"`
+	// OnTimeWeight, when above zero, favors flights that usually arrive
+	// on time, by the records onTime: gives. At 1, a flight on time 95%
+	// of the time gains on one on time 80% as much as a stop costs.
+	OnTimeWeight float64 `yaml:"onTimeWeight,omitempty"`
+}
+
+// WarmupConfig controls opening connections (DNS, TCP, TLS) to live
+// providers at process start, ahead of the first search.
+type WarmupConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// HooksConfig declares commands that post-process results. PostSearch
+// receives the SearchResult JSON on stdin and must print the (possibly
+// modified) document on stdout.
+type HooksConfig struct {
+	PostSearch string        `yaml:"postSearch,omitempty"`
+	Timeout    time.Duration `yaml:"timeout,omitempty"`
+}
+
+// DedupeConfig selects how offers for the same trip from several
+// providers are merged: flights by exact (the default), codeshare-aware,
+// or off; stays by fuzzy (the default), exact, or off.
+type DedupeConfig struct {
+	Flights string `yaml:"flights,omitempty"`
+	Stays   string `yaml:"stays,omitempty"`
+}
+
+// HistoryConfig tunes the passive price observations recorded for
+// searches run by the API server and trip planner. ObserveEvery is the
+// minimum time between observations of one route or city and date (one
+// hour when unset).
+type HistoryConfig struct {
+	ObserveEvery        time.Duration `yaml:"observeEvery,omitempty"`
+	DisableObservations bool          `yaml:"disableObservations,omitempty"`
+}
+
+// DaemonConfig controls `travel daemon`.
+type DaemonConfig struct {
+	// Interval is how often saved alerts are checked.
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// NotifyConfig selects where triggered alerts, from both `travel daemon`
+// and `travel alerts check`, and the daemon's scheduled-search summaries
+// are announced.
+type NotifyConfig struct {
+	// Desktop shows native desktop notifications.
+	Desktop  bool            `yaml:"desktop,omitempty"`
+	Webhooks []WebhookConfig `yaml:"webhooks,omitempty"`
+	Slack    *ChatConfig     `yaml:"slack,omitempty"`
+	Discord  *ChatConfig     `yaml:"discord,omitempty"`
+	Email    *EmailConfig    `yaml:"email,omitempty"`
+	// Digest is a cron expression on which the daemon sends a summary of
+	// tracked alerts and their price changes, e.g. "0 8 * * *" or
+	// "@weekly".
+	Digest string `yaml:"digest,omitempty"`
+}
+
+// EmailConfig sends notifications by SMTP, rendered from Template (a Go
+// text/template file, or html/template when it ends in .html) or the
+// built-in plain-text layout. Password and Template may reference
+// environment variables as ${NAME}. Events defaults to alerts.digest
+// only.
+type EmailConfig struct {
+	Host     string   `yaml:"host"`
+	Port     int      `yaml:"port,omitempty"`
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Template string   `yaml:"template,omitempty"`
+	Events   []string `yaml:"events,omitempty"`
+}
+
+// ChatConfig posts readable messages to a Slack or Discord incoming
+// webhook. URL may reference an environment variable as ${NAME}. Events
+// limits which notifications are sent (alert.triggered, schedule.ran,
+// alerts.digest); empty sends all.
+type ChatConfig struct {
+	URL    string   `yaml:"url"`
+	Events []string `yaml:"events,omitempty"`
+}
+
+// WebhookConfig POSTs notifications as JSON to URL, signed with Secret
+// when set. Secret should reference an environment variable or a saved
+// credential as ${NAME} rather than hold the key itself.
+// Events limits which notifications are sent (alert.triggered,
+// schedule.ran, alerts.digest); empty sends all.
+type WebhookConfig struct {
+	URL    string   `yaml:"url"`
+	Secret string   `yaml:"secret,omitempty"`
+	Events []string `yaml:"events,omitempty"`
+}
+
+// OutputConfig rewrites field names in the CLI's JSON output for
+// downstream systems. Rename maps field names to aliases and Drop lists
+// fields to omit; both match keys at any depth.
+type OutputConfig struct {
+	Rename map[string]string `yaml:"rename,omitempty"`
+	Drop   []string          `yaml:"drop,omitempty"`
+}
+
+// CacheConfig controls reusing fetched data. TTL says how long each
+// resource stays fresh, by name (CacheFlights, CacheStays, CacheFX,
+// CacheGeocode, CacheOnTime, CacheProviders); resources it leaves out use
+// DefaultCacheTTL. Disabled stops search results being cached.
+type CacheConfig struct {
+	Disabled bool                     `yaml:"disabled,omitempty"`
+	TTL      map[string]time.Duration `yaml:"ttl,omitempty"`
+}
+
+// Resources kept in the local cache.
+const (
+	CacheFlights   = "flights"
+	CacheStays     = "stays"
+	CacheFX        = "fx"
+	CacheGeocode   = "geocode"
+	CacheOnTime    = "ontime"
+	CacheProviders = "providers"
+)
+
+// DefaultCacheTTL is how long each resource stays fresh unless cache.ttl
+// says otherwise: flight prices move within minutes, stay availability
+// within hours, and reference exchange rates once a working day; on-time
+// records and provider metadata (what plugins say about themselves)
+// change slowly, and places rarely move.
+var DefaultCacheTTL = map[string]time.Duration{
+	CacheFlights:   10 * time.Minute,
+	CacheStays:     2 * time.Hour,
+	CacheFX:        12 * time.Hour,
+	CacheGeocode:   30 * 24 * time.Hour,
+	CacheOnTime:    7 * 24 * time.Hour,
+	CacheProviders: 7 * 24 * time.Hour,
+}
+
+// TTLFor returns how long resource stays fresh. Zero means it is always
+// fetched again.
+func (c CacheConfig) TTLFor(resource string) time.Duration {
+	if ttl, ok := c.TTL[resource]; ok {
+		return ttl
+	}
+	return DefaultCacheTTL[resource]
+}
+
+// LogConfig sets the level of diagnostics written to stderr: debug, info,
+// warn (the default), error, or off. TRAVEL_LOG overrides it, and
+// --verbose and --quiet override both.
+type LogConfig struct {
+	Level string `yaml:"level,omitempty"`
+}
+
+// FXConfig selects where exchange rates come from. Source is "static"
+// (the rates bundled with the CLI, the default) or "frankfurter" (daily
+// ECB reference rates, fetched at most twice a day). Rates, in units per
+// US dollar, override either.
+type FXConfig struct {
+	Source string             `yaml:"source,omitempty"`
+	URL    string             `yaml:"url,omitempty"`
+	Rates  map[string]float64 `yaml:"rates,omitempty"`
+}
+
+// GeocodeConfig points place lookups, as for stays search --near, at a
+// Nominatim server other than OpenStreetMap's public one, such as a
+// self-hosted instance.
+type GeocodeConfig struct {
+	URL string `yaml:"url,omitempty"`
+}
+
+// WeatherConfig points destinations weather and stays search --weather at
+// Open-Meteo forecast and historical weather APIs other than the public
+// ones.
+type WeatherConfig struct {
+	URL        string `yaml:"url,omitempty"`
+	ArchiveURL string `yaml:"archiveUrl,omitempty"`
+}
+
+// OnTimeConfig selects where flights' on-time records come from: "bts"
+// reads File, an on-time performance CSV downloaded from the US Bureau of
+// Transportation Statistics, and "aviationstack" asks aviationstack's API
+// about each flight's recent departures with APIKey, which may reference
+// an environment variable as ${NAME}. Unset, offers have no on-time
+// score.
+type OnTimeConfig struct {
+	Source string `yaml:"source,omitempty"`
+	File   string `yaml:"file,omitempty"`
+	URL    string `yaml:"url,omitempty"`
+	APIKey string `yaml:"apiKey,omitempty"`
+}
+
+// TravelerConfig describes the traveler. Nationality is the ISO 3166
+// code of their passport's country, such as CA.
+type TravelerConfig struct {
+	Nationality string `yaml:"nationality,omitempty"`
+}
+
+// AskConfig points `travel ask` at an OpenAI-compatible chat completions
+// endpoint that reads questions before the built-in rules do. APIKey may
+// reference an environment variable as ${NAME}. Unset, questions are read
+// by the rules alone.
+type AskConfig struct {
+	Endpoint string        `yaml:"endpoint,omitempty"`
+	Model    string        `yaml:"model,omitempty"`
+	APIKey   string        `yaml:"apiKey,omitempty"`
+	Timeout  time.Duration `yaml:"timeout,omitempty"`
+}
+
+// ScheduleConfig is a named search run by `travel daemon` whenever Cron
+// matches. Search names a saved search to run; otherwise Kind selects
+// which of the search fields apply: From/To/Depart for flights,
+// City/CheckIn/CheckOut for stays.
+type ScheduleConfig struct {
+	Name     string `yaml:"name" json:"name"`
+	Cron     string `yaml:"cron" json:"cron"`
+	Search   string `yaml:"search,omitempty" json:"search,omitempty"`
+	Kind     string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	From     string `yaml:"from,omitempty" json:"from,omitempty"`
+	To       string `yaml:"to,omitempty" json:"to,omitempty"`
+	Depart   string `yaml:"depart,omitempty" json:"depart,omitempty"`
+	Return   string `yaml:"return,omitempty" json:"return,omitempty"`
+	Adults   int    `yaml:"adults,omitempty" json:"adults,omitempty"`
+	Cabin    string `yaml:"cabin,omitempty" json:"cabin,omitempty"`
+	City     string `yaml:"city,omitempty" json:"city,omitempty"`
+	CheckIn  string `yaml:"checkin,omitempty" json:"checkin,omitempty"`
+	CheckOut string `yaml:"checkout,omitempty" json:"checkout,omitempty"`
+	Guests   int    `yaml:"guests,omitempty" json:"guests,omitempty"`
+	StayType string `yaml:"type,omitempty" json:"type,omitempty"`
 }
 
 type Config struct {
+	// Version is the config file layout; see CurrentVersion.
+	Version   int                       `yaml:"version,omitempty"`
 	Mode      Mode                      `yaml:"mode"`
 	Providers map[string]ProviderConfig `yaml:"providers"`
+	Ranking   RankingConfig             `yaml:"ranking,omitempty"`
+	Dedupe    DedupeConfig              `yaml:"dedupe,omitempty"`
+	Warmup    WarmupConfig              `yaml:"warmup,omitempty"`
+	// Links holds query parameters (UTM tags, affiliate IDs) appended to
+	// every deep link, keyed by provider name. The "*" key applies to all
+	// providers; provider-specific values win. Values may reference
+	// environment variables as ${NAME}.
+	Links   map[string]map[string]string `yaml:"links,omitempty"`
+	Hooks   HooksConfig                  `yaml:"hooks,omitempty"`
+	Daemon  DaemonConfig                 `yaml:"daemon,omitempty"`
+	History HistoryConfig                `yaml:"history,omitempty"`
+	Notify  NotifyConfig                 `yaml:"notify,omitempty"`
+	FX      FXConfig                     `yaml:"fx,omitempty"`
+	Ask     AskConfig                    `yaml:"ask,omitempty"`
+	Geocode GeocodeConfig                `yaml:"geocode,omitempty"`
+	Weather WeatherConfig                `yaml:"weather,omitempty"`
+	Output  OutputConfig                 `yaml:"output,omitempty"`
+	Log     LogConfig                    `yaml:"log,omitempty"`
+	Cache   CacheConfig                  `yaml:"cache,omitempty"`
+	// Traveler describes who travels, for hints such as visas.
+	Traveler TravelerConfig `yaml:"traveler,omitempty"`
+	// OnTime gives flight offers on-time scores.
+	OnTime OnTimeConfig `yaml:"onTime,omitempty"`
+	// Prefer names providers to favor over every other, first strongest:
+	// they are queried first and win ties in ranking and dedupe.
+	Prefer []string `yaml:"prefer,omitempty"`
+	// MaxConcurrency caps how many providers a search queries at once;
+	// the rest wait for a slot. Zero or unset queries them all together.
+	MaxConcurrency int `yaml:"maxConcurrency,omitempty"`
+	// SoftDeadline, when set, is how long a search waits before returning
+	// the offers that have arrived, as a partial result, rather than
+	// waiting for every provider to answer or time out.
+	SoftDeadline time.Duration `yaml:"softDeadline,omitempty"`
+	// Schedules are searches the daemon runs on cron schedules.
+	Schedules []ScheduleConfig `yaml:"schedules,omitempty"`
+	// Credentials are provider keys by environment variable name, used
+	// where the variable is unset. Programs embedding the broker set
+	// them instead of changing their own environment.
+	Credentials map[string]string `yaml:"-"`
+	// DataDir, when set, holds the search history, usage ledger, rate
+	// limits, and maintenance windows instead of the data directory.
+	DataDir string `yaml:"-"`
+
+	envProblems []Problem
+	fileVersion int
+}
+
+// FileVersion is the layout the config file is written in. Load reads an
+// older file as upgraded without rewriting it; `travel config migrate`
+// does that.
+func (c *Config) FileVersion() int {
+	return c.fileVersion
+}
+
+// EnvProblems lists TRAVEL_ variables Load skipped because their setting
+// can't hold the value.
+func (c *Config) EnvProblems() []Problem {
+	return c.envProblems
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Mode: ModeMock,
+		Version: CurrentVersion,
+		Mode:    ModeMock,
 		Providers: map[string]ProviderConfig{
-			"mock_flights": {Enabled: true, Priority: 100},
-			"mock_stays":   {Enabled: true, Priority: 100},
+			"mock_flights": {Enabled: Bool(true), Priority: 100},
+			"mock_stays":   {Enabled: Bool(true), Priority: 100},
 		},
 	}
 }
 
+// Disabled reports whether the provider's entry sets enabled: false.
+func (p ProviderConfig) Disabled() bool {
+	return p.Enabled != nil && !*p.Enabled
+}
+
+// Bool returns a pointer to b, for ProviderConfig.Enabled.
+func Bool(b bool) *bool {
+	return &b
+}
+
 func Load() *Config {
 	cfg := DefaultConfig()
+	cfg.fileVersion = CurrentVersion
 
+	var data []byte
 	if path := configPath(); path != "" {
-		if data, err := os.ReadFile(path); err == nil {
-			_ = yaml.Unmarshal(data, cfg)
+		if d, err := os.ReadFile(path); err == nil {
+			data, cfg.fileVersion, _ = upgrade(path, d)
 		}
 	}
+	doc, err := envDoc(data)
+	if err != nil {
+		doc, _ = envDoc(nil)
+	}
+	cfg.envProblems = applyEnv(doc.Content[0], os.Environ())
+	_ = doc.Decode(cfg)
 
-	if envMode := os.Getenv("TRAVEL_MODE"); envMode != "" {
-		switch strings.ToLower(envMode) {
-		case "mock":
-			cfg.Mode = ModeMock
-		case "live":
-			cfg.Mode = ModeLive
-		case "hybrid":
-			cfg.Mode = ModeHybrid
-		}
+	if mode, err := ParseMode(os.Getenv("TRAVEL_MODE")); err == nil {
+		cfg.Mode = mode
+	}
+
+	if envLog := os.Getenv("TRAVEL_LOG"); envLog != "" {
+		cfg.Log.Level = envLog
 	}
 
 	if envProviders := os.Getenv("TRAVEL_PROVIDERS"); envProviders != "" {
@@ -63,7 +403,7 @@ func Load() *Config {
 		for _, n := range names {
 			n = strings.TrimSpace(n)
 			if _, ok := cfg.Providers[n]; !ok {
-				cfg.Providers[n] = ProviderConfig{Enabled: true, Priority: 50}
+				cfg.Providers[n] = ProviderConfig{Enabled: Bool(true), Priority: 50}
 			}
 		}
 	}
@@ -72,16 +412,39 @@ func Load() *Config {
 }
 
 func (c *Config) WithMode(mode string) *Config {
-	if mode == "" {
-		return c
+	if m, err := ParseMode(mode); err == nil {
+		c.Mode = m
 	}
+	return c
+}
+
+// ParseMode reads a provider mode, in any case.
+func ParseMode(mode string) (Mode, error) {
 	switch strings.ToLower(mode) {
 	case "mock":
-		c.Mode = ModeMock
+		return ModeMock, nil
 	case "live":
-		c.Mode = ModeLive
+		return ModeLive, nil
 	case "hybrid":
-		c.Mode = ModeHybrid
+		return ModeHybrid, nil
+	}
+	return "", fmt.Errorf("unknown mode %q (want mock, live, or hybrid)", mode)
+}
+
+// Getenv returns the environment variable key, or the credential saved
+// for it in c.Credentials when it is unset.
+func (c *Config) Getenv(key string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return c.Credentials[key]
+}
+
+// WithPrefer favors the named providers, replacing the config's prefer
+// list unless names is empty.
+func (c *Config) WithPrefer(names []string) *Config {
+	if len(names) > 0 {
+		c.Prefer = names
 	}
 	return c
 }
@@ -92,7 +455,7 @@ func (c *Config) ProviderHasCredentials(name string) bool {
 		return false
 	}
 	for _, envKey := range pc.EnvKeys {
-		if os.Getenv(envKey) == "" {
+		if c.Getenv(envKey) == "" {
 			return false
 		}
 	}
@@ -106,7 +469,7 @@ func (c *Config) MissingCredentials(name string) []string {
 	}
 	var missing []string
 	for label, envKey := range pc.EnvKeys {
-		if os.Getenv(envKey) == "" {
+		if c.Getenv(envKey) == "" {
 			missing = append(missing, fmt.Sprintf("%s (%s)", label, envKey))
 		}
 	}
@@ -114,16 +477,28 @@ func (c *Config) MissingCredentials(name string) []string {
 }
 
 func configPath() string {
-	if p := os.Getenv("TRAVEL_CONFIG"); p != "" {
-		return p
-	}
-	home, err := os.UserHomeDir()
+	p, err := writablePath()
 	if err != nil {
 		return ""
 	}
-	p := filepath.Join(home, ".config", "beetlebot", "travel.yaml")
+	if os.Getenv("TRAVEL_CONFIG") != "" {
+		return p
+	}
 	if _, err := os.Stat(p); err == nil {
 		return p
 	}
 	return ""
 }
+
+// writablePath is where commands that edit the config write it: the
+// TRAVEL_CONFIG file, or the default location even if it doesn't exist yet.
+func writablePath() (string, error) {
+	if p := os.Getenv("TRAVEL_CONFIG"); p != "" {
+		return p, nil
+	}
+	base, err := paths.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "travel.yaml"), nil
+}
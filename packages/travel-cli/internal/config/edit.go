@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// EditFile applies edit to the top-level mapping of the config file and
+// writes it back. It works on the YAML node tree rather than Config so
+// comments, key order, and settings this version doesn't know about
+// survive the edit. A file from an older version is upgraded first, and a
+// missing one is created. The file is replaced atomically.
+func EditFile(edit func(root *yaml.Node) error) error {
+	path, err := writablePath()
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level is not a mapping", path)
+	}
+	from, err := fileVersion(root)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if from > CurrentVersion {
+		return &NewerVersionError{Path: path, Version: from}
+	}
+	if from < CurrentVersion {
+		if len(data) > 0 {
+			if err := backup(path, data, from); err != nil {
+				return err
+			}
+		}
+		if err := migrate(root, from); err != nil {
+			return err
+		}
+	}
+
+	if err := edit(root); err != nil {
+		return err
+	}
+
+	out, err := encodeDoc(&doc)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, out)
+}
+
+// mappingValue returns the value node for key in a mapping, adding an
+// empty node of the given kind when the key is absent.
+func mappingValue(m *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	v := &yaml.Node{Kind: kind}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, v)
+	return v
+}
+
+// AddSchedule appends s to the schedules section of the config file,
+// replacing an existing schedule with the same name.
+func AddSchedule(s ScheduleConfig) error {
+	return EditFile(func(root *yaml.Node) error {
+		list := mappingValue(root, "schedules", yaml.SequenceNode)
+		if list.Kind != yaml.SequenceNode {
+			*list = yaml.Node{Kind: yaml.SequenceNode}
+		}
+		var item yaml.Node
+		if err := item.Encode(s); err != nil {
+			return err
+		}
+		for i, existing := range list.Content {
+			if scheduleName(existing) == s.Name {
+				list.Content[i] = &item
+				return nil
+			}
+		}
+		list.Content = append(list.Content, &item)
+		return nil
+	})
+}
+
+// RemoveSchedule deletes the named schedule from the config file.
+func RemoveSchedule(name string) error {
+	return EditFile(func(root *yaml.Node) error {
+		list := mappingValue(root, "schedules", yaml.SequenceNode)
+		for i, existing := range list.Content {
+			if scheduleName(existing) == name {
+				list.Content = append(list.Content[:i], list.Content[i+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %s", ErrScheduleNotFound, name)
+	})
+}
+
+func scheduleName(n *yaml.Node) string {
+	if n.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == "name" {
+			return n.Content[i+1].Value
+		}
+	}
+	return ""
+}
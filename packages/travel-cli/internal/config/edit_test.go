@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddAndRemoveSchedulePreservesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	original := "# my settings\nmode: hybrid # keep this\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	june := ScheduleConfig{Name: "june", Cron: "0 */6 * * *", Kind: "flights", From: "YUL", To: "CDG", Depart: "2026-06-12"}
+	if err := AddSchedule(june); err != nil {
+		t.Fatal(err)
+	}
+	june.To = "LIS"
+	if err := AddSchedule(june); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddSchedule(ScheduleConfig{Name: "paris", Cron: "@daily", Kind: "stays", City: "Paris"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# my settings") || !strings.Contains(string(data), "# keep this") {
+		t.Errorf("comments lost:\n%s", data)
+	}
+	cfg := Load()
+	if cfg.Mode != ModeHybrid || len(cfg.Schedules) != 2 || cfg.Schedules[0].To != "LIS" {
+		t.Fatalf("loaded config = %+v", cfg)
+	}
+
+	if err := RemoveSchedule("june"); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveSchedule("june"); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("second remove: %v", err)
+	}
+	if cfg := Load(); len(cfg.Schedules) != 1 || cfg.Schedules[0].Name != "paris" {
+		t.Errorf("after remove = %+v", cfg.Schedules)
+	}
+}
+
+func TestSaveWritesThroughSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "travel.yaml")
+	link := filepath.Join(dir, "travel.yaml")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("mode: hybrid\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skip("symlinks unsupported:", err)
+	}
+	t.Setenv("TRAVEL_CONFIG", link)
+
+	if err := AddSchedule(ScheduleConfig{Name: "paris", Cron: "@daily", Kind: "stays", City: "Paris"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config symlink replaced: %v", err)
+	}
+	if data, _ := os.ReadFile(target); !strings.Contains(string(data), "paris") {
+		t.Errorf("target not updated:\n%s", data)
+	}
+}
+
+func TestAddScheduleCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	if err := AddSchedule(ScheduleConfig{Name: "x", Cron: "@hourly", Kind: "flights"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := Load(); len(cfg.Schedules) != 1 {
+		t.Errorf("schedules = %+v", cfg.Schedules)
+	}
+}
+
+func TestSaveSetupKeepsExistingSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	original := "# my settings\nmode: mock\nproviders:\n  duffel:\n    enabled: true\n    priority: 95 # tuned\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := SaveSetup(ModeLive, map[string]ProviderConfig{
+		"duffel":  {Enabled: Bool(false), Priority: 80, EnvKeys: map[string]string{"apiToken": "DUFFEL_API_TOKEN"}},
+		"expedia": {Enabled: Bool(true), Priority: 70, EnvKeys: map[string]string{"apiKey": "EXPEDIA_API_KEY"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "# my settings") || !strings.Contains(string(data), "# tuned") {
+		t.Errorf("comments lost:\n%s", data)
+	}
+	cfg := Load()
+	duffel, expedia := cfg.Providers["duffel"], cfg.Providers["expedia"]
+	if cfg.Mode != ModeLive || !duffel.Disabled() || duffel.Priority != 95 || duffel.EnvKeys["apiToken"] != "DUFFEL_API_TOKEN" {
+		t.Errorf("duffel = %+v in mode %s", duffel, cfg.Mode)
+	}
+	if expedia.Disabled() || expedia.Priority != 70 || expedia.EnvKeys["apiKey"] != "EXPEDIA_API_KEY" {
+		t.Errorf("expedia = %+v", expedia)
+	}
+}
+
+func TestSetChecksValueBeforeWriting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	if err := os.WriteFile(path, []byte("mode: mock # keep this\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set("mode", "hybrid"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set("warmup.timeout", "30s"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set("output.drop", "[deepLink, score]"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Load()
+	if cfg.Mode != ModeHybrid || cfg.Warmup.Timeout != 30*time.Second || len(cfg.Output.Drop) != 2 {
+		t.Errorf("after set = %+v", cfg)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "# keep this") {
+		t.Errorf("comment lost:\n%s", data)
+	}
+
+	before, _ := os.ReadFile(path)
+	var problem Problem
+	if err := Set("mode", "fast"); !errors.As(err, &problem) {
+		t.Errorf("invalid value error = %v", err)
+	}
+	if err := Set("mdoe", "live"); !errors.Is(err, ErrUnknownSetting) {
+		t.Errorf("unknown key error = %v", err)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Errorf("rejected set changed the file:\n%s", after)
+	}
+}
+
+func TestSaveMergesIntoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	t.Setenv("TRAVEL_MODE", "live")
+	original := `# my settings
+mode: hybrid # keep this
+ranking:
+  personalized: false # off for now
+daemon:
+  interval: 30m
+providers:
+  duffel:
+    enabled: true # turned on in June
+    priority: 80
+  kiwi:
+    enabled: true
+    priority: 60
+future: kept
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mode != ModeHybrid {
+		t.Fatalf("LoadFile applied TRAVEL_MODE: %s", cfg.Mode)
+	}
+	cfg.Providers["duffel"] = ProviderConfig{Enabled: Bool(false), Priority: 80}
+	delete(cfg.Providers, "kiwi")
+	cfg.MaxConcurrency = 2
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(path)
+	text := string(data)
+	for _, want := range []string{"# my settings", "# keep this", "# off for now", "# turned on in June", "interval: 30m\n", "future: kept", "maxConcurrency: 2"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "kiwi") {
+		t.Errorf("removed provider still written:\n%s", text)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("permissions = %v", info.Mode().Perm())
+	}
+	if saved, _ := LoadFile(); !saved.Providers["duffel"].Disabled() || saved.Mode != ModeHybrid {
+		t.Errorf("saved config = %+v", saved)
+	}
+}
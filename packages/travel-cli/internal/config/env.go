@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix starts the environment variable of every setting: the
+// dotted key in upper snake case, so providers.duffel.timeout is
+// TRAVEL_PROVIDERS_DUFFEL_TIMEOUT and maxConcurrency is
+// TRAVEL_MAX_CONCURRENCY.
+const envPrefix = "TRAVEL_"
+
+// envSpecial are TRAVEL_ variables Load handles itself rather than as a
+// setting's path.
+var envSpecial = []string{"TRAVEL_MODE", "TRAVEL_LOG", "TRAVEL_PROVIDERS"}
+
+// envRefused are the settings no variable may set, since they run
+// commands: only the config file can.
+var envRefused = []string{"hooks"}
+
+// applyEnv sets each setting that has a TRAVEL_ variable in the
+// environment on root, the config file's top-level mapping. Variables
+// that don't name a setting are left alone, since other TRAVEL_ variables
+// exist; ones whose value the setting can't hold are skipped and
+// returned as problems.
+func applyEnv(root *yaml.Node, environ []string) []Problem {
+	slices.Sort(environ)
+	var problems []Problem
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(name, envPrefix) || slices.Contains(envSpecial, name) {
+			continue
+		}
+		path := envPath(configType, strings.Split(strings.TrimPrefix(name, envPrefix), "_"))
+		if len(path) == 0 {
+			continue
+		}
+		if slices.Contains(envRefused, path[0]) {
+			problems = append(problems, Problem{Key: name, Message: path[0] + " can only be set in the config file"})
+			continue
+		}
+		t, pattern, err := keyType(path)
+		if err != nil {
+			continue
+		}
+		node, err := envNode(value, t)
+		if err != nil {
+			problems = append(problems, Problem{Key: name, Message: err.Error()})
+			continue
+		}
+		if p := checkNode(node, t, path, pattern); len(p) > 0 {
+			problems = append(problems, Problem{Key: name, Message: p[0].Message})
+			continue
+		}
+		if err := setNode(root, path, node); err != nil {
+			problems = append(problems, Problem{Key: name, Message: err.Error()})
+		}
+	}
+	return problems
+}
+
+// envNode reads a variable's value as YAML, like config set does. A list
+// setting also takes comma-separated items: TRAVEL_PREFER=duffel,expedia.
+func envNode(value string, t reflect.Type) (*yaml.Node, error) {
+	if t.Kind() == reflect.Slice && !strings.HasPrefix(strings.TrimSpace(value), "[") {
+		list := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list.Content = append(list.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: item})
+			}
+		}
+		return list, nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}, nil
+	}
+	return doc.Content[0], nil
+}
+
+// envPath finds the setting a variable's words (after TRAVEL_) name
+// under a setting of type t, or returns nil when they name none. Struct
+// fields match their words (maxConcurrency is MAX_CONCURRENCY); a map
+// key is as few words as leave a setting below it, so MOCK_FLIGHTS_ENABLED
+// is mock_flights.enabled, or all the remaining words in a map of plain
+// values; a list item is its index.
+func envPath(t reflect.Type, words []string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if len(words) == 0 {
+		return []string{}
+	}
+	switch {
+	case t.Kind() == reflect.Struct && t != timeType:
+		fields := yamlFields(t)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		// Longer names first, so a field isn't taken for a shorter one
+		// its words start with.
+		slices.Sort(names)
+		slices.SortStableFunc(names, func(a, b string) int { return len(b) - len(a) })
+		for _, name := range names {
+			f := fields[name]
+			kw := envWords(name)
+			if len(kw) > len(words) || !slices.Equal(kw, words[:len(kw)]) {
+				continue
+			}
+			if rest := envPath(f.Type, words[len(kw):]); rest != nil {
+				return append([]string{name}, rest...)
+			}
+		}
+	case t.Kind() == reflect.Map:
+		switch t.Elem().Kind() {
+		case reflect.Struct, reflect.Map, reflect.Slice, reflect.Pointer:
+			for k := 1; k < len(words); k++ {
+				if rest := envPath(t.Elem(), words[k:]); rest != nil {
+					return append([]string{strings.ToLower(strings.Join(words[:k], "_"))}, rest...)
+				}
+			}
+		default:
+			return []string{strings.ToLower(strings.Join(words, "_"))}
+		}
+	case t.Kind() == reflect.Slice:
+		if _, err := strconv.Atoi(words[0]); err == nil {
+			if rest := envPath(t.Elem(), words[1:]); rest != nil {
+				return append([]string{words[0]}, rest...)
+			}
+		}
+	}
+	return nil
+}
+
+// envWords splits a config key into its variable's words: maxConcurrency
+// is MAX, CONCURRENCY and mock_flights is MOCK, FLIGHTS.
+func envWords(key string) []string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '-' || r == '_':
+			b.WriteByte('_')
+		case unicode.IsUpper(r) && i > 0:
+			b.WriteByte('_')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return strings.Split(b.String(), "_")
+}
+
+// envDoc parses config file contents for applyEnv, starting an empty
+// mapping when there are none.
+func envDoc(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level is not a mapping")
+	}
+	return &doc, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEnvOverridesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	file := "version: 1\nmaxConcurrency: 2\nproviders:\n  duffel:\n    enabled: true\n    priority: 80\n"
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRAVEL_MAX_CONCURRENCY", "4")
+	t.Setenv("TRAVEL_PROVIDERS_DUFFEL_TIMEOUT", "30s")
+	t.Setenv("TRAVEL_PROVIDERS_MOCK_FLIGHTS_ENABLED", "false")
+	t.Setenv("TRAVEL_WARMUP_ENABLED", "true")
+	t.Setenv("TRAVEL_PREFER", "duffel, expedia")
+	t.Setenv("TRAVEL_FX_RATES_EUR", "0.9")
+	t.Setenv("TRAVEL_DEDUPE_FLIGHTS", "sometimes")
+	t.Setenv("TRAVEL_NOT_A_SETTING", "ignored")
+	t.Setenv("TRAVEL_HOOKS_POST_SEARCH", "touch pwned")
+
+	cfg := Load()
+	if cfg.MaxConcurrency != 4 {
+		t.Errorf("maxConcurrency = %d", cfg.MaxConcurrency)
+	}
+	if d := cfg.Providers["duffel"]; d.Timeout != 30*time.Second || d.Priority != 80 || d.Disabled() {
+		t.Errorf("duffel = %+v", d)
+	}
+	if !cfg.Providers["mock_flights"].Disabled() {
+		t.Error("mock_flights still enabled")
+	}
+	if !cfg.Warmup.Enabled || len(cfg.Prefer) != 2 || cfg.Prefer[1] != "expedia" || cfg.FX.Rates["eur"] != 0.9 {
+		t.Errorf("config = %+v", cfg)
+	}
+	if cfg.Hooks.PostSearch != "" {
+		t.Errorf("hook taken from the environment: %q", cfg.Hooks.PostSearch)
+	}
+	if cfg.Dedupe.Flights != "" {
+		t.Errorf("invalid value applied: %q", cfg.Dedupe.Flights)
+	}
+	if p := cfg.EnvProblems(); len(p) != 2 || p[0].Key != "TRAVEL_DEDUPE_FLIGHTS" || p[1].Key != "TRAVEL_HOOKS_POST_SEARCH" {
+		t.Errorf("problems = %+v", p)
+	}
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownSetting means a key doesn't name a setting the config file
+// can hold.
+var ErrUnknownSetting = errors.New("unknown setting")
+
+var (
+	configType   = reflect.TypeFor[Config]()
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// Get returns the setting at key, a dotted path such as "mode" or
+// "providers.duffel.priority" (list items by index: "schedules.0.cron"),
+// as the config file would spell it. A known setting that isn't set is
+// nil, or false for a switch.
+func (c *Config) Get(key string) (interface{}, error) {
+	path := strings.Split(key, ".")
+	t, _, err := keyType(path)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := c.decodeInto(&v); err != nil {
+		return nil, err
+	}
+	for _, part := range path {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[part]
+		case []interface{}:
+			i, _ := strconv.Atoi(part)
+			if i < 0 || i >= len(node) {
+				return nil, nil
+			}
+			v = node[i]
+		default:
+			v = nil
+		}
+	}
+	if v == nil && t.Kind() == reflect.Bool {
+		return false, nil
+	}
+	return v, nil
+}
+
+// Map returns the config as nested maps keyed the way the config file is,
+// with durations spelled out ("1h0m0s").
+func (c *Config) Map() (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	return m, c.decodeInto(&m)
+}
+
+func (c *Config) decodeInto(v interface{}) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, v)
+}
+
+// Set writes value to key in the config file, keeping everything else.
+// The value is read as YAML, so lists and maps can be given in flow
+// style ("[deepLink, score]"), and is checked against the setting before
+// the file is touched.
+func Set(key, value string) error {
+	path := strings.Split(key, ".")
+	t, pattern, err := keyType(path)
+	if err != nil {
+		return err
+	}
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if len(doc.Content) > 0 {
+		node = doc.Content[0]
+	}
+	if problems := checkNode(node, t, path, pattern); len(problems) > 0 {
+		p := problems[0]
+		p.Line = 0
+		return p
+	}
+
+	return EditFile(func(root *yaml.Node) error {
+		return setNode(root, path, node)
+	})
+}
+
+// setNode puts node at path under root, adding the mappings and lists
+// on the way. A list item must already exist.
+func setNode(root *yaml.Node, path []string, node *yaml.Node) error {
+	key := strings.Join(path, ".")
+	parent := root
+	for i, part := range path[:len(path)-1] {
+		if parent.Kind == yaml.SequenceNode {
+			n, _ := strconv.Atoi(part)
+			if n < 0 || n >= len(parent.Content) {
+				return Problem{Key: key, Message: fmt.Sprintf("%s has no item %d", strings.Join(path[:i], "."), n)}
+			}
+			parent = parent.Content[n]
+			continue
+		}
+		kind := yaml.MappingNode
+		if isList(path[:i+1]) {
+			kind = yaml.SequenceNode
+		}
+		next := mappingValue(parent, part, kind)
+		if next.Kind != yaml.MappingNode && next.Kind != yaml.SequenceNode {
+			*next = yaml.Node{Kind: kind}
+		}
+		parent = next
+	}
+	last := path[len(path)-1]
+	if parent.Kind == yaml.SequenceNode {
+		n, _ := strconv.Atoi(last)
+		if n < 0 || n >= len(parent.Content) {
+			return Problem{Key: key, Message: fmt.Sprintf("%s has no item %d", strings.Join(path[:len(path)-1], "."), n)}
+		}
+		parent.Content[n] = node
+		return nil
+	}
+	old := mappingValue(parent, last, node.Kind)
+	node.LineComment = old.LineComment
+	*old = *node
+	return nil
+}
+
+// isList reports whether the setting at path is a list.
+func isList(path []string) bool {
+	t, _, err := keyType(path)
+	return err == nil && t.Kind() == reflect.Slice
+}
+
+// keyType returns the Go type of the setting at path and the path's
+// pattern, with map keys and list indexes replaced by "*".
+func keyType(path []string) (reflect.Type, []string, error) {
+	t := configType
+	pattern := make([]string, 0, len(path))
+	for i, part := range path {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		key := strings.Join(path[:i+1], ".")
+		switch {
+		case part == "":
+			return nil, nil, fmt.Errorf("%w %q", ErrUnknownSetting, strings.Join(path, "."))
+		case t.Kind() == reflect.Struct && t != timeType:
+			fields := yamlFields(t)
+			f, ok := fields[part]
+			if !ok {
+				return nil, nil, unknownSetting(key, part, fields)
+			}
+			t = f.Type
+			pattern = append(pattern, part)
+		case t.Kind() == reflect.Map:
+			t = t.Elem()
+			pattern = append(pattern, "*")
+		case t.Kind() == reflect.Slice:
+			if _, err := strconv.Atoi(part); err != nil {
+				return nil, nil, fmt.Errorf("%w %s: %s is a list, so use an item number", ErrUnknownSetting, key, strings.Join(path[:i], "."))
+			}
+			t = t.Elem()
+			pattern = append(pattern, "*")
+		default:
+			return nil, nil, fmt.Errorf("%w %s: %s has no settings under it", ErrUnknownSetting, key, strings.Join(path[:i], "."))
+		}
+	}
+	return t, pattern, nil
+}
+
+// yamlFields maps the config file's names for a struct's settings to
+// their fields.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+func unknownSetting(key, name string, fields map[string]reflect.StructField) error {
+	if s := closest(name, fields); s != "" {
+		return fmt.Errorf("%w %s (did you mean %s?)", ErrUnknownSetting, key, s)
+	}
+	return fmt.Errorf("%w %s", ErrUnknownSetting, key)
+}
+
+// closest returns the known setting name nearest to a misspelled one, or
+// "" when none is within a couple of edits.
+func closest(name string, fields map[string]reflect.StructField) string {
+	names := make([]string, 0, len(fields))
+	for n := range fields {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	best, bestDist := "", 3
+	for _, n := range names {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(n)); d < bestDist {
+			best, bestDist = n, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the config file layout this version of the CLI
+// reads and writes. Files without a version: key are version 0.
+const CurrentVersion = 2
+
+// migrations[i] upgrades a config file from version i to i+1, editing
+// its top-level mapping in place. A change that renames or reshapes a
+// setting adds a migration here and bumps CurrentVersion, so older files
+// keep meaning what they meant instead of silently losing the setting.
+var migrations = []func(root *yaml.Node) error{
+	// 0 → 1: the version is recorded; no setting changed.
+	func(root *yaml.Node) error { return nil },
+	// 1 → 2: cache.ttl went from one duration for search results to a
+	// duration per resource.
+	func(root *yaml.Node) error {
+		section := childNode(root, "cache")
+		if section == nil || section.Kind != yaml.MappingNode {
+			return nil
+		}
+		ttl := childNode(section, "ttl")
+		if ttl == nil || ttl.Kind != yaml.ScalarNode {
+			return nil
+		}
+		value := *ttl
+		*ttl = yaml.Node{Kind: yaml.MappingNode, LineComment: value.LineComment}
+		value.LineComment = ""
+		for _, resource := range []string{CacheFlights, CacheStays} {
+			v := value
+			ttl.Content = append(ttl.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: resource}, &v)
+		}
+		return nil
+	},
+}
+
+// childNode returns the value for key in mapping m, or nil.
+func childNode(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// NewerVersionError means the config file was written by a newer version
+// of the CLI, whose settings this one may misread.
+type NewerVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *NewerVersionError) Error() string {
+	return fmt.Sprintf("%s is config version %d, but this travel reads up to version %d; upgrade travel", e.Path, e.Version, CurrentVersion)
+}
+
+// fileVersion reads the version: key of a config file's top-level
+// mapping, 0 when absent.
+func fileVersion(root *yaml.Node) (int, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "version" {
+			v, err := strconv.Atoi(root.Content[i+1].Value)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("version: want a whole number, got %q", root.Content[i+1].Value)
+			}
+			return v, nil
+		}
+	}
+	return 0, nil
+}
+
+// migrate upgrades root from version from to CurrentVersion and records
+// the new version, first in the file.
+func migrate(root *yaml.Node, from int) error {
+	for v := from; v < CurrentVersion; v++ {
+		if err := migrations[v](root); err != nil {
+			return fmt.Errorf("upgrade config from version %d: %w", v, err)
+		}
+	}
+	setVersion(root)
+	return nil
+}
+
+func setVersion(root *yaml.Node) {
+	value := strconv.Itoa(CurrentVersion)
+	if hasKey(root, "version") {
+		setScalar(root, "version", value)
+		return
+	}
+	key := &yaml.Node{Kind: yaml.ScalarNode, Value: "version"}
+	if len(root.Content) > 0 {
+		// A comment heading the file stays above everything.
+		key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+	}
+	root.Content = append([]*yaml.Node{key, {Kind: yaml.ScalarNode, Value: value}}, root.Content...)
+}
+
+// upgrade brings the config file at path, whose contents are data, up
+// to CurrentVersion in memory, returning the contents to load and the
+// version the file is in. The file itself is only rewritten by Migrate or
+// by a command that edits it.
+func upgrade(path string, data []byte) ([]byte, int, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, CurrentVersion, nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, CurrentVersion, err
+	}
+	root := doc.Content[0]
+	from, err := fileVersion(root)
+	if err != nil {
+		return data, CurrentVersion, err
+	}
+	if from > CurrentVersion {
+		return data, from, &NewerVersionError{Path: path, Version: from}
+	}
+	if from == CurrentVersion {
+		return data, from, nil
+	}
+	if err := migrate(root, from); err != nil {
+		return data, from, err
+	}
+	upgraded, err := encodeDoc(&doc)
+	if err != nil {
+		return data, from, err
+	}
+	return upgraded, from, nil
+}
+
+// Migrate rewrites the config file in the CurrentVersion layout, keeping
+// the original beside it as <path>.v<version>.bak, and returns the file's
+// path and the version it was in. A missing or current file is left
+// alone.
+func Migrate() (path string, from int, err error) {
+	if path, err = writablePath(); err != nil {
+		return "", 0, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return path, CurrentVersion, nil
+	}
+	if err != nil {
+		return path, 0, err
+	}
+	upgraded, from, err := upgrade(path, data)
+	if err != nil || from == CurrentVersion {
+		return path, from, err
+	}
+	if err := backup(path, data, from); err != nil {
+		return path, from, err
+	}
+	return path, from, writeFileAtomic(path, upgraded)
+}
+
+// backup keeps a config file's contents before an upgrade rewrites it,
+// with the file's permissions.
+func backup(path string, data []byte, version int) error {
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	return os.WriteFile(fmt.Sprintf("%s.v%d.bak", path, version), data, perm)
+}
+
+func encodeDoc(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
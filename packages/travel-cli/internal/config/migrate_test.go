@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadUpgradesOldFileInMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	original := "# my settings\nmode: hybrid # keep this\n"
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Load()
+	if cfg.Mode != ModeHybrid || cfg.Version != CurrentVersion || cfg.FileVersion() != 0 {
+		t.Fatalf("loaded config = %+v, file version %d", cfg, cfg.FileVersion())
+	}
+	if data, _ := os.ReadFile(path); string(data) != original {
+		t.Errorf("Load rewrote the file:\n%s", data)
+	}
+
+	if got, from, err := Migrate(); err != nil || got != path || from != 0 {
+		t.Fatalf("Migrate = %q, %d, %v", got, from, err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "# my settings\nversion: 2\n") || !strings.Contains(string(data), "# keep this") {
+		t.Errorf("upgraded file:\n%s", data)
+	}
+	saved, err := os.ReadFile(path + ".v0.bak")
+	if err != nil || string(saved) != original {
+		t.Errorf("backup = %q, %v", saved, err)
+	}
+	if cfg := Load(); cfg.FileVersion() != CurrentVersion {
+		t.Errorf("file version after migrating = %d", cfg.FileVersion())
+	}
+
+	// An upgraded file is left alone.
+	if err := os.Remove(path + ".v0.bak"); err != nil {
+		t.Fatal(err)
+	}
+	if _, from, err := Migrate(); err != nil || from != CurrentVersion {
+		t.Errorf("Migrate of a current file = %d, %v", from, err)
+	}
+	if _, err := os.Stat(path + ".v0.bak"); !os.IsNotExist(err) {
+		t.Errorf("current file backed up again: %v", err)
+	}
+}
+
+func TestNewerVersionIsNotEdited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	original := "version: 99\nmode: live\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg := Load(); cfg.Version != 99 || cfg.Mode != ModeLive {
+		t.Errorf("loaded config = %+v", cfg)
+	}
+	var newer *NewerVersionError
+	if err := Set("mode", "mock"); !errors.As(err, &newer) || newer.Version != 99 {
+		t.Errorf("Set = %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != original {
+		t.Errorf("file changed:\n%s", data)
+	}
+	if problems := Validate([]byte(original)); len(problems) != 1 || problems[0].Key != "version" {
+		t.Errorf("problems = %+v", problems)
+	}
+}
+
+func TestUpgradeSplitsCacheTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "travel.yaml")
+	t.Setenv("TRAVEL_CONFIG", path)
+	if err := os.WriteFile(path, []byte("version: 1\ncache:\n  ttl: 30m # searches\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Load()
+	if cfg.Cache.TTLFor(CacheFlights) != 30*time.Minute || cfg.Cache.TTLFor(CacheStays) != 30*time.Minute {
+		t.Errorf("ttl = %v", cfg.Cache.TTL)
+	}
+	if cfg.Cache.TTLFor(CacheFX) != DefaultCacheTTL[CacheFX] {
+		t.Errorf("fx ttl = %v", cfg.Cache.TTLFor(CacheFX))
+	}
+	if _, _, err := Migrate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path + ".v1.bak"); err != nil {
+		t.Errorf("no backup: %v", err)
+	}
+	if problems := Validate(mustRead(t, path)); len(problems) > 0 {
+		t.Errorf("upgraded file has problems: %v", problems)
+	}
+}
+
+func mustRead(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
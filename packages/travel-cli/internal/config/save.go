@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile reads the config file alone: the defaults and the file, with
+// no TRAVEL_* variables applied, so a Config changed and then saved
+// doesn't write the environment into the file. Unlike Load, a file that
+// can't be parsed is an error. A missing file gives the defaults.
+func LoadFile() (*Config, error) {
+	path, err := writablePath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadPath(path)
+}
+
+// LoadPath reads the config file at path as LoadFile reads the CLI's.
+func LoadPath(path string) (*Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data, _, err = upgrade(path, data); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes c to the config file. Settings are merged into the file
+// rather than replacing it, so comments on settings that remain, key
+// order, and settings this version doesn't know about survive; a known
+// setting c leaves at its zero value stays as written when the file's
+// value is also zero, and is removed otherwise.
+func (c *Config) Save() error {
+	var src yaml.Node
+	if err := src.Encode(c); err != nil {
+		return err
+	}
+	return EditFile(func(root *yaml.Node) error {
+		mergeNode(root, &src, configType)
+		return nil
+	})
+}
+
+// mergeNode updates dst, a node of the config file holding a setting of
+// type t, to the value src encodes, keeping dst's comments where the two
+// line up.
+func mergeNode(dst, src *yaml.Node, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if dst.Kind == yaml.ScalarNode && src.Kind == yaml.ScalarNode && sameValue(dst, src, t) {
+		return
+	}
+	if dst.Kind != src.Kind || src.Kind == yaml.ScalarNode || src.Kind == yaml.AliasNode {
+		src.HeadComment, src.LineComment, src.FootComment = dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		return
+	}
+
+	switch src.Kind {
+	case yaml.SequenceNode:
+		for i, item := range src.Content {
+			if i < len(dst.Content) {
+				mergeNode(dst.Content[i], item, t.Elem())
+			} else {
+				dst.Content = append(dst.Content, item)
+			}
+		}
+		dst.Content = dst.Content[:len(src.Content)]
+	case yaml.MappingNode:
+		var fields map[string]reflect.StructField
+		if t.Kind() == reflect.Struct && t != timeType {
+			fields = yamlFields(t)
+		}
+		elem := func(key string) (reflect.Type, bool) {
+			if fields == nil {
+				return t.Elem(), true
+			}
+			f, ok := fields[key]
+			return f.Type, ok
+		}
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key := src.Content[i].Value
+			seen[key] = true
+			et, _ := elem(key)
+			if hasKey(dst, key) {
+				mergeNode(mappingValue(dst, key, src.Content[i+1].Kind), src.Content[i+1], et)
+			} else {
+				dst.Content = append(dst.Content, src.Content[i], src.Content[i+1])
+			}
+		}
+		kept := dst.Content[:0]
+		for i := 0; i+1 < len(dst.Content); i += 2 {
+			k, v := dst.Content[i], dst.Content[i+1]
+			if et, known := elem(k.Value); !seen[k.Value] && known && !(fields != nil && isZero(v, et)) {
+				continue
+			}
+			kept = append(kept, k, v)
+		}
+		dst.Content = kept
+	}
+}
+
+// sameValue reports whether two scalars decode to the same t, as "30m"
+// and "30m0s" do, so a value spelled differently in the file is kept.
+func sameValue(a, b *yaml.Node, t reflect.Type) bool {
+	va, vb := reflect.New(t), reflect.New(t)
+	return a.Decode(va.Interface()) == nil && b.Decode(vb.Interface()) == nil && reflect.DeepEqual(va.Elem().Interface(), vb.Elem().Interface())
+}
+
+// isZero reports whether n holds the zero value of t, which an omitempty
+// setting leaves out when encoding.
+func isZero(n *yaml.Node, t reflect.Type) bool {
+	v := reflect.New(t)
+	return n.Decode(v.Interface()) == nil && v.Elem().IsZero()
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file
+// beside it and renaming it into place, so a crash or a concurrent reader
+// never sees a half-written file. An existing file keeps its permissions,
+// and a symlinked one, as from a dotfiles repo, stays a link: its target
+// is replaced.
+func writeFileAtomic(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProviderSpec describes a live provider the CLI ships an adapter for,
+// with the settings configs/providers.example.yaml gives it.
+type ProviderSpec struct {
+	Name     string
+	Kind     string // flights, stays, or status
+	Priority int
+	// EnvKeys maps labels to the environment variables holding keys.
+	EnvKeys map[string]string
+	SignUp  string
+}
+
+// LiveProviders lists the live providers, for setup to offer.
+var LiveProviders = []ProviderSpec{
+	{Name: "duffel", Kind: "flights", Priority: 80, EnvKeys: map[string]string{"apiToken": "DUFFEL_API_TOKEN"}, SignUp: "https://duffel.com"},
+	{Name: "expedia", Kind: "stays", Priority: 70, EnvKeys: map[string]string{"apiKey": "EXPEDIA_API_KEY", "apiSecret": "EXPEDIA_API_SECRET"}, SignUp: "https://developers.expediagroup.com"},
+	{Name: "airbnb", Kind: "stays", Priority: 60, EnvKeys: map[string]string{"affiliateId": "AIRBNB_AFFILIATE_ID"}, SignUp: "https://www.airbnb.com/associates"},
+	{Name: "aviationstack", Kind: "status", Priority: 60, EnvKeys: map[string]string{"apiKey": "AVIATIONSTACK_API_KEY"}, SignUp: "https://aviationstack.com"},
+}
+
+// KeyNames lists the environment variables that hold provider keys: the
+// live providers' and those the config's providers name in envKeys,
+// sorted.
+func (c *Config) KeyNames() []string {
+	var names []string
+	for _, spec := range LiveProviders {
+		for _, env := range spec.EnvKeys {
+			names = append(names, env)
+		}
+	}
+	for _, pc := range c.Providers {
+		for _, env := range pc.EnvKeys {
+			names = append(names, env)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// MissingKeys lists the provider's environment variables that are unset,
+// sorted.
+func (p ProviderSpec) MissingKeys() []string {
+	var missing []string
+	for _, env := range p.EnvKeys {
+		if os.Getenv(env) == "" {
+			missing = append(missing, env)
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// Path returns the config file commands write: TRAVEL_CONFIG, or
+// travel.yaml in paths.ConfigDir.
+func Path() (string, error) {
+	return writablePath()
+}
+
+// SaveSetup writes the mode and whether each provider is enabled to the
+// config file. A provider the file doesn't configure yet also gets its
+// priority and envKeys. Everything else in the file is kept.
+func SaveSetup(mode Mode, providers map[string]ProviderConfig) error {
+	return EditFile(func(root *yaml.Node) error {
+		setScalar(root, "mode", string(mode))
+		section := mappingValue(root, "providers", yaml.MappingNode)
+		if section.Kind != yaml.MappingNode {
+			*section = yaml.Node{Kind: yaml.MappingNode}
+		}
+		names := make([]string, 0, len(providers))
+		for name := range providers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			pc := providers[name]
+			node := mappingValue(section, name, yaml.MappingNode)
+			if node.Kind != yaml.MappingNode {
+				*node = yaml.Node{Kind: yaml.MappingNode}
+			}
+			setScalar(node, "enabled", strconv.FormatBool(!pc.Disabled()))
+			if !hasKey(node, "priority") {
+				setScalar(node, "priority", strconv.Itoa(pc.Priority))
+			}
+			if len(pc.EnvKeys) > 0 && !hasKey(node, "envKeys") {
+				if err := mappingValue(node, "envKeys", yaml.MappingNode).Encode(pc.EnvKeys); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func setScalar(m *yaml.Node, key, value string) {
+	v := mappingValue(m, key, yaml.ScalarNode)
+	*v = yaml.Node{Kind: yaml.ScalarNode, Value: value, LineComment: v.LineComment}
+}
+
+func hasKey(m *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveSecrets writes encrypted keys, by environment variable, to the
+// provider's secrets in the config file. A provider the file doesn't
+// configure yet is added enabled, so storing its keys doesn't take it
+// out of routing.
+func SaveSecrets(provider string, sealed map[string]string) error {
+	return EditFile(func(root *yaml.Node) error {
+		section := mappingValue(root, "providers", yaml.MappingNode)
+		if section.Kind != yaml.MappingNode {
+			*section = yaml.Node{Kind: yaml.MappingNode}
+		}
+		node := mappingValue(section, provider, yaml.MappingNode)
+		if node.Kind != yaml.MappingNode {
+			*node = yaml.Node{Kind: yaml.MappingNode}
+		}
+		if !hasKey(node, "enabled") {
+			setScalar(node, "enabled", "true")
+		}
+		secrets := mappingValue(node, "secrets", yaml.MappingNode)
+		if secrets.Kind != yaml.MappingNode {
+			*secrets = yaml.Node{Kind: yaml.MappingNode}
+		}
+		keys := make([]string, 0, len(sealed))
+		for key := range sealed {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			setScalar(secrets, key, sealed[key])
+		}
+		return nil
+	})
+}
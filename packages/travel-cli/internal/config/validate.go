@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is a mistake in a config file. Load ignores what it can't
+// read, so a problem means a setting silently has its default.
+type Problem struct {
+	Line    int    `json:"line,omitempty"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) Error() string {
+	msg := p.Message
+	if p.Key != "" {
+		msg = p.Key + ": " + msg
+	}
+	if p.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", p.Line, msg)
+	}
+	return msg
+}
+
+// choices lists the values allowed for settings with a fixed set, by key
+// pattern ("*" for any map key or list index). Empty leaves the default.
+var choices = map[string][]string{
+	"mode":                       {string(ModeMock), string(ModeLive), string(ModeHybrid)},
+	"log.level":                  {"debug", "info", "warn", "warning", "error", "off"},
+	"dedupe.flights":             {"exact", "codeshare-aware", "off"},
+	"dedupe.stays":               {"fuzzy", "exact", "off"},
+	"fx.source":                  {"static", "frankfurter"},
+	"onTime.source":              {"bts", "aviationstack"},
+	"schedules.*.kind":           {"flights", "stays"},
+	"notify.webhooks.*.events.*": {"alert.triggered", "schedule.ran", "alerts.digest"},
+	"notify.slack.events.*":      {"alert.triggered", "schedule.ran", "alerts.digest"},
+	"notify.discord.events.*":    {"alert.triggered", "schedule.ran", "alerts.digest"},
+	"notify.email.events.*":      {"alert.triggered", "schedule.ran", "alerts.digest"},
+}
+
+// Validate checks config file contents against the settings Config
+// knows: unknown keys (suggesting the nearest known one), values of the
+// wrong type, and values outside a setting's fixed choices.
+func Validate(data []byte) []Problem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Problem{syntaxProblem(err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	problems := checkNode(doc.Content[0], configType, nil, nil)
+	if doc.Content[0].Kind == yaml.MappingNode {
+		if v, err := fileVersion(doc.Content[0]); err == nil && v > CurrentVersion {
+			problems = append(problems, Problem{Key: "version", Message: fmt.Sprintf("version %d is newer than this travel reads (up to %d); upgrade travel", v, CurrentVersion)})
+		}
+	}
+	return problems
+}
+
+var yamlLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+func syntaxProblem(err error) Problem {
+	if m := yamlLine.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return Problem{Line: line, Message: m[2]}
+	}
+	return Problem{Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+}
+
+// checkNode checks n against the setting of type t at path, whose
+// pattern has map keys and list indexes replaced by "*".
+func checkNode(n *yaml.Node, t reflect.Type, path, pattern []string) []Problem {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.Tag == "!!null" {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	problem := func(format string, args ...interface{}) []Problem {
+		return []Problem{{Line: n.Line, Key: strings.Join(path, "."), Message: fmt.Sprintf(format, args...)}}
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && t != timeType:
+		if n.Kind != yaml.MappingNode {
+			return problem("want a group of settings, got %s", describe(n))
+		}
+		fields := yamlFields(t)
+		var problems []Problem
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			f, ok := fields[k.Value]
+			if !ok {
+				err := unknownSetting(strings.Join(with(path, k.Value), "."), k.Value, fields)
+				problems = append(problems, Problem{Line: k.Line, Message: err.Error()})
+				continue
+			}
+			problems = append(problems, checkNode(v, f.Type, with(path, k.Value), with(pattern, k.Value))...)
+		}
+		return problems
+	case t.Kind() == reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			return problem("want a mapping, got %s", describe(n))
+		}
+		var problems []Problem
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			problems = append(problems, checkNode(n.Content[i+1], t.Elem(), with(path, n.Content[i].Value), with(pattern, "*"))...)
+		}
+		return problems
+	case t.Kind() == reflect.Slice:
+		if n.Kind != yaml.SequenceNode {
+			return problem("want a list, got %s", describe(n))
+		}
+		var problems []Problem
+		for i, item := range n.Content {
+			problems = append(problems, checkNode(item, t.Elem(), with(path, strconv.Itoa(i)), with(pattern, "*"))...)
+		}
+		return problems
+	}
+
+	if n.Kind != yaml.ScalarNode {
+		return problem("want %s, got %s", describeType(t), describe(n))
+	}
+	if err := n.Decode(reflect.New(t).Interface()); err != nil {
+		return problem("want %s, got %q", describeType(t), n.Value)
+	}
+	if allowed, ok := choices[strings.Join(pattern, ".")]; ok && n.Value != "" {
+		if slices.Contains(allowed, n.Value) {
+			return nil
+		}
+		return problem("%q is not one of %s", n.Value, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+func with(path []string, part string) []string {
+	return append(path[:len(path):len(path)], part)
+}
+
+func describe(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return strconv.Quote(n.Value)
+}
+
+func describeType(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "a duration such as 30s or 1h"
+	case t == timeType:
+		return "a time such as 2026-06-01T09:00:00Z"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int64:
+		return "a whole number"
+	case reflect.Float64:
+		return "a number"
+	}
+	return "text"
+}
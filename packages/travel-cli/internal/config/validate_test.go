@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	data := `mode: hybird
+providers:
+  duffel:
+    enabled: true
+    priorty: 80
+warmup:
+  timeout: soon
+dedupe:
+  flights: codeshare-aware
+notify:
+  slack:
+    events: [alert.triggered, price.dropped]
+schedules:
+  - name: june
+    cron: "@daily"
+    kind: flights
+    adults: two
+`
+	got := map[string]Problem{}
+	for _, p := range Validate([]byte(data)) {
+		got[p.Error()] = p
+	}
+	for _, want := range []string{
+		`line 1: mode: "hybird" is not one of mock, live, hybrid`,
+		"line 5: unknown setting providers.duffel.priorty (did you mean priority?)",
+		`line 7: warmup.timeout: want a duration such as 30s or 1h, got "soon"`,
+		`line 12: notify.slack.events.1: "price.dropped" is not one of alert.triggered, schedule.ran, alerts.digest`,
+		`line 17: schedules.0.adults: want a whole number, got "two"`,
+	} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("missing problem %q", want)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("got %d problems, want 5: %v", len(got), got)
+	}
+
+	if problems := Validate([]byte("mode: [live\n")); len(problems) != 1 || problems[0].Line == 0 {
+		t.Errorf("syntax error problems = %v", problems)
+	}
+	if problems := Validate(nil); len(problems) != 0 {
+		t.Errorf("empty file problems = %v", problems)
+	}
+}
+
+func TestGet(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Schedules = []ScheduleConfig{{Name: "june", Cron: "@daily"}}
+
+	for key, want := range map[string]interface{}{
+		"mode":                            "mock",
+		"providers.mock_flights.priority": 100,
+		"schedules.0.cron":                "@daily",
+		"ranking.personalized":            false,
+		"schedules.3.cron":                nil,
+	} {
+		if got, err := cfg.Get(key); err != nil || got != want {
+			t.Errorf("Get(%q) = %v, %v; want %v", key, got, err, want)
+		}
+	}
+	if _, err := cfg.Get("warmup.enabeld"); err == nil || !strings.Contains(err.Error(), "did you mean enabled?") {
+		t.Errorf("misspelled key error = %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/aircraft"
+)
+
+// normalizeAircraft names each offer's aircraft as the bundled aircraft
+// list does, whether the provider gave its IATA code, ICAO designator, or
+// name, and sets economy offers' seat pitch to the type's typical one
+// when the provider didn't give it. Types the list lacks are left as the
+// provider named them.
+func normalizeAircraft(flights []FlightOffer) {
+	for i := range flights {
+		f := &flights[i]
+		if f.Aircraft == nil {
+			continue
+		}
+		a, err := aircraft.Lookup(f.Aircraft.Code)
+		if err != nil {
+			if a, err = aircraft.Lookup(f.Aircraft.Name); err != nil {
+				continue
+			}
+		}
+		f.Aircraft = &Aircraft{Code: a.Code, Name: a.Name, Widebody: a.Widebody}
+		if f.SeatPitch == 0 && (f.CabinClass == "" || strings.EqualFold(f.CabinClass, "economy")) {
+			f.SeatPitch = a.SeatPitch
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+type equipmentFlightAdapter struct {
+	fakeFlightAdapter
+	offers []FlightOffer
+}
+
+func (e *equipmentFlightAdapter) SearchFlights(req FlightSearchRequest) ([]FlightOffer, error) {
+	return e.offers, nil
+}
+
+func TestOrchestrator_AircraftAndWifi(t *testing.T) {
+	yes, no := true, false
+	depart := time.Date(2026, 6, 12, 9, 0, 0, 0, time.UTC)
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&equipmentFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, offers: []FlightOffer{
+		{Airline: "AC", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 500, Aircraft: &Aircraft{Code: "B789"}, WifiAvailable: &yes},
+		{Airline: "AF", FlightNumber: "AF347", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 450, Aircraft: &Aircraft{Name: "A350"}, WifiAvailable: &no},
+		{Airline: "AC", FlightNumber: "AC872", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 400, CabinClass: "business", Aircraft: &Aircraft{Code: "XYZ", Name: "Mystery Jet"}},
+	}})
+	req := FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", MaxResults: 10}
+
+	result, err := NewOrchestrator(router).SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]FlightOffer{}
+	for _, f := range result.Flights {
+		got[f.FlightNumber] = f
+	}
+	if a := got["AC870"].Aircraft; a == nil || *a != (Aircraft{Code: "789", Name: "Boeing 787-9", Widebody: true}) || got["AC870"].SeatPitch != 31 {
+		t.Errorf("AC870 = %+v, pitch %d", a, got["AC870"].SeatPitch)
+	}
+	if a := got["AF347"].Aircraft; a == nil || a.Code != "359" {
+		t.Errorf("AF347 aircraft = %+v, want 359", a)
+	}
+	if f := got["AC872"]; f.Aircraft.Name != "Mystery Jet" || f.SeatPitch != 0 {
+		t.Errorf("AC872 = %+v, pitch %d; want the provider's name and no pitch", f.Aircraft, f.SeatPitch)
+	}
+
+	req.Wifi = true
+	result, err = NewOrchestrator(router).SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Flights) != 1 || result.Flights[0].FlightNumber != "AC870" {
+		t.Errorf("wifi flights = %+v, want AC870 only", result.Flights)
+	}
+}
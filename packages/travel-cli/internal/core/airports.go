@@ -0,0 +1,68 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// ErrUnknownPlace is returned for a flight search from or to something
+// that is neither an airport code nor a city with airports.
+var ErrUnknownPlace = errors.New("not an airport code or a city with airports")
+
+var iataCode = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+// IsAirportCode reports whether s is written as an IATA airport or city
+// code, known or not.
+func IsAirportCode(s string) bool {
+	return iataCode.MatchString(s)
+}
+
+// ResolveAirports replaces city names in r's From and To with their
+// metropolitan code, or their airport's when they have one, and returns
+// the cities it read with the others each may mean. Codes pass, known or
+// not, since the bundled list only covers major airports.
+func (r *FlightSearchRequest) ResolveAirports() ([]ResolvedPlace, error) {
+	var resolved []ResolvedPlace
+	for _, f := range []struct {
+		name  string
+		value *string
+	}{{"from", &r.From}, {"to", &r.To}} {
+		code, place, err := resolveAirport(f.name, *f.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, err)
+		}
+		*f.value = code
+		if place != nil {
+			resolved = append(resolved, *place)
+		}
+	}
+	return resolved, nil
+}
+
+// resolveAirport reads the value of an airport field.
+func resolveAirport(field, value string) (string, *ResolvedPlace, error) {
+	if IsAirportCode(value) {
+		code := strings.ToUpper(value)
+		if _, err := airports.Find(code); err != nil {
+			logging.Logger().Info("airport code not in the bundled list; searching it as given", "code", code)
+		}
+		return code, nil, nil
+	}
+	matches, err := airports.Cities(value)
+	if err != nil {
+		return "", nil, fmt.Errorf("%q is %w", value, ErrUnknownPlace)
+	}
+	place := &ResolvedPlace{Field: field, Input: value, PlaceMatch: PlaceMatch(matches[0])}
+	for _, m := range matches[1:] {
+		place.Alternatives = append(place.Alternatives, PlaceMatch(m))
+	}
+	if len(place.Alternatives) > 0 {
+		logging.Logger().Warn("city name is ambiguous; see resolved in the result", "field", field, "city", value, "code", place.Code, "country", place.CountryCode)
+	}
+	return place.Code, place, nil
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveAirport(t *testing.T) {
+	for value, want := range map[string]string{
+		"cdg":         "CDG",
+		"ZZZ":         "ZZZ", // not in the bundled list, searched as given
+		"Montreal":    "YMQ",
+		"Paris":       "PAR",
+		"Mexico City": "MEX",
+	} {
+		got, _, err := resolveAirport("from", value)
+		if err != nil || got != want {
+			t.Errorf("resolveAirport(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"Atlantis", "CDG1", ""} {
+		if got, _, err := resolveAirport("from", value); err == nil {
+			t.Errorf("resolveAirport(%q) = %q, want an error", value, got)
+		}
+	}
+}
+
+func TestFlightSearchRequest_ResolveAirports(t *testing.T) {
+	req := FlightSearchRequest{From: "YUL", To: "London"}
+	resolved, err := req.ResolveAirports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.From != "YUL" || req.To != "LON" {
+		t.Errorf("route = %s-%s, want YUL-LON", req.From, req.To)
+	}
+	want := []ResolvedPlace{{
+		Field:        "to",
+		Input:        "London",
+		PlaceMatch:   PlaceMatch{Code: "LON", City: "London", CountryCode: "GB", Airports: []string{"LHR", "LGW", "STN", "LTN", "LCY"}},
+		Alternatives: []PlaceMatch{{Code: "YXU", City: "London", CountryCode: "CA", Airports: []string{"YXU"}}},
+	}}
+	if !reflect.DeepEqual(resolved, want) {
+		t.Errorf("resolved = %+v, want %+v", resolved, want)
+	}
+
+	req = FlightSearchRequest{From: "YUL", To: "Atlantis"}
+	if _, err := req.ResolveAirports(); !errors.Is(err, ErrUnknownPlace) || !strings.HasPrefix(err.Error(), "to: ") {
+		t.Errorf("err = %v, want ErrUnknownPlace naming the to field", err)
+	}
+}
@@ -0,0 +1,20 @@
+package core
+
+import "github.com/beetlebot/travel-cli/internal/baggage"
+
+// linkBaggagePolicies sets each offer's baggage policy link to the page
+// of the airline selling it, whose rules the ticket follows, unless the
+// provider gave one. Airlines the bundled baggage list lacks get none.
+func linkBaggagePolicies(flights []FlightOffer) {
+	for i := range flights {
+		f := &flights[i]
+		if f.BaggagePolicyURL != "" {
+			continue
+		}
+		if f.MarketedBy != nil {
+			f.BaggagePolicyURL = baggage.URL(f.MarketedBy.Code)
+		} else {
+			f.BaggagePolicyURL = baggage.URL(f.Airline)
+		}
+	}
+}
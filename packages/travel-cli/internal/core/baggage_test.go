@@ -0,0 +1,30 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinkBaggagePolicies(t *testing.T) {
+	flights := []FlightOffer{
+		// A codeshare follows the policy of the airline selling it.
+		{Airline: "United Airlines", FlightNumber: "UA8123", MarketedBy: &Carrier{Code: "UA"}, OperatedBy: &Carrier{Code: "AC"}},
+		{Airline: "Air France", FlightNumber: "AF347"},
+		{Airline: "Acme Air", FlightNumber: "ZZ12"},
+		{Airline: "Air Canada", FlightNumber: "AC870", BaggagePolicyURL: "https://example.com/bags"},
+	}
+	linkBaggagePolicies(flights)
+
+	if u := flights[0].BaggagePolicyURL; !strings.Contains(u, "united.com") {
+		t.Errorf("UA8123 baggage policy = %q, want United's", u)
+	}
+	if u := flights[1].BaggagePolicyURL; !strings.Contains(u, "airfrance") {
+		t.Errorf("AF347 baggage policy = %q, want Air France's", u)
+	}
+	if u := flights[2].BaggagePolicyURL; u != "" {
+		t.Errorf("ZZ12 baggage policy = %q, want none", u)
+	}
+	if u := flights[3].BaggagePolicyURL; u != "https://example.com/bags" {
+		t.Errorf("AC870 baggage policy = %q, want the provider's", u)
+	}
+}
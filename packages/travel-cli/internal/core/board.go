@@ -0,0 +1,64 @@
+package core
+
+import "strings"
+
+// Board types: the meals a stay's rate includes.
+const (
+	BoardRoomOnly     = "room-only"
+	BoardBreakfast    = "breakfast"
+	BoardHalfBoard    = "half-board"    // breakfast and dinner
+	BoardFullBoard    = "full-board"    // breakfast, lunch, and dinner
+	BoardAllInclusive = "all-inclusive" // meals and drinks
+)
+
+// boardCodes maps the board codes and names providers use, lowercased
+// with spaces and underscores as hyphens, to board types. The one- and
+// two-letter codes are the hotel industry's, as Hotelbeds and most bed
+// banks send them; the plans are the North American names.
+var boardCodes = map[string]string{
+	"ro":                 BoardRoomOnly,
+	"room-only":          BoardRoomOnly,
+	"sc":                 BoardRoomOnly, // self catering
+	"ep":                 BoardRoomOnly, // European plan
+	"bb":                 BoardBreakfast,
+	"breakfast":          BoardBreakfast,
+	"bed-and-breakfast":  BoardBreakfast,
+	"free-breakfast":     BoardBreakfast,
+	"breakfast-included": BoardBreakfast,
+	"cp":                 BoardBreakfast, // continental plan
+	"hb":                 BoardHalfBoard,
+	"half-board":         BoardHalfBoard,
+	"map":                BoardHalfBoard, // modified American plan
+	"fb":                 BoardFullBoard,
+	"full-board":         BoardFullBoard,
+	"ap":                 BoardFullBoard, // American plan
+	"ai":                 BoardAllInclusive,
+	"all-inclusive":      BoardAllInclusive,
+}
+
+// NormalizeBoard maps a provider's board code or name, such as BB or
+// "Half Board", to a board type, or "" for one it doesn't know.
+func NormalizeBoard(code string) string {
+	key := strings.ToLower(strings.TrimSpace(code))
+	key = strings.NewReplacer("_", "-", " ", "-", "&", "and").Replace(key)
+	return boardCodes[key]
+}
+
+// IncludesBreakfast reports whether a board type includes breakfast.
+func IncludesBreakfast(board string) bool {
+	switch board {
+	case BoardBreakfast, BoardHalfBoard, BoardFullBoard, BoardAllInclusive:
+		return true
+	}
+	return false
+}
+
+// normalizeBoards maps the board each provider gave its stays to a board
+// type, so stays read and filter alike whichever provider sent them.
+func normalizeBoards(stays []StayOffer) {
+	for i := range stays {
+		if stays[i].Board != "" {
+			stays[i].Board = NormalizeBoard(stays[i].Board)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+func TestNormalizeBoard(t *testing.T) {
+	for code, want := range map[string]string{
+		"RO":                 BoardRoomOnly,
+		"room_only":          BoardRoomOnly,
+		"BB":                 BoardBreakfast,
+		"Bed & Breakfast":    BoardBreakfast,
+		"free_breakfast":     BoardBreakfast,
+		"Half Board":         BoardHalfBoard,
+		"MAP":                BoardHalfBoard,
+		"FB":                 BoardFullBoard,
+		"all-inclusive":      BoardAllInclusive,
+		"continental brunch": "",
+	} {
+		if got := NormalizeBoard(code); got != want {
+			t.Errorf("NormalizeBoard(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestOrchestrator_SearchStaysBreakfast(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_stays", offers: []StayOffer{
+		{Name: "Bed and Breakfast", City: "Lisbon", Board: "BB", PricePerNight: 120},
+		{Name: "Half Board", City: "Lisbon", Board: "half_board", PricePerNight: 160},
+		{Name: "Room Only", City: "Lisbon", Board: "RO", PricePerNight: 90},
+		{Name: "Unsaid", City: "Lisbon", PricePerNight: 80},
+	}})
+	req := StaySearchRequest{City: "Lisbon", CheckIn: "2026-06-12", CheckOut: "2026-06-20", MaxResults: 10}
+
+	result, err := NewOrchestrator(router).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	boards := map[string]string{}
+	for _, st := range result.Stays {
+		boards[st.Name] = st.Board
+	}
+	if boards["Bed and Breakfast"] != BoardBreakfast || boards["Half Board"] != BoardHalfBoard || boards["Room Only"] != BoardRoomOnly || boards["Unsaid"] != "" {
+		t.Errorf("boards = %v, want the providers' codes mapped to board types", boards)
+	}
+
+	req.Breakfast = true
+	result, err = NewOrchestrator(router).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stays) != 2 {
+		t.Errorf("stays = %+v, want the two that include breakfast", result.Stays)
+	}
+}
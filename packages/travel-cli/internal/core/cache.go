@@ -0,0 +1,81 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// ResultCache keeps search results between runs, each kind for as long
+// as it stays fresh. cache.Store is one.
+type ResultCache interface {
+	Get(resource, key string) ([]byte, bool)
+	Set(resource, key string, data []byte) error
+}
+
+// WithCache answers a search from c when the same search, in the same
+// mode and over the same providers, is still fresh there, and stores
+// results every provider answered. Flight searches are cached as
+// config.CacheFlights and stay searches and lookups as
+// config.CacheStays. A nil c turns caching off.
+func (o *Orchestrator) WithCache(c ResultCache) *Orchestrator {
+	o.cache = c
+	return o
+}
+
+// cacheKey identifies a search by kind, mode, the providers queried in
+// dispatch order, and the request.
+func (o *Orchestrator) cacheKey(kind string, req interface{}, providers []string) string {
+	data, _ := json.Marshal(struct {
+		Kind      string      `json:"kind"`
+		Mode      string      `json:"mode"`
+		Providers []string    `json:"providers"`
+		Request   interface{} `json:"request"`
+	}{kind, string(o.router.cfg.Mode), providers, req})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// cached returns the stored resource result for key, marked fromCache, with query
+// as its query.
+func (o *Orchestrator) cached(resource, key string, query interface{}) *SearchResult {
+	if o.cache == nil {
+		return nil
+	}
+	data, ok := o.cache.Get(resource, key)
+	if !ok {
+		return nil
+	}
+	result, err := DecodeResult(data)
+	if err != nil {
+		return nil
+	}
+	result.Query = query
+	result.FromCache = true
+	return result
+}
+
+// store caches result under key unless a provider failed or was skipped,
+// so a later search tries that provider again.
+func (o *Orchestrator) store(resource, key string, result *SearchResult) {
+	if o.cache == nil || len(result.Errors) > 0 {
+		return
+	}
+	data, err := json.Marshal(result)
+	if err == nil {
+		err = o.cache.Set(resource, key, data)
+	}
+	if err != nil {
+		logging.Logger().Debug("result not cached", "error", err)
+	}
+}
+
+func adapterNames[A interface{ Name() string }](adapters []A) []string {
+	names := make([]string, len(adapters))
+	for i, a := range adapters {
+		names[i] = a.Name()
+	}
+	return names
+}
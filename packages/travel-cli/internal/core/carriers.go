@@ -0,0 +1,47 @@
+package core
+
+import "github.com/beetlebot/travel-cli/internal/airlines"
+
+// normalizeCarriers names each offer's airline as the bundled airline
+// list does, so offers from providers that write "AC", "ACA", or "AIR
+// CANADA" dedupe and group together, and sets MarketedBy from the flight
+// number's designator, or the airline when the number has none, and
+// OperatedBy to MarketedBy unless the provider set it. Airlines the list
+// lacks are left as the provider named them.
+func normalizeCarriers(flights []FlightOffer) {
+	for i := range flights {
+		f := &flights[i]
+		named, _ := airlines.Lookup(f.Airline)
+		if named != nil {
+			f.Airline = named.Name
+		}
+		marketer, err := airlines.ByFlightNumber(f.FlightNumber)
+		if err != nil {
+			marketer = named
+		}
+		if marketer != nil {
+			f.MarketedBy = carrier(marketer)
+		}
+		switch {
+		case f.OperatedBy == nil && f.MarketedBy != nil:
+			operator := *f.MarketedBy
+			f.OperatedBy = &operator
+		case f.OperatedBy != nil:
+			if a, err := airlines.Lookup(f.OperatedBy.Code); err == nil {
+				f.OperatedBy = carrier(a)
+			} else if a, err := airlines.Lookup(f.OperatedBy.Name); err == nil {
+				f.OperatedBy = carrier(a)
+			}
+		}
+	}
+}
+
+func carrier(a *airlines.Airline) *Carrier {
+	return &Carrier{Code: a.Code, Name: a.Name, Alliance: a.Alliance, LowCost: a.LowCost}
+}
+
+// soldBy reports whether f's marketing carrier belongs to alliance, the
+// airline that sells the ticket deciding where miles and status count.
+func soldBy(f FlightOffer, alliance string) bool {
+	return f.MarketedBy != nil && f.MarketedBy.Alliance == alliance
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeCarriers(t *testing.T) {
+	flights := []FlightOffer{
+		{Airline: "AIR CANADA", FlightNumber: "AC870"},
+		// A codeshare: sold as United, flown by Air Canada.
+		{Airline: "United", FlightNumber: "UA8123", OperatedBy: &Carrier{Code: "ACA"}},
+		{Airline: "ryanair", FlightNumber: "1234"},
+		{Airline: "Acme Air", FlightNumber: "ZZ12"},
+	}
+	normalizeCarriers(flights)
+
+	ac := &Carrier{Code: "AC", Name: "Air Canada", Alliance: "star"}
+	ua := &Carrier{Code: "UA", Name: "United Airlines", Alliance: "star"}
+	fr := &Carrier{Code: "FR", Name: "Ryanair", LowCost: true}
+	for i, want := range []struct {
+		airline                string
+		marketedBy, operatedBy *Carrier
+	}{
+		{"Air Canada", ac, ac},
+		{"United Airlines", ua, ac},
+		{"Ryanair", fr, fr},
+		{"Acme Air", nil, nil},
+	} {
+		f := flights[i]
+		if f.Airline != want.airline || !reflect.DeepEqual(f.MarketedBy, want.marketedBy) || !reflect.DeepEqual(f.OperatedBy, want.operatedBy) {
+			t.Errorf("flight %d = %s, marketed by %+v, operated by %+v; want %s, %+v, %+v",
+				i, f.Airline, f.MarketedBy, f.OperatedBy, want.airline, want.marketedBy, want.operatedBy)
+		}
+	}
+}
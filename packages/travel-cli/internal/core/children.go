@@ -0,0 +1,38 @@
+package core
+
+import "fmt"
+
+// Child age bands. Stay providers commonly let infants stay free and
+// charge young children less than older ones.
+const (
+	ChildBandInfant  = "infant"   // under 2
+	ChildBandUnder6  = "under-6"  // 2 to 5
+	ChildBandUnder12 = "under-12" // 6 to 11
+	ChildBandTeen    = "teen"     // 12 to 17
+)
+
+// MaxChildAge is the oldest age priced as a child; older guests count
+// toward Guests.
+const MaxChildAge = 17
+
+// ChildAgeBand returns the pricing band for a child of the given age.
+func ChildAgeBand(age int) string {
+	switch {
+	case age < 2:
+		return ChildBandInfant
+	case age < 6:
+		return ChildBandUnder6
+	case age < 12:
+		return ChildBandUnder12
+	}
+	return ChildBandTeen
+}
+
+func validateChildrenAges(ages []int) error {
+	for _, age := range ages {
+		if age < 0 || age > MaxChildAge {
+			return fmt.Errorf("child age %d out of range (0-%d; count older guests in guests)", age, MaxChildAge)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import "testing"
+
+func TestChildAgeBand(t *testing.T) {
+	cases := map[int]string{
+		0:  ChildBandInfant,
+		1:  ChildBandInfant,
+		2:  ChildBandUnder6,
+		5:  ChildBandUnder6,
+		6:  ChildBandUnder12,
+		11: ChildBandUnder12,
+		12: ChildBandTeen,
+		17: ChildBandTeen,
+	}
+	for age, want := range cases {
+		if got := ChildAgeBand(age); got != want {
+			t.Errorf("ChildAgeBand(%d) = %q, want %q", age, got, want)
+		}
+	}
+}
+
+func TestStaySearchRequestValidateChildrenAges(t *testing.T) {
+	req := StaySearchRequest{City: "Rome", CheckIn: "2026-07-03", CheckOut: "2026-07-10", ChildrenAges: []int{4, 9}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("valid ages: %v", err)
+	}
+	for _, ages := range [][]int{{-1}, {4, 18}} {
+		req.ChildrenAges = ages
+		if err := req.Validate(); err == nil {
+			t.Errorf("ages %v: expected error", ages)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/visas"
+)
+
+// WarningUnknownCurrency is the OfferWarning code for an offer priced in
+// a currency without an exchange rate.
+const WarningUnknownCurrency = "unknown_currency"
+
+// CombineOffers packages a flight and a stay into one trip price, warning
+// when either offer is past its expiry or price guarantee at now. The
+// orchestrator converts offers to dollars as they arrive; one it couldn't
+// convert is counted as quoted, with a warning.
+func CombineOffers(flight FlightOffer, stay StayOffer, now time.Time) CombinedOffer {
+	combined := CombinedOffer{
+		FlightOfferID: flight.ID,
+		StayOfferID:   stay.ID,
+		FXDate:        cmp.Or(flight.FXDate, stay.FXDate),
+	}
+	for _, part := range []struct {
+		id       string
+		currency string
+	}{
+		{flight.ID, flight.Currency},
+		{stay.ID, stay.Currency},
+	} {
+		if part.currency != "" && !strings.EqualFold(part.currency, fx.USD) {
+			combined.Warnings = append(combined.Warnings, OfferWarning{
+				Code:    WarningUnknownCurrency,
+				OfferID: part.id,
+				Message: fmt.Sprintf("offer %s is priced in %s, which has no exchange rate; its price is counted unconverted", part.id, part.currency),
+			})
+		}
+	}
+	combined.TotalPriceUSD = math.Round((flight.PriceUSD+stay.TotalPriceUSD)*100) / 100
+	combined.Warnings = AppendExpiryWarning(combined.Warnings, flight.CheckExpiry(now))
+	combined.Warnings = AppendExpiryWarning(combined.Warnings, stay.CheckExpiry(now))
+	return combined
+}
+
+// CheckVisa sets c's visa hint for a traveler with a passport of
+// nationality, visiting the country flight lands in, or stay's city's
+// when the airport list lacks it. Nothing is set when nationality is
+// empty or the bundled visa list doesn't cover the trip.
+func (c *CombinedOffer) CheckVisa(nationality string, flight FlightOffer, stay StayOffer) {
+	if nationality == "" {
+		return
+	}
+	var country string
+	if a, err := airports.Find(flight.To); err == nil {
+		country = a.CountryCode
+	} else if d, err := destinations.Lookup(stay.City); err == nil {
+		country = d.CountryCode
+	}
+	r, err := visas.Lookup(nationality, country)
+	if err != nil {
+		return
+	}
+	c.VisaRequired = &r.VisaRequired
+	c.EntryRequirement = r.Requirement
+}
+
+// AppendExpiryWarning turns an *OfferExpiredError into a warning; expired
+// offers are reported, not refused, so callers can decide.
+func AppendExpiryWarning(warnings []OfferWarning, err error) []OfferWarning {
+	var expired *OfferExpiredError
+	if errors.As(err, &expired) {
+		warnings = append(warnings, expired.Warning())
+	}
+	return warnings
+}
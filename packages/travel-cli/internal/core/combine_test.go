@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCombineOffers_Currencies(t *testing.T) {
+	flight := FlightOffer{ID: "f_1", PriceUSD: 500, Currency: "USD"}
+	stay := StayOffer{ID: "s_1", TotalPriceUSD: 500, Currency: "USD", FXDate: "2026-10-16"}
+
+	got := CombineOffers(flight, stay, time.Now())
+	if got.TotalPriceUSD != 1000 || got.FXDate != "2026-10-16" || len(got.Warnings) != 0 {
+		t.Errorf("combined = %+v, want 1000 USD with the stay's rates date", got)
+	}
+
+	stay.Currency = "XYZ"
+	got = CombineOffers(flight, stay, time.Now())
+	if got.TotalPriceUSD != 1000 || len(got.Warnings) != 1 || got.Warnings[0].Code != WarningUnknownCurrency || got.Warnings[0].OfferID != "s_1" {
+		t.Errorf("combined = %+v, want the stay counted unconverted with a warning", got)
+	}
+}
+
+func TestCombinedOffer_CheckVisa(t *testing.T) {
+	flight := FlightOffer{ID: "f_1", To: "NRT"}
+	stay := StayOffer{ID: "s_1", City: "Tokyo"}
+
+	var c CombinedOffer
+	c.CheckVisa("IN", flight, stay)
+	if c.VisaRequired == nil || !*c.VisaRequired || c.EntryRequirement != "visa_required" {
+		t.Errorf("IN to Japan = %+v, want visa required", c)
+	}
+
+	// Without the airport in the list, the stay's city tells the country.
+	c = CombinedOffer{}
+	c.CheckVisa("CA", FlightOffer{To: "XXX"}, StayOffer{City: "Paris"})
+	if c.VisaRequired == nil || *c.VisaRequired || c.EntryRequirement != "visa_free" {
+		t.Errorf("CA to Paris = %+v, want visa free", c)
+	}
+
+	c = CombinedOffer{}
+	c.CheckVisa("", flight, stay)
+	if c.VisaRequired != nil {
+		t.Errorf("no nationality = %+v, want no hint", c)
+	}
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// WithRates converts offers priced in another currency to dollars as they
+// arrive, so every offer is ranked, filtered, and combined on one basis.
+// rates is called the first time an offer needs converting.
+func (o *Orchestrator) WithRates(rates func() fx.Rates) *Orchestrator {
+	o.rates = rates
+	return o
+}
+
+// flightsToUSD converts each flight's prices to dollars; see WithRates.
+func (o *Orchestrator) flightsToUSD(batch []FlightOffer) {
+	for i := range batch {
+		f := &batch[i]
+		amounts := []*float64{&f.PriceUSD}
+		for j := range f.Prices {
+			amounts = append(amounts, &f.Prices[j].PriceUSD)
+		}
+		f.FXDate = o.toUSD(f.Source, &f.Currency, amounts...)
+	}
+}
+
+// staysToUSD is the stay counterpart of flightsToUSD.
+func (o *Orchestrator) staysToUSD(batch []StayOffer) {
+	for i := range batch {
+		s := &batch[i]
+		s.FXDate = o.toUSD(s.Source, &s.Currency, &s.PricePerNight, &s.TotalPriceUSD, &s.ChildrenPriceUSD)
+	}
+}
+
+// toUSD converts amounts, priced in *currency, to dollars and sets
+// *currency to USD, returning the date of the rates used. Amounts in a
+// currency without a rate are left as they are, with a warning.
+func (o *Orchestrator) toUSD(source string, currency *string, amounts ...*float64) string {
+	if *currency == "" || strings.EqualFold(*currency, fx.USD) || o.rates == nil {
+		return ""
+	}
+	rates := o.rates()
+	rate, ok := rates.Rate(*currency)
+	if !ok {
+		logging.Logger().Warn("offer price not converted to USD", "provider", source, "currency", *currency)
+		return ""
+	}
+	for _, a := range amounts {
+		*a = math.Round(*a/rate*100) / 100
+	}
+	*currency = fx.USD
+	return rates.Date
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/fx"
+)
+
+func TestOrchestrator_ConvertsOffersToUSD(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_stays", offers: []StayOffer{
+		{Name: "Canal House", City: "Lisbon", PricePerNight: 100, TotalPriceUSD: 800, Currency: "EUR"},
+		{Name: "Harbor Inn", City: "Lisbon", PricePerNight: 110, TotalPriceUSD: 880, Currency: "USD"},
+		{Name: "Odd Coin", City: "Lisbon", PricePerNight: 90, TotalPriceUSD: 720, Currency: "XYZ"},
+	}})
+	rates := fx.Rates{Base: fx.USD, Date: "2026-10-16", Rates: map[string]float64{"EUR": 0.8}}
+	orch := NewOrchestrator(router).WithRates(func() fx.Rates { return rates })
+
+	result, err := orch.SearchStays(StaySearchRequest{City: "Lisbon", CheckIn: "2026-06-12", CheckOut: "2026-06-20", MaxResults: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]StayOffer{}
+	for _, s := range result.Stays {
+		byName[s.Name] = s
+	}
+	if s := byName["Canal House"]; s.PricePerNight != 125 || s.TotalPriceUSD != 1000 || s.Currency != fx.USD || s.FXDate != "2026-10-16" {
+		t.Errorf("EUR stay = %+v, want 125/night and 1000 USD at the 2026-10-16 rates", s)
+	}
+	if s := byName["Harbor Inn"]; s.TotalPriceUSD != 880 || s.FXDate != "" {
+		t.Errorf("USD stay = %+v, want it unchanged", s)
+	}
+	if s := byName["Odd Coin"]; s.TotalPriceUSD != 720 || s.Currency != "XYZ" {
+		t.Errorf("stay without a rate = %+v, want it left as quoted", s)
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Dedupe strategies. Flights take off, exact, or codeshare-aware; stays
+// take off, exact, or fuzzy.
+const (
+	DedupeOff       = "off"
+	DedupeExact     = "exact"
+	DedupeCodeshare = "codeshare-aware"
+	DedupeFuzzy     = "fuzzy"
+)
+
+// DedupeStrategy selects how offers for the same trip from several
+// providers are merged. Empty fields use the defaults: exact for flights
+// (same carrier, flight number, and departure) and fuzzy for stays
+// (similar names at the same address or location).
+type DedupeStrategy struct {
+	Flights string `json:"flights,omitempty"`
+	Stays   string `json:"stays,omitempty"`
+}
+
+// Validate reports strategies that aren't recognized; those fall back to
+// the default.
+func (d DedupeStrategy) Validate() error {
+	var errs []error
+	switch d.Flights {
+	case "", DedupeOff, DedupeExact, DedupeCodeshare:
+	default:
+		errs = append(errs, fmt.Errorf("dedupe.flights: unknown strategy %q (use exact, codeshare-aware, or off)", d.Flights))
+	}
+	switch d.Stays {
+	case "", DedupeOff, DedupeExact, DedupeFuzzy:
+	default:
+		errs = append(errs, fmt.Errorf("dedupe.stays: unknown strategy %q (use fuzzy, exact, or off)", d.Stays))
+	}
+	return errors.Join(errs...)
+}
+
+func (d DedupeStrategy) flights() string {
+	switch d.Flights {
+	case DedupeOff, DedupeCodeshare:
+		return d.Flights
+	}
+	return DedupeExact
+}
+
+// stayMatcher returns how stays are matched, or nil when they are not
+// merged at all.
+func (d DedupeStrategy) stayMatcher() func(a, b StayOffer) bool {
+	switch d.Stays {
+	case DedupeOff:
+		return nil
+	case DedupeExact:
+		return sameListing
+	}
+	return sameProperty
+}
+
+// WithDedupe selects the dedupe strategies for flights and stays.
+func (o *Orchestrator) WithDedupe(d DedupeStrategy) *Orchestrator {
+	o.dedupe = d
+	return o
+}
+
+// sameListing is the exact stay match: identical normalized names and
+// addresses, or the same city when neither offer has an address.
+func sameListing(a, b StayOffer) bool {
+	if a.CheckIn != b.CheckIn || strings.Join(normalizeName(a.Name), " ") != strings.Join(normalizeName(b.Name), " ") {
+		return false
+	}
+	if a.Address != "" || b.Address != "" {
+		return normalizeAddress(a.Address) == normalizeAddress(b.Address)
+	}
+	return strings.EqualFold(a.City, b.City)
+}
+
+// dedupeStays merges stays that match, like DedupeStays; a nil match
+// keeps every offer.
+func dedupeStays(stays []StayOffer, match func(a, b StayOffer) bool) []StayOffer {
+	if match == nil {
+		return stays
+	}
+	var out []StayOffer
+	for _, s := range stays {
+		merged := false
+		for i := range out {
+			if match(out[i], s) {
+				out[i] = mergeStay(out[i], s)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlightMergerDedupeStrategies(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 18, 30, 0, 0, time.UTC)
+	arrive := depart.Add(7 * time.Hour)
+	batches := [][]FlightOffer{
+		{{ID: "a1", Source: "a", Airline: "Air Canada", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, ArriveTime: arrive, PriceUSD: 700}},
+		{
+			{ID: "b1", Source: "b", Airline: "Air Canada", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, ArriveTime: arrive, PriceUSD: 650},
+			// The Air France codeshare of the same flight.
+			{ID: "b2", Source: "b", Airline: "Air France", FlightNumber: "AF9250", From: "yul", To: "cdg", DepartTime: depart, ArriveTime: arrive, PriceUSD: 600},
+		},
+	}
+
+	for strategy, want := range map[string]int{DedupeExact: 2, DedupeCodeshare: 1, DedupeOff: 3} {
+		m := newFlightMerger(nil, strategy)
+		for _, b := range batches {
+			m.Add(b)
+		}
+		flights, _, _, err := m.Result(0, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(flights) != want {
+			t.Errorf("%s: got %d flights, want %d", strategy, len(flights), want)
+		}
+		if strategy == DedupeCodeshare && (flights[0].ID != "b2" || len(flights[0].Prices) != 3) {
+			t.Errorf("codeshare merge = %+v", flights[0])
+		}
+	}
+}
+
+func TestDedupeStaysStrategies(t *testing.T) {
+	stays := []StayOffer{
+		{Source: "a", Name: "Grand Lumière Palace", Address: "1 Rue de Rivoli", CheckIn: "2026-06-12", TotalPriceUSD: 300},
+		{Source: "b", Name: "The Grand Lumiere Palace Hotel", Address: "1 rue de Rivoli", CheckIn: "2026-06-12", TotalPriceUSD: 280},
+		{Source: "c", Name: "Grand Lumiere Palace Paris", Address: "1 Rue de Rivoli", CheckIn: "2026-06-12", TotalPriceUSD: 290},
+	}
+	for strategy, want := range map[string]int{DedupeFuzzy: 1, DedupeExact: 2, DedupeOff: 3, "": 1} {
+		got := dedupeStays(append([]StayOffer(nil), stays...), DedupeStrategy{Stays: strategy}.stayMatcher())
+		if len(got) != want {
+			t.Errorf("%q: got %d stays, want %d", strategy, len(got), want)
+		}
+	}
+}
+
+func TestDedupeStrategyValidate(t *testing.T) {
+	if err := (DedupeStrategy{Flights: DedupeCodeshare, Stays: DedupeExact}).Validate(); err != nil {
+		t.Errorf("valid strategy rejected: %v", err)
+	}
+	if err := (DedupeStrategy{Flights: DedupeFuzzy, Stays: DedupeCodeshare}).Validate(); err == nil {
+		t.Error("want errors for fuzzy flights and codeshare-aware stays")
+	}
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ApplyDefaults fills optional fields the same way for every entry point
+// (CLI flags, server requests, batch files).
+func (r *FlightSearchRequest) ApplyDefaults() {
+	if r.Adults == 0 {
+		r.Adults = 1
+	}
+	if r.CabinClass == "" {
+		r.CabinClass = "economy"
+	}
+	if r.MaxResults == 0 {
+		r.MaxResults = 10
+	}
+}
+
+func (r FlightSearchRequest) Validate() error {
+	if r.From == "" || r.To == "" || r.DepartDate == "" {
+		return errors.New("from, to, and departDate are required")
+	}
+	if r.MaxStops != nil && *r.MaxStops < 0 {
+		return errors.New("maxStops must not be negative")
+	}
+	if r.MaxPriceUSD < 0 {
+		return errors.New("maxPriceUSD must not be negative")
+	}
+	return nil
+}
+
+func (r *StaySearchRequest) ApplyDefaults() {
+	if r.Guests == 0 {
+		r.Guests = 2
+	}
+	if r.Rooms == 0 {
+		r.Rooms = 1
+	}
+	if r.MaxResults == 0 {
+		r.MaxResults = 10
+	}
+	if r.StayType == "" {
+		r.StayType = "any"
+	}
+	if r.Near != "" && r.RadiusKm == 0 {
+		r.RadiusKm = DefaultNearRadiusKm
+	}
+}
+
+func (r StaySearchRequest) Validate() error {
+	if r.City == "" || r.CheckIn == "" || r.CheckOut == "" {
+		return errors.New("city, checkIn, and checkOut are required")
+	}
+	if r.RadiusKm < 0 {
+		return errors.New("radiusKm must not be negative")
+	}
+	if r.RadiusKm > 0 && r.Near == "" {
+		return errors.New("radiusKm needs near")
+	}
+	if r.MinRating < 0 || r.MinRating > 5 {
+		return errors.New("minRating must be between 0 and 5")
+	}
+	if r.MinReviews < 0 {
+		return errors.New("minReviews must not be negative")
+	}
+	switch r.Sort {
+	case "", SortBest:
+	case SortDistance:
+		if r.Near == "" {
+			return errors.New("sort distance needs near")
+		}
+	default:
+		return fmt.Errorf("cannot sort stays by %q (use best, distance)", r.Sort)
+	}
+	return validateChildrenAges(r.ChildrenAges)
+}
+
+func (r *StayLookupRequest) ApplyDefaults() {
+	if r.Guests == 0 {
+		r.Guests = 2
+	}
+	if r.Rooms == 0 {
+		r.Rooms = 1
+	}
+}
+
+func (r StayLookupRequest) Validate() error {
+	if r.CheckIn == "" || r.CheckOut == "" {
+		return errors.New("checkIn and checkOut are required")
+	}
+	if r.PropertyID == "" && (r.Chain == "" || r.City == "") {
+		return errors.New("either propertyId or both chain and city are required")
+	}
+	if r.PropertyID != "" && r.Chain != "" {
+		return errors.New("propertyId and chain are mutually exclusive")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExpiryKind says which of an offer's deadlines has passed.
+type ExpiryKind string
+
+const (
+	// ExpiryOffer means the provider no longer honours the offer at all.
+	ExpiryOffer ExpiryKind = "offer_expired"
+	// ExpiryPriceGuarantee means the offer may still book, but the quoted
+	// price is no longer guaranteed.
+	ExpiryPriceGuarantee ExpiryKind = "price_guarantee_expired"
+)
+
+// OfferExpiredError reports that an offer referenced by ID is past one of
+// its deadlines. Callers use errors.As to tell it apart from lookup
+// failures and usually surface it as a warning.
+type OfferExpiredError struct {
+	OfferID string
+	Kind    ExpiryKind
+	At      time.Time
+}
+
+func (e *OfferExpiredError) Error() string {
+	switch e.Kind {
+	case ExpiryPriceGuarantee:
+		return fmt.Sprintf("offer %s: price guarantee ended at %s; reprice before booking", e.OfferID, e.At.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("offer %s expired at %s; search again for a fresh offer", e.OfferID, e.At.Format(time.RFC3339))
+	}
+}
+
+// OfferWarning is the JSON form of a non-fatal offer problem.
+type OfferWarning struct {
+	Code    string `json:"code"`
+	OfferID string `json:"offerId"`
+	Message string `json:"message"`
+}
+
+func (e *OfferExpiredError) Warning() OfferWarning {
+	return OfferWarning{Code: string(e.Kind), OfferID: e.OfferID, Message: e.Error()}
+}
+
+// CheckExpiry returns an *OfferExpiredError when the offer is past its
+// expiry or price guarantee at now, and nil otherwise. Full expiry takes
+// precedence since it makes the price guarantee moot.
+func (o FlightOffer) CheckExpiry(now time.Time) error {
+	return checkExpiry(o.ID, o.ExpiresAt, o.PriceGuaranteedUntil, now)
+}
+
+func (o StayOffer) CheckExpiry(now time.Time) error {
+	return checkExpiry(o.ID, o.ExpiresAt, o.PriceGuaranteedUntil, now)
+}
+
+func checkExpiry(id string, expiresAt, guaranteedUntil, now time.Time) error {
+	if !expiresAt.IsZero() && !now.Before(expiresAt) {
+		return &OfferExpiredError{OfferID: id, Kind: ExpiryOffer, At: expiresAt}
+	}
+	if !guaranteedUntil.IsZero() && !now.Before(guaranteedUntil) {
+		return &OfferExpiredError{OfferID: id, Kind: ExpiryPriceGuarantee, At: guaranteedUntil}
+	}
+	return nil
+}
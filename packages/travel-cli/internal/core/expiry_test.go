@@ -0,0 +1,38 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckExpiry(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		offer    FlightOffer
+		wantKind ExpiryKind
+	}{
+		{"no deadlines", FlightOffer{ID: "f_1"}, ""},
+		{"still valid", FlightOffer{ID: "f_1", ExpiresAt: now.Add(time.Hour), PriceGuaranteedUntil: now.Add(time.Minute)}, ""},
+		{"guarantee lapsed", FlightOffer{ID: "f_1", ExpiresAt: now.Add(time.Hour), PriceGuaranteedUntil: now.Add(-time.Minute)}, ExpiryPriceGuarantee},
+		{"expired wins", FlightOffer{ID: "f_1", ExpiresAt: now, PriceGuaranteedUntil: now.Add(-time.Hour)}, ExpiryOffer},
+	}
+	for _, tc := range cases {
+		err := tc.offer.CheckExpiry(now)
+		if tc.wantKind == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+			}
+			continue
+		}
+		var expired *OfferExpiredError
+		if !errors.As(err, &expired) {
+			t.Fatalf("%s: err = %v, want *OfferExpiredError", tc.name, err)
+		}
+		if expired.Kind != tc.wantKind || expired.OfferID != "f_1" {
+			t.Errorf("%s: got %+v", tc.name, expired)
+		}
+	}
+}
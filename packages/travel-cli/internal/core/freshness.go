@@ -0,0 +1,83 @@
+package core
+
+import (
+	"math"
+	"time"
+)
+
+// Volatility is how long a provider's prices can be quoted before they
+// should be rechecked with reprice.
+type Volatility struct {
+	RefreshAfter time.Duration
+}
+
+// Defaults by offer kind, used for providers without their own profile.
+var (
+	DefaultFlightVolatility = Volatility{RefreshAfter: 15 * time.Minute}
+	DefaultStayVolatility   = Volatility{RefreshAfter: time.Hour}
+)
+
+// providerVolatility reflects how quickly each provider's prices move:
+// airline fares change within minutes, vacation rentals within hours.
+var providerVolatility = map[string]Volatility{
+	"duffel":       {RefreshAfter: 10 * time.Minute},
+	"mock_flights": {RefreshAfter: 15 * time.Minute},
+	"expedia":      {RefreshAfter: 30 * time.Minute},
+	"mock_stays":   {RefreshAfter: time.Hour},
+	"airbnb":       {RefreshAfter: 6 * time.Hour},
+}
+
+func volatilityFor(source string, fallback Volatility) Volatility {
+	if v, ok := providerVolatility[source]; ok {
+		return v
+	}
+	return fallback
+}
+
+// AnnotateFreshness sets AgeSeconds and RefreshRecommended on every offer
+// in r as of now. It is meant to run right before output, so results that
+// were cached or stored are annotated with their true age.
+func AnnotateFreshness(r *SearchResult, now time.Time) {
+	if r == nil {
+		return
+	}
+	for i := range r.Flights {
+		r.Flights[i].annotateFreshness(now)
+	}
+	for i := range r.Stays {
+		r.Stays[i].annotateFreshness(now)
+	}
+	for _, g := range r.Groups {
+		if g.BestFlight != nil {
+			g.BestFlight.annotateFreshness(now)
+		}
+		if g.BestStay != nil {
+			g.BestStay.annotateFreshness(now)
+		}
+	}
+}
+
+func (o *FlightOffer) annotateFreshness(now time.Time) {
+	v := volatilityFor(o.Source, DefaultFlightVolatility)
+	o.AgeSeconds, o.RefreshRecommended = freshness(o.FetchedAt, o.PriceGuaranteedUntil, v, now)
+}
+
+func (o *StayOffer) annotateFreshness(now time.Time) {
+	v := volatilityFor(o.Source, DefaultStayVolatility)
+	o.AgeSeconds, o.RefreshRecommended = freshness(o.FetchedAt, o.PriceGuaranteedUntil, v, now)
+}
+
+// freshness returns the offer's age and whether it should be repriced
+// before being quoted: it is older than the provider's refresh window or
+// past its price guarantee.
+func freshness(fetchedAt, guaranteedUntil time.Time, v Volatility, now time.Time) (int, bool) {
+	if fetchedAt.IsZero() {
+		return 0, false
+	}
+	age := now.Sub(fetchedAt)
+	if age < 0 {
+		age = 0
+	}
+	stale := age >= v.RefreshAfter || (!guaranteedUntil.IsZero() && !now.Before(guaranteedUntil))
+	return int(math.Round(age.Seconds())), stale
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnnotateFreshness(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	r := &SearchResult{
+		Flights: []FlightOffer{
+			{ID: "fresh", Source: "duffel", FetchedAt: now.Add(-5 * time.Minute)},
+			{ID: "stale", Source: "duffel", FetchedAt: now.Add(-11 * time.Minute)},
+			{ID: "guarantee", Source: "duffel", FetchedAt: now.Add(-time.Minute), PriceGuaranteedUntil: now.Add(-time.Second)},
+			{ID: "unknown", Source: "other", FetchedAt: now.Add(-14 * time.Minute)},
+		},
+		Stays: []StayOffer{
+			{ID: "rental", Source: "airbnb", FetchedAt: now.Add(-2 * time.Hour)},
+			{ID: "hotel", Source: "expedia", FetchedAt: now.Add(-2 * time.Hour)},
+		},
+	}
+	AnnotateFreshness(r, now)
+
+	wantFlights := map[string]bool{"fresh": false, "stale": true, "guarantee": true, "unknown": false}
+	for _, f := range r.Flights {
+		if f.RefreshRecommended != wantFlights[f.ID] {
+			t.Errorf("%s: refreshRecommended = %v", f.ID, f.RefreshRecommended)
+		}
+	}
+	if r.Flights[0].AgeSeconds != 300 {
+		t.Errorf("ageSeconds = %d, want 300", r.Flights[0].AgeSeconds)
+	}
+	if r.Stays[0].RefreshRecommended || !r.Stays[1].RefreshRecommended {
+		t.Errorf("stays: airbnb %v, expedia %v", r.Stays[0].RefreshRecommended, r.Stays[1].RefreshRecommended)
+	}
+}
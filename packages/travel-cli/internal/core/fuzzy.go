@@ -0,0 +1,168 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// nameSimilarityThreshold is the token overlap above which two property
+// names are considered the same place.
+const nameSimilarityThreshold = 0.75
+
+// sameLocationMeters is how close two geocoded stays must be to count as
+// the same property when no address is available.
+const sameLocationMeters = 150.0
+
+// nameStopwords carry no identity: "The Grand Central Hotel" and "Grand
+// Central" are the same property.
+var nameStopwords = map[string]bool{
+	"the": true, "hotel": true, "hotels": true, "inn": true, "and": true,
+	"by": true, "a": true, "de": true, "la": true, "le": true, "les": true,
+}
+
+var addressAbbreviations = map[string]string{
+	"st": "street", "str": "street", "ave": "avenue", "av": "avenue",
+	"rd": "road", "blvd": "boulevard", "bd": "boulevard", "dr": "drive",
+	"ln": "lane", "pl": "place", "sq": "square", "rue": "street",
+}
+
+var foldReplacer = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"ç", "c", "è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ì", "i", "í", "i", "î", "i", "ï", "i", "ñ", "n",
+	"ò", "o", "ó", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ù", "u", "ú", "u", "û", "u", "ü", "u", "ß", "ss", "&", " and ",
+)
+
+// tokens lowercases, folds common accents, and splits on anything that is
+// not a letter or digit.
+func tokens(s string) []string {
+	s = foldReplacer.Replace(strings.ToLower(s))
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// normalizeName returns the identifying tokens of a property name, sorted
+// so word order does not matter.
+func normalizeName(name string) []string {
+	var out []string
+	for _, t := range tokens(name) {
+		if !nameStopwords[t] {
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func normalizeAddress(addr string) string {
+	parts := tokens(addr)
+	for i, t := range parts {
+		if full, ok := addressAbbreviations[t]; ok {
+			parts[i] = full
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// jaccard is the overlap of two token sets.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	set := map[string]bool{}
+	for _, t := range a {
+		set[t] = true
+	}
+	inter := 0
+	union := len(set)
+	seen := map[string]bool{}
+	for _, t := range b {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		if set[t] {
+			inter++
+		} else {
+			union++
+		}
+	}
+	return float64(inter) / float64(union)
+}
+
+// sameProperty decides whether two stay offers describe the same place.
+// Names must be similar; when both offers carry an address or coordinates,
+// those must agree too.
+func sameProperty(a, b StayOffer) bool {
+	if a.CheckIn != b.CheckIn {
+		return false
+	}
+	if jaccard(normalizeName(a.Name), normalizeName(b.Name)) < nameSimilarityThreshold {
+		return false
+	}
+	if a.Address != "" && b.Address != "" {
+		return normalizeAddress(a.Address) == normalizeAddress(b.Address)
+	}
+	if hasCoords(a) && hasCoords(b) {
+		return haversineMeters(a.Latitude, a.Longitude, b.Latitude, b.Longitude) <= sameLocationMeters
+	}
+	return strings.EqualFold(a.City, b.City)
+}
+
+func hasCoords(s StayOffer) bool {
+	return s.Latitude != 0 || s.Longitude != 0
+}
+
+func haversineMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371000.0
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
+
+// mergeStay folds dup into primary: the cheaper offer wins, missing
+// details are filled from the other, and all sources are listed.
+func mergeStay(primary, dup StayOffer) StayOffer {
+	if dup.TotalPriceUSD < primary.TotalPriceUSD {
+		primary, dup = dup, primary
+	}
+	if primary.Address == "" {
+		primary.Address = dup.Address
+	}
+	if !hasCoords(primary) && hasCoords(dup) {
+		primary.Latitude, primary.Longitude = dup.Latitude, dup.Longitude
+	}
+	if primary.Neighborhood == "" {
+		primary.Neighborhood = dup.Neighborhood
+	}
+	if primary.Rating == 0 {
+		primary.Rating, primary.ReviewCount = dup.Rating, dup.ReviewCount
+	}
+
+	sources := map[string]bool{}
+	for _, s := range append(sourcesOf(primary), sourcesOf(dup)...) {
+		sources[s] = true
+	}
+	primary.Sources = nil
+	if len(sources) > 1 {
+		for s := range sources {
+			primary.Sources = append(primary.Sources, s)
+		}
+		sort.Strings(primary.Sources)
+	}
+	return primary
+}
+
+func sourcesOf(s StayOffer) []string {
+	if len(s.Sources) > 0 {
+		return s.Sources
+	}
+	return []string{s.Source}
+}
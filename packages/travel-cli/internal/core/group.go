@@ -0,0 +1,103 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OfferGroup is one bucket of a grouped search. Offers are grouped after
+// ranking, so the first offer seen for a key is the best one in the bucket.
+type OfferGroup struct {
+	Key         string       `json:"key"`
+	Count       int          `json:"count"`
+	MinPriceUSD float64      `json:"minPriceUSD"`
+	BestFlight  *FlightOffer `json:"bestFlight,omitempty"`
+	BestStay    *StayOffer   `json:"bestStay,omitempty"`
+}
+
+var (
+	FlightGroupKeys = []string{"airline", "stops", "source"}
+	StayGroupKeys   = []string{"type", "neighborhood"}
+)
+
+// GroupFlights buckets ranked flights by airline, stops, or source.
+// Groups are ordered by the rank of their best offer.
+func GroupFlights(flights []FlightOffer, by string) ([]OfferGroup, error) {
+	keyOf, err := flightGroupKey(by)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []OfferGroup
+	index := map[string]int{}
+	for i := range flights {
+		f := flights[i]
+		key := keyOf(f)
+		if key == "" {
+			key = "unknown"
+		}
+		gi, ok := index[key]
+		if !ok {
+			index[key] = len(groups)
+			groups = append(groups, OfferGroup{Key: key, Count: 1, MinPriceUSD: f.PriceUSD, BestFlight: &f})
+			continue
+		}
+		groups[gi].Count++
+		if f.PriceUSD < groups[gi].MinPriceUSD {
+			groups[gi].MinPriceUSD = f.PriceUSD
+		}
+	}
+	return groups, nil
+}
+
+func flightGroupKey(by string) (func(FlightOffer) string, error) {
+	switch by {
+	case "airline":
+		return func(f FlightOffer) string { return f.Airline }, nil
+	case "stops":
+		return func(f FlightOffer) string { return strconv.Itoa(f.Stops) }, nil
+	case "source":
+		return func(f FlightOffer) string { return f.Source }, nil
+	}
+	return nil, fmt.Errorf("cannot group flights by %q (use airline, stops, source)", by)
+}
+
+// GroupStays buckets ranked stays by type or neighborhood. Stay prices
+// are compared per night.
+func GroupStays(stays []StayOffer, by string) ([]OfferGroup, error) {
+	keyOf, err := stayGroupKey(by)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []OfferGroup
+	index := map[string]int{}
+	for i := range stays {
+		s := stays[i]
+		key := keyOf(s)
+		if key == "" {
+			key = "unknown"
+		}
+		gi, ok := index[key]
+		if !ok {
+			index[key] = len(groups)
+			groups = append(groups, OfferGroup{Key: key, Count: 1, MinPriceUSD: s.PricePerNight, BestStay: &s})
+			continue
+		}
+		groups[gi].Count++
+		if s.PricePerNight < groups[gi].MinPriceUSD {
+			groups[gi].MinPriceUSD = s.PricePerNight
+		}
+	}
+	return groups, nil
+}
+
+func stayGroupKey(by string) (func(StayOffer) string, error) {
+	switch by {
+	case "type":
+		return func(s StayOffer) string { return s.Type }, nil
+	case "neighborhood":
+		return func(s StayOffer) string { return s.Neighborhood }, nil
+	}
+	return nil, fmt.Errorf("cannot group stays by %q (use type, neighborhood)", by)
+}
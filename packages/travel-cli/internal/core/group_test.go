@@ -0,0 +1,44 @@
+package core
+
+import "testing"
+
+func TestGroupFlights_ByAirlineKeepsBestOffer(t *testing.T) {
+	flights := []FlightOffer{
+		{ID: "ac1", Airline: "Air Canada", PriceUSD: 500},
+		{ID: "af1", Airline: "Air France", PriceUSD: 450},
+		{ID: "ac2", Airline: "Air Canada", PriceUSD: 420},
+	}
+
+	groups, err := GroupFlights(flights, "airline")
+	if err != nil {
+		t.Fatalf("group failed: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	ac := groups[0]
+	if ac.Key != "Air Canada" || ac.Count != 2 || ac.BestFlight.ID != "ac1" || ac.MinPriceUSD != 420 {
+		t.Errorf("unexpected Air Canada group: %+v", ac)
+	}
+}
+
+func TestGroupStays_UnknownNeighborhood(t *testing.T) {
+	stays := []StayOffer{
+		{ID: "a", Neighborhood: "Le Marais", PricePerNight: 150},
+		{ID: "b", PricePerNight: 90},
+	}
+
+	groups, err := GroupStays(stays, "neighborhood")
+	if err != nil {
+		t.Fatalf("group failed: %v", err)
+	}
+	if len(groups) != 2 || groups[1].Key != "unknown" {
+		t.Errorf("expected stays without neighborhood in an unknown bucket, got %+v", groups)
+	}
+}
+
+func TestGroupFlights_InvalidKey(t *testing.T) {
+	if _, err := GroupFlights(nil, "cabin"); err == nil {
+		t.Error("expected error for unsupported group key")
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+	"github.com/beetlebot/travel-cli/internal/destinations"
+	"github.com/beetlebot/travel-cli/internal/holidays"
+)
+
+// flightHolidays names the holidays at either end of req over its dates.
+func flightHolidays(req FlightSearchRequest) []string {
+	return holidayOverlap([]string{req.From, req.To}, req.DepartDate, cmp.Or(req.ReturnDate, req.DepartDate))
+}
+
+// stayHolidays names the holidays in req's city over its dates.
+func stayHolidays(req StaySearchRequest) []string {
+	return holidayOverlap([]string{req.City}, req.CheckIn, req.CheckOut)
+}
+
+// holidayOverlap names, once each, the holidays of the countries places
+// are in, airports or cities, that fall from one YYYY-MM-DD date to
+// another. Places the bundled lists don't place, and countries without a
+// holiday calendar, add none.
+func holidayOverlap(places []string, from, to string) []string {
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return nil
+	}
+	end, err := time.Parse(time.DateOnly, to)
+	if err != nil || end.Before(start) {
+		return nil
+	}
+	var countries, names []string
+	for _, place := range places {
+		country := placeCountry(place)
+		if country == "" || slices.Contains(countries, country) {
+			continue
+		}
+		countries = append(countries, country)
+		found, err := holidays.Between(country, start, end)
+		if err != nil {
+			continue
+		}
+		for _, h := range found {
+			if !slices.Contains(names, h.Name) {
+				names = append(names, h.Name)
+			}
+		}
+	}
+	return names
+}
+
+// placeCountry returns the country code of an airport or city, or "".
+func placeCountry(place string) string {
+	if a, err := airports.Find(place); err == nil {
+		return a.CountryCode
+	}
+	if d, err := destinations.Lookup(place); err == nil {
+		return d.CountryCode
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"net/url"
+	"os"
+)
+
+// AllProviders is the link parameter key that applies to every provider.
+const AllProviders = "*"
+
+// LinkDecorator appends operator-configured query parameters, such as UTM
+// tags or affiliate IDs, to offer deep links so click-throughs are
+// attributed the same way whichever adapter produced the offer.
+type LinkDecorator struct {
+	params map[string]map[string]string
+}
+
+// NewLinkDecorator takes parameters keyed by provider name, with
+// AllProviders applying everywhere. Values are expanded with os.ExpandEnv
+// so secrets like affiliate IDs can stay in the environment.
+func NewLinkDecorator(params map[string]map[string]string) *LinkDecorator {
+	expanded := make(map[string]map[string]string, len(params))
+	for provider, kv := range params {
+		expanded[provider] = make(map[string]string, len(kv))
+		for k, v := range kv {
+			expanded[provider][k] = os.ExpandEnv(v)
+		}
+	}
+	return &LinkDecorator{params: expanded}
+}
+
+// Decorate returns link with the parameters for source applied. Configured
+// parameters replace any the adapter already set with the same name.
+// Links that fail to parse are returned unchanged.
+func (d *LinkDecorator) Decorate(source, link string) string {
+	if d == nil || link == "" {
+		return link
+	}
+	global, specific := d.params[AllProviders], d.params[source]
+	if len(global) == 0 && len(specific) == 0 {
+		return link
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme == "" {
+		return link
+	}
+	q := u.Query()
+	for _, kv := range []map[string]string{global, specific} {
+		for k, v := range kv {
+			if v == "" {
+				continue
+			}
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func (d *LinkDecorator) DecorateFlights(flights []FlightOffer) {
+	if d == nil {
+		return
+	}
+	for i := range flights {
+		flights[i].DeepLink = d.Decorate(flights[i].Source, flights[i].DeepLink)
+	}
+}
+
+func (d *LinkDecorator) DecorateStays(stays []StayOffer) {
+	if d == nil {
+		return
+	}
+	for i := range stays {
+		stays[i].DeepLink = d.Decorate(stays[i].Source, stays[i].DeepLink)
+	}
+}
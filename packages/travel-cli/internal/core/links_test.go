@@ -0,0 +1,46 @@
+package core
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestLinkDecoratorMergesGlobalAndProviderParams(t *testing.T) {
+	t.Setenv("TEST_AFFILIATE_ID", "aff-42")
+	d := NewLinkDecorator(map[string]map[string]string{
+		AllProviders: {"utm_source": "beetlebot", "utm_medium": "cli"},
+		"airbnb":     {"utm_medium": "partner", "af": "${TEST_AFFILIATE_ID}"},
+	})
+
+	got, err := url.Parse(d.Decorate("airbnb", "https://www.airbnb.com/rooms/1?adults=2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := got.Query()
+	want := map[string]string{"adults": "2", "utm_source": "beetlebot", "utm_medium": "partner", "af": "aff-42"}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("%s = %q, want %q (link %s)", k, q.Get(k), v, got)
+		}
+	}
+
+	other, _ := url.Parse(d.Decorate("expedia", "https://www.expedia.com/h/1"))
+	if other.Query().Get("af") != "" || other.Query().Get("utm_medium") != "cli" {
+		t.Errorf("provider-specific params leaked or global missing: %s", other)
+	}
+}
+
+func TestLinkDecoratorLeavesLinksAloneWhenUnconfigured(t *testing.T) {
+	var nilDecorator *LinkDecorator
+	link := "https://example.com/book/f_1"
+	if got := nilDecorator.Decorate("mock_flights", link); got != link {
+		t.Errorf("nil decorator changed link: %s", got)
+	}
+	d := NewLinkDecorator(map[string]map[string]string{"airbnb": {"af": "1"}})
+	if got := d.Decorate("mock_flights", link); got != link {
+		t.Errorf("unconfigured provider changed link: %s", got)
+	}
+	if got := d.Decorate("airbnb", ""); got != "" {
+		t.Errorf("empty link decorated: %s", got)
+	}
+}
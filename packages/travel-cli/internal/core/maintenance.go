@@ -0,0 +1,101 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	MaintenanceFromConfig = "config"
+	MaintenanceDetected   = "detected"
+)
+
+// DefaultMaintenanceBackoff is how long a provider is skipped after it
+// reports maintenance without saying when it will be back.
+const DefaultMaintenanceBackoff = 15 * time.Minute
+
+// MaintenanceWindow is a period during which routing skips a provider.
+// A zero Start means "already started"; a zero Until means the window
+// lasts until it is removed from the config.
+type MaintenanceWindow struct {
+	Provider   string    `json:"provider"`
+	Start      time.Time `json:"start,omitzero"`
+	Until      time.Time `json:"until,omitzero"`
+	Reason     string    `json:"reason,omitempty"`
+	Source     string    `json:"source"`
+	StatusCode int       `json:"statusCode,omitempty"`
+}
+
+// ActiveAt reports whether t falls inside the window.
+func (w MaintenanceWindow) ActiveAt(t time.Time) bool {
+	if !w.Start.IsZero() && t.Before(w.Start) {
+		return false
+	}
+	return w.Until.IsZero() || t.Before(w.Until)
+}
+
+func (w MaintenanceWindow) describe() string {
+	s := "in maintenance"
+	if !w.Until.IsZero() {
+		s += " until " + w.Until.UTC().Format(time.RFC3339)
+	}
+	if w.Reason != "" {
+		s += ": " + w.Reason
+	}
+	return s
+}
+
+// IsMaintenanceStatus reports whether an HTTP status code means the
+// provider is down for maintenance rather than failing the request.
+func IsMaintenanceStatus(code int) bool {
+	return code == http.StatusServiceUnavailable
+}
+
+// MaintenanceError is returned by adapters whose provider answered with a
+// maintenance status. RetryAt is when the provider said to come back, or
+// zero if it didn't.
+type MaintenanceError struct {
+	Provider   string
+	StatusCode int
+	RetryAt    time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	msg := fmt.Sprintf("%s is down for maintenance (HTTP %d)", e.Provider, e.StatusCode)
+	if !e.RetryAt.IsZero() {
+		msg += ", retry after " + e.RetryAt.UTC().Format(time.RFC3339)
+	}
+	return msg
+}
+
+// Window converts the error into the window routing should honour,
+// falling back to DefaultMaintenanceBackoff from now.
+func (e *MaintenanceError) Window(now time.Time) MaintenanceWindow {
+	until := e.RetryAt
+	if until.IsZero() {
+		until = now.Add(DefaultMaintenanceBackoff)
+	}
+	return MaintenanceWindow{
+		Provider:   e.Provider,
+		Until:      until.UTC(),
+		Reason:     fmt.Sprintf("provider returned HTTP %d", e.StatusCode),
+		Source:     MaintenanceDetected,
+		StatusCode: e.StatusCode,
+	}
+}
+
+// maintenanceErrors reports each window as a ProviderError so results say
+// why a provider was skipped.
+func maintenanceErrors(windows []MaintenanceWindow) []ProviderError {
+	var errs []ProviderError
+	for _, w := range windows {
+		errs = append(errs, ProviderError{
+			Provider:    w.Provider,
+			Reason:      w.describe(),
+			Fallback:    "skipped until the maintenance window ends",
+			Maintenance: &w,
+		})
+	}
+	return errs
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+type downFlightAdapter struct {
+	fakeFlightAdapter
+	calls int
+}
+
+func (f *downFlightAdapter) SearchFlights(req FlightSearchRequest) ([]FlightOffer, error) {
+	f.calls++
+	return nil, &MaintenanceError{Provider: f.name, StatusCode: 503}
+}
+
+func TestRouter_ConfigMaintenanceWindow(t *testing.T) {
+	until := time.Date(2026, 6, 1, 6, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		Mode: config.ModeLive,
+		Providers: map[string]config.ProviderConfig{
+			"duffel": {Enabled: config.Bool(true), Maintenance: &config.MaintenanceConfig{Until: until, Reason: "planned upgrade"}},
+		},
+	}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeFlightAdapter{name: "duffel", avail: true})
+
+	router.now = func() time.Time { return until.Add(-time.Hour) }
+	if n := len(router.ActiveFlightAdapters()); n != 0 {
+		t.Fatalf("expected duffel skipped during maintenance, got %d adapters", n)
+	}
+	info := router.ProviderInfos()[0]
+	if info.Status != "maintenance" || !info.ReenableAt.Equal(until) {
+		t.Errorf("info = %+v", info)
+	}
+
+	router.now = func() time.Time { return until }
+	if n := len(router.ActiveFlightAdapters()); n != 1 {
+		t.Errorf("expected duffel re-enabled at %v, got %d adapters", until, n)
+	}
+}
+
+func TestRouter_HybridFallsBackToMockDuringMaintenance(t *testing.T) {
+	cfg := &config.Config{
+		Mode: config.ModeHybrid,
+		Providers: map[string]config.ProviderConfig{
+			"duffel": {Enabled: config.Bool(true)},
+		},
+	}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeFlightAdapter{name: "mock_flights", avail: true})
+	router.RegisterFlight(&fakeFlightAdapter{name: "duffel", avail: true})
+	router.MarkMaintenance(MaintenanceWindow{Provider: "duffel", Until: time.Now().Add(time.Hour), Source: MaintenanceDetected})
+
+	active := router.ActiveFlightAdapters()
+	if len(active) != 1 || active[0].Name() != "mock_flights" {
+		t.Fatalf("expected mock_flights to stand in, got %d adapters", len(active))
+	}
+	if skipped := router.FlightMaintenance(); len(skipped) != 1 || skipped[0].Provider != "duffel" {
+		t.Errorf("FlightMaintenance = %+v", skipped)
+	}
+}
+
+func TestOrchestrator_DetectedMaintenanceSkipsProvider(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeLive}
+	router := NewRouter(cfg)
+	down := &downFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "duffel", avail: true}}
+	router.RegisterFlight(down)
+	orch := NewOrchestrator(router)
+	req := FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}
+
+	first, err := orch.SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Errors) != 1 || first.Errors[0].Maintenance == nil || first.Errors[0].Maintenance.StatusCode != 503 {
+		t.Fatalf("expected a maintenance error, got %+v", first.Errors)
+	}
+	if first.Errors[0].Maintenance.Until.IsZero() {
+		t.Error("expected a default re-enable time")
+	}
+
+	second, err := orch.SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down.calls != 1 {
+		t.Errorf("provider called %d times, want 1", down.calls)
+	}
+	if len(second.Errors) != 2 || second.Errors[0].Maintenance == nil || second.Errors[1].Provider != "none" {
+		t.Errorf("expected skipped provider to be reported, got %+v", second.Errors)
+	}
+}
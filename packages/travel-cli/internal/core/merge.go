@@ -0,0 +1,325 @@
+package core
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+)
+
+// flightMerger dedupes and ranks flight offers as provider batches
+// arrive, so large searches (flexible dates, many providers) never build
+// one combined slice or sort every offer. Offers stay in the batches the
+// adapters returned; the merger keeps a small entry per distinct flight,
+// indexed by a hash of its identity, and copies out only the offers it
+// returns. With exact dedupe the result matches DedupeFlights,
+// RankFlightsWith, and truncation to the first maxResults.
+type flightMerger struct {
+	prefs *Preferences
+	// priority ranks sources to break ties; nil treats them alike.
+	priority func(source string) int
+	// onTimeWeight is how much on-time scores count; see WithOnTime.
+	onTimeWeight float64
+	// dedupe is DedupeExact, DedupeCodeshare, or DedupeOff.
+	dedupe  string
+	batches [][]FlightOffer
+	index   map[uint64][]int
+	entries []flightEntry
+}
+
+type flightEntry struct {
+	batch, pos int
+	// prices lists every source once a second source for the flight has
+	// been merged in, like FlightOffer.Prices after DedupeFlights.
+	prices []SourcePrice
+}
+
+func newFlightMerger(prefs *Preferences, dedupe string) *flightMerger {
+	return &flightMerger{prefs: prefs, dedupe: dedupe, index: map[uint64][]int{}}
+}
+
+func (m *flightMerger) offer(e flightEntry) *FlightOffer {
+	return &m.batches[e.batch][e.pos]
+}
+
+// flightKey hashes the fields flights are matched on: carrier, flight
+// number, and departure instant, like DedupeFlights, or for codeshares,
+// which are sold under several carriers' numbers, the route and schedule.
+func (m *flightMerger) flightKey(f *FlightOffer) uint64 {
+	h := fnv.New64a()
+	var ts [8]byte
+	writeTime := func(t time.Time) {
+		binary.LittleEndian.PutUint64(ts[:], uint64(t.UnixNano()))
+		h.Write(ts[:])
+	}
+	if m.dedupe == DedupeCodeshare {
+		h.Write([]byte(strings.ToUpper(f.From)))
+		h.Write([]byte{0})
+		h.Write([]byte(strings.ToUpper(f.To)))
+		h.Write([]byte{0, byte(f.Stops)})
+		writeTime(f.DepartTime)
+		writeTime(f.ArriveTime)
+		return h.Sum64()
+	}
+	h.Write([]byte(f.Airline))
+	h.Write([]byte{0})
+	h.Write([]byte(f.FlightNumber))
+	writeTime(f.DepartTime)
+	return h.Sum64()
+}
+
+func (m *flightMerger) sameFlight(a, b *FlightOffer) bool {
+	if m.dedupe == DedupeCodeshare {
+		return strings.EqualFold(a.From, b.From) && strings.EqualFold(a.To, b.To) && a.Stops == b.Stops &&
+			a.DepartTime.Equal(b.DepartTime) && a.ArriveTime.Equal(b.ArriveTime)
+	}
+	return a.Airline == b.Airline && a.FlightNumber == b.FlightNumber && a.DepartTime.Equal(b.DepartTime)
+}
+
+// Add merges one provider's offers and returns the entries it created or
+// made cheaper, in batch order. The merger keeps batch; callers must not
+// modify it afterwards.
+func (m *flightMerger) Add(batch []FlightOffer) []int {
+	b := len(m.batches)
+	m.batches = append(m.batches, batch)
+	var changed []int
+	marked := map[int]bool{}
+	mark := func(i int) {
+		if !marked[i] {
+			marked[i] = true
+			changed = append(changed, i)
+		}
+	}
+	for pos := range batch {
+		f := &batch[pos]
+		if m.dedupe == DedupeOff {
+			mark(len(m.entries))
+			m.entries = append(m.entries, flightEntry{batch: b, pos: pos})
+			continue
+		}
+		key := m.flightKey(f)
+		found := -1
+		for _, i := range m.index[key] {
+			if m.sameFlight(m.offer(m.entries[i]), f) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			m.index[key] = append(m.index[key], len(m.entries))
+			mark(len(m.entries))
+			m.entries = append(m.entries, flightEntry{batch: b, pos: pos})
+			continue
+		}
+
+		e := &m.entries[found]
+		primary := m.offer(*e)
+		prices := e.prices
+		if prices == nil {
+			prices = pricesOf(*primary)
+		}
+		for _, p := range pricesOf(*f) {
+			if !hasSourcePrice(prices, p) {
+				prices = append(prices, p)
+			}
+		}
+		if len(prices) > 1 {
+			e.prices = prices
+		}
+		if f.PriceUSD < primary.PriceUSD || f.PriceUSD == primary.PriceUSD && m.outranks(f.Source, primary.Source) {
+			e.batch, e.pos = b, pos
+			mark(found)
+		}
+	}
+	return changed
+}
+
+func (m *flightMerger) outranks(a, b string) bool {
+	return m.priority != nil && m.priority(a) > m.priority(b)
+}
+
+// materialize copies out the merged offer for entry i.
+func (m *flightMerger) materialize(i int) FlightOffer {
+	e := m.entries[i]
+	f := *m.offer(e)
+	if len(e.prices) > 1 {
+		prices := append([]SourcePrice(nil), e.prices...)
+		sort.SliceStable(prices, func(i, j int) bool { return prices[i].PriceUSD < prices[j].PriceUSD })
+		f.Prices = prices
+	}
+	return f
+}
+
+// cheapest returns the lowest-priced merged offer, or nil when there are
+// none.
+func (m *flightMerger) cheapest() *FlightOffer {
+	low := -1
+	for i, e := range m.entries {
+		if low < 0 || m.offer(e).PriceUSD < m.offer(m.entries[low]).PriceUSD {
+			low = i
+		}
+	}
+	if low < 0 {
+		return nil
+	}
+	f := m.materialize(low)
+	return &f
+}
+
+// Result ranks the merged offers and returns the best maxResults (all
+// when 0), the summary over every distinct offer, and groups when groupBy
+// is set.
+func (m *flightMerger) Result(maxResults int, groupBy string) ([]FlightOffer, *ResultSummary, []OfferGroup, error) {
+	var keyOf func(FlightOffer) string
+	if groupBy != "" {
+		var err error
+		if keyOf, err = flightGroupKey(groupBy); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if len(m.entries) == 0 {
+		return nil, nil, nil, nil
+	}
+
+	r := ranking{scores: make([]float64, len(m.entries))}
+	prices := make([]float64, len(m.entries))
+	sources := make([]string, len(m.entries))
+	direct := 0
+	for i, e := range m.entries {
+		f := m.offer(e)
+		r.scores[i] = flightScore(*f) + m.prefs.flightBoost(*f) + onTimeBoost(*f, m.onTimeWeight)
+		prices[i], sources[i] = f.PriceUSD, f.Source
+		if f.Stops == 0 {
+			direct++
+		}
+	}
+	summary := summarizePrices(prices, sources)
+	summary.DirectFlights = &direct
+	if m.priority != nil {
+		r.priority = make([]int, len(sources))
+		for i, source := range sources {
+			r.priority[i] = m.priority(source)
+		}
+	}
+
+	var groups []OfferGroup
+	if keyOf != nil {
+		groups = rankedGroups(r, func(i int) (string, float64) {
+			f := m.offer(m.entries[i])
+			return keyOf(*f), f.PriceUSD
+		}, func(g *OfferGroup, best int) {
+			f := m.materialize(best)
+			g.BestFlight = &f
+		})
+	}
+
+	top := topK(r, maxResults)
+	flights := make([]FlightOffer, len(top))
+	for i, idx := range top {
+		flights[i] = m.materialize(idx)
+	}
+	return flights, summary, groups, nil
+}
+
+// ranking orders offers like a stable sort by descending score: the
+// higher score first, then the higher source priority when priority is
+// set, then the earlier offer.
+type ranking struct {
+	scores   []float64
+	priority []int
+}
+
+func (r ranking) before(i, j int) bool {
+	if r.scores[i] != r.scores[j] {
+		return r.scores[i] > r.scores[j]
+	}
+	if r.priority != nil && r.priority[i] != r.priority[j] {
+		return r.priority[i] > r.priority[j]
+	}
+	return i < j
+}
+
+// topK returns the indexes of the k best-ranked offers, best first,
+// using a heap of size k rather than sorting everything. k <= 0 ranks
+// all.
+func topK(r ranking, k int) []int {
+	if k <= 0 || k > len(r.scores) {
+		k = len(r.scores)
+	}
+	h := &rankHeap{r: r}
+	for i := range r.scores {
+		if h.Len() < k {
+			heap.Push(h, i)
+		} else if r.before(i, h.idx[0]) {
+			h.idx[0] = i
+			heap.Fix(h, 0)
+		}
+	}
+	out := make([]int, h.Len())
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(h).(int)
+	}
+	return out
+}
+
+// rankHeap is a min-heap by rank: its root is the worst of the kept
+// offers, the one to evict when a better offer arrives.
+type rankHeap struct {
+	r   ranking
+	idx []int
+}
+
+func (h *rankHeap) Len() int           { return len(h.idx) }
+func (h *rankHeap) Less(a, b int) bool { return h.r.before(h.idx[b], h.idx[a]) }
+func (h *rankHeap) Swap(a, b int)      { h.idx[a], h.idx[b] = h.idx[b], h.idx[a] }
+func (h *rankHeap) Push(x interface{}) { h.idx = append(h.idx, x.(int)) }
+func (h *rankHeap) Pop() interface{} {
+	n := len(h.idx) - 1
+	x := h.idx[n]
+	h.idx = h.idx[:n]
+	return x
+}
+
+// rankedGroups buckets offers without ranking them all, matching
+// GroupFlights and GroupStays on a fully ranked list: groups are ordered
+// by the rank of their best offer, and setBest fills in that offer.
+func rankedGroups(r ranking, keyPrice func(i int) (string, float64), setBest func(g *OfferGroup, best int)) []OfferGroup {
+	var (
+		groups []OfferGroup
+		best   []int
+		index  = map[string]int{}
+	)
+	for i := range r.scores {
+		key, price := keyPrice(i)
+		if key == "" {
+			key = "unknown"
+		}
+		gi, ok := index[key]
+		if !ok {
+			index[key] = len(groups)
+			groups = append(groups, OfferGroup{Key: key, Count: 1, MinPriceUSD: price})
+			best = append(best, i)
+			continue
+		}
+		groups[gi].Count++
+		if price < groups[gi].MinPriceUSD {
+			groups[gi].MinPriceUSD = price
+		}
+		if r.before(i, best[gi]) {
+			best[gi] = i
+		}
+	}
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return r.before(best[order[a]], best[order[b]]) })
+	out := make([]OfferGroup, len(groups))
+	for i, gi := range order {
+		out[i] = groups[gi]
+		setBest(&out[i], best[gi])
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// randomBatches builds provider batches with cross-provider duplicates
+// and tied scores.
+func randomBatches(rng *rand.Rand, providers, perProvider int) [][]FlightOffer {
+	base := time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)
+	airlines := []string{"Air Canada", "Air France", "Delta"}
+	var batches [][]FlightOffer
+	for p := range providers {
+		var batch []FlightOffer
+		for range perProvider {
+			n := rng.Intn(perProvider)
+			f := FlightOffer{
+				Source:          fmt.Sprintf("p%d", p),
+				Airline:         airlines[n%len(airlines)],
+				FlightNumber:    fmt.Sprintf("X%d", n),
+				DepartTime:      base.Add(time.Duration(n%7) * time.Hour),
+				Stops:           n % 3,
+				DurationMinutes: 300,
+				PriceUSD:        float64(200 + rng.Intn(20)*50),
+			}
+			f.ID = StableFlightID(f)
+			batch = append(batch, f)
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func TestFlightMergerMatchesFullSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	prefs := &Preferences{PreferredAirlines: map[string]float64{"Delta": 1}, StopTolerance: -1}
+	for _, max := range []int{0, 1, 5, 40} {
+		for _, groupBy := range []string{"", "airline", "stops"} {
+			batches := randomBatches(rng, 3, 60)
+
+			var all []FlightOffer
+			m := newFlightMerger(prefs, DedupeExact)
+			for _, b := range batches {
+				all = append(all, b...)
+				m.Add(append([]FlightOffer(nil), b...))
+			}
+			want := DedupeFlights(all)
+			RankFlightsWith(want, prefs)
+			wantSummary := SummarizeFlights(want)
+			var wantGroups []OfferGroup
+			if groupBy != "" {
+				wantGroups, _ = GroupFlights(want, groupBy)
+			}
+			if max > 0 && len(want) > max {
+				want = want[:max]
+			}
+
+			got, summary, groups, err := m.Result(max, groupBy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("max=%d: merged offers differ from dedupe+rank", max)
+			}
+			if !reflect.DeepEqual(summary, wantSummary) {
+				t.Errorf("max=%d: summary = %+v, want %+v", max, summary, wantSummary)
+			}
+			if !reflect.DeepEqual(groups, wantGroups) {
+				t.Errorf("max=%d group-by=%s: groups differ", max, groupBy)
+			}
+		}
+	}
+}
+
+func TestFlightMergerAddReportsChanges(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 8, 0, 0, 0, time.UTC)
+	flight := func(src, number string, price float64) FlightOffer {
+		return FlightOffer{Source: src, Airline: "Delta", FlightNumber: number, DepartTime: depart, PriceUSD: price}
+	}
+	m := newFlightMerger(nil, DedupeExact)
+	if got := m.Add([]FlightOffer{flight("a", "DL1", 500), flight("a", "DL2", 300), flight("a", "DL1", 450)}); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("first batch changed %v, want [0 1]", got)
+	}
+	got := m.Add([]FlightOffer{flight("b", "DL1", 400), flight("b", "DL2", 350), flight("b", "DL3", 900)})
+	if !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("second batch changed %v, want [0 2] (cheaper DL1, new DL3)", got)
+	}
+	if f := m.materialize(0); f.Source != "b" || len(f.Prices) != 2 {
+		t.Errorf("DL1 = %+v", f)
+	}
+}
+
+func TestFlightMergerRejectsUnknownGroup(t *testing.T) {
+	if _, _, _, err := newFlightMerger(nil, DedupeExact).Result(10, "cabin"); err == nil {
+		t.Error("want error grouping by cabin")
+	}
+}
+
+func TestTopKKeepsStableOrder(t *testing.T) {
+	scores := []float64{1, 3, 2, 3, 0, 2}
+	if got := topK(ranking{scores: scores}, 3); !reflect.DeepEqual(got, []int{1, 3, 2}) {
+		t.Errorf("topK = %v, want [1 3 2]", got)
+	}
+	if got := topK(ranking{scores: scores}, 0); !reflect.DeepEqual(got, []int{1, 3, 2, 5, 0, 4}) {
+		t.Errorf("topK all = %v", got)
+	}
+}
+
+func BenchmarkFlightMerger(b *testing.B) {
+	batches := randomBatches(rand.New(rand.NewSource(1)), 4, 10000)
+	for b.Loop() {
+		m := newFlightMerger(nil, DedupeExact)
+		for _, batch := range batches {
+			m.Add(batch)
+		}
+		_, _, _, _ = m.Result(10, "")
+	}
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// offerIDHexLen keeps IDs short enough to paste into a command while
+// making collisions between distinct offers vanishingly unlikely.
+const offerIDHexLen = 12
+
+// StableFlightID derives an offer ID from the fields that identify a
+// flight itinerary, so the same flight gets the same ID on every run and
+// different routes or dates never share one.
+func StableFlightID(o FlightOffer) string {
+	return "f_" + shortHash(
+		o.Source,
+		o.Airline,
+		o.FlightNumber,
+		strings.ToUpper(o.From),
+		strings.ToUpper(o.To),
+		o.DepartTime.UTC().Format(time.RFC3339),
+		o.CabinClass,
+	)
+}
+
+// StableStayID is the stay counterpart of StableFlightID. Properties are
+// identified by provider property ID when known, else by name and address.
+func StableStayID(o StayOffer) string {
+	property := o.PropertyID
+	if property == "" {
+		property = strings.Join(normalizeName(o.Name), " ") + "|" + normalizeAddress(o.Address)
+	}
+	return "s_" + shortHash(
+		o.Source,
+		property,
+		strings.ToLower(o.City),
+		o.Type,
+		o.CheckIn,
+		o.CheckOut,
+	)
+}
+
+func shortHash(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:])[:offerIDHexLen]
+}
+
+// assignFlightIDs fills in IDs for adapters that leave them empty.
+func assignFlightIDs(flights []FlightOffer) {
+	for i := range flights {
+		if flights[i].ID == "" {
+			flights[i].ID = StableFlightID(flights[i])
+		}
+	}
+}
+
+func assignStayIDs(stays []StayOffer) {
+	for i := range stays {
+		if stays[i].ID == "" {
+			stays[i].ID = StableStayID(stays[i])
+		}
+	}
+}
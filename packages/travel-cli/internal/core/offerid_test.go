@@ -0,0 +1,59 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStableFlightIDDeterministicAndRouteSpecific(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 8, 0, 0, 0, time.UTC)
+	base := FlightOffer{Source: "mock_flights", Airline: "Air Canada", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, CabinClass: "economy"}
+
+	id := StableFlightID(base)
+	if !strings.HasPrefix(id, "f_") || len(id) != 2+offerIDHexLen {
+		t.Fatalf("unexpected ID format %q", id)
+	}
+
+	again := base
+	again.PriceUSD = 999 // price changes must not change identity
+	if StableFlightID(again) != id {
+		t.Error("same itinerary produced a different ID")
+	}
+
+	other := base
+	other.To = "LHR"
+	if StableFlightID(other) == id {
+		t.Error("different routes share an ID")
+	}
+	other = base
+	other.DepartTime = depart.AddDate(0, 0, 1)
+	if StableFlightID(other) == id {
+		t.Error("different dates share an ID")
+	}
+}
+
+func TestStableStayIDFallsBackToNameAndAddress(t *testing.T) {
+	a := StayOffer{Source: "airbnb", Name: "The Loft", Address: "12 Main Street", City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"}
+	b := a
+	b.Address = "99 Oak Street"
+	if StableStayID(a) == StableStayID(b) {
+		t.Error("different addresses share an ID")
+	}
+	c := a
+	c.Address = "12 Main St"
+	if StableStayID(a) != StableStayID(c) {
+		t.Error("address abbreviation changed the ID")
+	}
+}
+
+func TestAssignIDsKeepsAdapterIDs(t *testing.T) {
+	flights := []FlightOffer{{ID: "duffel_off_123", Source: "duffel"}, {Source: "duffel", FlightNumber: "AF1"}}
+	assignFlightIDs(flights)
+	if flights[0].ID != "duffel_off_123" {
+		t.Errorf("adapter ID overwritten: %q", flights[0].ID)
+	}
+	if !strings.HasPrefix(flights[1].ID, "f_") {
+		t.Errorf("missing ID not assigned: %q", flights[1].ID)
+	}
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// onTimeBaseline is about the share of flights that arrive on time in a
+// typical year; flights above it gain rank and those below it lose it.
+const onTimeBaseline = 0.8
+
+// OnTimeRecord is how a flight has arrived: Score is the share of its
+// Flights that arrived less than 15 minutes late, the threshold the US
+// Bureau of Transportation Statistics uses. Cancelled and diverted
+// flights count as late.
+type OnTimeRecord struct {
+	Score   float64 `json:"score"`
+	Flights int     `json:"flights"`
+}
+
+// OnTimeSource tells how often a flight, by its number such as AC870,
+// has arrived on time. A record of no flights means the source knows
+// none.
+type OnTimeSource interface {
+	OnTime(ctx context.Context, flightNumber string) (OnTimeRecord, error)
+}
+
+// WithOnTime gives flight offers the on-time scores src tells. A weight
+// above zero also ranks flights that usually arrive on time higher: at 1,
+// fifteen points of on-time share count as much as a stop.
+func (o *Orchestrator) WithOnTime(src OnTimeSource, weight float64) *Orchestrator {
+	o.onTime, o.onTimeWeight = src, weight
+	return o
+}
+
+// onTimeLookups caps the on-time lookups a search runs at once.
+const onTimeLookups = 8
+
+// onTimeTimeout bounds the on-time lookups of a search, which start only
+// once the providers have answered or the soft deadline has passed.
+const onTimeTimeout = 2 * time.Second
+
+// addOnTime sets the on-time score of each offer in batches whose flight
+// the source knows. Each distinct flight number is looked up once, up to
+// onTimeLookups at a time, after the providers have answered, so a slow
+// source never holds up their batches. Offers whose lookup hasn't
+// finished within onTimeTimeout go without a score. A search never fails
+// for it; the first error is logged.
+func (o *Orchestrator) addOnTime(batches [][]FlightOffer) {
+	if o.onTime == nil {
+		return
+	}
+	var numbers []string
+	seen := map[string]bool{}
+	for _, batch := range batches {
+		for _, f := range batch {
+			if !seen[f.FlightNumber] {
+				seen[f.FlightNumber] = true
+				numbers = append(numbers, f.FlightNumber)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(o.searchContext(), onTimeTimeout)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		scores   = make(map[string]*float64, len(numbers))
+		slots    = make(chan struct{}, onTimeLookups)
+	)
+lookups:
+	for _, number := range numbers {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break lookups
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			r, err := o.onTime.OnTime(ctx, number)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				firstErr = cmp.Or(firstErr, err)
+				return
+			}
+			if r.Flights > 0 {
+				rounded := math.Round(r.Score*100) / 100
+				scores[number] = &rounded
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+
+	// Lookups still running after the timeout score too late to count.
+	mu.Lock()
+	defer mu.Unlock()
+	for _, batch := range batches {
+		for i := range batch {
+			batch[i].OnTimeScore = scores[batch[i].FlightNumber]
+		}
+	}
+	if firstErr = cmp.Or(firstErr, ctx.Err()); firstErr != nil {
+		logging.Logger().Warn("on-time scores not added", "error", firstErr)
+	}
+}
+
+// onTimeBoost is what f's on-time score adds to its rank at weight.
+func onTimeBoost(f FlightOffer, weight float64) float64 {
+	if weight <= 0 || f.OnTimeScore == nil {
+		return 0
+	}
+	return weight * (*f.OnTimeScore - onTimeBaseline) * 100
+}
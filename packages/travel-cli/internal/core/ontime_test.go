@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+type mapOnTime map[string]OnTimeRecord
+
+func (m mapOnTime) OnTime(ctx context.Context, flightNumber string) (OnTimeRecord, error) {
+	if flightNumber == "XX1" {
+		return OnTimeRecord{}, errors.New("source down")
+	}
+	return m[flightNumber], nil
+}
+
+func TestOrchestrator_OnTime(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 9, 0, 0, 0, time.UTC)
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&equipmentFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, offers: []FlightOffer{
+		{Airline: "AC", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 400},
+		{Airline: "AF", FlightNumber: "AF347", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 450},
+		{Airline: "AC", FlightNumber: "AC872", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 900},
+		{Airline: "XX", FlightNumber: "XX1", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 950},
+	}})
+	src := mapOnTime{"AC870": {Score: 0.5, Flights: 30}, "AF347": {Score: 0.954, Flights: 30}}
+	req := FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", MaxResults: 10}
+
+	order := func(weight float64) []FlightOffer {
+		t.Helper()
+		result, err := NewOrchestrator(router).WithOnTime(src, weight).SearchFlights(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Flights
+	}
+	flights := order(0)
+	if flights[0].FlightNumber != "AC870" {
+		t.Errorf("unweighted first = %s, want the cheapest", flights[0].FlightNumber)
+	}
+	for _, f := range flights {
+		switch f.FlightNumber {
+		case "AF347":
+			if f.OnTimeScore == nil || *f.OnTimeScore != 0.95 {
+				t.Errorf("AF347 onTimeScore = %v, want 0.95", f.OnTimeScore)
+			}
+		case "AC872", "XX1":
+			if f.OnTimeScore != nil {
+				t.Errorf("%s onTimeScore = %v, want none", f.FlightNumber, *f.OnTimeScore)
+			}
+		}
+	}
+
+	if flights := order(1); flights[0].FlightNumber != "AF347" {
+		t.Errorf("weighted first = %s, want the punctual AF347", flights[0].FlightNumber)
+	}
+}
+
+type countingOnTime struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (c *countingOnTime) OnTime(ctx context.Context, flightNumber string) (OnTimeRecord, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[flightNumber]++
+	return OnTimeRecord{Score: 0.9, Flights: 10}, nil
+}
+
+func TestOrchestrator_OnTimeLooksUpEachFlightOnce(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 9, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Mode: config.ModeMock, Providers: map[string]config.ProviderConfig{
+		"mock_flights": {Enabled: config.Bool(true), Priority: 100},
+		"mock_other":   {Enabled: config.Bool(true), Priority: 90},
+	}}
+	offers := []FlightOffer{
+		{Airline: "AC", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 400},
+		{Airline: "AF", FlightNumber: "AF347", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 450},
+	}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&equipmentFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, offers: offers})
+	router.RegisterFlight(&equipmentFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_other", avail: true}, offers: slices.Clone(offers)})
+	src := &countingOnTime{calls: map[string]int{}}
+
+	result, err := NewOrchestrator(router).WithOnTime(src, 0).SearchFlights(FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", MaxResults: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src.calls["AC870"] != 1 || src.calls["AF347"] != 1 {
+		t.Errorf("lookups = %v, want each flight once", src.calls)
+	}
+	for _, f := range result.Flights {
+		if f.OnTimeScore == nil {
+			t.Errorf("%s has no onTimeScore", f.FlightNumber)
+		}
+	}
+}
+
+// stuckOnTime never answers for AF347, whatever its context says.
+type stuckOnTime struct{ release chan struct{} }
+
+func (s stuckOnTime) OnTime(ctx context.Context, flightNumber string) (OnTimeRecord, error) {
+	if flightNumber == "AF347" {
+		<-s.release
+	}
+	return OnTimeRecord{Score: 0.9, Flights: 10}, nil
+}
+
+func TestOrchestrator_OnTimeGivesUpOnSlowSource(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 9, 0, 0, 0, time.UTC)
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&equipmentFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, offers: []FlightOffer{
+		{Airline: "AC", FlightNumber: "AC870", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 400},
+		{Airline: "AF", FlightNumber: "AF347", From: "YUL", To: "CDG", DepartTime: depart, PriceUSD: 450},
+	}})
+	src := stuckOnTime{release: make(chan struct{})}
+	defer close(src.release)
+
+	start := time.Now()
+	result, err := NewOrchestrator(router).WithOnTime(src, 0).SearchFlights(FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", MaxResults: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if took := time.Since(start); took > onTimeTimeout+time.Second {
+		t.Errorf("search took %s, want it to stop waiting after %s", took, onTimeTimeout)
+	}
+	for _, f := range result.Flights {
+		if got := f.OnTimeScore != nil; got != (f.FlightNumber == "AC870") {
+			t.Errorf("%s onTimeScore = %v", f.FlightNumber, f.OnTimeScore)
+		}
+	}
+}
@@ -1,185 +1,652 @@
 package core
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/beetlebot/travel-cli/internal/airlines"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/logging"
 )
 
+// defaultTimeout bounds a provider's search unless its config sets one.
 const defaultTimeout = 15 * time.Second
 
+// retryDelay is how long a provider that failed is left before its one
+// retry within a search.
+const retryDelay = 250 * time.Millisecond
+
 type Orchestrator struct {
-	router *Router
+	router   *Router
+	prefs    *Preferences
+	links    *LinkDecorator
+	progress func(ProgressEvent)
+	batches  func(OfferBatch)
+	dedupe   DedupeStrategy
+	cache    ResultCache
+	limiter  RateLimiter
+	usage    UsageLedger
+	geocoder Geocoder
+	weather  Forecaster
+	rates    func() fx.Rates
+	ctx      context.Context
+
+	onTime       OnTimeSource
+	onTimeWeight float64
 }
 
 func NewOrchestrator(router *Router) *Orchestrator {
 	return &Orchestrator{router: router}
 }
 
+// WithPreferences enables personalized ranking using the given learned
+// preferences. Passing nil restores the default ranking.
+func (o *Orchestrator) WithPreferences(p *Preferences) *Orchestrator {
+	o.prefs = p
+	return o
+}
+
+// WithLinkDecorator applies d to the deep links of every offer returned.
+func (o *Orchestrator) WithLinkDecorator(d *LinkDecorator) *Orchestrator {
+	o.links = d
+	return o
+}
+
+// WithContext stops searches when ctx is done, as on Ctrl-C: each returns
+// the offers that have arrived, reporting the providers still running as
+// interrupted.
+func (o *Orchestrator) WithContext(ctx context.Context) *Orchestrator {
+	o.ctx = ctx
+	return o
+}
+
+// ForContext returns a copy of o whose searches stop when ctx is done, as
+// WithContext's do. The copy shares o's router, cache, and limits, so one
+// orchestrator can serve concurrent requests with their own contexts.
+func (o *Orchestrator) ForContext(ctx context.Context) *Orchestrator {
+	c := *o
+	c.ctx = ctx
+	return &c
+}
+
+// ProviderInfos reports the providers o routes to, with the maintenance
+// windows its searches have run into since it was built.
+func (o *Orchestrator) ProviderInfos() []ProviderInfo {
+	return o.router.ProviderInfos()
+}
+
+// SearchFlights searches every active flight provider for req. City names
+// in req.From and req.To are resolved to airport codes first, and listed
+// in the result's Resolved.
 func (o *Orchestrator) SearchFlights(req FlightSearchRequest) (*SearchResult, error) {
+	resolved, err := req.ResolveAirports()
+	if err != nil {
+		return nil, err
+	}
+	result, err := o.searchFlights(req, req, []FlightSearchRequest{req})
+	if err == nil && resolved != nil {
+		result.Resolved = resolved
+	}
+	return result, err
+}
+
+// SearchFlightsBetween searches req's dates and travelers on every route
+// from an airport in from to one in to, and ranks the offers of all of
+// them together as one result for req.
+func (o *Orchestrator) SearchFlightsBetween(req FlightSearchRequest, from, to []string) (*SearchResult, error) {
+	var routes []FlightSearchRequest
+	for _, f := range from {
+		for _, t := range to {
+			if f != t {
+				route := req
+				route.From, route.To = f, t
+				routes = append(routes, route)
+			}
+		}
+	}
+	if len(routes) == 0 {
+		return nil, errors.New("no routes between the airports given")
+	}
+	return o.searchFlights(req, struct {
+		FlightSearchRequest
+		From []string `json:"fromAirports"`
+		To   []string `json:"toAirports"`
+	}{req, from, to}, routes)
+}
+
+// searchFlights searches every route for each provider in turn, ranking
+// their offers together as one result for req. A provider fails only
+// when all of its routes do. The result is cached under cacheReq.
+func (o *Orchestrator) searchFlights(req FlightSearchRequest, cacheReq interface{}, routes []FlightSearchRequest) (*SearchResult, error) {
+	var alliance string
+	if req.Alliance != "" {
+		var err error
+		if alliance, err = airlines.ParseAlliance(req.Alliance); err != nil {
+			return nil, err
+		}
+	}
 	adapters := o.router.ActiveFlightAdapters()
+	skipped := maintenanceErrors(o.router.FlightMaintenance())
 	if len(adapters) == 0 {
 		return &SearchResult{
 			Query:     req,
 			Mode:      o.router.cfg.Mode,
 			Providers: nil,
-			Errors:    []ProviderError{{Provider: "none", Reason: "no active flight providers for current mode"}},
+			Errors:    append(skipped, ProviderError{Provider: "none", Reason: "no active flight providers for current mode"}),
 			FetchedAt: time.Now().UTC(),
 		}, nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-	defer cancel()
-
-	var (
-		mu       sync.Mutex
-		wg       sync.WaitGroup
-		flights  []FlightOffer
-		provUsed []string
-		errs     []ProviderError
-	)
-
-	for _, a := range adapters {
-		wg.Add(1)
-		go func(adapter FlightAdapter) {
-			defer wg.Done()
-
-			done := make(chan struct{})
-			var results []FlightOffer
-			var err error
-
-			go func() {
-				results, err = adapter.SearchFlights(req)
-				close(done)
-			}()
-
-			select {
-			case <-done:
-			case <-ctx.Done():
-				mu.Lock()
-				errs = append(errs, ProviderError{
-					Provider: adapter.Name(),
-					Reason:   "timeout",
-					Fallback: "results from other providers may still be available",
-				})
-				mu.Unlock()
-				return
-			}
+	key := o.cacheKey("flights", cacheReq, adapterNames(adapters))
+	if result := o.cached(config.CacheFlights, key, req); result != nil {
+		return result, nil
+	}
 
-			mu.Lock()
-			defer mu.Unlock()
+	report := o.tracker("flights")
+	merger := newFlightMerger(o.prefs, o.dedupe.flights())
+	merger.priority = o.router.Priority
+	merger.onTimeWeight = o.onTimeWeight
+	provUsed, errs, partial := o.collectFlights(adapters, report, func(a FlightAdapter) ([]FlightOffer, error) {
+		var (
+			offers   []FlightOffer
+			firstErr error
+		)
+		for _, route := range routes {
+			batch, err := throttle(o, meter(o, func(a FlightAdapter) ([]FlightOffer, error) {
+				return a.SearchFlights(route)
+			}))(a)
 			if err != nil {
-				errs = append(errs, ProviderError{
-					Provider: adapter.Name(),
-					Reason:   err.Error(),
-				})
-			} else {
-				flights = append(flights, results...)
-				provUsed = append(provUsed, adapter.Name())
+				firstErr = cmp.Or(firstErr, err)
+				continue
 			}
-		}(a)
-	}
-
-	wg.Wait()
-
-	flights = DedupeFlights(flights)
-	RankFlights(flights)
+			offers = append(offers, batch...)
+		}
+		if len(offers) == 0 && firstErr != nil {
+			return nil, firstErr
+		}
+		return offers, nil
+	}, func(provider string, batch []FlightOffer) {
+		o.flightsToUSD(batch)
+		normalizeCarriers(batch)
+		linkBaggagePolicies(batch)
+		localizeFlightTimes(batch)
+		normalizeAircraft(batch)
+		if alliance != "" {
+			batch = slices.DeleteFunc(batch, func(f FlightOffer) bool { return !soldBy(f, alliance) })
+		}
+		if req.Wifi {
+			batch = slices.DeleteFunc(batch, func(f FlightOffer) bool { return f.WifiAvailable == nil || !*f.WifiAvailable })
+		}
+		if req.MaxStops != nil {
+			batch = slices.DeleteFunc(batch, func(f FlightOffer) bool { return f.Stops > *req.MaxStops })
+		}
+		if req.MaxPriceUSD > 0 {
+			batch = slices.DeleteFunc(batch, func(f FlightOffer) bool { return f.PriceUSD > float64(req.MaxPriceUSD) })
+		}
+		assignFlightIDs(batch)
+		o.links.DecorateFlights(batch)
+		changed := merger.Add(batch)
+		if o.batches != nil {
+			flights := make([]FlightOffer, len(changed))
+			for i, idx := range changed {
+				flights[i] = merger.materialize(idx)
+			}
+			o.batches(OfferBatch{Search: "flights", Provider: provider, Flights: flights})
+		}
+	})
+	errs = append(skipped, o.noteMaintenance(errs)...)
+	o.addOnTime(merger.batches)
 
-	if req.MaxResults > 0 && len(flights) > req.MaxResults {
-		flights = flights[:req.MaxResults]
+	flights, summary, groups, err := merger.Result(req.MaxResults, req.GroupBy)
+	if err != nil {
+		return nil, err
 	}
+	report(ProgressEvent{Type: ProgressRankingDone, Offers: offerCount(len(merger.entries))})
 
-	return &SearchResult{
+	result := &SearchResult{
 		Query:      req,
 		Mode:       o.router.cfg.Mode,
 		Providers:  provUsed,
 		Flights:    flights,
 		TotalFound: len(flights),
+		Summary:    summary,
+		Groups:     groups,
 		Errors:     errs,
+		Partial:    partial,
 		FetchedAt:  time.Now().UTC(),
-	}, nil
+	}
+	result.CheapestFlight = merger.cheapest()
+	result.HolidayOverlap = flightHolidays(req)
+	o.store(config.CacheFlights, key, result)
+	return result, nil
 }
 
 func (o *Orchestrator) SearchStays(req StaySearchRequest) (*SearchResult, error) {
 	adapters := o.router.ActiveStayAdapters()
+	skipped := maintenanceErrors(o.router.StayMaintenance())
 	if len(adapters) == 0 {
-		return &SearchResult{
-			Query:     req,
-			Mode:      o.router.cfg.Mode,
-			Providers: nil,
-			Errors:    []ProviderError{{Provider: "none", Reason: "no active stay providers for current mode"}},
-			FetchedAt: time.Now().UTC(),
-		}, nil
+		return o.noStayProviders(req, skipped, "no active stay providers for current mode"), nil
+	}
+
+	key := o.cacheKey("stays", req, adapterNames(adapters))
+	if result := o.cached(config.CacheStays, key, req); result != nil {
+		return result, nil
+	}
+	filter, err := o.placeFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	filter = offerFilter(filter, req)
+
+	report := o.tracker("stays")
+	stays, provUsed, errs, partial := o.collectStays(adapters, report, func(a StayAdapter) ([]StayOffer, error) {
+		return a.SearchStays(req)
+	}, o.streamStays("stays", filter))
+	errs = append(skipped, o.noteMaintenance(errs)...)
+	if filter != nil {
+		stays = filter(stays)
+	}
+	result, err := o.stayResult(req, stays, provUsed, errs, req.GroupBy, req.Sort, req.MaxResults, report)
+	if err == nil {
+		result.Partial = partial
+		result.HolidayOverlap = stayHolidays(req)
+		if req.Weather {
+			result.Weather = o.stayWeather(req)
+		}
+		o.store(config.CacheStays, key, result)
+	}
+	return result, err
+}
+
+// LookupStays prices a known property, or a chain's properties in a city,
+// across every active provider that supports direct lookups.
+func (o *Orchestrator) LookupStays(req StayLookupRequest) (*SearchResult, error) {
+	adapters := o.router.ActiveLookupAdapters()
+	skipped := maintenanceErrors(o.router.LookupMaintenance())
+	if len(adapters) == 0 {
+		return o.noStayProviders(req, skipped, "no active providers support property lookup in current mode"), nil
+	}
+
+	key := o.cacheKey("stays.lookup", req, adapterNames(adapters))
+	if result := o.cached(config.CacheStays, key, req); result != nil {
+		return result, nil
+	}
+
+	report := o.tracker("stays.lookup")
+	stays, provUsed, errs, partial := o.collectStays(adapters, report, func(a StayAdapter) ([]StayOffer, error) {
+		return a.(PropertyLookupAdapter).LookupStays(req)
+	}, o.streamStays("stays.lookup", nil))
+	errs = append(skipped, o.noteMaintenance(errs)...)
+	result, err := o.stayResult(req, stays, provUsed, errs, "", "", req.MaxResults, report)
+	if err == nil {
+		result.Partial = partial
+		o.store(config.CacheStays, key, result)
 	}
+	return result, err
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
-	defer cancel()
+// offerFilter extends filter to drop the stays rated below req.MinRating
+// or by fewer than req.MinReviews reviews, and those without breakfast
+// when req.Breakfast is set, so every provider's stays are held to the
+// same bar whether or not it filters them itself.
+func offerFilter(filter func([]StayOffer) []StayOffer, req StaySearchRequest) func([]StayOffer) []StayOffer {
+	if req.MinRating <= 0 && req.MinReviews <= 0 && !req.Breakfast {
+		return filter
+	}
+	return func(stays []StayOffer) []StayOffer {
+		if filter != nil {
+			stays = filter(stays)
+		}
+		return slices.DeleteFunc(slices.Clone(stays), func(st StayOffer) bool {
+			return st.Rating < req.MinRating || st.ReviewCount < req.MinReviews || (req.Breakfast && !IncludesBreakfast(st.Board))
+		})
+	}
+}
+
+func (o *Orchestrator) noStayProviders(query interface{}, skipped []ProviderError, reason string) *SearchResult {
+	return &SearchResult{
+		Query:     query,
+		Mode:      o.router.cfg.Mode,
+		Providers: nil,
+		Errors:    append(skipped, ProviderError{Provider: "none", Reason: reason}),
+		FetchedAt: time.Now().UTC(),
+	}
+}
 
+// noteMaintenance takes providers that answered with a maintenance status
+// out of routing, so later searches by this process skip them.
+func (o *Orchestrator) noteMaintenance(errs []ProviderError) []ProviderError {
+	for _, e := range errs {
+		if e.Maintenance != nil {
+			o.router.MarkMaintenance(*e.Maintenance)
+		}
+	}
+	return errs
+}
+
+// streamStays returns the per-provider callback for collectStays, sending
+// each batch's new and cheaper stays when WithBatches is set, through
+// filter when it isn't nil.
+func (o *Orchestrator) streamStays(search string, filter func([]StayOffer) []StayOffer) func(string, []StayOffer) {
+	if o.batches == nil {
+		return func(string, []StayOffer) {}
+	}
+	match := o.dedupe.stayMatcher()
+	stream := stayStream{match: match}
+	return func(provider string, batch []StayOffer) {
+		if filter != nil {
+			batch = filter(batch)
+		}
+		assignStayIDs(batch)
+		o.links.DecorateStays(batch)
+		o.batches(OfferBatch{Search: search, Provider: provider, Stays: stream.add(dedupeStays(batch, match))})
+	}
+}
+
+func (o *Orchestrator) stayResult(query interface{}, stays []StayOffer, provUsed []string, errs []ProviderError, groupBy, sortBy string, maxResults int, report func(ProgressEvent)) (*SearchResult, error) {
+	assignStayIDs(stays)
+	o.links.DecorateStays(stays)
+	// Higher-priority sources first, so they stay primary when dedupe
+	// merges equally priced offers.
+	slices.SortStableFunc(stays, func(a, b StayOffer) int { return o.router.Priority(b.Source) - o.router.Priority(a.Source) })
+	stays = dedupeStays(stays, o.dedupe.stayMatcher())
+	summary := SummarizeStays(stays)
+	var cheapest *StayOffer
+	if low := cheapestStay(stays); low != nil {
+		st := *low
+		cheapest = &st
+	}
+
+	r := ranking{scores: make([]float64, len(stays)), priority: make([]int, len(stays))}
+	for i, st := range stays {
+		r.scores[i] = stayScore(st) + o.prefs.stayBoost(st)
+		if sortBy == SortDistance {
+			r.scores[i] = -st.DistanceKm
+		}
+		r.priority[i] = o.router.Priority(st.Source)
+	}
+	var groups []OfferGroup
+	if groupBy != "" {
+		keyOf, err := stayGroupKey(groupBy)
+		if err != nil {
+			return nil, err
+		}
+		groups = rankedGroups(r, func(i int) (string, float64) {
+			return keyOf(stays[i]), stays[i].PricePerNight
+		}, func(g *OfferGroup, best int) {
+			st := stays[best]
+			g.BestStay = &st
+		})
+	}
+
+	top := topK(r, maxResults)
+	ranked := make([]StayOffer, len(top))
+	for i, idx := range top {
+		ranked[i] = stays[idx]
+	}
+	report(ProgressEvent{Type: ProgressRankingDone, Offers: offerCount(len(stays))})
+	stays = ranked
+
+	return &SearchResult{
+		Query:        query,
+		Mode:         o.router.cfg.Mode,
+		Providers:    provUsed,
+		Stays:        stays,
+		TotalFound:   len(stays),
+		Summary:      summary,
+		Groups:       groups,
+		Errors:       errs,
+		CheapestStay: cheapest,
+		FetchedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// collectFlights runs search against every adapter in parallel and passes
+// each provider's offers to add as they arrive, one call at a time.
+// Providers starting and finishing are reported. It returns the providers
+// that answered, the per-provider errors, and whether the soft deadline or
+// the orchestrator's context cut the search short. search applies rate
+// limits and records usage itself, since it may call a provider more than
+// once.
+func (o *Orchestrator) collectFlights(adapters []FlightAdapter, report func(ProgressEvent), search func(FlightAdapter) ([]FlightOffer, error), add func(provider string, batch []FlightOffer)) ([]string, []ProviderError, bool) {
+	return collect(o.searchContext(), o.router.cfg, adapters, report, search, add)
+}
+
+// collectStays is the stay counterpart of collectFlights. It also gathers
+// the offers, after passing each provider's to add.
+func (o *Orchestrator) collectStays(adapters []StayAdapter, report func(ProgressEvent), search func(StayAdapter) ([]StayOffer, error), add func(provider string, batch []StayOffer)) ([]StayOffer, []string, []ProviderError, bool) {
+	var stays []StayOffer
+	provUsed, errs, partial := collect(o.searchContext(), o.router.cfg, adapters, report, throttle(o, meter(o, search)), func(provider string, batch []StayOffer) {
+		o.staysToUSD(batch)
+		normalizeBoards(batch)
+		add(provider, batch)
+		stays = append(stays, batch...)
+	})
+	return stays, provUsed, errs, partial
+}
+
+func (o *Orchestrator) searchContext() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+	return o.ctx
+}
+
+const (
+	// softDeadlineReason is the error reason of providers still running
+	// when a search's soft deadline passed.
+	softDeadlineReason = "soft deadline"
+	// interruptedReason is the error reason of providers still running
+	// when a search's context was canceled.
+	interruptedReason = "interrupted"
+)
+
+// collect fans search out to adapters, in order, with at most
+// cfg.MaxConcurrency running at once (no limit when unset). Each provider
+// gets its providers.<name>.timeout, or defaultTimeout, from when its
+// search starts; one that runs over is reported as timed out but keeps its
+// slot until its call returns, so abandoned calls still count against the
+// limit. A provider that fails fast is retried once, after retryDelay, if
+// the retry is likely to finish in time, unless its HTTP requests are
+// already retried by httpclient. With
+// cfg.SoftDeadline set, or once ctx is done, collect returns with what has
+// arrived, reporting the providers still running, or not yet started, as
+// errors; partial is then true. Their late answers are dropped.
+func collect[A interface{ Name() string }, O any](ctx context.Context, cfg *config.Config, adapters []A, report func(ProgressEvent), search func(A) ([]O, error), add func(provider string, batch []O)) (provUsed []string, errs []ProviderError, partial bool) {
 	var (
 		mu       sync.Mutex
 		wg       sync.WaitGroup
-		stays    []StayOffer
-		provUsed []string
-		errs     []ProviderError
+		slots    chan struct{}
+		finished = make([]bool, len(adapters))
+		closed   bool
 	)
+	if cfg.MaxConcurrency > 0 {
+		slots = make(chan struct{}, cfg.MaxConcurrency)
+	}
+	searchStart := time.Now()
 
-	for _, a := range adapters {
-		wg.Add(1)
-		go func(adapter StayAdapter) {
-			defer wg.Done()
+	run := func(i int, adapter A) {
+		defer wg.Done()
+		release := func() {
+			if slots != nil {
+				<-slots
+			}
+		}
 
-			done := make(chan struct{})
-			var results []StayOffer
-			var err error
+		timeout := defaultTimeout
+		if t := cfg.Providers[adapter.Name()].Timeout; t > 0 {
+			timeout = t
+		}
+		begun := time.Now()
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
 
-			go func() {
-				results, err = adapter.SearchStays(req)
-				close(done)
-			}()
+		done := make(chan struct{})
+		var (
+			results  []O
+			err      error
+			attempts atomic.Int32
+		)
 
+		mu.Lock()
+		if closed {
+			mu.Unlock()
+			release()
+			return
+		}
+		report(ProgressEvent{Type: ProgressProviderStarted, Provider: adapter.Name()})
+		mu.Unlock()
+		go func() {
+			// The slot is freed when the provider call returns, not when
+			// run gives up on it.
+			defer release()
+			defer close(done)
+			attempts.Store(1)
+			results, err = search(adapter)
+			// Providers whose HTTP requests go through httpclient are
+			// retried there, request by request, and not again here.
+			if err == nil || !retryable(err) || metersRequests(adapter) {
+				return
+			}
+			// A provider that failed fast gets one more try, if one as
+			// long as the first fits in its timeout and the soft deadline.
+			took := time.Since(begun)
+			if 2*took+retryDelay >= timeout || cfg.SoftDeadline > 0 && time.Since(searchStart)+took+retryDelay >= cfg.SoftDeadline {
+				return
+			}
+			logging.Logger().Debug("retrying provider", "provider", adapter.Name(), "error", err)
 			select {
-			case <-done:
 			case <-ctx.Done():
-				mu.Lock()
-				errs = append(errs, ProviderError{
-					Provider: adapter.Name(),
-					Reason:   "timeout",
-					Fallback: "results from other providers may still be available",
-				})
-				mu.Unlock()
 				return
+			case <-time.After(retryDelay):
 			}
+			attempts.Store(2)
+			results, err = search(adapter)
+		}()
 
+		select {
+		case <-done:
+		case <-timer.C:
 			mu.Lock()
 			defer mu.Unlock()
-			if err != nil {
-				errs = append(errs, ProviderError{
-					Provider: adapter.Name(),
-					Reason:   err.Error(),
-				})
-			} else {
-				stays = append(stays, results...)
-				provUsed = append(provUsed, adapter.Name())
+			if closed {
+				return
 			}
-		}(a)
+			finished[i] = true
+			errs = append(errs, ProviderError{
+				Provider: adapter.Name(),
+				Reason:   "timeout",
+				Fallback: "results from other providers may still be available",
+				Attempts: int(attempts.Load()),
+			})
+			report(ProgressEvent{Type: ProgressProviderFailed, Provider: adapter.Name(), Reason: "timeout"})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		finished[i] = true
+		if err != nil {
+			pe := providerError(adapter.Name(), err)
+			pe.Attempts = int(attempts.Load())
+			errs = append(errs, pe)
+			report(ProgressEvent{Type: ProgressProviderFailed, Provider: adapter.Name(), Reason: err.Error()})
+		} else {
+			add(adapter.Name(), results)
+			provUsed = append(provUsed, adapter.Name())
+			report(ProgressEvent{Type: ProgressProviderCompleted, Provider: adapter.Name(), Offers: offerCount(len(results))})
+		}
 	}
 
-	wg.Wait()
+	wg.Add(len(adapters))
+	go func() {
+		for i, a := range adapters {
+			if slots != nil {
+				slots <- struct{}{}
+			}
+			mu.Lock()
+			stop := closed
+			mu.Unlock()
+			if stop {
+				wg.Done()
+				if slots != nil {
+					<-slots
+				}
+				continue
+			}
+			go run(i, a)
+		}
+	}()
+	all := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(all)
+	}()
 
-	stays = DedupeStays(stays)
-	RankStays(stays)
+	var soft <-chan time.Time
+	if cfg.SoftDeadline > 0 {
+		timer := time.NewTimer(cfg.SoftDeadline)
+		defer timer.Stop()
+		soft = timer.C
+	}
+	reason, fallback := softDeadlineReason, fmt.Sprintf("still searching after %s; its offers are left out of this partial result", cfg.SoftDeadline)
+	select {
+	case <-all:
+		return provUsed, errs, false
+	case <-soft:
+	case <-ctx.Done():
+		reason, fallback = interruptedReason, "the search was interrupted; its offers are left out of this partial result"
+	}
 
-	if req.MaxResults > 0 && len(stays) > req.MaxResults {
-		stays = stays[:req.MaxResults]
+	mu.Lock()
+	defer mu.Unlock()
+	closed = true
+	for i, a := range adapters {
+		if finished[i] {
+			continue
+		}
+		errs = append(errs, ProviderError{Provider: a.Name(), Reason: reason, Fallback: fallback})
+		report(ProgressEvent{Type: ProgressProviderFailed, Provider: a.Name(), Reason: reason})
 	}
+	return provUsed, errs, true
+}
 
-	return &SearchResult{
-		Query:      req,
-		Mode:       o.router.cfg.Mode,
-		Providers:  provUsed,
-		Stays:      stays,
-		TotalFound: len(stays),
-		Errors:     errs,
-		FetchedAt:  time.Now().UTC(),
-	}, nil
+// retryable reports whether a failed provider search is worth trying
+// again within the search: not when the provider rejected its
+// credentials, is in maintenance, or is out of quota.
+func retryable(err error) bool {
+	var (
+		me *MaintenanceError
+		rl *RateLimitError
+	)
+	return !errors.Is(err, ErrInvalidCredentials) && !errors.As(err, &me) && !errors.As(err, &rl)
+}
+
+// providerError reports a failed search, recognizing maintenance responses
+// and spent rate limits.
+func providerError(name string, err error) ProviderError {
+	pe := ProviderError{Provider: name, Reason: err.Error()}
+	var (
+		me *MaintenanceError
+		rl *RateLimitError
+	)
+	switch {
+	case errors.As(err, &me):
+		w := me.Window(time.Now())
+		pe.Maintenance = &w
+		pe.Fallback = "skipped until the maintenance window ends"
+	case errors.As(err, &rl):
+		pe.Fallback = "skipped to stay within the provider's quota; results from other providers may still be available"
+	}
+	return pe
 }
@@ -0,0 +1,676 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+type fakeStayAdapter struct {
+	name   string
+	offers []StayOffer
+	err    error
+}
+
+func (f *fakeStayAdapter) Name() string               { return f.name }
+func (f *fakeStayAdapter) Tier() ProviderTier         { return TierEasySignup }
+func (f *fakeStayAdapter) Capabilities() []Capability { return []Capability{CapStaysSearch} }
+func (f *fakeStayAdapter) Available() (bool, string)  { return true, "" }
+func (f *fakeStayAdapter) SearchStays(req StaySearchRequest) ([]StayOffer, error) {
+	return f.offers, f.err
+}
+
+type fakeLookupAdapter struct {
+	fakeStayAdapter
+}
+
+func (f *fakeLookupAdapter) LookupStays(req StayLookupRequest) ([]StayOffer, error) {
+	return []StayOffer{{ID: req.PropertyID, Source: f.name, Name: "Known Hotel", CheckIn: req.CheckIn}}, nil
+}
+
+func TestOrchestrator_LookupStays_OnlyLookupAdapters(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeMock}
+	router := NewRouter(cfg)
+	router.RegisterStay(&fakeStayAdapter{name: "mock_plain"})
+	router.RegisterStay(&fakeLookupAdapter{fakeStayAdapter{name: "mock_lookup"}})
+
+	result, err := NewOrchestrator(router).LookupStays(StayLookupRequest{PropertyID: "EXP12345", CheckIn: "2026-06-12"})
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(result.Providers) != 1 || result.Providers[0] != "mock_lookup" {
+		t.Errorf("expected only lookup-capable provider, got %v", result.Providers)
+	}
+	if len(result.Stays) != 1 || result.Stays[0].ID != "EXP12345" {
+		t.Errorf("unexpected stays: %+v", result.Stays)
+	}
+}
+
+func TestOrchestrator_LookupStays_NoCapableProviders(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeMock}
+	router := NewRouter(cfg)
+	router.RegisterStay(&fakeStayAdapter{name: "mock_plain"})
+
+	result, err := NewOrchestrator(router).LookupStays(StayLookupRequest{PropertyID: "EXP12345"})
+	if err != nil {
+		t.Fatalf("lookup failed: %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Provider != "none" {
+		t.Errorf("expected a 'none' provider error, got %+v", result.Errors)
+	}
+}
+
+func TestOrchestrator_ReportsProgress(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_a", offers: []StayOffer{{Name: "A", PricePerNight: 100}, {Name: "B", PricePerNight: 90}}})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_b", err: errors.New("rate limited")})
+
+	var events []ProgressEvent
+	orch := NewOrchestrator(router).WithProgress(func(e ProgressEvent) { events = append(events, e) })
+	if _, err := orch.SearchStays(StaySearchRequest{City: "Paris", MaxResults: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	counts := map[string]int{}
+	for _, e := range events {
+		counts[e.Type]++
+		if e.Search != "stays" || e.At.IsZero() {
+			t.Errorf("event not stamped: %+v", e)
+		}
+		switch {
+		case e.Type == ProgressProviderCompleted && (e.Provider != "mock_a" || *e.Offers != 2),
+			e.Type == ProgressProviderFailed && (e.Provider != "mock_b" || e.Reason != "rate limited"):
+			t.Errorf("unexpected %+v", e)
+		}
+	}
+	if counts[ProgressProviderStarted] != 2 || counts[ProgressProviderCompleted] != 1 || counts[ProgressProviderFailed] != 1 {
+		t.Errorf("counts = %v", counts)
+	}
+	if last := events[len(events)-1]; last.Type != ProgressRankingDone || *last.Offers != 2 {
+		t.Errorf("last event = %+v, want ranking.done over 2 offers", last)
+	}
+}
+
+func TestOrchestrator_StreamsNewAndCheaperOffers(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_a", offers: []StayOffer{
+		{Source: "mock_a", Name: "Hotel Lumiere", Address: "1 Rue A", TotalPriceUSD: 300},
+		{Source: "mock_a", Name: "Hotel Soleil", Address: "2 Rue B", TotalPriceUSD: 200},
+	}})
+
+	var batches []OfferBatch
+	orch := NewOrchestrator(router).WithBatches(func(b OfferBatch) { batches = append(batches, b) })
+	if _, err := orch.SearchStays(StaySearchRequest{City: "Paris"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 1 || batches[0].Provider != "mock_a" || len(batches[0].Stays) != 2 || batches[0].Stays[0].ID == "" {
+		t.Fatalf("batches = %+v", batches)
+	}
+
+	stream := stayStream{match: sameProperty}
+	stream.add(batches[0].Stays)
+	again := stream.add([]StayOffer{
+		{Source: "mock_b", Name: "Hotel Lumiere", Address: "1 Rue A", TotalPriceUSD: 250},
+		{Source: "mock_b", Name: "Hotel Soleil", Address: "2 Rue B", TotalPriceUSD: 220},
+	})
+	if len(again) != 1 || again[0].Name != "Hotel Lumiere" {
+		t.Errorf("second provider streamed %+v, want only the cheaper Lumiere", again)
+	}
+}
+
+type slowStayAdapter struct {
+	fakeStayAdapter
+	delay    time.Duration
+	inFlight *atomic.Int32
+	peak     *atomic.Int32
+}
+
+func (s *slowStayAdapter) SearchStays(req StaySearchRequest) ([]StayOffer, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(s.delay)
+	return []StayOffer{{Source: s.name, Name: "Hotel " + s.name, TotalPriceUSD: 100}}, nil
+}
+
+func TestOrchestrator_ProviderTimeoutAndConcurrency(t *testing.T) {
+	cfg := &config.Config{
+		Mode:           config.ModeMock,
+		MaxConcurrency: 2,
+		Providers: map[string]config.ProviderConfig{
+			"mock_slow": {Enabled: config.Bool(true), Timeout: 20 * time.Millisecond},
+		},
+	}
+	var inFlight, peak atomic.Int32
+	router := NewRouter(cfg)
+	for _, name := range []string{"mock_a", "mock_b", "mock_c"} {
+		router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: name}, 30 * time.Millisecond, &inFlight, &peak})
+	}
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_slow"}, time.Second, &inFlight, &peak})
+
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Providers) != 3 {
+		t.Errorf("providers = %v, want the three that answered in time", result.Providers)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Provider != "mock_slow" || result.Errors[0].Reason != "timeout" {
+		t.Errorf("errors = %+v, want mock_slow timed out", result.Errors)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("%d providers searched at once, want at most 2", p)
+	}
+}
+
+func TestOrchestrator_TimedOutProviderKeepsItsSlot(t *testing.T) {
+	cfg := &config.Config{
+		Mode:           config.ModeMock,
+		MaxConcurrency: 1,
+		Providers: map[string]config.ProviderConfig{
+			"mock_slow": {Enabled: config.Bool(true), Timeout: 20 * time.Millisecond},
+		},
+	}
+	var inFlight, peak atomic.Int32
+	router := NewRouter(cfg)
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_slow"}, 150 * time.Millisecond, &inFlight, &peak})
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_fast"}, 0, &inFlight, &peak})
+
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Providers) != 1 || result.Providers[0] != "mock_fast" {
+		t.Errorf("providers = %v, want mock_fast after mock_slow timed out", result.Providers)
+	}
+	if p := peak.Load(); p > 1 {
+		t.Errorf("%d providers searched at once, want the timed-out call to hold its slot", p)
+	}
+}
+
+func TestOrchestrator_SoftDeadlineReturnsPartialResult(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeMock, SoftDeadline: 50 * time.Millisecond, MaxConcurrency: 2}
+	var inFlight, peak atomic.Int32
+	router := NewRouter(cfg)
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_fast"}, 0, &inFlight, &peak})
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_slow"}, 300 * time.Millisecond, &inFlight, &peak})
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_queued"}, 300 * time.Millisecond, &inFlight, &peak})
+
+	start := time.Now()
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("search took %s, want it back at the soft deadline", elapsed)
+	}
+	if !result.Partial || len(result.Providers) != 1 || len(result.Stays) != 1 {
+		t.Errorf("partial = %v, providers = %v, stays = %d", result.Partial, result.Providers, len(result.Stays))
+	}
+	if len(result.Errors) != 2 || result.Errors[0].Reason != softDeadlineReason || result.Errors[1].Reason != softDeadlineReason {
+		t.Errorf("errors = %+v, want the slow and queued providers", result.Errors)
+	}
+
+	cfg.SoftDeadline = time.Second
+	if result, _ := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"}); result.Partial || len(result.Providers) != 3 {
+		t.Errorf("partial = %v with every provider in time", result.Partial)
+	}
+}
+
+func TestOrchestrator_CanceledContextReturnsPartialResult(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_fast"}, 0, &inFlight, &peak})
+	router.RegisterStay(&slowStayAdapter{fakeStayAdapter{name: "mock_slow"}, 300 * time.Millisecond, &inFlight, &peak})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	result, err := NewOrchestrator(router).WithContext(ctx).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Errorf("search took %s, want it back once canceled", elapsed)
+	}
+	if !result.Partial || len(result.Providers) != 1 || result.Providers[0] != "mock_fast" {
+		t.Errorf("partial = %v, providers = %v", result.Partial, result.Providers)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Provider != "mock_slow" || result.Errors[0].Reason != interruptedReason {
+		t.Errorf("errors = %+v, want mock_slow interrupted", result.Errors)
+	}
+}
+
+type flakyStayAdapter struct {
+	fakeStayAdapter
+	failures []error
+	calls    atomic.Int32
+}
+
+func (f *flakyStayAdapter) SearchStays(req StaySearchRequest) ([]StayOffer, error) {
+	if n := int(f.calls.Add(1)); n <= len(f.failures) {
+		return nil, f.failures[n-1]
+	}
+	return []StayOffer{{Source: f.name, Name: "Hotel " + f.name, TotalPriceUSD: 100}}, nil
+}
+
+func TestOrchestrator_RetriesFailedProvidersOnce(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	reset := errors.New("connection reset")
+	flaky := &flakyStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_flaky"}, failures: []error{reset}}
+	down := &flakyStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_down"}, failures: []error{reset, reset, reset}}
+	denied := &flakyStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_denied"}, failures: []error{ErrInvalidCredentials}}
+	for _, a := range []*flakyStayAdapter{flaky, down, denied} {
+		router.RegisterStay(a)
+	}
+
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Providers) != 1 || result.Providers[0] != "mock_flaky" {
+		t.Errorf("providers = %v, want mock_flaky after its retry", result.Providers)
+	}
+	attempts := map[string]int{}
+	for _, e := range result.Errors {
+		attempts[e.Provider] = e.Attempts
+	}
+	if len(attempts) != 2 || attempts["mock_down"] != 2 || attempts["mock_denied"] != 1 {
+		t.Errorf("attempts = %v, want mock_down retried and mock_denied not", attempts)
+	}
+	if n := down.calls.Load(); n != 2 {
+		t.Errorf("mock_down searched %d times, want 2", n)
+	}
+}
+
+// meteredStayAdapter counts and retries its own requests.
+type meteredStayAdapter struct {
+	*flakyStayAdapter
+}
+
+func (meteredStayAdapter) MetersRequests() bool { return true }
+
+func TestOrchestrator_LeavesHTTPRetriesToHTTPClient(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	reset := errors.New("connection reset")
+	adapter := &flakyStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_http"}, failures: []error{reset}}
+	router.RegisterStay(meteredStayAdapter{adapter})
+
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := adapter.calls.Load(); n != 1 || len(result.Errors) != 1 || result.Errors[0].Attempts != 1 {
+		t.Errorf("searched %d times, errors %+v; want one attempt, its requests retried by httpclient", n, result.Errors)
+	}
+}
+
+func TestOrchestrator_PriorityBreaksRankingTies(t *testing.T) {
+	cfg := &config.Config{
+		Mode: config.ModeMock,
+		Providers: map[string]config.ProviderConfig{
+			"mock_low":  {Enabled: config.Bool(true), Priority: 10},
+			"mock_high": {Enabled: config.Bool(true), Priority: 90},
+		},
+	}
+	router := NewRouter(cfg)
+	router.RegisterStay(&fakeStayAdapter{name: "mock_low", offers: []StayOffer{{Source: "mock_low", Name: "Harbor Inn", PricePerNight: 120, Rating: 4.2}}})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_high", offers: []StayOffer{{Source: "mock_high", Name: "Canal House", PricePerNight: 120, Rating: 4.2}}})
+
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Lisbon"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(result.Stays) != 2 || result.Stays[0].Source != "mock_high" {
+		t.Fatalf("expected the higher-priority source first, got %+v", result.Stays)
+	}
+
+	cfg.Prefer = []string{"mock_low"}
+	result, err = NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Lisbon"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if result.Stays[0].Source != "mock_low" {
+		t.Errorf("expected the preferred source first, got %s", result.Stays[0].Source)
+	}
+}
+
+// memoryCache keeps entries until fresh is turned off for their
+// resource.
+type memoryCache struct {
+	data  map[string][]byte
+	stale map[string]bool
+}
+
+func (m memoryCache) Get(resource, key string) ([]byte, bool) {
+	data, ok := m.data[resource+"|"+key]
+	return data, ok && !m.stale[resource]
+}
+
+func (m memoryCache) Set(resource, key string, data []byte) error {
+	m.data[resource+"|"+key] = data
+	return nil
+}
+
+type countingStayAdapter struct {
+	fakeStayAdapter
+	calls int
+}
+
+func (c *countingStayAdapter) SearchStays(req StaySearchRequest) ([]StayOffer, error) {
+	c.calls++
+	return c.fakeStayAdapter.SearchStays(req)
+}
+
+func TestOrchestrator_CachesResults(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	adapter := &countingStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_a", offers: []StayOffer{{Source: "mock_a", Name: "A", PricePerNight: 100}}}}
+	router.RegisterStay(adapter)
+	cache := memoryCache{data: map[string][]byte{}, stale: map[string]bool{}}
+	orch := NewOrchestrator(router).WithCache(cache)
+
+	first, err := orch.SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil || first.FromCache {
+		t.Fatalf("first search: %+v, %v", first, err)
+	}
+	second, err := orch.SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil || !second.FromCache || adapter.calls != 1 {
+		t.Fatalf("second search: fromCache=%v calls=%d err=%v", second.FromCache, adapter.calls, err)
+	}
+	if len(second.Stays) != 1 || second.Stays[0].ID != first.Stays[0].ID {
+		t.Errorf("cached stays = %+v", second.Stays)
+	}
+	if q, ok := second.Query.(StaySearchRequest); !ok || q.City != "Paris" {
+		t.Errorf("cached query = %#v", second.Query)
+	}
+
+	if r, _ := orch.SearchStays(StaySearchRequest{City: "Lyon"}); r.FromCache || adapter.calls != 2 {
+		t.Errorf("another city served from cache")
+	}
+
+	cache.stale[config.CacheStays] = true
+	if r, _ := orch.SearchStays(StaySearchRequest{City: "Paris"}); r.FromCache || adapter.calls != 3 {
+		t.Errorf("stale result served from cache")
+	}
+	cache.stale[config.CacheStays] = false
+
+	adapter.err = errors.New("rate limited")
+	if _, err := orch.SearchStays(StaySearchRequest{City: "Rome"}); err != nil {
+		t.Fatal(err)
+	}
+	adapter.err = nil
+	if r, _ := orch.SearchStays(StaySearchRequest{City: "Rome"}); r.FromCache {
+		t.Error("a failed search was cached")
+	}
+}
+
+type spentLimiter struct{ reserved []string }
+
+func (l *spentLimiter) Reserve(provider string, perMinute int, maxWait time.Duration) (time.Duration, bool, error) {
+	l.reserved = append(l.reserved, provider)
+	return time.Minute, false, nil
+}
+
+func TestOrchestrator_RateLimitsProviders(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeMock, Providers: map[string]config.ProviderConfig{
+		"mock_a": {Enabled: config.Bool(true), RequestsPerMinute: 10},
+	}}
+	router := NewRouter(cfg)
+	limited := &countingStayAdapter{fakeStayAdapter: fakeStayAdapter{name: "mock_a", offers: []StayOffer{{Source: "mock_a", Name: "A", PricePerNight: 100}}}}
+	router.RegisterStay(limited)
+	router.RegisterStay(&fakeStayAdapter{name: "mock_b", offers: []StayOffer{{Source: "mock_b", Name: "B", PricePerNight: 120}}})
+	limiter := &spentLimiter{}
+
+	result, err := NewOrchestrator(router).WithRateLimiter(limiter).SearchStays(StaySearchRequest{City: "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limited.calls != 0 || len(limiter.reserved) != 1 || limiter.reserved[0] != "mock_a" {
+		t.Errorf("calls = %d, reserved = %v", limited.calls, limiter.reserved)
+	}
+	if len(result.Providers) != 1 || result.Providers[0] != "mock_b" {
+		t.Errorf("providers = %v", result.Providers)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Provider != "mock_a" || !strings.Contains(result.Errors[0].Reason, "rate limited to 10 requests a minute") {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+}
+
+type waitingLimiter struct{}
+
+func (waitingLimiter) Reserve(provider string, perMinute int, maxWait time.Duration) (time.Duration, bool, error) {
+	return maxWait, true, nil
+}
+
+func TestOrchestrator_SpendWaitsForQuotaUntilCanceled(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeLive, Providers: map[string]config.ProviderConfig{
+		"duffel": {RequestsPerMinute: 10, Timeout: time.Hour},
+	}}
+	ledger := &memoryLedger{calls: map[string]int{}}
+	orch := NewOrchestrator(NewRouter(cfg)).WithRateLimiter(waitingLimiter{}).WithUsage(ledger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := orch.Spend(ctx, "duffel"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Spend = %v, want the context's error rather than a half-hour wait", err)
+	}
+	if ledger.calls["duffel"] != 0 {
+		t.Error("a request that was never sent was counted")
+	}
+}
+
+type memoryLedger struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (l *memoryLedger) Record(provider string, costUSD float64, at time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls[provider]++
+	return l.calls[provider], nil
+}
+
+func TestOrchestrator_CountsProviderCalls(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_a", offers: []StayOffer{{Source: "mock_a", Name: "A", PricePerNight: 100}}})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_b", offers: []StayOffer{{Source: "mock_b", Name: "B", PricePerNight: 120}}})
+	ledger := &memoryLedger{calls: map[string]int{}}
+	orch := NewOrchestrator(router).WithUsage(ledger).WithCache(memoryCache{data: map[string][]byte{}, stale: map[string]bool{}})
+
+	for _, city := range []string{"Paris", "Paris", "Lyon"} {
+		if _, err := orch.SearchStays(StaySearchRequest{City: city}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The second Paris search is answered from the cache.
+	if ledger.calls["mock_a"] != 2 || ledger.calls["mock_b"] != 2 {
+		t.Errorf("calls = %v", ledger.calls)
+	}
+}
+
+// routeFlightAdapter offers one flight per route, priced by its origin,
+// and fails for routes from down.
+type routeFlightAdapter struct {
+	fakeFlightAdapter
+	down string
+}
+
+func (r *routeFlightAdapter) SearchFlights(req FlightSearchRequest) ([]FlightOffer, error) {
+	if req.From == r.down {
+		return nil, errors.New("route unavailable")
+	}
+	price := map[string]float64{"YUL": 500, "YHU": 450, "PBG": 400}[req.From]
+	return []FlightOffer{{Source: r.name, Airline: "Air Test", FlightNumber: req.From + req.To, From: req.From, To: req.To, DepartTime: time.Date(2026, 6, 12, 9, 0, 0, 0, time.UTC), PriceUSD: price}}, nil
+}
+
+func TestOrchestrator_SearchFlightsBetween(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&routeFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, down: "PBG"})
+	ledger := &memoryLedger{calls: map[string]int{}}
+	req := FlightSearchRequest{From: "YUL", To: "LIS", DepartDate: "2026-06-12", MaxResults: 10}
+
+	result, err := NewOrchestrator(router).WithUsage(ledger).SearchFlightsBetween(req, []string{"YUL", "YHU", "PBG"}, []string{"LIS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Flights) != 2 || result.Flights[0].From != "YHU" || len(result.Errors) != 0 {
+		t.Errorf("flights = %+v, errors = %+v; want YHU then YUL", result.Flights, result.Errors)
+	}
+	if q := result.Query.(FlightSearchRequest); q.From != "YUL" {
+		t.Errorf("query from = %s, want the request's", q.From)
+	}
+	if ledger.calls["mock_flights"] != 3 {
+		t.Errorf("calls = %d, want one per route", ledger.calls["mock_flights"])
+	}
+
+	// A provider fails when every route does.
+	result, err = NewOrchestrator(router).SearchFlightsBetween(req, []string{"PBG"}, []string{"LIS", "OPO"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Provider != "mock_flights" {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+}
+
+func TestOrchestrator_SearchFlightsResolvesCities(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&routeFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}})
+	req := FlightSearchRequest{From: "yul", To: "Lisbon", DepartDate: "2026-06-12", MaxResults: 10}
+
+	result, err := NewOrchestrator(router).SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Flights) != 1 || result.Flights[0].From != "YUL" || result.Flights[0].To != "LIS" {
+		t.Errorf("flights = %+v, want YUL-LIS", result.Flights)
+	}
+	if len(result.Resolved) != 1 || result.Resolved[0].Field != "to" || result.Resolved[0].Code != "LIS" {
+		t.Errorf("resolved = %+v, want Lisbon as LIS", result.Resolved)
+	}
+
+	req.To = "Atlantis"
+	if _, err := NewOrchestrator(router).SearchFlights(req); !errors.Is(err, ErrUnknownPlace) {
+		t.Errorf("err = %v, want ErrUnknownPlace", err)
+	}
+}
+
+// carrierFlightAdapter offers one flight per airline code it lists, the
+// way providers that name airlines by code write them.
+type carrierFlightAdapter struct {
+	fakeFlightAdapter
+	codes []string
+}
+
+func (c *carrierFlightAdapter) SearchFlights(req FlightSearchRequest) ([]FlightOffer, error) {
+	var offers []FlightOffer
+	for i, code := range c.codes {
+		offers = append(offers, FlightOffer{Source: c.name, Airline: code, FlightNumber: code + "100", From: req.From, To: req.To,
+			DepartTime: time.Date(2026, 6, 12, 9+i, 0, 0, 0, time.UTC), Stops: i % 2, PriceUSD: float64(400 + 10*i)})
+	}
+	return offers, nil
+}
+
+func TestOrchestrator_Alliance(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&carrierFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, codes: []string{"AC", "BA", "LH", "ZZ"}})
+	req := FlightSearchRequest{From: "YUL", To: "LHR", DepartDate: "2026-06-12", MaxResults: 10, Alliance: "Star Alliance"}
+
+	result, err := NewOrchestrator(router).SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range result.Flights {
+		names = append(names, f.Airline)
+	}
+	if strings.Join(names, ",") != "Air Canada,Lufthansa" {
+		t.Errorf("airlines = %v, want the Star Alliance members", names)
+	}
+
+	req.Alliance = "pirates"
+	if _, err := NewOrchestrator(router).SearchFlights(req); err == nil {
+		t.Error("unknown alliance searched")
+	}
+}
+
+func TestOrchestrator_MaxStopsAndPrice(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&carrierFlightAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, codes: []string{"AC", "BA", "LH", "ZZ"}})
+	req := FlightSearchRequest{From: "YUL", To: "LHR", DepartDate: "2026-06-12", MaxResults: 10, MaxStops: new(int), MaxPriceUSD: 425}
+
+	result, err := NewOrchestrator(router).SearchFlights(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range result.Flights {
+		names = append(names, f.Airline)
+	}
+	if strings.Join(names, ",") != "Air Canada,Lufthansa" || result.TotalFound != 2 {
+		t.Errorf("airlines = %v of %d, want the nonstop flights at or under $425", names, result.TotalFound)
+	}
+}
+
+func TestOrchestrator_SearchStaysMinRating(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_stays", offers: []StayOffer{
+		{Name: "Well Loved", City: "Lisbon", Rating: 4.6, ReviewCount: 850, PricePerNight: 180},
+		{Name: "Few Reviews", City: "Lisbon", Rating: 4.9, ReviewCount: 12, PricePerNight: 150},
+		{Name: "Middling", City: "Lisbon", Rating: 3.8, ReviewCount: 2400, PricePerNight: 90},
+		{Name: "Unrated", City: "Lisbon", PricePerNight: 70},
+	}})
+	req := StaySearchRequest{City: "Lisbon", CheckIn: "2026-06-12", CheckOut: "2026-06-20", MaxResults: 10, MinRating: 4.0, MinReviews: 100}
+
+	result, err := NewOrchestrator(router).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stays) != 1 || result.Stays[0].Name != "Well Loved" {
+		t.Errorf("stays = %+v, want only Well Loved", result.Stays)
+	}
+
+	req.MinReviews = 0
+	result, err = NewOrchestrator(router).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stays) != 2 {
+		t.Errorf("got %d stays, want the two rated 4.0 or better", len(result.Stays))
+	}
+
+	req.MinRating = 6
+	if err := req.Validate(); err == nil {
+		t.Error("want an error for a rating above 5")
+	}
+}
+
+func TestOrchestrator_CheapestOfferSurvivesMaxResults(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_stays", offers: []StayOffer{
+		{Name: "Grand", City: "Lisbon", Rating: 4.9, ReviewCount: 900, PricePerNight: 240},
+		{Name: "Hostel", City: "Lisbon", Rating: 3.1, ReviewCount: 40, PricePerNight: 35},
+	}})
+	result, err := NewOrchestrator(router).SearchStays(StaySearchRequest{City: "Lisbon", CheckIn: "2026-06-12", CheckOut: "2026-06-20", MaxResults: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stays) != 1 || result.Stays[0].Name != "Grand" {
+		t.Fatalf("stays = %+v, want only the top-ranked Grand", result.Stays)
+	}
+	if low := result.LowestStay(); low == nil || low.Name != "Hostel" {
+		t.Errorf("lowest stay = %+v, want Hostel though maxResults left it out", low)
+	}
+}
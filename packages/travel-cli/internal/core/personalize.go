@@ -0,0 +1,40 @@
+package core
+
+// Preferences are soft signals learned from past searches. They never
+// filter offers out; they only nudge the ranking score.
+type Preferences struct {
+	// PreferredAirlines maps an airline name to how often it was the
+	// top pick, as a share between 0 and 1.
+	PreferredAirlines map[string]float64 `json:"preferredAirlines,omitempty"`
+	// StopTolerance is the typical number of stops accepted. Negative
+	// means no flight history was available.
+	StopTolerance int `json:"stopTolerance"`
+	// RatingFloor is the lowest stay rating typically accepted.
+	RatingFloor float64 `json:"ratingFloor,omitempty"`
+}
+
+// NoPreferences returns an empty preference set that leaves scores as-is.
+func NoPreferences() Preferences {
+	return Preferences{StopTolerance: -1}
+}
+
+func (p *Preferences) flightBoost(f FlightOffer) float64 {
+	if p == nil {
+		return 0
+	}
+	boost := p.PreferredAirlines[f.Airline] * 12.0
+	if p.StopTolerance >= 0 && f.Stops > p.StopTolerance {
+		boost -= float64(f.Stops-p.StopTolerance) * 10.0
+	}
+	return boost
+}
+
+func (p *Preferences) stayBoost(s StayOffer) float64 {
+	if p == nil || p.RatingFloor <= 0 || s.Rating == 0 {
+		return 0
+	}
+	if s.Rating < p.RatingFloor {
+		return -15.0
+	}
+	return 0
+}
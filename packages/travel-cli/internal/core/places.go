@@ -0,0 +1,191 @@
+package core
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// DefaultNearRadiusKm is how far from StaySearchRequest.Near stays are
+// kept when RadiusKm is unset, about a half-hour walk.
+const DefaultNearRadiusKm = 2.0
+
+// maxLocatedStays caps how many stays without coordinates one search
+// places by geocoding their address, since public geocoders answer about
+// one request a second. Stays past the cap are left out.
+const maxLocatedStays = 10
+
+// Place is where a geocoder found a query: a point and, for areas such
+// as neighborhoods, the box around it.
+type Place struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Bounds    *Bounds `json:"bounds,omitempty"`
+}
+
+// Bounds is the box between two latitudes and two longitudes.
+type Bounds struct {
+	South float64 `json:"south"`
+	North float64 `json:"north"`
+	West  float64 `json:"west"`
+	East  float64 `json:"east"`
+}
+
+// Contains reports whether a point is in b.
+func (b Bounds) Contains(lat, lon float64) bool {
+	return lat >= b.South && lat <= b.North && lon >= b.West && lon <= b.East
+}
+
+// Geocoder finds a place, street address, or neighborhood.
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (Place, error)
+}
+
+// WithGeocoder lets stay searches keep the stays near a place or in a
+// neighborhood.
+func (o *Orchestrator) WithGeocoder(g Geocoder) *Orchestrator {
+	o.geocoder = g
+	return o
+}
+
+// placeFilter returns a filter keeping the stays within req.RadiusKm of
+// req.Near, with DistanceKm set, and in req.Neighborhood, or nil when req
+// asks for neither.
+//
+// A stay is in the neighborhood when its provider names it, or, when the
+// provider names none, when it lies in the neighborhood's bounds; it is
+// then given the neighborhood's name. The bounds are only looked up once
+// a stay needs them, so searches of providers that name neighborhoods
+// work offline.
+func (o *Orchestrator) placeFilter(req StaySearchRequest) (func([]StayOffer) []StayOffer, error) {
+	if req.Near == "" && req.Neighborhood == "" {
+		return nil, nil
+	}
+	if o.geocoder == nil {
+		return nil, errors.New("no geocoder to find places with")
+	}
+	ctx := o.searchContext()
+	place := o.stayPlacer(ctx)
+	var tests []func(st *StayOffer) bool
+
+	if req.Near != "" {
+		center, err := o.geocoder.Geocode(ctx, nearQuery(req))
+		if err != nil {
+			return nil, fmt.Errorf("near: %w", err)
+		}
+		radius := cmp.Or(req.RadiusKm, DefaultNearRadiusKm)
+		tests = append(tests, func(st *StayOffer) bool {
+			if !place(st) {
+				return false
+			}
+			st.DistanceKm = math.Round(haversineMeters(center.Latitude, center.Longitude, st.Latitude, st.Longitude)/10) / 100
+			return st.DistanceKm <= radius
+		})
+	}
+
+	if req.Neighborhood != "" {
+		name := tokens(req.Neighborhood)
+		area := sync.OnceValue(func() *Bounds {
+			p, err := o.geocoder.Geocode(ctx, req.Neighborhood+", "+req.City)
+			if err == nil && p.Bounds == nil {
+				err = errors.New("found as a point, not an area")
+			}
+			if err != nil {
+				logging.Logger().Warn("neighborhood not found; keeping only stays named in it", "neighborhood", req.Neighborhood, "error", err)
+				return nil
+			}
+			return p.Bounds
+		})
+		tests = append(tests, func(st *StayOffer) bool {
+			if st.Neighborhood != "" {
+				return slices.Equal(tokens(st.Neighborhood), name)
+			}
+			if area() == nil || !place(st) || !area().Contains(st.Latitude, st.Longitude) {
+				return false
+			}
+			st.Neighborhood = req.Neighborhood
+			return true
+		})
+	}
+
+	return func(stays []StayOffer) []StayOffer {
+		var kept []StayOffer
+		for _, st := range stays {
+			ok := true
+			for _, test := range tests {
+				if ok = test(&st); !ok {
+					break
+				}
+			}
+			if ok {
+				kept = append(kept, st)
+			}
+		}
+		return kept
+	}, nil
+}
+
+// stayPlacer returns a function giving a stay without coordinates those
+// of its address, and reporting whether the stay has coordinates. Stream
+// batches and the final result pass the same stays through, so each
+// address is geocoded once.
+func (o *Orchestrator) stayPlacer(ctx context.Context) func(st *StayOffer) bool {
+	placed := map[string]*Place{}
+	return func(st *StayOffer) bool {
+		if hasCoords(*st) {
+			return true
+		}
+		p, ok := placed[st.Address]
+		if !ok && st.Address != "" && len(placed) < maxLocatedStays {
+			p = o.locate(ctx, *st)
+			placed[st.Address] = p
+		}
+		if p == nil {
+			return false
+		}
+		st.Latitude, st.Longitude = p.Latitude, p.Longitude
+		return true
+	}
+}
+
+// nearQuery is what req.Near is looked up as: a landmark or address in
+// req.City, as stays' addresses are, or coordinates as given.
+func nearQuery(req StaySearchRequest) string {
+	if req.City == "" || isCoordinates(req.Near) || strings.Contains(strings.ToLower(req.Near), strings.ToLower(req.City)) {
+		return req.Near
+	}
+	return req.Near + ", " + req.City
+}
+
+// isCoordinates reports whether s is written "latitude,longitude".
+func isCoordinates(s string) bool {
+	lat, lon, ok := strings.Cut(s, ",")
+	if !ok {
+		return false
+	}
+	_, errLat := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	_, errLon := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	return errLat == nil && errLon == nil
+}
+
+// locate geocodes st's address, or returns nil when it can't.
+func (o *Orchestrator) locate(ctx context.Context, st StayOffer) *Place {
+	query := st.Address
+	if !strings.Contains(strings.ToLower(query), strings.ToLower(st.City)) {
+		query += ", " + st.City
+	}
+	p, err := o.geocoder.Geocode(ctx, query)
+	if err != nil {
+		logging.Logger().Debug("stay not placed", "stay", st.Name, "address", query, "error", err)
+		return nil
+	}
+	return &p
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+// mapGeocoder knows the places it lists, counting the lookups.
+type mapGeocoder struct {
+	places  map[string]Place
+	lookups int
+}
+
+func (g *mapGeocoder) Geocode(ctx context.Context, query string) (Place, error) {
+	g.lookups++
+	p, ok := g.places[query]
+	if !ok {
+		return Place{}, fmt.Errorf("no place %q", query)
+	}
+	return p, nil
+}
+
+func parisStays() *Router {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterStay(&fakeStayAdapter{name: "mock_stays", offers: []StayOffer{
+		{Name: "Across the Seine", City: "Paris", Latitude: 48.8614, Longitude: 2.2893, PricePerNight: 200},
+		{Name: "Far East", City: "Paris", Latitude: 48.8466, Longitude: 2.3958, PricePerNight: 90},
+		{Name: "By Address", City: "Paris", Address: "5 Avenue Anatole France", PricePerNight: 150},
+		{Name: "Nowhere", City: "Paris", Address: "1 Unknown Street", PricePerNight: 80},
+		{Name: "Named Marais", City: "Paris", Neighborhood: "le marais", PricePerNight: 170},
+		{Name: "In the Box", City: "Paris", Latitude: 48.8590, Longitude: 2.3600, PricePerNight: 130},
+		{Name: "Named Bastille", City: "Paris", Neighborhood: "Bastille", Latitude: 48.8590, Longitude: 2.3600, PricePerNight: 120},
+	}})
+	return router
+}
+
+func TestOrchestrator_SearchStaysNear(t *testing.T) {
+	geocoder := &mapGeocoder{places: map[string]Place{
+		"Eiffel Tower, Paris":            {Latitude: 48.8584, Longitude: 2.2945},
+		"5 Avenue Anatole France, Paris": {Latitude: 48.8570, Longitude: 2.2980},
+		"48.8584,2.2945":                 {Latitude: 48.8584, Longitude: 2.2945},
+	}}
+	req := StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15", MaxResults: 10, Near: "Eiffel Tower", RadiusKm: 2}
+
+	result, err := NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]float64{}
+	for _, st := range result.Stays {
+		got[st.Name] = st.DistanceKm
+	}
+	if len(got) != 2 || got["Across the Seine"] < 0.5 || got["Across the Seine"] > 0.6 || got["By Address"] < 0.2 || got["By Address"] > 0.4 {
+		t.Errorf("stays = %v, want the two within 2 km with their distances", got)
+	}
+	if geocoder.lookups != 3 {
+		t.Errorf("lookups = %d, want the landmark and the two stays without coordinates", geocoder.lookups)
+	}
+
+	// Coordinates are looked up as given, without the city.
+	req.Near = "48.8584,2.2945"
+	if result, err := NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req); err != nil {
+		t.Errorf("near coordinates: %v", err)
+	} else if len(result.Stays) != 2 {
+		t.Errorf("near coordinates: %d stays, want 2", len(result.Stays))
+	}
+
+	req.Near = "Atlantis"
+	if _, err := NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req); err == nil {
+		t.Error("searched near a place that wasn't found")
+	}
+	if _, err := NewOrchestrator(parisStays()).SearchStays(req); err == nil {
+		t.Error("searched near a place without a geocoder")
+	}
+}
+
+func TestOrchestrator_SearchStaysInNeighborhood(t *testing.T) {
+	geocoder := &mapGeocoder{places: map[string]Place{
+		"Le Marais, Paris": {Latitude: 48.8590, Longitude: 2.3620, Bounds: &Bounds{South: 48.8530, North: 48.8650, West: 2.3500, East: 2.3700}},
+		"Eiffel Tower":     {Latitude: 48.8584, Longitude: 2.2945},
+	}}
+	req := StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15", MaxResults: 10, Neighborhood: "Le Marais"}
+
+	result, err := NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, st := range result.Stays {
+		got[st.Name] = st.Neighborhood
+	}
+	// The provider's name for a stay's neighborhood wins over the box.
+	if len(got) != 2 || got["Named Marais"] != "le marais" || got["In the Box"] != "Le Marais" {
+		t.Errorf("stays = %v, want the one named in Le Marais and the one in its bounds", got)
+	}
+
+	// Without bounds, only the stays named in the neighborhood are kept.
+	req.Neighborhood = "Bastille"
+	result, err = NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stays) != 1 || result.Stays[0].Name != "Named Bastille" {
+		t.Errorf("stays = %+v, want the one named in Bastille", result.Stays)
+	}
+}
+
+func TestOrchestrator_SearchStaysByDistance(t *testing.T) {
+	geocoder := &mapGeocoder{places: map[string]Place{
+		"Eiffel Tower, Paris":            {Latitude: 48.8584, Longitude: 2.2945},
+		"5 Avenue Anatole France, Paris": {Latitude: 48.8570, Longitude: 2.2980},
+	}}
+	req := StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15", MaxResults: 3, Near: "Eiffel Tower", RadiusKm: 10, Sort: SortDistance}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := NewOrchestrator(parisStays()).WithGeocoder(geocoder).SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for i, st := range result.Stays {
+		names = append(names, st.Name)
+		if i > 0 && st.DistanceKm < result.Stays[i-1].DistanceKm {
+			t.Errorf("stays not closest first: %v", result.Stays)
+		}
+	}
+	if len(names) != 3 || names[0] != "By Address" || names[1] != "Across the Seine" {
+		t.Errorf("stays = %v, want the three closest, By Address first", names)
+	}
+
+	req.Near = ""
+	if err := req.Validate(); err == nil {
+		t.Error("sorted by distance without near")
+	}
+	req.Sort = ""
+	if err := req.Validate(); err == nil {
+		t.Error("radius without near")
+	}
+	req.Near, req.Sort = "Eiffel Tower", "rating"
+	if err := req.Validate(); err == nil {
+		t.Error("sorted by an unknown order")
+	}
+}
+
+func TestOrchestrator_HolidayOverlap(t *testing.T) {
+	result, err := NewOrchestrator(parisStays()).SearchStays(StaySearchRequest{City: "Paris", CheckIn: "2026-07-12", CheckOut: "2026-07-16"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.HolidayOverlap) != 1 || result.HolidayOverlap[0] != "Bastille Day" {
+		t.Errorf("holidayOverlap = %v, want Bastille Day", result.HolidayOverlap)
+	}
+
+	// Holidays are named once, whichever end of the trip has them.
+	got := flightHolidays(FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-12-20", ReturnDate: "2026-12-27"})
+	if want := []string{"Christmas Day", "Boxing Day"}; !slices.Equal(got, want) {
+		t.Errorf("flightHolidays = %v, want %v", got, want)
+	}
+}
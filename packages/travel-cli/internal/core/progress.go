@@ -0,0 +1,77 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// Progress event types, in the order a search reports them.
+const (
+	ProgressProviderStarted   = "provider.started"
+	ProgressProviderCompleted = "provider.completed"
+	ProgressProviderFailed    = "provider.failed"
+	ProgressRankingDone       = "ranking.done"
+)
+
+// ProgressEvent reports a step of a running search, for UIs that show live
+// status. Offers is the provider's offer count on provider.completed and
+// the number of distinct offers ranked on ranking.done.
+type ProgressEvent struct {
+	Type      string    `json:"type"`
+	Search    string    `json:"search"`
+	Provider  string    `json:"provider,omitempty"`
+	Offers    *int      `json:"offers,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ElapsedMs int64     `json:"elapsedMs"`
+	At        time.Time `json:"at"`
+}
+
+// WithProgress calls fn with progress events as searches run. Calls are
+// serialized, so fn need not be safe for concurrent use.
+func (o *Orchestrator) WithProgress(fn func(ProgressEvent)) *Orchestrator {
+	o.progress = fn
+	return o
+}
+
+// tracker returns a reporter for one search that stamps events with the
+// search name and time since it began. Events are logged, and passed on
+// when WithProgress is set.
+func (o *Orchestrator) tracker(search string) func(ProgressEvent) {
+	var mu sync.Mutex
+	start := time.Now()
+	return func(e ProgressEvent) {
+		now := time.Now()
+		e.Search, e.At, e.ElapsedMs = search, now.UTC(), now.Sub(start).Milliseconds()
+		logProgress(e)
+		if o.progress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		o.progress(e)
+	}
+}
+
+// logProgress logs provider timings at info level and the rest at debug.
+// Providers are queried concurrently, so a provider's elapsed time is how
+// long it took to answer.
+func logProgress(e ProgressEvent) {
+	log := logging.Logger()
+	elapsed := time.Duration(e.ElapsedMs) * time.Millisecond
+	switch e.Type {
+	case ProgressProviderStarted:
+		log.Debug("provider started", "search", e.Search, "provider", e.Provider)
+	case ProgressProviderCompleted:
+		log.Info("provider completed", "search", e.Search, "provider", e.Provider, "offers", *e.Offers, "elapsed", elapsed)
+	case ProgressProviderFailed:
+		log.Info("provider failed", "search", e.Search, "provider", e.Provider, "reason", e.Reason, "elapsed", elapsed)
+	case ProgressRankingDone:
+		log.Debug("ranking done", "search", e.Search, "offers", *e.Offers, "elapsed", elapsed)
+	}
+}
+
+func offerCount(n int) *int {
+	return &n
+}
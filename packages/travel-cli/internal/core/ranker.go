@@ -2,10 +2,25 @@ package core
 
 import "sort"
 
+// How stay results are ordered.
+const (
+	SortBest     = "best"
+	SortDistance = "distance"
+)
+
+// StaySorts lists the orders StaySearchRequest.Sort takes.
+var StaySorts = []string{SortBest, SortDistance}
+
 func RankFlights(flights []FlightOffer) {
+	RankFlightsWith(flights, nil)
+}
+
+// RankFlightsWith ranks flights and applies a personalization boost when
+// prefs is non-nil.
+func RankFlightsWith(flights []FlightOffer, prefs *Preferences) {
 	sort.SliceStable(flights, func(i, j int) bool {
-		si := flightScore(flights[i])
-		sj := flightScore(flights[j])
+		si := flightScore(flights[i]) + prefs.flightBoost(flights[i])
+		sj := flightScore(flights[j]) + prefs.flightBoost(flights[j])
 		return si > sj
 	})
 }
@@ -29,9 +44,15 @@ func flightScore(f FlightOffer) float64 {
 }
 
 func RankStays(stays []StayOffer) {
+	RankStaysWith(stays, nil)
+}
+
+// RankStaysWith ranks stays and applies a personalization boost when
+// prefs is non-nil.
+func RankStaysWith(stays []StayOffer, prefs *Preferences) {
 	sort.SliceStable(stays, func(i, j int) bool {
-		si := stayScore(stays[i])
-		sj := stayScore(stays[j])
+		si := stayScore(stays[i]) + prefs.stayBoost(stays[i])
+		sj := stayScore(stays[j]) + prefs.stayBoost(stays[j])
 		return si > sj
 	})
 }
@@ -52,30 +73,63 @@ func stayScore(s StayOffer) float64 {
 	return score
 }
 
+// DedupeFlights merges offers for the same flight (carrier, number, and
+// departure time). When several providers sell it, the merged offer lists
+// each source's price and the cheapest becomes the primary offer.
 func DedupeFlights(flights []FlightOffer) []FlightOffer {
-	seen := make(map[string]bool)
+	index := make(map[string]int)
 	var out []FlightOffer
 	for _, f := range flights {
 		key := f.Airline + f.FlightNumber + f.DepartTime.String()
-		if seen[key] {
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(out)
+			out = append(out, f)
 			continue
 		}
-		seen[key] = true
-		out = append(out, f)
+		out[i] = mergeFlight(out[i], f)
 	}
 	return out
 }
 
-func DedupeStays(stays []StayOffer) []StayOffer {
-	seen := make(map[string]bool)
-	var out []StayOffer
-	for _, s := range stays {
-		key := s.Name + s.Source + s.CheckIn
-		if seen[key] {
-			continue
+func mergeFlight(primary, dup FlightOffer) FlightOffer {
+	prices := pricesOf(primary)
+	for _, p := range pricesOf(dup) {
+		if !hasSourcePrice(prices, p) {
+			prices = append(prices, p)
 		}
-		seen[key] = true
-		out = append(out, s)
 	}
-	return out
+	if dup.PriceUSD < primary.PriceUSD {
+		primary = dup
+	}
+	if len(prices) > 1 {
+		sort.SliceStable(prices, func(i, j int) bool { return prices[i].PriceUSD < prices[j].PriceUSD })
+		primary.Prices = prices
+	}
+	return primary
+}
+
+func pricesOf(f FlightOffer) []SourcePrice {
+	if len(f.Prices) > 0 {
+		return append([]SourcePrice(nil), f.Prices...)
+	}
+	return []SourcePrice{{Source: f.Source, OfferID: f.ID, PriceUSD: f.PriceUSD, DeepLink: f.DeepLink}}
+}
+
+// hasSourcePrice reports whether the exact same provider offer is already
+// listed, so repeated results from one provider don't show up twice.
+func hasSourcePrice(prices []SourcePrice, p SourcePrice) bool {
+	for _, existing := range prices {
+		if existing.Source == p.Source && existing.OfferID == p.OfferID {
+			return true
+		}
+	}
+	return false
+}
+
+// DedupeStays merges offers for the same property, within and across
+// providers, using normalized names plus address or location matching.
+// The merged offer keeps the lowest price and lists every source.
+func DedupeStays(stays []StayOffer) []StayOffer {
+	return dedupeStays(stays, sameProperty)
 }
@@ -71,3 +71,69 @@ func TestDedupeStays(t *testing.T) {
 		t.Errorf("expected 2 unique stays, got %d", len(result))
 	}
 }
+
+func TestRankFlightsWith_PreferredAirlineBoost(t *testing.T) {
+	flights := []FlightOffer{
+		{ID: "other", Airline: "United Airlines", PriceUSD: 400, Stops: 0, DurationMinutes: 450, Confidence: 0.9, IsBookable: true},
+		{ID: "preferred", Airline: "Air Canada", PriceUSD: 420, Stops: 0, DurationMinutes: 450, Confidence: 0.9, IsBookable: true},
+	}
+
+	prefs := Preferences{PreferredAirlines: map[string]float64{"Air Canada": 0.8}, StopTolerance: 0}
+	RankFlightsWith(flights, &prefs)
+
+	if flights[0].ID != "preferred" {
+		t.Errorf("expected preferred airline first, got %s", flights[0].ID)
+	}
+}
+
+func TestDedupeStays_FuzzyAcrossProviders(t *testing.T) {
+	stays := []StayOffer{
+		{ID: "e1", Source: "expedia", Name: "Grand Hotel Central", Address: "12 Main St.", CheckIn: "2026-06-01", TotalPriceUSD: 900},
+		{ID: "b1", Source: "booking", Name: "The Grand Central Hotel", Address: "12 Main Street", CheckIn: "2026-06-01", TotalPriceUSD: 850, Rating: 4.5},
+		{ID: "e2", Source: "expedia", Name: "Grand Central Suites", Address: "80 King Street", CheckIn: "2026-06-01", TotalPriceUSD: 700},
+	}
+
+	result := DedupeStays(stays)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 properties, got %d", len(result))
+	}
+	merged := result[0]
+	if merged.ID != "b1" || merged.TotalPriceUSD != 850 {
+		t.Errorf("expected cheapest offer as primary, got %s at %.2f", merged.ID, merged.TotalPriceUSD)
+	}
+	if len(merged.Sources) != 2 || merged.Sources[0] != "booking" || merged.Sources[1] != "expedia" {
+		t.Errorf("expected both sources listed, got %v", merged.Sources)
+	}
+}
+
+func TestDedupeStays_SameNameDifferentAddress(t *testing.T) {
+	stays := []StayOffer{
+		{ID: "a", Source: "expedia", Name: "Hotel Central", Address: "1 Oak Street", CheckIn: "2026-06-01"},
+		{ID: "b", Source: "booking", Name: "Hotel Central", Address: "99 River Road", CheckIn: "2026-06-01"},
+	}
+
+	if result := DedupeStays(stays); len(result) != 2 {
+		t.Errorf("expected different addresses to stay separate, got %d", len(result))
+	}
+}
+
+func TestDedupeFlights_MergesProviderPrices(t *testing.T) {
+	now := time.Now()
+	flights := []FlightOffer{
+		{ID: "m1", Source: "mock_flights", Airline: "AC", FlightNumber: "AC870", DepartTime: now, PriceUSD: 650},
+		{ID: "d1", Source: "duffel", Airline: "AC", FlightNumber: "AC870", DepartTime: now, PriceUSD: 610},
+		{ID: "a1", Source: "amadeus", Airline: "AC", FlightNumber: "AC870", DepartTime: now, PriceUSD: 630},
+	}
+
+	result := DedupeFlights(flights)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 merged flight, got %d", len(result))
+	}
+	merged := result[0]
+	if merged.ID != "d1" || merged.PriceUSD != 610 {
+		t.Errorf("expected cheapest offer as primary, got %s at %.2f", merged.ID, merged.PriceUSD)
+	}
+	if len(merged.Prices) != 3 || merged.Prices[0].Source != "duffel" || merged.Prices[2].Source != "mock_flights" {
+		t.Errorf("expected prices from all sources sorted cheapest first, got %+v", merged.Prices)
+	}
+}
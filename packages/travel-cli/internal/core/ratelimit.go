@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// RateLimiter spends providers' request quotas. ratelimit.Store is one,
+// keeping quotas across runs.
+type RateLimiter interface {
+	// Reserve takes one request from provider's quota of perMinute
+	// requests a minute and returns how long to wait before sending it.
+	// If that would be longer than maxWait, nothing is taken and ok is
+	// false, with wait saying when a request will be allowed.
+	Reserve(provider string, perMinute int, maxWait time.Duration) (wait time.Duration, ok bool, err error)
+}
+
+// WithRateLimiter holds each request to a provider with
+// providers.<name>.requestsPerMinute set to that quota in l: each HTTP
+// request, retries included, for a provider whose traffic is metered
+// (see Spend), or else each search. A request waits up to half the
+// provider's timeout for its turn, leaving the rest for the request, and
+// is otherwise reported as rate limited. A nil l turns limiting off.
+func (o *Orchestrator) WithRateLimiter(l RateLimiter) *Orchestrator {
+	o.limiter = l
+	return o
+}
+
+// RateLimitError is returned for a provider whose quota is spent.
+// RetryAt is when its next request will be allowed.
+type RateLimitError struct {
+	Provider  string
+	PerMinute int
+	RetryAt   time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limited to %d requests a minute, next request allowed at %s", e.Provider, e.PerMinute, e.RetryAt.UTC().Format(time.RFC3339))
+}
+
+// throttle wraps search so it first waits for a request from the
+// provider's quota. Providers whose HTTP requests are metered, retries
+// included, spend their quota there instead; see Spend.
+func throttle[A interface{ Name() string }, O any](o *Orchestrator, search func(A) ([]O, error)) func(A) ([]O, error) {
+	if o.limiter == nil {
+		return search
+	}
+	return func(a A) ([]O, error) {
+		if !metersRequests(a) {
+			if err := o.reserve(o.searchContext(), a.Name()); err != nil {
+				return nil, err
+			}
+		}
+		return search(a)
+	}
+}
+
+// reserve waits for a request from provider's quota, or until ctx is
+// done.
+func (o *Orchestrator) reserve(ctx context.Context, provider string) error {
+	pc := o.router.cfg.Providers[provider]
+	if o.limiter == nil || pc.RequestsPerMinute <= 0 {
+		return nil
+	}
+	timeout := defaultTimeout
+	if pc.Timeout > 0 {
+		timeout = pc.Timeout
+	}
+	wait, ok, err := o.limiter.Reserve(provider, pc.RequestsPerMinute, timeout/2)
+	switch {
+	case err != nil:
+		logging.Logger().Debug("rate limit not checked", "provider", provider, "error", err)
+	case !ok:
+		return &RateLimitError{Provider: provider, PerMinute: pc.RequestsPerMinute, RetryAt: time.Now().Add(wait)}
+	case wait > 0:
+		logging.Logger().Debug("waiting for rate limit", "provider", provider, "wait", wait.Round(time.Millisecond))
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// Spend takes one HTTP request to provider from its quota, waiting for
+// it unless ctx is done first, and counts it in the usage ledger. It is
+// the httpclient.Meter for the providers' HTTP traffic, so every request
+// is counted, retries included.
+func (o *Orchestrator) Spend(ctx context.Context, provider string) error {
+	if err := o.reserve(ctx, provider); err != nil {
+		return err
+	}
+	o.record(provider)
+	return nil
+}
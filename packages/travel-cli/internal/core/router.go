@@ -1,6 +1,10 @@
 package core
 
 import (
+	"slices"
+	"sync"
+	"time"
+
 	"github.com/beetlebot/travel-cli/internal/config"
 )
 
@@ -8,10 +12,93 @@ type Router struct {
 	cfg            *config.Config
 	flightAdapters []FlightAdapter
 	stayAdapters   []StayAdapter
+	statusAdapters []StatusAdapter
+
+	mu       sync.Mutex
+	detected map[string]MaintenanceWindow
+	now      func() time.Time
 }
 
 func NewRouter(cfg *config.Config) *Router {
-	return &Router{cfg: cfg}
+	return &Router{cfg: cfg, detected: map[string]MaintenanceWindow{}, now: time.Now}
+}
+
+// WithMaintenance seeds the router with maintenance windows detected by
+// earlier runs. Windows from the config file are read from cfg directly.
+func (r *Router) WithMaintenance(windows []MaintenanceWindow) *Router {
+	for _, w := range windows {
+		r.MarkMaintenance(w)
+	}
+	return r
+}
+
+// MarkMaintenance takes a provider out of routing until w ends.
+func (r *Router) MarkMaintenance(w MaintenanceWindow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.detected[w.Provider] = w
+}
+
+// Maintenance returns the provider's current maintenance window, if any.
+// A window from the config file wins over a detected one.
+func (r *Router) Maintenance(name string) (MaintenanceWindow, bool) {
+	now := r.now()
+	if pc, ok := r.cfg.Providers[name]; ok && pc.Maintenance != nil {
+		w := MaintenanceWindow{
+			Provider: name,
+			Start:    pc.Maintenance.From,
+			Until:    pc.Maintenance.Until,
+			Reason:   pc.Maintenance.Reason,
+			Source:   MaintenanceFromConfig,
+		}
+		if w.ActiveAt(now) {
+			return w, true
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if w, ok := r.detected[name]; ok && w.ActiveAt(now) {
+		return w, true
+	}
+	return MaintenanceWindow{}, false
+}
+
+// FlightMaintenance returns the windows of flight providers that would be
+// routed to if they weren't in maintenance.
+func (r *Router) FlightMaintenance() []MaintenanceWindow {
+	var out []MaintenanceWindow
+	for _, a := range r.flightAdapters {
+		if w, ok := r.Maintenance(a.Name()); ok && r.eligible(a.Name()) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// LookupMaintenance is StayMaintenance limited to providers that support
+// property lookups.
+func (r *Router) LookupMaintenance() []MaintenanceWindow {
+	var out []MaintenanceWindow
+	for _, a := range r.stayAdapters {
+		if _, ok := a.(PropertyLookupAdapter); !ok {
+			continue
+		}
+		if w, ok := r.Maintenance(a.Name()); ok && r.eligible(a.Name()) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// StayMaintenance is the stay counterpart of FlightMaintenance.
+func (r *Router) StayMaintenance() []MaintenanceWindow {
+	var out []MaintenanceWindow
+	for _, a := range r.stayAdapters {
+		if w, ok := r.Maintenance(a.Name()); ok && r.eligible(a.Name()) {
+			out = append(out, w)
+		}
+	}
+	return out
 }
 
 func (r *Router) RegisterFlight(a FlightAdapter) {
@@ -22,6 +109,35 @@ func (r *Router) RegisterStay(a StayAdapter) {
 	r.stayAdapters = append(r.stayAdapters, a)
 }
 
+// RegisterStatus registers a provider of flight status only. Flight
+// adapters that tell status too are found among the flight adapters.
+func (r *Router) RegisterStatus(a StatusAdapter) {
+	r.statusAdapters = append(r.statusAdapters, a)
+}
+
+// Registered reports whether a flight, stay, or status adapter has the
+// name.
+func (r *Router) Registered(name string) bool {
+	for _, a := range r.flightAdapters {
+		if a.Name() == name {
+			return true
+		}
+	}
+	for _, a := range r.stayAdapters {
+		if a.Name() == name {
+			return true
+		}
+	}
+	for _, a := range r.statusAdapters {
+		if a.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveFlightAdapters returns the flight adapters to search, highest
+// priority first.
 func (r *Router) ActiveFlightAdapters() []FlightAdapter {
 	var out []FlightAdapter
 	for _, a := range r.flightAdapters {
@@ -29,9 +145,12 @@ func (r *Router) ActiveFlightAdapters() []FlightAdapter {
 			out = append(out, a)
 		}
 	}
+	slices.SortStableFunc(out, func(a, b FlightAdapter) int { return r.Priority(b.Name()) - r.Priority(a.Name()) })
 	return out
 }
 
+// ActiveStayAdapters returns the stay adapters to search, highest
+// priority first.
 func (r *Router) ActiveStayAdapters() []StayAdapter {
 	var out []StayAdapter
 	for _, a := range r.stayAdapters {
@@ -39,10 +158,115 @@ func (r *Router) ActiveStayAdapters() []StayAdapter {
 			out = append(out, a)
 		}
 	}
+	slices.SortStableFunc(out, func(a, b StayAdapter) int { return r.Priority(b.Name()) - r.Priority(a.Name()) })
+	return out
+}
+
+// ActiveStatusAdapters returns the status providers to ask, highest
+// priority first: the status adapters and the flight adapters that tell
+// status. In hybrid mode mock providers stand in for live status
+// providers rather than live flight ones, so they are left out only when
+// a live status provider is active.
+func (r *Router) ActiveStatusAdapters() []StatusAdapter {
+	var out []StatusAdapter
+	for _, a := range r.flightAdapters {
+		s, ok := a.(StatusAdapter)
+		if !ok {
+			continue
+		}
+		_, down := r.Maintenance(a.Name())
+		standIn := r.cfg.Mode == config.ModeHybrid && isMockProvider(a.Name()) && !r.disabled(a.Name()) && !down
+		if standIn || r.shouldUse(a.Name()) {
+			out = append(out, s)
+		}
+	}
+	for _, a := range r.statusAdapters {
+		if r.shouldUse(a.Name()) {
+			out = append(out, a)
+		}
+	}
+	if slices.ContainsFunc(out, func(a StatusAdapter) bool { return !isMockProvider(a.Name()) }) {
+		out = slices.DeleteFunc(out, func(a StatusAdapter) bool { return isMockProvider(a.Name()) })
+	}
+	slices.SortStableFunc(out, func(a, b StatusAdapter) int { return r.Priority(b.Name()) - r.Priority(a.Name()) })
+	return out
+}
+
+// Priority ranks a provider for dispatch order and breaking ties between
+// equally good offers: its configured priority, or above every provider
+// when the config's prefer list names it, earlier names higher.
+func (r *Router) Priority(name string) int {
+	if i := slices.Index(r.cfg.Prefer, name); i >= 0 {
+		return preferredPriority - i
+	}
+	return r.cfg.Providers[name].Priority
+}
+
+// preferredPriority is above any priority a config would set.
+const preferredPriority = 1 << 20
+
+// LiveProviders counts the active live providers that flight and stay
+// searches query, leaving out mock providers.
+func (r *Router) LiveProviders() (flights, stays int) {
+	for _, a := range r.ActiveFlightAdapters() {
+		if !isMockProvider(a.Name()) {
+			flights++
+		}
+	}
+	for _, a := range r.ActiveStayAdapters() {
+		if !isMockProvider(a.Name()) {
+			stays++
+		}
+	}
+	return flights, stays
+}
+
+// Validator returns the registered adapter for provider if it can check
+// credentials, whatever the current mode.
+func (r *Router) Validator(provider string) (CredentialValidator, bool) {
+	for _, a := range r.flightAdapters {
+		if v, ok := a.(CredentialValidator); ok && a.Name() == provider {
+			return v, true
+		}
+	}
+	for _, a := range r.stayAdapters {
+		if v, ok := a.(CredentialValidator); ok && a.Name() == provider {
+			return v, true
+		}
+	}
+	for _, a := range r.statusAdapters {
+		if v, ok := a.(CredentialValidator); ok && a.Name() == provider {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// ActiveLookupAdapters returns the active stay adapters that implement
+// PropertyLookupAdapter.
+func (r *Router) ActiveLookupAdapters() []StayAdapter {
+	var out []StayAdapter
+	for _, a := range r.ActiveStayAdapters() {
+		if _, ok := a.(PropertyLookupAdapter); ok {
+			out = append(out, a)
+		}
+	}
 	return out
 }
 
 func (r *Router) shouldUse(name string) bool {
+	if _, down := r.Maintenance(name); down {
+		return false
+	}
+	return r.eligible(name)
+}
+
+// eligible reports whether the mode and credentials allow routing to the
+// provider, ignoring maintenance.
+func (r *Router) eligible(name string) bool {
+	if r.disabled(name) {
+		return false
+	}
 	switch r.cfg.Mode {
 	case config.ModeMock:
 		return isMockProvider(name)
@@ -57,18 +281,20 @@ func (r *Router) shouldUse(name string) bool {
 	return false
 }
 
+// noLiveAlternative reports whether a mock provider should stand in because
+// no live provider of its kind is configured and out of maintenance.
 func (r *Router) noLiveAlternative(mockName string) bool {
 	switch mockName {
 	case "mock_flights":
 		for _, a := range r.flightAdapters {
-			if !isMockProvider(a.Name()) && r.cfg.ProviderHasCredentials(a.Name()) {
+			if r.liveAlternative(a.Name()) {
 				return false
 			}
 		}
 		return true
 	case "mock_stays":
 		for _, a := range r.stayAdapters {
-			if !isMockProvider(a.Name()) && r.cfg.ProviderHasCredentials(a.Name()) {
+			if r.liveAlternative(a.Name()) {
 				return false
 			}
 		}
@@ -77,6 +303,20 @@ func (r *Router) noLiveAlternative(mockName string) bool {
 	return true
 }
 
+func (r *Router) liveAlternative(name string) bool {
+	if isMockProvider(name) || r.disabled(name) || !r.cfg.ProviderHasCredentials(name) {
+		return false
+	}
+	_, down := r.Maintenance(name)
+	return !down
+}
+
+// disabled reports whether the config sets enabled: false for the
+// provider. Providers the config doesn't list are not disabled.
+func (r *Router) disabled(name string) bool {
+	return r.cfg.Providers[name].Disabled()
+}
+
 func isMockProvider(name string) bool {
 	return len(name) >= 5 && name[:5] == "mock_"
 }
@@ -85,42 +325,64 @@ func (r *Router) ProviderInfos() []ProviderInfo {
 	var infos []ProviderInfo
 
 	for _, a := range r.flightAdapters {
-		info := ProviderInfo{
-			Name:         a.Name(),
-			Capabilities: a.Capabilities(),
-			Tier:         a.Tier(),
-		}
-		if avail, reason := a.Available(); avail {
-			info.Status = "active"
-		} else {
-			info.Status = "no_credentials"
-			info.Reason = reason
-		}
-		if r.cfg.Mode == config.ModeMock && !isMockProvider(a.Name()) {
-			info.Status = "inactive"
-			info.Reason = "mode is mock"
-		}
-		infos = append(infos, info)
+		infos = append(infos, r.providerInfo(a))
 	}
 
 	for _, a := range r.stayAdapters {
-		info := ProviderInfo{
-			Name:         a.Name(),
-			Capabilities: a.Capabilities(),
-			Tier:         a.Tier(),
-		}
-		if avail, reason := a.Available(); avail {
-			info.Status = "active"
-		} else {
-			info.Status = "no_credentials"
-			info.Reason = reason
+		// A provider that searches flights too is already listed.
+		if slices.ContainsFunc(r.flightAdapters, func(f FlightAdapter) bool { return f.Name() == a.Name() }) {
+			continue
 		}
-		if r.cfg.Mode == config.ModeMock && !isMockProvider(a.Name()) {
-			info.Status = "inactive"
-			info.Reason = "mode is mock"
-		}
-		infos = append(infos, info)
+		infos = append(infos, r.providerInfo(a))
+	}
+
+	for _, a := range r.statusAdapters {
+		infos = append(infos, r.providerInfo(a))
 	}
 
 	return infos
 }
+
+// provider is what every kind of adapter has in common.
+type provider interface {
+	Name() string
+	Tier() ProviderTier
+	Capabilities() []Capability
+	Available() (bool, string)
+}
+
+func (r *Router) providerInfo(a provider) ProviderInfo {
+	info := ProviderInfo{
+		Name:         a.Name(),
+		Capabilities: a.Capabilities(),
+		Tier:         a.Tier(),
+	}
+	if avail, reason := a.Available(); avail {
+		info.Status = "active"
+	} else {
+		info.Status = "no_credentials"
+		info.Reason = reason
+	}
+	if r.disabled(a.Name()) {
+		info.Status = "inactive"
+		info.Reason = "disabled in config"
+	} else if r.cfg.Mode == config.ModeMock && !isMockProvider(a.Name()) {
+		info.Status = "inactive"
+		info.Reason = "mode is mock"
+	} else {
+		r.applyMaintenance(&info)
+	}
+	return info
+}
+
+// applyMaintenance reports a provider in maintenance as such, with the
+// time routing resumes.
+func (r *Router) applyMaintenance(info *ProviderInfo) {
+	w, ok := r.Maintenance(info.Name)
+	if !ok {
+		return
+	}
+	info.Status = "maintenance"
+	info.Reason = w.describe()
+	info.ReenableAt = w.Until
+}
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/beetlebot/travel-cli/internal/config"
 )
@@ -90,3 +92,69 @@ func TestProviderInfos_ShowsAllProviders(t *testing.T) {
 		t.Errorf("expected duffel inactive in mock mode, got %s", infos[1].Status)
 	}
 }
+
+func TestRouter_DisabledProviderIsSkipped(t *testing.T) {
+	cfg := &config.Config{
+		Mode: config.ModeHybrid,
+		Providers: map[string]config.ProviderConfig{
+			"mock_flights": {Enabled: config.Bool(true)},
+			"duffel":       {Enabled: config.Bool(false)},
+		},
+	}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeFlightAdapter{name: "mock_flights", avail: true})
+	router.RegisterFlight(&fakeFlightAdapter{name: "duffel", avail: true})
+
+	active := router.ActiveFlightAdapters()
+	if len(active) != 1 || active[0].Name() != "mock_flights" {
+		t.Fatalf("expected only the mock fallback, got %d adapters", len(active))
+	}
+	if infos := router.ProviderInfos(); infos[1].Reason != "disabled in config" {
+		t.Errorf("duffel info = %+v", infos[1])
+	}
+}
+
+func TestRouter_ProviderWithoutEnabledKeyIsRouted(t *testing.T) {
+	cfg := &config.Config{
+		Mode: config.ModeLive,
+		Providers: map[string]config.ProviderConfig{
+			"duffel": {Timeout: 30 * time.Second},
+		},
+	}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeFlightAdapter{name: "duffel", avail: true})
+
+	if active := router.ActiveFlightAdapters(); len(active) != 1 {
+		t.Errorf("got %d adapters, want duffel, whose entry only sets a timeout", len(active))
+	}
+}
+
+func TestRouter_PriorityOrdersDispatch(t *testing.T) {
+	cfg := &config.Config{
+		Mode: config.ModeMock,
+		Providers: map[string]config.ProviderConfig{
+			"mock_a": {Enabled: config.Bool(true), Priority: 10},
+			"mock_b": {Enabled: config.Bool(true), Priority: 50},
+			"mock_c": {Enabled: config.Bool(true), Priority: 10},
+		},
+	}
+	router := NewRouter(cfg)
+	for _, name := range []string{"mock_a", "mock_b", "mock_c"} {
+		router.RegisterFlight(&fakeFlightAdapter{name: name, avail: true})
+	}
+
+	names := func() []string {
+		var out []string
+		for _, a := range router.ActiveFlightAdapters() {
+			out = append(out, a.Name())
+		}
+		return out
+	}
+	if got := names(); !slices.Equal(got, []string{"mock_b", "mock_a", "mock_c"}) {
+		t.Errorf("by priority: got %v", got)
+	}
+	cfg.Prefer = []string{"mock_c"}
+	if got := names(); !slices.Equal(got, []string{"mock_c", "mock_b", "mock_a"}) {
+		t.Errorf("preferring mock_c: got %v", got)
+	}
+}
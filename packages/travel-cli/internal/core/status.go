@@ -0,0 +1,160 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/aircraft"
+	"github.com/beetlebot/travel-cli/internal/airlines"
+)
+
+// Flight statuses, as aviationstack and FlightAware name them.
+const (
+	FlightScheduled = "scheduled"
+	FlightActive    = "active"
+	FlightLanded    = "landed"
+	FlightCancelled = "cancelled"
+	FlightDiverted  = "diverted"
+	FlightIncident  = "incident"
+)
+
+// ErrFlightNotFound is returned when no status provider knows the flight
+// on the date.
+var ErrFlightNotFound = errors.New("flight not found")
+
+// ErrNoStatusProviders is returned when no provider can tell flight
+// status in the current mode.
+var ErrNoStatusProviders = errors.New("no active flight status providers in current mode")
+
+// FlightStatusRequest asks where a flight, by its number such as AC871,
+// is on its departure date.
+type FlightStatusRequest struct {
+	Flight string `json:"flight"`
+	Date   string `json:"date"`
+}
+
+func (r FlightStatusRequest) Validate() error {
+	if r.Flight == "" || r.Date == "" {
+		return errors.New("flight and date are required")
+	}
+	if _, err := time.Parse(time.DateOnly, r.Date); err != nil {
+		return fmt.Errorf("date %q is not YYYY-MM-DD", r.Date)
+	}
+	return nil
+}
+
+// FlightStatus is how a flight stands on the day: one of the Flight*
+// statuses, its gates and delays, and the aircraft flying it.
+type FlightStatus struct {
+	Flight    string          `json:"flight"`
+	Date      string          `json:"date"`
+	Source    string          `json:"source"`
+	Airline   string          `json:"airline,omitempty"`
+	Status    string          `json:"status"`
+	Departure FlightStatusEnd `json:"departure"`
+	Arrival   FlightStatusEnd `json:"arrival"`
+	// Aircraft is the type flown, completed from the bundled aircraft
+	// list, and Registration its tail number, when the provider knows
+	// them.
+	Aircraft     *Aircraft `json:"aircraft,omitempty"`
+	Registration string    `json:"registration,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+// FlightStatusEnd is one end of a flight. Times are in the airport's time
+// zone; Estimated and Actual are zero until the provider has them.
+// DelayMinutes is how late the flight leaves or arrives.
+type FlightStatusEnd struct {
+	Airport      string    `json:"airport"`
+	Terminal     string    `json:"terminal,omitempty"`
+	Gate         string    `json:"gate,omitempty"`
+	Baggage      string    `json:"baggage,omitempty"`
+	Scheduled    time.Time `json:"scheduled,omitzero"`
+	Estimated    time.Time `json:"estimated,omitzero"`
+	Actual       time.Time `json:"actual,omitzero"`
+	DelayMinutes int       `json:"delayMinutes,omitempty"`
+}
+
+// FlightStatus asks the active status providers, highest priority first,
+// where a flight is on a date, answering with the first that knows it.
+func (o *Orchestrator) FlightStatus(req FlightStatusRequest) (*FlightStatus, error) {
+	req.Flight = strings.ToUpper(strings.ReplaceAll(req.Flight, " ", ""))
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	adapters := o.router.ActiveStatusAdapters()
+	if len(adapters) == 0 {
+		return nil, ErrNoStatusProviders
+	}
+
+	lookup := throttle(o, meter(o, func(a StatusAdapter) ([]FlightStatus, error) {
+		s, err := a.FlightStatus(req)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		return []FlightStatus{*s}, nil
+	}))
+	var errs []error
+	for _, a := range adapters {
+		found, err := lookup(a)
+		if err != nil {
+			o.noteMaintenance([]ProviderError{providerError(a.Name(), err)})
+			errs = append(errs, err)
+			continue
+		}
+		if len(found) == 0 {
+			continue
+		}
+		s := &found[0]
+		s.Flight, s.Date = cmp.Or(s.Flight, req.Flight), cmp.Or(s.Date, req.Date)
+		s.Source = a.Name()
+		if s.FetchedAt.IsZero() {
+			s.FetchedAt = time.Now().UTC()
+		}
+		normalizeFlightStatus(s)
+		return s, nil
+	}
+	// A provider that failed may know the flight, so the failures are
+	// reported rather than the flight being missing.
+	if failed := slices.DeleteFunc(errs, func(err error) bool { return errors.Is(err, ErrFlightNotFound) }); len(failed) > 0 {
+		return nil, errors.Join(failed...)
+	}
+	return nil, fmt.Errorf("%w: %s on %s", ErrFlightNotFound, req.Flight, req.Date)
+}
+
+// normalizeFlightStatus names the airline and aircraft as the bundled
+// lists do, puts each end's times in its airport's time zone, and works
+// out delays the provider left out from the times it gave.
+func normalizeFlightStatus(s *FlightStatus) {
+	if a, err := airlines.Lookup(s.Airline); err == nil {
+		s.Airline = a.Name
+	} else if a, err := airlines.ByFlightNumber(s.Flight); err == nil && s.Airline == "" {
+		s.Airline = a.Name
+	}
+	if s.Aircraft != nil {
+		a, err := aircraft.Lookup(s.Aircraft.Code)
+		if err != nil {
+			a, err = aircraft.Lookup(s.Aircraft.Name)
+		}
+		if err == nil {
+			s.Aircraft = &Aircraft{Code: a.Code, Name: a.Name, Widebody: a.Widebody}
+		}
+	}
+	for _, end := range []*FlightStatusEnd{&s.Departure, &s.Arrival} {
+		if loc := AirportLocation(end.Airport); loc != nil {
+			for _, t := range []*time.Time{&end.Scheduled, &end.Estimated, &end.Actual} {
+				if !t.IsZero() {
+					*t = t.In(loc)
+				}
+			}
+		}
+		latest := cmp.Or(end.Actual, end.Estimated)
+		if end.DelayMinutes == 0 && !end.Scheduled.IsZero() && latest.After(end.Scheduled) {
+			end.DelayMinutes = int(latest.Sub(end.Scheduled).Minutes())
+		}
+	}
+}
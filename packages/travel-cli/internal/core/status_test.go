@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+type fakeStatusAdapter struct {
+	fakeFlightAdapter
+	status *FlightStatus
+	err    error
+}
+
+func (f *fakeStatusAdapter) FlightStatus(req FlightStatusRequest) (*FlightStatus, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	s := *f.status
+	return &s, nil
+}
+
+func TestOrchestrator_FlightStatus(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeHybrid, Providers: map[string]config.ProviderConfig{
+		"mock_flights": {Enabled: config.Bool(true), Priority: 100},
+		"tracker":      {Enabled: config.Bool(true), Priority: 60},
+		"backup":       {Enabled: config.Bool(true), Priority: 40},
+	}}
+	notFound := fmt.Errorf("%w: AC871", ErrFlightNotFound)
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeStatusAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}, status: &FlightStatus{Status: FlightScheduled}})
+	tracker := &fakeStatusAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "tracker", avail: true}, err: notFound}
+	router.RegisterStatus(tracker)
+	backup := &fakeStatusAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "backup", avail: true}, status: &FlightStatus{
+		Flight:   "AC871",
+		Airline:  "AC",
+		Status:   FlightActive,
+		Aircraft: &Aircraft{Code: "B789"},
+		Departure: FlightStatusEnd{
+			Airport:   "YUL",
+			Scheduled: time.Date(2026, 6, 12, 22, 30, 0, 0, time.UTC),
+			Actual:    time.Date(2026, 6, 12, 23, 5, 0, 0, time.UTC),
+		},
+		Arrival: FlightStatusEnd{
+			Airport:      "CDG",
+			Scheduled:    time.Date(2026, 6, 13, 5, 35, 0, 0, time.UTC),
+			Estimated:    time.Date(2026, 6, 13, 5, 55, 0, 0, time.UTC),
+			DelayMinutes: 15,
+		},
+	}}
+	router.RegisterStatus(backup)
+	orch := NewOrchestrator(router)
+	req := FlightStatusRequest{Flight: "ac 871", Date: "2026-06-12"}
+
+	s, err := orch.FlightStatus(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Source != "backup" || s.Flight != "AC871" || s.Date != "2026-06-12" || s.Airline != "Air Canada" {
+		t.Errorf("status = %s %s %s %s, want backup's AC871 by Air Canada on 2026-06-12", s.Source, s.Flight, s.Date, s.Airline)
+	}
+	if s.Aircraft == nil || s.Aircraft.Code != "789" {
+		t.Errorf("aircraft = %+v, want 789", s.Aircraft)
+	}
+	montreal, _ := time.LoadLocation("America/Toronto")
+	if want := time.Date(2026, 6, 12, 18, 30, 0, 0, montreal); !s.Departure.Scheduled.Equal(want) || s.Departure.Scheduled.Location() != AirportLocation("YUL") {
+		t.Errorf("departure scheduled = %s, want %s", s.Departure.Scheduled, want)
+	}
+	if s.Departure.DelayMinutes != 35 || s.Arrival.DelayMinutes != 15 {
+		t.Errorf("delays = %d, %d; want 35 worked out and 15 as given", s.Departure.DelayMinutes, s.Arrival.DelayMinutes)
+	}
+
+	backup.err = notFound
+	if _, err := orch.FlightStatus(req); !errors.Is(err, ErrFlightNotFound) {
+		t.Errorf("err = %v, want ErrFlightNotFound when no provider knows the flight", err)
+	}
+	backup.err = errors.New("backup: unexpected HTTP status 500")
+	if _, err := orch.FlightStatus(req); err == nil || errors.Is(err, ErrFlightNotFound) {
+		t.Errorf("err = %v, want the provider's failure", err)
+	}
+	if _, err := orch.FlightStatus(FlightStatusRequest{Flight: "AC871", Date: "06/12"}); err == nil {
+		t.Error("want an error for a malformed date")
+	}
+}
+
+func TestOrchestrator_FlightStatusNoProviders(t *testing.T) {
+	router := NewRouter(&config.Config{Mode: config.ModeMock})
+	router.RegisterFlight(&fakeFlightAdapter{name: "mock_flights", avail: true})
+	if _, err := NewOrchestrator(router).FlightStatus(FlightStatusRequest{Flight: "AC871", Date: "2026-06-12"}); !errors.Is(err, ErrNoStatusProviders) {
+		t.Errorf("err = %v, want ErrNoStatusProviders", err)
+	}
+}
+
+func TestRouter_StatusMockStandsInForLiveStatusOnly(t *testing.T) {
+	cfg := &config.Config{Mode: config.ModeHybrid, Providers: map[string]config.ProviderConfig{
+		"mock_flights": {Enabled: config.Bool(true), Priority: 100},
+		"duffel":       {Enabled: config.Bool(true), Priority: 80},
+	}}
+	router := NewRouter(cfg)
+	router.RegisterFlight(&fakeStatusAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "mock_flights", avail: true}})
+	router.RegisterFlight(&fakeFlightAdapter{name: "duffel", avail: true})
+	if got := adapterNames(router.ActiveStatusAdapters()); len(got) != 1 || got[0] != "mock_flights" {
+		t.Errorf("status adapters = %v, want mock_flights standing in for a live status provider", got)
+	}
+
+	cfg.Providers["tracker"] = config.ProviderConfig{Enabled: config.Bool(true), Priority: 40}
+	router.RegisterStatus(&fakeStatusAdapter{fakeFlightAdapter: fakeFlightAdapter{name: "tracker", avail: true}})
+	if got := adapterNames(router.ActiveStatusAdapters()); len(got) != 1 || got[0] != "tracker" {
+		t.Errorf("status adapters = %v, want tracker only", got)
+	}
+}
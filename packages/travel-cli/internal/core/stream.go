@@ -0,0 +1,63 @@
+package core
+
+// OfferBatch carries the offers one provider added to a running search:
+// offers for trips no earlier provider returned, and offers that beat the
+// best price sent so far for one. Offers are deduped but not ranked;
+// the final SearchResult is.
+type OfferBatch struct {
+	Search   string        `json:"search"`
+	Provider string        `json:"provider"`
+	Flights  []FlightOffer `json:"flights,omitempty"`
+	Stays    []StayOffer   `json:"stays,omitempty"`
+}
+
+// WithBatches calls fn with each provider's offers as they arrive, before
+// the search completes. Calls are serialized.
+func (o *Orchestrator) WithBatches(fn func(OfferBatch)) *Orchestrator {
+	o.batches = fn
+	return o
+}
+
+// Streaming returns a copy of o that calls fn with each provider's offers
+// as they arrive. The copy shares o's router, cache, and limits, so one
+// orchestrator can stream concurrent searches to their own callers.
+func (o *Orchestrator) Streaming(fn func(OfferBatch)) *Orchestrator {
+	c := *o
+	c.batches = fn
+	return &c
+}
+
+// stayStream tracks the best stay streamed per property, matching offers
+// with match; a nil match streams every offer.
+type stayStream struct {
+	match func(a, b StayOffer) bool
+	best  []StayOffer
+}
+
+// add returns the offers in batch that are new properties or cheaper than
+// any sent before for theirs.
+func (s *stayStream) add(batch []StayOffer) []StayOffer {
+	if s.match == nil {
+		return batch
+	}
+	var out []StayOffer
+	for _, st := range batch {
+		found := -1
+		for i := range s.best {
+			if s.match(s.best[i], st) {
+				found = i
+				break
+			}
+		}
+		switch {
+		case found < 0:
+			s.best = append(s.best, st)
+		case st.TotalPriceUSD < s.best[found].TotalPriceUSD:
+			s.best[found] = st
+		default:
+			continue
+		}
+		out = append(out, st)
+	}
+	return out
+}
@@ -0,0 +1,134 @@
+package core
+
+import "sort"
+
+// ResultSummary describes the price distribution of a search so AI
+// consumers can reason about it without iterating every offer. It is
+// computed over all deduped offers, before truncation to MaxResults.
+// Stay prices are per night.
+type ResultSummary struct {
+	Count          int                      `json:"count"`
+	MinPriceUSD    float64                  `json:"minPriceUSD"`
+	MedianPriceUSD float64                  `json:"medianPriceUSD"`
+	MaxPriceUSD    float64                  `json:"maxPriceUSD"`
+	DirectFlights  *int                     `json:"directFlights,omitempty"`
+	AvgRating      float64                  `json:"avgRating,omitempty"`
+	BySource       map[string]SourceSummary `json:"bySource,omitempty"`
+}
+
+type SourceSummary struct {
+	Count       int     `json:"count"`
+	MinPriceUSD float64 `json:"minPriceUSD"`
+	AvgPriceUSD float64 `json:"avgPriceUSD"`
+}
+
+// LowestFlight returns CheapestFlight or, for a result without it such as
+// one saved by an earlier version, the cheapest of Flights.
+func (r *SearchResult) LowestFlight() *FlightOffer {
+	if r.CheapestFlight != nil {
+		return r.CheapestFlight
+	}
+	var low *FlightOffer
+	for i := range r.Flights {
+		if low == nil || r.Flights[i].PriceUSD < low.PriceUSD {
+			low = &r.Flights[i]
+		}
+	}
+	return low
+}
+
+// LowestStay is the stay counterpart of LowestFlight, comparing prices
+// per night.
+func (r *SearchResult) LowestStay() *StayOffer {
+	if r.CheapestStay != nil {
+		return r.CheapestStay
+	}
+	return cheapestStay(r.Stays)
+}
+
+func cheapestStay(stays []StayOffer) *StayOffer {
+	var low *StayOffer
+	for i := range stays {
+		if low == nil || stays[i].PricePerNight < low.PricePerNight {
+			low = &stays[i]
+		}
+	}
+	return low
+}
+
+func SummarizeFlights(flights []FlightOffer) *ResultSummary {
+	if len(flights) == 0 {
+		return nil
+	}
+	prices := make([]float64, 0, len(flights))
+	sources := make([]string, 0, len(flights))
+	direct := 0
+	for _, f := range flights {
+		prices = append(prices, f.PriceUSD)
+		sources = append(sources, f.Source)
+		if f.Stops == 0 {
+			direct++
+		}
+	}
+	s := summarizePrices(prices, sources)
+	s.DirectFlights = &direct
+	return s
+}
+
+func SummarizeStays(stays []StayOffer) *ResultSummary {
+	if len(stays) == 0 {
+		return nil
+	}
+	prices := make([]float64, 0, len(stays))
+	sources := make([]string, 0, len(stays))
+	var ratingSum float64
+	rated := 0
+	for _, st := range stays {
+		prices = append(prices, st.PricePerNight)
+		sources = append(sources, st.Source)
+		if st.Rating > 0 {
+			ratingSum += st.Rating
+			rated++
+		}
+	}
+	s := summarizePrices(prices, sources)
+	if rated > 0 {
+		s.AvgRating = round2(ratingSum / float64(rated))
+	}
+	return s
+}
+
+func summarizePrices(prices []float64, sources []string) *ResultSummary {
+	s := &ResultSummary{Count: len(prices), BySource: map[string]SourceSummary{}}
+
+	totals := map[string]float64{}
+	for i, p := range prices {
+		src := s.BySource[sources[i]]
+		if src.Count == 0 || p < src.MinPriceUSD {
+			src.MinPriceUSD = p
+		}
+		src.Count++
+		totals[sources[i]] += p
+		s.BySource[sources[i]] = src
+	}
+	for name, src := range s.BySource {
+		src.AvgPriceUSD = round2(totals[name] / float64(src.Count))
+		s.BySource[name] = src
+	}
+
+	sorted := append([]float64(nil), prices...)
+	sort.Float64s(sorted)
+	s.MinPriceUSD = sorted[0]
+	s.MaxPriceUSD = sorted[len(sorted)-1]
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		s.MedianPriceUSD = round2((sorted[mid-1] + sorted[mid]) / 2)
+	} else {
+		s.MedianPriceUSD = sorted[mid]
+	}
+	return s
+}
+
+func round2(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
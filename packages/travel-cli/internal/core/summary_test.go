@@ -0,0 +1,47 @@
+package core
+
+import "testing"
+
+func TestSummarizeFlights(t *testing.T) {
+	flights := []FlightOffer{
+		{Source: "mock_flights", PriceUSD: 400, Stops: 0},
+		{Source: "mock_flights", PriceUSD: 600, Stops: 1},
+		{Source: "duffel", PriceUSD: 500, Stops: 0},
+	}
+
+	s := SummarizeFlights(flights)
+	if s.MinPriceUSD != 400 || s.MedianPriceUSD != 500 || s.MaxPriceUSD != 600 {
+		t.Errorf("unexpected price range: %+v", s)
+	}
+	if s.DirectFlights == nil || *s.DirectFlights != 2 {
+		t.Errorf("expected 2 direct flights, got %v", s.DirectFlights)
+	}
+	if got := s.BySource["mock_flights"]; got.Count != 2 || got.MinPriceUSD != 400 || got.AvgPriceUSD != 500 {
+		t.Errorf("unexpected mock_flights breakdown: %+v", got)
+	}
+}
+
+func TestSummarizeStays_AverageRating(t *testing.T) {
+	stays := []StayOffer{
+		{Source: "mock_stays", PricePerNight: 100, Rating: 4.0},
+		{Source: "mock_stays", PricePerNight: 200, Rating: 4.5},
+		{Source: "mock_stays", PricePerNight: 150},
+	}
+
+	s := SummarizeStays(stays)
+	if s.MedianPriceUSD != 150 {
+		t.Errorf("expected median 150, got %.2f", s.MedianPriceUSD)
+	}
+	if s.AvgRating != 4.25 {
+		t.Errorf("expected unrated stays to be ignored in avg rating, got %.2f", s.AvgRating)
+	}
+	if s.DirectFlights != nil {
+		t.Error("direct flight count should be omitted for stays")
+	}
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	if SummarizeFlights(nil) != nil || SummarizeStays(nil) != nil {
+		t.Error("expected nil summary for empty results")
+	}
+}
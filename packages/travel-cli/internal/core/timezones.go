@@ -0,0 +1,58 @@
+package core
+
+import (
+	"sync"
+	"time"
+	// Airport times must come out the same on hosts without a zoneinfo
+	// database, such as Windows and scratch containers.
+	_ "time/tzdata"
+
+	"github.com/beetlebot/travel-cli/internal/airports"
+)
+
+const (
+	localTimeLayout = "2006-01-02T15:04:05"
+	utcOffsetLayout = "-07:00"
+)
+
+var zones sync.Map // place -> *time.Location, nil when unknown
+
+// AirportLocation returns the time zone of an airport, metropolitan code,
+// or city, as the bundled airport list gives it, or nil when the list
+// lacks the place or its zone.
+func AirportLocation(place string) *time.Location {
+	if loc, ok := zones.Load(place); ok {
+		return loc.(*time.Location)
+	}
+	var loc *time.Location
+	if a, err := airports.Find(place); err == nil && a.Timezone != "" {
+		loc, _ = time.LoadLocation(a.Timezone)
+	}
+	zones.Store(place, loc)
+	return loc
+}
+
+// localizeFlightTimes puts each offer's departure and arrival in the time
+// zones of its airports, sets the local times and UTC offsets from them,
+// and measures the duration between the two instants. Adapters give
+// instants: those reading a provider's airport clocks without an offset
+// read them in the airport's zone, as the mock does, so a UTC time here is
+// UTC. Times at airports the list lacks are left alone.
+func localizeFlightTimes(flights []FlightOffer) {
+	for i := range flights {
+		f := &flights[i]
+		from, to := AirportLocation(f.From), AirportLocation(f.To)
+		if from != nil && !f.DepartTime.IsZero() {
+			f.DepartTime = f.DepartTime.In(from)
+			f.DepartTimeLocal, f.DepartUTCOffset = f.DepartTime.Format(localTimeLayout), f.DepartTime.Format(utcOffsetLayout)
+		}
+		if to != nil && !f.ArriveTime.IsZero() {
+			f.ArriveTime = f.ArriveTime.In(to)
+			f.ArriveTimeLocal, f.ArriveUTCOffset = f.ArriveTime.Format(localTimeLayout), f.ArriveTime.Format(utcOffsetLayout)
+		}
+		if from != nil && to != nil && f.ArriveTime.After(f.DepartTime) {
+			f.Duration = f.ArriveTime.Sub(f.DepartTime)
+			f.DurationMinutes = int(f.Duration.Minutes())
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocalizeFlightTimes(t *testing.T) {
+	flights := []FlightOffer{
+		// Given in UTC: Montreal is UTC-4 and Paris UTC+2 in June.
+		{From: "YUL", To: "CDG", DepartTime: time.Date(2026, 6, 12, 22, 0, 0, 0, time.UTC), ArriveTime: time.Date(2026, 6, 13, 5, 15, 0, 0, time.UTC), DurationMinutes: 795},
+		// Given with other offsets.
+		{From: "NRT", To: "LAX", DepartTime: time.Date(2026, 1, 10, 17, 0, 0, 0, time.FixedZone("", 9*3600)), ArriveTime: time.Date(2026, 1, 10, 10, 30, 0, 0, time.FixedZone("", -8*3600))},
+		{From: "XXX", To: "CDG", DepartTime: time.Date(2026, 6, 12, 18, 0, 0, 0, time.UTC), DurationMinutes: 60},
+	}
+	localizeFlightTimes(flights)
+
+	f := flights[0]
+	if f.DepartTimeLocal != "2026-06-12T18:00:00" || f.DepartUTCOffset != "-04:00" || f.ArriveTimeLocal != "2026-06-13T07:15:00" || f.ArriveUTCOffset != "+02:00" {
+		t.Errorf("YUL-CDG local times = %s%s, %s%s", f.DepartTimeLocal, f.DepartUTCOffset, f.ArriveTimeLocal, f.ArriveUTCOffset)
+	}
+	if !f.DepartTime.Equal(time.Date(2026, 6, 12, 22, 0, 0, 0, time.UTC)) || f.DurationMinutes != 435 {
+		t.Errorf("YUL-CDG departs %s after %d minutes, want 22:00 UTC after 435", f.DepartTime.UTC(), f.DurationMinutes)
+	}
+
+	f = flights[1]
+	if f.DepartTimeLocal != "2026-01-10T17:00:00" || f.ArriveTimeLocal != "2026-01-10T10:30:00" || f.DurationMinutes != 630 {
+		t.Errorf("NRT-LAX = %s to %s in %d minutes, want 630", f.DepartTimeLocal, f.ArriveTimeLocal, f.DurationMinutes)
+	}
+
+	f = flights[2]
+	if f.DepartTimeLocal != "" || f.DepartTime.Location() != time.UTC || f.DurationMinutes != 60 {
+		t.Errorf("unknown airport changed: %+v", f)
+	}
+}
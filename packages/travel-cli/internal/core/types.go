@@ -1,6 +1,9 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/beetlebot/travel-cli/internal/config"
@@ -10,7 +13,9 @@ type Capability string
 
 const (
 	CapFlightsSearch Capability = "flights.search"
+	CapFlightsStatus Capability = "flights.status"
 	CapStaysSearch   Capability = "stays.search"
+	CapStaysLookup   Capability = "stays.lookup"
 	CapReprice       Capability = "reprice"
 	CapDeepLink      Capability = "deepLink"
 )
@@ -31,17 +36,64 @@ type FlightSearchRequest struct {
 	Adults     int    `json:"adults,omitempty"`
 	CabinClass string `json:"cabinClass,omitempty"`
 	MaxResults int    `json:"maxResults,omitempty"`
+	GroupBy    string `json:"groupBy,omitempty"`
+	// Alliance keeps only offers sold by members of one airline
+	// alliance: star, oneworld, or skyteam.
+	Alliance string `json:"alliance,omitempty"`
+	// Wifi keeps only offers whose provider says the flight has wifi.
+	Wifi bool `json:"wifi,omitempty"`
+	// MaxStops keeps only offers with at most this many stops, 0 for
+	// nonstop flights; nil keeps offers with any number.
+	MaxStops *int `json:"maxStops,omitempty"`
+	// MaxPriceUSD keeps only offers priced at or under it (0 = no limit).
+	MaxPriceUSD int `json:"maxPriceUSD,omitempty"`
 }
 
 type StaySearchRequest struct {
-	City        string `json:"city"`
-	CheckIn     string `json:"checkIn"`
-	CheckOut    string `json:"checkOut"`
-	Guests      int    `json:"guests,omitempty"`
-	Rooms       int    `json:"rooms,omitempty"`
-	MaxResults  int    `json:"maxResults,omitempty"`
-	StayType    string `json:"stayType,omitempty"`
-	MaxPriceUSD int    `json:"maxPriceUSD,omitempty"`
+	City     string `json:"city"`
+	CheckIn  string `json:"checkIn"`
+	CheckOut string `json:"checkOut"`
+	// Guests counts adults; children are listed by age in ChildrenAges
+	// so providers can price them by age band.
+	Guests       int    `json:"guests,omitempty"`
+	ChildrenAges []int  `json:"childrenAges,omitempty"`
+	Rooms        int    `json:"rooms,omitempty"`
+	MaxResults   int    `json:"maxResults,omitempty"`
+	StayType     string `json:"stayType,omitempty"`
+	MaxPriceUSD  int    `json:"maxPriceUSD,omitempty"`
+	GroupBy      string `json:"groupBy,omitempty"`
+	// Near keeps only stays within RadiusKm of a landmark, address, or
+	// "latitude,longitude", and sets their DistanceKm.
+	Near     string  `json:"near,omitempty"`
+	RadiusKm float64 `json:"radiusKm,omitempty"`
+	// Neighborhood keeps only stays in a neighborhood of City.
+	Neighborhood string `json:"neighborhood,omitempty"`
+	// Sort is SortBest, the default, or SortDistance to list the stays
+	// closest to Near first.
+	Sort string `json:"sort,omitempty"`
+	// Weather adds the weather in City over the stay to the result.
+	Weather bool `json:"weather,omitempty"`
+	// MinRating and MinReviews keep only stays rated at least MinRating
+	// out of 5 by at least MinReviews reviews; stays a provider gives no
+	// rating or review count for don't meet them.
+	MinRating  float64 `json:"minRating,omitempty"`
+	MinReviews int     `json:"minReviews,omitempty"`
+	// Breakfast keeps only stays whose rate includes breakfast; see
+	// IncludesBreakfast.
+	Breakfast bool `json:"breakfast,omitempty"`
+}
+
+// StayLookupRequest prices a known property directly, either by provider
+// property ID or by hotel chain within a city.
+type StayLookupRequest struct {
+	PropertyID string `json:"propertyId,omitempty"`
+	Chain      string `json:"chain,omitempty"`
+	City       string `json:"city,omitempty"`
+	CheckIn    string `json:"checkIn"`
+	CheckOut   string `json:"checkOut"`
+	Guests     int    `json:"guests,omitempty"`
+	Rooms      int    `json:"rooms,omitempty"`
+	MaxResults int    `json:"maxResults,omitempty"`
 }
 
 type FlightOffer struct {
@@ -59,20 +111,92 @@ type FlightOffer struct {
 	CabinClass      string        `json:"cabinClass"`
 	PriceUSD        float64       `json:"priceUSD"`
 	Currency        string        `json:"currency"`
+	Prices          []SourcePrice `json:"prices,omitempty"`
 	DeepLink        string        `json:"deepLink,omitempty"`
 	Confidence      float64       `json:"confidence"`
 	IsBookable      bool          `json:"isBookable"`
 	RepriceRequired bool          `json:"repriceRequired"`
 	FetchedAt       time.Time     `json:"fetchedAt"`
+	// MarketedBy is the airline selling the flight under its number and
+	// OperatedBy the one flying it; they differ on codeshares. Adapters
+	// may set OperatedBy; the orchestrator fills both from the bundled
+	// airline list.
+	MarketedBy *Carrier `json:"marketedBy,omitempty"`
+	OperatedBy *Carrier `json:"operatedBy,omitempty"`
+	// ExpiresAt is when the provider stops honouring the offer, and
+	// PriceGuaranteedUntil when its price may start to change. Zero
+	// when the provider does not say.
+	ExpiresAt            time.Time `json:"expiresAt,omitzero"`
+	PriceGuaranteedUntil time.Time `json:"priceGuaranteedUntil,omitzero"`
+	// FXDate is the date of the exchange rates the offer's prices were
+	// converted to dollars at, when the provider quoted another currency.
+	FXDate string `json:"fxDate,omitempty"`
+	// AgeSeconds and RefreshRecommended are set at output time by
+	// AnnotateFreshness.
+	AgeSeconds         int  `json:"ageSeconds"`
+	RefreshRecommended bool `json:"refreshRecommended"`
+	// DepartTime and ArriveTime are in their airports' time zones. The
+	// local times, without offset, and the UTC offsets are spelled out
+	// for readers that don't parse timestamps; they are empty for
+	// airports the bundled list lacks.
+	DepartTimeLocal string `json:"departTimeLocal,omitempty"`
+	DepartUTCOffset string `json:"departUtcOffset,omitempty"`
+	ArriveTimeLocal string `json:"arriveTimeLocal,omitempty"`
+	ArriveUTCOffset string `json:"arriveUtcOffset,omitempty"`
+	// Aircraft is the type flown, as adapters give it by code or name;
+	// the orchestrator completes it from the bundled aircraft list.
+	// WifiAvailable is nil when the provider doesn't say. SeatPitch is
+	// in inches: the provider's figure, or the type's typical economy
+	// pitch for economy offers.
+	Aircraft      *Aircraft `json:"aircraft,omitempty"`
+	WifiAvailable *bool     `json:"wifiAvailable,omitempty"`
+	SeatPitch     int       `json:"seatPitch,omitempty"`
+	// OnTimeScore is the share of the flight's past departures that
+	// arrived on time, when an on-time source knows it.
+	OnTimeScore *float64 `json:"onTimeScore,omitempty"`
+	// BaggagePolicyURL is the selling airline's baggage policy page;
+	// `travel airlines baggage` tells what its fares include.
+	BaggagePolicyURL string `json:"baggagePolicyURL,omitempty"`
+}
+
+// Carrier is an airline an offer names.
+type Carrier struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Alliance string `json:"alliance,omitempty"`
+	LowCost  bool   `json:"lowCost,omitempty"`
+}
+
+// Aircraft is an aircraft type an offer names. Code is the IATA type
+// code.
+type Aircraft struct {
+	Code     string `json:"code,omitempty"`
+	Name     string `json:"name"`
+	Widebody bool   `json:"widebody,omitempty"`
+}
+
+// SourcePrice is one provider's price for an offer that several providers
+// returned.
+type SourcePrice struct {
+	Source   string  `json:"source"`
+	OfferID  string  `json:"offerId"`
+	PriceUSD float64 `json:"priceUSD"`
+	DeepLink string  `json:"deepLink,omitempty"`
 }
 
 type StayOffer struct {
 	ID              string    `json:"id"`
 	Source          string    `json:"source"`
+	Sources         []string  `json:"sources,omitempty"`
 	Name            string    `json:"name"`
+	PropertyID      string    `json:"propertyId,omitempty"`
+	Chain           string    `json:"chain,omitempty"`
 	Type            string    `json:"type"`
 	City            string    `json:"city"`
 	Address         string    `json:"address,omitempty"`
+	Latitude        float64   `json:"latitude,omitempty"`
+	Longitude       float64   `json:"longitude,omitempty"`
+	Neighborhood    string    `json:"neighborhood,omitempty"`
 	CheckIn         string    `json:"checkIn"`
 	CheckOut        string    `json:"checkOut"`
 	NightsCount     int       `json:"nightsCount"`
@@ -87,12 +211,40 @@ type StayOffer struct {
 	IsBookable      bool      `json:"isBookable"`
 	RepriceRequired bool      `json:"repriceRequired"`
 	FetchedAt       time.Time `json:"fetchedAt"`
+	// Board is the meals the rate includes: BoardRoomOnly, BoardBreakfast,
+	// and so on, or empty when the provider doesn't say. Adapters may set
+	// the provider's own code; the orchestrator maps it with NormalizeBoard.
+	Board string `json:"board,omitempty"`
+	// ChildrenPriceUSD is the part of TotalPriceUSD charged for the
+	// children in StaySearchRequest.ChildrenAges; PricePerNight and
+	// TotalPriceUSD already include it.
+	ChildrenPriceUSD float64 `json:"childrenPriceUSD,omitempty"`
+	// DistanceKm is how far the stay is from StaySearchRequest.Near.
+	DistanceKm float64 `json:"distanceKm,omitempty"`
+	// See FlightOffer.
+	ExpiresAt            time.Time `json:"expiresAt,omitzero"`
+	PriceGuaranteedUntil time.Time `json:"priceGuaranteedUntil,omitzero"`
+	// See FlightOffer.
+	FXDate string `json:"fxDate,omitempty"`
+	// AgeSeconds and RefreshRecommended are set at output time by
+	// AnnotateFreshness.
+	AgeSeconds         int  `json:"ageSeconds"`
+	RefreshRecommended bool `json:"refreshRecommended"`
 }
 
 type CombinedOffer struct {
-	FlightOfferID string  `json:"flightOfferId"`
-	StayOfferID   string  `json:"stayOfferId"`
-	TotalPriceUSD float64 `json:"totalPriceUSD"`
+	FlightOfferID string         `json:"flightOfferId"`
+	StayOfferID   string         `json:"stayOfferId"`
+	TotalPriceUSD float64        `json:"totalPriceUSD"`
+	Warnings      []OfferWarning `json:"warnings,omitempty"`
+	// FXDate is the date of the exchange rates an offer's prices were
+	// converted to dollars at, when a provider quoted another currency.
+	FXDate string `json:"fxDate,omitempty"`
+	// VisaRequired hints whether the traveler needs a visa for the
+	// trip's destination, and EntryRequirement is what they need there;
+	// see CheckVisa.
+	VisaRequired     *bool  `json:"visaRequired,omitempty"`
+	EntryRequirement string `json:"entryRequirement,omitempty"`
 }
 
 type SearchResult struct {
@@ -103,14 +255,81 @@ type SearchResult struct {
 	Stays      []StayOffer     `json:"stays,omitempty"`
 	Combined   []CombinedOffer `json:"combined,omitempty"`
 	TotalFound int             `json:"totalFound"`
+	Summary    *ResultSummary  `json:"summary,omitempty"`
+	Groups     []OfferGroup    `json:"groups,omitempty"`
 	Errors     []ProviderError `json:"errors,omitempty"`
-	FetchedAt  time.Time       `json:"fetchedAt"`
+	// CheapestFlight and CheapestStay are the lowest-priced offers of
+	// every one found, which maxResults may have left out of Flights or
+	// Stays. Stays are compared per night, like Summary and MaxPriceUSD.
+	CheapestFlight *FlightOffer `json:"cheapestFlight,omitempty"`
+	CheapestStay   *StayOffer   `json:"cheapestStay,omitempty"`
+	// Resolved lists the places the search was given as city names and
+	// the codes they were searched as.
+	Resolved []ResolvedPlace `json:"resolved,omitempty"`
+	// Weather is the weather over a stay search's dates, when asked for.
+	Weather *WeatherSummary `json:"weather,omitempty"`
+	// HolidayOverlap names the public holidays at the places searched
+	// during its dates, which often explain high prices.
+	HolidayOverlap []string `json:"holidayOverlap,omitempty"`
+	// Partial is set when the soft deadline passed before every provider
+	// answered; those still searching are listed in Errors.
+	Partial   bool      `json:"partial,omitempty"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	// FromCache is set when the result was stored by an earlier search
+	// rather than fetched now; FetchedAt says when.
+	FromCache bool `json:"fromCache,omitempty"`
+}
+
+// DecodeResult reads a search result written as JSON, either bare or as
+// the data of a command's output document, restoring the fields JSON
+// leaves out.
+func DecodeResult(data []byte) (*SearchResult, error) {
+	var doc struct {
+		SchemaVersion int             `json:"schemaVersion"`
+		Data          json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(data, &doc) == nil && doc.SchemaVersion > 0 && len(doc.Data) > 0 {
+		data = doc.Data
+	}
+	var result SearchResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	for i := range result.Flights {
+		result.Flights[i].Duration = time.Duration(result.Flights[i].DurationMinutes) * time.Minute
+	}
+	return &result, nil
 }
 
 type ProviderError struct {
 	Provider string `json:"provider"`
 	Reason   string `json:"reason"`
 	Fallback string `json:"fallback,omitempty"`
+	// Maintenance is set when the provider was skipped or answered with a
+	// maintenance status.
+	Maintenance *MaintenanceWindow `json:"maintenance,omitempty"`
+	// Attempts is how many times the provider was searched before it was
+	// reported, when it was searched at all.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// PlaceMatch is a city and the airport or metropolitan code that
+// searches it.
+type PlaceMatch struct {
+	Code        string   `json:"code"`
+	City        string   `json:"city"`
+	CountryCode string   `json:"countryCode"`
+	Airports    []string `json:"airports"`
+}
+
+// ResolvedPlace is a city name given for a request field, from or to,
+// and the city it was read as. Alternatives are the other cities the name
+// may mean, likeliest first.
+type ResolvedPlace struct {
+	Field string `json:"field"`
+	Input string `json:"input"`
+	PlaceMatch
+	Alternatives []PlaceMatch `json:"alternatives,omitempty"`
 }
 
 type ProviderInfo struct {
@@ -119,6 +338,8 @@ type ProviderInfo struct {
 	Tier         ProviderTier `json:"tier"`
 	Status       string       `json:"status"`
 	Reason       string       `json:"reason,omitempty"`
+	// ReenableAt is when a provider in maintenance is routed to again.
+	ReenableAt time.Time `json:"reenableAt,omitzero"`
 }
 
 type DoctorReport struct {
@@ -126,6 +347,8 @@ type DoctorReport struct {
 	Providers []ProviderInfo `json:"providers"`
 	Healthy   bool           `json:"healthy"`
 	Summary   string         `json:"summary"`
+	// EnvFile is the .env file provider keys were loaded from, if any.
+	EnvFile string `json:"envFile,omitempty"`
 }
 
 type FlightAdapter interface {
@@ -143,3 +366,56 @@ type StayAdapter interface {
 	Available() (bool, string)
 	SearchStays(req StaySearchRequest) ([]StayOffer, error)
 }
+
+// PropertyLookupAdapter is implemented by stay adapters that can price a
+// specific property or chain without a broad city search.
+type PropertyLookupAdapter interface {
+	LookupStays(req StayLookupRequest) ([]StayOffer, error)
+}
+
+// FlightStatusAdapter is implemented by flight adapters that can tell
+// where a flight is on the day: gates, delays, and aircraft.
+type FlightStatusAdapter interface {
+	FlightStatus(req FlightStatusRequest) (*FlightStatus, error)
+}
+
+// StatusAdapter is a provider of flight status only, such as a flight
+// tracking API, or a flight adapter that tells status too.
+type StatusAdapter interface {
+	Name() string
+	Tier() ProviderTier
+	Capabilities() []Capability
+	Available() (bool, string)
+	FlightStatusAdapter
+}
+
+// CredentialValidator is implemented by adapters that can check API keys
+// against the provider before they are saved. creds maps environment
+// variable names to values.
+type CredentialValidator interface {
+	ValidateCredentials(ctx context.Context, creds map[string]string) error
+}
+
+// ErrInvalidCredentials is returned by CredentialValidator when the
+// provider rejects the keys.
+var ErrInvalidCredentials = errors.New("provider rejected the credentials")
+
+// Warmer is implemented by adapters that can prepare for a search ahead of
+// time, e.g. by resolving OAuth tokens or opening connections.
+type Warmer interface {
+	Warmup(ctx context.Context) error
+}
+
+// RequestMeterer is implemented by adapters that count each HTTP request
+// they send, retries included, through the orchestrator's Spend, and that
+// retry failed requests themselves. Their searches are then not counted,
+// throttled, or retried again as a whole.
+type RequestMeterer interface {
+	MetersRequests() bool
+}
+
+// metersRequests reports whether adapter counts its own requests.
+func metersRequests(adapter interface{ Name() string }) bool {
+	m, ok := adapter.(RequestMeterer)
+	return ok && m.MetersRequests()
+}
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeResult(t *testing.T) {
+	bare := `{"flights":[{"id":"f_1","source":"duffel","durationMinutes":90}],"totalFound":1}`
+	wrapped := `{"schemaVersion":1,"command":"flights search","data":` + bare + `}`
+	for name, doc := range map[string]string{"bare": bare, "document": wrapped} {
+		result, err := DecodeResult([]byte(doc))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(result.Flights) != 1 || result.Flights[0].ID != "f_1" || result.Flights[0].Duration != 90*time.Minute {
+			t.Errorf("%s: decoded %+v", name, result.Flights)
+		}
+	}
+	if _, err := DecodeResult([]byte("mode: live")); err == nil {
+		t.Error("decoded YAML as a result")
+	}
+}
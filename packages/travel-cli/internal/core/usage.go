@@ -0,0 +1,62 @@
+package core
+
+import (
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// UsageWarnAt is the share of a provider's monthly limit past which each
+// call is warned about.
+const UsageWarnAt = 0.8
+
+// UsageLedger counts provider calls by month. quota.Ledger is one.
+type UsageLedger interface {
+	// Record counts a call to provider at the given estimated cost and
+	// returns the calls made to it so far in at's month.
+	Record(provider string, costUSD float64, at time.Time) (calls int, err error)
+}
+
+// WithUsage counts each provider call in l: each HTTP request, retries
+// included, for a provider whose traffic is metered (see Spend), or else
+// each search. Each costs the provider's providers.<name>.costPerCall,
+// and every call past UsageWarnAt of its providers.<name>.monthlyLimit
+// logs a warning. Searches skipped
+// for maintenance, rate limits, or a cached result don't count. A nil l
+// turns counting off.
+func (o *Orchestrator) WithUsage(l UsageLedger) *Orchestrator {
+	o.usage = l
+	return o
+}
+
+// meter wraps search so each call is counted in the usage ledger,
+// unless the provider's HTTP requests are counted as they're sent.
+func meter[A interface{ Name() string }, O any](o *Orchestrator, search func(A) ([]O, error)) func(A) ([]O, error) {
+	if o.usage == nil {
+		return search
+	}
+	return func(a A) ([]O, error) {
+		if !metersRequests(a) {
+			o.record(a.Name())
+		}
+		return search(a)
+	}
+}
+
+// record counts a call to provider in the usage ledger, warning once it
+// nears or passes the provider's monthly limit.
+func (o *Orchestrator) record(provider string) {
+	if o.usage == nil {
+		return
+	}
+	pc := o.router.cfg.Providers[provider]
+	calls, err := o.usage.Record(provider, pc.CostPerCall, time.Now())
+	switch {
+	case err != nil:
+		logging.Logger().Debug("usage not recorded", "provider", provider, "error", err)
+	case pc.MonthlyLimit > 0 && calls > pc.MonthlyLimit:
+		logging.Logger().Warn("provider over its monthly limit", "provider", provider, "calls", calls, "limit", pc.MonthlyLimit)
+	case pc.MonthlyLimit > 0 && float64(calls) >= UsageWarnAt*float64(pc.MonthlyLimit):
+		logging.Logger().Warn("provider nearing its monthly limit", "provider", provider, "calls", calls, "limit", pc.MonthlyLimit)
+	}
+}
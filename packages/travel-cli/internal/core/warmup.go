@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type WarmupResult struct {
+	Provider   string `json:"provider"`
+	DurationMS int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Warmup prepares every active live adapter that implements Warmer, in
+// parallel. Mock adapters have nothing to warm and are skipped.
+func (r *Router) Warmup(ctx context.Context) []WarmupResult {
+	var warmers []namedWarmer
+	for _, a := range r.ActiveFlightAdapters() {
+		if w, ok := a.(Warmer); ok && !isMockProvider(a.Name()) {
+			warmers = append(warmers, namedWarmer{a.Name(), w})
+		}
+	}
+	for _, a := range r.ActiveStayAdapters() {
+		if w, ok := a.(Warmer); ok && !isMockProvider(a.Name()) {
+			warmers = append(warmers, namedWarmer{a.Name(), w})
+		}
+	}
+
+	results := make([]WarmupResult, len(warmers))
+	var wg sync.WaitGroup
+	for i, nw := range warmers {
+		wg.Add(1)
+		go func(i int, nw namedWarmer) {
+			defer wg.Done()
+			start := time.Now()
+			err := nw.w.Warmup(ctx)
+			results[i] = WarmupResult{Provider: nw.name, DurationMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, nw)
+	}
+	wg.Wait()
+	return results
+}
+
+type namedWarmer struct {
+	name string
+	w    Warmer
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// Where a WeatherSummary's days come from.
+const (
+	WeatherForecast = "forecast"
+	WeatherHistory  = "history"
+)
+
+// wetDayMm is the precipitation from which a day counts as wet.
+const wetDayMm = 1.0
+
+// WeatherSummary is the weather in a city over a trip's dates: forecast
+// when they are near enough, otherwise what the same dates were like in
+// past years.
+type WeatherSummary struct {
+	City string `json:"city"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Source is WeatherForecast or WeatherHistory, averaging Years years.
+	Source   string  `json:"source"`
+	Years    int     `json:"years,omitempty"`
+	AvgHighC float64 `json:"avgHighC"`
+	AvgLowC  float64 `json:"avgLowC"`
+	// PrecipitationMm is the total over the dates, and WetDays the days
+	// with at least 1 mm.
+	PrecipitationMm float64      `json:"precipitationMm"`
+	WetDays         int          `json:"wetDays"`
+	Days            []WeatherDay `json:"days,omitempty"`
+}
+
+type WeatherDay struct {
+	Date            string  `json:"date"`
+	HighC           float64 `json:"highC"`
+	LowC            float64 `json:"lowC"`
+	PrecipitationMm float64 `json:"precipitationMm"`
+}
+
+// Forecaster tells the daily weather at a point from one date to another,
+// both included, setting the summary's Source, Years, and Days.
+type Forecaster interface {
+	Weather(ctx context.Context, lat, lon float64, from, to time.Time) (*WeatherSummary, error)
+}
+
+// WithWeather lets Weather and stay searches asking for it tell the
+// weather at a destination. Cities are found with the geocoder.
+func (o *Orchestrator) WithWeather(f Forecaster) *Orchestrator {
+	o.weather = f
+	return o
+}
+
+// Weather returns the weather in city from one YYYY-MM-DD date to
+// another, both included.
+func (o *Orchestrator) Weather(city, from, to string) (*WeatherSummary, error) {
+	if o.weather == nil || o.geocoder == nil {
+		return nil, errors.New("no weather source")
+	}
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	end, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+	if end.Before(start) {
+		return nil, errors.New("to is before from")
+	}
+	ctx := o.searchContext()
+	place, err := o.geocoder.Geocode(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+	w, err := o.weather.Weather(ctx, place.Latitude, place.Longitude, start, end)
+	if err != nil {
+		return nil, err
+	}
+	w.City, w.From, w.To = city, from, to
+	summarizeWeather(w)
+	return w, nil
+}
+
+// stayWeather returns the weather summary a stay search embeds, without
+// its days, or nil when it can't be told; a search never fails for it.
+func (o *Orchestrator) stayWeather(req StaySearchRequest) *WeatherSummary {
+	w, err := o.Weather(req.City, req.CheckIn, req.CheckOut)
+	if err != nil {
+		logging.Logger().Warn("weather not added", "city", req.City, "error", err)
+		return nil
+	}
+	w.Days = nil
+	return w
+}
+
+// summarizeWeather sets w's averages and totals from its days.
+func summarizeWeather(w *WeatherSummary) {
+	if len(w.Days) == 0 {
+		return
+	}
+	var high, low, precipitation float64
+	for _, d := range w.Days {
+		high += d.HighC
+		low += d.LowC
+		precipitation += d.PrecipitationMm
+		if d.PrecipitationMm >= wetDayMm {
+			w.WetDays++
+		}
+	}
+	n := float64(len(w.Days))
+	w.AvgHighC = math.Round(high/n*10) / 10
+	w.AvgLowC = math.Round(low/n*10) / 10
+	w.PrecipitationMm = math.Round(precipitation*10) / 10
+}
@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fixedForecaster forecasts a high of 20, a low of 12, and 3 mm of rain on
+// the first day for any place and dates.
+type fixedForecaster struct{}
+
+func (fixedForecaster) Weather(ctx context.Context, lat, lon float64, from, to time.Time) (*WeatherSummary, error) {
+	w := &WeatherSummary{Source: WeatherForecast}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		w.Days = append(w.Days, WeatherDay{Date: d.Format(time.DateOnly), HighC: 20, LowC: 12})
+	}
+	w.Days[0].PrecipitationMm = 3
+	return w, nil
+}
+
+func TestOrchestrator_Weather(t *testing.T) {
+	geocoder := &mapGeocoder{places: map[string]Place{"Paris": {Latitude: 48.8566, Longitude: 2.3522}}}
+	orch := NewOrchestrator(parisStays()).WithGeocoder(geocoder).WithWeather(fixedForecaster{})
+
+	w, err := orch.Weather("Paris", "2026-06-12", "2026-06-15")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.City != "Paris" || len(w.Days) != 4 || w.AvgHighC != 20 || w.AvgLowC != 12 || w.PrecipitationMm != 3 || w.WetDays != 1 {
+		t.Errorf("weather = %+v, want 4 days averaged", w)
+	}
+	if _, err := orch.Weather("Paris", "2026-06-15", "2026-06-12"); err == nil {
+		t.Error("told the weather for dates out of order")
+	}
+
+	req := StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15", MaxResults: 10, Weather: true}
+	result, err := orch.SearchStays(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Weather == nil || result.Weather.AvgHighC != 20 || result.Weather.Days != nil {
+		t.Errorf("stay search weather = %+v, want the summary without days", result.Weather)
+	}
+	req.City = "Atlantis"
+	if result, err := orch.SearchStays(req); err != nil || result.Weather != nil {
+		t.Errorf("search where the weather can't be told = %v, %v; want results without weather", result, err)
+	}
+}
@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// service names the entries the CLI creates in the OS keyring.
+const service = "beetlebot-travel"
+
+var ErrNotFound = errors.New("credential not found")
+
+// Backend stores secret values by key.
+type Backend interface {
+	Name() string
+	Get(key string) (string, error)
+	Set(key, value string) error
+}
+
+// DefaultBackend picks the OS keyring when its command-line tool is
+// installed (macOS Keychain via security, Secret Service via secret-tool)
+// and a private file under dir otherwise. TRAVEL_CREDENTIALS_BACKEND=file
+// forces the file, e.g. on headless CI runners.
+func DefaultBackend(dir string) Backend {
+	file := &FileBackend{path: filepath.Join(dir, "travel-secrets.json")}
+	if os.Getenv("TRAVEL_CREDENTIALS_BACKEND") == "file" {
+		return file
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err == nil {
+			return keychain{}
+		}
+	case "linux", "freebsd", "openbsd":
+		if _, err := exec.LookPath("secret-tool"); err == nil {
+			return secretService{}
+		}
+	}
+	return file
+}
+
+// FileBackend keeps secrets in a JSON file readable only by the user.
+type FileBackend struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (f *FileBackend) Name() string { return "file" }
+
+func (f *FileBackend) Get(key string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	all, err := f.load()
+	if err != nil {
+		return "", err
+	}
+	v, ok := all[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return v, nil
+}
+
+func (f *FileBackend) Set(key, value string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	all, err := f.load()
+	if err != nil {
+		return err
+	}
+	all[key] = value
+	return f.save(all)
+}
+
+func (f *FileBackend) load() (map[string]string, error) {
+	all := map[string]string{}
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode credentials: %w", err)
+	}
+	return all, nil
+}
+
+func (f *FileBackend) save(all map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// keychain uses the macOS security tool.
+type keychain struct{}
+
+func (keychain) Name() string { return "keychain" }
+
+func (keychain) Get(key string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", key, "-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// Set runs security in interactive mode, reading the command from stdin,
+// so the value never appears in the process list. The value is given hex
+// encoded (-X), which needs no quoting.
+func (keychain) Set(key, value string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", service, key, hex.EncodeToString([]byte(value))))
+	return run(cmd)
+}
+
+// secretService uses libsecret's secret-tool, which reads the secret from
+// stdin so it never appears in the process list.
+type secretService struct{}
+
+func (secretService) Name() string { return "secret-service" }
+
+func (secretService) Get(key string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "key", key).Output()
+	if err != nil || len(out) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (secretService) Set(key, value string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", service+" "+key, "service", service, "key", key)
+	cmd.Stdin = strings.NewReader(value)
+	return run(cmd)
+}
+
+func run(cmd *exec.Cmd) error {
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
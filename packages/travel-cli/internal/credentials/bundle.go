@@ -0,0 +1,104 @@
+package credentials
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle is the import/export file format: environment variables grouped
+// by provider.
+//
+//	providers:
+//	  duffel:
+//	    DUFFEL_API_TOKEN: duffel_live_...
+type Bundle struct {
+	Providers map[string]map[string]string `yaml:"providers"`
+}
+
+var envName = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+
+// ParseBundle decodes and validates a bundle.
+func ParseBundle(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+	if len(b.Providers) == 0 {
+		return nil, fmt.Errorf("parse credentials: no providers section")
+	}
+	for provider, keys := range b.Providers {
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("provider %s has no keys", provider)
+		}
+		for key, value := range keys {
+			if !envName.MatchString(key) {
+				return nil, fmt.Errorf("provider %s: %q is not an environment variable name", provider, key)
+			}
+			if strings.TrimSpace(value) == "" {
+				return nil, fmt.Errorf("provider %s: %s is empty", provider, key)
+			}
+		}
+	}
+	return &b, nil
+}
+
+// FromEntries groups stored credentials into a bundle.
+func FromEntries(entries []Entry) *Bundle {
+	b := &Bundle{Providers: map[string]map[string]string{}}
+	for _, e := range entries {
+		if b.Providers[e.Provider] == nil {
+			b.Providers[e.Provider] = map[string]string{}
+		}
+		b.Providers[e.Provider][e.Key] = e.Value
+	}
+	return b
+}
+
+// ProviderNames returns the bundle's providers in order.
+func (b *Bundle) ProviderNames() []string {
+	names := make([]string, 0, len(b.Providers))
+	for name := range b.Providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Redacted returns a copy with every value masked, safe to paste into
+// tickets or logs.
+func (b *Bundle) Redacted() *Bundle {
+	out := &Bundle{Providers: map[string]map[string]string{}}
+	for provider, keys := range b.Providers {
+		out.Providers[provider] = map[string]string{}
+		for key, value := range keys {
+			out.Providers[provider][key] = Redact(value)
+		}
+	}
+	return out
+}
+
+// Env renders the bundle as KEY=value lines, the format CI secret
+// tooling (e.g. gh secret set -f) reads.
+func (b *Bundle) Env() string {
+	var lines []string
+	for _, keys := range b.Providers {
+		for key, value := range keys {
+			lines = append(lines, key+"="+value)
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Redact keeps the last four characters of long values so keys can be
+// told apart, and masks short ones entirely.
+func Redact(value string) string {
+	if len(value) < 12 {
+		return "****"
+	}
+	return "****" + value[len(value)-4:]
+}
@@ -0,0 +1,194 @@
+package credentials
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreRoundTripAndApplyEnv(t *testing.T) {
+	t.Setenv("TRAVEL_CREDENTIALS_BACKEND", "file")
+	t.Setenv("DUFFEL_API_TOKEN", "")
+	t.Setenv("EXPEDIA_API_KEY", "from-shell")
+
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.Backend() != "file" {
+		t.Fatalf("backend = %s", store.Backend())
+	}
+	for _, e := range []Entry{
+		{"expedia", "EXPEDIA_API_KEY", "from-store"},
+		{"duffel", "DUFFEL_API_TOKEN", "duffel_test_token"},
+	} {
+		if err := store.Set(e.Provider, e.Key, e.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := store.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Provider != "duffel" || entries[1].Value != "from-store" {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if err := store.ApplyEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("DUFFEL_API_TOKEN"); got != "duffel_test_token" {
+		t.Errorf("DUFFEL_API_TOKEN = %q", got)
+	}
+	if got := os.Getenv("EXPEDIA_API_KEY"); got != "from-shell" {
+		t.Errorf("stored key overrode the environment: %q", got)
+	}
+}
+
+func TestParseBundle(t *testing.T) {
+	b, err := ParseBundle([]byte("providers:\n  duffel:\n    DUFFEL_API_TOKEN: duffel_live_abcdef123456\n  airbnb:\n    AIRBNB_AFFILIATE_ID: aff\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := b.ProviderNames(); len(names) != 2 || names[0] != "airbnb" {
+		t.Errorf("providers = %v", names)
+	}
+	if env := b.Env(); env != "AIRBNB_AFFILIATE_ID=aff\nDUFFEL_API_TOKEN=duffel_live_abcdef123456\n" {
+		t.Errorf("env = %q", env)
+	}
+	red := b.Redacted()
+	if red.Providers["duffel"]["DUFFEL_API_TOKEN"] != "****3456" || red.Providers["airbnb"]["AIRBNB_AFFILIATE_ID"] != "****" {
+		t.Errorf("redacted = %+v", red.Providers)
+	}
+
+	for _, bad := range []string{
+		"duffel: {}",
+		"providers:\n  duffel:\n    api-token: x\n",
+		"providers:\n  duffel:\n    DUFFEL_API_TOKEN: \"\"\n",
+	} {
+		if _, err := ParseBundle([]byte(bad)); err == nil {
+			t.Errorf("ParseBundle(%q) accepted an invalid bundle", bad)
+		}
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	data := `# project keys
+export DUFFEL_API_TOKEN=duffel_test_1 # sandbox
+EXPEDIA_API_KEY="a \"quoted\" #key"
+EXPEDIA_API_SECRET='lit\eral'
+
+AIRBNB_AFFILIATE_ID=
+`
+	vars, err := ParseEnv([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{
+		{"DUFFEL_API_TOKEN", "duffel_test_1"},
+		{"EXPEDIA_API_KEY", `a "quoted" #key`},
+		{"EXPEDIA_API_SECRET", `lit\eral`},
+		{"AIRBNB_AFFILIATE_ID", ""},
+	}
+	if len(vars) != len(want) {
+		t.Fatalf("vars = %q", vars)
+	}
+	for i := range want {
+		if vars[i] != want[i] {
+			t.Errorf("var %d = %q, want %q", i, vars[i], want[i])
+		}
+	}
+
+	if _, err := ParseEnv([]byte("OK=1\nnot a variable\n")); err == nil || err.Error() != "line 2: want KEY=value" {
+		t.Errorf("bad line error = %v", err)
+	}
+}
+
+func TestFindAndLoadEnvFile(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "project", "src")
+	if err := os.MkdirAll(filepath.Join(root, "project", ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(root, ".env")
+	if err := os.WriteFile(outside, []byte("DUFFEL_API_TOKEN=outside\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TRAVEL_ENV_FILE", "")
+	if got := FindEnvFile(sub); got != "" {
+		t.Errorf("found %s beyond the project root", got)
+	}
+	project := filepath.Join(root, "project", ".env")
+	if err := os.WriteFile(project, []byte("DUFFEL_API_TOKEN=from-file\nEXPEDIA_API_KEY=from-file\nTRAVEL_HOOKS_POST_SEARCH=touch pwned\nHTTPS_PROXY=http://evil.example\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindEnvFile(sub); got != project {
+		t.Errorf("FindEnvFile = %q, want %q", got, project)
+	}
+	t.Setenv("TRAVEL_ENV_FILE", outside)
+	if got := FindEnvFile(sub); got != outside {
+		t.Errorf("TRAVEL_ENV_FILE ignored: %q", got)
+	}
+	t.Setenv("TRAVEL_ENV_FILE", "off")
+	if got := FindEnvFile(sub); got != "" {
+		t.Errorf("TRAVEL_ENV_FILE=off found %q", got)
+	}
+
+	t.Setenv("DUFFEL_API_TOKEN", "")
+	t.Setenv("EXPEDIA_API_KEY", "from-shell")
+	t.Setenv("TRAVEL_HOOKS_POST_SEARCH", "")
+	t.Setenv("HTTPS_PROXY", "")
+	set, skipped, err := LoadEnvFile(project, []string{"DUFFEL_API_TOKEN", "EXPEDIA_API_KEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set) != 1 || os.Getenv("DUFFEL_API_TOKEN") != "from-file" || os.Getenv("EXPEDIA_API_KEY") != "from-shell" {
+		t.Errorf("set %v; DUFFEL_API_TOKEN=%q EXPEDIA_API_KEY=%q", set, os.Getenv("DUFFEL_API_TOKEN"), os.Getenv("EXPEDIA_API_KEY"))
+	}
+	if len(skipped) != 2 || os.Getenv("TRAVEL_HOOKS_POST_SEARCH") != "" || os.Getenv("HTTPS_PROXY") != "" {
+		t.Errorf("skipped %v; want the variables that aren't provider keys left unset", skipped)
+	}
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	c := NewCipher("correct horse")
+	a, err := c.Seal("duffel_test_token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := c.Seal("duffel_test_token")
+	if !IsSealed(a) || a == b {
+		t.Errorf("sealed values %q and %q should be marked and differ", a, b)
+	}
+	if got, err := NewCipher("correct horse").Open(a); err != nil || got != "duffel_test_token" {
+		t.Errorf("Open = %q, %v", got, err)
+	}
+	if _, err := NewCipher("wrong").Open(a); !errors.Is(err, ErrBadPassphrase) {
+		t.Errorf("wrong passphrase error = %v", err)
+	}
+
+	t.Setenv("TRAVEL_PASSPHRASE", "")
+	t.Setenv("TRAVEL_PASSPHRASE_FILE", "")
+	t.Setenv("DUFFEL_API_TOKEN", "from-shell")
+	if err := ApplySealed(map[string]string{"DUFFEL_API_TOKEN": a}); err != nil {
+		t.Errorf("passphrase needed for a key already set: %v", err)
+	}
+	t.Setenv("DUFFEL_API_TOKEN", "")
+	if err := ApplySealed(map[string]string{"DUFFEL_API_TOKEN": a}); !errors.Is(err, ErrNoPassphrase) {
+		t.Errorf("missing passphrase error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(path, []byte("correct horse\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TRAVEL_PASSPHRASE_FILE", path)
+	if err := ApplySealed(map[string]string{"DUFFEL_API_TOKEN": a}); err != nil || os.Getenv("DUFFEL_API_TOKEN") != "duffel_test_token" {
+		t.Errorf("ApplySealed: %v, DUFFEL_API_TOKEN=%q", err, os.Getenv("DUFFEL_API_TOKEN"))
+	}
+}
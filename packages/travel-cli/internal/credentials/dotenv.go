@@ -0,0 +1,130 @@
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FindEnvFile returns the .env file to load keys from: TRAVEL_ENV_FILE
+// when set ("off" disables loading), otherwise the nearest .env in dir or
+// its parents, stopping at the project root (the first directory holding
+// .git). It returns "" when there is none.
+func FindEnvFile(dir string) string {
+	if p, ok := os.LookupEnv("TRAVEL_ENV_FILE"); ok && p != "" {
+		if p == "off" {
+			return ""
+		}
+		return p
+	}
+	for {
+		p := filepath.Join(dir, ".env")
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadEnvFile exports the variables in a .env file that name one of
+// keys and aren't already set, so per-project keys work like exported
+// ones without overriding the shell. Any other variable is skipped: a
+// .env comes with whatever repository it sits in, and variables such as
+// TRAVEL_HOOKS_POST_SEARCH or HTTPS_PROXY would let it run commands or
+// read traffic. It returns the names it set and the ones it skipped.
+func LoadEnvFile(path string, keys []string) (set, skipped []string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	vars, err := ParseEnv(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, v := range vars {
+		if !slices.Contains(keys, v[0]) {
+			skipped = append(skipped, v[0])
+			continue
+		}
+		if os.Getenv(v[0]) != "" {
+			continue
+		}
+		if err := os.Setenv(v[0], v[1]); err != nil {
+			return set, skipped, err
+		}
+		set = append(set, v[0])
+	}
+	return set, skipped, nil
+}
+
+// ParseEnv reads KEY=value lines in file order. Blank lines, # comments,
+// and a leading "export" are skipped; double-quoted values understand \n,
+// \t, \", and \\, single-quoted ones are taken literally, and unquoted
+// ones end at " #".
+func ParseEnv(data []byte) ([][2]string, error) {
+	var vars [][2]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: want KEY=value", n)
+		}
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			end := closingQuote(value)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quote", n)
+			}
+			unquoted, err := strconv.Unquote(value[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", n, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			end := strings.Index(value[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quote", n)
+			}
+			value = value[1 : end+1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		vars = append(vars, [2]string{key, value})
+	}
+	return vars, scanner.Err()
+}
+
+// closingQuote returns the index of the double quote ending s, which
+// starts with one, skipping escaped quotes.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
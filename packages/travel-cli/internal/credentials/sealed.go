@@ -0,0 +1,161 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// sealedPrefix marks a value encrypted by Cipher. The rest is base64 of
+// the PBKDF2 salt, the AES-GCM nonce, and the ciphertext.
+const sealedPrefix = "enc:v1:"
+
+const (
+	saltSize = 16
+	// kdfIterations follows OWASP's recommendation for PBKDF2-HMAC-SHA512.
+	kdfIterations = 210_000
+)
+
+var (
+	ErrNoPassphrase  = errors.New("no passphrase: set TRAVEL_PASSPHRASE or TRAVEL_PASSPHRASE_FILE")
+	ErrBadPassphrase = errors.New("wrong passphrase or damaged value")
+)
+
+// Passphrase returns the passphrase for encrypted config values, from
+// TRAVEL_PASSPHRASE or the first line of the file TRAVEL_PASSPHRASE_FILE
+// names.
+func Passphrase() (string, error) {
+	if p := os.Getenv("TRAVEL_PASSPHRASE"); p != "" {
+		return p, nil
+	}
+	if path := os.Getenv("TRAVEL_PASSPHRASE_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		line, _, _ := strings.Cut(string(data), "\n")
+		if p := strings.TrimRight(line, "\r"); p != "" {
+			return p, nil
+		}
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return "", ErrNoPassphrase
+}
+
+// IsSealed reports whether a config value was encrypted by Cipher.
+func IsSealed(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
+
+// Cipher encrypts values with AES-256-GCM under a key derived from a
+// passphrase, for keeping provider keys in the config file on machines
+// without a keyring. Deriving a key is deliberately slow, so a Cipher
+// reuses one salt for everything it encrypts and remembers the keys it
+// derived for decrypting.
+type Cipher struct {
+	passphrase string
+	salt       []byte
+	keys       map[string]cipher.AEAD
+}
+
+func NewCipher(passphrase string) *Cipher {
+	return &Cipher{passphrase: passphrase, keys: map[string]cipher.AEAD{}}
+}
+
+// Seal encrypts value, returning text safe to put in YAML.
+func (c *Cipher) Seal(value string) (string, error) {
+	if c.salt == nil {
+		c.salt = make([]byte, saltSize)
+		if _, err := rand.Read(c.salt); err != nil {
+			return "", err
+		}
+	}
+	aead, err := c.aead(c.salt)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	out := append(append(append([]byte{}, c.salt...), nonce...), aead.Seal(nil, nonce, []byte(value), nil)...)
+	return sealedPrefix + base64.RawStdEncoding.EncodeToString(out), nil
+}
+
+// Open decrypts a value produced by Seal.
+func (c *Cipher) Open(sealed string) (string, error) {
+	if !IsSealed(sealed) {
+		return "", fmt.Errorf("not an encrypted value (want the %s prefix)", sealedPrefix)
+	}
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil || len(data) < saltSize {
+		return "", ErrBadPassphrase
+	}
+	aead, err := c.aead(data[:saltSize])
+	if err != nil {
+		return "", err
+	}
+	data = data[saltSize:]
+	if len(data) < aead.NonceSize() {
+		return "", ErrBadPassphrase
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrBadPassphrase
+	}
+	return string(plain), nil
+}
+
+func (c *Cipher) aead(salt []byte) (cipher.AEAD, error) {
+	if aead, ok := c.keys[string(salt)]; ok {
+		return aead, nil
+	}
+	key, err := pbkdf2.Key(sha512.New, c.passphrase, salt, kdfIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[string(salt)] = aead
+	return aead, nil
+}
+
+// ApplySealed exports encrypted values, keyed by environment variable,
+// for variables not already set. The passphrase is only needed, and only
+// looked up, when one of them is unset.
+func ApplySealed(values map[string]string) error {
+	var c *Cipher
+	for key, sealed := range values {
+		if os.Getenv(key) != "" {
+			continue
+		}
+		if c == nil {
+			passphrase, err := Passphrase()
+			if err != nil {
+				return err
+			}
+			c = NewCipher(passphrase)
+		}
+		value, err := c.Open(sealed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
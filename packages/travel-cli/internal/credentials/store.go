@@ -0,0 +1,136 @@
+// Package credentials keeps provider API keys in the OS keyring and moves
+// them between machines as YAML bundles.
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// Entry is one stored credential: the environment variable a provider
+// reads and its value.
+type Entry struct {
+	Provider string
+	Key      string
+	Value    string
+}
+
+// Store pairs a secret Backend with an index of which keys it holds and
+// which provider each belongs to. Keyrings can't be listed reliably, and
+// the index holds no secrets.
+type Store struct {
+	backend Backend
+	index   string
+	mu      sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(base)
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create credentials dir: %w", err)
+	}
+	return &Store{backend: DefaultBackend(dir), index: filepath.Join(dir, "travel-credentials.json")}, nil
+}
+
+// Backend names where secret values are kept.
+func (s *Store) Backend() string { return s.backend.Name() }
+
+// Set stores value under key for provider.
+func (s *Store) Set(provider, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.loadIndex()
+	if err != nil {
+		return err
+	}
+	if err := s.backend.Set(key, value); err != nil {
+		return fmt.Errorf("store %s: %w", key, err)
+	}
+	idx[key] = provider
+	return s.saveIndex(idx)
+}
+
+// Entries returns every stored credential ordered by provider and key.
+// Keys whose secret has gone missing from the backend are skipped.
+func (s *Store) Entries() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, err := s.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	var out []Entry
+	for key, provider := range idx {
+		value, err := s.backend.Get(key)
+		if err != nil {
+			continue
+		}
+		out = append(out, Entry{Provider: provider, Key: key, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Provider != out[j].Provider {
+			return out[i].Provider < out[j].Provider
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, nil
+}
+
+// ApplyEnv exports stored credentials as environment variables so
+// adapters find them like any other key. Variables already set win.
+func (s *Store) ApplyEnv() error {
+	entries, err := s.Entries()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if os.Getenv(e.Key) == "" {
+			if err := os.Setenv(e.Key, e.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) loadIndex() (map[string]string, error) {
+	idx := map[string]string{}
+	data, err := os.ReadFile(s.index)
+	if os.IsNotExist(err) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("decode credentials index: %w", err)
+	}
+	return idx, nil
+}
+
+func (s *Store) saveIndex(idx map[string]string) error {
+	raw, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.index + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.index)
+}
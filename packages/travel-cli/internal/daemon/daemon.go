@@ -0,0 +1,284 @@
+// Package daemon runs saved alerts and scheduled searches in the
+// background.
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+	"github.com/beetlebot/travel-cli/internal/notify"
+	"github.com/beetlebot/travel-cli/internal/schedule"
+)
+
+// DefaultInterval is how often alerts are checked when not configured.
+const DefaultInterval = 30 * time.Minute
+
+const (
+	EventStarted       = "daemon.started"
+	EventAlertsChecked = "alerts.checked"
+	EventScheduleRan   = "schedule.ran"
+	EventDigestSent    = "alerts.digest"
+	EventError         = "daemon.error"
+)
+
+// idleWait is how long Run sleeps when no schedule has a next run.
+const idleWait = 24 * time.Hour
+
+// Event is emitted for each unit of daemon work; the CLI prints events as
+// JSON lines.
+type Event struct {
+	Time     time.Time            `json:"time"`
+	Type     string               `json:"type"`
+	Report   *alerts.Report       `json:"report,omitempty"`
+	Schedule *ScheduleRun         `json:"schedule,omitempty"`
+	Digest   *notify.Notification `json:"digest,omitempty"`
+	Error    string               `json:"error,omitempty"`
+}
+
+// ScheduleRun summarizes one run of a scheduled search.
+type ScheduleRun struct {
+	Name        string               `json:"name"`
+	Kind        string               `json:"kind"`
+	Query       interface{}          `json:"query"`
+	TotalFound  int                  `json:"totalFound"`
+	CheapestUSD float64              `json:"cheapestUSD,omitempty"`
+	Summary     *core.ResultSummary  `json:"summary,omitempty"`
+	Errors      []core.ProviderError `json:"errors,omitempty"`
+	NextRun     time.Time            `json:"nextRun,omitzero"`
+}
+
+type Daemon struct {
+	searcher alerts.Searcher
+	alerts   *alerts.Store
+	interval time.Duration
+	jobs     []*schedule.Job
+	digest   *schedule.Cron
+	history  *history.Store
+	notifier *notify.Notifier
+	emit     func(Event)
+}
+
+func New(searcher alerts.Searcher, store *alerts.Store, interval time.Duration) *Daemon {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Daemon{searcher: searcher, alerts: store, interval: interval, emit: func(Event) {}}
+}
+
+// OnEvent sets the function that receives daemon events.
+func (d *Daemon) OnEvent(fn func(Event)) *Daemon {
+	d.emit = fn
+	return d
+}
+
+// WithSchedules adds cron-scheduled searches to the daemon's work.
+func (d *Daemon) WithSchedules(jobs []*schedule.Job) *Daemon {
+	d.jobs = jobs
+	return d
+}
+
+// WithNotifier announces newly triggered alerts and scheduled-search
+// summaries through n.
+func (d *Daemon) WithNotifier(n *notify.Notifier) *Daemon {
+	d.notifier = n
+	return d
+}
+
+// WithDigest sends a summary of tracked alerts through the notifier
+// whenever c matches. Price changes are read from h, over the time
+// between two runs of c; h may be nil.
+func (d *Daemon) WithDigest(c *schedule.Cron, h *history.Store) *Daemon {
+	d.digest, d.history = c, h
+	return d
+}
+
+// Run checks alerts immediately and then every interval, and runs each
+// scheduled search whenever its cron expression matches, until ctx is done.
+func (d *Daemon) Run(ctx context.Context) error {
+	d.emit(Event{Time: time.Now().UTC(), Type: EventStarted})
+	d.Tick(ctx)
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	now := time.Now()
+	next := make([]time.Time, len(d.jobs))
+	for i, j := range d.jobs {
+		next[i] = j.Next(now)
+	}
+	var digestAt time.Time
+	if d.digest != nil {
+		digestAt = d.digest.Next(now)
+	}
+	wait := func(now time.Time) time.Duration {
+		return min(untilNext(next, now), untilNext([]time.Time{digestAt}, now))
+	}
+	timer := time.NewTimer(wait(now))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.Tick(ctx)
+		case <-timer.C:
+			now := time.Now()
+			for i, j := range d.jobs {
+				if !next[i].IsZero() && !now.Before(next[i]) {
+					next[i] = j.Next(now)
+					d.RunSchedule(ctx, j, next[i])
+				}
+			}
+			if !digestAt.IsZero() && !now.Before(digestAt) {
+				digestAt = d.digest.Next(now)
+				d.SendDigest(ctx)
+			}
+			timer.Reset(wait(now))
+		}
+	}
+}
+
+// RunOnce runs a single round of all work: every alert and every
+// scheduled search, regardless of its cron expression.
+func (d *Daemon) RunOnce(ctx context.Context) {
+	d.Tick(ctx)
+	now := time.Now()
+	for _, j := range d.jobs {
+		d.RunSchedule(ctx, j, j.Next(now))
+	}
+}
+
+// RunSchedule runs one scheduled search and emits its summary. nextRun is
+// reported in the event so consumers can tell when it will run again.
+func (d *Daemon) RunSchedule(ctx context.Context, j *schedule.Job, nextRun time.Time) {
+	if ctx.Err() != nil {
+		return
+	}
+	var (
+		result *core.SearchResult
+		err    error
+	)
+	switch {
+	case j.Flight != nil:
+		result, err = d.searcher.SearchFlights(*j.Flight)
+	case j.Stay != nil:
+		result, err = d.searcher.SearchStays(*j.Stay)
+	}
+	if err != nil {
+		d.fail(fmt.Errorf("schedule %s: %w", j.Name, err))
+		return
+	}
+	if result == nil {
+		return
+	}
+
+	run := &ScheduleRun{
+		Name:       j.Name,
+		Kind:       j.Kind,
+		Query:      result.Query,
+		TotalFound: result.TotalFound,
+		Summary:    result.Summary,
+		Errors:     result.Errors,
+		NextRun:    nextRun,
+	}
+	if result.Summary != nil {
+		run.CheapestUSD = result.Summary.MinPriceUSD
+	}
+	d.emit(Event{Time: time.Now().UTC(), Type: EventScheduleRan, Schedule: run})
+	if err := d.notifier.Send(ctx, scheduleNotification(run)); err != nil {
+		d.fail(err)
+	}
+}
+
+// SendDigest sends the alerts digest built from each alert's latest check.
+// The period it covers is as long as the wait until the next digest, so a
+// daily digest reports the last day's price changes. Nothing is sent when
+// there are no alerts.
+func (d *Daemon) SendDigest(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+	now := time.Now().UTC()
+	var since time.Time
+	if d.digest != nil {
+		since = now.Add(-d.digest.Next(now).Sub(now))
+	}
+	all, err := d.alerts.List()
+	if err != nil {
+		d.fail(err)
+		return
+	}
+	if len(all) == 0 {
+		return
+	}
+	var entries []history.Entry
+	if d.history != nil {
+		if entries, err = d.history.List(0); err != nil {
+			d.fail(err)
+		}
+	}
+	n := notify.Digest(all, entries, since, now)
+	if err := d.notifier.Send(ctx, n); err != nil {
+		d.fail(err)
+	}
+	d.emit(Event{Time: n.Time, Type: EventDigestSent, Digest: &n})
+}
+
+func scheduleNotification(run *ScheduleRun) notify.Notification {
+	body := fmt.Sprintf("%d offers", run.TotalFound)
+	if run.CheapestUSD > 0 {
+		body += fmt.Sprintf(", cheapest $%.0f", run.CheapestUSD)
+	}
+	return notify.Notification{
+		Event: notify.EventScheduleRan,
+		Time:  time.Now().UTC(),
+		Title: "Scheduled search " + run.Name,
+		Body:  body,
+		Data:  run,
+	}
+}
+
+func untilNext(next []time.Time, now time.Time) time.Duration {
+	wait := idleWait
+	for _, t := range next {
+		if !t.IsZero() && t.Sub(now) < wait {
+			wait = t.Sub(now)
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// Tick runs one round of work: every saved alert is re-checked, the
+// alerts store updated with the observed prices, and new triggers sent to
+// the notifier.
+func (d *Daemon) Tick(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+	all, err := d.alerts.List()
+	if err != nil {
+		d.fail(err)
+		return
+	}
+	if len(all) == 0 {
+		return
+	}
+	report, checked := alerts.Check(d.searcher, all)
+	if err := d.alerts.Update(checked); err != nil {
+		d.fail(err)
+	}
+	d.notifier.Triggers(ctx, report)
+	d.emit(Event{Time: time.Now().UTC(), Type: EventAlertsChecked, Report: report})
+}
+
+func (d *Daemon) fail(err error) {
+	d.emit(Event{Time: time.Now().UTC(), Type: EventError, Error: err.Error()})
+}
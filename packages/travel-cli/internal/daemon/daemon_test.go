@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/notify"
+	"github.com/beetlebot/travel-cli/internal/schedule"
+)
+
+type countingSearcher struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *countingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	return &core.SearchResult{Query: req, Flights: []core.FlightOffer{{ID: "f_1", PriceUSD: 500}}}, nil
+}
+
+func (s *countingSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{Query: req}, nil
+}
+
+func (s *countingSearcher) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestRunChecksAlertsEveryInterval(t *testing.T) {
+	store, err := alerts.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &alerts.Alert{Kind: alerts.KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 600}
+	if err := store.Add(a); err != nil {
+		t.Fatal(err)
+	}
+
+	searcher := &countingSearcher{}
+	var mu sync.Mutex
+	var events []Event
+	d := New(searcher, store, 20*time.Millisecond).OnEvent(func(e Event) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 70*time.Millisecond)
+	defer cancel()
+	if err := d.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if searcher.count() < 2 {
+		t.Errorf("alerts checked %d times, want at least 2", searcher.count())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if events[0].Type != EventStarted || events[1].Type != EventAlertsChecked || len(events[1].Report.Triggered) != 1 {
+		t.Fatalf("events = %+v", events)
+	}
+	stored, _ := store.List()
+	if !stored[0].Triggered || stored[0].LastPriceUSD != 500 {
+		t.Errorf("alert state not persisted: %+v", stored[0])
+	}
+}
+
+type sinkFunc func(notify.Notification)
+
+func (f sinkFunc) Name() string { return "func" }
+
+func (f sinkFunc) Send(ctx context.Context, n notify.Notification) error {
+	f(n)
+	return nil
+}
+
+func TestTickNotifiesOnlyNewTriggers(t *testing.T) {
+	store, err := alerts.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &alerts.Alert{Kind: alerts.KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 600}
+	if err := store.Add(a); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []notify.Notification
+	d := New(&countingSearcher{}, store, time.Hour).
+		WithNotifier(notify.New(sinkFunc(func(n notify.Notification) { sent = append(sent, n) })))
+	d.Tick(context.Background())
+	d.Tick(context.Background())
+
+	if len(sent) != 1 || sent[0].Event != notify.EventAlertTriggered {
+		t.Fatalf("sent = %+v, want one alert notification", sent)
+	}
+}
+
+func TestRunOnceRunsEverySchedule(t *testing.T) {
+	store, err := alerts.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := schedule.FromConfig(config.ScheduleConfig{Name: "june", Cron: "0 0 1 1 *", Kind: schedule.KindFlights, From: "YUL", To: "CDG", Depart: "2026-06-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	searcher := &countingSearcher{}
+	var events []Event
+	New(searcher, store, time.Hour).
+		WithSchedules([]*schedule.Job{job}).
+		OnEvent(func(e Event) { events = append(events, e) }).
+		RunOnce(context.Background())
+
+	if searcher.count() != 1 || len(events) != 1 || events[0].Type != EventScheduleRan {
+		t.Fatalf("calls = %d, events = %+v", searcher.count(), events)
+	}
+	run := events[0].Schedule
+	if run.Name != "june" || run.NextRun.Month() != time.January || run.NextRun.Day() != 1 {
+		t.Errorf("schedule run = %+v", run)
+	}
+}
+
+func TestSendDigest(t *testing.T) {
+	store, err := alerts.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &alerts.Alert{Kind: alerts.KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 600}
+	if err := store.Add(a); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent []notify.Notification
+	d := New(&countingSearcher{}, store, time.Hour).
+		WithNotifier(notify.New(sinkFunc(func(n notify.Notification) { sent = append(sent, n) })))
+	d.Tick(context.Background())
+	d.SendDigest(context.Background())
+
+	if len(sent) != 2 || sent[1].Event != notify.EventDigest || len(sent[1].Items) != 1 {
+		t.Fatalf("sent = %+v, want an alert and a digest with one deal", sent)
+	}
+}
@@ -0,0 +1,122 @@
+// Package destinations serves practical city metadata (currency, plugs,
+// tipping, budgets, neighborhoods) from a dataset embedded in the binary,
+// so it works offline and in every provider mode.
+package destinations
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:embed destinations.json
+var rawDataset []byte
+
+// DailyBudget is a rough per-person daily spend in USD covering lodging,
+// food, and local transport at three comfort levels.
+type DailyBudget struct {
+	Budget   int `json:"budget"`
+	MidRange int `json:"midRange"`
+	Luxury   int `json:"luxury"`
+}
+
+type Neighborhood struct {
+	Name string `json:"name"`
+	Vibe string `json:"vibe"`
+}
+
+type Destination struct {
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	CountryCode string   `json:"countryCode"`
+	Aliases     []string `json:"aliases,omitempty"`
+	// Airports are the city's IATA airport codes, main airport first.
+	Airports       []string       `json:"airports,omitempty"`
+	Currency       string         `json:"currency"`
+	PlugTypes      []string       `json:"plugTypes"`
+	Voltage        int            `json:"voltage"`
+	Languages      []string       `json:"languages"`
+	Timezone       string         `json:"timezone"`
+	Tipping        string         `json:"tipping"`
+	DailyBudgetUSD DailyBudget    `json:"dailyBudgetUSD"`
+	Neighborhoods  []Neighborhood `json:"neighborhoods"`
+}
+
+var ErrNotFound = errors.New("destination not found")
+
+var (
+	loadOnce  sync.Once
+	all       []Destination
+	byKey     map[string]*Destination
+	byAirport map[string]*Destination
+	loadErr   error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &all); err != nil {
+			loadErr = fmt.Errorf("decode embedded destinations: %w", err)
+			return
+		}
+		byKey = make(map[string]*Destination, len(all)*2)
+		byAirport = make(map[string]*Destination, len(all)*2)
+		for i := range all {
+			d := &all[i]
+			byKey[key(d.Name)] = d
+			for _, a := range d.Aliases {
+				byKey[key(a)] = d
+			}
+			for _, code := range d.Airports {
+				byAirport[code] = d
+			}
+		}
+	})
+	return loadErr
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Lookup finds a destination by city name or alias, ignoring case and
+// extra whitespace.
+func Lookup(city string) (*Destination, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	d, ok := byKey[key(city)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, city)
+	}
+	return d, nil
+}
+
+// ByAirport finds the destination an IATA airport code serves, ignoring
+// case.
+func ByAirport(code string) (*Destination, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	d, ok := byAirport[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return nil, fmt.Errorf("%w: airport %q", ErrNotFound, code)
+	}
+	return d, nil
+}
+
+// Names lists the cities in the dataset, sorted.
+func Names() ([]string, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(all))
+	for i, d := range all {
+		names[i] = d.Name
+	}
+	sort.Strings(names)
+	return names, nil
+}
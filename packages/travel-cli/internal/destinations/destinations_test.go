@@ -0,0 +1,53 @@
+package destinations
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupByNameAndAlias(t *testing.T) {
+	for _, city := range []string{"Lisbon", "  lisbon ", "LISBOA"} {
+		d, err := Lookup(city)
+		if err != nil {
+			t.Fatalf("Lookup(%q): %v", city, err)
+		}
+		if d.Name != "Lisbon" || d.Currency != "EUR" {
+			t.Errorf("Lookup(%q) = %s/%s, want Lisbon/EUR", city, d.Name, d.Currency)
+		}
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	if _, err := Lookup("Atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestByAirport(t *testing.T) {
+	for code, want := range map[string]string{"LIS": "Lisbon", "ory": "Paris", "YUL": "Montreal"} {
+		d, err := ByAirport(code)
+		if err != nil || d.Name != want {
+			t.Errorf("ByAirport(%q) = %v, %v; want %s", code, d, err, want)
+		}
+	}
+	if _, err := ByAirport("XXX"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDatasetComplete(t *testing.T) {
+	names, err := Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range names {
+		d, _ := Lookup(n)
+		if d.Currency == "" || len(d.PlugTypes) == 0 || d.Tipping == "" || len(d.Neighborhoods) == 0 || len(d.Airports) == 0 {
+			t.Errorf("%s is missing required fields", n)
+		}
+		b := d.DailyBudgetUSD
+		if !(b.Budget > 0 && b.Budget < b.MidRange && b.MidRange < b.Luxury) {
+			t.Errorf("%s daily budget tiers out of order: %+v", n, b)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package filelock serializes read-modify-write updates of a local file
+// across processes, such as the CLI, the daemon, and travel serve sharing
+// one rate limit or usage file. The lock is a file created beside it,
+// which works alike on every platform.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// staleAfter is how old a lock file must be before it's taken to be
+	// left over from a process that died while holding it. Updates hold
+	// their lock for milliseconds.
+	staleAfter = 10 * time.Second
+	// wait bounds how long Lock keeps trying.
+	wait = 5 * time.Second
+	// poll is how often Lock tries again.
+	poll = 5 * time.Millisecond
+)
+
+// Lock takes the lock on path, waiting for any other process holding it,
+// and returns the function that releases it.
+func Lock(path string) (unlock func(), err error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(wait)
+	for {
+		f, err := os.OpenFile(lock, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(lock) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock %s: %w", path, err)
+		}
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > staleAfter {
+			_ = os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lock %s: held by another process for over %s", path, wait)
+		}
+		time.Sleep(poll)
+	}
+}
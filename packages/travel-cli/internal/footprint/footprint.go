@@ -0,0 +1,186 @@
+// Package footprint estimates greenhouse-gas emissions for trip segments.
+//
+// Factors are kg CO2e per passenger-km (per vehicle-km for cars), in line
+// with the UK DESNZ conversion factors including radiative forcing for
+// aviation. They are estimates meant for comparing alternatives, not
+// certified reporting.
+package footprint
+
+import (
+	"math"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+const (
+	flightDomesticFactor  = 0.246 // < 1000 km
+	flightShortHaulFactor = 0.154 // < 3700 km
+	flightLongHaulFactor  = 0.148
+	railFactor            = 0.035
+	carFactor             = 0.170 // per vehicle-km, shared across passengers
+
+	// shortHaulKm is the distance under which rail and car alternatives
+	// are suggested for a flight.
+	shortHaulKm = 1000.0
+	// groundDetour accounts for rail and roads not following the great
+	// circle route a flight distance is based on.
+	groundDetour = 1.15
+	// cruiseKmh and taxiHours estimate flight distance from block time
+	// when no distance is known.
+	cruiseKmh = 780.0
+	taxiHours = 0.5
+)
+
+var cabinMultiplier = map[string]float64{
+	"":                1.0,
+	"economy":         1.0,
+	"premium_economy": 1.6,
+	"business":        2.9,
+	"first":           4.0,
+}
+
+// Report is the emissions breakdown of a whole trip.
+type Report struct {
+	Trip               string             `json:"trip"`
+	TotalKgCO2e        float64            `json:"totalKgCO2e"`
+	ByKind             map[string]float64 `json:"byKind"`
+	PotentialSavingsKg float64            `json:"potentialSavingsKg,omitempty"`
+	Segments           []SegmentReport    `json:"segments"`
+	Methodology        string             `json:"methodology"`
+}
+
+type SegmentReport struct {
+	Index        int           `json:"index"`
+	Kind         string        `json:"kind"`
+	From         string        `json:"from,omitempty"`
+	To           string        `json:"to,omitempty"`
+	DistanceKm   float64       `json:"distanceKm,omitempty"`
+	Estimated    bool          `json:"distanceEstimated,omitempty"`
+	Passengers   int           `json:"passengers"`
+	KgCO2e       float64       `json:"kgCO2e"`
+	Alternatives []Alternative `json:"alternatives,omitempty"`
+	Note         string        `json:"note,omitempty"`
+}
+
+// Alternative compares a segment with the same journey by another mode.
+type Alternative struct {
+	Kind       string  `json:"kind"`
+	KgCO2e     float64 `json:"kgCO2e"`
+	SavingsKg  float64 `json:"savingsKg"`
+	SavingsPct float64 `json:"savingsPct"`
+}
+
+// ForTrip computes per-segment emissions and suggests lower-carbon
+// alternatives for short-haul flights. Stay segments are listed with zero
+// transport emissions so the report mirrors the itinerary.
+func ForTrip(t *trips.Trip) Report {
+	r := Report{
+		Trip:        t.Name,
+		ByKind:      map[string]float64{},
+		Segments:    []SegmentReport{},
+		Methodology: "DESNZ-style factors per passenger-km; flights include radiative forcing and cabin-class multipliers",
+	}
+
+	for i, seg := range t.Segments {
+		sr := SegmentReport{Index: i, Kind: string(seg.Kind), From: seg.From, To: seg.To, Passengers: passengers(seg)}
+
+		if seg.Kind == trips.KindStay {
+			sr.Note = "accommodation is not included in transport emissions"
+			r.Segments = append(r.Segments, sr)
+			continue
+		}
+
+		km, estimated := distance(seg)
+		if km <= 0 {
+			sr.Note = "distance unknown; add the segment with --distance-km"
+			r.Segments = append(r.Segments, sr)
+			continue
+		}
+		sr.DistanceKm = round1(km)
+		sr.Estimated = estimated
+		sr.KgCO2e = round1(Segment(seg.Kind, seg.CabinClass, km, sr.Passengers))
+
+		if seg.Kind == trips.KindFlight && km < shortHaulKm {
+			for _, kind := range []trips.SegmentKind{trips.KindRail, trips.KindCar} {
+				alt := round1(Segment(kind, "", km*groundDetour, sr.Passengers))
+				if alt >= sr.KgCO2e {
+					continue
+				}
+				savings := round1(sr.KgCO2e - alt)
+				sr.Alternatives = append(sr.Alternatives, Alternative{
+					Kind:       string(kind),
+					KgCO2e:     alt,
+					SavingsKg:  savings,
+					SavingsPct: round1(savings / sr.KgCO2e * 100),
+				})
+			}
+			if len(sr.Alternatives) > 0 {
+				r.PotentialSavingsKg += sr.Alternatives[0].SavingsKg
+			}
+		}
+
+		r.TotalKgCO2e += sr.KgCO2e
+		r.ByKind[sr.Kind] += sr.KgCO2e
+		r.Segments = append(r.Segments, sr)
+	}
+
+	r.TotalKgCO2e = round1(r.TotalKgCO2e)
+	r.PotentialSavingsKg = round1(r.PotentialSavingsKg)
+	for k, v := range r.ByKind {
+		r.ByKind[k] = round1(v)
+	}
+	return r
+}
+
+// Segment returns kg CO2e for all passengers travelling km by kind.
+func Segment(kind trips.SegmentKind, cabin string, km float64, passengers int) float64 {
+	if passengers < 1 {
+		passengers = 1
+	}
+	switch kind {
+	case trips.KindFlight:
+		factor := flightLongHaulFactor
+		switch {
+		case km < shortHaulKm:
+			factor = flightDomesticFactor
+		case km < 3700:
+			factor = flightShortHaulFactor
+		}
+		mult, ok := cabinMultiplier[strings.ToLower(cabin)]
+		if !ok {
+			mult = 1.0
+		}
+		return km * factor * mult * float64(passengers)
+	case trips.KindRail:
+		return km * railFactor * float64(passengers)
+	case trips.KindCar:
+		// One car carries the whole party.
+		return km * carFactor
+	}
+	return 0
+}
+
+func distance(seg trips.Segment) (float64, bool) {
+	if seg.DistanceKm > 0 {
+		return seg.DistanceKm, false
+	}
+	if seg.Kind == trips.KindFlight && !seg.DepartTime.IsZero() && seg.ArriveTime.After(seg.DepartTime) {
+		hours := seg.ArriveTime.Sub(seg.DepartTime).Hours() - taxiHours
+		if hours > 0 {
+			return hours * cruiseKmh, true
+		}
+	}
+	return 0, false
+}
+
+func passengers(seg trips.Segment) int {
+	if seg.Passengers < 1 {
+		return 1
+	}
+	return seg.Passengers
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
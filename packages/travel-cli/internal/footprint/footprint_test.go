@@ -0,0 +1,54 @@
+package footprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+func TestForTrip_ShortHaulSuggestsRail(t *testing.T) {
+	trip := &trips.Trip{Name: "paris-lyon", Segments: []trips.Segment{
+		{Kind: trips.KindFlight, From: "CDG", To: "LYS", DistanceKm: 400},
+	}}
+
+	r := ForTrip(trip)
+	if r.TotalKgCO2e != 98.4 {
+		t.Errorf("expected 98.4 kg for a 400km flight, got %.1f", r.TotalKgCO2e)
+	}
+	seg := r.Segments[0]
+	if len(seg.Alternatives) == 0 || seg.Alternatives[0].Kind != "rail" {
+		t.Fatalf("expected a rail alternative, got %+v", seg.Alternatives)
+	}
+	if seg.Alternatives[0].SavingsKg <= 0 {
+		t.Errorf("expected rail to save emissions, got %+v", seg.Alternatives[0])
+	}
+	if r.PotentialSavingsKg != seg.Alternatives[0].SavingsKg {
+		t.Errorf("expected potential savings to match rail savings, got %.1f", r.PotentialSavingsKg)
+	}
+}
+
+func TestForTrip_LongHaulAndMixedSegments(t *testing.T) {
+	depart := time.Date(2026, 6, 12, 8, 0, 0, 0, time.UTC)
+	trip := &trips.Trip{Name: "yul-cdg", Segments: []trips.Segment{
+		{Kind: trips.KindFlight, From: "YUL", To: "CDG", DepartTime: depart, ArriveTime: depart.Add(7 * time.Hour), CabinClass: "business"},
+		{Kind: trips.KindStay, Name: "Hotel"},
+		{Kind: trips.KindRail, From: "Paris", To: "Lyon", DistanceKm: 460, Passengers: 2},
+		{Kind: trips.KindCar, From: "Lyon", To: "Annecy"},
+	}}
+
+	r := ForTrip(trip)
+	flight := r.Segments[0]
+	if !flight.Estimated || flight.DistanceKm != 5070 {
+		t.Errorf("expected distance estimated from block time, got %+v", flight)
+	}
+	if len(flight.Alternatives) != 0 {
+		t.Error("long-haul flights should not suggest ground alternatives")
+	}
+	if r.ByKind["rail"] != 32.2 {
+		t.Errorf("expected rail emissions for 2 passengers, got %.1f", r.ByKind["rail"])
+	}
+	if r.Segments[3].Note == "" || r.Segments[3].KgCO2e != 0 {
+		t.Errorf("expected car without distance to be flagged, got %+v", r.Segments[3])
+	}
+}
@@ -0,0 +1,180 @@
+// Package fx converts prices between US dollars and other currencies.
+// Fetched rates are kept with each price observation so history is shown
+// at the rate of the day it was recorded, not today's.
+package fx
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+const USD = "USD"
+
+const (
+	SourceStatic      = "static"
+	SourceFrankfurter = "frankfurter"
+)
+
+// DefaultFrankfurterURL is the public ECB reference-rate API.
+const DefaultFrankfurterURL = "https://api.frankfurter.app"
+
+var ErrUnknownCurrency = errors.New("no exchange rate for currency")
+
+//go:embed rates.json
+var fallbackJSON []byte
+
+// Rates are units of each currency per one US dollar, as of Date.
+// Source is where they came from: SourceStatic for the bundled table, or
+// the API they were fetched from.
+type Rates struct {
+	Base   string             `json:"base"`
+	Date   string             `json:"date"`
+	Rates  map[string]float64 `json:"rates"`
+	Source string             `json:"source,omitempty"`
+}
+
+// Live reports whether r was fetched rather than read from the bundled
+// table, and so is worth recording as the rate of its day.
+func (r Rates) Live() bool {
+	return r.Source != "" && r.Source != SourceStatic
+}
+
+// Rate returns units of currency per US dollar.
+func (r Rates) Rate(currency string) (float64, bool) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == USD {
+		return 1, true
+	}
+	rate, ok := r.Rates[currency]
+	return rate, ok && rate > 0
+}
+
+// FromUSD converts a USD amount into currency.
+func (r Rates) FromUSD(amount float64, currency string) (float64, error) {
+	rate, ok := r.Rate(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, strings.ToUpper(currency))
+	}
+	return amount * rate, nil
+}
+
+// ToUSD converts an amount in currency into US dollars.
+func (r Rates) ToUSD(amount float64, currency string) (float64, error) {
+	rate, ok := r.Rate(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCurrency, strings.ToUpper(currency))
+	}
+	return amount / rate, nil
+}
+
+// Convert converts an amount from one currency into another through the
+// dollar.
+func (r Rates) Convert(amount float64, from, to string) (float64, error) {
+	usd, err := r.ToUSD(amount, from)
+	if err != nil {
+		return 0, err
+	}
+	return r.FromUSD(usd, to)
+}
+
+// Fallback returns the rates bundled with the CLI.
+func Fallback() Rates {
+	r := Rates{Source: SourceStatic}
+	_ = json.Unmarshal(fallbackJSON, &r)
+	return r
+}
+
+// Current returns today's rates from the configured source, reusing
+// rates fetched within ttl (cache.ttl.fx). Fetch failures fall back to
+// the bundled rates, and rates set in the config file override either.
+func Current(cfg config.FXConfig, ttl time.Duration) Rates {
+	r := Fallback()
+	if cfg.Source == SourceFrankfurter {
+		url := cfg.URL
+		if url == "" {
+			url = DefaultFrankfurterURL
+		}
+		if fetched, err := cachedFetch(url, ttl); err == nil {
+			r = fetched
+			r.Source = SourceFrankfurter
+		}
+	}
+	if len(cfg.Rates) > 0 {
+		rates := maps.Clone(r.Rates)
+		for cur, rate := range cfg.Rates {
+			rates[strings.ToUpper(cur)] = rate
+		}
+		r.Rates = rates
+		r.Date = time.Now().UTC().Format("2006-01-02")
+	}
+	return r
+}
+
+// Fetch downloads the latest USD rates from a Frankfurter-compatible API.
+func Fetch(ctx context.Context, baseURL string) (Rates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/latest?from=USD", nil)
+	if err != nil {
+		return Rates{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Rates{}, fmt.Errorf("fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Rates{}, fmt.Errorf("fetch rates: %s", resp.Status)
+	}
+	var r Rates
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return Rates{}, fmt.Errorf("decode rates: %w", err)
+	}
+	if r.Base != USD || len(r.Rates) == 0 {
+		return Rates{}, fmt.Errorf("fetch rates: unexpected response for base %q", r.Base)
+	}
+	return r, nil
+}
+
+type cacheFile struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Rates     Rates     `json:"rates"`
+}
+
+// cachedFetch reuses rates fetched in the last ttl so only the first
+// search of the day waits on the network.
+func cachedFetch(baseURL string, ttl time.Duration) (Rates, error) {
+	base, err := paths.CacheDir()
+	if err != nil {
+		return Rates{}, err
+	}
+	path := filepath.Join(base, "travel-fx.json")
+	if data, err := os.ReadFile(path); err == nil {
+		var c cacheFile
+		if json.Unmarshal(data, &c) == nil && time.Since(c.FetchedAt) < ttl {
+			return c.Rates, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	r, err := Fetch(ctx, baseURL)
+	if err != nil {
+		return Rates{}, err
+	}
+	if raw, err := json.Marshal(cacheFile{FetchedAt: time.Now().UTC(), Rates: r}); err == nil {
+		_ = os.MkdirAll(filepath.Dir(path), 0o755)
+		_ = os.WriteFile(path, raw, 0o644)
+	}
+	return r, nil
+}
@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+)
+
+func TestFallbackRates(t *testing.T) {
+	r := Fallback()
+	if r.Base != USD || r.Date == "" {
+		t.Fatalf("fallback = %+v", r)
+	}
+	if rate, ok := r.Rate("usd"); !ok || rate != 1 {
+		t.Errorf("USD rate = %v, %v", rate, ok)
+	}
+	if _, err := r.FromUSD(100, "XYZ"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("FromUSD(XYZ) err = %v", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	r := Rates{Base: USD, Rates: map[string]float64{"EUR": 0.8, "JPY": 160}}
+	if got, err := r.ToUSD(80, "eur"); err != nil || got != 100 {
+		t.Errorf("ToUSD(80, EUR) = %v, %v", got, err)
+	}
+	if got, err := r.Convert(80, "EUR", "JPY"); err != nil || got != 16000 {
+		t.Errorf("Convert(80, EUR, JPY) = %v, %v", got, err)
+	}
+	if got, err := r.Convert(42, "USD", "USD"); err != nil || got != 42 {
+		t.Errorf("Convert(42, USD, USD) = %v, %v", got, err)
+	}
+	if _, err := r.Convert(1, "XYZ", "USD"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("Convert(XYZ) err = %v", err)
+	}
+}
+
+func TestCurrentAppliesConfigOverrides(t *testing.T) {
+	r := Current(config.FXConfig{Rates: map[string]float64{"eur": 0.5}}, config.DefaultCacheTTL[config.CacheFX])
+	got, err := r.FromUSD(100, "EUR")
+	if err != nil || got != 50 {
+		t.Errorf("FromUSD(100, EUR) = %v, %v", got, err)
+	}
+	if Fallback().Rates["EUR"] == 0.5 {
+		t.Error("override leaked into the bundled rates")
+	}
+	if r.Live() {
+		t.Error("overridden bundled rates reported as live")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest" || r.URL.Query().Get("from") != "USD" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"amount":1.0,"base":"USD","date":"2026-10-16","rates":{"EUR":0.91}}`))
+	}))
+	defer srv.Close()
+
+	r, err := Fetch(context.Background(), srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Date != "2026-10-16" || r.Rates["EUR"] != 0.91 {
+		t.Errorf("rates = %+v", r)
+	}
+}
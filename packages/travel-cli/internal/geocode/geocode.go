@@ -0,0 +1,180 @@
+// Package geocode finds landmarks, street addresses, and neighborhoods
+// with a Nominatim (OpenStreetMap) server, keeping answers on disk since
+// places rarely move.
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+)
+
+// DefaultNominatimURL is OpenStreetMap's public Nominatim server.
+const DefaultNominatimURL = "https://nominatim.openstreetmap.org"
+
+// minInterval is the spacing Nominatim's usage policy asks between
+// requests from one application.
+const minInterval = time.Second
+
+var ErrNotFound = errors.New("place not found")
+
+// Nominatim geocodes with a Nominatim server's search API, one request a
+// second at most.
+type Nominatim struct {
+	URL    string
+	client *http.Client
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+func NewNominatim(baseURL string) *Nominatim {
+	if baseURL == "" {
+		baseURL = DefaultNominatimURL
+	}
+	return &Nominatim{URL: baseURL, client: httpclient.New()}
+}
+
+// Geocode returns the best match for query, with its bounding box.
+func (n *Nominatim) Geocode(ctx context.Context, query string) (core.Place, error) {
+	if err := n.wait(ctx); err != nil {
+		return core.Place{}, err
+	}
+	u := strings.TrimRight(n.URL, "/") + "/search?" + url.Values{"q": {query}, "format": {"jsonv2"}, "limit": {"1"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return core.Place{}, err
+	}
+	// Nominatim refuses requests that don't identify the application.
+	req.Header.Set("User-Agent", "beetlebot-travel")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return core.Place{}, fmt.Errorf("geocode %q: %w", query, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return core.Place{}, fmt.Errorf("geocode %q: %s", query, resp.Status)
+	}
+	// Nominatim writes coordinates as strings, and the bounding box as
+	// south, north, west, east.
+	var places []struct {
+		Lat         string    `json:"lat"`
+		Lon         string    `json:"lon"`
+		BoundingBox [4]string `json:"boundingbox"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&places); err != nil {
+		return core.Place{}, fmt.Errorf("decode geocode response: %w", err)
+	}
+	if len(places) == 0 {
+		return core.Place{}, fmt.Errorf("%w: %q", ErrNotFound, query)
+	}
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return core.Place{}, fmt.Errorf("decode geocode response: %w", err)
+	}
+	lon, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return core.Place{}, fmt.Errorf("decode geocode response: %w", err)
+	}
+	return core.Place{Latitude: lat, Longitude: lon, Bounds: bounds(places[0].BoundingBox)}, nil
+}
+
+// bounds reads a Nominatim bounding box, or returns nil for a malformed
+// one.
+func bounds(box [4]string) *core.Bounds {
+	var v [4]float64
+	for i, s := range box {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil
+		}
+		v[i] = f
+	}
+	b := core.Bounds{South: v[0], North: v[1], West: v[2], East: v[3]}
+	if b.South > b.North || b.West > b.East {
+		return nil
+	}
+	return &b
+}
+
+// wait holds a request until minInterval has passed since the last one.
+func (n *Nominatim) wait(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if d := minInterval - time.Since(n.last); d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	n.last = time.Now()
+	return nil
+}
+
+// Cache answers queries written as "latitude,longitude" itself, and others
+// from the places next looked up while they are fresh in store, asking
+// next only for the rest.
+type Cache struct {
+	next  core.Geocoder
+	store core.ResultCache
+}
+
+// NewCache keeps next's answers in store as config.CacheGeocode. Without
+// a store, only coordinates are answered without asking next.
+func NewCache(next core.Geocoder, store core.ResultCache) *Cache {
+	return &Cache{next: next, store: store}
+}
+
+func (c *Cache) Geocode(ctx context.Context, query string) (core.Place, error) {
+	if p, ok := coordinates(query); ok {
+		return p, nil
+	}
+	if c.store == nil {
+		return c.next.Geocode(ctx, query)
+	}
+	key := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if data, ok := c.store.Get(config.CacheGeocode, key); ok {
+		var p core.Place
+		if json.Unmarshal(data, &p) == nil {
+			return p, nil
+		}
+	}
+
+	p, err := c.next.Geocode(ctx, query)
+	if err != nil {
+		return core.Place{}, err
+	}
+	if raw, err := json.Marshal(p); err == nil {
+		_ = c.store.Set(config.CacheGeocode, key, raw)
+	}
+	return p, nil
+}
+
+// coordinates reads s as "latitude,longitude".
+func coordinates(s string) (core.Place, bool) {
+	latText, lonText, ok := strings.Cut(s, ",")
+	if !ok {
+		return core.Place{}, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return core.Place{}, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonText), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return core.Place{}, false
+	}
+	return core.Place{Latitude: lat, Longitude: lon}, true
+}
@@ -0,0 +1,78 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/cache"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestNominatim(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("format") != "jsonv2" || r.Header.Get("User-Agent") == "" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("q") != "Eiffel Tower" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"lat":"48.8582599","lon":"2.2945006","boundingbox":["48.8574753","48.8590453","2.2933119","2.2956897"],"display_name":"Tour Eiffel, Paris"}]`))
+	}))
+	defer srv.Close()
+
+	n := NewNominatim(srv.URL + "/")
+	p, err := n.Geocode(context.Background(), "Eiffel Tower")
+	if err != nil || p.Latitude != 48.8582599 || p.Longitude != 2.2945006 {
+		t.Errorf("Geocode = %+v, %v", p, err)
+	}
+	if p.Bounds == nil || p.Bounds.South != 48.8574753 || p.Bounds.East != 2.2956897 {
+		t.Errorf("bounds = %+v", p.Bounds)
+	}
+	start := time.Now()
+	if _, err := n.Geocode(context.Background(), "Atlantis"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+	if time.Since(start) < minInterval/2 {
+		t.Error("second request wasn't spaced from the first")
+	}
+}
+
+// countingGeocoder answers every query with one place.
+type countingGeocoder struct{ calls int }
+
+func (g *countingGeocoder) Geocode(ctx context.Context, query string) (core.Place, error) {
+	g.calls++
+	return core.Place{Latitude: 45.5, Longitude: -73.6, Bounds: &core.Bounds{South: 45.4, North: 45.6, West: -73.7, East: -73.5}}, nil
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	store, err := cache.OpenDir(dir, func(string) time.Duration { return time.Hour })
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _ := cache.OpenDir(dir, func(string) time.Duration { return 0 })
+	next := &countingGeocoder{}
+
+	for _, q := range []string{"Old Port, Montreal", " old port,  montreal"} {
+		if p, err := NewCache(next, store).Geocode(context.Background(), q); err != nil || p.Latitude != 45.5 || p.Bounds == nil || p.Bounds.West != -73.7 {
+			t.Errorf("Geocode(%q) = %+v, %v", q, p, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %d, want the second answered from the cache", next.calls)
+	}
+
+	if p, err := NewCache(next, store).Geocode(context.Background(), "48.8584, 2.2945"); err != nil || p.Latitude != 48.8584 || p.Longitude != 2.2945 {
+		t.Errorf("coordinates = %+v, %v", p, err)
+	}
+	if _, err := NewCache(next, expired).Geocode(context.Background(), "Old Port, Montreal"); err != nil || next.calls != 2 {
+		t.Errorf("expired entry: calls = %d, err = %v", next.calls, err)
+	}
+}
@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/pkg/travelv1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// searchResult converts a result to its message. Summaries, groups, and
+// combined offers are left to the JSON API.
+func searchResult(r *core.SearchResult) *travelv1.SearchResult {
+	out := &travelv1.SearchResult{
+		Mode:       string(r.Mode),
+		Providers:  r.Providers,
+		TotalFound: int32(r.TotalFound),
+		Partial:    r.Partial,
+		FetchedAt:  timestamp(r.FetchedAt),
+		FromCache:  r.FromCache,
+	}
+	for _, f := range r.Flights {
+		out.Flights = append(out.Flights, flightOffer(f))
+	}
+	for _, s := range r.Stays {
+		out.Stays = append(out.Stays, stayOffer(s))
+	}
+	for _, e := range r.Errors {
+		out.Errors = append(out.Errors, &travelv1.ProviderError{
+			Provider: e.Provider,
+			Reason:   e.Reason,
+			Fallback: e.Fallback,
+			Attempts: int32(e.Attempts),
+		})
+	}
+	return out
+}
+
+func flightOffer(f core.FlightOffer) *travelv1.FlightOffer {
+	out := &travelv1.FlightOffer{
+		Id:                   f.ID,
+		Source:               f.Source,
+		Airline:              f.Airline,
+		FlightNumber:         f.FlightNumber,
+		From:                 f.From,
+		To:                   f.To,
+		DepartTime:           timestamp(f.DepartTime),
+		ArriveTime:           timestamp(f.ArriveTime),
+		DurationMinutes:      int32(f.DurationMinutes),
+		Stops:                int32(f.Stops),
+		CabinClass:           f.CabinClass,
+		PriceUsd:             f.PriceUSD,
+		Currency:             f.Currency,
+		DeepLink:             f.DeepLink,
+		Confidence:           f.Confidence,
+		IsBookable:           f.IsBookable,
+		RepriceRequired:      f.RepriceRequired,
+		FetchedAt:            timestamp(f.FetchedAt),
+		ExpiresAt:            timestamp(f.ExpiresAt),
+		PriceGuaranteedUntil: timestamp(f.PriceGuaranteedUntil),
+	}
+	for _, p := range f.Prices {
+		out.Prices = append(out.Prices, &travelv1.SourcePrice{Source: p.Source, OfferId: p.OfferID, PriceUsd: p.PriceUSD, DeepLink: p.DeepLink})
+	}
+	return out
+}
+
+func stayOffer(s core.StayOffer) *travelv1.StayOffer {
+	return &travelv1.StayOffer{
+		Id:                   s.ID,
+		Source:               s.Source,
+		Sources:              s.Sources,
+		Name:                 s.Name,
+		PropertyId:           s.PropertyID,
+		Chain:                s.Chain,
+		Type:                 s.Type,
+		City:                 s.City,
+		Address:              s.Address,
+		Latitude:             s.Latitude,
+		Longitude:            s.Longitude,
+		Neighborhood:         s.Neighborhood,
+		CheckIn:              s.CheckIn,
+		CheckOut:             s.CheckOut,
+		NightsCount:          int32(s.NightsCount),
+		PricePerNight:        s.PricePerNight,
+		TotalPriceUsd:        s.TotalPriceUSD,
+		Currency:             s.Currency,
+		Rating:               s.Rating,
+		ReviewCount:          int32(s.ReviewCount),
+		Amenities:            s.Amenities,
+		DeepLink:             s.DeepLink,
+		Confidence:           s.Confidence,
+		IsBookable:           s.IsBookable,
+		RepriceRequired:      s.RepriceRequired,
+		FetchedAt:            timestamp(s.FetchedAt),
+		ChildrenPriceUsd:     s.ChildrenPriceUSD,
+		ExpiresAt:            timestamp(s.ExpiresAt),
+		PriceGuaranteedUntil: timestamp(s.PriceGuaranteedUntil),
+	}
+}
+
+// timestamp leaves zero times unset, as the JSON output leaves them out.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,196 @@
+// Package grpcserver implements the travel.v1 TravelService, defined in
+// proto/travel/v1/travel.proto, over the same searcher, offer store, and
+// provider list as the HTTP server, so travel serve --grpc answers like
+// the /v1 endpoints.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/internal/server"
+	"github.com/beetlebot/travel-cli/pkg/travelv1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service is the TravelService. Searches are answered by the searcher;
+// Reprice needs WithOffers and ListProviders WithProviders, and report
+// Unimplemented without them.
+type Service struct {
+	travelv1.UnimplementedTravelServiceServer
+	searcher  func(ctx context.Context) server.Searcher
+	slots     chan struct{}
+	offers    server.Offers
+	providers func() []core.ProviderInfo
+}
+
+func New(searcher server.Searcher) *Service {
+	return &Service{searcher: func(context.Context) server.Searcher { return searcher }}
+}
+
+// WithSearcherFor answers each search with the searcher fn returns for the
+// call's context, so a search stops when its caller cancels it.
+func (s *Service) WithSearcherFor(fn func(ctx context.Context) server.Searcher) *Service {
+	s.searcher = fn
+	return s
+}
+
+// WithSlots runs each search holding one of slots, as the HTTP server
+// runs its own, waiting for a free one until the call is canceled.
+func (s *Service) WithSlots(slots chan struct{}) *Service {
+	s.slots = slots
+	return s
+}
+
+// WithOffers saves the offers of every search the service runs to o, and
+// reprices offers from it.
+func (s *Service) WithOffers(o server.Offers) *Service {
+	s.offers = o
+	return s
+}
+
+// WithProviders answers ListProviders with what list returns, read on
+// each call.
+func (s *Service) WithProviders(list func() []core.ProviderInfo) *Service {
+	s.providers = list
+	return s
+}
+
+func (s *Service) SearchFlights(ctx context.Context, in *travelv1.SearchFlightsRequest) (*travelv1.SearchFlightsResponse, error) {
+	req := core.FlightSearchRequest{
+		From:       in.GetFrom(),
+		To:         in.GetTo(),
+		DepartDate: in.GetDepartDate(),
+		ReturnDate: in.GetReturnDate(),
+		Adults:     int(in.GetAdults()),
+		CabinClass: in.GetCabinClass(),
+		MaxResults: int(in.GetMaxResults()),
+		GroupBy:    in.GetGroupBy(),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.ApplyDefaults()
+	result, err := s.search(ctx, func(searcher server.Searcher) (*core.SearchResult, error) { return searcher.SearchFlights(req) })
+	if err != nil {
+		return nil, err
+	}
+	return &travelv1.SearchFlightsResponse{Result: result}, nil
+}
+
+func (s *Service) SearchStays(ctx context.Context, in *travelv1.SearchStaysRequest) (*travelv1.SearchStaysResponse, error) {
+	req := core.StaySearchRequest{
+		City:        in.GetCity(),
+		CheckIn:     in.GetCheckIn(),
+		CheckOut:    in.GetCheckOut(),
+		Guests:      int(in.GetGuests()),
+		Rooms:       int(in.GetRooms()),
+		MaxResults:  int(in.GetMaxResults()),
+		StayType:    in.GetStayType(),
+		MaxPriceUSD: int(in.GetMaxPriceUsd()),
+		GroupBy:     in.GetGroupBy(),
+	}
+	for _, age := range in.GetChildrenAges() {
+		req.ChildrenAges = append(req.ChildrenAges, int(age))
+	}
+	if err := req.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.ApplyDefaults()
+	result, err := s.search(ctx, func(searcher server.Searcher) (*core.SearchResult, error) { return searcher.SearchStays(req) })
+	if err != nil {
+		return nil, err
+	}
+	return &travelv1.SearchStaysResponse{Result: result}, nil
+}
+
+// search runs run in a slot with the searcher for ctx, and saves its
+// offers for Reprice. A search cut short by ctx fails with its code.
+func (s *Service) search(ctx context.Context, run func(server.Searcher) (*core.SearchResult, error)) (*travelv1.SearchResult, error) {
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+			defer func() { <-s.slots }()
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	result, err := run(s.searcher(ctx))
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, status.FromContextError(ctxErr).Err()
+	}
+	if errors.Is(err, core.ErrUnknownPlace) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if s.offers != nil {
+		_ = s.offers.SaveResult(result)
+	}
+	return searchResult(result), nil
+}
+
+// Reprice answers like travel offers reprice: live repricing isn't
+// implemented, so the stored offer comes back with its expiry warnings.
+func (s *Service) Reprice(ctx context.Context, in *travelv1.RepriceRequest) (*travelv1.RepriceResponse, error) {
+	if s.offers == nil {
+		return nil, status.Error(codes.Unimplemented, "no offer store")
+	}
+	id := in.GetOfferId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "offer_id is required")
+	}
+	resp := &travelv1.RepriceResponse{
+		OfferId: id,
+		Status:  "reprice_not_implemented",
+		Message: "Reprice requires live provider connection. Coming in a future version.",
+	}
+	var warnings []core.OfferWarning
+	flight, err := s.offers.Flight(id)
+	if err == nil {
+		resp.Offer = &travelv1.RepriceResponse_Flight{Flight: flightOffer(*flight)}
+		warnings = core.AppendExpiryWarning(warnings, flight.CheckExpiry(time.Now()))
+	} else if errors.Is(err, offers.ErrNotFound) {
+		var stay *core.StayOffer
+		if stay, err = s.offers.Stay(id); err == nil {
+			resp.Offer = &travelv1.RepriceResponse_Stay{Stay: stayOffer(*stay)}
+			warnings = core.AppendExpiryWarning(warnings, stay.CheckExpiry(time.Now()))
+		}
+	}
+	switch {
+	case errors.Is(err, offers.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "%v (offers are kept for a week after the search that returned them)", err)
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, w := range warnings {
+		resp.Warnings = append(resp.Warnings, &travelv1.OfferWarning{Code: w.Code, OfferId: w.OfferID, Message: w.Message})
+	}
+	return resp, nil
+}
+
+func (s *Service) ListProviders(ctx context.Context, in *travelv1.ListProvidersRequest) (*travelv1.ListProvidersResponse, error) {
+	if s.providers == nil {
+		return nil, status.Error(codes.Unimplemented, "no provider list")
+	}
+	resp := &travelv1.ListProvidersResponse{}
+	for _, info := range s.providers() {
+		p := &travelv1.Provider{
+			Name:       info.Name,
+			Tier:       string(info.Tier),
+			Status:     info.Status,
+			Reason:     info.Reason,
+			ReenableAt: timestamp(info.ReenableAt),
+		}
+		for _, c := range info.Capabilities {
+			p.Capabilities = append(p.Capabilities, string(c))
+		}
+		resp.Providers = append(resp.Providers, p)
+	}
+	return resp, nil
+}
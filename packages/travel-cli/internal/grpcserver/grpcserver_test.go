@@ -0,0 +1,127 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/pkg/travelv1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeSearcher struct{}
+
+func (fakeSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{
+		Query:      req,
+		Providers:  []string{"mock_flights"},
+		Flights:    []core.FlightOffer{{ID: "f1", Source: "mock_flights", From: req.From, To: req.To, PriceUSD: 500, ExpiresAt: time.Now().Add(-time.Hour)}},
+		TotalFound: 1,
+		Errors:     []core.ProviderError{{Provider: "duffel", Reason: "timeout", Attempts: 2}},
+	}, nil
+}
+
+func (fakeSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{Query: req}, nil
+}
+
+func newClient(t *testing.T) travelv1.TravelServiceClient {
+	t.Helper()
+	saved, err := offers.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(fakeSearcher{}).WithOffers(saved).WithProviders(func() []core.ProviderInfo {
+		return []core.ProviderInfo{{Name: "mock_flights", Capabilities: []core.Capability{core.CapFlightsSearch}, Tier: core.TierEasySignup, Status: "active"}}
+	})
+
+	ln := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	travelv1.RegisterTravelServiceServer(g, svc)
+	go func() { _ = g.Serve(ln) }()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return travelv1.NewTravelServiceClient(conn)
+}
+
+func TestService_SearchThenReprice(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	resp, err := client.SearchFlights(ctx, &travelv1.SearchFlightsRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := resp.GetResult()
+	if len(result.GetFlights()) != 1 || result.GetFlights()[0].GetPriceUsd() != 500 || result.GetFlights()[0].GetFrom() != "YUL" {
+		t.Errorf("flights = %v", result.GetFlights())
+	}
+	if len(result.GetErrors()) != 1 || result.GetErrors()[0].GetAttempts() != 2 {
+		t.Errorf("errors = %v", result.GetErrors())
+	}
+
+	repriced, err := client.Reprice(ctx, &travelv1.RepriceRequest{OfferId: "f1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repriced.GetFlight().GetId() != "f1" || len(repriced.GetWarnings()) != 1 {
+		t.Errorf("reprice = %v, want the stored flight with an expiry warning", repriced)
+	}
+
+	_, err = client.Reprice(ctx, &travelv1.RepriceRequest{OfferId: "f2"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown offer: %v, want NotFound", err)
+	}
+}
+
+func TestService_RejectsInvalidSearch(t *testing.T) {
+	_, err := newClient(t).SearchStays(context.Background(), &travelv1.SearchStaysRequest{City: "Paris"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestService_ListProviders(t *testing.T) {
+	resp, err := newClient(t).ListProviders(context.Background(), &travelv1.ListProvidersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetProviders()) != 1 || resp.GetProviders()[0].GetCapabilities()[0] != string(core.CapFlightsSearch) {
+		t.Errorf("providers = %v", resp.GetProviders())
+	}
+}
+
+func TestService_WaitsForSearchSlot(t *testing.T) {
+	slots := make(chan struct{}, 1)
+	slots <- struct{}{}
+	svc := New(fakeSearcher{}).WithSlots(slots)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := svc.SearchFlights(ctx, &travelv1.SearchFlightsRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("err = %v, want DeadlineExceeded while every slot is taken", err)
+	}
+
+	<-slots
+	if _, err := svc.SearchFlights(context.Background(), &travelv1.SearchFlightsRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}); err != nil {
+		t.Errorf("err = %v with a free slot", err)
+	}
+	if len(slots) != 0 {
+		t.Error("search kept its slot")
+	}
+}
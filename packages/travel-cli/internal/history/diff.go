@@ -0,0 +1,290 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/fx"
+)
+
+var ErrNoRuns = errors.New("no matching searches in history")
+
+// DiffQuery selects the past searches to compare: flights by Route
+// ("YUL-CDG") and departure date, or stays by City and check-in date.
+type DiffQuery struct {
+	Route string `json:"route,omitempty"`
+	City  string `json:"city,omitempty"`
+	Date  string `json:"date"`
+}
+
+// ParseRoute validates a FROM-TO route and returns it upper-cased.
+func ParseRoute(route string) (from, to string, err error) {
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(route)), "-")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid route %q (expected FROM-TO, e.g. YUL-CDG)", route)
+	}
+	return parts[0], parts[1], nil
+}
+
+// searchKey holds the identifying fields of flight and stay queries.
+type searchKey struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	DepartDate string `json:"departDate"`
+	City       string `json:"city"`
+	CheckIn    string `json:"checkIn"`
+}
+
+// Matches reports whether e is a run of the query.
+func (q DiffQuery) Matches(e Entry) bool {
+	var k searchKey
+	if err := json.Unmarshal(e.Query, &k); err != nil {
+		return false
+	}
+	if q.Route != "" {
+		from, to, err := ParseRoute(q.Route)
+		return err == nil && e.Kind == KindFlights &&
+			strings.EqualFold(k.From, from) && strings.EqualFold(k.To, to) && k.DepartDate == q.Date
+	}
+	return e.Kind == KindStays && strings.EqualFold(strings.TrimSpace(k.City), strings.TrimSpace(q.City)) && k.CheckIn == q.Date
+}
+
+// Run is one past execution of the query and how its cheapest price moved
+// from the run before.
+type Run struct {
+	EntryID     string    `json:"entryId"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Mode        string    `json:"mode"`
+	CheapestUSD float64   `json:"cheapestUSD"`
+	Cheapest    *Pick     `json:"cheapest,omitempty"`
+	MedianUSD   float64   `json:"medianUSD,omitempty"`
+	ChangeUSD   float64   `json:"changeUSD"`
+	ChangePct   float64   `json:"changePct"`
+}
+
+// Diff summarizes how the cheapest offer for a query moved over time.
+type Diff struct {
+	Query               DiffQuery `json:"query"`
+	Runs                []Run     `json:"runs"`
+	FirstUSD            float64   `json:"firstUSD"`
+	LatestUSD           float64   `json:"latestUSD"`
+	LowestUSD           float64   `json:"lowestUSD"`
+	LowestAt            time.Time `json:"lowestAt"`
+	ChangeSinceFirstUSD float64   `json:"changeSinceFirstUSD"`
+	ChangeSinceFirstPct float64   `json:"changeSinceFirstPct"`
+	ChangeSinceLastUSD  float64   `json:"changeSinceLastUSD"`
+	// Trend compares the latest run with the one before it: "up",
+	// "down", "flat", or "new" when there is only one run.
+	Trend string `json:"trend"`
+	// Converted repeats the diff in another currency when one is asked
+	// for.
+	Converted *Converted `json:"converted,omitempty"`
+}
+
+const (
+	RateObserved = "observed"
+	RateCurrent  = "current"
+)
+
+// Converted is a diff in a currency other than USD.
+type Converted struct {
+	Currency            string         `json:"currency"`
+	Runs                []ConvertedRun `json:"runs"`
+	First               float64        `json:"first"`
+	Latest              float64        `json:"latest"`
+	Lowest              float64        `json:"lowest"`
+	ChangeSinceFirst    float64        `json:"changeSinceFirst"`
+	ChangeSinceFirstPct float64        `json:"changeSinceFirstPct"`
+	ChangeSinceLast     float64        `json:"changeSinceLast"`
+	Trend               string         `json:"trend"`
+}
+
+// ConvertedRun is a run's cheapest price converted at Rate. RateSource is
+// "observed" when the rate was recorded with the run and "current" when
+// the run predates recorded rates.
+type ConvertedRun struct {
+	EntryID    string  `json:"entryId"`
+	Cheapest   float64 `json:"cheapest"`
+	Change     float64 `json:"change"`
+	ChangePct  float64 `json:"changePct"`
+	Rate       float64 `json:"rate"`
+	RateDate   string  `json:"rateDate,omitempty"`
+	RateSource string  `json:"rateSource"`
+}
+
+// Compute builds the diff for q from entries in any order. Runs without
+// results are skipped since they carry no price. At most limit runs, the
+// most recent, are listed; 0 lists them all.
+func Compute(entries []Entry, q DiffQuery, limit int) (*Diff, error) {
+	matched := matchRuns(entries, q)
+	if len(matched) == 0 {
+		return nil, ErrNoRuns
+	}
+
+	prices := make([]float64, len(matched))
+	for i, e := range matched {
+		prices[i] = e.CheapestUSD
+	}
+	m := analyze(prices)
+
+	d := &Diff{
+		Query:               q,
+		FirstUSD:            m.first,
+		LatestUSD:           m.latest,
+		LowestUSD:           m.lowest,
+		LowestAt:            matched[m.lowestIdx].CreatedAt,
+		ChangeSinceFirstUSD: m.sinceFirst,
+		ChangeSinceFirstPct: m.sinceFirstPct,
+		ChangeSinceLastUSD:  m.sinceLast,
+		Trend:               m.trend,
+	}
+	for i, e := range matched {
+		run := Run{
+			EntryID:     e.ID,
+			CreatedAt:   e.CreatedAt,
+			Mode:        e.Mode,
+			CheapestUSD: e.CheapestUSD,
+			Cheapest:    e.Cheapest,
+			ChangeUSD:   m.changes[i],
+			ChangePct:   m.pcts[i],
+		}
+		if e.Summary != nil {
+			run.MedianUSD = e.Summary.MedianPriceUSD
+		}
+		d.Runs = append(d.Runs, run)
+	}
+	d.Runs = newestFirst(d.Runs, limit)
+	return d, nil
+}
+
+// ComputeIn is Compute with the diff also expressed in currency. Each run
+// is converted at the rate recorded with it, so the trend reflects prices
+// as they were on each day rather than today's exchange rate. Runs
+// recorded before rates were kept fall back to current.
+func ComputeIn(entries []Entry, q DiffQuery, limit int, currency string, current fx.Rates) (*Diff, error) {
+	d, err := Compute(entries, q, limit)
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if err != nil || currency == "" || currency == fx.USD {
+		return d, err
+	}
+
+	matched := matchRuns(entries, q)
+	prices := make([]float64, len(matched))
+	runs := make([]ConvertedRun, len(matched))
+	for i, e := range matched {
+		rates, source := current, RateCurrent
+		if e.FX != nil {
+			if _, ok := e.FX.Rate(currency); ok {
+				rates, source = *e.FX, RateObserved
+			}
+		}
+		rate, ok := rates.Rate(currency)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", fx.ErrUnknownCurrency, currency)
+		}
+		prices[i] = round2(e.CheapestUSD * rate)
+		runs[i] = ConvertedRun{EntryID: e.ID, Cheapest: prices[i], Rate: rate, RateDate: rates.Date, RateSource: source}
+	}
+	m := analyze(prices)
+	for i := range runs {
+		runs[i].Change, runs[i].ChangePct = m.changes[i], m.pcts[i]
+	}
+
+	d.Converted = &Converted{
+		Currency:            currency,
+		Runs:                newestFirst(runs, limit),
+		First:               m.first,
+		Latest:              m.latest,
+		Lowest:              m.lowest,
+		ChangeSinceFirst:    m.sinceFirst,
+		ChangeSinceFirstPct: m.sinceFirstPct,
+		ChangeSinceLast:     m.sinceLast,
+		Trend:               m.trend,
+	}
+	return d, nil
+}
+
+// matchRuns returns the runs of q that found offers, oldest first.
+func matchRuns(entries []Entry, q DiffQuery) []Entry {
+	var matched []Entry
+	for _, e := range entries {
+		if e.CheapestUSD > 0 && q.Matches(e) {
+			matched = append(matched, e)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].CreatedAt.Before(matched[j].CreatedAt) })
+	return matched
+}
+
+// movement describes a series of prices, oldest first.
+type movement struct {
+	first, latest, lowest float64
+	lowestIdx             int
+	changes, pcts         []float64
+	sinceFirst            float64
+	sinceFirstPct         float64
+	sinceLast             float64
+	trend                 string
+}
+
+func analyze(prices []float64) movement {
+	n := len(prices)
+	m := movement{
+		first:   prices[0],
+		latest:  prices[n-1],
+		changes: make([]float64, n),
+		pcts:    make([]float64, n),
+		trend:   "new",
+	}
+	for i, p := range prices {
+		if i > 0 {
+			m.changes[i] = round2(p - prices[i-1])
+			m.pcts[i] = pct(p, prices[i-1])
+		}
+		if m.lowest == 0 || p <= m.lowest {
+			m.lowest, m.lowestIdx = p, i
+		}
+	}
+	m.sinceFirst = round2(m.latest - m.first)
+	m.sinceFirstPct = pct(m.latest, m.first)
+	if n > 1 {
+		m.sinceLast = m.changes[n-1]
+		switch {
+		case m.sinceLast > 0:
+			m.trend = "up"
+		case m.sinceLast < 0:
+			m.trend = "down"
+		default:
+			m.trend = "flat"
+		}
+	}
+	return m
+}
+
+// newestFirst reverses runs, matching history list, and keeps at most
+// limit of them.
+func newestFirst[T any](runs []T, limit int) []T {
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+	return runs
+}
+
+func pct(now, before float64) float64 {
+	if before == 0 {
+		return 0
+	}
+	return round2((now - before) / before * 100)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,92 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/fx"
+)
+
+func flightRun(id string, at time.Time, from, to, date string, cheapest float64) Entry {
+	q, _ := json.Marshal(map[string]string{"from": from, "to": to, "departDate": date})
+	return Entry{ID: id, Kind: KindFlights, Query: q, CheapestUSD: cheapest, CreatedAt: at}
+}
+
+func TestComputeDiff(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		// Newest first, as returned by Store.List.
+		flightRun("h_4", t0.Add(72*time.Hour), "YUL", "CDG", "2026-06-12", 560),
+		flightRun("h_x", t0.Add(60*time.Hour), "YUL", "LHR", "2026-06-12", 300),
+		flightRun("h_3", t0.Add(48*time.Hour), "yul", "cdg", "2026-06-12", 520),
+		flightRun("h_e", t0.Add(36*time.Hour), "YUL", "CDG", "2026-06-12", 0), // no results
+		flightRun("h_2", t0.Add(24*time.Hour), "YUL", "CDG", "2026-06-12", 610),
+		flightRun("h_1", t0, "YUL", "CDG", "2026-06-12", 640),
+		flightRun("h_d", t0, "YUL", "CDG", "2026-06-13", 100),
+	}
+
+	d, err := Compute(entries, DiffQuery{Route: "yul-cdg", Date: "2026-06-12"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Runs) != 4 || d.Runs[0].EntryID != "h_4" || d.Runs[3].EntryID != "h_1" {
+		t.Fatalf("runs = %+v", d.Runs)
+	}
+	if d.FirstUSD != 640 || d.LatestUSD != 560 || d.LowestUSD != 520 || !d.LowestAt.Equal(t0.Add(48*time.Hour)) {
+		t.Errorf("first/latest/lowest = %v/%v/%v at %v", d.FirstUSD, d.LatestUSD, d.LowestUSD, d.LowestAt)
+	}
+	if d.ChangeSinceFirstUSD != -80 || d.ChangeSinceFirstPct != -12.5 {
+		t.Errorf("change since first = %v (%v%%)", d.ChangeSinceFirstUSD, d.ChangeSinceFirstPct)
+	}
+	if d.ChangeSinceLastUSD != 40 || d.Trend != "up" {
+		t.Errorf("change since last = %v, trend %q", d.ChangeSinceLastUSD, d.Trend)
+	}
+
+	limited, _ := Compute(entries, DiffQuery{Route: "YUL-CDG", Date: "2026-06-12"}, 2)
+	if len(limited.Runs) != 2 || limited.FirstUSD != 640 {
+		t.Errorf("limit should trim runs but not totals: %+v", limited)
+	}
+}
+
+func TestComputeDiffNoRuns(t *testing.T) {
+	if _, err := Compute(nil, DiffQuery{Route: "YUL-CDG", Date: "2026-06-12"}, 0); !errors.Is(err, ErrNoRuns) {
+		t.Fatalf("err = %v, want ErrNoRuns", err)
+	}
+}
+
+func TestComputeInUsesObservedRates(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	old := flightRun("h_1", t0, "YUL", "CDG", "2026-06-12", 500)
+	old.FX = &fx.Rates{Base: "USD", Date: "2026-05-01", Rates: map[string]float64{"EUR": 0.80}}
+	recent := flightRun("h_2", t0.Add(24*time.Hour), "YUL", "CDG", "2026-06-12", 500)
+	recent.FX = &fx.Rates{Base: "USD", Date: "2026-05-02", Rates: map[string]float64{"EUR": 0.90}}
+	legacy := flightRun("h_0", t0.Add(-24*time.Hour), "YUL", "CDG", "2026-06-12", 500)
+	current := fx.Rates{Base: "USD", Date: "2026-10-17", Rates: map[string]float64{"EUR": 1.0}}
+
+	d, err := ComputeIn([]Entry{recent, old, legacy}, DiffQuery{Route: "YUL-CDG", Date: "2026-06-12"}, 0, "eur", current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Trend != "flat" {
+		t.Errorf("USD trend = %q, want flat", d.Trend)
+	}
+	c := d.Converted
+	if c == nil || c.Currency != "EUR" {
+		t.Fatalf("converted = %+v", c)
+	}
+	if c.First != 500 || c.Latest != 450 || c.Trend != "up" || c.ChangeSinceLast != 50 {
+		t.Errorf("converted totals = %+v", c)
+	}
+	if c.Runs[0].EntryID != "h_2" || c.Runs[0].RateSource != RateObserved || c.Runs[0].RateDate != "2026-05-02" {
+		t.Errorf("newest run = %+v", c.Runs[0])
+	}
+	if c.Runs[2].EntryID != "h_0" || c.Runs[2].RateSource != RateCurrent {
+		t.Errorf("legacy run = %+v", c.Runs[2])
+	}
+
+	if _, err := ComputeIn([]Entry{old}, DiffQuery{Route: "YUL-CDG", Date: "2026-06-12"}, 0, "XYZ", current); !errors.Is(err, fx.ErrUnknownCurrency) {
+		t.Errorf("unknown currency err = %v", err)
+	}
+}
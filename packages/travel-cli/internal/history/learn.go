@@ -0,0 +1,69 @@
+package history
+
+import (
+	"sort"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+const (
+	// minSelections is the evidence needed before a preference is learned.
+	minSelections = 3
+	// minAirlineShare keeps airlines the user only tried now and then from
+	// being treated as preferred.
+	minAirlineShare = 0.3
+)
+
+// Learn derives ranking preferences from the offers the user selected.
+// Search results are deliberately not considered: their top picks are
+// the ranker's own output, so learning from them would only reinforce it.
+func Learn(selections []Selection) core.Preferences {
+	prefs := core.NoPreferences()
+
+	var flightSelections, staySelections int
+	airlineCounts := map[string]int{}
+	var stops []int
+	var ratings []float64
+
+	for _, sel := range selections {
+		switch sel.Kind {
+		case KindFlights:
+			flightSelections++
+			if sel.Pick.Airline != "" {
+				airlineCounts[sel.Pick.Airline]++
+			}
+			stops = append(stops, sel.Pick.Stops)
+		case KindStays:
+			staySelections++
+			if sel.Pick.Rating > 0 {
+				ratings = append(ratings, sel.Pick.Rating)
+			}
+		}
+	}
+
+	if flightSelections >= minSelections {
+		total := 0
+		for _, c := range airlineCounts {
+			total += c
+		}
+		for airline, c := range airlineCounts {
+			share := float64(c) / float64(total)
+			if share >= minAirlineShare {
+				if prefs.PreferredAirlines == nil {
+					prefs.PreferredAirlines = map[string]float64{}
+				}
+				prefs.PreferredAirlines[airline] = share
+			}
+		}
+		sort.Ints(stops)
+		prefs.StopTolerance = stops[len(stops)/2]
+	}
+
+	if staySelections >= minSelections && len(ratings) > 0 {
+		sort.Float64s(ratings)
+		// Lower quartile: most selected stays were rated at least this.
+		prefs.RatingFloor = ratings[len(ratings)/4]
+	}
+
+	return prefs
+}
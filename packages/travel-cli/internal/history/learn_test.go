@@ -0,0 +1,44 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestLearn_NeedsEnoughSelections(t *testing.T) {
+	sels := []Selection{
+		FlightSelection(ActionReprice, core.FlightOffer{Airline: "Air Canada"}),
+	}
+
+	prefs := Learn(sels)
+	if len(prefs.PreferredAirlines) != 0 || prefs.StopTolerance != -1 {
+		t.Errorf("expected no preferences from a single selection, got %+v", prefs)
+	}
+}
+
+func TestLearn_FlightAndStayPreferences(t *testing.T) {
+	sels := []Selection{
+		FlightSelection(ActionTrip, core.FlightOffer{Airline: "Air Canada"}),
+		FlightSelection(ActionCombine, core.FlightOffer{Airline: "Air Canada", Stops: 1}),
+		FlightSelection(ActionReprice, core.FlightOffer{Airline: "Air Canada"}),
+		FlightSelection(ActionReprice, core.FlightOffer{Airline: "Lufthansa"}),
+		StaySelection(ActionCombine, core.StayOffer{Rating: 4.5}),
+		StaySelection(ActionTrip, core.StayOffer{Rating: 4.2}),
+		StaySelection(ActionTrip, core.StayOffer{Rating: 4.8}),
+	}
+
+	prefs := Learn(sels)
+	if prefs.PreferredAirlines["Air Canada"] < 0.7 {
+		t.Errorf("expected Air Canada to be preferred, got %+v", prefs.PreferredAirlines)
+	}
+	if _, ok := prefs.PreferredAirlines["Lufthansa"]; ok {
+		t.Errorf("expected an airline picked once in four not to be preferred, got %+v", prefs.PreferredAirlines)
+	}
+	if prefs.StopTolerance != 0 {
+		t.Errorf("expected stop tolerance 0, got %d", prefs.StopTolerance)
+	}
+	if prefs.RatingFloor != 4.2 {
+		t.Errorf("expected rating floor 4.2, got %.1f", prefs.RatingFloor)
+	}
+}
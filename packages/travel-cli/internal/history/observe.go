@@ -0,0 +1,91 @@
+package history
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// DefaultObserveEvery is how often a route and date gets a passive
+// observation when searched repeatedly.
+const DefaultObserveEvery = time.Hour
+
+// Observation builds a compact entry for a search run on behalf of another
+// feature (the API server, trip planning): the price summary without the
+// picks, so it feeds history diff without steering learned preferences.
+func Observation(kind string, r *core.SearchResult) Entry {
+	e := FromResult(kind, r)
+	e.Cheapest, e.Top = nil, nil
+	if e.Summary != nil {
+		s := *e.Summary
+		s.BySource = nil
+		e.Summary = &s
+	}
+	e.Observed = true
+	return e
+}
+
+// observationKey identifies the route or city and date of an entry, or ""
+// when its query has neither.
+func observationKey(e Entry) string {
+	var k searchKey
+	if err := json.Unmarshal(e.Query, &k); err != nil {
+		return ""
+	}
+	switch {
+	case k.From != "" && k.To != "" && k.DepartDate != "":
+		return e.Kind + "|" + strings.ToUpper(k.From) + "-" + strings.ToUpper(k.To) + "|" + k.DepartDate
+	case k.City != "" && k.CheckIn != "":
+		return e.Kind + "|" + strings.ToLower(strings.TrimSpace(k.City)) + "|" + k.CheckIn
+	}
+	return ""
+}
+
+// Observe appends e unless its route or city and date were recorded less
+// than every ago, so repeated searches (a planner pricing hundreds of legs,
+// a busy API) add one snapshot per interval. Entries without a route or
+// city are skipped. It reports whether e was written.
+func (s *Store) Observe(e Entry, every time.Duration) (bool, error) {
+	key := observationKey(e)
+	if key == "" || e.Summary == nil {
+		return false, nil
+	}
+	if err := s.loadRecent(); err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.recent[key]; ok && e.CreatedAt.Sub(last) < every {
+		return false, nil
+	}
+	return true, s.appendLocked(e)
+}
+
+// loadRecent indexes when each route and date was last recorded, once per
+// store.
+func (s *Store) loadRecent() error {
+	s.mu.Lock()
+	loaded := s.recent != nil
+	s.mu.Unlock()
+	if loaded {
+		return nil
+	}
+	entries, err := s.List(0)
+	if err != nil {
+		return err
+	}
+	recent := map[string]time.Time{}
+	for _, e := range entries {
+		if key := observationKey(e); key != "" && e.CreatedAt.After(recent[key]) {
+			recent[key] = e.CreatedAt
+		}
+	}
+	s.mu.Lock()
+	if s.recent == nil {
+		s.recent = recent
+	}
+	s.mu.Unlock()
+	return nil
+}
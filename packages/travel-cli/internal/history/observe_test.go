@@ -0,0 +1,64 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestObserveOncePerInterval(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := func(from string) *core.SearchResult {
+		return &core.SearchResult{
+			Query:      core.FlightSearchRequest{From: from, To: "CDG", DepartDate: "2026-06-12"},
+			TotalFound: 2,
+			Flights:    []core.FlightOffer{{ID: "f_1", Source: "a", PriceUSD: 400}, {ID: "f_2", Source: "b", PriceUSD: 500}},
+			Summary:    &core.ResultSummary{Count: 2, MinPriceUSD: 400, MedianPriceUSD: 450, BySource: map[string]core.SourceSummary{"a": {Count: 1}}},
+		}
+	}
+
+	first := Observation(KindFlights, result("YUL"))
+	if !first.Observed || first.Top != nil || first.Cheapest != nil || first.CheapestUSD != 400 || first.Summary.BySource != nil {
+		t.Fatalf("observation not compact: %+v", first)
+	}
+	observe := func(e Entry, at time.Time) bool {
+		e.CreatedAt = at
+		ok, err := store.Observe(e, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ok
+	}
+	now := time.Now().UTC()
+	if !observe(first, now) {
+		t.Error("first observation skipped")
+	}
+	if observe(Observation(KindFlights, result("yul")), now.Add(30*time.Minute)) {
+		t.Error("same route within the hour was written")
+	}
+	if !observe(Observation(KindFlights, result("JFK")), now.Add(30*time.Minute)) {
+		t.Error("other route skipped")
+	}
+	if !observe(Observation(KindFlights, result("YUL")), now.Add(61*time.Minute)) {
+		t.Error("route skipped after the interval")
+	}
+
+	// A full entry appended directly also counts as a recent snapshot.
+	full := FromResult(KindFlights, result("YUL"))
+	full.CreatedAt = now.Add(2 * time.Hour)
+	if err := store.Append(full); err != nil {
+		t.Fatal(err)
+	}
+	if observe(Observation(KindFlights, result("YUL")), now.Add(150*time.Minute)) {
+		t.Error("observation written right after a full entry")
+	}
+
+	entries, _ := store.List(0)
+	if len(entries) != 4 {
+		t.Errorf("got %d entries, want 4", len(entries))
+	}
+}
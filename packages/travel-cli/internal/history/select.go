@@ -0,0 +1,92 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// Actions that record a selection.
+const (
+	ActionReprice = "reprice"
+	ActionCombine = "combine"
+	ActionTrip    = "trip"
+)
+
+// Selection records an offer the user acted on: repriced, combined into a
+// package or added to a trip. Unlike the top picks of a search, which
+// only echo the ranking, selections are what preferences are learned from.
+type Selection struct {
+	// Kind is KindFlights or KindStays.
+	Kind      string    `json:"kind"`
+	Action    string    `json:"action"`
+	Pick      Pick      `json:"pick"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FlightSelection records that the user took action on a flight offer.
+func FlightSelection(action string, f core.FlightOffer) Selection {
+	return Selection{Kind: KindFlights, Action: action, Pick: flightPick(f), CreatedAt: time.Now().UTC()}
+}
+
+// StaySelection records that the user took action on a stay offer.
+func StaySelection(action string, s core.StayOffer) Selection {
+	return Selection{Kind: KindStays, Action: action, Pick: stayPick(s), CreatedAt: time.Now().UTC()}
+}
+
+// Select appends sel to the selections log.
+func (s *Store) Select(sel Selection) error {
+	raw, err := json.Marshal(sel)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.selections, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(raw, '\n'))
+	return err
+}
+
+// Selections returns recorded selections newest first. A limit of 0
+// returns everything.
+func (s *Store) Selections(limit int) ([]Selection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.selections)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sels []Selection
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var sel Selection
+		if err := json.Unmarshal(scanner.Bytes(), &sel); err != nil {
+			continue
+		}
+		sels = append(sels, sel)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(sels)-1; i < j; i, j = i+1, j-1 {
+		sels[i], sels[j] = sels[j], sels[i]
+	}
+	if limit > 0 && len(sels) > limit {
+		sels = sels[:limit]
+	}
+	return sels, nil
+}
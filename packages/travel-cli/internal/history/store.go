@@ -0,0 +1,221 @@
+package history
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+const (
+	KindFlights = "flights"
+	KindStays   = "stays"
+	// KindLookup records a stays lookup; its query is a
+	// core.StayLookupRequest.
+	KindLookup = "lookup"
+)
+
+var ErrNotFound = errors.New("search not found in history")
+
+// maxPicks is how many top-ranked offers are kept per entry; enough to
+// learn from without storing whole result sets.
+const maxPicks = 3
+
+// Entry is a compact record of one executed search.
+type Entry struct {
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	Query       json.RawMessage `json:"query"`
+	Mode        string          `json:"mode"`
+	Providers   []string        `json:"providers,omitempty"`
+	TotalFound  int             `json:"totalFound"`
+	CheapestUSD float64         `json:"cheapestUSD,omitempty"`
+	// Cheapest and Summary capture the price landscape of the search so
+	// later runs of the same query can be diffed against it. Cheapest is
+	// the lowest-priced of every offer found, not only those returned,
+	// and stays are priced per night, like the summary and alert
+	// thresholds.
+	Cheapest *Pick               `json:"cheapest,omitempty"`
+	Summary  *core.ResultSummary `json:"summary,omitempty"`
+	Top      []Pick              `json:"top,omitempty"`
+	// FX holds the exchange rates in effect when the search ran, so its
+	// prices can later be shown in another currency at that day's rate.
+	FX *fx.Rates `json:"fx,omitempty"`
+	// Observed marks a compact snapshot of a search the user did not run
+	// directly; see Observation.
+	Observed  bool      `json:"observed,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Pick is a snapshot of one of the top-ranked offers of a search.
+type Pick struct {
+	OfferID  string  `json:"offerId"`
+	Source   string  `json:"source"`
+	Airline  string  `json:"airline,omitempty"`
+	Stops    int     `json:"stops"`
+	Rating   float64 `json:"rating,omitempty"`
+	PriceUSD float64 `json:"priceUSD"`
+}
+
+func flightPick(f core.FlightOffer) Pick {
+	return Pick{OfferID: f.ID, Source: f.Source, Airline: f.Airline, Stops: f.Stops, PriceUSD: f.PriceUSD}
+}
+
+func stayPick(s core.StayOffer) Pick {
+	return Pick{OfferID: s.ID, Source: s.Source, Rating: s.Rating, PriceUSD: s.PricePerNight}
+}
+
+// Store is an append-only JSON-lines log of searches.
+type Store struct {
+	path string
+	// selections is the log of offers the user acted on; see Selection.
+	selections string
+	mu         sync.Mutex
+	// recent maps observation keys to when they were last recorded, once
+	// Observe has loaded it.
+	recent map[string]time.Time
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+// OpenDir opens the history log in dir, creating the directory if needed.
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create history dir: %w", err)
+	}
+	return &Store{
+		path:       filepath.Join(dir, "history.jsonl"),
+		selections: filepath.Join(dir, "selections.jsonl"),
+	}, nil
+}
+
+// FromResult builds a history entry from a search result.
+func FromResult(kind string, r *core.SearchResult) Entry {
+	query, _ := json.Marshal(r.Query)
+	e := Entry{
+		Kind:       kind,
+		Query:      query,
+		Mode:       string(r.Mode),
+		Providers:  r.Providers,
+		TotalFound: r.TotalFound,
+		Summary:    r.Summary,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if f := r.LowestFlight(); f != nil {
+		e.CheapestUSD = f.PriceUSD
+		p := flightPick(*f)
+		e.Cheapest = &p
+	}
+	if s := r.LowestStay(); s != nil {
+		e.CheapestUSD = s.PricePerNight
+		p := stayPick(*s)
+		e.Cheapest = &p
+	}
+	for i, f := range r.Flights {
+		if i < maxPicks {
+			e.Top = append(e.Top, flightPick(f))
+		}
+	}
+	for i, s := range r.Stays {
+		if i < maxPicks {
+			e.Top = append(e.Top, stayPick(s))
+		}
+	}
+
+	h := sha256.Sum256([]byte(e.CreatedAt.Format(time.RFC3339Nano) + kind + string(query)))
+	e.ID = "h_" + hex.EncodeToString(h[:])[:10]
+	return e
+}
+
+func (s *Store) Append(e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appendLocked(e)
+}
+
+func (s *Store) appendLocked(e Entry) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		return err
+	}
+	if key := observationKey(e); key != "" && s.recent != nil && e.CreatedAt.After(s.recent[key]) {
+		s.recent[key] = e.CreatedAt
+	}
+	return nil
+}
+
+// List returns entries newest first. A limit of 0 returns everything.
+func (s *Store) List(limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue // skip corrupt lines rather than losing the whole log
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// Get returns the entry with the given ID.
+func (s *Store) Get(id string) (*Entry, error) {
+	entries, err := s.List(0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
@@ -0,0 +1,42 @@
+package history
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestGet(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := FromResult(KindLookup, &core.SearchResult{
+		Query: core.StayLookupRequest{PropertyID: "EXP12345", CheckIn: "2026-06-12", CheckOut: "2026-06-20"},
+		Stays: []core.StayOffer{{ID: "s_1", Source: "expedia", PricePerNight: 112.5, TotalPriceUSD: 900}},
+	})
+	if err := store.Append(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Get(entry.ID)
+	if err != nil || got.Kind != KindLookup || got.CheapestUSD != 112.5 || string(got.Query) != string(entry.Query) {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+	if _, err := store.Get("h_missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing entry error = %v", err)
+	}
+}
+
+func TestFromResult_CheapestOfEveryOffer(t *testing.T) {
+	cheapest := core.FlightOffer{ID: "f_low", Source: "duffel", PriceUSD: 410}
+	e := FromResult(KindFlights, &core.SearchResult{
+		Query:          core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"},
+		Flights:        []core.FlightOffer{{ID: "f_best", Source: "duffel", PriceUSD: 520}},
+		CheapestFlight: &cheapest,
+	})
+	if e.CheapestUSD != 410 || e.Cheapest == nil || e.Cheapest.OfferID != "f_low" {
+		t.Errorf("cheapest = %v %+v, want the cheaper offer maxResults left out", e.CheapestUSD, e.Cheapest)
+	}
+}
@@ -0,0 +1,159 @@
+// Package holidays serves the major public holidays of the countries in
+// the destinations guide, from a calendar embedded in the binary. Prices
+// spike around them, so searches note the ones their dates cross.
+package holidays
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed holidays.json
+var rawDataset []byte
+
+// Holiday is one year's occurrence of a holiday, which may last several
+// days, such as Golden Week.
+type Holiday struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	// Start and End are its first and last days, YYYY-MM-DD.
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// rule places a holiday in a year: on a fixed month and day, a number of
+// days from Easter Sunday, or on the first given weekday on or after a
+// month and day, as the fourth Thursday of November is the first on or
+// after the 22nd. Days is how long it lasts, one when unset.
+type rule struct {
+	Name      string `json:"name"`
+	Date      string `json:"date,omitempty"`
+	Easter    *int   `json:"easter,omitempty"`
+	Weekday   string `json:"weekday,omitempty"`
+	OnOrAfter string `json:"onOrAfter,omitempty"`
+	Days      int    `json:"days,omitempty"`
+}
+
+var ErrNotFound = errors.New("no holiday calendar")
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+var (
+	loadOnce  sync.Once
+	byCountry map[string][]rule
+	loadErr   error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &byCountry); err != nil {
+			loadErr = fmt.Errorf("decode embedded holidays: %w", err)
+		}
+	})
+	return loadErr
+}
+
+// Between returns the holidays of country, an ISO 3166 country code, that
+// fall on any day from from to to, both included, in date order. Countries
+// without a calendar are ErrNotFound.
+func Between(country string, from, to time.Time) ([]Holiday, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	code := strings.ToUpper(strings.TrimSpace(country))
+	rules, ok := byCountry[code]
+	if !ok {
+		return nil, fmt.Errorf("%w for %q (covered: %s)", ErrNotFound, country, strings.Join(Countries(), ", "))
+	}
+	from, to = day(from), day(to)
+	var out []Holiday
+	// A holiday starting late the year before, such as Japan's New Year
+	// holidays, may run into from's year.
+	for year := from.Year() - 1; year <= to.Year(); year++ {
+		for _, r := range rules {
+			start, err := r.in(year)
+			if err != nil {
+				return nil, fmt.Errorf("holiday %q in %s: %w", r.Name, code, err)
+			}
+			end := start.AddDate(0, 0, max(r.Days, 1)-1)
+			if end.Before(from) || start.After(to) {
+				continue
+			}
+			out = append(out, Holiday{Name: r.Name, Country: code, Start: start.Format(time.DateOnly), End: end.Format(time.DateOnly)})
+		}
+	}
+	slices.SortStableFunc(out, func(a, b Holiday) int { return strings.Compare(a.Start, b.Start) })
+	return out, nil
+}
+
+// Countries lists the codes of the countries with a calendar, sorted.
+func Countries() []string {
+	if err := load(); err != nil {
+		return nil
+	}
+	var codes []string
+	for code := range byCountry {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// in returns the day r starts in year.
+func (r rule) in(year int) (time.Time, error) {
+	switch {
+	case r.Easter != nil:
+		return easter(year).AddDate(0, 0, *r.Easter), nil
+	case r.Weekday != "":
+		weekday, ok := weekdays[r.Weekday]
+		if !ok {
+			return time.Time{}, fmt.Errorf("unknown weekday %q", r.Weekday)
+		}
+		d, err := monthDay(year, r.OnOrAfter)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return d.AddDate(0, 0, (int(weekday)-int(d.Weekday())+7)%7), nil
+	default:
+		return monthDay(year, r.Date)
+	}
+}
+
+// monthDay reads an MM-DD date in year.
+func monthDay(year int, s string) (time.Time, error) {
+	d, err := time.Parse("01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q: %w", s, err)
+	}
+	return time.Date(year, d.Month(), d.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// easter returns Easter Sunday in the Gregorian calendar, by the
+// anonymous Gregorian algorithm.
+func easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	dayOfMonth := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), dayOfMonth, 0, 0, 0, 0, time.UTC)
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,79 @@
+package holidays
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/destinations"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestBetween(t *testing.T) {
+	for _, tc := range []struct {
+		country, from, to string
+		want              []Holiday
+	}{
+		{"FR", "2026-07-10", "2026-07-17", []Holiday{{"Bastille Day", "FR", "2026-07-14", "2026-07-14"}}},
+		{"fr", "2026-07-15", "2026-07-20", nil},
+		// Easter Sunday 2026 is April 5th.
+		{"GB", "2026-04-01", "2026-04-07", []Holiday{
+			{"Good Friday", "GB", "2026-04-03", "2026-04-03"},
+			{"Easter Monday", "GB", "2026-04-06", "2026-04-06"},
+		}},
+		{"US", "2026-11-27", "2026-11-30", []Holiday{{"Thanksgiving", "US", "2026-11-26", "2026-11-29"}}},
+		{"US", "2027-05-20", "2027-06-01", []Holiday{{"Memorial Day", "US", "2027-05-31", "2027-05-31"}}},
+		// Japan's New Year holidays start the year before.
+		{"JP", "2027-01-02", "2027-01-05", []Holiday{{"New Year Holidays", "JP", "2026-12-29", "2027-01-03"}}},
+	} {
+		got, err := Between(tc.country, date(tc.from), date(tc.to))
+		if err != nil {
+			t.Fatalf("Between(%s, %s, %s): %v", tc.country, tc.from, tc.to, err)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("Between(%s, %s, %s) = %v, want %v", tc.country, tc.from, tc.to, got, tc.want)
+		}
+	}
+	if _, err := Between("KP", date("2026-01-01"), date("2026-12-31")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Between(KP): err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEaster(t *testing.T) {
+	for year, want := range map[int]string{2024: "2024-03-31", 2025: "2025-04-20", 2026: "2026-04-05", 2027: "2027-03-28", 2038: "2038-04-25"} {
+		if got := easter(year).Format(time.DateOnly); got != want {
+			t.Errorf("easter(%d) = %s, want %s", year, got, want)
+		}
+	}
+}
+
+// Every rule places its holiday, and every country in the destinations
+// guide has a calendar.
+func TestCoversDestinations(t *testing.T) {
+	for _, code := range Countries() {
+		if _, err := Between(code, date("2026-01-01"), date("2026-12-31")); err != nil {
+			t.Error(err)
+		}
+	}
+	names, err := destinations.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		d, err := destinations.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Contains(Countries(), d.CountryCode) {
+			t.Errorf("%s's country %s has no calendar", name, d.CountryCode)
+		}
+	}
+}
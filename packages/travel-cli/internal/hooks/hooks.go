@@ -0,0 +1,68 @@
+// Package hooks runs user-supplied commands that post-process search
+// results, so users can enrich or filter output without upstream changes.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a hook when the config sets none, so a hung script
+// can't stall the CLI forever.
+const DefaultTimeout = 30 * time.Second
+
+// waitDelay is how long a timed-out hook's output is waited for after the
+// shell is killed, since a process it started may keep stdout open.
+const waitDelay = time.Second
+
+// Run executes command through the system shell with doc encoded as JSON
+// on stdin, and returns the JSON document the command writes to stdout.
+// A non-zero exit, a timeout, or output that is not valid JSON is an error.
+func Run(ctx context.Context, command string, timeout time.Duration, doc interface{}) (json.RawMessage, error) {
+	input, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode hook input: %w", err)
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, command)
+	cmd.WaitDelay = waitDelay
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("hook %q timed out after %s", command, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("hook %q: %w: %s", command, err, msg)
+		}
+		return nil, fmt.Errorf("hook %q: %w", command, err)
+	}
+
+	out := bytes.TrimSpace(stdout.Bytes())
+	if !json.Valid(out) {
+		return nil, fmt.Errorf("hook %q did not write a JSON document to stdout", command)
+	}
+	return json.RawMessage(out), nil
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
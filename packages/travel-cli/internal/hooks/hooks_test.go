@@ -0,0 +1,49 @@
+//go:build !windows
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunTransformsDocument(t *testing.T) {
+	// sed stands in for a user's enrichment script.
+	out, err := Run(context.Background(), `sed 's/"city":"Paris"/"city":"Paris","enriched":true/'`, 0, map[string]string{"city": "Paris"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["enriched"] != true || got["city"] != "Paris" {
+		t.Errorf("hook output = %s", out)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	cases := []struct {
+		name, command, want string
+		timeout             time.Duration
+	}{
+		{"non-zero exit", "echo nope >&2; exit 3", "nope", 0},
+		{"not json", "echo hello", "did not write a JSON document", 0},
+		{"timeout", "sleep 5", "timed out", 50 * time.Millisecond},
+		// The shell is killed but the sleep it started keeps stdout open.
+		{"timeout with a child", "sleep 5; echo {}", "timed out", 50 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		start := time.Now()
+		_, err := Run(context.Background(), tc.command, tc.timeout, map[string]int{"n": 1})
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want it to mention %q", tc.name, err, tc.want)
+		}
+		if elapsed := time.Since(start); elapsed > tc.timeout+waitDelay+time.Second {
+			t.Errorf("%s: returned after %s", tc.name, elapsed)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package httpclient builds the HTTP client live adapters share: bounded
+// timeouts, pooled connections, and retries with jittered exponential
+// backoff when a provider answers 429 or 5xx, so one flaky response
+// doesn't fail a whole search.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/cassette"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+const (
+	// Timeout bounds a request, retries included.
+	Timeout = 15 * time.Second
+	// Retries is how many times a failed request is sent again.
+	Retries = 2
+	// baseDelay is the backoff before the first retry; each later retry
+	// waits up to twice as long as the one before.
+	baseDelay = 250 * time.Millisecond
+	// maxRetryAfter is the longest Retry-After worth waiting for within a
+	// search. A provider asking for longer gets its response back, so a
+	// maintenance window is reported rather than waited out.
+	maxRetryAfter = 5 * time.Second
+)
+
+// New returns a client over a transport of its own, unmetered; see
+// Client.
+func New() *http.Client {
+	return Client(Transport(), nil, "")
+}
+
+// Transport returns a transport over pooled connections that logs each
+// request, writes it to a transcript once SetTranscriptDir is called, and
+// records or replays traffic as cassette.Wrap decides. Clients built on
+// one transport share its connections.
+func Transport() http.RoundTripper {
+	return logging.Transport(Transcripts(cassette.Wrap(&http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConnsPerHost: 4,
+		IdleConnTimeout:     90 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+		ForceAttemptHTTP2:   true,
+	})))
+}
+
+// Client returns a client over base that retries through Retry and, with
+// m set, counts each attempt against provider through Metering, so every
+// request, retries included, spends the provider's quota.
+func Client(base http.RoundTripper, m Meter, provider string) *http.Client {
+	return &http.Client{
+		Timeout:   Timeout,
+		Transport: Retry(Metering(base, m, provider), Retries),
+	}
+}
+
+// Retry sends requests through next, and up to retries more times when
+// the response is 429 or 5xx, or when a GET or HEAD fails to get one.
+// It waits as long as Retry-After says, if that's at most maxRetryAfter,
+// or else an exponentially growing, jittered delay. Requests whose body
+// can't be rewound (no GetBody) are sent once.
+func Retry(next http.RoundTripper, retries int) http.RoundTripper {
+	return &retryTransport{next: next, retries: retries, sleep: sleep}
+}
+
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(req.Context())
+			r.Body = body
+		}
+		resp, err := t.next.RoundTrip(r)
+		delay, retry := t.backoff(req, resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		logging.Logger().Debug("retrying request", "url", req.URL.Host+req.URL.Path, "attempt", attempt+1, "delay", delay.Round(time.Millisecond))
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff decides whether attempt is retried and after how long.
+func (t *retryTransport) backoff(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= t.retries || req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	if err != nil {
+		idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+		return jitter(attempt), idempotent && !errors.Is(err, context.Canceled)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return 0, false
+	}
+	if at := RetryAfter(resp.Header.Get("Retry-After"), time.Now()); !at.IsZero() {
+		wait := time.Until(at)
+		return max(wait, 0), wait <= maxRetryAfter
+	}
+	return jitter(attempt), true
+}
+
+// jitter is the backoff before retry attempt+1: a random delay between
+// half and all of baseDelay doubled attempt times.
+func jitter(attempt int) time.Duration {
+	d := baseDelay << attempt
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date. It returns the zero time if the header is
+// absent or malformed.
+func RetryAfter(header string, now time.Time) time.Time {
+	if header == "" {
+		return time.Time{}
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		return t
+	}
+	return time.Time{}
+}
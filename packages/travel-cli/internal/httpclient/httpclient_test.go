@@ -0,0 +1,113 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryBacksOffOnTransientStatuses(t *testing.T) {
+	var bodies []string
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(statuses[len(bodies)-1])
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	rt := &retryTransport{next: http.DefaultTransport, retries: 2, sleep: func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"slices":[]}`))
+	resp, err := rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("RoundTrip = %v, %v", resp, err)
+	}
+	if len(bodies) != 3 || bodies[2] != `{"slices":[]}` {
+		t.Errorf("server got bodies %q", bodies)
+	}
+	if len(waits) != 2 || waits[0] < baseDelay/2 || waits[0] > baseDelay || waits[1] < baseDelay || waits[1] > 2*baseDelay {
+		t.Errorf("waits = %v", waits)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	calls := 0
+	retryAfter := "1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", retryAfter)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	rt := &retryTransport{next: http.DefaultTransport, retries: 1, sleep: func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if resp, err := rt.RoundTrip(req); err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 2 {
+		t.Fatalf("RoundTrip = %v, %v after %d calls", resp, err, calls)
+	}
+	if len(waits) != 1 || waits[0] < 900*time.Millisecond || waits[0] > time.Second {
+		t.Errorf("waits = %v", waits)
+	}
+
+	// A maintenance window longer than a search is reported, not waited for.
+	calls, retryAfter = 0, "3600"
+	if resp, err := rt.RoundTrip(req); err != nil || resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("RoundTrip = %v, %v after %d calls", resp, err, calls)
+	}
+}
+
+func TestRetryStopsWhenCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	rt := &retryTransport{next: http.DefaultTransport, retries: 3, sleep: func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleep(ctx, d)
+	}}
+	if _, err := rt.RoundTrip(req); err != context.Canceled {
+		t.Errorf("RoundTrip error = %v", err)
+	}
+}
+
+func TestMeteringCountsRetries(t *testing.T) {
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if served++; served == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	var calls []string
+	meter := func(_ context.Context, provider string) error {
+		calls = append(calls, provider)
+		return nil
+	}
+
+	rt := &retryTransport{next: Metering(http.DefaultTransport, meter, "duffel"), retries: 2, sleep: func(context.Context, time.Duration) error { return nil }}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("RoundTrip = %v, %v", resp, err)
+	}
+	if len(calls) != 2 || calls[0] != "duffel" {
+		t.Errorf("metered %q, want duffel twice: the request and its retry", calls)
+	}
+}
@@ -0,0 +1,33 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// Meter is called before each request a metered client sends, retries
+// included, with the provider the client belongs to. It may wait for the
+// provider's quota; an error stops the request.
+type Meter func(ctx context.Context, provider string) error
+
+// Metering calls m with provider before each request is passed to next.
+// A nil m meters nothing.
+func Metering(next http.RoundTripper, m Meter, provider string) http.RoundTripper {
+	if m == nil {
+		return next
+	}
+	return meterTransport{next: next, meter: m, provider: provider}
+}
+
+type meterTransport struct {
+	next     http.RoundTripper
+	meter    Meter
+	provider string
+}
+
+func (t meterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.meter(req.Context(), t.provider); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
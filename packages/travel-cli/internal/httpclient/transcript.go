@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/cassette"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// maxTranscriptBody is how much of each body a transcript keeps.
+const maxTranscriptBody = 4 << 10
+
+var (
+	transcriptMu  sync.Mutex
+	transcriptDir string
+	transcriptSeq atomic.Int64
+)
+
+// SetTranscriptDir makes clients from New write a transcript of every
+// request they send to dir, one file per attempt. An empty dir stops
+// them.
+func SetTranscriptDir(dir string) {
+	transcriptMu.Lock()
+	defer transcriptMu.Unlock()
+	transcriptDir = dir
+}
+
+func currentTranscriptDir() string {
+	transcriptMu.Lock()
+	defer transcriptMu.Unlock()
+	return transcriptDir
+}
+
+// Transcripts writes each request sent through next, and its response or
+// error, to the directory set by SetTranscriptDir. Transcripts are
+// redacted like cassettes, give the time taken, and cut bodies off at
+// 4 KiB.
+func Transcripts(next http.RoundTripper) http.RoundTripper {
+	return transcriptTransport{next: next}
+}
+
+type transcriptTransport struct {
+	next http.RoundTripper
+}
+
+func (t transcriptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	dir := currentTranscriptDir()
+	if dir == "" {
+		return t.next.RoundTrip(req)
+	}
+	body, err := copyBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s, %s\n\n", start.UTC().Format(time.RFC3339Nano), elapsed.Round(time.Millisecond))
+	r := cassette.RedactRequest(req, body)
+	fmt.Fprintf(&b, "%s %s\n", r.Method, r.URL)
+	writeTranscriptPart(&b, r.Header, r.Body)
+	if err != nil {
+		fmt.Fprintf(&b, "\nerror: %v\n", err)
+	} else {
+		respBody, readErr := copyBody(&resp.Body)
+		if readErr != nil {
+			return nil, readErr
+		}
+		fmt.Fprintf(&b, "\n%s %s\n", resp.Proto, resp.Status)
+		writeTranscriptPart(&b, cassette.RedactHeader(resp.Header), cassette.RedactBody(resp.Header.Get("Content-Type"), respBody))
+	}
+
+	name := fmt.Sprintf("%s-%04d-%s.http", start.UTC().Format("20060102T150405.000"), transcriptSeq.Add(1), strings.NewReplacer(":", "_", "/", "_").Replace(req.URL.Host))
+	if werr := writeTranscript(filepath.Join(dir, name), b.String()); werr != nil {
+		logging.Logger().Warn("http transcript not written", "error", werr)
+	}
+	return resp, err
+}
+
+func writeTranscriptPart(b *strings.Builder, h http.Header, body string) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range h[name] {
+			fmt.Fprintf(b, "%s: %s\n", name, v)
+		}
+	}
+	if body == "" {
+		return
+	}
+	if len(body) > maxTranscriptBody {
+		body = fmt.Sprintf("%s\n[... %d more bytes]", body[:maxTranscriptBody], len(body)-maxTranscriptBody)
+	}
+	fmt.Fprintf(b, "\n%s\n", body)
+}
+
+func writeTranscript(path, text string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0o600)
+}
+
+// copyBody reads *body and replaces it with a copy, so it can still be
+// sent or read by the caller.
+func copyBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
@@ -0,0 +1,57 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTranscripts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = w.Write([]byte(`{"data":"` + strings.Repeat("x", 2*maxTranscriptBody) + `"}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transcripts(http.DefaultTransport)}
+	send := func() {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/offers?api_key=k123", strings.NewReader(`{"token":"t456","from":"YUL"}`))
+		req.Header.Set("Authorization", "Bearer s789")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	send()
+	dir := t.TempDir()
+	SetTranscriptDir(dir)
+	defer SetTranscriptDir("")
+	send()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.http"))
+	if len(files) != 1 {
+		t.Fatalf("transcripts = %v, want one once the dir is set", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(data)
+	for _, secret := range []string{"k123", "t456", "s789", "abc"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("transcript leaks %q:\n%s", secret, text)
+		}
+	}
+	for _, want := range []string{"POST http://", "/offers?api_key=REDACTED", `"from":"YUL"`, "HTTP/1.1 200 OK", "Set-Cookie: REDACTED", "more bytes]"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("transcript lacks %q:\n%.600s", want, text)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+const (
+	KindFlights = "flights"
+	KindStays   = "stays"
+)
+
+// Job is a search executed asynchronously. Its state is persisted so
+// clients can keep polling across server restarts.
+type Job struct {
+	ID         string             `json:"id"`
+	Kind       string             `json:"kind"`
+	Status     Status             `json:"status"`
+	Request    json.RawMessage    `json:"request"`
+	Result     *core.SearchResult `json:"result,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	StartedAt  time.Time          `json:"startedAt,omitzero"`
+	FinishedAt time.Time          `json:"finishedAt,omitzero"`
+}
+
+func (j *Job) Done() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+var ErrNotFound = errors.New("job not found")
+
+// retention bounds how long a job is kept after it was last updated.
+// Clients poll for a result within minutes of submitting it, so anything
+// older is only taking up space.
+const retention = 7 * 24 * time.Hour
+
+// activeExt marks a job that is queued or running, so FailInterrupted
+// finds those without reading every job.
+const activeExt = ".active"
+
+var validID = regexp.MustCompile(`^j_[0-9a-f]{16}$`)
+
+// Store keeps one JSON file per job.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel", "jobs"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create jobs dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+func NewID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "j_" + hex.EncodeToString(b)
+}
+
+func (s *Store) Put(j *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	// Write then rename so pollers never read a half-written job.
+	tmp := s.path(j.ID) + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path(j.ID)); err != nil {
+		return err
+	}
+	if j.Done() {
+		if err := os.Remove(s.marker(j.ID)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(s.marker(j.ID), nil, 0o644)
+}
+
+func (s *Store) Get(id string) (*Job, error) {
+	if !validID.MatchString(id) {
+		return nil, ErrNotFound
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var j Job
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("decode job %s: %w", id, err)
+	}
+	return &j, nil
+}
+
+// FailInterrupted marks jobs that were queued or running when the previous
+// server stopped as failed, since nothing will ever finish them.
+func (s *Store) FailInterrupted() error {
+	markers, err := filepath.Glob(filepath.Join(s.dir, "*"+activeExt))
+	if err != nil {
+		return err
+	}
+	for _, m := range markers {
+		j, err := s.Get(strings.TrimSuffix(filepath.Base(m), activeExt))
+		if errors.Is(err, ErrNotFound) {
+			_ = os.Remove(m)
+			continue
+		}
+		if err != nil || j.Done() {
+			continue
+		}
+		j.Status = StatusFailed
+		j.Error = "interrupted by server restart"
+		j.FinishedAt = time.Now().UTC()
+		if err := s.Put(j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Prune deletes finished jobs last updated more than retention ago.
+func (s *Store) Prune() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-retention)
+	for _, e := range entries {
+		name := e.Name()
+		if filepath.Ext(name) == activeExt {
+			continue
+		}
+		if id, ok := strings.CutSuffix(name, ".json"); ok {
+			if _, err := os.Stat(s.marker(id)); err == nil {
+				continue
+			}
+		}
+		info, err := e.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		_ = os.Remove(filepath.Join(s.dir, name))
+	}
+	return nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *Store) marker(id string) string {
+	return filepath.Join(s.dir, id+activeExt)
+}
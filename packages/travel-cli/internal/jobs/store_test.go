@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFailInterrupted(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	running := &Job{ID: NewID(), Kind: KindFlights, Status: StatusRunning}
+	done := &Job{ID: NewID(), Kind: KindStays, Status: StatusSucceeded}
+	for _, j := range []*Job{running, done} {
+		if err := store.Put(j); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.FailInterrupted(); err != nil {
+		t.Fatal(err)
+	}
+	if j, err := store.Get(running.ID); err != nil || j.Status != StatusFailed || j.FinishedAt.IsZero() {
+		t.Errorf("running job = %+v, %v, want it failed", j, err)
+	}
+	if j, err := store.Get(done.ID); err != nil || j.Status != StatusSucceeded {
+		t.Errorf("finished job = %+v, %v, want it untouched", j, err)
+	}
+	if _, err := os.Stat(store.marker(running.ID)); !os.IsNotExist(err) {
+		t.Errorf("failed job still marked active: %v", err)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := &Job{ID: NewID(), Status: StatusSucceeded}
+	recent := &Job{ID: NewID(), Status: StatusFailed}
+	queued := &Job{ID: NewID(), Status: StatusQueued}
+	for _, j := range []*Job{old, recent, queued} {
+		if err := store.Put(j); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stale := time.Now().Add(-retention - time.Hour)
+	for _, id := range []string{old.ID, queued.ID} {
+		if err := os.Chtimes(store.path(id), stale, stale); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(old.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("old job: err = %v, want it pruned", err)
+	}
+	for _, id := range []string{recent.ID, queued.ID} {
+		if _, err := store.Get(id); err != nil {
+			t.Errorf("job %s: %v, want it kept", id, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Package logging holds the CLI's leveled diagnostics: provider timings,
+// HTTP summaries, and debug detail written to stderr, apart from the
+// command output on stdout.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LevelOff discards every record, for --quiet and TRAVEL_LOG=off.
+const LevelOff = slog.LevelError + 4
+
+// logger discards records until Setup, so packages can log freely when
+// embedded in programs that never configure it.
+var logger = slog.New(slog.DiscardHandler)
+
+// ParseLevel parses debug, info, warn, error, or off. Empty is warn.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	case "off":
+		return LevelOff, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (use debug, info, warn, error, or off)", name)
+}
+
+// Setup writes records at level and above to w as logfmt lines.
+func Setup(w io.Writer, level slog.Level) {
+	if level >= LevelOff {
+		logger = slog.New(slog.DiscardHandler)
+		return
+	}
+	logger = slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// Logger returns the logger configured by Setup.
+func Logger() *slog.Logger {
+	return logger
+}
+
+// Transport logs a summary of each request next sends at info level:
+// method, host and path, status, and time taken. Query strings are left
+// out since providers may take keys in them.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return transport{next: next}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []interface{}{
+		"method", req.Method,
+		"url", req.URL.Host + req.URL.Path,
+		"elapsed", time.Since(start).Round(time.Millisecond),
+	}
+	if err != nil {
+		logger.Info("http request failed", append(attrs, "error", err)...)
+		return nil, err
+	}
+	logger.Info("http request", append(attrs, "status", resp.StatusCode)...)
+	logger.Debug("http response", "url", req.URL.Host+req.URL.Path, "contentLength", resp.ContentLength, "contentType", resp.Header.Get("Content-Type"))
+	return resp, nil
+}
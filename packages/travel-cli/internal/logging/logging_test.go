@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]slog.Level{
+		"":      slog.LevelWarn,
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"error": slog.LevelError,
+		"off":   LevelOff,
+	}
+	for name, want := range cases {
+		got, err := ParseLevel(name)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel accepted an unknown level")
+	}
+}
+
+func TestSetupFiltersByLevel(t *testing.T) {
+	defer Setup(nil, LevelOff)
+
+	var buf bytes.Buffer
+	Setup(&buf, slog.LevelInfo)
+	Logger().Debug("hidden")
+	Logger().Info("provider completed", "provider", "duffel")
+	if out := buf.String(); strings.Contains(out, "hidden") || !strings.Contains(out, "provider=duffel") {
+		t.Errorf("info log = %q", out)
+	}
+
+	buf.Reset()
+	Setup(&buf, LevelOff)
+	Logger().Error("dropped")
+	if buf.Len() != 0 {
+		t.Errorf("off still logged %q", buf.String())
+	}
+}
+
+func TestTransportLogsSummary(t *testing.T) {
+	defer Setup(nil, LevelOff)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	Setup(&buf, slog.LevelInfo)
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+	resp, err := client.Get(srv.URL + "/offers?api_key=secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	out := buf.String()
+	for _, want := range []string{"method=GET", "/offers", "status=418", "elapsed="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q missing %q", out, want)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("log leaked the query string: %q", out)
+	}
+}
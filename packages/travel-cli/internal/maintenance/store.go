@@ -0,0 +1,124 @@
+// Package maintenance remembers providers detected to be down for
+// maintenance, so later commands skip them until their window ends.
+package maintenance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+var ErrNotFound = errors.New("provider not in maintenance")
+
+// Store keeps detected windows in a single JSON file keyed by provider.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create maintenance dir: %w", err)
+	}
+	return &Store{path: filepath.Join(dir, "maintenance.json")}, nil
+}
+
+// Record stores windows, replacing any earlier window for the same
+// provider, and drops windows that have ended.
+func (s *Store) Record(windows ...core.MaintenanceWindow) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	for _, w := range windows {
+		all[w.Provider] = w
+	}
+	return s.save(all)
+}
+
+// Active returns the windows in effect at now, ordered by provider.
+func (s *Store) Active(now time.Time) ([]core.MaintenanceWindow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []core.MaintenanceWindow
+	for _, w := range all {
+		if w.ActiveAt(now) {
+			out = append(out, w)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out, nil
+}
+
+// Clear puts a provider back into routing before its window ends.
+func (s *Store) Clear(provider string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[provider]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, provider)
+	}
+	delete(all, provider)
+	return s.save(all)
+}
+
+func (s *Store) load() (map[string]core.MaintenanceWindow, error) {
+	all := map[string]core.MaintenanceWindow{}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode maintenance: %w", err)
+	}
+	return all, nil
+}
+
+func (s *Store) save(all map[string]core.MaintenanceWindow) error {
+	now := time.Now()
+	for name, w := range all {
+		if !w.Until.IsZero() && !now.Before(w.Until) {
+			delete(all, name)
+		}
+	}
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,42 @@
+package maintenance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestRecordActiveClear(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	err = store.Record(
+		core.MaintenanceWindow{Provider: "duffel", Until: now.Add(time.Hour), Source: core.MaintenanceDetected, StatusCode: 503},
+		core.MaintenanceWindow{Provider: "expedia", Until: now.Add(-time.Minute), Source: core.MaintenanceDetected},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	active, err := store.Active(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 || active[0].Provider != "duffel" || active[0].StatusCode != 503 {
+		t.Fatalf("Active = %+v", active)
+	}
+	if active, _ := store.Active(now.Add(2 * time.Hour)); len(active) != 0 {
+		t.Errorf("window still active after it ended: %+v", active)
+	}
+
+	if err := store.Clear("duffel"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Clear("duffel"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Clear = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,217 @@
+// Package metrics counts searches and provider calls in long-running
+// modes (travel daemon and travel serve) and serves them in the
+// Prometheus text format, so deployments can be monitored.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the provider
+// latency histogram. The last is the default provider timeout.
+var latencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15}
+
+// Registry holds the metrics. Its methods are safe for concurrent use.
+type Registry struct {
+	mu        sync.Mutex
+	searches  map[[2]string]float64 // kind, "true" if served from cache
+	calls     map[[3]string]float64 // search, provider, outcome
+	latencies map[string]*histogram // by provider
+	state     func() []core.ProviderInfo
+}
+
+type histogram struct {
+	counts []float64 // per bucket, not cumulative
+	sum    float64
+	count  float64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		searches:  map[[2]string]float64{},
+		calls:     map[[3]string]float64{},
+		latencies: map[string]*histogram{},
+	}
+}
+
+// WithProviderState reports each provider's circuit as open while state
+// says it is in maintenance and routing skips it. state is called on
+// every scrape.
+func (r *Registry) WithProviderState(state func() []core.ProviderInfo) *Registry {
+	r.state = state
+	return r
+}
+
+// Progress returns a progress function for core.Orchestrator.WithProgress
+// that counts provider calls and their latency, then passes each event on
+// to next, if any.
+func (r *Registry) Progress(next func(core.ProgressEvent)) func(core.ProgressEvent) {
+	return func(e core.ProgressEvent) {
+		if e.Type == core.ProgressProviderCompleted || e.Type == core.ProgressProviderFailed {
+			r.observeProvider(e)
+		}
+		if next != nil {
+			next(e)
+		}
+	}
+}
+
+func (r *Registry) observeProvider(e core.ProgressEvent) {
+	outcome := "ok"
+	switch {
+	case e.Type == core.ProgressProviderCompleted:
+	case e.Reason == "timeout":
+		outcome = "timeout"
+	default:
+		outcome = "error"
+	}
+	seconds := float64(e.ElapsedMs) / 1000
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[[3]string{e.Search, e.Provider, outcome}]++
+	h := r.latencies[e.Provider]
+	if h == nil {
+		h = &histogram{counts: make([]float64, len(latencyBuckets))}
+		r.latencies[e.Provider] = h
+	}
+	for i, le := range latencyBuckets {
+		if seconds <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// Searcher runs searches, as core.Orchestrator does.
+type Searcher interface {
+	SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error)
+	SearchStays(req core.StaySearchRequest) (*core.SearchResult, error)
+}
+
+// Searcher wraps s so each search it runs is counted, by kind and
+// whether the result cache answered it.
+func (r *Registry) Searcher(s Searcher) Searcher {
+	return countingSearcher{next: s, reg: r}
+}
+
+type countingSearcher struct {
+	next Searcher
+	reg  *Registry
+}
+
+func (c countingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	result, err := c.next.SearchFlights(req)
+	c.reg.countSearch("flights", result)
+	return result, err
+}
+
+func (c countingSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	result, err := c.next.SearchStays(req)
+	c.reg.countSearch("stays", result)
+	return result, err
+}
+
+func (r *Registry) countSearch(kind string, result *core.SearchResult) {
+	cached := result != nil && result.FromCache
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.searches[[2]string{kind, strconv.FormatBool(cached)}]++
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.Write(w)
+	})
+}
+
+// Write writes the metrics to w in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	var infos []core.ProviderInfo
+	if r.state != nil {
+		infos = r.state()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+
+	header(&b, "travel_searches_total", "counter", "Searches run, by kind and whether the result cache answered them.")
+	for _, k := range sortedKeys(r.searches, func(k [2]string) string { return k[0] + k[1] }) {
+		fmt.Fprintf(&b, "travel_searches_total{kind=%q,cached=%q} %s\n", k[0], k[1], number(r.searches[k]))
+	}
+
+	header(&b, "travel_cache_hit_ratio", "gauge", "Share of searches answered from the result cache.")
+	var hits, total float64
+	for k, n := range r.searches {
+		total += n
+		if k[1] == "true" {
+			hits += n
+		}
+	}
+	ratio := 0.0
+	if total > 0 {
+		ratio = hits / total
+	}
+	fmt.Fprintf(&b, "travel_cache_hit_ratio %s\n", number(ratio))
+
+	header(&b, "travel_provider_calls_total", "counter", "Provider searches, by search kind, provider, and outcome (ok, error, timeout).")
+	for _, k := range sortedKeys(r.calls, func(k [3]string) string { return k[0] + "\x00" + k[1] + "\x00" + k[2] }) {
+		fmt.Fprintf(&b, "travel_provider_calls_total{search=%q,provider=%q,outcome=%q} %s\n", k[0], k[1], k[2], number(r.calls[k]))
+	}
+
+	header(&b, "travel_provider_latency_seconds", "histogram", "Time providers took to answer or fail.")
+	for _, provider := range sortedKeys(r.latencies, func(k string) string { return k }) {
+		h := r.latencies[provider]
+		var cumulative float64
+		for i, le := range latencyBuckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "travel_provider_latency_seconds_bucket{provider=%q,le=%q} %s\n", provider, number(le), number(cumulative))
+		}
+		fmt.Fprintf(&b, "travel_provider_latency_seconds_bucket{provider=%q,le=\"+Inf\"} %s\n", provider, number(h.count))
+		fmt.Fprintf(&b, "travel_provider_latency_seconds_sum{provider=%q} %s\n", provider, number(h.sum))
+		fmt.Fprintf(&b, "travel_provider_latency_seconds_count{provider=%q} %s\n", provider, number(h.count))
+	}
+
+	header(&b, "travel_provider_circuit_open", "gauge", "1 while a provider is in maintenance and left out of routing, else 0.")
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	for _, info := range infos {
+		open := 0
+		if info.Status == "maintenance" {
+			open = 1
+		}
+		fmt.Fprintf(&b, "travel_provider_circuit_open{provider=%q} %d\n", info.Name, open)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func header(b *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[K comparable, V any](m map[K]V, by func(K) string) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return by(keys[i]) < by(keys[j]) })
+	return keys
+}
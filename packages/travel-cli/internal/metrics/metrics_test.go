@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+type fakeSearcher struct{ cached bool }
+
+func (f fakeSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{FromCache: f.cached}, nil
+}
+
+func (f fakeSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{FromCache: f.cached}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry().WithProviderState(func() []core.ProviderInfo {
+		return []core.ProviderInfo{{Name: "duffel", Status: "maintenance"}, {Name: "expedia", Status: "active"}}
+	})
+
+	var passed int
+	progress := reg.Progress(func(core.ProgressEvent) { passed++ })
+	progress(core.ProgressEvent{Type: core.ProgressProviderStarted, Search: "stays", Provider: "expedia"})
+	progress(core.ProgressEvent{Type: core.ProgressProviderCompleted, Search: "stays", Provider: "expedia", ElapsedMs: 300})
+	progress(core.ProgressEvent{Type: core.ProgressProviderFailed, Search: "stays", Provider: "expedia", Reason: "timeout", ElapsedMs: 15000})
+	progress(core.ProgressEvent{Type: core.ProgressProviderFailed, Search: "flights", Provider: "duffel", Reason: "HTTP 500", ElapsedMs: 80})
+	if passed != 4 {
+		t.Errorf("%d events passed on, want 4", passed)
+	}
+
+	_, _ = reg.Searcher(fakeSearcher{}).SearchStays(core.StaySearchRequest{})
+	_, _ = reg.Searcher(fakeSearcher{}).SearchFlights(core.FlightSearchRequest{})
+	_, _ = reg.Searcher(fakeSearcher{cached: true}).SearchStays(core.StaySearchRequest{})
+	_, _ = reg.Searcher(fakeSearcher{cached: true}).SearchStays(core.StaySearchRequest{})
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`travel_searches_total{kind="flights",cached="false"} 1`,
+		`travel_searches_total{kind="stays",cached="true"} 2`,
+		"travel_cache_hit_ratio 0.5",
+		`travel_provider_calls_total{search="stays",provider="expedia",outcome="ok"} 1`,
+		`travel_provider_calls_total{search="stays",provider="expedia",outcome="timeout"} 1`,
+		`travel_provider_calls_total{search="flights",provider="duffel",outcome="error"} 1`,
+		`travel_provider_latency_seconds_bucket{provider="expedia",le="0.25"} 0`,
+		`travel_provider_latency_seconds_bucket{provider="expedia",le="0.5"} 1`,
+		`travel_provider_latency_seconds_bucket{provider="expedia",le="15"} 2`,
+		`travel_provider_latency_seconds_bucket{provider="expedia",le="+Inf"} 2`,
+		`travel_provider_latency_seconds_sum{provider="expedia"} 15.3`,
+		`travel_provider_circuit_open{provider="duffel"} 1`,
+		`travel_provider_circuit_open{provider="expedia"} 0`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Message size limits, below the services' own (3000 characters per
+// Slack text block, 4096 per Discord embed description) so item lists are
+// cut cleanly instead of rejected.
+const (
+	slackTextLimit    = 2900
+	discordTitleLimit = 256
+	discordDescLimit  = 4000
+)
+
+const (
+	chatUsername      = "beetlebot travel"
+	discordEmbedColor = 0x2eb67d
+)
+
+// Slack posts notifications to a Slack incoming webhook as a linked
+// title, the body, and one bullet per item.
+type Slack struct {
+	url    string
+	client *http.Client
+}
+
+func NewSlack(url string) *Slack {
+	return &Slack{url: url, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (s *Slack) Name() string { return "slack" }
+
+func (s *Slack) Send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, s.client, s.url, SlackMessage(n))
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type string    `json:"type"`
+	Text slackText `json:"text"`
+}
+
+type slackPayload struct {
+	// Text is the fallback shown in push notifications.
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// SlackMessage renders n in Slack's Block Kit mrkdwn.
+func SlackMessage(n Notification) interface{} {
+	head := "*" + slackLink(n.URL, n.Title) + "*"
+	if n.Body != "" {
+		head += "\n" + slackEscape(n.Body)
+	}
+	p := slackPayload{
+		Text:   slackEscape(n.Title),
+		Blocks: []slackBlock{{Type: "section", Text: slackText{Type: "mrkdwn", Text: head}}},
+	}
+	if len(n.Items) > 0 {
+		list := itemLines(n.Items, slackTextLimit, func(it Item) string {
+			return "• " + slackLink(it.URL, it.Text)
+		})
+		p.Blocks = append(p.Blocks, slackBlock{Type: "section", Text: slackText{Type: "mrkdwn", Text: list}})
+	}
+	return p
+}
+
+func slackLink(url, text string) string {
+	if url == "" {
+		return slackEscape(text)
+	}
+	return "<" + url + "|" + slackEscape(text) + ">"
+}
+
+// slackEscape escapes the characters Slack treats as control sequences.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// Discord posts notifications to a Discord webhook as an embed whose
+// title links to the offer, with items as a Markdown list.
+type Discord struct {
+	url    string
+	client *http.Client
+}
+
+func NewDiscord(url string) *Discord {
+	return &Discord{url: url, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (d *Discord) Name() string { return "discord" }
+
+func (d *Discord) Send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, d.client, d.url, DiscordMessage(n))
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Color       int    `json:"color"`
+}
+
+type discordPayload struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+// DiscordMessage renders n as a Discord embed.
+func DiscordMessage(n Notification) interface{} {
+	e := discordEmbed{Title: truncate(n.Title, discordTitleLimit), URL: n.URL, Color: discordEmbedColor}
+	if !n.Time.IsZero() {
+		e.Timestamp = n.Time.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	desc := discordEscape(n.Body)
+	if len(n.Items) > 0 {
+		list := itemLines(n.Items, discordDescLimit-len(desc), func(it Item) string {
+			if it.URL == "" {
+				return "- " + discordEscape(it.Text)
+			}
+			return "- [" + discordEscape(it.Text) + "](" + it.URL + ")"
+		})
+		desc = strings.TrimSpace(desc + "\n\n" + list)
+	}
+	e.Description = desc
+	return discordPayload{Username: chatUsername, Embeds: []discordEmbed{e}}
+}
+
+// discordEscape keeps prices and names from being read as Markdown.
+func discordEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "[", `\[`, "]", `\]`).Replace(s)
+}
+
+// itemLines formats items one per line, keeping the list under limit
+// characters and noting how many items were left out.
+func itemLines(items []Item, limit int, format func(Item) string) string {
+	var b strings.Builder
+	for i, it := range items {
+		line := format(it)
+		need := len(line) + 1
+		if rest := len(items) - i - 1; rest > 0 {
+			need += len(moreItems(rest)) + 1
+		}
+		if b.Len()+need > limit {
+			b.WriteString(moreItems(len(items) - i))
+			break
+		}
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func moreItems(n int) string {
+	return fmt.Sprintf("…and %d more", n)
+}
+
+func truncate(s string, limit int) string {
+	r := []rune(s)
+	if len(r) <= limit {
+		return s
+	}
+	return string(r[:limit-1]) + "…"
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beetlebot-travel")
+	return do(client, req)
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestSlackMessageLinksAndEscapes(t *testing.T) {
+	n := Notification{
+		Title: "YUL → CDG under $600",
+		Body:  "$480 on A&B <Air>",
+		URL:   "https://example.com/f",
+		Items: []Item{{Text: "Paris Jun 12 to Jun 15: $90", URL: "https://example.com/s"}, {Text: "no link"}},
+	}
+	p := SlackMessage(n).(slackPayload)
+
+	if p.Text != "YUL → CDG under $600" || len(p.Blocks) != 2 {
+		t.Fatalf("payload = %+v", p)
+	}
+	if got := p.Blocks[0].Text.Text; got != "*<https://example.com/f|YUL → CDG under $600>*\n$480 on A&amp;B &lt;Air&gt;" {
+		t.Errorf("head = %q", got)
+	}
+	if got := p.Blocks[1].Text.Text; got != "• <https://example.com/s|Paris Jun 12 to Jun 15: $90>\n• no link" {
+		t.Errorf("items = %q", got)
+	}
+}
+
+func TestDiscordMessageEmbed(t *testing.T) {
+	at := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	n := Notification{Title: "Daily deals", Body: "Under *target*:", Time: at, Items: []Item{{Text: "YUL → CDG [AC]", URL: "https://example.com/f"}}}
+	p := DiscordMessage(n).(discordPayload)
+
+	e := p.Embeds[0]
+	if e.Title != "Daily deals" || e.Timestamp != "2026-06-01T08:00:00Z" {
+		t.Errorf("embed = %+v", e)
+	}
+	if e.Description != `Under \*target\*:`+"\n\n"+`- [YUL → CDG \[AC\]](https://example.com/f)` {
+		t.Errorf("description = %q", e.Description)
+	}
+}
+
+func TestItemLinesStopsAtLimit(t *testing.T) {
+	var items []Item
+	for i := range 50 {
+		items = append(items, Item{Text: fmt.Sprintf("deal %02d", i)})
+	}
+	got := itemLines(items, 100, func(it Item) string { return "- " + it.Text })
+
+	if len(got) > 100 || !strings.HasSuffix(got, "more") || !strings.HasPrefix(got, "- deal 00\n") {
+		t.Errorf("lines = %q", got)
+	}
+}
+
+func TestTriggersPostToAlertTargets(t *testing.T) {
+	var got slackPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+	t.Setenv("TEST_SLACK_URL", srv.URL)
+
+	report := &alerts.Report{Triggered: []alerts.Trigger{{
+		AlertID: "a_1", New: true, MaxPriceUSD: 600, PriceUSD: 480,
+		Flight: &core.FlightOffer{From: "YUL", To: "CDG", DeepLink: "https://example.com/f"},
+		Notify: &alerts.Targets{Slack: "${TEST_SLACK_URL}"},
+	}}}
+	var nt *Notifier
+	nt.Triggers(context.Background(), report)
+
+	if len(report.Errors) != 0 || got.Text != "YUL → CDG under $600" {
+		t.Errorf("errors = %+v, payload = %+v", report.Errors, got)
+	}
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Desktop shows native notifications using the tools each platform ships
+// with: osascript on macOS, notify-send on Linux and BSDs, and PowerShell
+// on Windows.
+type Desktop struct{}
+
+func NewDesktop() *Desktop { return &Desktop{} }
+
+func (d *Desktop) Name() string { return "desktop" }
+
+func (d *Desktop) Send(ctx context.Context, n Notification) error {
+	name, args := desktopCommand(runtime.GOOS, n)
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s not found; desktop notifications need it on %s", name, runtime.GOOS)
+	}
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// desktopCommand builds the command that shows n on goos. The URL goes in
+// the body since not every platform's notifications can open links.
+func desktopCommand(goos string, n Notification) (string, []string) {
+	body := n.Body
+	if n.URL != "" {
+		body += "\n" + n.URL
+	}
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(n.Title))
+		return "osascript", []string{"-e", script}
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(10000, %s, %s, [System.Windows.Forms.ToolTipIcon]::Info)
+Start-Sleep -Seconds 1
+$n.Dispose()`, powerShellString(n.Title), powerShellString(body))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		return "notify-send", []string{"--app-name=travel", n.Title, body}
+	}
+}
+
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/history"
+)
+
+// DigestData is the content of an alerts digest: every tracked alert with
+// its latest price and how that price moved over the period.
+type DigestData struct {
+	Since     time.Time     `json:"since,omitzero"`
+	Until     time.Time     `json:"until"`
+	Tracked   int           `json:"tracked"`
+	Triggered int           `json:"triggered"`
+	Routes    []DigestRoute `json:"routes"`
+}
+
+// DigestRoute is one alert in a digest. Change fields compare the latest
+// price with the last one recorded before the period began, or the first
+// one within it; they are zero when history has fewer than two runs.
+type DigestRoute struct {
+	AlertID   string  `json:"alertId"`
+	Kind      string  `json:"kind"`
+	Label     string  `json:"label"`
+	TargetUSD float64 `json:"targetUSD"`
+	PriceUSD  float64 `json:"priceUSD,omitempty"`
+	ChangeUSD float64 `json:"changeUSD"`
+	ChangePct float64 `json:"changePct"`
+	// Runs is how many searches of the route the change is based on.
+	Runs        int       `json:"runs"`
+	Triggered   bool      `json:"triggered"`
+	TriggeredAt time.Time `json:"triggeredAt,omitzero"`
+	DeepLink    string    `json:"deepLink,omitempty"`
+}
+
+// Deals returns the routes at or below their targets.
+func (d DigestData) Deals() []DigestRoute {
+	var deals []DigestRoute
+	for _, r := range d.Routes {
+		if r.Triggered {
+			deals = append(deals, r)
+		}
+	}
+	return deals
+}
+
+// Digest summarizes every alert's latest check, with price changes since
+// since taken from the search history entries. Items link to each alert
+// at or below its target.
+func Digest(all []alerts.Alert, entries []history.Entry, since, at time.Time) Notification {
+	data := DigestData{Since: since, Until: at, Tracked: len(all)}
+	for _, a := range all {
+		r := DigestRoute{
+			AlertID:   a.ID,
+			Kind:      a.Kind,
+			Label:     Label(a),
+			TargetUSD: a.MaxPriceUSD,
+			PriceUSD:  a.LastPriceUSD,
+			Triggered: a.Triggered,
+			DeepLink:  a.LastDeepLink,
+		}
+		if a.Triggered {
+			r.TriggeredAt = a.LastTriggeredAt
+			data.Triggered++
+		}
+		if q, ok := diffQuery(a); ok {
+			if d, err := history.Compute(periodRuns(entries, q, since), q, 0); err == nil {
+				r.ChangeUSD, r.ChangePct, r.Runs = d.ChangeSinceFirstUSD, d.ChangeSinceFirstPct, len(d.Runs)
+			}
+		}
+		data.Routes = append(data.Routes, r)
+	}
+
+	n := Notification{Event: EventDigest, Time: at, Data: data}
+	for _, r := range data.Deals() {
+		n.Items = append(n.Items, Item{
+			Text: fmt.Sprintf("%s: $%.0f (target $%.0f)", r.Label, r.PriceUSD, r.TargetUSD),
+			URL:  r.DeepLink,
+		})
+	}
+	if data.Triggered == 0 {
+		n.Title = "Travel deals: nothing under target"
+		n.Body = fmt.Sprintf("None of your %d alerts is at or below its target price.", len(all))
+	} else {
+		n.Title = fmt.Sprintf("Travel deals: %d of %d alerts under target", data.Triggered, len(all))
+		n.Body = "Latest prices at or below your targets:"
+	}
+	return n
+}
+
+// diffQuery is the history query matching an alert's searches.
+func diffQuery(a alerts.Alert) (history.DiffQuery, bool) {
+	switch {
+	case a.Flight != nil:
+		return history.DiffQuery{Route: a.Flight.From + "-" + a.Flight.To, Date: a.Flight.DepartDate}, true
+	case a.Stay != nil:
+		return history.DiffQuery{City: a.Stay.City, Date: a.Stay.CheckIn}, true
+	}
+	return history.DiffQuery{}, false
+}
+
+// periodRuns keeps q's runs since since, plus the newest one before it as
+// the baseline the period's change is measured from.
+func periodRuns(entries []history.Entry, q history.DiffQuery, since time.Time) []history.Entry {
+	var (
+		runs     []history.Entry
+		baseline *history.Entry
+	)
+	for i, e := range entries {
+		if e.CheapestUSD <= 0 || !q.Matches(e) {
+			continue
+		}
+		if !e.CreatedAt.Before(since) {
+			runs = append(runs, e)
+		} else if baseline == nil || e.CreatedAt.After(baseline.CreatedAt) {
+			baseline = &entries[i]
+		}
+	}
+	if baseline != nil {
+		runs = append(runs, *baseline)
+	}
+	return runs
+}
+
+// Label names what an alert watches, e.g. "YUL → CDG Jun 12" or
+// "Paris Jun 12 to Jun 15".
+func Label(a alerts.Alert) string {
+	switch {
+	case a.Flight != nil:
+		l := fmt.Sprintf("%s → %s %s", a.Flight.From, a.Flight.To, shortDate(a.Flight.DepartDate))
+		if a.Flight.ReturnDate != "" {
+			l += " to " + shortDate(a.Flight.ReturnDate)
+		}
+		return l
+	case a.Stay != nil:
+		return fmt.Sprintf("%s %s to %s", a.Stay.City, shortDate(a.Stay.CheckIn), shortDate(a.Stay.CheckOut))
+	}
+	return "Alert " + a.ID
+}
+
+func shortDate(date string) string {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return d.Format("Jan 2")
+}
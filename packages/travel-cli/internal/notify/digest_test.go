@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/history"
+)
+
+func flightEntry(t *testing.T, price float64, at time.Time) history.Entry {
+	t.Helper()
+	q, err := json.Marshal(core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return history.Entry{ID: at.String(), Kind: history.KindFlights, Query: q, CheapestUSD: price, CreatedAt: at}
+}
+
+func TestDigestListsDeals(t *testing.T) {
+	all := []alerts.Alert{
+		{ID: "a_1", Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 600,
+			Triggered: true, LastPriceUSD: 480, LastDeepLink: "https://example.com/f"},
+		{ID: "a_2", Stay: &core.StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"}, MaxPriceUSD: 100, LastPriceUSD: 140},
+	}
+	n := Digest(all, nil, time.Time{}, time.Now())
+
+	if n.Title != "Travel deals: 1 of 2 alerts under target" || len(n.Items) != 1 {
+		t.Fatalf("digest = %+v", n)
+	}
+	if n.Items[0] != (Item{Text: "YUL → CDG Jun 12: $480 (target $600)", URL: "https://example.com/f"}) {
+		t.Errorf("item = %+v", n.Items[0])
+	}
+	if n := Digest(all[1:], nil, time.Time{}, time.Now()); n.Title != "Travel deals: nothing under target" || len(n.Items) != 0 {
+		t.Errorf("empty digest = %+v", n)
+	}
+}
+
+func TestDigestPriceChangeOverPeriod(t *testing.T) {
+	now := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+	entries := []history.Entry{
+		flightEntry(t, 700, since.Add(-72*time.Hour)),
+		flightEntry(t, 560, since.Add(-time.Hour)), // baseline: newest before the period
+		flightEntry(t, 540, since.Add(2*time.Hour)),
+		flightEntry(t, 520, now.Add(-time.Hour)),
+	}
+	all := []alerts.Alert{{ID: "a_1", Kind: alerts.KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 500, LastPriceUSD: 520}}
+
+	data := Digest(all, entries, since, now).Data.(DigestData)
+	r := data.Routes[0]
+	if r.ChangeUSD != -40 || r.Runs != 3 || r.Label != "YUL → CDG Jun 12" {
+		t.Errorf("route = %+v, want -40 over 3 runs", r)
+	}
+}
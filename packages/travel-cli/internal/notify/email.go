@@ -0,0 +1,221 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed email.tmpl
+var defaultEmailTemplate string
+
+// SMTP holds the settings for sending mail. Port 465 uses implicit TLS;
+// other ports upgrade with STARTTLS when the server offers it. Username
+// and Password are optional, for relays that don't require auth.
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s SMTP) addr() string {
+	port := s.Port
+	if port == 0 {
+		port = 587
+	}
+	return net.JoinHostPort(s.Host, strconv.Itoa(port))
+}
+
+// Email sends notifications as mail rendered from a Go template. The
+// template's data is EmailData; templates named *.html are rendered with
+// html/template and sent as HTML.
+type Email struct {
+	smtp   SMTP
+	render func(io.Writer, EmailData) error
+	html   bool
+}
+
+// EmailData is what email templates render: the notification, plus the
+// digest's contents when it is one.
+type EmailData struct {
+	Notification
+	Digest *DigestData
+}
+
+// NewEmail builds an email sink using the template at path, or the
+// built-in plain-text template when path is empty.
+func NewEmail(s SMTP, path string) (*Email, error) {
+	if s.Host == "" || s.From == "" || len(s.To) == 0 {
+		return nil, errors.New("email: host, from, and to are required")
+	}
+	e := &Email{smtp: s}
+	src, name := defaultEmailTemplate, "email.tmpl"
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("email template: %w", err)
+		}
+		src, name = string(raw), filepath.Base(path)
+	}
+	funcs := map[string]interface{}{"usd": usd, "signed": signed, "date": formatDate}
+	if strings.HasSuffix(name, ".html") {
+		t, err := htmltemplate.New(name).Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("email template: %w", err)
+		}
+		e.render = func(w io.Writer, d EmailData) error { return t.Execute(w, d) }
+		e.html = true
+	} else {
+		t, err := template.New(name).Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, fmt.Errorf("email template: %w", err)
+		}
+		e.render = func(w io.Writer, d EmailData) error { return t.Execute(w, d) }
+	}
+	return e, nil
+}
+
+func (e *Email) Name() string { return "email" }
+
+func (e *Email) Send(ctx context.Context, n Notification) error {
+	msg, err := e.Message(n)
+	if err != nil {
+		return err
+	}
+	return sendMail(ctx, e.smtp, msg)
+}
+
+// Message renders n as an RFC 5322 message with a quoted-printable body.
+func (e *Email) Message(n Notification) ([]byte, error) {
+	data := EmailData{Notification: n}
+	if d, ok := n.Data.(DigestData); ok {
+		data.Digest = &d
+	}
+	var body bytes.Buffer
+	if err := e.render(&body, data); err != nil {
+		return nil, fmt.Errorf("email template: %w", err)
+	}
+
+	contentType := "text/plain"
+	if e.html {
+		contentType = "text/html"
+	}
+	date := n.Time
+	if date.IsZero() {
+		date = time.Now()
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.smtp.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.smtp.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	qp := quotedprintable.NewWriter(&msg)
+	if _, err := qp.Write(body.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// sendMail is smtp.SendMail bounded by ctx, with implicit TLS on port 465.
+func sendMail(ctx context.Context, s SMTP, msg []byte) error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if s.Port == 465 {
+		d := &tls.Dialer{Config: &tls.Config{ServerName: s.Host}}
+		conn, err = d.DialContext(ctx, "tcp", s.addr())
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", s.addr())
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if _, isTLS := conn.(*tls.Conn); !isTLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+				return err
+			}
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	for _, to := range s.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func usd(v float64) string {
+	return fmt.Sprintf("$%.0f", v)
+}
+
+// signed formats a price change with its sign, e.g. "-$40" or "+$12".
+func signed(v float64) string {
+	switch {
+	case v < 0:
+		return fmt.Sprintf("-$%.0f", -v)
+	case v > 0:
+		return fmt.Sprintf("+$%.0f", v)
+	}
+	return "no change"
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("Mon Jan 2")
+}
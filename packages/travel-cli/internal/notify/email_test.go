@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// fakeSMTP accepts one message without TLS or auth and returns its data.
+func fakeSMTP(t *testing.T) (port int, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	ch := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+		reply("220 localhost ESMTP")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				reply("250 queued")
+				ch <- data.String()
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, ch
+}
+
+func testDigest() Notification {
+	all := []alerts.Alert{
+		{ID: "a_1", Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}, MaxPriceUSD: 600,
+			Triggered: true, LastPriceUSD: 480, LastDeepLink: "https://example.com/f"},
+		{ID: "a_2", Stay: &core.StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"}, MaxPriceUSD: 100, LastPriceUSD: 140},
+	}
+	return Digest(all, nil, time.Time{}, time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC))
+}
+
+func TestEmailSendsDigest(t *testing.T) {
+	port, received := fakeSMTP(t)
+	email, err := NewEmail(SMTP{Host: "127.0.0.1", Port: port, From: "travel@example.com", To: []string{"me@example.com"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := email.Send(context.Background(), testDigest()); err != nil {
+		t.Fatal(err)
+	}
+
+	msg := <-received
+	head, body, _ := strings.Cut(msg, "\r\n\r\n")
+	if !strings.Contains(head, "Subject: Travel deals: 1 of 2 alerts under target") || !strings.Contains(head, "To: me@example.com") {
+		t.Errorf("headers:\n%s", head)
+	}
+	text, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Under target", "YUL → CDG Jun 12: $480 (target $600)", "https://example.com/f", "Paris Jun 12 to Jun 15: $140, target $100"} {
+		if !strings.Contains(string(text), want) {
+			t.Errorf("body missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestEmailCustomHTMLTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "digest.html")
+	tmpl := `<h1>{{.Title}}</h1>{{range .Digest.Routes}}<p>{{.Label}} {{usd .PriceUSD}}</p>{{end}}`
+	if err := os.WriteFile(path, []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	email, err := NewEmail(SMTP{Host: "localhost", From: "a@example.com", To: []string{"b@example.com"}}, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := email.Message(testDigest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, body, _ := strings.Cut(string(msg), "\r\n\r\n")
+	text, _ := io.ReadAll(quotedprintable.NewReader(strings.NewReader(body)))
+	if !strings.Contains(head, "Content-Type: text/html") || !strings.Contains(string(text), "<p>YUL → CDG Jun 12 $480</p>") {
+		t.Errorf("message:\n%s\n%s", head, text)
+	}
+}
+
+func TestNewEmailRequiresAddresses(t *testing.T) {
+	if _, err := NewEmail(SMTP{Host: "localhost", Port: 25}, ""); err == nil {
+		t.Error("want error without from/to")
+	}
+	if s := (SMTP{Host: "smtp.example.com"}).addr(); s != "smtp.example.com:"+strconv.Itoa(587) {
+		t.Errorf("default addr = %s", s)
+	}
+}
@@ -0,0 +1,157 @@
+// Package notify delivers notifications about triggered alerts and
+// scheduled searches through pluggable sinks.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+)
+
+const (
+	EventAlertTriggered = "alert.triggered"
+	EventScheduleRan    = "schedule.ran"
+	EventDigest         = "alerts.digest"
+)
+
+// sendTimeout bounds each sink so a hung notifier can't stall the daemon.
+const sendTimeout = 10 * time.Second
+
+// Notification is what sinks deliver. Title, Body, URL, and Items are for
+// humans; Data carries the underlying record for machine consumers.
+type Notification struct {
+	Event string      `json:"event"`
+	Time  time.Time   `json:"time"`
+	Title string      `json:"title"`
+	Body  string      `json:"body"`
+	URL   string      `json:"url,omitempty"`
+	Items []Item      `json:"items,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+}
+
+// Item is one line of a list notification such as the deal digest.
+type Item struct {
+	Text string `json:"text"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Sink delivers notifications to one destination.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, n Notification) error
+}
+
+// Only restricts s to the given events. With no events s receives all of
+// them.
+func Only(s Sink, events ...string) Sink {
+	if len(events) == 0 {
+		return s
+	}
+	return filtered{Sink: s, events: events}
+}
+
+type filtered struct {
+	Sink
+	events []string
+}
+
+func (f filtered) Send(ctx context.Context, n Notification) error {
+	if !slices.Contains(f.events, n.Event) {
+		return nil
+	}
+	return f.Sink.Send(ctx, n)
+}
+
+// Notifier fans notifications out to every configured sink. A nil
+// Notifier sends nothing.
+type Notifier struct {
+	sinks []Sink
+}
+
+func New(sinks ...Sink) *Notifier {
+	return &Notifier{sinks: sinks}
+}
+
+// Enabled reports whether any sink is configured.
+func (nt *Notifier) Enabled() bool {
+	return nt != nil && len(nt.sinks) > 0
+}
+
+// Send delivers n to every sink and joins their errors.
+func (nt *Notifier) Send(ctx context.Context, n Notification) error {
+	if !nt.Enabled() {
+		return nil
+	}
+	var errs []error
+	for _, s := range nt.sinks {
+		sctx, cancel := context.WithTimeout(ctx, sendTimeout)
+		if err := s.Send(sctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("notify %s: %w", s.Name(), err))
+		}
+		cancel()
+	}
+	return errors.Join(errs...)
+}
+
+// Triggers notifies about each newly triggered alert in report, through
+// the notifier's sinks and the alert's own targets. Alerts that were
+// already triggered on the previous check are skipped so users aren't
+// notified again for the same deal. Delivery failures are added to
+// report.Errors.
+func (nt *Notifier) Triggers(ctx context.Context, report *alerts.Report) {
+	for _, t := range report.Triggered {
+		if !t.New {
+			continue
+		}
+		n := AlertTriggered(t, report.CheckedAt)
+		err := errors.Join(nt.Send(ctx, n), New(TargetSinks(t.Notify)...).Send(ctx, n))
+		if err != nil {
+			report.Errors = append(report.Errors, alerts.CheckError{AlertID: t.AlertID, Reason: err.Error()})
+		}
+	}
+}
+
+// TargetSinks returns sinks for an alert's own destinations. URLs may
+// reference environment variables as ${NAME}.
+func TargetSinks(t *alerts.Targets) []Sink {
+	if t == nil {
+		return nil
+	}
+	var sinks []Sink
+	if t.Slack != "" {
+		sinks = append(sinks, NewSlack(os.ExpandEnv(t.Slack)))
+	}
+	if t.Discord != "" {
+		sinks = append(sinks, NewDiscord(os.ExpandEnv(t.Discord)))
+	}
+	return sinks
+}
+
+// AlertTriggered renders a trigger as a notification.
+func AlertTriggered(t alerts.Trigger, at time.Time) Notification {
+	n := Notification{Event: EventAlertTriggered, Time: at, Data: t}
+	switch {
+	case t.Flight != nil:
+		f := t.Flight
+		n.Title = fmt.Sprintf("%s → %s under $%.0f", f.From, f.To, t.MaxPriceUSD)
+		n.Body = fmt.Sprintf("$%.0f on %s %s, departing %s", t.PriceUSD, f.Airline, f.FlightNumber, f.DepartTime.Format("Jan 2 15:04"))
+		n.URL = f.DeepLink
+	case t.Stay != nil:
+		s := t.Stay
+		n.Title = fmt.Sprintf("%s stay under $%.0f/night", s.City, t.MaxPriceUSD)
+		n.Body = fmt.Sprintf("$%.0f/night at %s, %s to %s", t.PriceUSD, s.Name, s.CheckIn, s.CheckOut)
+		n.URL = s.DeepLink
+	default:
+		n.Title = fmt.Sprintf("Alert %s triggered", t.AlertID)
+		n.Body = fmt.Sprintf("$%.0f, under your $%.0f limit", t.PriceUSD, t.MaxPriceUSD)
+	}
+	if t.PreviousPriceUSD > 0 && t.PreviousPriceUSD != t.PriceUSD {
+		n.Body += fmt.Sprintf(" (was $%.0f)", t.PreviousPriceUSD)
+	}
+	return n
+}
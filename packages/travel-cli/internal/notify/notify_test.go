@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/alerts"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+type recordingSink struct {
+	sent []Notification
+	err  error
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Send(ctx context.Context, n Notification) error {
+	s.sent = append(s.sent, n)
+	return s.err
+}
+
+func TestTriggersSkipsRepeats(t *testing.T) {
+	sink := &recordingSink{}
+	report := &alerts.Report{
+		CheckedAt: time.Now(),
+		Triggered: []alerts.Trigger{
+			{AlertID: "a_new", New: true, MaxPriceUSD: 600, PriceUSD: 480, PreviousPriceUSD: 520,
+				Flight: &core.FlightOffer{From: "YUL", To: "CDG", Airline: "Air Canada", FlightNumber: "AC870", DeepLink: "https://example.com/f"}},
+			{AlertID: "a_old", New: false, MaxPriceUSD: 600, PriceUSD: 490},
+		},
+	}
+
+	New(sink).Triggers(context.Background(), report)
+
+	if len(sink.sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(sink.sent))
+	}
+	n := sink.sent[0]
+	if n.Title != "YUL → CDG under $600" || !strings.Contains(n.Body, "$480 on Air Canada AC870") || !strings.HasSuffix(n.Body, "(was $520)") {
+		t.Errorf("notification = %+v", n)
+	}
+	if n.URL != "https://example.com/f" {
+		t.Errorf("URL = %q", n.URL)
+	}
+}
+
+func TestTriggersRecordsSinkFailures(t *testing.T) {
+	sink := &recordingSink{err: errors.New("boom")}
+	report := &alerts.Report{Triggered: []alerts.Trigger{{AlertID: "a_1", New: true}}}
+
+	New(sink).Triggers(context.Background(), report)
+
+	if len(report.Errors) != 1 || report.Errors[0].AlertID != "a_1" || !strings.Contains(report.Errors[0].Reason, "notify recording: boom") {
+		t.Errorf("errors = %+v", report.Errors)
+	}
+}
+
+func TestNilNotifierSendsNothing(t *testing.T) {
+	var nt *Notifier
+	if nt.Enabled() {
+		t.Error("nil notifier enabled")
+	}
+	if err := nt.Send(context.Background(), Notification{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDesktopCommandQuoting(t *testing.T) {
+	n := Notification{Title: `Paris "deal"`, Body: `it's $90`}
+
+	name, args := desktopCommand("darwin", n)
+	if name != "osascript" || args[1] != `display notification "it's $90" with title "Paris \"deal\""` {
+		t.Errorf("darwin: %s %q", name, args)
+	}
+	name, args = desktopCommand("windows", n)
+	if name != "powershell" || !strings.Contains(args[len(args)-1], `'Paris "deal"', 'it''s $90'`) {
+		t.Errorf("windows: %s %q", name, args)
+	}
+	name, args = desktopCommand("linux", n)
+	if name != "notify-send" || args[1] != n.Title || args[2] != n.Body {
+		t.Errorf("linux: %s %q", name, args)
+	}
+}
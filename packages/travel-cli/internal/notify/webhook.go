@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Beetlebot-Event"
+	HeaderTimestamp = "X-Beetlebot-Timestamp"
+	HeaderSignature = "X-Beetlebot-Signature"
+)
+
+// Webhook POSTs each notification as JSON. With a secret, requests carry
+// an HMAC-SHA256 signature of "<timestamp>.<body>" in
+// X-Beetlebot-Signature as "sha256=<hex>", so receivers can check the
+// sender and reject replays by timestamp.
+type Webhook struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{url: url, secret: secret, client: &http.Client{Timeout: sendTimeout}}
+}
+
+func (w *Webhook) Name() string { return "webhook" }
+
+func (w *Webhook) Send(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beetlebot-travel")
+	req.Header.Set(HeaderEvent, n.Event)
+	req.Header.Set(HeaderTimestamp, ts)
+	if w.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(w.secret, ts, body))
+	}
+
+	return do(w.client, req)
+}
+
+// do sends req and turns non-2xx answers into errors carrying the start
+// of the response body, where chat services explain rejected payloads.
+// Only the host is named: chat webhook URLs embed their credentials.
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			return fmt.Errorf("post to %s: %w", req.URL.Host, uerr.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s answered %s: %s", req.URL.Host, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// Sign computes the X-Beetlebot-Signature value for a request body sent
+// at timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
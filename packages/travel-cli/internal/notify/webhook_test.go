@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookSignsPayload(t *testing.T) {
+	var got Notification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts := r.Header.Get(HeaderTimestamp)
+		if r.Header.Get(HeaderSignature) != Sign("s3cret", ts, body) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(HeaderEvent) != EventAlertTriggered {
+			http.Error(w, "bad event", http.StatusBadRequest)
+			return
+		}
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+
+	n := Notification{Event: EventAlertTriggered, Title: "YUL → CDG under $600"}
+	if err := NewWebhook(srv.URL, "s3cret").Send(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != n.Title {
+		t.Errorf("received %+v", got)
+	}
+
+	err := NewWebhook(srv.URL, "wrong").Send(context.Background(), n)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+}
+
+func TestOnlyFiltersEvents(t *testing.T) {
+	sink := &recordingSink{}
+	nt := New(Only(sink, EventAlertTriggered))
+	_ = nt.Send(context.Background(), Notification{Event: EventScheduleRan})
+	_ = nt.Send(context.Background(), Notification{Event: EventAlertTriggered})
+	if len(sink.sent) != 1 || sink.sent[0].Event != EventAlertTriggered {
+		t.Errorf("sent = %+v", sink.sent)
+	}
+}
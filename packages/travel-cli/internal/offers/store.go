@@ -0,0 +1,163 @@
+// Package offers remembers offers returned by recent searches so later
+// commands (combine, reprice) can refer to them by ID.
+package offers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// retention bounds how long offers are kept. Providers hold offers for
+// minutes to hours, so anything older is only useful as an expiry warning.
+const retention = 7 * 24 * time.Hour
+
+var ErrNotFound = errors.New("offer not found")
+
+// Offer IDs come from providers, so only path-safe ones are stored.
+var validID = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+
+type record struct {
+	Flight  *core.FlightOffer `json:"flight,omitempty"`
+	Stay    *core.StayOffer   `json:"stay,omitempty"`
+	SavedAt time.Time         `json:"savedAt"`
+}
+
+// Store keeps one JSON file per offer, keyed by offer ID.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel", "offers"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create offers dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// SaveResult stores every offer in a search result, including the
+// per-source offers folded into merged flights.
+func (s *Store) SaveResult(result *core.SearchResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	for i := range result.Flights {
+		f := result.Flights[i]
+		if err := s.write(f.ID, record{Flight: &f, SavedAt: now}); err != nil {
+			return err
+		}
+		for _, p := range f.Prices {
+			if p.OfferID == f.ID {
+				continue
+			}
+			alt := f
+			alt.ID, alt.Source, alt.PriceUSD, alt.DeepLink, alt.Prices = p.OfferID, p.Source, p.PriceUSD, p.DeepLink, nil
+			if err := s.write(alt.ID, record{Flight: &alt, SavedAt: now}); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range result.Stays {
+		st := result.Stays[i]
+		if err := s.write(st.ID, record{Stay: &st, SavedAt: now}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) Flight(id string) (*core.FlightOffer, error) {
+	r, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Flight == nil {
+		return nil, fmt.Errorf("%w: %s is not a flight offer", ErrNotFound, id)
+	}
+	return r.Flight, nil
+}
+
+func (s *Store) Stay(id string) (*core.StayOffer, error) {
+	r, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Stay == nil {
+		return nil, fmt.Errorf("%w: %s is not a stay offer", ErrNotFound, id)
+	}
+	return r.Stay, nil
+}
+
+// Prune deletes offers saved more than retention ago.
+func (s *Store) Prune() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-retention)
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		_ = os.Remove(filepath.Join(s.dir, e.Name()))
+	}
+	return nil
+}
+
+func (s *Store) read(id string) (*record, error) {
+	if !validID.MatchString(id) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decode offer %s: %w", id, err)
+	}
+	return &r, nil
+}
+
+func (s *Store) write(id string, r record) error {
+	if !validID.MatchString(id) {
+		return nil
+	}
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(id), raw, 0o644)
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
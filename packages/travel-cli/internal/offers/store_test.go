@@ -0,0 +1,55 @@
+package offers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestSaveResultAndResolve(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &core.SearchResult{
+		Flights: []core.FlightOffer{{
+			ID: "f_aaa", Source: "mock_flights", PriceUSD: 500,
+			Prices: []core.SourcePrice{
+				{Source: "mock_flights", OfferID: "f_aaa", PriceUSD: 500},
+				{Source: "duffel", OfferID: "off_123", PriceUSD: 540},
+			},
+		}},
+		Stays: []core.StayOffer{{ID: "s_bbb", Source: "mock_stays", TotalPriceUSD: 300}},
+	}
+	if err := store.SaveResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := store.Flight("f_aaa")
+	if err != nil || f.PriceUSD != 500 {
+		t.Fatalf("Flight(f_aaa) = %+v, %v", f, err)
+	}
+	alt, err := store.Flight("off_123")
+	if err != nil || alt.Source != "duffel" || alt.PriceUSD != 540 {
+		t.Fatalf("Flight(off_123) = %+v, %v", alt, err)
+	}
+	if _, err := store.Stay("s_bbb"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveMissingOrWrongKind(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = store.SaveResult(&core.SearchResult{Stays: []core.StayOffer{{ID: "s_bbb"}}})
+
+	for _, id := range []string{"f_missing", "s_bbb", "../etc/passwd"} {
+		if _, err := store.Flight(id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Flight(%q) err = %v, want ErrNotFound", id, err)
+		}
+	}
+}
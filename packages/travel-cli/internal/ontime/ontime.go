@@ -0,0 +1,279 @@
+// Package ontime tells how often flights arrive on time, from on-time
+// performance data the US Bureau of Transportation Statistics publishes
+// or from aviationstack's flight API, keeping the API's answers on disk
+// since records change slowly.
+package ontime
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+	"github.com/beetlebot/travel-cli/internal/logging"
+)
+
+// The sources onTime.source selects.
+const (
+	SourceBTS           = "bts"
+	SourceAviationstack = "aviationstack"
+)
+
+// DefaultAviationstackURL is aviationstack's API over HTTPS. Its free plan
+// only serves plain HTTP, which sends the key unencrypted; onTime.url can
+// name http://api.aviationstack.com/v1 to accept that.
+const DefaultAviationstackURL = "https://api.aviationstack.com/v1"
+
+// httpsRestricted is aviationstack's error code for an HTTPS request on a
+// plan that only allows HTTP.
+const httpsRestricted = "https_access_restricted"
+
+// lateMinutes is the arrival delay from which a flight is late.
+const lateMinutes = 15
+
+// tally counts a flight's arrivals.
+type tally struct {
+	flights, onTime int
+}
+
+func (t tally) record() core.OnTimeRecord {
+	if t.flights == 0 {
+		return core.OnTimeRecord{}
+	}
+	return core.OnTimeRecord{Score: float64(t.onTime) / float64(t.flights), Flights: t.flights}
+}
+
+// key reads a flight number such as AC870, "aa 0100", or UA8123 as its
+// airline designator and number without leading zeros.
+func key(flightNumber string) string {
+	n := strings.ToUpper(strings.ReplaceAll(flightNumber, " ", ""))
+	if len(n) <= 2 {
+		return n
+	}
+	digits := strings.TrimLeft(n[2:], "0")
+	return n[:2] + digits
+}
+
+// BTS reads on-time records from a CSV downloaded from the Bureau of
+// Transportation Statistics' TranStats site, either the Reporting Carrier
+// On-Time Performance table or the Airline On-Time Statistics one. It
+// covers flights of US carriers within the US, over the months the file
+// holds.
+type BTS struct {
+	Path string
+
+	once    sync.Once
+	flights map[string]tally
+	err     error
+}
+
+func NewBTS(path string) *BTS {
+	return &BTS{Path: path}
+}
+
+// The column names of the two tables, in the order tried.
+var (
+	carrierColumns   = []string{"Reporting_Airline", "OP_UNIQUE_CARRIER", "OP_CARRIER"}
+	numberColumns    = []string{"Flight_Number_Reporting_Airline", "OP_CARRIER_FL_NUM"}
+	delayedColumns   = []string{"ArrDel15", "ARR_DEL15"}
+	cancelledColumns = []string{"Cancelled", "CANCELLED"}
+	divertedColumns  = []string{"Diverted", "DIVERTED"}
+)
+
+func (b *BTS) OnTime(ctx context.Context, flightNumber string) (core.OnTimeRecord, error) {
+	b.once.Do(func() { b.flights, b.err = readBTS(b.Path) })
+	if b.err != nil {
+		return core.OnTimeRecord{}, b.err
+	}
+	return b.flights[key(flightNumber)].record(), nil
+}
+
+func readBTS(path string) (map[string]tally, error) {
+	if path == "" {
+		return nil, errors.New("on-time: onTime.file is not set")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("on-time: %w", err)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.ReuseRecord = true
+	// TranStats ends every line with a comma.
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("on-time: read %s: %w", path, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	find := func(names []string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	carrier, number, delayed := find(carrierColumns), find(numberColumns), find(delayedColumns)
+	cancelled, diverted := find(cancelledColumns), find(divertedColumns)
+	if carrier < 0 || number < 0 || delayed < 0 {
+		return nil, fmt.Errorf("on-time: %s is not a BTS on-time performance file (it needs %s, %s, and %s columns)", path, carrierColumns[0], numberColumns[0], delayedColumns[0])
+	}
+	flag := func(row []string, i int) bool {
+		if i < 0 || i >= len(row) {
+			return false
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(row[i]), 64)
+		return err == nil && v > 0
+	}
+
+	flights := map[string]tally{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("on-time: read %s: %w", path, err)
+		}
+		if carrier >= len(row) || number >= len(row) || delayed >= len(row) {
+			continue
+		}
+		k := key(strings.TrimSpace(row[carrier]) + strings.TrimSpace(row[number]))
+		t := flights[k]
+		t.flights++
+		if !flag(row, delayed) && !flag(row, cancelled) && !flag(row, diverted) && strings.TrimSpace(row[delayed]) != "" {
+			t.onTime++
+		}
+		flights[k] = t
+	}
+	return flights, nil
+}
+
+// Aviationstack asks aviationstack's flights API how a flight's recent
+// departures arrived. Each lookup is one request of the plan's monthly
+// quota, so wrap it in a Cache.
+type Aviationstack struct {
+	URL    string
+	APIKey string
+	client *http.Client
+}
+
+func NewAviationstack(baseURL, apiKey string) *Aviationstack {
+	if baseURL == "" {
+		baseURL = DefaultAviationstackURL
+	}
+	if strings.HasPrefix(baseURL, "http://") {
+		logging.Logger().Warn("on-time: aviationstack is reached over plain HTTP, so onTime.apiKey is sent unencrypted", "url", baseURL)
+	}
+	return &Aviationstack{URL: baseURL, APIKey: apiKey, client: httpclient.New()}
+}
+
+func (a *Aviationstack) OnTime(ctx context.Context, flightNumber string) (core.OnTimeRecord, error) {
+	if a.APIKey == "" {
+		return core.OnTimeRecord{}, errors.New("on-time: onTime.apiKey is not set")
+	}
+	q := url.Values{"access_key": {a.APIKey}, "flight_iata": {key(flightNumber)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(a.URL, "/")+"/flights?"+q.Encode(), nil)
+	if err != nil {
+		return core.OnTimeRecord{}, err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		// The error names the URL, key included.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return core.OnTimeRecord{}, fmt.Errorf("on-time: %w", err)
+	}
+	defer resp.Body.Close()
+	// aviationstack explains refusals in error, and gives a delay only
+	// for flights that were late.
+	var body struct {
+		Error *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+		Data []struct {
+			Status  string `json:"flight_status"`
+			Arrival struct {
+				Delay *int `json:"delay"`
+			} `json:"arrival"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return core.OnTimeRecord{}, fmt.Errorf("decode on-time response: %w", err)
+	}
+	if body.Error != nil && body.Error.Code == httpsRestricted {
+		return core.OnTimeRecord{}, errors.New("on-time: the aviationstack plan doesn't allow HTTPS; set onTime.url to http://api.aviationstack.com/v1 to send the key unencrypted, or upgrade the plan")
+	}
+	if body.Error != nil {
+		return core.OnTimeRecord{}, fmt.Errorf("on-time: %s", body.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return core.OnTimeRecord{}, fmt.Errorf("on-time: %s", resp.Status)
+	}
+	var t tally
+	for _, f := range body.Data {
+		switch f.Status {
+		case "landed":
+			t.flights++
+			if f.Arrival.Delay == nil || *f.Arrival.Delay < lateMinutes {
+				t.onTime++
+			}
+		case "cancelled", "diverted":
+			t.flights++
+		}
+	}
+	return t.record(), nil
+}
+
+// Cache answers from the records next told while they are fresh in
+// store, asking next only for the rest.
+type Cache struct {
+	next  core.OnTimeSource
+	store core.ResultCache
+}
+
+// NewCache keeps next's answers in store as config.CacheOnTime. Without a
+// store, every lookup asks next.
+func NewCache(next core.OnTimeSource, store core.ResultCache) *Cache {
+	return &Cache{next: next, store: store}
+}
+
+func (c *Cache) OnTime(ctx context.Context, flightNumber string) (core.OnTimeRecord, error) {
+	if c.store == nil {
+		return c.next.OnTime(ctx, flightNumber)
+	}
+	k := key(flightNumber)
+	if data, ok := c.store.Get(config.CacheOnTime, k); ok {
+		var r core.OnTimeRecord
+		if json.Unmarshal(data, &r) == nil {
+			return r, nil
+		}
+	}
+
+	r, err := c.next.OnTime(ctx, flightNumber)
+	if err != nil {
+		return core.OnTimeRecord{}, err
+	}
+	if raw, err := json.Marshal(r); err == nil {
+		_ = c.store.Set(config.CacheOnTime, k, raw)
+	}
+	return r, nil
+}
@@ -0,0 +1,115 @@
+package ontime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/cache"
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestBTS(t *testing.T) {
+	for name, csv := range map[string]string{
+		"reporting carrier": `"Year","Reporting_Airline","Flight_Number_Reporting_Airline","Origin","ArrDel15","Cancelled","Diverted",
+2026,"AA","100","JFK",0.00,0.00,0.00,
+2026,"AA","100","JFK",1.00,0.00,0.00,
+2026,"AA","100","JFK",0.00,0.00,0.00,
+2026,"AA","100","JFK",,1.00,0.00,
+2026,"DL","5","ATL",0.00,0.00,0.00,
+`,
+		"on-time statistics": `FL_DATE,OP_UNIQUE_CARRIER,OP_CARRIER_FL_NUM,ARR_DEL15,CANCELLED,DIVERTED
+2026-01-02,AA,100,0.00,0.00,0.00
+2026-01-03,AA,100,1.00,0.00,0.00
+2026-01-04,AA,100,0.00,0.00,0.00
+2026-01-05,AA,100,,0.00,1.00
+2026-01-02,DL,5,0.00,0.00,0.00
+`,
+	} {
+		path := filepath.Join(t.TempDir(), "ontime.csv")
+		if err := os.WriteFile(path, []byte(csv), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		b := NewBTS(path)
+		for flight, want := range map[string]core.OnTimeRecord{
+			"AA100": {Score: 0.5, Flights: 4}, "aa 0100": {Score: 0.5, Flights: 4},
+			"DL5": {Score: 1, Flights: 1}, "UA1": {},
+		} {
+			if got, err := b.OnTime(context.Background(), flight); err != nil || got != want {
+				t.Errorf("%s: OnTime(%s) = %+v, %v; want %+v", name, flight, got, err, want)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "other.csv")
+	if err := os.WriteFile(path, []byte("a,b\n1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewBTS(path).OnTime(context.Background(), "AA100"); err == nil || !strings.Contains(err.Error(), "not a BTS") {
+		t.Errorf("err = %v, want not a BTS file", err)
+	}
+}
+
+func TestAviationstack(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/flights" || r.URL.Query().Get("access_key") != "secret" {
+			w.Write([]byte(`{"error": {"code": "invalid_access_key", "message": "You have not supplied a valid API Access Key."}}`))
+			return
+		}
+		if r.URL.Query().Get("flight_iata") != "AC870" {
+			t.Errorf("flight_iata = %q", r.URL.Query().Get("flight_iata"))
+		}
+		w.Write([]byte(`{"data": [
+			{"flight_status": "landed", "arrival": {"delay": null}},
+			{"flight_status": "landed", "arrival": {"delay": 9}},
+			{"flight_status": "landed", "arrival": {"delay": 42}},
+			{"flight_status": "cancelled", "arrival": {"delay": null}},
+			{"flight_status": "scheduled", "arrival": {"delay": null}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	got, err := NewAviationstack(srv.URL, "secret").OnTime(context.Background(), "AC 870")
+	if err != nil || got != (core.OnTimeRecord{Score: 0.5, Flights: 4}) {
+		t.Errorf("OnTime = %+v, %v; want 2 of 4 on time", got, err)
+	}
+	if _, err := NewAviationstack(srv.URL, "wrong").OnTime(context.Background(), "AC870"); err == nil || !strings.Contains(err.Error(), "valid API Access Key") {
+		t.Errorf("err = %v, want aviationstack's reason", err)
+	}
+}
+
+type countingSource struct {
+	calls int
+}
+
+func (c *countingSource) OnTime(ctx context.Context, flightNumber string) (core.OnTimeRecord, error) {
+	c.calls++
+	return core.OnTimeRecord{Score: 0.9, Flights: 20}, nil
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	store, err := cache.OpenDir(dir, func(string) time.Duration { return time.Hour })
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _ := cache.OpenDir(dir, func(string) time.Duration { return 0 })
+	next := &countingSource{}
+
+	for _, flight := range []string{"AC870", "ac 870"} {
+		if r, err := NewCache(next, store).OnTime(context.Background(), flight); err != nil || r.Flights != 20 {
+			t.Errorf("OnTime(%q) = %+v, %v", flight, r, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %d, want the second answered from the cache", next.calls)
+	}
+	if _, err := NewCache(next, expired).OnTime(context.Background(), "AC870"); err != nil || next.calls != 2 {
+		t.Errorf("expired entry: calls = %d, err = %v", next.calls, err)
+	}
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"io"
+	"os"
+)
+
+// ANSI styles for Markdown shown in a terminal.
+const (
+	colorGreen  = "32"
+	colorYellow = "33"
+	colorRed    = "31"
+)
+
+var color bool
+
+// SetColor turns terminal colors in Markdown output on or off: cheapest
+// prices in green, warnings in yellow, and errors in red. JSON, CSV, and
+// templates are never colored.
+func SetColor(on bool) {
+	color = on
+}
+
+// ColorSupported reports whether w is a terminal that should get colors:
+// not when NO_COLOR is set (see no-color.org) or TERM is dumb.
+func ColorSupported(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return IsTerminal(w)
+}
+
+// IsTerminal reports whether w is a file open on a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func paint(style, s string) string {
+	if !color || s == "" {
+		return s
+	}
+	return "\x1b[" + style + "m" + s + "\x1b[0m"
+}
+
+// cheapest paints s green when price is the lowest of the shown offers.
+func cheapest(s string, price, min float64) string {
+	if price != min {
+		return s
+	}
+	return paint(colorGreen, s)
+}
+
+func minPrice(n int, price func(i int) float64) float64 {
+	var min float64
+	for i := 0; i < n; i++ {
+		if p := price(i); i == 0 || p < min {
+			min = p
+		}
+	}
+	return min
+}
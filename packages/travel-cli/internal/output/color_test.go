@@ -0,0 +1,43 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestMarkdownColor(t *testing.T) {
+	SetColor(true)
+	defer SetColor(false)
+
+	md, err := Markdown(&core.SearchResult{
+		Flights: []core.FlightOffer{{Airline: "Air Canada", PriceUSD: 612.5}, {Airline: "Delta", PriceUSD: 580}},
+		Errors:  []core.ProviderError{{Provider: "kiwi", Reason: "timeout"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(md, "\x1b[32m$580.00\x1b[0m") || strings.Contains(md, "\x1b[32m$612.50") {
+		t.Errorf("only the cheapest price should be green:\n%s", md)
+	}
+	if !strings.Contains(md, "\x1b[31m**kiwi:**\x1b[0m timeout") {
+		t.Errorf("provider errors should be red:\n%s", md)
+	}
+
+	SetColor(false)
+	if md, _ := Markdown(ErrorResponse{Error: "search failed"}); strings.Contains(md, "\x1b[") {
+		t.Errorf("colors off, got %q", md)
+	}
+}
+
+func TestColorSupported(t *testing.T) {
+	if ColorSupported(&bytes.Buffer{}) {
+		t.Error("buffers are not terminals")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if ColorSupported(nil) {
+		t.Error("NO_COLOR should disable colors")
+	}
+}
@@ -0,0 +1,242 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// FormatCSV writes one row per offer for spreadsheets.
+const FormatCSV Format = "csv"
+
+type column[T any] struct {
+	name  string
+	value func(T) string
+}
+
+var flightColumns = []column[core.FlightOffer]{
+	{"id", func(f core.FlightOffer) string { return f.ID }},
+	{"source", func(f core.FlightOffer) string { return f.Source }},
+	{"airline", func(f core.FlightOffer) string { return f.Airline }},
+	{"flightNumber", func(f core.FlightOffer) string { return f.FlightNumber }},
+	{"from", func(f core.FlightOffer) string { return f.From }},
+	{"to", func(f core.FlightOffer) string { return f.To }},
+	{"departTime", func(f core.FlightOffer) string { return timestamp(f.DepartTime) }},
+	{"arriveTime", func(f core.FlightOffer) string { return timestamp(f.ArriveTime) }},
+	{"durationMinutes", func(f core.FlightOffer) string { return strconv.Itoa(f.DurationMinutes) }},
+	{"stops", func(f core.FlightOffer) string { return strconv.Itoa(f.Stops) }},
+	{"cabinClass", func(f core.FlightOffer) string { return f.CabinClass }},
+	{"priceUSD", func(f core.FlightOffer) string { return number(f.PriceUSD) }},
+	{"currency", func(f core.FlightOffer) string { return f.Currency }},
+	{"prices", func(f core.FlightOffer) string { return sourcePrices(f.Prices) }},
+	{"deepLink", func(f core.FlightOffer) string { return f.DeepLink }},
+	{"confidence", func(f core.FlightOffer) string { return number(f.Confidence) }},
+	{"isBookable", func(f core.FlightOffer) string { return strconv.FormatBool(f.IsBookable) }},
+	{"repriceRequired", func(f core.FlightOffer) string { return strconv.FormatBool(f.RepriceRequired) }},
+	{"fetchedAt", func(f core.FlightOffer) string { return timestamp(f.FetchedAt) }},
+	{"expiresAt", func(f core.FlightOffer) string { return timestamp(f.ExpiresAt) }},
+	{"ageSeconds", func(f core.FlightOffer) string { return strconv.Itoa(f.AgeSeconds) }},
+	{"refreshRecommended", func(f core.FlightOffer) string { return strconv.FormatBool(f.RefreshRecommended) }},
+}
+
+var stayColumns = []column[core.StayOffer]{
+	{"id", func(s core.StayOffer) string { return s.ID }},
+	{"source", func(s core.StayOffer) string { return s.Source }},
+	{"sources", func(s core.StayOffer) string { return strings.Join(s.Sources, "; ") }},
+	{"name", func(s core.StayOffer) string { return s.Name }},
+	{"propertyId", func(s core.StayOffer) string { return s.PropertyID }},
+	{"chain", func(s core.StayOffer) string { return s.Chain }},
+	{"type", func(s core.StayOffer) string { return s.Type }},
+	{"city", func(s core.StayOffer) string { return s.City }},
+	{"neighborhood", func(s core.StayOffer) string { return s.Neighborhood }},
+	{"address", func(s core.StayOffer) string { return s.Address }},
+	{"latitude", func(s core.StayOffer) string { return number(s.Latitude) }},
+	{"longitude", func(s core.StayOffer) string { return number(s.Longitude) }},
+	{"distanceKm", func(s core.StayOffer) string { return number(s.DistanceKm) }},
+	{"checkIn", func(s core.StayOffer) string { return s.CheckIn }},
+	{"checkOut", func(s core.StayOffer) string { return s.CheckOut }},
+	{"nightsCount", func(s core.StayOffer) string { return strconv.Itoa(s.NightsCount) }},
+	{"pricePerNight", func(s core.StayOffer) string { return number(s.PricePerNight) }},
+	{"totalPriceUSD", func(s core.StayOffer) string { return number(s.TotalPriceUSD) }},
+	{"childrenPriceUSD", func(s core.StayOffer) string { return number(s.ChildrenPriceUSD) }},
+	{"currency", func(s core.StayOffer) string { return s.Currency }},
+	{"rating", func(s core.StayOffer) string { return number(s.Rating) }},
+	{"reviewCount", func(s core.StayOffer) string { return strconv.Itoa(s.ReviewCount) }},
+	{"amenities", func(s core.StayOffer) string { return strings.Join(s.Amenities, "; ") }},
+	{"board", func(s core.StayOffer) string { return s.Board }},
+	{"deepLink", func(s core.StayOffer) string { return s.DeepLink }},
+	{"confidence", func(s core.StayOffer) string { return number(s.Confidence) }},
+	{"isBookable", func(s core.StayOffer) string { return strconv.FormatBool(s.IsBookable) }},
+	{"repriceRequired", func(s core.StayOffer) string { return strconv.FormatBool(s.RepriceRequired) }},
+	{"fetchedAt", func(s core.StayOffer) string { return timestamp(s.FetchedAt) }},
+	{"expiresAt", func(s core.StayOffer) string { return timestamp(s.ExpiresAt) }},
+	{"ageSeconds", func(s core.StayOffer) string { return strconv.Itoa(s.AgeSeconds) }},
+	{"refreshRecommended", func(s core.StayOffer) string { return strconv.FormatBool(s.RefreshRecommended) }},
+}
+
+// CSV renders v as comma-separated rows with a header: search results as
+// one row per flight or stay, any other list as one row per item, and
+// errors as an error/details row. Headers follow the field map.
+func CSV(v interface{}) ([]byte, error) {
+	switch d := v.(type) {
+	case *core.SearchResult:
+		return searchCSV(d)
+	case core.SearchResult:
+		return searchCSV(&d)
+	case ErrorResponse:
+		return csvTable([]string{"error", "details"}, [][]string{{d.Error, d.Details}})
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+	return documentCSV(data)
+}
+
+func writeCSV(v interface{}) error {
+	data, err := CSV(v)
+	if err != nil {
+		return err
+	}
+	_, err = Writer.Write(data)
+	return err
+}
+
+func searchCSV(r *core.SearchResult) ([]byte, error) {
+	if len(r.Flights) > 0 && len(r.Stays) > 0 {
+		return nil, errors.New("csv output holds flights or stays, not both; use --format json")
+	}
+	if len(r.Stays) > 0 {
+		return offerCSV(stayColumns, r.Stays)
+	}
+	return offerCSV(flightColumns, r.Flights)
+}
+
+func offerCSV[T any](columns []column[T], offers []T) ([]byte, error) {
+	var (
+		header []string
+		keep   []column[T]
+	)
+	for _, c := range columns {
+		name, ok := fields.alias(c.name)
+		if !ok {
+			continue
+		}
+		header = append(header, name)
+		keep = append(keep, c)
+	}
+	rows := make([][]string, len(offers))
+	for i, o := range offers {
+		rows[i] = make([]string, len(keep))
+		for j, c := range keep {
+			rows[i][j] = c.value(o)
+		}
+	}
+	return csvTable(header, rows)
+}
+
+// documentCSV flattens a JSON list of objects, one column per key in
+// first-seen order. Nested values are written as compact JSON.
+func documentCSV(data []byte) ([]byte, error) {
+	data, err := fields.Rewrite(data)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite fields: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, fmt.Errorf("json decode: %w", err)
+	}
+	items, ok := doc.([]interface{})
+	if !ok {
+		return nil, errors.New("csv output needs search results or a list; use --format json")
+	}
+	var header []string
+	seen := map[string]bool{}
+	for _, item := range items {
+		obj, ok := item.(orderedObject)
+		if !ok {
+			return nil, errors.New("csv output needs a list of objects; use --format json")
+		}
+		for _, f := range obj {
+			if !seen[f.key] {
+				seen[f.key] = true
+				header = append(header, f.key)
+			}
+		}
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		obj := item.(orderedObject)
+		rows[i] = make([]string, len(header))
+		for j, key := range header {
+			if val, ok := obj.get(key); ok {
+				rows[i][j] = csvValue(val)
+			}
+		}
+	}
+	return csvTable(header, rows)
+}
+
+func csvTable(header []string, rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func csvValue(v interface{}) string {
+	switch d := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return d
+	case json.Number:
+		return d.String()
+	case bool:
+		return strconv.FormatBool(d)
+	case []interface{}:
+		if isScalarList(d) {
+			parts := make([]string, len(d))
+			for i, item := range d {
+				parts[i] = csvValue(item)
+			}
+			return strings.Join(parts, "; ")
+		}
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// sourcePrices lists each provider's price as "source price" pairs.
+func sourcePrices(prices []core.SourcePrice) string {
+	parts := make([]string, len(prices))
+	for i, p := range prices {
+		parts[i] = p.Source + " " + number(p.PriceUSD)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
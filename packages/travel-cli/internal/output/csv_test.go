@@ -0,0 +1,50 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestCSVFlights(t *testing.T) {
+	SetFieldMap(NewFieldMap(map[string]string{"priceUSD": "price_usd"}, []string{"confidence", "fetchedAt", "expiresAt", "ageSeconds", "refreshRecommended", "isBookable", "repriceRequired", "currency", "cabinClass", "arriveTime", "durationMinutes"}))
+	defer SetFieldMap(nil)
+
+	result := &core.SearchResult{Flights: []core.FlightOffer{{
+		ID: "f_1", Source: "duffel", Airline: "Air Canada, Inc.", FlightNumber: "AC870", From: "YUL", To: "CDG",
+		DepartTime: time.Date(2026, 6, 12, 18, 30, 0, 0, time.UTC), PriceUSD: 612.5,
+		Prices:   []core.SourcePrice{{Source: "duffel", PriceUSD: 612.5}, {Source: "kiwi", PriceUSD: 640}},
+		DeepLink: "https://x.test/book?a=1&b=2",
+	}}}
+
+	data, err := CSV(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id,source,airline,flightNumber,from,to,departTime,stops,price_usd,prices,deepLink\n" +
+		`f_1,duffel,"Air Canada, Inc.",AC870,YUL,CDG,2026-06-12T18:30:00Z,0,612.5,duffel 612.5; kiwi 640,https://x.test/book?a=1&b=2` + "\n"
+	if string(data) != want {
+		t.Errorf("got\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestCSVDocument(t *testing.T) {
+	data, err := CSV([]map[string]interface{}{{"id": "a1", "tags": []string{"x", "y"}}, {"id": "a2", "notify": map[string]string{"slack": "s"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id,tags,notify\na1,x; y,\na2,,\"{\"\"slack\"\":\"\"s\"\"}\"\n"
+	if string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	if _, err := CSV(map[string]int{"count": 1}); err == nil || !strings.Contains(err.Error(), "list") {
+		t.Errorf("err = %v, want error for a non-list document", err)
+	}
+	both := &core.SearchResult{Flights: []core.FlightOffer{{}}, Stays: []core.StayOffer{{}}}
+	if _, err := CSV(both); err == nil {
+		t.Error("want error for flights and stays together")
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/beetlebot/travel-cli/internal/schema"
+)
+
+// envelope wraps each JSON document a command writes. SchemaVersion is
+// the version of the command's data in the schema registry, so readers
+// can detect shape changes. Errors lists the command's own failures, as
+// ErrorResponse objects with Data null; provider errors stay in the data.
+type envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Command       string          `json:"command"`
+	Data          json.RawMessage `json:"data"`
+	Errors        json.RawMessage `json:"errors,omitempty"`
+}
+
+var command string
+
+// SetCommand wraps JSON documents written from now on in an envelope for
+// the named command, such as "flights search". Empty writes them bare.
+// Event streams from JSONCompact, GeoJSON, and the Markdown, CSV, and
+// template formats are never wrapped.
+func SetCommand(name string) {
+	command = name
+}
+
+// writeEnvelope wraps an encoded document. The field map applies to the
+// data and errors but not to the envelope's own fields.
+func writeEnvelope(data []byte, errs []ErrorResponse) error {
+	env := envelope{SchemaVersion: schema.Version(command), Command: command, Data: json.RawMessage("null")}
+	if data != nil {
+		rewritten, err := fields.Rewrite(data)
+		if err != nil {
+			return fmt.Errorf("rewrite fields: %w", err)
+		}
+		env.Data = rewritten
+	}
+	if len(errs) > 0 {
+		encoded, err := marshal(errs)
+		if err != nil {
+			return err
+		}
+		if env.Errors, err = fields.Rewrite(encoded); err != nil {
+			return fmt.Errorf("rewrite fields: %w", err)
+		}
+	}
+	doc, err := marshal(env)
+	if err != nil {
+		return err
+	}
+	return writeIndented(doc, "  ")
+}
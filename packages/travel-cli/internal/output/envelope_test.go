@@ -0,0 +1,61 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestEnvelope(t *testing.T) {
+	var buf bytes.Buffer
+	prev := Writer
+	Writer = &buf
+	SetCommand("flights search")
+	SetFieldMap(NewFieldMap(map[string]string{"airline": "carrier"}, []string{"details"}))
+	defer func() { Writer = prev; SetCommand(""); SetFieldMap(nil) }()
+
+	var env struct {
+		SchemaVersion int                          `json:"schemaVersion"`
+		Command       string                       `json:"command"`
+		Data          map[string]json.RawMessage   `json:"data"`
+		Errors        []map[string]json.RawMessage `json:"errors"`
+	}
+	decode := func() {
+		t.Helper()
+		env.Data, env.Errors = nil, nil
+		if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+			t.Fatalf("decode %q: %v", buf.String(), err)
+		}
+		buf.Reset()
+	}
+
+	if err := JSON(&core.SearchResult{Flights: []core.FlightOffer{{Airline: "Delta"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("{\n  \"schemaVersion\": 1,\n  \"command\": \"flights search\",\n")) {
+		t.Errorf("rendered envelope = %s; want it to open with the schema version and command", buf.Bytes())
+	}
+	decode()
+	if env.SchemaVersion != 1 || env.Command != "flights search" || env.Data["flights"] == nil || env.Errors != nil {
+		t.Errorf("result envelope = %+v", env)
+	}
+	if !bytes.Contains(env.Data["flights"], []byte(`"carrier"`)) || bytes.Contains(env.Data["flights"], []byte(`"airline"`)) {
+		t.Errorf("field map should apply to data: %s", env.Data["flights"])
+	}
+
+	JSONError("search failed", "boom")
+	decode()
+	if env.Data != nil || len(env.Errors) != 1 || string(env.Errors[0]["error"]) != `"search failed"` || env.Errors[0]["details"] != nil {
+		t.Errorf("error envelope = %+v", env)
+	}
+
+	if err := RawJSON([]byte(`{"flights":[],"extra":true}`)); err != nil {
+		t.Fatal(err)
+	}
+	decode()
+	if string(env.Data["extra"]) != "true" {
+		t.Errorf("raw documents should be wrapped as data: %+v", env)
+	}
+}
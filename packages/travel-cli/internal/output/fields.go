@@ -0,0 +1,183 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FieldMap renames and drops object keys in every document written, so
+// integrators get the field names their pipelines expect. Keys match at
+// any depth, and documents keep their field order.
+type FieldMap struct {
+	rename map[string]string
+	drop   map[string]bool
+}
+
+var fields *FieldMap
+
+// NewFieldMap returns nil when there is nothing to rewrite.
+func NewFieldMap(rename map[string]string, drop []string) *FieldMap {
+	if len(rename) == 0 && len(drop) == 0 {
+		return nil
+	}
+	m := &FieldMap{rename: rename, drop: make(map[string]bool, len(drop))}
+	for _, k := range drop {
+		m.drop[k] = true
+	}
+	return m
+}
+
+// SetFieldMap applies m to everything written by this package from now
+// on. nil restores field names as-is.
+func SetFieldMap(m *FieldMap) {
+	fields = m
+}
+
+// alias returns the name key is written under, and false when it is
+// dropped.
+func (m *FieldMap) alias(key string) (string, bool) {
+	if m == nil {
+		return key, true
+	}
+	if m.drop[key] {
+		return "", false
+	}
+	if alias, ok := m.rename[key]; ok {
+		return alias, true
+	}
+	return key, true
+}
+
+type frame struct {
+	object    bool
+	n         int
+	expectKey bool
+}
+
+// Rewrite applies the map to one JSON document.
+func (m *FieldMap) Rewrite(data []byte) ([]byte, error) {
+	if m == nil {
+		return data, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var (
+		out   bytes.Buffer
+		stack []*frame
+	)
+	top := func() *frame {
+		if len(stack) == 0 {
+			return nil
+		}
+		return stack[len(stack)-1]
+	}
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if f := top(); f != nil && f.object && f.expectKey {
+			if d, ok := tok.(json.Delim); ok && d == '}' {
+				out.WriteByte('}')
+				stack = stack[:len(stack)-1]
+				valueDone(top())
+				continue
+			}
+			key := tok.(string)
+			if m.drop[key] {
+				if err := skipValue(dec); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if alias, ok := m.rename[key]; ok {
+				key = alias
+			}
+			if f.n > 0 {
+				out.WriteByte(',')
+			}
+			f.n++
+			if err := writeScalar(&out, key); err != nil {
+				return nil, err
+			}
+			out.WriteByte(':')
+			f.expectKey = false
+			continue
+		}
+
+		if f := top(); f != nil && !f.object {
+			if d, ok := tok.(json.Delim); !ok || d != ']' {
+				if f.n > 0 {
+					out.WriteByte(',')
+				}
+				f.n++
+			}
+		}
+		switch t := tok.(type) {
+		case json.Delim:
+			out.WriteByte(byte(t.String()[0]))
+			switch t {
+			case '{', '[':
+				stack = append(stack, &frame{object: t == '{', expectKey: t == '{'})
+				continue
+			default:
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			if err := writeScalar(&out, t); err != nil {
+				return nil, err
+			}
+		}
+		valueDone(top())
+	}
+	return out.Bytes(), nil
+}
+
+// valueDone marks a completed value in its enclosing object, so the next
+// token is read as a key.
+func valueDone(f *frame) {
+	if f != nil && f.object {
+		f.expectKey = true
+	}
+}
+
+func writeScalar(out *bytes.Buffer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("json marshal: %w", err)
+	}
+	out.Truncate(out.Len() - 1) // Encode appends a newline
+	return nil
+}
+
+// skipValue consumes the next value, including nested objects and
+// arrays.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			default:
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFieldMapRewrite(t *testing.T) {
+	m := NewFieldMap(map[string]string{"priceUSD": "price_usd"}, []string{"confidence", "prices"})
+	in := `{"flights":[{"id":"f_1","priceUSD":500,"confidence":0.9,"prices":[{"source":"a","priceUSD":1}],"deepLink":"https://x.test/?a=1&b=2"},{}],"empty":[],"none":null,"ok":true}`
+
+	got, err := m.Rewrite([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"flights":[{"id":"f_1","price_usd":500,"deepLink":"https://x.test/?a=1&b=2"},{}],"empty":[],"none":null,"ok":true}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestFieldMapAppliesToOutput(t *testing.T) {
+	var buf bytes.Buffer
+	prev := Writer
+	Writer = &buf
+	SetFieldMap(NewFieldMap(nil, []string{"details"}))
+	defer func() { Writer = prev; SetFieldMap(nil) }()
+
+	JSONError("search failed", "boom")
+	if buf.String() != "{\n  \"error\": \"search failed\"\n}\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	if NewFieldMap(nil, nil) != nil {
+		t.Error("empty config should disable rewriting")
+	}
+}
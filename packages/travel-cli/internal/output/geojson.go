@@ -0,0 +1,99 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// FormatGeoJSON writes stay results as a GeoJSON FeatureCollection for
+// dropping onto a map.
+const FormatGeoJSON Format = "geojson"
+
+// FeatureCollection is a minimal RFC 7946 GeoJSON document, enough for
+// Leaflet/Mapbox to plot stay offers without any client-side reshaping.
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+// StaysFeatureCollection converts stay offers to GeoJSON features. Offers
+// without coordinates keep a null geometry (allowed by the spec) so the
+// feature count always matches the result count.
+func StaysFeatureCollection(stays []core.StayOffer) FeatureCollection {
+	fc := FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+	for _, s := range stays {
+		f := Feature{
+			Type: "Feature",
+			ID:   s.ID,
+			Properties: map[string]interface{}{
+				"name":          s.Name,
+				"type":          s.Type,
+				"source":        s.Source,
+				"price":         s.PricePerNight,
+				"totalPriceUSD": s.TotalPriceUSD,
+				"currency":      s.Currency,
+				"rating":        s.Rating,
+				"reviewCount":   s.ReviewCount,
+			},
+		}
+		if s.DeepLink != "" {
+			f.Properties["deepLink"] = s.DeepLink
+		}
+		if s.Neighborhood != "" {
+			f.Properties["neighborhood"] = s.Neighborhood
+		}
+		if s.Board != "" {
+			f.Properties["board"] = s.Board
+		}
+		if s.DistanceKm != 0 {
+			f.Properties["distanceKm"] = s.DistanceKm
+		}
+		if s.Latitude != 0 || s.Longitude != 0 {
+			// GeoJSON positions are [longitude, latitude].
+			f.Geometry = &Geometry{Type: "Point", Coordinates: []float64{s.Longitude, s.Latitude}}
+		}
+		fc.Features = append(fc.Features, f)
+	}
+	return fc
+}
+
+// writeGeoJSON writes the stays of a search result as a FeatureCollection.
+// Errors stay JSON documents.
+func writeGeoJSON(v interface{}) error {
+	var r *core.SearchResult
+	switch d := v.(type) {
+	case ErrorResponse:
+		return writeDocument(v)
+	case *core.SearchResult:
+		r = d
+	case core.SearchResult:
+		r = &d
+	case json.RawMessage:
+		// A postSearch hook's document, if it kept the result's shape.
+		var decoded core.SearchResult
+		if json.Unmarshal(d, &decoded) != nil {
+			return errors.New("geojson output needs stay search results; use --format json")
+		}
+		r = &decoded
+	default:
+		return errors.New("geojson output needs stay search results; use --format json")
+	}
+	if len(r.Flights) > 0 {
+		return errors.New("geojson output holds stays, not flights; use --format json")
+	}
+	return encode(StaysFeatureCollection(r.Stays), "  ")
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestStaysFeatureCollection(t *testing.T) {
+	stays := []core.StayOffer{
+		{ID: "a", Name: "Hotel A", PricePerNight: 120, Rating: 4.5, Latitude: 48.85, Longitude: 2.35},
+		{ID: "b", Name: "Hotel B", PricePerNight: 90, Rating: 4.1},
+	}
+
+	fc := StaysFeatureCollection(stays)
+	if fc.Type != "FeatureCollection" {
+		t.Fatalf("expected FeatureCollection, got %s", fc.Type)
+	}
+	if len(fc.Features) != 2 {
+		t.Fatalf("expected 2 features, got %d", len(fc.Features))
+	}
+
+	first := fc.Features[0]
+	if first.Geometry == nil || first.Geometry.Coordinates[0] != 2.35 || first.Geometry.Coordinates[1] != 48.85 {
+		t.Errorf("expected [lon, lat] point geometry, got %+v", first.Geometry)
+	}
+	if first.Properties["price"] != 120.0 || first.Properties["rating"] != 4.5 {
+		t.Errorf("unexpected properties: %+v", first.Properties)
+	}
+
+	if fc.Features[1].Geometry != nil {
+		t.Errorf("expected null geometry for offer without coordinates")
+	}
+}
+
+func TestFormatGeoJSON(t *testing.T) {
+	var buf bytes.Buffer
+	prev := Writer
+	Writer = &buf
+	SetFormat(FormatGeoJSON)
+	SetCommand("stays search")
+	defer func() { Writer = prev; SetFormat(FormatJSON); SetCommand("") }()
+
+	result := &core.SearchResult{Stays: []core.StayOffer{{ID: "a", Name: "Hotel A", PricePerNight: 120, Latitude: 48.85, Longitude: 2.35}}}
+	if err := JSON(result); err != nil {
+		t.Fatal(err)
+	}
+	var fc FeatureCollection
+	if err := json.Unmarshal(buf.Bytes(), &fc); err != nil {
+		t.Fatalf("output is not a bare FeatureCollection: %v\n%s", err, buf.String())
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 1 || fc.Features[0].ID != "a" {
+		t.Errorf("got %+v", fc)
+	}
+
+	if err := JSON(&core.SearchResult{Flights: []core.FlightOffer{{ID: "f"}}}); err == nil {
+		t.Error("geojson accepted flight results")
+	}
+	if err := JSON([]string{"x"}); err == nil {
+		t.Error("geojson accepted a document that isn't a search result")
+	}
+}
@@ -0,0 +1,235 @@
+package output
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/fx"
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+// FormatICS writes trips and flight offers as iCalendar (RFC 5545) events
+// for importing into calendar apps.
+const FormatICS Format = "ics"
+
+// ICS renders a trip or flight offer as a calendar: one event per timed
+// flight, rail, or car segment, from departure to arrival, and an all-day
+// event per stay spanning check-in to check-out. Segments without a
+// departure time are left out since they can't be placed on a calendar.
+func ICS(v interface{}) ([]byte, error) {
+	c := &calendar{}
+	switch d := v.(type) {
+	case *trips.Trip:
+		c.trip(d)
+	case trips.Trip:
+		c.trip(&d)
+	case *core.FlightOffer:
+		c.flight(d)
+	case core.FlightOffer:
+		c.flight(&d)
+	default:
+		return nil, errors.New("ics output needs a trip or a flight offer; use --format json")
+	}
+	return c.bytes(), nil
+}
+
+func writeICS(v interface{}) error {
+	if _, ok := v.(ErrorResponse); ok {
+		return writeDocument(v)
+	}
+	data, err := ICS(v)
+	if err != nil {
+		return err
+	}
+	_, err = Writer.Write(data)
+	return err
+}
+
+// calendar accumulates the content lines of a VCALENDAR.
+type calendar struct {
+	events []string
+}
+
+func (c *calendar) trip(t *trips.Trip) {
+	stamp := t.UpdatedAt
+	for _, seg := range t.Segments {
+		uid := segmentUID(t.Name, seg)
+		if seg.Kind == trips.KindStay {
+			c.stay(uid, stamp, seg)
+			continue
+		}
+		if seg.DepartTime.IsZero() {
+			continue
+		}
+		var details []string
+		if seg.CabinClass != "" {
+			details = append(details, "Cabin: "+seg.CabinClass)
+		}
+		if seg.Passengers > 1 {
+			details = append(details, "Passengers: "+strconv.Itoa(seg.Passengers))
+		}
+		if seg.Confirmation != "" {
+			details = append(details, "Confirmation: "+seg.Confirmation)
+		}
+		if seg.OfferID != "" {
+			details = append(details, "Offer: "+seg.OfferID)
+		}
+		summary := fmt.Sprintf("%s %s → %s", kindTitle(seg.Kind), seg.From, seg.To)
+		if id := strings.TrimSpace(seg.Carrier + " " + seg.Number); id != "" {
+			summary += " (" + id + ")"
+		}
+		c.event(uid, stamp, []string{
+			"DTSTART:" + icsTime(seg.DepartTime),
+			endLine(seg.DepartTime, seg.ArriveTime),
+			"SUMMARY:" + icsText(summary),
+			"LOCATION:" + icsText(seg.From),
+			"DESCRIPTION:" + icsText(strings.Join(details, "\n")),
+		})
+	}
+}
+
+func (c *calendar) stay(uid string, stamp time.Time, seg trips.Segment) {
+	checkIn, errIn := time.Parse("2006-01-02", seg.CheckIn)
+	checkOut, errOut := time.Parse("2006-01-02", seg.CheckOut)
+	if errIn != nil || errOut != nil {
+		return
+	}
+	details := []string{"Check-in: " + seg.CheckIn, "Check-out: " + seg.CheckOut}
+	if seg.Confirmation != "" {
+		details = append(details, "Confirmation: "+seg.Confirmation)
+	}
+	if seg.OfferID != "" {
+		details = append(details, "Offer: "+seg.OfferID)
+	}
+	c.event(uid, stamp, []string{
+		"DTSTART;VALUE=DATE:" + checkIn.Format("20060102"),
+		"DTEND;VALUE=DATE:" + checkOut.Format("20060102"),
+		"SUMMARY:" + icsText("Stay: "+seg.Name),
+		"LOCATION:" + icsText(seg.Name),
+		"DESCRIPTION:" + icsText(strings.Join(details, "\n")),
+		"TRANSP:TRANSPARENT",
+	})
+}
+
+func (c *calendar) flight(f *core.FlightOffer) {
+	details := []string{
+		strings.TrimSpace(f.Airline + " " + f.FlightNumber),
+		// Offers are converted to dollars as they arrive; one left in
+		// another currency keeps its own code, and one without a code
+		// was priced in dollars.
+		fmt.Sprintf("Price: %s %s", number(f.PriceUSD), cmp.Or(f.Currency, fx.USD)),
+	}
+	if f.CabinClass != "" {
+		details = append(details, "Cabin: "+f.CabinClass)
+	}
+	if f.Stops > 0 {
+		details = append(details, "Stops: "+strconv.Itoa(f.Stops))
+	}
+	details = append(details, "Offer: "+f.ID)
+	lines := []string{
+		"DTSTART:" + icsTime(f.DepartTime),
+		endLine(f.DepartTime, f.ArriveTime),
+		"SUMMARY:" + icsText(fmt.Sprintf("Flight %s → %s (%s %s)", f.From, f.To, f.Airline, f.FlightNumber)),
+		"LOCATION:" + icsText(f.From),
+		"DESCRIPTION:" + icsText(strings.Join(details, "\n")),
+	}
+	if f.DeepLink != "" {
+		lines = append(lines, "URL:"+f.DeepLink)
+	}
+	c.event(f.ID+"@travel.beetlebot", f.FetchedAt, lines)
+}
+
+func (c *calendar) event(uid string, stamp time.Time, lines []string) {
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	c.events = append(c.events, "BEGIN:VEVENT", "UID:"+uid, "DTSTAMP:"+icsTime(stamp))
+	for _, l := range lines {
+		if l != "" {
+			c.events = append(c.events, l)
+		}
+	}
+	c.events = append(c.events, "END:VEVENT")
+}
+
+func (c *calendar) bytes() []byte {
+	lines := append([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Beetlebot//travel-cli//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}, c.events...)
+	lines = append(lines, "END:VCALENDAR")
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(foldLine(l))
+		b.WriteString("\r\n")
+	}
+	return []byte(b.String())
+}
+
+// segmentUID identifies a segment's event by what the segment is rather
+// than its position, so re-importing an edited trip updates events
+// instead of duplicating them.
+func segmentUID(trip string, seg trips.Segment) string {
+	h := fnv.New32a()
+	for _, part := range []string{string(seg.Kind), seg.From, seg.To, seg.DepartTime.UTC().Format(time.RFC3339), seg.Name, seg.CheckIn} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%s-%s-%08x@travel.beetlebot", trip, seg.Kind, h.Sum32())
+}
+
+// endLine is the DTEND for a timed event, or nothing when the arrival is
+// unknown or not after departure.
+func endLine(depart, arrive time.Time) string {
+	if !arrive.After(depart) {
+		return ""
+	}
+	return "DTEND:" + icsTime(arrive)
+}
+
+func icsTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icsText escapes a TEXT value: backslashes, semicolons, commas, and
+// newlines.
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldLine splits content lines longer than 75 octets, continuing each
+// on a line starting with a space, without splitting UTF-8 characters.
+func foldLine(l string) string {
+	if len(l) <= 75 {
+		return l
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range l {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
+
+func kindTitle(k trips.SegmentKind) string {
+	s := string(k)
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,99 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+func TestICSTrip(t *testing.T) {
+	trip := &trips.Trip{
+		Name:      "paris",
+		UpdatedAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC),
+		Segments: []trips.Segment{
+			{Kind: trips.KindFlight, From: "YUL", To: "CDG", Carrier: "AC", Number: "870",
+				DepartTime:   time.Date(2026, 6, 12, 8, 0, 0, 0, time.FixedZone("EDT", -4*3600)),
+				ArriveTime:   time.Date(2026, 6, 12, 20, 30, 0, 0, time.UTC),
+				Confirmation: "XYZ123"},
+			{Kind: trips.KindStay, Name: "Grand Hotel, Central", CheckIn: "2026-06-12", CheckOut: "2026-06-15"},
+			{Kind: trips.KindRail, From: "Paris", To: "Lyon"},
+		},
+	}
+
+	data, err := ICS(trip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20260612T120000Z\r\nDTEND:20260612T203000Z\r\n",
+		"SUMMARY:Flight YUL → CDG (AC 870)\r\n",
+		"DESCRIPTION:Confirmation: XYZ123\r\n",
+		"DTSTART;VALUE=DATE:20260612\r\nDTEND;VALUE=DATE:20260615\r\n",
+		`SUMMARY:Stay: Grand Hotel\, Central`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("got %d events, want 2 (the untimed rail segment is left out)", n)
+	}
+
+	// Event UIDs survive segments being added before them.
+	trip.Segments = append([]trips.Segment{{Kind: trips.KindCar, From: "Home", To: "YUL", DepartTime: time.Date(2026, 6, 12, 5, 0, 0, 0, time.UTC)}}, trip.Segments...)
+	again, _ := ICS(trip)
+	uid := segmentUID("paris", trip.Segments[1])
+	if !strings.Contains(out, uid) || !strings.Contains(string(again), uid) {
+		t.Errorf("flight UID %s changed when a segment was added", uid)
+	}
+}
+
+func TestICSFlightOffer(t *testing.T) {
+	data, err := ICS(core.FlightOffer{
+		ID: "f_1", Airline: "Air Canada", FlightNumber: "AC870", From: "YUL", To: "CDG",
+		DepartTime: time.Date(2026, 6, 12, 18, 30, 0, 0, time.UTC), ArriveTime: time.Date(2026, 6, 13, 7, 15, 0, 0, time.UTC),
+		PriceUSD: 612.5, Currency: "USD", DeepLink: "https://x.test/book?a=1&b=2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{"UID:f_1@travel.beetlebot", "DTEND:20260613T071500Z", "Price: 612.5 USD", "URL:https://x.test/book?a=1&b=2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar missing %q:\n%s", want, out)
+		}
+	}
+
+	for currency, want := range map[string]string{"EUR": "Price: 540 EUR", "": "Price: 540 USD"} {
+		data, err := ICS(core.FlightOffer{ID: "f_2", From: "YUL", To: "CDG", PriceUSD: 540, Currency: currency})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("currency %q: calendar missing %q:\n%s", currency, want, data)
+		}
+	}
+
+	if _, err := ICS([]string{"x"}); err == nil {
+		t.Error("ICS accepted a document that isn't a trip or flight")
+	}
+}
+
+func TestICSFoldsLongLines(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := foldLine(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("folded line has %d octets: %q", len(part), part)
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Errorf("unfolding %q doesn't give back the line", folded)
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,21 +10,134 @@ import (
 
 var Writer io.Writer = os.Stdout
 
+// ProgressWriter receives progress events, so Writer only ever holds the
+// final document.
+var ProgressWriter io.Writer = os.Stderr
+
 func JSON(v interface{}) error {
-	data, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
+	switch format {
+	case FormatMarkdown:
+		return writeMarkdown(v)
+	case FormatCSV:
+		return writeCSV(v)
+	case FormatTemplate:
+		return writeTemplate(v)
+	case FormatICS:
+		return writeICS(v)
+	case FormatGeoJSON:
+		return writeGeoJSON(v)
+	}
+	return writeDocument(v)
+}
+
+func JSONCompact(v interface{}) error {
+	return encode(v, "")
+}
+
+// Progress writes v to ProgressWriter as one compact JSON line, whatever
+// the output format.
+func Progress(v interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
 		return fmt.Errorf("json marshal: %w", err)
 	}
-	_, err = fmt.Fprintln(Writer, string(data))
+	_, err := ProgressWriter.Write(buf.Bytes())
 	return err
 }
 
-func JSONCompact(v interface{}) error {
-	data, err := json.Marshal(v)
+// encode writes v followed by a newline. HTML escaping is off so deep
+// links keep a literal & between query parameters.
+func encode(v interface{}, indent string) error {
+	data, err := marshal(v)
 	if err != nil {
-		return fmt.Errorf("json marshal: %w", err)
+		return err
+	}
+	return writeRewritten(data, indent)
+}
+
+// writeDocument writes v as a command's JSON document, in an envelope
+// once SetCommand has named the command.
+func writeDocument(v interface{}) error {
+	if command == "" {
+		return encode(v, "  ")
+	}
+	if e, ok := v.(ErrorResponse); ok {
+		return writeEnvelope(nil, []ErrorResponse{e})
+	}
+	data, err := marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeEnvelope(data, nil)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("json marshal: %w", err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// RawJSON writes an already-encoded document, indented like JSON or
+// rendered like any other document in Markdown and CSV. Templates see
+// it decoded, keyed by its JSON field names.
+func RawJSON(raw []byte) error {
+	switch format {
+	case FormatMarkdown:
+		s, err := documentMarkdown(raw)
+		if err != nil {
+			return err
+		}
+		_, err = Writer.Write([]byte(s))
+		return err
+	case FormatCSV:
+		data, err := documentCSV(raw)
+		if err != nil {
+			return err
+		}
+		_, err = Writer.Write(data)
+		return err
+	case FormatTemplate:
+		var doc interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("json decode: %w", err)
+		}
+		return writeTemplate(doc)
+	case FormatICS:
+		return writeICS(json.RawMessage(raw))
+	case FormatGeoJSON:
+		return writeGeoJSON(json.RawMessage(raw))
+	}
+	if command != "" {
+		return writeEnvelope(raw, nil)
+	}
+	return writeRewritten(raw, "  ")
+}
+
+// writeRewritten applies the field map to doc and writes it, indented
+// unless indent is empty.
+func writeRewritten(doc []byte, indent string) error {
+	doc, err := fields.Rewrite(doc)
+	if err != nil {
+		return fmt.Errorf("rewrite fields: %w", err)
+	}
+	return writeIndented(doc, indent)
+}
+
+func writeIndented(doc []byte, indent string) error {
+	var buf bytes.Buffer
+	if indent == "" {
+		buf.Write(doc)
+	} else if err := json.Indent(&buf, doc, "", indent); err != nil {
+		return fmt.Errorf("json indent: %w", err)
 	}
-	_, err = fmt.Fprintln(Writer, string(data))
+	buf.WriteByte('\n')
+	_, err := Writer.Write(buf.Bytes())
 	return err
 }
 
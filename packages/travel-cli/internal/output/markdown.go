@@ -0,0 +1,464 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// Format selects how JSON and RawJSON render documents.
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+)
+
+var format = FormatJSON
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "json":
+		return FormatJSON, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "csv":
+		return FormatCSV, nil
+	case "ics":
+		return FormatICS, nil
+	case "geojson":
+		return FormatGeoJSON, nil
+	}
+	return "", fmt.Errorf("unsupported --format %q (use json, markdown, csv, ics, or geojson)", s)
+}
+
+// SetFormat renders everything written by JSON and RawJSON in f from now
+// on. JSONCompact stays JSON since it writes event streams.
+func SetFormat(f Format) {
+	format = f
+}
+
+// Markdown renders v for pasting into notes or chat: search results as a
+// summary section and offer tables, errors as a short message, and any
+// other document as field lists and tables.
+func Markdown(v interface{}) (string, error) {
+	switch d := v.(type) {
+	case *core.SearchResult:
+		return searchMarkdown(d), nil
+	case core.SearchResult:
+		return searchMarkdown(&d), nil
+	case ErrorResponse:
+		s := paint(colorRed, "**Error:**") + " " + d.Error + "\n"
+		if d.Details != "" {
+			s += "\n" + d.Details + "\n"
+		}
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("json marshal: %w", err)
+	}
+	return documentMarkdown(data)
+}
+
+func writeMarkdown(v interface{}) error {
+	s, err := Markdown(v)
+	if err != nil {
+		return err
+	}
+	_, err = Writer.Write([]byte(s))
+	return err
+}
+
+func searchMarkdown(r *core.SearchResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", searchTitle(r.Query))
+
+	b.WriteString("## Summary\n\n")
+	shown := len(r.Flights) + len(r.Stays)
+	fmt.Fprintf(&b, "- **Results:** %d shown of %d found\n", shown, r.TotalFound)
+	if s := r.Summary; s != nil {
+		fmt.Fprintf(&b, "- **Price:** %s min, %s median, %s max\n", usd(s.MinPriceUSD), usd(s.MedianPriceUSD), usd(s.MaxPriceUSD))
+		if s.DirectFlights != nil {
+			fmt.Fprintf(&b, "- **Direct flights:** %d\n", *s.DirectFlights)
+		}
+		if s.AvgRating > 0 {
+			fmt.Fprintf(&b, "- **Average rating:** %.1f\n", s.AvgRating)
+		}
+	}
+	if len(r.Providers) > 0 {
+		fmt.Fprintf(&b, "- **Providers:** %s (%s mode)\n", strings.Join(r.Providers, ", "), r.Mode)
+	}
+	if !r.FetchedAt.IsZero() {
+		fmt.Fprintf(&b, "- **Fetched:** %s\n", r.FetchedAt.UTC().Format("2006-01-02 15:04 MST"))
+	}
+	if s := r.Summary; s != nil && len(s.BySource) > 1 {
+		sources := make([]string, 0, len(s.BySource))
+		for src := range s.BySource {
+			sources = append(sources, src)
+		}
+		sort.Strings(sources)
+		rows := make([][]string, len(sources))
+		for i, src := range sources {
+			ss := s.BySource[src]
+			rows[i] = []string{src, strconv.Itoa(ss.Count), usd(ss.MinPriceUSD), usd(ss.AvgPriceUSD)}
+		}
+		b.WriteString("\n")
+		table(&b, []string{"Source", "Offers", "Min", "Avg"}, rows)
+	}
+
+	if len(r.Flights) > 0 {
+		b.WriteString("\n## Flights\n\n")
+		rows := make([][]string, len(r.Flights))
+		low := minPrice(len(r.Flights), func(i int) float64 { return r.Flights[i].PriceUSD })
+		for i, f := range r.Flights {
+			rows[i] = []string{
+				strconv.Itoa(i + 1), f.Airline, f.FlightNumber, f.From + " → " + f.To,
+				clock(f.DepartTime), clock(f.ArriveTime), duration(f.DurationMinutes),
+				strconv.Itoa(f.Stops), cheapest(usd(f.PriceUSD), f.PriceUSD, low), f.Source, link(f.DeepLink),
+			}
+		}
+		table(&b, []string{"#", "Airline", "Flight", "Route", "Depart", "Arrive", "Duration", "Stops", "Price", "Source", "Book"}, rows)
+	}
+
+	if len(r.Stays) > 0 {
+		b.WriteString("\n## Stays\n\n")
+		rows := make([][]string, len(r.Stays))
+		low := minPrice(len(r.Stays), func(i int) float64 { return r.Stays[i].TotalPriceUSD })
+		for i, s := range r.Stays {
+			rating := ""
+			if s.Rating > 0 {
+				rating = fmt.Sprintf("%.1f", s.Rating)
+			}
+			rows[i] = []string{
+				strconv.Itoa(i + 1), s.Name, s.Type, s.Neighborhood, rating,
+				usd(s.PricePerNight), cheapest(usd(s.TotalPriceUSD), s.TotalPriceUSD, low), s.Source, link(s.DeepLink),
+			}
+		}
+		table(&b, []string{"#", "Name", "Type", "Area", "Rating", "Per night", "Total", "Source", "Book"}, rows)
+	}
+
+	if len(r.Combined) > 0 {
+		b.WriteString("\n## Flight + stay\n\n")
+		rows := make([][]string, len(r.Combined))
+		low := minPrice(len(r.Combined), func(i int) float64 { return r.Combined[i].TotalPriceUSD })
+		for i, c := range r.Combined {
+			warnings := make([]string, len(c.Warnings))
+			for j, w := range c.Warnings {
+				warnings[j] = w.Message
+			}
+			rows[i] = []string{c.FlightOfferID, c.StayOfferID, cheapest(usd(c.TotalPriceUSD), c.TotalPriceUSD, low), paint(colorYellow, strings.Join(warnings, "; "))}
+		}
+		table(&b, []string{"Flight", "Stay", "Total", "Warnings"}, rows)
+	}
+
+	if len(r.Groups) > 0 {
+		b.WriteString("\n## Groups\n\n")
+		rows := make([][]string, len(r.Groups))
+		low := minPrice(len(r.Groups), func(i int) float64 { return r.Groups[i].MinPriceUSD })
+		for i, g := range r.Groups {
+			best := ""
+			switch {
+			case g.BestFlight != nil:
+				best = g.BestFlight.Airline + " " + g.BestFlight.FlightNumber
+			case g.BestStay != nil:
+				best = g.BestStay.Name
+			}
+			rows[i] = []string{g.Key, strconv.Itoa(g.Count), cheapest(usd(g.MinPriceUSD), g.MinPriceUSD, low), best}
+		}
+		table(&b, []string{"Group", "Offers", "From", "Best"}, rows)
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("\n## Provider errors\n\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(&b, "- %s %s\n", paint(colorRed, "**"+e.Provider+":**"), cell(e.Reason))
+		}
+	}
+	return b.String()
+}
+
+// searchTitle names the search from its query, which is a request struct
+// or, after a postSearch hook, a decoded JSON object.
+func searchTitle(query interface{}) string {
+	var q struct {
+		From       string `json:"from"`
+		To         string `json:"to"`
+		DepartDate string `json:"departDate"`
+		ReturnDate string `json:"returnDate"`
+		City       string `json:"city"`
+		CheckIn    string `json:"checkIn"`
+		CheckOut   string `json:"checkOut"`
+	}
+	if data, err := json.Marshal(query); err == nil {
+		_ = json.Unmarshal(data, &q)
+	}
+	switch {
+	case q.From != "" && q.To != "":
+		title := fmt.Sprintf("Flights %s → %s, %s", q.From, q.To, q.DepartDate)
+		if q.ReturnDate != "" {
+			title += " – " + q.ReturnDate
+		}
+		return title
+	case q.CheckIn != "":
+		title := "Stays"
+		if q.City != "" {
+			title += " in " + q.City
+		}
+		return fmt.Sprintf("%s, %s – %s", title, q.CheckIn, q.CheckOut)
+	}
+	return "Search results"
+}
+
+// documentMarkdown renders an arbitrary JSON document: scalar fields as a
+// list, arrays of objects as tables, and nested objects as sections.
+func documentMarkdown(data []byte) (string, error) {
+	data, err := fields.Rewrite(data)
+	if err != nil {
+		return "", fmt.Errorf("rewrite fields: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	doc, err := decodeOrdered(dec)
+	if err != nil {
+		return "", fmt.Errorf("json decode: %w", err)
+	}
+	var b strings.Builder
+	section(&b, doc, 2)
+	return strings.TrimLeft(b.String(), "\n"), nil
+}
+
+func section(b *strings.Builder, v interface{}, level int) {
+	switch d := v.(type) {
+	case orderedObject:
+		var nested []orderedField
+		list := false
+		for _, f := range d {
+			if isScalar(f.value) || isScalarList(f.value) {
+				fmt.Fprintf(b, "- **%s:** %s\n", f.key, cell(scalar(f.value)))
+				list = true
+				continue
+			}
+			nested = append(nested, f)
+		}
+		for _, f := range nested {
+			if list {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(b, "%s %s\n\n", strings.Repeat("#", min(level, 6)), f.key)
+			section(b, f.value, level+1)
+			list = true
+		}
+	case []interface{}:
+		if len(d) == 0 {
+			b.WriteString("_None._\n")
+			return
+		}
+		var header []string
+		seen := map[string]bool{}
+		for _, item := range d {
+			obj, ok := item.(orderedObject)
+			if !ok {
+				for _, item := range d {
+					fmt.Fprintf(b, "- %s\n", cell(scalar(item)))
+				}
+				return
+			}
+			for _, f := range obj {
+				if !seen[f.key] {
+					seen[f.key] = true
+					header = append(header, f.key)
+				}
+			}
+		}
+		rows := make([][]string, len(d))
+		for i, item := range d {
+			obj := item.(orderedObject)
+			rows[i] = make([]string, len(header))
+			for j, key := range header {
+				if val, ok := obj.get(key); ok {
+					rows[i][j] = scalar(val)
+				}
+			}
+		}
+		table(b, header, rows)
+	default:
+		b.WriteString(cell(scalar(v)) + "\n")
+	}
+}
+
+func table(b *strings.Builder, header []string, rows [][]string) {
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n|")
+	b.WriteString(strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			cells[i] = cell(c)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+}
+
+// cell makes s safe inside a table cell or list item.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case orderedObject, []interface{}:
+		return false
+	}
+	return true
+}
+
+func isScalarList(v interface{}) bool {
+	items, ok := v.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if !isScalar(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// scalar formats a value for one cell; nested values fall back to
+// compact JSON.
+func scalar(v interface{}) string {
+	switch d := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if strings.HasPrefix(d, "https://") || strings.HasPrefix(d, "http://") {
+			return link(d)
+		}
+		return d
+	case json.Number:
+		return d.String()
+	case bool:
+		return strconv.FormatBool(d)
+	case []interface{}:
+		if isScalarList(d) {
+			parts := make([]string, len(d))
+			for i, item := range d {
+				parts[i] = scalar(item)
+			}
+			return strings.Join(parts, ", ")
+		}
+	}
+	data, _ := json.Marshal(v)
+	return "`" + string(data) + "`"
+}
+
+// orderedObject is a decoded JSON object that keeps its field order, so
+// lists and table columns follow the document.
+type orderedObject []orderedField
+
+type orderedField struct {
+	key   string
+	value interface{}
+}
+
+func (o orderedObject) get(key string) (interface{}, bool) {
+	for _, f := range o {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+	return nil, false
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeScalar(&buf, f.key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrdered reads the next value, decoding objects as orderedObject
+// and arrays as []interface{}.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	d, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	if d == '[' {
+		items := []interface{}{}
+		for dec.More() {
+			item, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	}
+	obj := orderedObject{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeOrdered(dec)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, orderedField{key: key.(string), value: value})
+	}
+	_, err = dec.Token()
+	return obj, err
+}
+
+func link(url string) string {
+	if url == "" {
+		return ""
+	}
+	return "[link](" + strings.ReplaceAll(url, ")", "%29") + ")"
+}
+
+func usd(v float64) string {
+	return "$" + strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func clock(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func duration(minutes int) string {
+	if minutes <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestMarkdownSearchResult(t *testing.T) {
+	direct := 1
+	result := &core.SearchResult{
+		Query:      core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"},
+		Mode:       "mock",
+		Providers:  []string{"mock_flights"},
+		TotalFound: 4,
+		Flights: []core.FlightOffer{{
+			Airline: "Air | Canada", FlightNumber: "AC870", From: "YUL", To: "CDG",
+			DepartTime:      time.Date(2026, 6, 12, 18, 30, 0, 0, time.UTC),
+			DurationMinutes: 425, PriceUSD: 612.5, Source: "duffel",
+			DeepLink: "https://x.test/book?a=1&b=2",
+		}},
+		Summary: &core.ResultSummary{Count: 4, MinPriceUSD: 612.5, MedianPriceUSD: 700, MaxPriceUSD: 900, DirectFlights: &direct},
+		Errors:  []core.ProviderError{{Provider: "kiwi", Reason: "timeout"}},
+	}
+
+	md, err := Markdown(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Flights YUL → CDG, 2026-06-12\n",
+		"- **Results:** 1 shown of 4 found\n",
+		"- **Price:** $612.50 min, $700.00 median, $900.00 max\n",
+		"| 1 | Air \\| Canada | AC870 | YUL → CDG | 2026-06-12 18:30 |  | 7h05m | 0 | $612.50 | duffel | [link](https://x.test/book?a=1&b=2) |\n",
+		"- **kiwi:** timeout\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in\n%s", want, md)
+		}
+	}
+}
+
+func TestMarkdownDocument(t *testing.T) {
+	var buf bytes.Buffer
+	prev := Writer
+	Writer = &buf
+	SetFormat(FormatMarkdown)
+	SetFieldMap(NewFieldMap(map[string]string{"priceUSD": "price"}, nil))
+	defer func() { Writer = prev; SetFormat(FormatJSON); SetFieldMap(nil) }()
+
+	doc := map[string]interface{}{
+		"count":  2,
+		"alerts": []map[string]interface{}{{"id": "a1", "priceUSD": 500}, {"id": "a2", "tags": []string{"x", "y"}}},
+	}
+	if err := JSON(doc); err != nil {
+		t.Fatal(err)
+	}
+	want := "- **count:** 2\n\n## alerts\n\n| id | price | tags |\n| --- | --- | --- |\n| a1 | 500 |  |\n| a2 |  | x, y |\n"
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	JSONError("search failed", "boom")
+	if buf.String() != "**Error:** search failed\n\nboom\n" {
+		t.Errorf("error = %q", buf.String())
+	}
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// ProgressText writes e to ProgressWriter as a line for people watching a
+// terminal, such as "querying duffel…" or "duffel returned 14 offers in
+// 2.3s". Progress writes the same events as JSON.
+func ProgressText(e core.ProgressEvent) error {
+	line := progressLine(e)
+	if line == "" {
+		return nil
+	}
+	_, err := fmt.Fprintln(ProgressWriter, line)
+	return err
+}
+
+func progressLine(e core.ProgressEvent) string {
+	elapsed := strconv.FormatFloat(float64(e.ElapsedMs)/1000, 'f', 1, 64) + "s"
+	switch e.Type {
+	case core.ProgressProviderStarted:
+		return "querying " + e.Provider + "…"
+	case core.ProgressProviderCompleted:
+		return fmt.Sprintf("%s returned %s in %s", e.Provider, offerCount(e.Offers), elapsed)
+	case core.ProgressProviderFailed:
+		return fmt.Sprintf("%s failed after %s: %s", e.Provider, elapsed, e.Reason)
+	case core.ProgressRankingDone:
+		return fmt.Sprintf("ranked %s in %s", offerCount(e.Offers), elapsed)
+	}
+	return ""
+}
+
+func offerCount(n *int) string {
+	if n == nil {
+		return "no offers"
+	}
+	if *n == 1 {
+		return "1 offer"
+	}
+	return strconv.Itoa(*n) + " offers"
+}
@@ -0,0 +1,36 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestProgressText(t *testing.T) {
+	var buf bytes.Buffer
+	old := ProgressWriter
+	ProgressWriter = &buf
+	defer func() { ProgressWriter = old }()
+
+	fourteen, one := 14, 1
+	events := []core.ProgressEvent{
+		{Type: core.ProgressProviderStarted, Provider: "duffel"},
+		{Type: core.ProgressProviderCompleted, Provider: "duffel", Offers: &fourteen, ElapsedMs: 2310},
+		{Type: core.ProgressProviderFailed, Provider: "kiwi", Reason: "timeout", ElapsedMs: 15000},
+		{Type: core.ProgressRankingDone, Offers: &one, ElapsedMs: 15004},
+	}
+	for _, e := range events {
+		if err := ProgressText(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := "querying duffel…\n" +
+		"duffel returned 14 offers in 2.3s\n" +
+		"kiwi failed after 15.0s: timeout\n" +
+		"ranked 1 offer in 15.0s\n"
+	if got := buf.String(); got != want {
+		t.Errorf("progress =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// FormatTemplate renders documents with the template set by SetTemplate.
+const FormatTemplate Format = "template"
+
+var tmpl *template.Template
+
+// templateFuncs are available to --template in addition to the text/template
+// builtins.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"usd":  usd,
+	"join": strings.Join,
+}
+
+// ParseTemplate reads a --template value: the template itself, or @path to
+// read it from a file. Fields are the Go names of the document, e.g.
+// {{range .Flights}}{{.Airline}} {{.PriceUSD}}{{"\n"}}{{end}}.
+func ParseTemplate(text string) (*template.Template, error) {
+	if path, ok := strings.CutPrefix(text, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read template: %w", err)
+		}
+		text = string(data)
+	}
+	t, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse template: %w", err)
+	}
+	return t, nil
+}
+
+// SetTemplate renders everything written by JSON with t from now on. Errors
+// are still written as JSON, since a template for results can't show them.
+func SetTemplate(t *template.Template) {
+	tmpl = t
+	format = FormatTemplate
+}
+
+func writeTemplate(v interface{}) error {
+	if _, ok := v.(ErrorResponse); ok {
+		return writeDocument(v)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, v); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+	_, err := Writer.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestTemplateOutput(t *testing.T) {
+	var buf bytes.Buffer
+	prev := Writer
+	Writer = &buf
+	defer func() { Writer = prev; SetFormat(FormatJSON) }()
+
+	tpl, err := ParseTemplate(`{{range .Flights}}{{.Airline}} {{usd .PriceUSD}}{{"\n"}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTemplate(tpl)
+	result := &core.SearchResult{Flights: []core.FlightOffer{{Airline: "Air Canada", PriceUSD: 612.5}, {Airline: "Delta", PriceUSD: 580}}}
+	if err := JSON(result); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Air Canada $612.50\nDelta $580.00\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	buf.Reset()
+	raw, _ := ParseTemplate(`{{range .flights}}{{.airline}}{{end}}`)
+	SetTemplate(raw)
+	if err := RawJSON([]byte(`{"flights":[{"airline":"Delta"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Delta" {
+		t.Errorf("raw documents are keyed by JSON names, got %q", buf.String())
+	}
+
+	buf.Reset()
+	JSONError("search failed", "")
+	if buf.String() != "{\n  \"error\": \"search failed\"\n}\n" {
+		t.Errorf("errors should stay JSON, got %q", buf.String())
+	}
+}
+
+func TestParseTemplateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.tmpl")
+	if err := os.WriteFile(path, []byte(`{{.TotalFound}} found`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tpl, err := ParseTemplate("@" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, core.SearchResult{TotalFound: 3}); err != nil || buf.String() != "3 found" {
+		t.Errorf("got %q, %v", buf.String(), err)
+	}
+	if _, err := ParseTemplate("{{.Flights"); err == nil {
+		t.Error("want parse error")
+	}
+}
@@ -0,0 +1,153 @@
+// Package paths locates the directories the CLI keeps its files in,
+// following the XDG base directory variables on Unix and the AppData
+// folders on Windows.
+package paths
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// app is the directory name under each base directory.
+const app = "beetlebot"
+
+// goos is runtime.GOOS, replaced in tests.
+var goos = runtime.GOOS
+
+// ConfigDir holds the config file and credentials: $XDG_CONFIG_HOME,
+// %APPDATA% on Windows, or ~/.config.
+func ConfigDir() (string, error) {
+	return dir("XDG_CONFIG_HOME", "APPDATA", "", ".config")
+}
+
+// CacheDir holds data that can be fetched again (responses, tokens,
+// exchange rates): $XDG_CACHE_HOME, %LOCALAPPDATA%\beetlebot\cache on
+// Windows, or ~/.cache.
+func CacheDir() (string, error) {
+	return dir("XDG_CACHE_HOME", "LOCALAPPDATA", "cache", ".cache")
+}
+
+// DataDir holds what the CLI records (alerts, history, trips, jobs):
+// $XDG_DATA_HOME, %LOCALAPPDATA%\beetlebot\data on Windows, or
+// ~/.local/share.
+func DataDir() (string, error) {
+	return dir("XDG_DATA_HOME", "LOCALAPPDATA", "data", filepath.Join(".local", "share"))
+}
+
+// dir resolves the beetlebot directory under a base: the XDG variable
+// when set to an absolute path (the spec says to ignore relative ones),
+// the Windows folder on Windows, or home/unixDefault. When that is not
+// where earlier versions kept it, under home/unixDefault, the old
+// directory is copied over the first time.
+func dir(xdgVar, windowsVar, windowsSub, unixDefault string) (string, error) {
+	home, homeErr := os.UserHomeDir()
+	legacy := ""
+	if homeErr == nil {
+		legacy = filepath.Join(home, unixDefault, app)
+	}
+
+	var p string
+	if base := os.Getenv(xdgVar); filepath.IsAbs(base) {
+		p = filepath.Join(base, app)
+	} else if base := os.Getenv(windowsVar); goos == "windows" && base != "" {
+		p = filepath.Join(base, app, windowsSub)
+	} else if homeErr != nil {
+		return "", homeErr
+	} else {
+		return legacy, nil
+	}
+	if legacy != "" && p != legacy {
+		return migrate(legacy, p), nil
+	}
+	return p, nil
+}
+
+var migrated sync.Map
+
+// migrate copies the legacy directory to p when p doesn't exist yet. The
+// legacy directory is left as it was, so nothing is lost if p turns out
+// to be the wrong place; if the copy fails it stays in use instead.
+func migrate(legacy, p string) string {
+	if done, ok := migrated.Load(p); ok {
+		return done.(string)
+	}
+	result := p
+	if _, err := os.Stat(p); errors.Is(err, os.ErrNotExist) {
+		if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+			if copyDir(legacy, p) != nil {
+				result = legacy
+			}
+		}
+	}
+	migrated.Store(p, result)
+	return result
+}
+
+// copyDir copies the tree at src to dst, keeping file modes. It copies
+// into a temporary directory beside dst first and renames that into
+// place, so dst never exists half-copied.
+func copyDir(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dst), "."+app+"-copy-*")
+	if err != nil {
+		return err
+	}
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(tmp, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		os.RemoveAll(tmp)
+	}
+	return err
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,75 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "relative/ignored")
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	for name, tc := range map[string]struct {
+		fn   func() (string, error)
+		want string
+	}{
+		"config": {ConfigDir, filepath.Join(home, ".config", "beetlebot")},
+		"cache":  {CacheDir, filepath.Join(home, ".cache", "beetlebot")},
+		"data":   {DataDir, filepath.Join(home, "data", "beetlebot")},
+	} {
+		if got, err := tc.fn(); err != nil || got != tc.want {
+			t.Errorf("%s = %q, %v; want %q", name, got, err, tc.want)
+		}
+	}
+}
+
+func TestWindowsDirs(t *testing.T) {
+	defer func(old string) { goos = old }(goos)
+	goos = "windows"
+	home, appData, local := t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("APPDATA", appData)
+	t.Setenv("LOCALAPPDATA", local)
+
+	if got, _ := ConfigDir(); got != filepath.Join(appData, "beetlebot") {
+		t.Errorf("config = %q", got)
+	}
+	if got, _ := CacheDir(); got != filepath.Join(local, "beetlebot", "cache") {
+		t.Errorf("cache = %q", got)
+	}
+}
+
+func TestMigratesLegacyDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	legacy := filepath.Join(home, ".config", "beetlebot")
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(legacy, "travel.yaml"), []byte("mode: live\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	xdg := filepath.Join(home, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+
+	got, err := ConfigDir()
+	if err != nil || got != filepath.Join(xdg, "beetlebot") {
+		t.Fatalf("config = %q, %v", got, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(got, "travel.yaml")); err != nil || string(data) != "mode: live\n" {
+		t.Errorf("migrated file = %q, %v", data, err)
+	}
+	if info, err := os.Stat(filepath.Join(got, "travel.yaml")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("migrated file mode = %v, %v; want 0600 kept", info, err)
+	}
+	if data, err := os.ReadFile(filepath.Join(legacy, "travel.yaml")); err != nil || string(data) != "mode: live\n" {
+		t.Errorf("legacy file = %q, %v; want it left in place", data, err)
+	}
+}
@@ -0,0 +1,376 @@
+// Package planner proposes multi-stop itineraries from fare searches.
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+const (
+	// DefaultMaxSearches bounds the fare searches one plan may run.
+	DefaultMaxSearches = 500
+	// maxStops keeps the orderings explored (stops factorial) tractable.
+	maxStops = 7
+	// fetchWorkers is how many fare searches run at once.
+	fetchWorkers = 4
+)
+
+var (
+	ErrNoRouting       = errors.New("no routing has fares for every leg")
+	ErrTooManySearches = errors.New("routing needs more fare searches than allowed")
+	// ErrFaresUnavailable means every fare search failed, so no routing
+	// could be priced.
+	ErrFaresUnavailable = errors.New("every fare search failed")
+)
+
+// FareSource finds the cheapest flight for a leg. A nil offer with a nil
+// error means the leg has no fares that day.
+type FareSource interface {
+	Cheapest(from, to, date string) (*core.FlightOffer, error)
+}
+
+// RTWRequest asks for the cheapest order and dates to visit Cities[1:]
+// starting from Cities[0] on Start, spending MinDays to MaxDays in each.
+type RTWRequest struct {
+	Cities  []string `json:"cities"`
+	Start   string   `json:"start"`
+	MinDays int      `json:"minDays"`
+	MaxDays int      `json:"maxDays"`
+	// Return ends the trip back in the first city.
+	Return bool `json:"return"`
+	// FixedOrder only varies dates, keeping the cities in the order given.
+	FixedOrder   bool `json:"fixedOrder,omitempty"`
+	Alternatives int  `json:"alternatives"`
+	MaxSearches  int  `json:"maxSearches"`
+}
+
+// ParseDays reads a --days-per-city value: "4-7" or a single "5".
+func ParseDays(s string) (minDays, maxDays int, err error) {
+	lo, hi, found := strings.Cut(strings.TrimSpace(s), "-")
+	if !found {
+		hi = lo
+	}
+	minDays, err1 := strconv.Atoi(strings.TrimSpace(lo))
+	maxDays, err2 := strconv.Atoi(strings.TrimSpace(hi))
+	if err1 != nil || err2 != nil {
+		return 0, 0, fmt.Errorf("invalid days %q (expected e.g. 4-7 or 5)", s)
+	}
+	return minDays, maxDays, nil
+}
+
+func (r *RTWRequest) Validate() error {
+	for i, c := range r.Cities {
+		r.Cities[i] = strings.ToUpper(strings.TrimSpace(c))
+	}
+	switch {
+	case len(r.Cities) < 2:
+		return errors.New("give at least a start city and one stop")
+	case len(r.Cities)-1 > maxStops:
+		return fmt.Errorf("at most %d stops besides the start city", maxStops)
+	case r.MinDays < 1 || r.MaxDays < r.MinDays:
+		return fmt.Errorf("days per city must be at least 1 and min <= max, got %d-%d", r.MinDays, r.MaxDays)
+	}
+	seen := map[string]bool{}
+	for _, c := range r.Cities {
+		if c == "" || seen[c] {
+			return fmt.Errorf("cities must be distinct and non-empty: %s", strings.Join(r.Cities, ","))
+		}
+		seen[c] = true
+	}
+	if _, err := time.Parse("2006-01-02", r.Start); err != nil {
+		return fmt.Errorf("invalid start date %q", r.Start)
+	}
+	return nil
+}
+
+func (r *RTWRequest) ApplyDefaults() {
+	if r.MaxSearches <= 0 {
+		r.MaxSearches = DefaultMaxSearches
+	}
+	if r.Alternatives < 0 {
+		r.Alternatives = 0
+	}
+}
+
+// Leg is one flight of a routing.
+type Leg struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Date     string            `json:"date"`
+	PriceUSD float64           `json:"priceUSD"`
+	Flight   *core.FlightOffer `json:"flight,omitempty"`
+}
+
+// Stop is the time spent in one city. Depart is empty for the last city
+// of a one-way trip.
+type Stop struct {
+	City   string `json:"city"`
+	Arrive string `json:"arrive"`
+	Depart string `json:"depart,omitempty"`
+	Days   int    `json:"days,omitempty"`
+}
+
+// Routing is one order of cities with the cheapest dates for it.
+type Routing struct {
+	Order    []string `json:"order"`
+	TotalUSD float64  `json:"totalUSD"`
+	EndDate  string   `json:"endDate"`
+	Legs     []Leg    `json:"legs"`
+	Stops    []Stop   `json:"stops"`
+}
+
+// RTWPlan is the cheapest routing found, with runner-up orderings.
+type RTWPlan struct {
+	Query        RTWRequest `json:"query"`
+	Best         *Routing   `json:"best"`
+	Alternatives []Routing  `json:"alternatives,omitempty"`
+	// Orderings counts the city orders explored and Feasible those with
+	// fares on every leg.
+	Orderings int `json:"orderings"`
+	Feasible  int `json:"feasible"`
+	Searches  int `json:"searches"`
+	// Errors lists legs whose fare search failed; they count as having no
+	// fares.
+	Errors []string `json:"errors,omitempty"`
+}
+
+type legKey struct {
+	from, to string
+	day      int
+}
+
+// PlanRTW explores city orders and stay lengths for req. Fares for every
+// leg and date any routing could use are fetched up front, then each order
+// is solved for its cheapest dates.
+func PlanRTW(src FareSource, req RTWRequest) (*RTWPlan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.ApplyDefaults()
+	start, _ := time.Parse("2006-01-02", req.Start)
+
+	keys := neededLegs(req)
+	if len(keys) > req.MaxSearches {
+		return nil, fmt.Errorf("%w: %d needed, limit %d (narrow --days-per-city or drop a city)", ErrTooManySearches, len(keys), req.MaxSearches)
+	}
+	fares, errs := fetch(src, keys, start)
+
+	plan := &RTWPlan{Query: req, Searches: len(keys), Errors: errs}
+	var routings []Routing
+	permute(req.Cities[1:], req.FixedOrder, func(order []string) {
+		plan.Orderings++
+		if r, ok := solve(req, order, fares, start); ok {
+			routings = append(routings, r)
+		}
+	})
+	plan.Feasible = len(routings)
+	if len(fares) == 0 && len(errs) > 0 {
+		return plan, fmt.Errorf("%w (%d searches, first: %s)", ErrFaresUnavailable, len(errs), errs[0])
+	}
+	if len(routings) == 0 {
+		return plan, ErrNoRouting
+	}
+	sort.SliceStable(routings, func(i, j int) bool {
+		if routings[i].TotalUSD != routings[j].TotalUSD {
+			return routings[i].TotalUSD < routings[j].TotalUSD
+		}
+		return routings[i].EndDate < routings[j].EndDate
+	})
+	plan.Best = &routings[0]
+	if n := min(req.Alternatives, len(routings)-1); n > 0 {
+		plan.Alternatives = routings[1 : n+1]
+	}
+	return plan, nil
+}
+
+// neededLegs lists every (from, to, day) a routing of req can fly, as days
+// after the start. The i-th stop is left between i*MinDays and i*MaxDays
+// days in. With FixedOrder only consecutive cities of the given order are
+// paired.
+func neededLegs(req RTWRequest) []legKey {
+	origin, stops := req.Cities[0], req.Cities[1:]
+	n := len(stops)
+	seen := map[legKey]bool{}
+	var keys []legKey
+	add := func(k legKey) {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	if req.FixedOrder {
+		cities := append([]string(nil), req.Cities...)
+		if req.Return {
+			cities = append(cities, origin)
+		}
+		for i := 0; i+1 < len(cities); i++ {
+			for day := i * req.MinDays; day <= i*req.MaxDays; day++ {
+				add(legKey{cities[i], cities[i+1], day})
+			}
+		}
+		return keys
+	}
+	for _, c := range stops {
+		add(legKey{origin, c, 0})
+	}
+	for i := 1; i < n; i++ {
+		for day := i * req.MinDays; day <= i*req.MaxDays; day++ {
+			for _, a := range stops {
+				for _, b := range stops {
+					if a != b {
+						add(legKey{a, b, day})
+					}
+				}
+			}
+		}
+	}
+	if req.Return {
+		for day := n * req.MinDays; day <= n*req.MaxDays; day++ {
+			for _, c := range stops {
+				add(legKey{c, origin, day})
+			}
+		}
+	}
+	return keys
+}
+
+func fetch(src FareSource, keys []legKey, start time.Time) (map[legKey]*core.FlightOffer, []string) {
+	var (
+		mu     sync.Mutex
+		fares  = make(map[legKey]*core.FlightOffer, len(keys))
+		errs   []string
+		wg     sync.WaitGroup
+		queue  = make(chan legKey)
+		search = func(k legKey) {
+			date := start.AddDate(0, 0, k.day).Format("2006-01-02")
+			offer, err := src.Cheapest(k.from, k.to, date)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s-%s %s: %v", k.from, k.to, date, err))
+				return
+			}
+			fares[k] = offer
+		}
+	)
+	for range fetchWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range queue {
+				search(k)
+			}
+		}()
+	}
+	for _, k := range keys {
+		queue <- k
+	}
+	close(queue)
+	wg.Wait()
+	sort.Strings(errs)
+	return fares, errs
+}
+
+// solve finds the cheapest days to fly order, by dynamic programming over
+// the day each city is reached.
+func solve(req RTWRequest, order []string, fares map[legKey]*core.FlightOffer, start time.Time) (Routing, bool) {
+	cities := append([]string{req.Cities[0]}, order...)
+	if req.Return {
+		cities = append(cities, req.Cities[0])
+	}
+	legs := len(cities) - 1
+	horizon := legs*req.MaxDays + 1
+
+	// cost[i][d] is the cheapest way to reach cities[i] on day d, and
+	// prev[i][d] the day cities[i-1] was left to get there.
+	cost := make([][]float64, legs+1)
+	prev := make([][]int, legs+1)
+	for i := range cost {
+		cost[i] = make([]float64, horizon)
+		prev[i] = make([]int, horizon)
+		for d := range cost[i] {
+			cost[i][d] = math.Inf(1)
+		}
+	}
+	if f := fares[legKey{cities[0], cities[1], 0}]; f != nil {
+		cost[1][0] = f.PriceUSD
+	}
+	for i := 1; i < legs; i++ {
+		for d, c := range cost[i] {
+			if math.IsInf(c, 1) {
+				continue
+			}
+			for s := req.MinDays; s <= req.MaxDays && d+s < horizon; s++ {
+				f := fares[legKey{cities[i], cities[i+1], d + s}]
+				if f != nil && c+f.PriceUSD < cost[i+1][d+s] {
+					cost[i+1][d+s], prev[i+1][d+s] = c+f.PriceUSD, d
+				}
+			}
+		}
+	}
+
+	end := -1
+	for d, c := range cost[legs] {
+		if !math.IsInf(c, 1) && (end < 0 || c < cost[legs][end]) {
+			end = d
+		}
+	}
+	if end < 0 {
+		return Routing{}, false
+	}
+
+	date := func(day int) string { return start.AddDate(0, 0, day).Format("2006-01-02") }
+	r := Routing{Order: order, TotalUSD: round2(cost[legs][end]), EndDate: date(end)}
+	days := make([]int, legs+1)
+	days[legs] = end
+	for i := legs; i > 1; i-- {
+		days[i-1] = prev[i][days[i]]
+	}
+	for i := 0; i < legs; i++ {
+		f := fares[legKey{cities[i], cities[i+1], days[i+1]}]
+		r.Legs = append(r.Legs, Leg{From: cities[i], To: cities[i+1], Date: date(days[i+1]), PriceUSD: f.PriceUSD, Flight: f})
+	}
+	for i := 1; i <= len(order); i++ {
+		stop := Stop{City: cities[i], Arrive: date(days[i])}
+		if i < legs {
+			stop.Depart, stop.Days = date(days[i+1]), days[i+1]-days[i]
+		}
+		r.Stops = append(r.Stops, stop)
+	}
+	return r, true
+}
+
+// permute calls fn with every order of cities, or only the given one when
+// fixed is set.
+func permute(cities []string, fixed bool, fn func([]string)) {
+	order := append([]string(nil), cities...)
+	if fixed {
+		fn(order)
+		return
+	}
+	var rec func(k int)
+	rec = func(k int) {
+		if k == len(order) {
+			fn(append([]string(nil), order...))
+			return
+		}
+		for i := k; i < len(order); i++ {
+			order[k], order[i] = order[i], order[k]
+			rec(k + 1)
+			order[k], order[i] = order[i], order[k]
+		}
+	}
+	rec(0)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,137 @@
+package planner
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// tableFares prices legs from a table keyed "FROM-TO date", defaulting to
+// base; legs listed in missing have no fares.
+type tableFares struct {
+	base    float64
+	prices  map[string]float64
+	missing map[string]bool
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (f *tableFares) Cheapest(from, to, date string) (*core.FlightOffer, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	key := from + "-" + to + " " + date
+	if f.missing[key] {
+		return nil, nil
+	}
+	price, ok := f.prices[key]
+	if !ok {
+		price = f.base
+	}
+	return &core.FlightOffer{From: from, To: to, PriceUSD: price}, nil
+}
+
+func TestPlanRTWFindsCheapestOrderAndDates(t *testing.T) {
+	fares := &tableFares{base: 500, prices: map[string]float64{
+		"YUL-LIS 2026-09-01": 300,
+		"LIS-BKK 2026-09-06": 200, // only cheap if LIS gets 5 days
+		"BKK-YUL 2026-09-10": 250,
+	}}
+	req := RTWRequest{Cities: []string{"yul", "lis", "bkk"}, Start: "2026-09-01", MinDays: 4, MaxDays: 6, Return: true, Alternatives: 1}
+
+	plan, err := PlanRTW(fares, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := plan.Best
+	if b.TotalUSD != 750 || b.Order[0] != "LIS" || b.EndDate != "2026-09-10" {
+		t.Fatalf("best = %+v", b)
+	}
+	if b.Stops[0] != (Stop{City: "LIS", Arrive: "2026-09-01", Depart: "2026-09-06", Days: 5}) || b.Stops[1].Days != 4 {
+		t.Errorf("stops = %+v", b.Stops)
+	}
+	if plan.Orderings != 2 || len(plan.Alternatives) != 1 || plan.Alternatives[0].Order[0] != "BKK" {
+		t.Errorf("orderings = %d, alternatives = %+v", plan.Orderings, plan.Alternatives)
+	}
+	if fares.calls != plan.Searches {
+		t.Errorf("searched %d legs, reported %d", fares.calls, plan.Searches)
+	}
+}
+
+func TestPlanRTWSkipsLegsWithoutFares(t *testing.T) {
+	fares := &tableFares{base: 100, missing: map[string]bool{"YUL-LIS 2026-09-01": true}}
+	req := RTWRequest{Cities: []string{"YUL", "LIS", "BKK"}, Start: "2026-09-01", MinDays: 3, MaxDays: 3}
+
+	plan, err := PlanRTW(fares, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Feasible != 1 || plan.Best.Order[0] != "BKK" || len(plan.Best.Legs) != 2 {
+		t.Errorf("plan = %+v", plan)
+	}
+	if last := plan.Best.Stops[1]; last.Depart != "" || last.Arrive != "2026-09-04" {
+		t.Errorf("one-way last stop = %+v", last)
+	}
+
+	fares.missing["YUL-BKK 2026-09-01"] = true
+	if _, err := PlanRTW(fares, req); !errors.Is(err, ErrNoRouting) {
+		t.Errorf("err = %v, want ErrNoRouting", err)
+	}
+}
+
+func TestPlanRTWSearchBudget(t *testing.T) {
+	req := RTWRequest{Cities: []string{"YUL", "LIS", "CPT", "BKK", "NRT"}, Start: "2026-09-01", MinDays: 4, MaxDays: 7, Return: true, MaxSearches: 100}
+	fares := &tableFares{base: 100}
+	if _, err := PlanRTW(fares, req); !errors.Is(err, ErrTooManySearches) || fares.calls != 0 {
+		t.Errorf("err = %v after %d searches, want ErrTooManySearches before searching", err, fares.calls)
+	}
+}
+
+func TestPlanRTWFixedOrderSearchesOnlyItsLegs(t *testing.T) {
+	req := RTWRequest{Cities: []string{"YUL", "LIS", "CPT", "BKK"}, Start: "2026-09-01", MinDays: 4, MaxDays: 6, Return: true, FixedOrder: true}
+	fares := &tableFares{base: 100}
+	plan, err := PlanRTW(fares, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// YUL-LIS on day 0, LIS-CPT on days 4-6, CPT-BKK on 8-12, BKK-YUL on 12-18.
+	if fares.calls != 1+3+5+7 || plan.Orderings != 1 {
+		t.Errorf("searched %d legs over %d orderings, want 16 over 1", fares.calls, plan.Orderings)
+	}
+	if got := plan.Best.Order; got[0] != "LIS" || got[1] != "CPT" || got[2] != "BKK" {
+		t.Errorf("order = %v", got)
+	}
+}
+
+type failingFares struct{}
+
+func (failingFares) Cheapest(from, to, date string) (*core.FlightOffer, error) {
+	return nil, errors.New("provider down")
+}
+
+func TestPlanRTWReportsFailedSearches(t *testing.T) {
+	req := RTWRequest{Cities: []string{"YUL", "LIS", "BKK"}, Start: "2026-09-01", MinDays: 3, MaxDays: 3}
+	plan, err := PlanRTW(failingFares{}, req)
+	if !errors.Is(err, ErrFaresUnavailable) || errors.Is(err, ErrNoRouting) {
+		t.Fatalf("err = %v, want ErrFaresUnavailable", err)
+	}
+	if !strings.Contains(err.Error(), "provider down") || len(plan.Errors) != plan.Searches {
+		t.Errorf("err = %v, plan errors = %v", err, plan.Errors)
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	for in, want := range map[string][2]int{"4-7": {4, 7}, "5": {5, 5}, " 3 - 4 ": {3, 4}} {
+		lo, hi, err := ParseDays(in)
+		if err != nil || lo != want[0] || hi != want[1] {
+			t.Errorf("ParseDays(%q) = %d, %d, %v", in, lo, hi, err)
+		}
+	}
+	if _, _, err := ParseDays("a-b"); err == nil {
+		t.Error("want error for a-b")
+	}
+}
@@ -0,0 +1,121 @@
+// Package quota keeps a local ledger of provider calls and their estimated
+// cost by month, for `travel providers usage` and monthly limit warnings.
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/filelock"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// MonthLayout formats the months the ledger is kept by, in UTC.
+const MonthLayout = "2006-01"
+
+// Count is a provider's calls in a month.
+type Count struct {
+	Calls   int     `json:"calls"`
+	CostUSD float64 `json:"costUSD"`
+}
+
+// Ledger keeps every month's counts in a single JSON file, keyed by month
+// and then provider, locked while it's read or updated so processes
+// sharing it count every call.
+type Ledger struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open() (*Ledger, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+func OpenDir(dir string) (*Ledger, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create usage dir: %w", err)
+	}
+	return &Ledger{path: filepath.Join(dir, "usage.json")}, nil
+}
+
+// Record counts a call to provider at at, costing costUSD, and returns
+// the provider's calls so far that month.
+func (l *Ledger) Record(provider string, costUSD float64, at time.Time) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	unlock, err := filelock.Lock(l.path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	all, err := l.load()
+	if err != nil {
+		return 0, err
+	}
+	month := at.UTC().Format(MonthLayout)
+	if all[month] == nil {
+		all[month] = map[string]Count{}
+	}
+	c := all[month][provider]
+	c.Calls++
+	c.CostUSD += costUSD
+	all[month][provider] = c
+	return c.Calls, l.save(all)
+}
+
+// Month returns each provider's counts for month, formatted as
+// MonthLayout.
+func (l *Ledger) Month(month string) (map[string]Count, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	unlock, err := filelock.Lock(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	all, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	if all[month] == nil {
+		return map[string]Count{}, nil
+	}
+	return all[month], nil
+}
+
+func (l *Ledger) load() (map[string]map[string]Count, error) {
+	all := map[string]map[string]Count{}
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode usage: %w", err)
+	}
+	return all, nil
+}
+
+func (l *Ledger) save(all map[string]map[string]Count) error {
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
@@ -0,0 +1,76 @@
+package quota
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordAndMonth(t *testing.T) {
+	ledger, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	june := time.Date(2026, 6, 30, 22, 0, 0, 0, time.UTC)
+	// The third call falls in July, UTC, and starts a new count.
+	for i, want := range []int{1, 2, 1} {
+		calls, err := ledger.Record("duffel", 0.25, june.Add(time.Duration(i)*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != want {
+			t.Errorf("call %d: %d calls so far, want %d", i, calls, want)
+		}
+	}
+	if _, err := ledger.Record("expedia", 0, june); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := ledger.Month("2026-06")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := counts["duffel"]; c.Calls != 2 || c.CostUSD != 0.5 {
+		t.Errorf("duffel in June = %+v", c)
+	}
+	if c := counts["expedia"]; c.Calls != 1 || c.CostUSD != 0 {
+		t.Errorf("expedia in June = %+v", c)
+	}
+	if counts, _ := ledger.Month("2026-07"); counts["duffel"].Calls != 1 {
+		t.Errorf("July = %+v", counts)
+	}
+	if counts, err := ledger.Month("2026-05"); err != nil || len(counts) != 0 {
+		t.Errorf("May = %+v, %v", counts, err)
+	}
+}
+
+func TestRecord_SharedAcrossLedgers(t *testing.T) {
+	// Ledgers opened on one directory stand in for processes sharing it.
+	dir := t.TempDir()
+	at := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		ledger, err := OpenDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := ledger.Record("duffel", 0, at); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	ledger, _ := OpenDir(dir)
+	counts, err := ledger.Month("2026-06")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["duffel"].Calls != 40 {
+		t.Errorf("calls = %d, want 40", counts["duffel"].Calls)
+	}
+}
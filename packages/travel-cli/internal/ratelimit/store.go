@@ -0,0 +1,120 @@
+// Package ratelimit keeps a token bucket per provider in a local file, so
+// provider quotas hold across CLI invocations and the daemon rather than
+// resetting with each process.
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/filelock"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+// Bucket is a provider's quota: Tokens requests may be sent right away,
+// and the bucket refills at the provider's rate, up to a minute's worth,
+// from Updated on. Tokens goes below zero when requests have been
+// promised to callers still waiting to send them.
+type Bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store keeps every provider's bucket in a single JSON file, locked while
+// a request is taken so processes sharing it don't undo each other's.
+type Store struct {
+	path string
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create rate limit dir: %w", err)
+	}
+	return &Store{path: filepath.Join(dir, "ratelimit.json"), now: time.Now}, nil
+}
+
+// Reserve takes a request from provider's bucket, which holds perMinute
+// requests and refills perMinute times a minute. A provider first seen
+// starts with a full bucket. When the bucket is empty the request is
+// taken ahead of time and wait says how long until it refills, unless
+// that is longer than maxWait, in which case nothing is taken and ok is
+// false.
+func (s *Store) Reserve(provider string, perMinute int, maxWait time.Duration) (time.Duration, bool, error) {
+	if perMinute <= 0 {
+		return 0, true, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	unlock, err := filelock.Lock(s.path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer unlock()
+
+	all, err := s.load()
+	if err != nil {
+		return 0, false, err
+	}
+	now := s.now()
+	capacity := float64(perMinute)
+	b, seen := all[provider]
+	if !seen {
+		b = Bucket{Tokens: capacity, Updated: now}
+	}
+	if elapsed := now.Sub(b.Updated); elapsed > 0 {
+		b.Tokens = min(capacity, b.Tokens+elapsed.Minutes()*capacity)
+		b.Updated = now
+	}
+
+	var wait time.Duration
+	if b.Tokens < 1 {
+		wait = time.Duration((1 - b.Tokens) / capacity * float64(time.Minute))
+		if wait > maxWait {
+			return wait, false, nil
+		}
+	}
+	b.Tokens--
+	all[provider] = b
+	return wait, true, s.save(all)
+}
+
+func (s *Store) load() (map[string]Bucket, error) {
+	all := map[string]Bucket{}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode rate limits: %w", err)
+	}
+	return all, nil
+}
+
+func (s *Store) save(all map[string]Bucket) error {
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReserve(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	open := func() *Store {
+		s, err := OpenDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	store := open()
+	for i := 0; i < 2; i++ {
+		if wait, ok, err := store.Reserve("duffel", 2, 0); err != nil || !ok || wait != 0 {
+			t.Fatalf("request %d: wait %v, ok %v, err %v", i, wait, ok, err)
+		}
+	}
+	if wait, ok, _ := store.Reserve("duffel", 2, time.Second); ok || wait != 30*time.Second {
+		t.Errorf("over quota: wait %v, ok %v", wait, ok)
+	}
+	if _, ok, _ := store.Reserve("expedia", 2, 0); !ok {
+		t.Error("expedia limited by duffel's quota")
+	}
+
+	// A new process sees the spent quota, and may wait for the refill.
+	store = open()
+	if wait, ok, _ := store.Reserve("duffel", 2, time.Minute); !ok || wait != 30*time.Second {
+		t.Errorf("reserved ahead: wait %v, ok %v", wait, ok)
+	}
+	if wait, ok, _ := store.Reserve("duffel", 2, time.Minute); !ok || wait != time.Minute {
+		t.Errorf("second reserved ahead: wait %v, ok %v", wait, ok)
+	}
+
+	// Idle time refills the bucket, but never past a minute's quota.
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if wait, ok, _ := store.Reserve("duffel", 2, 0); !ok || wait != 0 {
+			t.Fatalf("after refill %d: wait %v, ok %v", i, wait, ok)
+		}
+	}
+	if _, ok, _ := store.Reserve("duffel", 2, 0); ok {
+		t.Error("bucket refilled past its capacity")
+	}
+
+	if _, ok, _ := store.Reserve("duffel", 0, 0); !ok {
+		t.Error("unlimited provider was limited")
+	}
+}
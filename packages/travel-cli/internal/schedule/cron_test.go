@@ -0,0 +1,54 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// Friday 2026-05-01 10:17 UTC.
+	from := time.Date(2026, 5, 1, 10, 17, 30, 0, time.UTC)
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 5, 1, 10, 30, 0, 0, time.UTC)},
+		{"0 */6 * * *", time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{"30 8 * * mon-fri", time.Date(2026, 5, 4, 8, 30, 0, 0, time.UTC)},
+		{"0 9 1,15 * *", time.Date(2026, 5, 15, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 10th or any Monday.
+		{"0 12 10 * 1", time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)},
+		{"17 10 * * *", time.Date(2026, 5, 2, 10, 17, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.spec)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tc.spec, err)
+		}
+		if got := c.Next(from); !got.Equal(tc.want) {
+			t.Errorf("%q.Next = %v, want %v", tc.spec, got, tc.want)
+		}
+	}
+}
+
+func TestCronNeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Feb 30 matched at %v", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "x * * * *"} {
+		if _, err := ParseCron(spec); err == nil {
+			t.Errorf("ParseCron(%q) succeeded", spec)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/searches"
+)
+
+const (
+	KindFlights = "flights"
+	KindStays   = "stays"
+)
+
+// Job is a configured schedule resolved into a runnable search.
+type Job struct {
+	Name   string
+	Cron   *Cron
+	Kind   string
+	Flight *core.FlightSearchRequest
+	Stay   *core.StaySearchRequest
+}
+
+// FromConfig validates a schedule from the config file and builds its
+// search request with the usual defaults applied. A schedule naming a
+// saved search runs that search as it is saved when the schedule is read.
+func FromConfig(sc config.ScheduleConfig) (*Job, error) {
+	if sc.Name == "" {
+		return nil, fmt.Errorf("schedule has no name")
+	}
+	cron, err := ParseCron(sc.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", sc.Name, err)
+	}
+	job := &Job{Name: sc.Name, Cron: cron, Kind: sc.Kind}
+	if sc.Search != "" {
+		return fromSaved(job, sc.Search)
+	}
+
+	switch sc.Kind {
+	case KindFlights:
+		req := core.FlightSearchRequest{From: sc.From, To: sc.To, DepartDate: sc.Depart, ReturnDate: sc.Return, Adults: sc.Adults, CabinClass: sc.Cabin}
+		if err := req.Validate(); err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", sc.Name, err)
+		}
+		req.ApplyDefaults()
+		job.Flight = &req
+	case KindStays:
+		req := core.StaySearchRequest{City: sc.City, CheckIn: sc.CheckIn, CheckOut: sc.CheckOut, Guests: sc.Guests, StayType: sc.StayType}
+		if err := req.Validate(); err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", sc.Name, err)
+		}
+		req.ApplyDefaults()
+		job.Stay = &req
+	default:
+		return nil, fmt.Errorf("schedule %s: unknown kind %q (use flights or stays)", sc.Name, sc.Kind)
+	}
+	return job, nil
+}
+
+func fromSaved(job *Job, name string) (*Job, error) {
+	store, err := searches.Open()
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", job.Name, err)
+	}
+	s, err := store.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", job.Name, err)
+	}
+	job.Kind, job.Flight, job.Stay = s.Kind, s.Flight, s.Stay
+	if job.Flight == nil && job.Stay == nil {
+		return nil, fmt.Errorf("schedule %s: saved search %s has no request", job.Name, name)
+	}
+	return job, nil
+}
+
+// Next is the job's next run time after t.
+func (j *Job) Next(t time.Time) time.Time {
+	return j.Cron.Next(t)
+}
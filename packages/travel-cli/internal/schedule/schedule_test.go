@@ -0,0 +1,34 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/searches"
+)
+
+func TestFromConfigRunsSavedSearch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	store, err := searches.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12", Adults: 2}
+	if err := store.Save(&searches.Search{Name: "paris-summer", Kind: searches.KindFlights, Flight: &req}); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := FromConfig(config.ScheduleConfig{Name: "daily", Cron: "@daily", Search: "paris-summer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Kind != KindFlights || job.Flight == nil || *job.Flight != req {
+		t.Errorf("job = %+v", job)
+	}
+	if _, err := FromConfig(config.ScheduleConfig{Name: "daily", Cron: "@daily", Search: "missing"}); !errors.Is(err, searches.ErrNotFound) {
+		t.Errorf("missing saved search = %v", err)
+	}
+}
@@ -0,0 +1,305 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/jobs"
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+// Draft is the JSON Schema dialect Generate writes.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// envelope mirrors the response envelope the output package writes
+// around every command's data.
+type envelope struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Command       string          `json:"command"`
+	Data          json.RawMessage `json:"data"`
+	Errors        []commandError  `json:"errors,omitempty"`
+}
+
+type commandError struct {
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+}
+
+// types are the documents integrators can get schemas for, by name.
+var types = map[string]reflect.Type{
+	"Envelope":            reflect.TypeFor[envelope](),
+	"Error":               reflect.TypeFor[commandError](),
+	"SearchResult":        reflect.TypeFor[core.SearchResult](),
+	"FlightOffer":         reflect.TypeFor[core.FlightOffer](),
+	"StayOffer":           reflect.TypeFor[core.StayOffer](),
+	"CombinedOffer":       reflect.TypeFor[core.CombinedOffer](),
+	"FlightSearchRequest": reflect.TypeFor[core.FlightSearchRequest](),
+	"StaySearchRequest":   reflect.TypeFor[core.StaySearchRequest](),
+	"StayLookupRequest":   reflect.TypeFor[core.StayLookupRequest](),
+	"FlightStatus":        reflect.TypeFor[core.FlightStatus](),
+	"DoctorReport":        reflect.TypeFor[core.DoctorReport](),
+	"ProviderInfo":        reflect.TypeFor[core.ProviderInfo](),
+	"ProgressEvent":       reflect.TypeFor[core.ProgressEvent](),
+	"OfferBatch":          reflect.TypeFor[core.OfferBatch](),
+	"Trip":                reflect.TypeFor[trips.Trip](),
+	"Job":                 reflect.TypeFor[jobs.Job](),
+}
+
+// enums lists the values of string types with a fixed set of values.
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[config.Mode]():       {string(config.ModeMock), string(config.ModeLive), string(config.ModeHybrid)},
+	reflect.TypeFor[core.Capability]():   {string(core.CapFlightsSearch), string(core.CapFlightsStatus), string(core.CapStaysSearch), string(core.CapStaysLookup), string(core.CapReprice), string(core.CapDeepLink)},
+	reflect.TypeFor[core.ProviderTier](): {string(core.TierEasySignup), string(core.TierPartnerRequired), string(core.TierEnterpriseOnly)},
+	reflect.TypeFor[trips.SegmentKind](): {string(trips.KindFlight), string(trips.KindRail), string(trips.KindCar), string(trips.KindStay)},
+	reflect.TypeFor[jobs.Status]():       {string(jobs.StatusQueued), string(jobs.StatusRunning), string(jobs.StatusSucceeded), string(jobs.StatusFailed)},
+}
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+	rawType  = reflect.TypeFor[json.RawMessage]()
+)
+
+// Names lists the types Generate knows, sorted.
+func Names() []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Schema is a JSON Schema node. Properties keep the Go field order.
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        interface{}        `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Default     interface{}        `json:"default,omitempty"`
+	Properties  *Properties        `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Additional  *Schema            `json:"additionalProperties,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Properties is an object's properties in declaration order.
+type Properties struct {
+	names   []string
+	schemas map[string]*Schema
+}
+
+func (p *Properties) set(name string, s *Schema) {
+	if p.schemas == nil {
+		p.schemas = map[string]*Schema{}
+	}
+	if _, ok := p.schemas[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.schemas[name] = s
+}
+
+func (p *Properties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range p.names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		val, err := json.Marshal(p.schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Generate returns the JSON Schema for the named type, with the structs it
+// refers to under $defs. Name matching ignores case.
+func Generate(name string) (*Schema, error) {
+	for n, t := range types {
+		if strings.EqualFold(n, name) {
+			g := generator{root: t, defs: map[string]*Schema{}, prefix: defsPrefix}
+			root := g.define(t)
+			root.Schema, root.Title = Draft, n
+			if len(g.defs) > 0 {
+				root.Defs = g.defs
+			}
+			return root, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown type %q (use %s)", name, strings.Join(Names(), ", "))
+}
+
+// GenerateAll returns one schema with every known type under $defs.
+func GenerateAll() *Schema {
+	return &Schema{Schema: Draft, Title: "travel", Defs: Components(defsPrefix)}
+}
+
+// Components returns every known type's schema, and those of the structs
+// they refer to, by name. References between them are prefix followed by
+// the name, such as "#/components/schemas/" for an OpenAPI document.
+func Components(prefix string) map[string]*Schema {
+	g := generator{defs: map[string]*Schema{}, prefix: prefix}
+	for _, name := range Names() {
+		g.ref(types[name])
+	}
+	return g.defs
+}
+
+// Parameters returns the schema of struct type t as the parameters of an
+// LLM tool or function: no $schema or title, with describe called on each
+// property and the struct field behind it to add a description or default.
+func Parameters(t reflect.Type, describe func(f reflect.StructField, s *Schema)) *Schema {
+	g := generator{root: t, defs: map[string]*Schema{}, prefix: defsPrefix, describe: describe}
+	root := g.define(t)
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+// defsPrefix refers to a definition under a schema's own $defs.
+const defsPrefix = "#/$defs/"
+
+type generator struct {
+	// root is defined at the top of the document rather than in defs.
+	root reflect.Type
+	defs map[string]*Schema
+	// prefix comes before a definition's name in references to it.
+	prefix string
+	// describe, if set, is called on each property of the root type.
+	describe func(f reflect.StructField, s *Schema)
+}
+
+// ref returns a reference to struct type t, defining it on first use.
+// Anonymous structs are defined in place.
+func (g *generator) ref(t reflect.Type) *Schema {
+	switch {
+	case t == g.root:
+		return &Schema{Ref: "#"}
+	case t.Name() == "":
+		return g.define(t)
+	}
+	name := defName(t)
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = nil // placeholder for recursive types
+		g.defs[name] = g.define(t)
+	}
+	return &Schema{Ref: g.prefix + name}
+}
+
+// defName is the name a struct is defined under: its name in types, or
+// else its Go name.
+func defName(t reflect.Type) string {
+	for name, known := range types {
+		if known == t {
+			return name
+		}
+	}
+	return t.Name()
+}
+
+// define returns the object schema for struct type t.
+func (g *generator) define(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: &Properties{}}
+	g.fields(t, s)
+	return s
+}
+
+// fields adds t's JSON fields to s, inlining embedded structs like
+// encoding/json.
+func (g *generator) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, s)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		optional := strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero")
+		prop := g.schema(ft, !optional)
+		if g.describe != nil && t == g.root {
+			g.describe(f, prop)
+		}
+		s.Properties.set(name, prop)
+		if !optional {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// schema returns the schema for a value of type t. Values that encode as
+// null when empty (nil pointers, slices, maps) allow null when nullable.
+func (g *generator) schema(t reflect.Type, nullable bool) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawType:
+		return &Schema{}
+	}
+	if values, ok := enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Struct:
+		return g.ref(t)
+	case reflect.Pointer:
+		return orNull(g.schema(t.Elem(), false), nullable)
+	case reflect.Slice, reflect.Array:
+		return orNull(&Schema{Type: "array", Items: g.schema(t.Elem(), false)}, nullable && t.Kind() == reflect.Slice)
+	case reflect.Map:
+		return orNull(&Schema{Type: "object", Additional: g.schema(t.Elem(), false)}, nullable)
+	}
+	// interface{} holds any JSON value.
+	return &Schema{}
+}
+
+func orNull(s *Schema, nullable bool) *Schema {
+	if !nullable {
+		return s
+	}
+	if typ, ok := s.Type.(string); ok {
+		s.Type = []string{typ, "null"}
+		return s
+	}
+	return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	s, err := Generate("searchresult")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Schema != Draft || s.Title != "SearchResult" || s.Type != "object" {
+		t.Errorf("root = %+v", s)
+	}
+	if !slices.Contains(s.Required, "providers") || slices.Contains(s.Required, "flights") {
+		t.Errorf("required = %v; omitempty fields are optional", s.Required)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(data)
+	for _, want := range []string{
+		`"providers":{"type":["array","null"],"items":{"type":"string"}}`,
+		`"flights":{"type":"array","items":{"$ref":"#/$defs/FlightOffer"}}`,
+		`"departTime":{"type":"string","format":"date-time"}`,
+		`"mode":{"type":"string","enum":["mock","live","hybrid"]}`,
+		`"directFlights":{"type":"integer"}`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("schema lacks %s", want)
+		}
+	}
+	if strings.Contains(doc, `"Duration"`) {
+		t.Error(`fields tagged json:"-" should be left out`)
+	}
+	if strings.Index(doc, `"id"`) > strings.Index(doc, `"source"`) {
+		t.Error("properties should keep field order")
+	}
+
+	if _, err := Generate("Nope"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestGenerateAll(t *testing.T) {
+	s := GenerateAll()
+	for _, name := range Names() {
+		if s.Defs[name] == nil {
+			t.Errorf("missing $defs/%s", name)
+		}
+	}
+}
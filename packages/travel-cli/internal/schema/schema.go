@@ -0,0 +1,51 @@
+// Package schema versions the data each command outputs, so tools reading
+// the response envelope can tell when a command's data has changed shape.
+package schema
+
+// versions holds each command's data schema version, keyed by command
+// path without the root command. Bump a command's version when a change
+// could break a reader of its data: a field removed or renamed, or a
+// field's type or meaning changed. New fields need no bump.
+var versions = map[string]int{
+	"alerts add":        1,
+	"alerts check":      1,
+	"alerts digest":     1,
+	"alerts list":       1,
+	"alerts remove":     1,
+	"auth export":       1,
+	"auth import":       1,
+	"daemon":            1,
+	"destinations info": 1,
+	"destinations list": 1,
+	"doctor":            1,
+	"flights search":    1,
+	"history diff":      1,
+	"history list":      1,
+	"offers combine":    1,
+	"offers reprice":    1,
+	"plan rtw":          1,
+	"providers list":    1,
+	"providers resume":  1,
+	"schema":            1,
+	"schedules add":     1,
+	"schedules list":    1,
+	"schedules remove":  1,
+	"serve":             1,
+	"stays lookup":      1,
+	"stays search":      1,
+	"tools manifest":    1,
+	"trips add":         1,
+	"trips create":      1,
+	"trips footprint":   1,
+	"trips list":        1,
+	"trips show":        1,
+}
+
+// Version returns the schema version of command's data. Commands not in
+// the registry are at version 1.
+func Version(command string) int {
+	if v, ok := versions[command]; ok {
+		return v
+	}
+	return 1
+}
@@ -0,0 +1,17 @@
+package schema
+
+import "testing"
+
+func TestVersion(t *testing.T) {
+	for command, v := range versions {
+		if v < 1 {
+			t.Errorf("%s: version %d, versions start at 1", command, v)
+		}
+	}
+	if got := Version("flights search"); got != 1 {
+		t.Errorf("flights search: version %d, want 1", got)
+	}
+	if got := Version("unregistered"); got != 1 {
+		t.Errorf("unregistered command: version %d, want 1", got)
+	}
+}
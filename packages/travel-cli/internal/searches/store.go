@@ -0,0 +1,155 @@
+// Package searches stores named search requests so they can be run again
+// by name, from the command line or on a daemon schedule.
+package searches
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+const (
+	KindFlights = "flights"
+	KindStays   = "stays"
+)
+
+// Search is a saved request: Flight for flights, Stay for stays.
+type Search struct {
+	Name      string                    `json:"name"`
+	Kind      string                    `json:"kind"`
+	Flight    *core.FlightSearchRequest `json:"flight,omitempty"`
+	Stay      *core.StaySearchRequest   `json:"stay,omitempty"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	UpdatedAt time.Time                 `json:"updatedAt"`
+}
+
+var ErrNotFound = errors.New("saved search not found")
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Store keeps all saved searches in a single JSON file.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create searches dir: %w", err)
+	}
+	return &Store{path: filepath.Join(dir, "searches.json")}, nil
+}
+
+// Save adds s, or replaces the saved search with the same name, keeping
+// its creation time.
+func (st *Store) Save(s *Search) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if !validName.MatchString(s.Name) {
+		return fmt.Errorf("invalid search name %q (letters, digits, '.', '_' and '-' only)", s.Name)
+	}
+	all, err := st.load()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	s.CreatedAt, s.UpdatedAt = now, now
+	for i, existing := range all {
+		if existing.Name == s.Name {
+			s.CreatedAt = existing.CreatedAt
+			all[i] = *s
+			return st.save(all)
+		}
+	}
+	return st.save(append(all, *s))
+}
+
+func (st *Store) Get(name string) (*Search, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	all, err := st.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		if all[i].Name == name {
+			return &all[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// List returns saved searches sorted by name.
+func (st *Store) List() ([]Search, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	all, err := st.load()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all, nil
+}
+
+func (st *Store) Remove(name string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	all, err := st.load()
+	if err != nil {
+		return err
+	}
+	for i, s := range all {
+		if s.Name == name {
+			return st.save(append(all[:i], all[i+1:]...))
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+func (st *Store) load() ([]Search, error) {
+	data, err := os.ReadFile(st.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var all []Search
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("decode saved searches: %w", err)
+	}
+	return all, nil
+}
+
+func (st *Store) save(all []Search) error {
+	raw, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := st.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path)
+}
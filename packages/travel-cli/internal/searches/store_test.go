@@ -0,0 +1,42 @@
+package searches
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+func TestSaveReplacesByName(t *testing.T) {
+	store, err := OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := &Search{Name: "paris-summer", Kind: KindFlights, Flight: &core.FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"}}
+	if err := store.Save(first); err != nil {
+		t.Fatal(err)
+	}
+	second := &Search{Name: "paris-summer", Kind: KindStays, Stay: &core.StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-20"}}
+	if err := store.Save(second); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := store.List()
+	if err != nil || len(all) != 1 {
+		t.Fatalf("List = %+v, %v", all, err)
+	}
+	got, err := store.Get("paris-summer")
+	if err != nil || got.Stay == nil || got.Flight != nil || !got.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("Get = %+v, %v", got, err)
+	}
+
+	if err := store.Save(&Search{Name: "../escape"}); err == nil {
+		t.Error("invalid name saved")
+	}
+	if err := store.Remove("paris-summer"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("paris-summer"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Remove = %v", err)
+	}
+}
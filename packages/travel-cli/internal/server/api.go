@@ -0,0 +1,123 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/offers"
+)
+
+// maxBodyBytes bounds a request body.
+const maxBodyBytes = 1 << 20
+
+// The /v1 endpoints answer synchronously: a search responds with its
+// result once every provider has answered, timed out, or been cut off by
+// the soft deadline, as on the command line. Clients that accept
+// text/event-stream get the offers as they arrive instead; see
+// streamSearch.
+
+func (s *Server) handleSearchFlights(w http.ResponseWriter, r *http.Request) {
+	raw, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	req, err := flightRequest(raw)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid search request", Details: err.Error()})
+		return
+	}
+	s.respondSearch(w, r, func(searcher Searcher) (*core.SearchResult, error) { return searcher.SearchFlights(req) })
+}
+
+func (s *Server) handleSearchStays(w http.ResponseWriter, r *http.Request) {
+	raw, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	req, err := stayRequest(raw)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid search request", Details: err.Error()})
+		return
+	}
+	s.respondSearch(w, r, func(searcher Searcher) (*core.SearchResult, error) { return searcher.SearchStays(req) })
+}
+
+// respondSearch runs search in one of the job slots, so synchronous and
+// background searches share the same bound on provider load.
+func (s *Server) respondSearch(w http.ResponseWriter, r *http.Request, search func(Searcher) (*core.SearchResult, error)) {
+	if s.stream != nil && acceptsEventStream(r) {
+		s.streamSearch(w, r, search)
+		return
+	}
+	// A client that goes away gets no answer: its search is not started,
+	// or is cut short with the offers it found remembered.
+	if !s.acquire(r.Context()) {
+		return
+	}
+	result, err := search(s.searcherFor(r.Context()))
+	<-s.slots
+	if r.Context().Err() != nil {
+		if err == nil {
+			s.remember(result)
+		}
+		return
+	}
+	if errors.Is(err, core.ErrUnknownPlace) {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid search request", Details: err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: "search failed", Details: err.Error()})
+		return
+	}
+	s.remember(result)
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleGetOffer answers with the flight or stay offer an earlier search
+// returned under the ID.
+func (s *Server) handleGetOffer(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	flight, err := s.offers.Flight(id)
+	if err == nil {
+		writeJSON(w, http.StatusOK, flight)
+		return
+	}
+	if errors.Is(err, offers.ErrNotFound) {
+		var stay *core.StayOffer
+		if stay, err = s.offers.Stay(id); err == nil {
+			writeJSON(w, http.StatusOK, stay)
+			return
+		}
+	}
+	if errors.Is(err, offers.ErrNotFound) {
+		writeJSON(w, http.StatusNotFound, errorBody{Error: "offer not found", Details: "offers are kept for a week after the search that returned them"})
+		return
+	}
+	writeJSON(w, http.StatusInternalServerError, errorBody{Error: "could not load offer", Details: err.Error()})
+}
+
+func (s *Server) handleProviders(w http.ResponseWriter, r *http.Request) {
+	infos := s.providers()
+	if infos == nil {
+		infos = []core.ProviderInfo{}
+	}
+	writeJSON(w, http.StatusOK, infos)
+}
+
+func (s *Server) handleDoctor(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.doctor())
+}
+
+// readBody reads a request body up to maxBodyBytes, answering 400 itself
+// when it can't.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid JSON body", Details: err.Error()})
+		return nil, false
+	}
+	return raw, true
+}
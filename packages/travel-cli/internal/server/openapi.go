@@ -0,0 +1,180 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/beetlebot/travel-cli/internal/schema"
+)
+
+// openAPIVersion is the OpenAPI dialect of GET /openapi.json. 3.1 takes
+// JSON Schema 2020-12, so component schemas are the ones travel schema
+// prints.
+const openAPIVersion = "3.1.0"
+
+// componentsPrefix is how operations refer to component schemas.
+const componentsPrefix = "#/components/schemas/"
+
+type document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       info                            `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+}
+
+type info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type components struct {
+	Schemas map[string]*schema.Schema `json:"schemas"`
+}
+
+type operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]response `json:"responses"`
+}
+
+type parameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required"`
+	Schema   *schema.Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *schema.Schema `json:"schema"`
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.openAPI())
+}
+
+// openAPI describes the endpoints Handler serves, with schemas generated
+// from the Go types behind their requests and responses.
+func (s *Server) openAPI() document {
+	paths := map[string]map[string]operation{
+		"/v1/flights/search": {"post": {
+			OperationID: "searchFlights",
+			Summary:     "Search flights across the active providers",
+			RequestBody: jsonBody("FlightSearchRequest"),
+			Responses:   s.searchResponses(),
+		}},
+		"/v1/stays/search": {"post": {
+			OperationID: "searchStays",
+			Summary:     "Search stays across the active providers",
+			RequestBody: jsonBody("StaySearchRequest"),
+			Responses:   s.searchResponses(),
+		}},
+		"/jobs/search": {"post": {
+			OperationID: "createSearchJob",
+			Summary:     "Start a flight or stay search in the background",
+			// The request is a FlightSearchRequest or StaySearchRequest
+			// as kind says.
+			RequestBody: &requestBody{Required: true, Content: jsonContent(&schema.Schema{Type: "object", Required: []string{"kind", "request"}})},
+			Responses: map[string]response{
+				"202": {Description: "The job was queued; poll the Location header", Content: jsonContent(&schema.Schema{Type: "object", Required: []string{"id", "status", "poll"}})},
+				"400": errorResponse("The request is not a valid search"),
+			},
+		}},
+		"/jobs/{id}": {"get": {
+			OperationID: "getSearchJob",
+			Summary:     "Get a search job's status and, once finished, its result",
+			Parameters:  []parameter{pathParam("id")},
+			Responses: map[string]response{
+				"200": jsonResponse("The job", "Job"),
+				"404": errorResponse("No job has this ID"),
+			},
+		}},
+	}
+	if s.offers != nil {
+		paths["/v1/offers/{id}"] = map[string]operation{"get": {
+			OperationID: "getOffer",
+			Summary:     "Get a flight or stay offer a recent search returned",
+			Parameters:  []parameter{pathParam("id")},
+			Responses: map[string]response{
+				"200": {Description: "The offer", Content: jsonContent(&schema.Schema{AnyOf: []*schema.Schema{ref("FlightOffer"), ref("StayOffer")}})},
+				"404": errorResponse("No offer from the last week has this ID"),
+			},
+		}}
+	}
+	if s.providers != nil {
+		paths["/v1/providers"] = map[string]operation{"get": {
+			OperationID: "listProviders",
+			Summary:     "List the registered providers and their status",
+			Responses:   map[string]response{"200": {Description: "The providers", Content: jsonContent(&schema.Schema{Type: "array", Items: ref("ProviderInfo")})}},
+		}}
+	}
+	if s.doctor != nil {
+		paths["/v1/doctor"] = map[string]operation{"get": {
+			OperationID: "doctor",
+			Summary:     "Check configuration, credentials, and provider health",
+			Responses:   map[string]response{"200": jsonResponse("The health report", "DoctorReport")},
+		}}
+	}
+	if s.metrics != nil {
+		paths["/metrics"] = map[string]operation{"get": {
+			OperationID: "metrics",
+			Summary:     "Search, provider, and cache metrics in the Prometheus text format",
+			Responses:   map[string]response{"200": {Description: "The metrics", Content: map[string]mediaType{"text/plain": {Schema: &schema.Schema{Type: "string"}}}}},
+		}}
+	}
+	return document{
+		OpenAPI:    openAPIVersion,
+		Info:       info{Title: "travel", Version: "1"},
+		Paths:      paths,
+		Components: components{Schemas: schema.Components(componentsPrefix)},
+	}
+}
+
+func ref(name string) *schema.Schema {
+	return &schema.Schema{Ref: componentsPrefix + name}
+}
+
+func jsonContent(s *schema.Schema) map[string]mediaType {
+	return map[string]mediaType{"application/json": {Schema: s}}
+}
+
+func jsonBody(name string) *requestBody {
+	return &requestBody{Required: true, Content: jsonContent(ref(name))}
+}
+
+func jsonResponse(description, name string) response {
+	return response{Description: description, Content: jsonContent(ref(name))}
+}
+
+func errorResponse(description string) response {
+	return jsonResponse(description, "Error")
+}
+
+// searchResponses describes the answers to a /v1 search, including the
+// server-sent events streamed to clients that accept them.
+func (s *Server) searchResponses() map[string]response {
+	ok := jsonResponse("The ranked offers, with an error for each provider that failed", "SearchResult")
+	if s.stream != nil {
+		ok.Description += ". With Accept: text/event-stream, offers events (OfferBatch) as providers answer, then a result event (SearchResult) or an error event (Error)"
+		ok.Content[eventStream] = mediaType{Schema: &schema.Schema{Type: "string"}}
+	}
+	return map[string]response{
+		"200": ok,
+		"400": errorResponse("The request is not a valid search"),
+		"500": errorResponse("The search could not run"),
+	}
+}
+
+func pathParam(name string) parameter {
+	return parameter{Name: name, In: "path", Required: true, Schema: &schema.Schema{Type: "string"}}
+}
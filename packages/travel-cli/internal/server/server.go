@@ -0,0 +1,289 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/jobs"
+)
+
+// maxConcurrentJobs bounds how many background searches run at once so a
+// burst of job submissions can't exhaust provider quotas.
+const maxConcurrentJobs = 4
+
+// Searcher is the subset of the orchestrator the server needs.
+type Searcher interface {
+	SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error)
+	SearchStays(req core.StaySearchRequest) (*core.SearchResult, error)
+}
+
+// Offers keeps the offers searches return so they can be fetched by ID.
+// offers.Store is one.
+type Offers interface {
+	SaveResult(result *core.SearchResult) error
+	Flight(id string) (*core.FlightOffer, error)
+	Stay(id string) (*core.StayOffer, error)
+}
+
+type Server struct {
+	searcher Searcher
+	// searcherFor returns the searcher for a synchronous search, bound to
+	// its request's context.
+	searcherFor func(ctx context.Context) Searcher
+	jobs        *jobs.Store
+	slots       chan struct{}
+	metrics     http.Handler
+	offers      Offers
+	providers   func() []core.ProviderInfo
+	doctor      func() core.DoctorReport
+	stream      func(ctx context.Context, batches func(core.OfferBatch)) Searcher
+}
+
+func New(searcher Searcher, store *jobs.Store) *Server {
+	return &Server{
+		searcher:    searcher,
+		searcherFor: func(context.Context) Searcher { return searcher },
+		jobs:        store,
+		slots:       make(chan struct{}, maxConcurrentJobs),
+	}
+}
+
+// WithSearcherFor answers each synchronous /v1 search with the searcher
+// fn returns for the request's context, so a search stops when its client
+// goes away. Background jobs outlive their requests and keep using the
+// searcher New was given.
+func (s *Server) WithSearcherFor(fn func(ctx context.Context) Searcher) *Server {
+	s.searcherFor = fn
+	return s
+}
+
+// acquire takes one of the slots, waiting for a free one until ctx is
+// done, and reports whether it got one.
+func (s *Server) acquire(ctx context.Context) bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Slots returns the slots searches hold while they run. Other front ends
+// on the same orchestrator, such as the gRPC service, share them, so one
+// bound on provider load covers every search.
+func (s *Server) Slots() chan struct{} {
+	return s.slots
+}
+
+// WithMetrics serves h, a Prometheus metrics handler, on GET /metrics.
+func (s *Server) WithMetrics(h http.Handler) *Server {
+	s.metrics = h
+	return s
+}
+
+// WithOffers saves the offers of every search the server runs to o, and
+// serves them on GET /v1/offers/{id}.
+func (s *Server) WithOffers(o Offers) *Server {
+	s.offers = o
+	return s
+}
+
+// WithProviders serves the providers list returns, read on each request,
+// on GET /v1/providers.
+func (s *Server) WithProviders(list func() []core.ProviderInfo) *Server {
+	s.providers = list
+	return s
+}
+
+// WithDoctor serves the report check returns, run on each request, on
+// GET /v1/doctor.
+func (s *Server) WithDoctor(check func() core.DoctorReport) *Server {
+	s.doctor = check
+	return s
+}
+
+// WithStreaming answers /v1 searches from clients that accept
+// text/event-stream with server-sent events, searching with the Searcher
+// stream returns for each, which calls batches with each provider's
+// offers as they arrive and stops when ctx, the request's, is done.
+func (s *Server) WithStreaming(stream func(ctx context.Context, batches func(core.OfferBatch)) Searcher) *Server {
+	s.stream = stream
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs/search", s.handleCreateJob)
+	mux.HandleFunc("GET /jobs/{id}", s.handleGetJob)
+	mux.HandleFunc("POST /v1/flights/search", s.handleSearchFlights)
+	mux.HandleFunc("POST /v1/stays/search", s.handleSearchStays)
+	if s.offers != nil {
+		mux.HandleFunc("GET /v1/offers/{id}", s.handleGetOffer)
+	}
+	if s.providers != nil {
+		mux.HandleFunc("GET /v1/providers", s.handleProviders)
+	}
+	if s.doctor != nil {
+		mux.HandleFunc("GET /v1/doctor", s.handleDoctor)
+	}
+	if s.metrics != nil {
+		mux.Handle("GET /metrics", s.metrics)
+	}
+	mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	return mux
+}
+
+// jobRequest is the body of POST /jobs/search. Request holds a
+// FlightSearchRequest or StaySearchRequest depending on Kind.
+type jobRequest struct {
+	Kind    string          `json:"kind"`
+	Request json.RawMessage `json:"request"`
+}
+
+type jobAccepted struct {
+	ID     string      `json:"id"`
+	Status jobs.Status `json:"status"`
+	Poll   string      `json:"poll"`
+}
+
+type errorBody struct {
+	Error   string `json:"error"`
+	Details string `json:"details,omitempty"`
+}
+
+func (s *Server) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var body jobRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid JSON body", Details: err.Error()})
+		return
+	}
+
+	run, normalized, err := s.prepare(body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid search request", Details: err.Error()})
+		return
+	}
+
+	job := &jobs.Job{
+		ID:        jobs.NewID(),
+		Kind:      body.Kind,
+		Status:    jobs.StatusQueued,
+		Request:   normalized,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.jobs.Put(job); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: "could not persist job", Details: err.Error()})
+		return
+	}
+	_ = s.jobs.Prune()
+
+	accepted := jobAccepted{ID: job.ID, Status: job.Status, Poll: "/jobs/" + job.ID}
+	go s.execute(job, run)
+
+	w.Header().Set("Location", accepted.Poll)
+	writeJSON(w, http.StatusAccepted, accepted)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Get(r.PathValue("id"))
+	if errors.Is(err, jobs.ErrNotFound) {
+		writeJSON(w, http.StatusNotFound, errorBody{Error: "job not found"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: "could not load job", Details: err.Error()})
+		return
+	}
+	// Finished jobs can be polled long after the search ran.
+	core.AnnotateFreshness(job.Result, time.Now())
+	writeJSON(w, http.StatusOK, job)
+}
+
+// prepare validates the request up front so bad input fails the POST
+// rather than a job the client has to poll for.
+func (s *Server) prepare(body jobRequest) (func() (*core.SearchResult, error), json.RawMessage, error) {
+	switch body.Kind {
+	case jobs.KindFlights:
+		req, err := flightRequest(body.Request)
+		if err != nil {
+			return nil, nil, err
+		}
+		raw, _ := json.Marshal(req)
+		return func() (*core.SearchResult, error) { return s.searcher.SearchFlights(req) }, raw, nil
+	case jobs.KindStays:
+		req, err := stayRequest(body.Request)
+		if err != nil {
+			return nil, nil, err
+		}
+		raw, _ := json.Marshal(req)
+		return func() (*core.SearchResult, error) { return s.searcher.SearchStays(req) }, raw, nil
+	}
+	return nil, nil, fmt.Errorf("unknown kind %q (use flights or stays)", body.Kind)
+}
+
+func (s *Server) execute(job *jobs.Job, run func() (*core.SearchResult, error)) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	job.Status = jobs.StatusRunning
+	job.StartedAt = time.Now().UTC()
+	_ = s.jobs.Put(job)
+
+	result, err := run()
+	job.FinishedAt = time.Now().UTC()
+	if err != nil {
+		job.Status = jobs.StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = jobs.StatusSucceeded
+		job.Result = result
+		s.remember(result)
+	}
+	_ = s.jobs.Put(job)
+}
+
+// flightRequest decodes, validates, and fills in the defaults of a flight
+// search request.
+func flightRequest(raw []byte) (core.FlightSearchRequest, error) {
+	var req core.FlightSearchRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return req, err
+	}
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	req.ApplyDefaults()
+	return req, nil
+}
+
+// stayRequest is the stay counterpart of flightRequest.
+func stayRequest(raw []byte) (core.StaySearchRequest, error) {
+	var req core.StaySearchRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return req, err
+	}
+	if err := req.Validate(); err != nil {
+		return req, err
+	}
+	req.ApplyDefaults()
+	return req, nil
+}
+
+// remember saves result's offers for GET /v1/offers/{id}. It is a
+// convenience, so failures are ignored.
+func (s *Server) remember(result *core.SearchResult) {
+	if s.offers != nil {
+		_ = s.offers.SaveResult(result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
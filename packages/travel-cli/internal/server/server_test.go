@@ -0,0 +1,316 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/jobs"
+	"github.com/beetlebot/travel-cli/internal/offers"
+)
+
+type fakeSearcher struct{}
+
+func (fakeSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{Query: req, Flights: []core.FlightOffer{{ID: "f1", PriceUSD: 500}}, TotalFound: 1}, nil
+}
+
+func (fakeSearcher) SearchStays(req core.StaySearchRequest) (*core.SearchResult, error) {
+	return &core.SearchResult{Query: req}, nil
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	store, err := jobs.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	srv := httptest.NewServer(New(fakeSearcher{}, store).Handler())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestJobs_SubmitAndPoll(t *testing.T) {
+	srv := newTestServer(t)
+
+	body := `{"kind":"flights","request":{"from":"YUL","to":"CDG","departDate":"2026-06-12"}}`
+	resp, err := http.Post(srv.URL+"/jobs/search", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("post failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", resp.StatusCode)
+	}
+	var accepted jobAccepted
+	_ = json.NewDecoder(resp.Body).Decode(&accepted)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		r, err := http.Get(srv.URL + accepted.Poll)
+		if err != nil {
+			t.Fatalf("poll failed: %v", err)
+		}
+		var job jobs.Job
+		_ = json.NewDecoder(r.Body).Decode(&job)
+		r.Body.Close()
+
+		if job.Status == jobs.StatusSucceeded {
+			if job.Result == nil || job.Result.TotalFound != 1 {
+				t.Errorf("expected stored result, got %+v", job.Result)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish, last status %s", job.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestJobs_RejectsInvalidRequest(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Post(srv.URL+"/jobs/search", "application/json", strings.NewReader(`{"kind":"flights","request":{"from":"YUL"}}`))
+	if err != nil {
+		t.Fatalf("post failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestJobs_UnknownID(t *testing.T) {
+	srv := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/jobs/j_0000000000000000")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestV1_SearchThenGetOffer(t *testing.T) {
+	store, err := jobs.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := offers.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(New(fakeSearcher{}, store).WithOffers(saved).Handler())
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/v1/flights/search", "application/json", strings.NewReader(`{"from":"YUL","to":"CDG","departDate":"2026-06-12"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result core.SearchResult
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || result.TotalFound != 1 {
+		t.Fatalf("search: status %d, result %+v", resp.StatusCode, result)
+	}
+
+	resp, err = http.Get(srv.URL + "/v1/offers/f1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var offer core.FlightOffer
+	_ = json.NewDecoder(resp.Body).Decode(&offer)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || offer.PriceUSD != 500 {
+		t.Errorf("offer: status %d, %+v", resp.StatusCode, offer)
+	}
+
+	for path, want := range map[string]int{"/v1/offers/f2": http.StatusNotFound, "/v1/providers": http.StatusNotFound} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s = %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+
+	resp, err = http.Post(srv.URL+"/v1/stays/search", "application/json", strings.NewReader(`{"city":"Paris"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("stay search without dates = %d, want 400", resp.StatusCode)
+	}
+}
+
+// streamingSearcher sends each flight search's offers as a batch before
+// returning them.
+type streamingSearcher struct {
+	fakeSearcher
+	batches func(core.OfferBatch)
+}
+
+func (s streamingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	result, err := s.fakeSearcher.SearchFlights(req)
+	s.batches(core.OfferBatch{Search: "flights", Provider: "mock_flights", Flights: result.Flights})
+	return result, err
+}
+
+func TestV1_StreamSearch(t *testing.T) {
+	store, err := jobs.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream := func(_ context.Context, batches func(core.OfferBatch)) Searcher {
+		return streamingSearcher{batches: batches}
+	}
+	srv := httptest.NewServer(New(fakeSearcher{}, store).WithStreaming(stream).Handler())
+	defer srv.Close()
+
+	req, _ := http.NewRequest("POST", srv.URL+"/v1/flights/search", strings.NewReader(`{"from":"YUL","to":"CDG","departDate":"2026-06-12"}`))
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	if len(events) != 2 || !strings.HasPrefix(events[0], "event: offers\ndata: {") || !strings.HasPrefix(events[1], "event: result\ndata: {") {
+		t.Fatalf("events = %q", events)
+	}
+	var batch core.OfferBatch
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(events[0], "event: offers\ndata: ")), &batch); err != nil || len(batch.Flights) != 1 {
+		t.Errorf("offers event = %+v, %v", batch, err)
+	}
+	var result core.SearchResult
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(events[1], "event: result\ndata: ")), &result); err != nil || result.TotalFound != 1 {
+		t.Errorf("result event = %+v, %v", result, err)
+	}
+
+	// Clients that don't ask for events get the JSON result.
+	resp, err = http.Post(srv.URL+"/v1/flights/search", "application/json", strings.NewReader(`{"from":"YUL","to":"CDG","departDate":"2026-06-12"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type without Accept = %q", ct)
+	}
+}
+
+// blockingSearcher streams its offers, then waits for its context.
+type blockingSearcher struct {
+	streamingSearcher
+	ctx     context.Context
+	stopped chan struct{}
+}
+
+func (s blockingSearcher) SearchFlights(req core.FlightSearchRequest) (*core.SearchResult, error) {
+	result, err := s.streamingSearcher.SearchFlights(req)
+	<-s.ctx.Done()
+	close(s.stopped)
+	return result, err
+}
+
+func TestV1_StreamSearchStopsWhenClientLeaves(t *testing.T) {
+	store, err := jobs.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan struct{})
+	stream := func(ctx context.Context, batches func(core.OfferBatch)) Searcher {
+		return blockingSearcher{streamingSearcher: streamingSearcher{batches: batches}, ctx: ctx, stopped: stopped}
+	}
+	srv := httptest.NewServer(New(fakeSearcher{}, store).WithStreaming(stream).Handler())
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "POST", srv.URL+"/v1/flights/search", strings.NewReader(`{"from":"YUL","to":"CDG","departDate":"2026-06-12"}`))
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line, err := bufio.NewReader(resp.Body).ReadString('\n'); err != nil || line != "event: offers\n" {
+		t.Fatalf("first line = %q, %v", line, err)
+	}
+	cancel()
+	resp.Body.Close()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("search kept running after the client left")
+	}
+}
+
+func TestV1_SearchStopsWaitingWhenClientLeaves(t *testing.T) {
+	store, err := jobs.OpenDir(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := New(fakeSearcher{}, store)
+	for i := 0; i < cap(srv.Slots()); i++ {
+		srv.Slots() <- struct{}{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequestWithContext(ctx, "POST", "/v1/flights/search", strings.NewReader(`{"from":"YUL","to":"CDG","departDate":"2026-06-12"}`))
+	done := make(chan struct{})
+	go func() {
+		srv.Handler().ServeHTTP(httptest.NewRecorder(), req)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("search kept waiting for a slot after the client left")
+	}
+}
+
+func TestOpenAPI_RefsResolve(t *testing.T) {
+	srv := New(fakeSearcher{}, nil).
+		WithProviders(func() []core.ProviderInfo { return nil }).
+		WithDoctor(func() core.DoctorReport { return core.DoctorReport{} })
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/openapi.json", nil))
+	doc := rec.Body.String()
+
+	var parsed document
+	if err := json.Unmarshal([]byte(doc), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/v1/flights/search", "/v1/stays/search", "/v1/providers", "/v1/doctor"} {
+		if parsed.Paths[path] == nil {
+			t.Errorf("missing path %s", path)
+		}
+	}
+	if parsed.Paths["/v1/offers/{id}"] != nil {
+		t.Error("documents /v1/offers/{id}, which isn't served without an offer store")
+	}
+	for _, m := range regexp.MustCompile(`"\$ref":"([^"]*)"`).FindAllStringSubmatch(doc, -1) {
+		if name, ok := strings.CutPrefix(m[1], componentsPrefix); !ok || parsed.Components.Schemas[name] == nil {
+			t.Errorf("$ref %s does not resolve", m[1])
+		}
+	}
+}
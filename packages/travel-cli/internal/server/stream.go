@@ -0,0 +1,61 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// eventStream is the media type of server-sent events.
+const eventStream = "text/event-stream"
+
+// acceptsEventStream reports whether r's Accept header lists
+// text/event-stream.
+func acceptsEventStream(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		if mediaType, _, err := mime.ParseMediaType(accept); err == nil && mediaType == eventStream {
+			return true
+		}
+	}
+	return false
+}
+
+// streamSearch runs search as server-sent events, like travel's --stream:
+// an offers event with each provider's new or cheaper offers as they
+// arrive, then a result event with the ranked result, or an error event
+// if the search could not run. Each event's data is one line of JSON.
+func (s *Server) streamSearch(w http.ResponseWriter, r *http.Request, search func(Searcher) (*core.SearchResult, error)) {
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", eventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	// Once the client has gone, writes fail and the request's context
+	// cuts the search short; the offers it found are still remembered.
+	send := func(event string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err == nil {
+			_ = rc.Flush()
+		}
+	}
+
+	if !s.acquire(r.Context()) {
+		return
+	}
+	result, err := search(s.stream(r.Context(), func(b core.OfferBatch) { send("offers", b) }))
+	<-s.slots
+	if err != nil {
+		send("error", errorBody{Error: "search failed", Details: err.Error()})
+		return
+	}
+	s.remember(result)
+	send("result", result)
+}
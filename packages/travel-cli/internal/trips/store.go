@@ -0,0 +1,207 @@
+package trips
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/paths"
+)
+
+type SegmentKind string
+
+const (
+	KindFlight SegmentKind = "flight"
+	KindRail   SegmentKind = "rail"
+	KindCar    SegmentKind = "car"
+	KindStay   SegmentKind = "stay"
+)
+
+func ParseKind(s string) (SegmentKind, error) {
+	switch k := SegmentKind(strings.ToLower(s)); k {
+	case KindFlight, KindRail, KindCar, KindStay:
+		return k, nil
+	}
+	return "", fmt.Errorf("unknown segment kind %q (use flight, rail, car, stay)", s)
+}
+
+// Segment is one leg or stay of a trip. Transport segments use From/To and
+// times; stay segments use Name and CheckIn/CheckOut.
+type Segment struct {
+	Kind         SegmentKind `json:"kind"`
+	From         string      `json:"from,omitempty"`
+	To           string      `json:"to,omitempty"`
+	DepartTime   time.Time   `json:"departTime,omitzero"`
+	ArriveTime   time.Time   `json:"arriveTime,omitzero"`
+	DistanceKm   float64     `json:"distanceKm,omitempty"`
+	Carrier      string      `json:"carrier,omitempty"`
+	Number       string      `json:"number,omitempty"`
+	CabinClass   string      `json:"cabinClass,omitempty"`
+	Passengers   int         `json:"passengers,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	CheckIn      string      `json:"checkIn,omitempty"`
+	CheckOut     string      `json:"checkOut,omitempty"`
+	OfferID      string      `json:"offerId,omitempty"`
+	Confirmation string      `json:"confirmation,omitempty"`
+}
+
+// Validate checks that a segment has what its kind needs: a name and
+// dates for stays, and endpoints for transport.
+func (seg Segment) Validate() error {
+	if _, err := ParseKind(string(seg.Kind)); err != nil {
+		return err
+	}
+	if seg.Kind == KindStay {
+		if seg.Name == "" || seg.CheckIn == "" || seg.CheckOut == "" {
+			return fmt.Errorf("stay segments need a name, checkIn and checkOut")
+		}
+	} else if seg.From == "" || seg.To == "" {
+		return fmt.Errorf("%s segments need from and to", seg.Kind)
+	}
+	return nil
+}
+
+type Trip struct {
+	Name      string    `json:"name"`
+	Segments  []Segment `json:"segments"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+var ErrNotFound = errors.New("trip not found")
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Store keeps one JSON file per trip.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func Open() (*Store, error) {
+	base, err := paths.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return OpenDir(filepath.Join(base, "travel", "trips"))
+}
+
+func OpenDir(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create trips dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+func (s *Store) Get(name string) (*Trip, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(name)
+}
+
+func (s *Store) get(name string) (*Trip, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid trip name %q", name)
+	}
+	data, err := os.ReadFile(s.path(name))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var t Trip
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("decode trip %s: %w", name, err)
+	}
+	return &t, nil
+}
+
+// Create adds an empty trip. It fails if the trip already exists.
+func (s *Store) Create(name string) (*Trip, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid trip name %q (letters, digits, '.', '_' and '-' only)", name)
+	}
+	if _, err := os.Stat(s.path(name)); err == nil {
+		return nil, fmt.Errorf("trip %s already exists", name)
+	}
+	now := time.Now().UTC()
+	t := &Trip{Name: name, Segments: []Segment{}, CreatedAt: now, UpdatedAt: now}
+	return t, s.write(t)
+}
+
+// AddSegment validates and appends a segment, keeping segments in
+// chronological order.
+func (s *Store) AddSegment(name string, seg Segment) (*Trip, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := seg.Validate(); err != nil {
+		return nil, err
+	}
+	t, err := s.get(name)
+	if err != nil {
+		return nil, err
+	}
+	t.Segments = append(t.Segments, seg)
+	sort.SliceStable(t.Segments, func(i, j int) bool {
+		return t.Segments[i].start().Before(t.Segments[j].start())
+	})
+	t.UpdatedAt = time.Now().UTC()
+	return t, s.write(t)
+}
+
+func (s *Store) List() ([]Trip, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []Trip
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		if t, err := s.get(name); err == nil {
+			out = append(out, *t)
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) write(t *Trip) error {
+	raw, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(t.Name), raw, 0o644)
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".json")
+}
+
+// start is the sort key for a segment: departure for transport, check-in
+// for stays. Segments without a date sort first.
+func (seg Segment) start() time.Time {
+	if !seg.DepartTime.IsZero() {
+		return seg.DepartTime
+	}
+	if t, err := time.Parse("2006-01-02", seg.CheckIn); err == nil {
+		return t
+	}
+	return time.Time{}
+}
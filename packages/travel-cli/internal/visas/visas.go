@@ -0,0 +1,168 @@
+// Package visas serves what a passport needs to visit a country as a
+// tourist, from a list embedded in the binary covering the countries in
+// the destinations guide and the most common passports. Entry rules
+// change; the list is a hint to check against official sources, not
+// advice.
+package visas
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed visas.json
+var rawDataset []byte
+
+// Entry requirements, from least to most involved.
+const (
+	// None is for citizens and free movement, as within the EU.
+	None     = "none"
+	VisaFree = "visa_free"
+	// ETA is an electronic travel authorization applied for online
+	// before leaving, such as ESTA or Canada's eTA.
+	ETA          = "eta"
+	VisaRequired = "visa_required"
+)
+
+// Requirement is what holders of a passport need to visit a country.
+type Requirement struct {
+	Nationality string `json:"nationality"`
+	Country     string `json:"country"`
+	CountryName string `json:"countryName"`
+	// Requirement is None, VisaFree, ETA, or VisaRequired, and
+	// VisaRequired is set for the last.
+	Requirement  string `json:"requirement"`
+	VisaRequired bool   `json:"visaRequired"`
+	// Authorization names the visa or ETA to apply for.
+	Authorization string `json:"authorization,omitempty"`
+	// Days is the longest stay allowed without a visa.
+	Days int    `json:"days,omitempty"`
+	Note string `json:"note,omitempty"`
+	// AsOf is when the list was last checked.
+	AsOf string `json:"asOf"`
+}
+
+type rule struct {
+	Requirement   string   `json:"requirement"`
+	Authorization string   `json:"authorization,omitempty"`
+	Days          int      `json:"days,omitempty"`
+	Passports     []string `json:"passports"`
+	Note          string   `json:"note,omitempty"`
+}
+
+// country lists its rules, or names the rule set it shares, such as the
+// Schengen area's. A passport gets the first rule listing it.
+type country struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	RuleSet string `json:"ruleSet,omitempty"`
+	Rules   []rule `json:"rules,omitempty"`
+}
+
+// dataset's passport lists may name groups, such as EEA.
+type dataset struct {
+	AsOf      string              `json:"asOf"`
+	Groups    map[string][]string `json:"groups"`
+	Covers    []string            `json:"covers"`
+	RuleSets  map[string][]rule   `json:"ruleSets"`
+	Countries []country           `json:"countries"`
+}
+
+var ErrNotFound = errors.New("no visa information")
+
+var (
+	loadOnce  sync.Once
+	data      dataset
+	byCountry map[string]*country
+	covered   map[string]bool
+	loadErr   error
+)
+
+func load() error {
+	loadOnce.Do(func() {
+		if err := json.Unmarshal(rawDataset, &data); err != nil {
+			loadErr = fmt.Errorf("decode embedded visas: %w", err)
+			return
+		}
+		expand := func(passports []string) []string {
+			var out []string
+			for _, p := range passports {
+				if group, ok := data.Groups[p]; ok {
+					out = append(out, group...)
+				} else {
+					out = append(out, p)
+				}
+			}
+			return out
+		}
+		covered = map[string]bool{}
+		for _, p := range expand(data.Covers) {
+			covered[p] = true
+		}
+		byCountry = make(map[string]*country, len(data.Countries))
+		for i := range data.Countries {
+			c := &data.Countries[i]
+			if c.RuleSet != "" {
+				c.Rules = data.RuleSets[c.RuleSet]
+			}
+			rules := make([]rule, len(c.Rules))
+			for j, r := range c.Rules {
+				r.Passports = expand(r.Passports)
+				rules[j] = r
+			}
+			c.Rules = rules
+			byCountry[c.Code] = c
+		}
+	})
+	return loadErr
+}
+
+// Lookup returns what a passport of nationality, an ISO 3166 country
+// code, needs to visit country. Citizens need nothing; passports the list
+// doesn't cover and countries it lacks are ErrNotFound.
+func Lookup(nationality, country string) (*Requirement, error) {
+	if err := load(); err != nil {
+		return nil, err
+	}
+	nationality = strings.ToUpper(strings.TrimSpace(nationality))
+	code := strings.ToUpper(strings.TrimSpace(country))
+	c, ok := byCountry[code]
+	if !ok {
+		return nil, fmt.Errorf("%w for %q (covered: %s)", ErrNotFound, country, strings.Join(Countries(), ", "))
+	}
+	req := &Requirement{Nationality: nationality, Country: c.Code, CountryName: c.Name, AsOf: data.AsOf}
+	if nationality == c.Code {
+		req.Requirement, req.Note = None, "Citizens"
+		return req, nil
+	}
+	if !covered[nationality] {
+		return nil, fmt.Errorf("%w for %q passports", ErrNotFound, nationality)
+	}
+	req.Requirement = VisaRequired
+	for _, r := range c.Rules {
+		if slices.Contains(r.Passports, nationality) {
+			req.Requirement, req.Authorization, req.Days, req.Note = r.Requirement, r.Authorization, r.Days, r.Note
+			break
+		}
+	}
+	req.VisaRequired = req.Requirement == VisaRequired
+	return req, nil
+}
+
+// Countries lists the codes of the countries covered, sorted.
+func Countries() []string {
+	if err := load(); err != nil {
+		return nil
+	}
+	var codes []string
+	for code := range byCountry {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
@@ -0,0 +1,78 @@
+package visas
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/destinations"
+)
+
+func TestLookup(t *testing.T) {
+	for _, tc := range []struct {
+		nationality, country, want string
+		days                       int
+	}{
+		{"CA", "JP", VisaFree, 90},
+		{"ca", "us", VisaFree, 180},
+		{"FR", "US", ETA, 90},
+		{"BG", "US", VisaRequired, 0},
+		{"DE", "FR", None, 0},
+		{"IN", "ES", VisaRequired, 0},
+		{"GB", "GB", None, 0},
+	} {
+		r, err := Lookup(tc.nationality, tc.country)
+		if err != nil {
+			t.Fatalf("Lookup(%s, %s): %v", tc.nationality, tc.country, err)
+		}
+		if r.Requirement != tc.want || r.Days != tc.days || r.VisaRequired != (tc.want == VisaRequired) || r.AsOf == "" {
+			t.Errorf("Lookup(%s, %s) = %+v, want %s for %d days", tc.nationality, tc.country, r, tc.want, tc.days)
+		}
+	}
+	if _, err := Lookup("CA", "KP"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Lookup(CA, KP): err = %v, want ErrNotFound", err)
+	}
+	if _, err := Lookup("KP", "JP"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Lookup(KP, JP): err = %v, want ErrNotFound", err)
+	}
+}
+
+// Every covered passport is listed for every country, so none falls to
+// the visa-required default by omission.
+func TestDatasetComplete(t *testing.T) {
+	if err := load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range data.Countries {
+		listed := map[string]bool{}
+		for _, r := range c.Rules {
+			if !slices.Contains([]string{None, VisaFree, ETA, VisaRequired}, r.Requirement) {
+				t.Errorf("%s: unknown requirement %q", c.Code, r.Requirement)
+			}
+			for _, p := range r.Passports {
+				listed[p] = true
+			}
+		}
+		for p := range covered {
+			if !listed[p] && p != c.Code {
+				t.Errorf("%s: %s passports not listed", c.Code, p)
+			}
+		}
+	}
+}
+
+func TestCoversDestinations(t *testing.T) {
+	names, err := destinations.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		d, err := destinations.Lookup(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Contains(Countries(), d.CountryCode) {
+			t.Errorf("%s's country %s not covered", name, d.CountryCode)
+		}
+	}
+}
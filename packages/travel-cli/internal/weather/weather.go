@@ -0,0 +1,165 @@
+// Package weather tells the weather at a destination with Open-Meteo's
+// free APIs: the forecast for dates in its reach, and the same dates in
+// past years, from its archive, for dates further out.
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/httpclient"
+)
+
+const (
+	DefaultForecastURL = "https://api.open-meteo.com/v1/forecast"
+	DefaultArchiveURL  = "https://archive-api.open-meteo.com/v1/archive"
+)
+
+const (
+	// ForecastDays is how many days ahead, today included, Open-Meteo
+	// forecasts.
+	ForecastDays = 16
+	// HistoryYears is how many past years are averaged for dates past
+	// the forecast.
+	HistoryYears = 3
+	// archiveLag is how far behind today the archive is complete.
+	archiveLag = 7 * 24 * time.Hour
+)
+
+// OpenMeteo tells the weather with Open-Meteo's forecast and historical
+// weather APIs.
+type OpenMeteo struct {
+	ForecastURL string
+	ArchiveURL  string
+	client      *http.Client
+	now         func() time.Time
+}
+
+// NewOpenMeteo returns a client of Open-Meteo's servers, or others at the
+// given URLs when they aren't empty.
+func NewOpenMeteo(forecastURL, archiveURL string) *OpenMeteo {
+	if forecastURL == "" {
+		forecastURL = DefaultForecastURL
+	}
+	if archiveURL == "" {
+		archiveURL = DefaultArchiveURL
+	}
+	return &OpenMeteo{ForecastURL: forecastURL, ArchiveURL: archiveURL, client: httpclient.New(), now: time.Now}
+}
+
+// Weather returns the forecast when every day from from to to is in its
+// reach, and otherwise each day's average over the last HistoryYears
+// years that are fully in the archive.
+func (m *OpenMeteo) Weather(ctx context.Context, lat, lon float64, from, to time.Time) (*core.WeatherSummary, error) {
+	today := m.now().UTC().Truncate(24 * time.Hour)
+	if !from.Before(today) && to.Before(today.AddDate(0, 0, ForecastDays)) {
+		days, err := m.daily(ctx, m.ForecastURL, lat, lon, from, to)
+		if err != nil {
+			return nil, err
+		}
+		if len(days) == 0 {
+			return nil, fmt.Errorf("weather: no forecast for %s to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+		}
+		return &core.WeatherSummary{Source: core.WeatherForecast, Days: days}, nil
+	}
+
+	back := 1
+	for to.AddDate(-back, 0, 0).After(today.Add(-archiveLag)) {
+		back++
+	}
+	past, err := m.daily(ctx, m.ArchiveURL, lat, lon, from.AddDate(-(back+HistoryYears-1), 0, 0), to.AddDate(-back, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string]core.WeatherDay, len(past))
+	for _, d := range past {
+		byDate[d.Date] = d
+	}
+	var days []core.WeatherDay
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		avg := core.WeatherDay{Date: day.Format(time.DateOnly)}
+		n := 0.0
+		for y := back; y < back+HistoryYears; y++ {
+			d, ok := byDate[day.AddDate(-y, 0, 0).Format(time.DateOnly)]
+			if !ok {
+				continue
+			}
+			avg.HighC += d.HighC
+			avg.LowC += d.LowC
+			avg.PrecipitationMm += d.PrecipitationMm
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		avg.HighC = round1(avg.HighC / n)
+		avg.LowC = round1(avg.LowC / n)
+		avg.PrecipitationMm = round1(avg.PrecipitationMm / n)
+		days = append(days, avg)
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("weather: no past weather for %s to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	return &core.WeatherSummary{Source: core.WeatherHistory, Years: HistoryYears, Days: days}, nil
+}
+
+// daily fetches the daily high, low, and precipitation at a point from
+// an Open-Meteo API, skipping days it has no values for.
+func (m *OpenMeteo) daily(ctx context.Context, base string, lat, lon float64, from, to time.Time) ([]core.WeatherDay, error) {
+	q := url.Values{
+		"latitude":   {strconv.FormatFloat(lat, 'f', 4, 64)},
+		"longitude":  {strconv.FormatFloat(lon, 'f', 4, 64)},
+		"daily":      {"temperature_2m_max,temperature_2m_min,precipitation_sum"},
+		"start_date": {from.Format(time.DateOnly)},
+		"end_date":   {to.Format(time.DateOnly)},
+		"timezone":   {"auto"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("weather: %w", err)
+	}
+	defer resp.Body.Close()
+	// Open-Meteo writes values it lacks as null, and explains refusals
+	// in reason.
+	var body struct {
+		Reason string `json:"reason"`
+		Daily  struct {
+			Time          []string   `json:"time"`
+			High          []*float64 `json:"temperature_2m_max"`
+			Low           []*float64 `json:"temperature_2m_min"`
+			Precipitation []*float64 `json:"precipitation_sum"`
+		} `json:"daily"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("decode weather response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("weather: %s", strings.TrimSpace(resp.Status+" "+body.Reason))
+	}
+	daily := body.Daily
+	var days []core.WeatherDay
+	for i, date := range daily.Time {
+		if i >= len(daily.High) || i >= len(daily.Low) || i >= len(daily.Precipitation) ||
+			daily.High[i] == nil || daily.Low[i] == nil || daily.Precipitation[i] == nil {
+			continue
+		}
+		days = append(days, core.WeatherDay{Date: date, HighC: *daily.High[i], LowC: *daily.Low[i], PrecipitationMm: *daily.Precipitation[i]})
+	}
+	return days, nil
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,73 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// openMeteo serves daily weather for every day from start_date to
+// end_date: a high of 20 plus the year's last digit, a low of 10, and 2 mm
+// of rain on odd days of the month.
+func openMeteo(paths *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		q := r.URL.Query()
+		if q.Get("latitude") != "38.7223" || !strings.Contains(q.Get("daily"), "precipitation_sum") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":true,"reason":"bad query"}`)
+			return
+		}
+		from, _ := time.Parse(time.DateOnly, q.Get("start_date"))
+		to, _ := time.Parse(time.DateOnly, q.Get("end_date"))
+		var dates, highs, lows, rain []string
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			dates = append(dates, `"`+d.Format(time.DateOnly)+`"`)
+			highs = append(highs, fmt.Sprint(20+d.Year()%10))
+			lows = append(lows, "10")
+			rain = append(rain, fmt.Sprint(2*(d.Day()%2)))
+		}
+		fmt.Fprintf(w, `{"daily":{"time":[%s],"temperature_2m_max":[%s],"temperature_2m_min":[%s],"precipitation_sum":[%s]}}`,
+			strings.Join(dates, ","), strings.Join(highs, ","), strings.Join(lows, ","), strings.Join(rain, ","))
+	}))
+}
+
+func TestOpenMeteo(t *testing.T) {
+	var paths []string
+	srv := openMeteo(&paths)
+	defer srv.Close()
+	m := NewOpenMeteo(srv.URL+"/forecast", srv.URL+"/archive")
+	m.now = func() time.Time { return time.Date(2026, 6, 10, 15, 0, 0, 0, time.UTC) }
+	date := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+
+	w, err := m.Weather(context.Background(), 38.7223, -9.1393, date("2026-06-12"), date("2026-06-14"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Source != core.WeatherForecast || len(w.Days) != 3 || w.Days[0].HighC != 26 || paths[0] != "/forecast" {
+		t.Errorf("near dates = %+v from %v, want a 3-day forecast", w, paths)
+	}
+
+	// Past the forecast, each day averages 2023 to 2025.
+	w, err = m.Weather(context.Background(), 38.7223, -9.1393, date("2026-09-01"), date("2026-09-02"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []core.WeatherDay{{Date: "2026-09-01", HighC: 24, LowC: 10, PrecipitationMm: 2}, {Date: "2026-09-02", HighC: 24, LowC: 10}}
+	if w.Source != core.WeatherHistory || w.Years != HistoryYears || fmt.Sprint(w.Days) != fmt.Sprint(want) || paths[1] != "/archive" {
+		t.Errorf("far dates = %+v from %v, want %v", w, paths, want)
+	}
+
+	if _, err := m.Weather(context.Background(), 0, 0, date("2026-06-12"), date("2026-06-14")); err == nil || !strings.Contains(err.Error(), "bad query") {
+		t.Errorf("err = %v, want Open-Meteo's reason", err)
+	}
+}
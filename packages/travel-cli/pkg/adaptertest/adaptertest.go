@@ -0,0 +1,35 @@
+// Package adaptertest forwards to pkg/travelsdk/adaptertest, where the
+// adapter contract checks now live with the rest of the adapter SDK.
+//
+// Deprecated: import github.com/beetlebot/travel-cli/pkg/travelsdk/adaptertest.
+package adaptertest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+	"github.com/beetlebot/travel-cli/pkg/travelsdk/adaptertest"
+)
+
+// FlightRequests is adaptertest.FlightRequests.
+func FlightRequests(now time.Time) []travelsdk.FlightSearchRequest {
+	return adaptertest.FlightRequests(now)
+}
+
+// StayRequests is adaptertest.StayRequests.
+func StayRequests(now time.Time) []travelsdk.StaySearchRequest {
+	return adaptertest.StayRequests(now)
+}
+
+// Flights is adaptertest.Flights.
+func Flights(t *testing.T, a travelsdk.FlightAdapter) {
+	t.Helper()
+	adaptertest.Flights(t, a)
+}
+
+// Stays is adaptertest.Stays.
+func Stays(t *testing.T, a travelsdk.StayAdapter) {
+	t.Helper()
+	adaptertest.Stays(t, a)
+}
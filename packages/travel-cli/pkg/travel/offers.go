@@ -0,0 +1,34 @@
+package travel
+
+import (
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// Flight returns a flight offer from a recent search by ID.
+func (c *Client) Flight(id string) (*FlightOffer, error) {
+	return c.offers.Flight(id)
+}
+
+// Stay returns a stay offer from a recent search by ID.
+func (c *Client) Stay(id string) (*StayOffer, error) {
+	return c.offers.Stay(id)
+}
+
+// Combine prices a flight and a stay from recent searches as one trip.
+// Offers past their expiry or price guarantee are combined with a warning,
+// and the config's traveler.nationality adds a visa hint.
+func (c *Client) Combine(flightID, stayID string) (*CombinedOffer, error) {
+	flight, err := c.offers.Flight(flightID)
+	if err != nil {
+		return nil, err
+	}
+	stay, err := c.offers.Stay(stayID)
+	if err != nil {
+		return nil, err
+	}
+	combined := core.CombineOffers(*flight, *stay, time.Now())
+	combined.CheckVisa(c.nationality, *flight, *stay)
+	return &combined, nil
+}
@@ -0,0 +1,61 @@
+package travel
+
+import (
+	"context"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// SearchFlights searches every active flight provider and returns the
+// deduped, ranked offers. Missing optional fields get the CLI's defaults.
+//
+// When ctx is done the search stops waiting on the providers still
+// running and returns ctx.Err().
+func (c *Client) SearchFlights(ctx context.Context, req FlightSearchRequest) (*SearchResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.ApplyDefaults()
+	return c.search(ctx, func(o *core.Orchestrator) (*SearchResult, error) { return o.SearchFlights(req) })
+}
+
+// SearchStays searches every active stay provider, like SearchFlights.
+func (c *Client) SearchStays(ctx context.Context, req StaySearchRequest) (*SearchResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.ApplyDefaults()
+	return c.search(ctx, func(o *core.Orchestrator) (*SearchResult, error) { return o.SearchStays(req) })
+}
+
+// LookupStays prices a known property, or a chain's properties in a city,
+// across the providers that support direct lookups.
+func (c *Client) LookupStays(ctx context.Context, req StayLookupRequest) (*SearchResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.ApplyDefaults()
+	return c.search(ctx, func(o *core.Orchestrator) (*SearchResult, error) { return o.LookupStays(req) })
+}
+
+// search runs fn on an orchestrator bound to ctx. Results are annotated
+// with their freshness and their offers remembered for Combine.
+func (c *Client) search(ctx context.Context, fn func(*core.Orchestrator) (*SearchResult, error)) (*SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := fn(c.orch.ForContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// An interrupted search returns the offers that arrived in time; the
+	// SDK reports the cancellation instead of a partial result.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	core.AnnotateFreshness(result, time.Now())
+	_ = c.offers.Prune()
+	_ = c.offers.SaveResult(result)
+	return result, nil
+}
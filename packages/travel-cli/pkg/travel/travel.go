@@ -0,0 +1,240 @@
+// Package travel embeds the travel search broker in Go programs, so
+// services can search flights and stays, combine offers, and keep trips
+// without shelling out to the travel CLI.
+//
+//	client, err := travel.New(travel.WithMode(travel.ModeMock))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.SearchFlights(ctx, travel.FlightSearchRequest{
+//		From: "YUL", To: "CDG", DepartDate: "2026-06-12",
+//	})
+//
+// A client reads the same config file as the CLI unless given
+// WithConfigFile, and shares its result cache, remembered offers, saved
+// trips, search history, and provider usage unless given WithDataDir.
+// Geocodes, exchange rates, and on-time scores, which are public data,
+// are cached with the CLI's either way. Searches are ranked as the CLI
+// ranks them, and answered from the cache while fresh by cache.ttl or
+// WithCacheTTL. Flight searches take city names as well as airport codes.
+// Provider keys come from the environment, falling back to credentials
+// saved with travel auth; the process environment is never changed.
+// Clients are safe for concurrent use.
+package travel
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/broker"
+	"github.com/beetlebot/travel-cli/internal/cache"
+	"github.com/beetlebot/travel-cli/internal/config"
+	"github.com/beetlebot/travel-cli/internal/core"
+	"github.com/beetlebot/travel-cli/internal/credentials"
+	"github.com/beetlebot/travel-cli/internal/logging"
+	"github.com/beetlebot/travel-cli/internal/offers"
+	"github.com/beetlebot/travel-cli/internal/trips"
+)
+
+// Mode selects which providers a client searches.
+type Mode string
+
+const (
+	// ModeMock searches the built-in mock providers only.
+	ModeMock Mode = "mock"
+	// ModeLive searches the providers with credentials only.
+	ModeLive Mode = "live"
+	// ModeHybrid searches live providers, falling back to mock ones.
+	ModeHybrid Mode = "hybrid"
+)
+
+// Types shared with the CLI. Their JSON forms are the CLI's output.
+type (
+	FlightSearchRequest = core.FlightSearchRequest
+	StaySearchRequest   = core.StaySearchRequest
+	StayLookupRequest   = core.StayLookupRequest
+	SearchResult        = core.SearchResult
+	FlightOffer         = core.FlightOffer
+	StayOffer           = core.StayOffer
+	CombinedOffer       = core.CombinedOffer
+	OfferWarning        = core.OfferWarning
+	ProgressEvent       = core.ProgressEvent
+	OfferBatch          = core.OfferBatch
+	WeatherSummary      = core.WeatherSummary
+	WeatherDay          = core.WeatherDay
+
+	Trip        = trips.Trip
+	Segment     = trips.Segment
+	SegmentKind = trips.SegmentKind
+)
+
+const (
+	SegmentFlight = trips.KindFlight
+	SegmentRail   = trips.KindRail
+	SegmentCar    = trips.KindCar
+	SegmentStay   = trips.KindStay
+)
+
+var (
+	// ErrOfferNotFound is returned for offer IDs no search has returned
+	// recently.
+	ErrOfferNotFound = offers.ErrNotFound
+	// ErrTripNotFound is returned for trips that were never created.
+	ErrTripNotFound = trips.ErrNotFound
+)
+
+// Client runs searches and manages offers and trips.
+type Client struct {
+	orch   *core.Orchestrator
+	offers *offers.Store
+	trips  *trips.Store
+	// nationality is traveler.nationality, for visa hints.
+	nationality string
+}
+
+type options struct {
+	cfgFile  string
+	mode     Mode
+	dataDir  string
+	cacheTTL *time.Duration
+	progress func(ProgressEvent)
+	batches  func(OfferBatch)
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithConfigFile reads the config file at path instead of the CLI's. New
+// fails if it can't be read or parsed.
+func WithConfigFile(path string) Option {
+	return func(o *options) { o.cfgFile = path }
+}
+
+// WithMode overrides the config's provider mode. New fails for a mode
+// other than ModeMock, ModeLive, or ModeHybrid.
+func WithMode(mode Mode) Option {
+	return func(o *options) { o.mode = mode }
+}
+
+// WithDataDir keeps the result cache, remembered offers, trips, search
+// history, and provider usage and rate limits under dir instead of the
+// CLI's data and cache directories.
+func WithDataDir(dir string) Option {
+	return func(o *options) { o.dataDir = dir }
+}
+
+// WithCacheTTL reuses the result of the same search run within ttl,
+// instead of the config's cache.ttl. Zero always queries the providers
+// but still refreshes the cache, like travel's --no-cache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) { o.cacheTTL = &ttl }
+}
+
+// WithProgress calls fn as each provider starts, finishes, or fails and
+// when ranking is done. One search's events arrive one at a time, but
+// searches run at once call fn concurrently, and a search whose ctx is
+// done keeps calling it until it finishes in the background.
+func WithProgress(fn func(ProgressEvent)) Option {
+	return func(o *options) { o.progress = fn }
+}
+
+// WithBatches calls fn with each provider's new or cheaper offers as they
+// arrive, before the search returns its ranked result.
+func WithBatches(fn func(OfferBatch)) Option {
+	return func(o *options) { o.batches = fn }
+}
+
+// New returns a client for the active providers of the configured mode.
+func New(opts ...Option) (*Client, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	cfg := config.Load()
+	if o.cfgFile != "" {
+		file, err := config.LoadPath(o.cfgFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg = file
+	}
+	if o.mode != "" {
+		mode, err := config.ParseMode(string(o.mode))
+		if err != nil {
+			return nil, err
+		}
+		cfg.Mode = mode
+	}
+	if o.dataDir != "" {
+		cfg.DataDir = o.dataDir
+	}
+	cfg.Credentials = savedCredentials()
+
+	c := &Client{orch: broker.NewOrchestrator(cfg), nationality: cfg.Traveler.Nationality}
+	if o.progress != nil {
+		c.orch.WithProgress(o.progress)
+	}
+	if o.batches != nil {
+		c.orch.WithBatches(o.batches)
+	}
+	if !cfg.Cache.Disabled {
+		c.orch.WithCache(openCache(cfg.Cache, o))
+	}
+
+	var err error
+	if o.dataDir != "" {
+		c.offers, err = offers.OpenDir(filepath.Join(o.dataDir, "offers"))
+	} else {
+		c.offers, err = offers.Open()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.dataDir != "" {
+		c.trips, err = trips.OpenDir(filepath.Join(o.dataDir, "trips"))
+	} else {
+		c.trips, err = trips.Open()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// savedCredentials returns the keys saved with travel auth by variable
+// name, for the config to fall back on when the variable is unset.
+func savedCredentials() map[string]string {
+	creds := map[string]string{}
+	if store, err := credentials.Open(); err == nil {
+		if entries, err := store.Entries(); err == nil {
+			for _, e := range entries {
+				creds[e.Key] = e.Value
+			}
+		}
+	}
+	return creds
+}
+
+// openCache returns the result cache for o, or nil, searching without
+// one, if it can't be created.
+func openCache(cfg config.CacheConfig, o options) core.ResultCache {
+	ttl := cfg.TTLFor
+	if o.cacheTTL != nil {
+		override := *o.cacheTTL
+		ttl = func(string) time.Duration { return override }
+	}
+	var (
+		store *cache.Store
+		err   error
+	)
+	if o.dataDir != "" {
+		store, err = cache.OpenDir(filepath.Join(o.dataDir, "cache"), ttl)
+	} else {
+		store, err = cache.Open(ttl)
+	}
+	if err != nil {
+		logging.Logger().Debug("result cache unavailable", "error", err)
+		return nil
+	}
+	return store
+}
@@ -0,0 +1,157 @@
+package travel
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/beetlebot/travel-cli/internal/credentials"
+)
+
+func newMockClient(t *testing.T) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TRAVEL_CONFIG", "")
+	c, err := New(WithMode(ModeMock), WithDataDir(t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNewRejectsUnknownMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TRAVEL_CONFIG", "")
+	if _, err := New(WithMode("sandbox"), WithDataDir(t.TempDir())); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestNewLeavesEnvironment(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("TRAVEL_CONFIG", "")
+	t.Setenv("TRAVEL_CREDENTIALS_BACKEND", "file")
+	t.Setenv("DUFFEL_API_TOKEN", "")
+	store, err := credentials.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set("duffel", "DUFFEL_API_TOKEN", "saved"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := New(WithMode(ModeLive), WithDataDir(t.TempDir())); err != nil {
+		t.Fatal(err)
+	}
+	if v := os.Getenv("DUFFEL_API_TOKEN"); v != "" {
+		t.Errorf("DUFFEL_API_TOKEN = %q, want it left unset", v)
+	}
+}
+
+func TestClientSearchAndCombine(t *testing.T) {
+	c := newMockClient(t)
+	ctx := context.Background()
+
+	flights, err := c.SearchFlights(ctx, FlightSearchRequest{From: "YUL", To: "CDG", DepartDate: "2026-06-12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flights.Flights) == 0 {
+		t.Fatal("no flights")
+	}
+	stays, err := c.SearchStays(ctx, StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stays.Stays) == 0 {
+		t.Fatal("no stays")
+	}
+
+	f, s := flights.Flights[0], stays.Stays[0]
+	combined, err := c.Combine(f.ID, s.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if combined.FlightOfferID != f.ID || combined.StayOfferID != s.ID || combined.TotalPriceUSD <= f.PriceUSD {
+		t.Errorf("combined = %+v", combined)
+	}
+	if _, err := c.Combine("f_missing", s.ID); !errors.Is(err, ErrOfferNotFound) {
+		t.Errorf("missing offer: err = %v, want ErrOfferNotFound", err)
+	}
+}
+
+func TestClientCachesResults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TRAVEL_CONFIG", "")
+	dir := t.TempDir()
+	req := StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"}
+	search := func(opts ...Option) *SearchResult {
+		t.Helper()
+		c, err := New(append([]Option{WithMode(ModeMock), WithDataDir(dir)}, opts...)...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := c.SearchStays(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if search().FromCache {
+		t.Error("first search came from the cache")
+	}
+	if !search().FromCache {
+		t.Error("repeated search did not come from the cache")
+	}
+	if search(WithCacheTTL(0)).FromCache {
+		t.Error("search with zero TTL came from the cache")
+	}
+}
+
+func TestClientSearchValidates(t *testing.T) {
+	c := newMockClient(t)
+	if _, err := c.SearchFlights(context.Background(), FlightSearchRequest{From: "YUL"}); err == nil {
+		t.Error("expected error for missing fields")
+	}
+	if _, err := c.LookupStays(context.Background(), StayLookupRequest{CheckIn: "2026-06-12", CheckOut: "2026-06-15"}); err == nil {
+		t.Error("expected error without propertyId or chain")
+	}
+}
+
+func TestClientSearchCanceled(t *testing.T) {
+	c := newMockClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.SearchStays(ctx, StaySearchRequest{City: "Paris", CheckIn: "2026-06-12", CheckOut: "2026-06-15"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestClientTrips(t *testing.T) {
+	c := newMockClient(t)
+	if _, err := c.CreateTrip("paris"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddSegment("paris", Segment{Kind: SegmentStay, Name: "Grand Hotel Central"}); err == nil {
+		t.Error("expected error for stay without dates")
+	}
+	trip, err := c.AddSegment("paris", Segment{Kind: SegmentRail, From: "Paris", To: "Lyon"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trip.Segments) != 1 {
+		t.Errorf("segments = %+v", trip.Segments)
+	}
+	if _, err := c.Trip("rome"); !errors.Is(err, ErrTripNotFound) {
+		t.Errorf("err = %v, want ErrTripNotFound", err)
+	}
+	list, err := c.Trips()
+	if err != nil || len(list) != 1 {
+		t.Errorf("Trips() = %v, %v", list, err)
+	}
+}
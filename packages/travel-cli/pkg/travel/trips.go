@@ -0,0 +1,23 @@
+package travel
+
+// CreateTrip saves an empty trip. Names use letters, digits, '.', '_'
+// and '-'; creating an existing trip fails.
+func (c *Client) CreateTrip(name string) (*Trip, error) {
+	return c.trips.Create(name)
+}
+
+// AddSegment adds a flight, rail, car, or stay segment to a trip, keeping
+// segments in chronological order.
+func (c *Client) AddSegment(trip string, seg Segment) (*Trip, error) {
+	return c.trips.AddSegment(trip, seg)
+}
+
+// Trip returns a saved trip.
+func (c *Client) Trip(name string) (*Trip, error) {
+	return c.trips.Get(name)
+}
+
+// Trips returns every saved trip.
+func (c *Client) Trips() ([]Trip, error) {
+	return c.trips.List()
+}
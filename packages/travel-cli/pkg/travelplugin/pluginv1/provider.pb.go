@@ -0,0 +1,500 @@
+// The provider service a go-plugin provider plugin serves, so adapters
+// can run as long-lived processes instead of one process per search. Use
+// pkg/travelplugin to write one in Go. Requests and offers travel as the
+// JSON the CLI prints, as in the exec plugin protocol, so both kinds of
+// plugin see the same fields. Regenerate the Go code in
+// pkg/travelplugin/pluginv1 by running `buf generate` in
+// packages/travel-cli after changing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: travel/plugin/v1/provider.proto
+
+package pluginv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoRequest) Reset() {
+	*x = GetInfoRequest{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoRequest) ProtoMessage() {}
+
+func (x *GetInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetInfoRequest) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{0}
+}
+
+type GetInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// easySignup, partnerRequired, or enterpriseOnly; easySignup if unset.
+	Tier string `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	// flights.search, stays.search, stays.lookup, reprice, deepLink.
+	Capabilities []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// Why the provider can't search, such as missing credentials; empty
+	// when it can.
+	Unavailable   string `protobuf:"bytes,4,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInfoResponse) Reset() {
+	*x = GetInfoResponse{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInfoResponse) ProtoMessage() {}
+
+func (x *GetInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetInfoResponse) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetInfoResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *GetInfoResponse) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *GetInfoResponse) GetUnavailable() string {
+	if x != nil {
+		return x.Unavailable
+	}
+	return ""
+}
+
+type SearchFlightsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A FlightSearchRequest as JSON.
+	Request       []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsRequest) Reset() {
+	*x = SearchFlightsRequest{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsRequest) ProtoMessage() {}
+
+func (x *SearchFlightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFlightsRequest) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchFlightsRequest) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type SearchFlightsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A JSON array of FlightOffer.
+	Offers        []byte `protobuf:"bytes,1,opt,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsResponse) Reset() {
+	*x = SearchFlightsResponse{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsResponse) ProtoMessage() {}
+
+func (x *SearchFlightsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsResponse.ProtoReflect.Descriptor instead.
+func (*SearchFlightsResponse) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchFlightsResponse) GetOffers() []byte {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type SearchStaysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A StaySearchRequest as JSON.
+	Request       []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStaysRequest) Reset() {
+	*x = SearchStaysRequest{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaysRequest) ProtoMessage() {}
+
+func (x *SearchStaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaysRequest.ProtoReflect.Descriptor instead.
+func (*SearchStaysRequest) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchStaysRequest) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type SearchStaysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A JSON array of StayOffer.
+	Offers        []byte `protobuf:"bytes,1,opt,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStaysResponse) Reset() {
+	*x = SearchStaysResponse{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaysResponse) ProtoMessage() {}
+
+func (x *SearchStaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaysResponse.ProtoReflect.Descriptor instead.
+func (*SearchStaysResponse) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchStaysResponse) GetOffers() []byte {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type LookupStaysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A StayLookupRequest as JSON.
+	Request       []byte `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupStaysRequest) Reset() {
+	*x = LookupStaysRequest{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupStaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupStaysRequest) ProtoMessage() {}
+
+func (x *LookupStaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupStaysRequest.ProtoReflect.Descriptor instead.
+func (*LookupStaysRequest) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LookupStaysRequest) GetRequest() []byte {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type LookupStaysResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A JSON array of StayOffer.
+	Offers        []byte `protobuf:"bytes,1,opt,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupStaysResponse) Reset() {
+	*x = LookupStaysResponse{}
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupStaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupStaysResponse) ProtoMessage() {}
+
+func (x *LookupStaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_plugin_v1_provider_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupStaysResponse.ProtoReflect.Descriptor instead.
+func (*LookupStaysResponse) Descriptor() ([]byte, []int) {
+	return file_travel_plugin_v1_provider_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LookupStaysResponse) GetOffers() []byte {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+var File_travel_plugin_v1_provider_proto protoreflect.FileDescriptor
+
+const file_travel_plugin_v1_provider_proto_rawDesc = "" +
+	"\n" +
+	"\x1ftravel/plugin/v1/provider.proto\x12\x10travel.plugin.v1\"\x10\n" +
+	"\x0eGetInfoRequest\"\x7f\n" +
+	"\x0fGetInfoResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\tR\x04tier\x12\"\n" +
+	"\fcapabilities\x18\x03 \x03(\tR\fcapabilities\x12 \n" +
+	"\vunavailable\x18\x04 \x01(\tR\vunavailable\"0\n" +
+	"\x14SearchFlightsRequest\x12\x18\n" +
+	"\arequest\x18\x01 \x01(\fR\arequest\"/\n" +
+	"\x15SearchFlightsResponse\x12\x16\n" +
+	"\x06offers\x18\x01 \x01(\fR\x06offers\".\n" +
+	"\x12SearchStaysRequest\x12\x18\n" +
+	"\arequest\x18\x01 \x01(\fR\arequest\"-\n" +
+	"\x13SearchStaysResponse\x12\x16\n" +
+	"\x06offers\x18\x01 \x01(\fR\x06offers\".\n" +
+	"\x12LookupStaysRequest\x12\x18\n" +
+	"\arequest\x18\x01 \x01(\fR\arequest\"-\n" +
+	"\x13LookupStaysResponse\x12\x16\n" +
+	"\x06offers\x18\x01 \x01(\fR\x06offers2\xfb\x02\n" +
+	"\x0fProviderService\x12N\n" +
+	"\aGetInfo\x12 .travel.plugin.v1.GetInfoRequest\x1a!.travel.plugin.v1.GetInfoResponse\x12`\n" +
+	"\rSearchFlights\x12&.travel.plugin.v1.SearchFlightsRequest\x1a'.travel.plugin.v1.SearchFlightsResponse\x12Z\n" +
+	"\vSearchStays\x12$.travel.plugin.v1.SearchStaysRequest\x1a%.travel.plugin.v1.SearchStaysResponse\x12Z\n" +
+	"\vLookupStays\x12$.travel.plugin.v1.LookupStaysRequest\x1a%.travel.plugin.v1.LookupStaysResponseBDZBgithub.com/beetlebot/travel-cli/pkg/travelplugin/pluginv1;pluginv1b\x06proto3"
+
+var (
+	file_travel_plugin_v1_provider_proto_rawDescOnce sync.Once
+	file_travel_plugin_v1_provider_proto_rawDescData []byte
+)
+
+func file_travel_plugin_v1_provider_proto_rawDescGZIP() []byte {
+	file_travel_plugin_v1_provider_proto_rawDescOnce.Do(func() {
+		file_travel_plugin_v1_provider_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_travel_plugin_v1_provider_proto_rawDesc), len(file_travel_plugin_v1_provider_proto_rawDesc)))
+	})
+	return file_travel_plugin_v1_provider_proto_rawDescData
+}
+
+var file_travel_plugin_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_travel_plugin_v1_provider_proto_goTypes = []any{
+	(*GetInfoRequest)(nil),        // 0: travel.plugin.v1.GetInfoRequest
+	(*GetInfoResponse)(nil),       // 1: travel.plugin.v1.GetInfoResponse
+	(*SearchFlightsRequest)(nil),  // 2: travel.plugin.v1.SearchFlightsRequest
+	(*SearchFlightsResponse)(nil), // 3: travel.plugin.v1.SearchFlightsResponse
+	(*SearchStaysRequest)(nil),    // 4: travel.plugin.v1.SearchStaysRequest
+	(*SearchStaysResponse)(nil),   // 5: travel.plugin.v1.SearchStaysResponse
+	(*LookupStaysRequest)(nil),    // 6: travel.plugin.v1.LookupStaysRequest
+	(*LookupStaysResponse)(nil),   // 7: travel.plugin.v1.LookupStaysResponse
+}
+var file_travel_plugin_v1_provider_proto_depIdxs = []int32{
+	0, // 0: travel.plugin.v1.ProviderService.GetInfo:input_type -> travel.plugin.v1.GetInfoRequest
+	2, // 1: travel.plugin.v1.ProviderService.SearchFlights:input_type -> travel.plugin.v1.SearchFlightsRequest
+	4, // 2: travel.plugin.v1.ProviderService.SearchStays:input_type -> travel.plugin.v1.SearchStaysRequest
+	6, // 3: travel.plugin.v1.ProviderService.LookupStays:input_type -> travel.plugin.v1.LookupStaysRequest
+	1, // 4: travel.plugin.v1.ProviderService.GetInfo:output_type -> travel.plugin.v1.GetInfoResponse
+	3, // 5: travel.plugin.v1.ProviderService.SearchFlights:output_type -> travel.plugin.v1.SearchFlightsResponse
+	5, // 6: travel.plugin.v1.ProviderService.SearchStays:output_type -> travel.plugin.v1.SearchStaysResponse
+	7, // 7: travel.plugin.v1.ProviderService.LookupStays:output_type -> travel.plugin.v1.LookupStaysResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_travel_plugin_v1_provider_proto_init() }
+func file_travel_plugin_v1_provider_proto_init() {
+	if File_travel_plugin_v1_provider_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_travel_plugin_v1_provider_proto_rawDesc), len(file_travel_plugin_v1_provider_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_travel_plugin_v1_provider_proto_goTypes,
+		DependencyIndexes: file_travel_plugin_v1_provider_proto_depIdxs,
+		MessageInfos:      file_travel_plugin_v1_provider_proto_msgTypes,
+	}.Build()
+	File_travel_plugin_v1_provider_proto = out.File
+	file_travel_plugin_v1_provider_proto_goTypes = nil
+	file_travel_plugin_v1_provider_proto_depIdxs = nil
+}
@@ -0,0 +1,251 @@
+// The provider service a go-plugin provider plugin serves, so adapters
+// can run as long-lived processes instead of one process per search. Use
+// pkg/travelplugin to write one in Go. Requests and offers travel as the
+// JSON the CLI prints, as in the exec plugin protocol, so both kinds of
+// plugin see the same fields. Regenerate the Go code in
+// pkg/travelplugin/pluginv1 by running `buf generate` in
+// packages/travel-cli after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: travel/plugin/v1/provider.proto
+
+package pluginv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProviderService_GetInfo_FullMethodName       = "/travel.plugin.v1.ProviderService/GetInfo"
+	ProviderService_SearchFlights_FullMethodName = "/travel.plugin.v1.ProviderService/SearchFlights"
+	ProviderService_SearchStays_FullMethodName   = "/travel.plugin.v1.ProviderService/SearchStays"
+	ProviderService_LookupStays_FullMethodName   = "/travel.plugin.v1.ProviderService/LookupStays"
+)
+
+// ProviderServiceClient is the client API for ProviderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProviderServiceClient interface {
+	// GetInfo describes the provider: its name and the searches it serves.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// SearchFlights answers a flights.search.
+	SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error)
+	// SearchStays answers a stays.search.
+	SearchStays(ctx context.Context, in *SearchStaysRequest, opts ...grpc.CallOption) (*SearchStaysResponse, error)
+	// LookupStays answers a stays.lookup.
+	LookupStays(ctx context.Context, in *LookupStaysRequest, opts ...grpc.CallOption) (*LookupStaysResponse, error)
+}
+
+type providerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProviderServiceClient(cc grpc.ClientConnInterface) ProviderServiceClient {
+	return &providerServiceClient{cc}
+}
+
+func (c *providerServiceClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInfoResponse)
+	err := c.cc.Invoke(ctx, ProviderService_GetInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchFlightsResponse)
+	err := c.cc.Invoke(ctx, ProviderService_SearchFlights_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) SearchStays(ctx context.Context, in *SearchStaysRequest, opts ...grpc.CallOption) (*SearchStaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchStaysResponse)
+	err := c.cc.Invoke(ctx, ProviderService_SearchStays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *providerServiceClient) LookupStays(ctx context.Context, in *LookupStaysRequest, opts ...grpc.CallOption) (*LookupStaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LookupStaysResponse)
+	err := c.cc.Invoke(ctx, ProviderService_LookupStays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProviderServiceServer is the server API for ProviderService service.
+// All implementations must embed UnimplementedProviderServiceServer
+// for forward compatibility.
+type ProviderServiceServer interface {
+	// GetInfo describes the provider: its name and the searches it serves.
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// SearchFlights answers a flights.search.
+	SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error)
+	// SearchStays answers a stays.search.
+	SearchStays(context.Context, *SearchStaysRequest) (*SearchStaysResponse, error)
+	// LookupStays answers a stays.lookup.
+	LookupStays(context.Context, *LookupStaysRequest) (*LookupStaysResponse, error)
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+// UnimplementedProviderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProviderServiceServer struct{}
+
+func (UnimplementedProviderServiceServer) GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedProviderServiceServer) SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchFlights not implemented")
+}
+func (UnimplementedProviderServiceServer) SearchStays(context.Context, *SearchStaysRequest) (*SearchStaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchStays not implemented")
+}
+func (UnimplementedProviderServiceServer) LookupStays(context.Context, *LookupStaysRequest) (*LookupStaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LookupStays not implemented")
+}
+func (UnimplementedProviderServiceServer) mustEmbedUnimplementedProviderServiceServer() {}
+func (UnimplementedProviderServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeProviderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProviderServiceServer will
+// result in compilation errors.
+type UnsafeProviderServiceServer interface {
+	mustEmbedUnimplementedProviderServiceServer()
+}
+
+func RegisterProviderServiceServer(s grpc.ServiceRegistrar, srv ProviderServiceServer) {
+	// If the following call panics, it indicates UnimplementedProviderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProviderService_ServiceDesc, srv)
+}
+
+func _ProviderService_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_GetInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_SearchFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).SearchFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_SearchFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).SearchFlights(ctx, req.(*SearchFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_SearchStays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).SearchStays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_SearchStays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).SearchStays(ctx, req.(*SearchStaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProviderService_LookupStays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupStaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProviderServiceServer).LookupStays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProviderService_LookupStays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProviderServiceServer).LookupStays(ctx, req.(*LookupStaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProviderService_ServiceDesc is the grpc.ServiceDesc for ProviderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProviderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "travel.plugin.v1.ProviderService",
+	HandlerType: (*ProviderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _ProviderService_GetInfo_Handler,
+		},
+		{
+			MethodName: "SearchFlights",
+			Handler:    _ProviderService_SearchFlights_Handler,
+		},
+		{
+			MethodName: "SearchStays",
+			Handler:    _ProviderService_SearchStays_Handler,
+		},
+		{
+			MethodName: "LookupStays",
+			Handler:    _ProviderService_LookupStays_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "travel/plugin/v1/provider.proto",
+}
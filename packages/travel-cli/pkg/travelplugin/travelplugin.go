@@ -0,0 +1,258 @@
+// Package travelplugin serves a provider adapter as a go-plugin
+// (github.com/hashicorp/go-plugin) plugin. The CLI starts the plugin once
+// and keeps it running, talking to it over gRPC, which suits plugins that
+// keep connections or tokens warm between searches, as travel serve and
+// travel daemon run many. A provider is a main package:
+//
+//	func main() { travelplugin.Serve(&acme{}) }
+//
+// built into ~/.config/beetlebot/travel/plugins/grpc/. The protocol is
+// defined in proto/travel/plugin/v1/provider.proto.
+package travelplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/beetlebot/travel-cli/pkg/travelplugin/pluginv1"
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+	"github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProtocolVersion is the version of the provider service this package
+// speaks. The CLI and a plugin agree on a version when the plugin starts,
+// and the CLI refuses plugins built for a version it doesn't know.
+const ProtocolVersion = 1
+
+// Handshake is what the CLI and a plugin check before talking, so a
+// plugin run by hand, or by another program, says so and exits.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  ProtocolVersion,
+	MagicCookieKey:   "TRAVEL_PLUGIN",
+	MagicCookieValue: "provider",
+}
+
+// PluginName is the name the provider is dispensed under.
+const PluginName = "provider"
+
+// The request and offer types are the adapter SDK's.
+type (
+	FlightSearchRequest = travelsdk.FlightSearchRequest
+	StaySearchRequest   = travelsdk.StaySearchRequest
+	StayLookupRequest   = travelsdk.StayLookupRequest
+	FlightOffer         = travelsdk.FlightOffer
+	StayOffer           = travelsdk.StayOffer
+)
+
+// Info describes a provider. Capabilities must include flights.search or
+// stays.search; stays.lookup adds property lookups.
+type Info struct {
+	Name         string
+	Tier         string
+	Capabilities []string
+	// Unavailable is why the provider can't search, such as missing
+	// credentials; empty when it can.
+	Unavailable string
+}
+
+// Provider is a provider adapter. The CLI only calls the searches Info
+// lists; embed Unsupported for the rest.
+type Provider interface {
+	Info(ctx context.Context) (Info, error)
+	SearchFlights(ctx context.Context, req FlightSearchRequest) ([]FlightOffer, error)
+	SearchStays(ctx context.Context, req StaySearchRequest) ([]StayOffer, error)
+	LookupStays(ctx context.Context, req StayLookupRequest) ([]StayOffer, error)
+}
+
+// ErrUnsupported is returned for a search the provider doesn't serve.
+var ErrUnsupported = errors.New("search not supported by this provider")
+
+// Unsupported answers every search with ErrUnsupported.
+type Unsupported struct{}
+
+func (Unsupported) SearchFlights(context.Context, FlightSearchRequest) ([]FlightOffer, error) {
+	return nil, ErrUnsupported
+}
+
+func (Unsupported) SearchStays(context.Context, StaySearchRequest) ([]StayOffer, error) {
+	return nil, ErrUnsupported
+}
+
+func (Unsupported) LookupStays(context.Context, StayLookupRequest) ([]StayOffer, error) {
+	return nil, ErrUnsupported
+}
+
+// Serve serves p until the CLI stops the plugin. It is the whole of a
+// plugin's main.
+func Serve(p Provider) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig:  Handshake,
+		VersionedPlugins: Plugins(p),
+		GRPCServer:       plugin.DefaultGRPCServer,
+	})
+}
+
+// Plugins is the plugin set for each protocol version, serving p, or,
+// with p nil, the set the CLI dispenses clients from.
+func Plugins(p Provider) map[int]plugin.PluginSet {
+	return map[int]plugin.PluginSet{
+		ProtocolVersion: {PluginName: &GRPCPlugin{Impl: p}},
+	}
+}
+
+// GRPCPlugin is the go-plugin plugin for a Provider.
+type GRPCPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+	Impl Provider
+}
+
+func (p *GRPCPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	pluginv1.RegisterProviderServiceServer(s, &server{impl: p.Impl})
+	return nil
+}
+
+// GRPCClient returns a Provider that calls the plugin.
+func (p *GRPCPlugin) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return &client{rpc: pluginv1.NewProviderServiceClient(c)}, nil
+}
+
+type server struct {
+	pluginv1.UnimplementedProviderServiceServer
+	impl Provider
+}
+
+func (s *server) GetInfo(ctx context.Context, _ *pluginv1.GetInfoRequest) (*pluginv1.GetInfoResponse, error) {
+	info, err := s.impl.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.GetInfoResponse{Name: info.Name, Tier: info.Tier, Capabilities: info.Capabilities, Unavailable: info.Unavailable}, nil
+}
+
+func (s *server) SearchFlights(ctx context.Context, in *pluginv1.SearchFlightsRequest) (*pluginv1.SearchFlightsResponse, error) {
+	var req FlightSearchRequest
+	offers, err := serveSearch(in.GetRequest(), &req, func() (interface{}, error) { return s.impl.SearchFlights(ctx, req) })
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.SearchFlightsResponse{Offers: offers}, nil
+}
+
+func (s *server) SearchStays(ctx context.Context, in *pluginv1.SearchStaysRequest) (*pluginv1.SearchStaysResponse, error) {
+	var req StaySearchRequest
+	offers, err := serveSearch(in.GetRequest(), &req, func() (interface{}, error) { return s.impl.SearchStays(ctx, req) })
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.SearchStaysResponse{Offers: offers}, nil
+}
+
+func (s *server) LookupStays(ctx context.Context, in *pluginv1.LookupStaysRequest) (*pluginv1.LookupStaysResponse, error) {
+	var req StayLookupRequest
+	offers, err := serveSearch(in.GetRequest(), &req, func() (interface{}, error) { return s.impl.LookupStays(ctx, req) })
+	if err != nil {
+		return nil, err
+	}
+	return &pluginv1.LookupStaysResponse{Offers: offers}, nil
+}
+
+// serveSearch decodes raw into req, runs search, and encodes the offers
+// it returns.
+func serveSearch(raw []byte, req interface{}, search func() (interface{}, error)) ([]byte, error) {
+	if err := json.Unmarshal(raw, req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	offers, err := search()
+	switch {
+	case errors.Is(err, ErrUnsupported):
+		return nil, status.Error(codes.Unimplemented, err.Error())
+	case err != nil:
+		return nil, err
+	}
+	return json.Marshal(offers)
+}
+
+type client struct {
+	rpc pluginv1.ProviderServiceClient
+}
+
+func (c *client) Info(ctx context.Context) (Info, error) {
+	resp, err := c.rpc.GetInfo(ctx, &pluginv1.GetInfoRequest{})
+	if err != nil {
+		return Info{}, callError(err)
+	}
+	return Info{Name: resp.GetName(), Tier: resp.GetTier(), Capabilities: resp.GetCapabilities(), Unavailable: resp.GetUnavailable()}, nil
+}
+
+func (c *client) SearchFlights(ctx context.Context, req FlightSearchRequest) ([]FlightOffer, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.SearchFlights(ctx, &pluginv1.SearchFlightsRequest{Request: raw})
+	if err != nil {
+		return nil, callError(err)
+	}
+	var offers []FlightOffer
+	if err := decodeOffers(resp.GetOffers(), &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+func (c *client) SearchStays(ctx context.Context, req StaySearchRequest) ([]StayOffer, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.SearchStays(ctx, &pluginv1.SearchStaysRequest{Request: raw})
+	if err != nil {
+		return nil, callError(err)
+	}
+	var offers []StayOffer
+	if err := decodeOffers(resp.GetOffers(), &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+func (c *client) LookupStays(ctx context.Context, req StayLookupRequest) ([]StayOffer, error) {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.rpc.LookupStays(ctx, &pluginv1.LookupStaysRequest{Request: raw})
+	if err != nil {
+		return nil, callError(err)
+	}
+	var offers []StayOffer
+	if err := decodeOffers(resp.GetOffers(), &offers); err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+func decodeOffers(raw []byte, offers interface{}) error {
+	if err := json.Unmarshal(raw, offers); err != nil {
+		return fmt.Errorf("invalid offers: %w", err)
+	}
+	return nil
+}
+
+// callError turns a failed call back into the provider's error: its
+// message alone, or ErrUnsupported.
+func callError(err error) error {
+	st, ok := status.FromError(err)
+	switch {
+	case !ok:
+		return err
+	case st.Code() == codes.Unimplemented:
+		return ErrUnsupported
+	}
+	return errors.New(st.Message())
+}
@@ -0,0 +1,197 @@
+// Package adaptertest checks provider adapters against the contract the
+// router and orchestrator rely on: each canonical search succeeds and
+// every offer it returns carries its provider's name, a path-safe ID,
+// sane prices, and dates that match the request. An adapter proves
+// conformance with one line in its tests:
+//
+//	func TestContract(t *testing.T) { adaptertest.Flights(t, NewDuffelFlightsAdapter()) }
+//
+// Adapters that report themselves unavailable, such as live adapters
+// without credentials, are skipped. The checks follow the broker's
+// version: a newer one may check fields an older one didn't.
+package adaptertest
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+)
+
+// offerID matches the IDs the offer store can keep, so commands can
+// refer to any offer a search returned.
+var offerID = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+
+const dateLayout = "2006-01-02"
+
+// FlightRequests are the canonical flight searches, departing a month or
+// more after now so they stay bookable: one-way economy, and a round trip
+// in business for two.
+func FlightRequests(now time.Time) []travelsdk.FlightSearchRequest {
+	day := func(days int) string { return now.AddDate(0, 0, days).Format(dateLayout) }
+	return []travelsdk.FlightSearchRequest{
+		{From: "YUL", To: "CDG", DepartDate: day(30)},
+		{From: "JFK", To: "LAX", DepartDate: day(45), ReturnDate: day(52), Adults: 2, CabinClass: "business"},
+	}
+}
+
+// StayRequests are the canonical stay searches: a capped hotel search,
+// and a family stay with children in two rooms.
+func StayRequests(now time.Time) []travelsdk.StaySearchRequest {
+	day := func(days int) string { return now.AddDate(0, 0, days).Format(dateLayout) }
+	return []travelsdk.StaySearchRequest{
+		{City: "Paris", CheckIn: day(30), CheckOut: day(34), StayType: "hotel", MaxPriceUSD: 300},
+		{City: "Banff", CheckIn: day(60), CheckOut: day(63), Guests: 2, ChildrenAges: []int{4, 9}, Rooms: 2},
+	}
+}
+
+// Flights runs a against FlightRequests and fails t for every broken
+// invariant.
+func Flights(t *testing.T, a travelsdk.FlightAdapter) {
+	t.Helper()
+	if ok, reason := a.Available(); !ok {
+		t.Skipf("%s unavailable: %s", a.Name(), reason)
+	}
+	if !slices.Contains(a.Capabilities(), travelsdk.CapFlightsSearch) {
+		t.Errorf("%s does not list %s", a.Name(), travelsdk.CapFlightsSearch)
+	}
+	for _, req := range FlightRequests(time.Now()) {
+		req.ApplyDefaults()
+		t.Run(req.From+"-"+req.To, func(t *testing.T) {
+			for _, p := range checkFlights(a, req) {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+// Stays runs a against StayRequests and fails t for every broken
+// invariant.
+func Stays(t *testing.T, a travelsdk.StayAdapter) {
+	t.Helper()
+	if ok, reason := a.Available(); !ok {
+		t.Skipf("%s unavailable: %s", a.Name(), reason)
+	}
+	if !slices.Contains(a.Capabilities(), travelsdk.CapStaysSearch) {
+		t.Errorf("%s does not list %s", a.Name(), travelsdk.CapStaysSearch)
+	}
+	for _, req := range StayRequests(time.Now()) {
+		req.ApplyDefaults()
+		t.Run(req.City, func(t *testing.T) {
+			for _, p := range checkStays(a, req) {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+func checkFlights(a travelsdk.FlightAdapter, req travelsdk.FlightSearchRequest) []string {
+	offers, err := a.SearchFlights(req)
+	if err != nil {
+		return []string{fmt.Sprintf("search failed: %v", err)}
+	}
+	var problems []string
+	seen := map[string]bool{}
+	for i, f := range offers {
+		report := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf("offer %d (%s): ", i, f.ID)+fmt.Sprintf(format, args...))
+		}
+		checkOffer(report, a.Name(), f.ID, f.Source, f.DeepLink, seen)
+		if !validPrice(f.PriceUSD) {
+			report("priceUSD %v is not a non-negative amount", f.PriceUSD)
+		}
+		if !strings.EqualFold(f.From, req.From) || !strings.EqualFold(f.To, req.To) {
+			report("route %s-%s, want %s-%s", f.From, f.To, req.From, req.To)
+		}
+		switch {
+		case f.DepartTime.IsZero() || f.ArriveTime.IsZero():
+			report("missing departTime or arriveTime")
+		case !f.ArriveTime.After(f.DepartTime):
+			report("arrives %s, not after it departs %s", f.ArriveTime, f.DepartTime)
+		case f.DepartTime.Format(dateLayout) != req.DepartDate:
+			report("departs %s, want %s", f.DepartTime.Format(dateLayout), req.DepartDate)
+		}
+		if f.DurationMinutes < 0 || f.Stops < 0 {
+			report("negative durationMinutes %d or stops %d", f.DurationMinutes, f.Stops)
+		}
+		if f.Airline == "" {
+			report("missing airline")
+		}
+	}
+	return problems
+}
+
+func checkStays(a travelsdk.StayAdapter, req travelsdk.StaySearchRequest) []string {
+	offers, err := a.SearchStays(req)
+	if err != nil {
+		return []string{fmt.Sprintf("search failed: %v", err)}
+	}
+	checkIn, _ := time.Parse(dateLayout, req.CheckIn)
+	checkOut, _ := time.Parse(dateLayout, req.CheckOut)
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+
+	var problems []string
+	seen := map[string]bool{}
+	for i, s := range offers {
+		report := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf("offer %d (%s): ", i, s.ID)+fmt.Sprintf(format, args...))
+		}
+		checkOffer(report, a.Name(), s.ID, s.Source, s.DeepLink, seen)
+		if s.Name == "" {
+			report("missing name")
+		}
+		if !validPrice(s.PricePerNight) || !validPrice(s.TotalPriceUSD) || !validPrice(s.ChildrenPriceUSD) {
+			report("prices %v per night, %v total, %v for children are not all non-negative amounts", s.PricePerNight, s.TotalPriceUSD, s.ChildrenPriceUSD)
+		}
+		if s.ChildrenPriceUSD > s.TotalPriceUSD {
+			report("childrenPriceUSD %v is more than totalPriceUSD %v", s.ChildrenPriceUSD, s.TotalPriceUSD)
+		}
+		if s.CheckIn != req.CheckIn || s.CheckOut != req.CheckOut {
+			report("dates %s to %s, want %s to %s", s.CheckIn, s.CheckOut, req.CheckIn, req.CheckOut)
+		}
+		if s.NightsCount != nights {
+			report("nightsCount %d, want %d", s.NightsCount, nights)
+		} else if total := s.PricePerNight * float64(nights); math.Abs(total-s.TotalPriceUSD) > math.Max(1, total*0.01) {
+			report("totalPriceUSD %v is not pricePerNight %v for %d nights", s.TotalPriceUSD, s.PricePerNight, nights)
+		}
+		if req.StayType != "" && req.StayType != "any" && s.Type != req.StayType {
+			report("type %q, want %q", s.Type, req.StayType)
+		}
+		if req.MaxPriceUSD > 0 && s.PricePerNight > float64(req.MaxPriceUSD) {
+			report("pricePerNight %v is over the maximum %d", s.PricePerNight, req.MaxPriceUSD)
+		}
+		if math.Abs(s.Latitude) > 90 || math.Abs(s.Longitude) > 180 {
+			report("coordinates %v,%v out of range", s.Latitude, s.Longitude)
+		}
+	}
+	return problems
+}
+
+// checkOffer checks what every offer needs: a unique, path-safe ID, the
+// adapter's name as its source, and an absolute deep link if any.
+func checkOffer(report func(string, ...interface{}), name, id, source, deepLink string, seen map[string]bool) {
+	if !offerID.MatchString(id) {
+		report("id %q is not 1-128 letters, digits, '.', '_' or '-'", id)
+	} else if seen[id] {
+		report("id %q returned twice", id)
+	}
+	seen[id] = true
+	if source != name {
+		report("source %q, want the adapter's name %q", source, name)
+	}
+	if deepLink != "" {
+		if u, err := url.Parse(deepLink); err != nil || !u.IsAbs() {
+			report("deepLink %q is not an absolute URL", deepLink)
+		}
+	}
+}
+
+func validPrice(p float64) bool {
+	return p >= 0 && !math.IsNaN(p) && !math.IsInf(p, 0)
+}
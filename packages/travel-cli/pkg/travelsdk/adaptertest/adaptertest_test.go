@@ -0,0 +1,78 @@
+package adaptertest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beetlebot/travel-cli/pkg/travelsdk"
+)
+
+// brokenAdapter returns one offer that breaks most invariants.
+type brokenAdapter struct{}
+
+func (brokenAdapter) Name() string                 { return "broken" }
+func (brokenAdapter) Tier() travelsdk.ProviderTier { return travelsdk.TierEasySignup }
+func (brokenAdapter) Capabilities() []travelsdk.Capability {
+	return []travelsdk.Capability{travelsdk.CapFlightsSearch}
+}
+func (brokenAdapter) Available() (bool, string) { return true, "" }
+
+func (brokenAdapter) SearchFlights(req travelsdk.FlightSearchRequest) ([]travelsdk.FlightOffer, error) {
+	depart := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	return []travelsdk.FlightOffer{{
+		ID:         "../f 1",
+		Source:     "other",
+		Airline:    "AC",
+		From:       req.From,
+		To:         req.To,
+		DepartTime: depart,
+		ArriveTime: depart.Add(-time.Hour),
+		PriceUSD:   -10,
+		DeepLink:   "/book",
+	}}, nil
+}
+
+func TestCheckFlightsReportsViolations(t *testing.T) {
+	req := FlightRequests(time.Now())[0]
+	problems := strings.Join(checkFlights(brokenAdapter{}, req), "\n")
+	for _, want := range []string{"id", "source", "priceUSD", "not after it departs", "deepLink"} {
+		if !strings.Contains(problems, want) {
+			t.Errorf("no %q problem in:\n%s", want, problems)
+		}
+	}
+}
+
+// sdkAdapter is written against travelsdk alone, as an external adapter
+// would be, and keeps the contract.
+type sdkAdapter struct{}
+
+func (sdkAdapter) Name() string                 { return "sdk" }
+func (sdkAdapter) Tier() travelsdk.ProviderTier { return travelsdk.TierEasySignup }
+func (sdkAdapter) Capabilities() []travelsdk.Capability {
+	return []travelsdk.Capability{travelsdk.CapFlightsSearch}
+}
+func (sdkAdapter) Available() (bool, string) { return true, "" }
+
+func (sdkAdapter) SearchFlights(req travelsdk.FlightSearchRequest) ([]travelsdk.FlightOffer, error) {
+	depart, err := time.Parse(dateLayout, req.DepartDate)
+	if err != nil {
+		return nil, err
+	}
+	offer := travelsdk.FlightOffer{
+		Source:     "sdk",
+		Airline:    "Acme Air",
+		From:       req.From,
+		To:         req.To,
+		DepartTime: depart.Add(8 * time.Hour),
+		ArriveTime: depart.Add(15 * time.Hour),
+		CabinClass: req.CabinClass,
+		PriceUSD:   420,
+	}
+	offer.ID = travelsdk.StableFlightID(offer)
+	return []travelsdk.FlightOffer{offer}, nil
+}
+
+func TestFlights_SDKAdapter(t *testing.T) {
+	Flights(t, sdkAdapter{})
+}
@@ -0,0 +1,156 @@
+// Package travelsdk is what a provider adapter needs from the travel
+// broker: the search requests and offers, the adapter interfaces, and the
+// errors and helpers adapters share, without importing internal packages.
+// Its requests and offers are the ones pkg/travelplugin plugins exchange.
+// Adapters check themselves with pkg/travelsdk/adaptertest:
+//
+//	var _ travelsdk.FlightAdapter = (*AcmeFlightsAdapter)(nil)
+//
+//	func TestContract(t *testing.T) { adaptertest.Flights(t, NewAcmeFlightsAdapter()) }
+//
+// The types are the SDK's own, holding what adapters fill in; the broker
+// converts them to its internal ones where it calls an adapter, so its
+// internal changes don't reach them. Fields are added, not renamed or
+// removed, within a major version.
+package travelsdk
+
+import (
+	"context"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// FlightAdapter searches flights for the router.
+type FlightAdapter interface {
+	Name() string
+	Tier() ProviderTier
+	Capabilities() []Capability
+	Available() (bool, string)
+	SearchFlights(req FlightSearchRequest) ([]FlightOffer, error)
+}
+
+// StayAdapter searches stays for the router.
+type StayAdapter interface {
+	Name() string
+	Tier() ProviderTier
+	Capabilities() []Capability
+	Available() (bool, string)
+	SearchStays(req StaySearchRequest) ([]StayOffer, error)
+}
+
+// PropertyLookupAdapter is implemented by stay adapters that can price a
+// property or chain without a broad city search.
+type PropertyLookupAdapter interface {
+	LookupStays(req StayLookupRequest) ([]StayOffer, error)
+}
+
+// FlightStatusAdapter is implemented by flight adapters that can tell
+// where a flight is on the day.
+type FlightStatusAdapter interface {
+	FlightStatus(req FlightStatusRequest) (*FlightStatus, error)
+}
+
+// StatusAdapter tells flight status for the router, without searching
+// flights.
+type StatusAdapter interface {
+	Name() string
+	Tier() ProviderTier
+	Capabilities() []Capability
+	Available() (bool, string)
+	FlightStatusAdapter
+}
+
+// CredentialValidator is implemented by adapters that can check keys
+// before travel auth saves them. creds maps environment variable names to
+// values.
+type CredentialValidator interface {
+	ValidateCredentials(ctx context.Context, creds map[string]string) error
+}
+
+// Warmer is implemented by adapters that can prepare for a search ahead
+// of time.
+type Warmer interface {
+	Warmup(ctx context.Context) error
+}
+
+const (
+	CapFlightsSearch = Capability(core.CapFlightsSearch)
+	CapFlightsStatus = Capability(core.CapFlightsStatus)
+	CapStaysSearch   = Capability(core.CapStaysSearch)
+	CapStaysLookup   = Capability(core.CapStaysLookup)
+	CapReprice       = Capability(core.CapReprice)
+	CapDeepLink      = Capability(core.CapDeepLink)
+
+	TierEasySignup      = ProviderTier(core.TierEasySignup)
+	TierPartnerRequired = ProviderTier(core.TierPartnerRequired)
+	TierEnterpriseOnly  = ProviderTier(core.TierEnterpriseOnly)
+
+	FlightScheduled = core.FlightScheduled
+	FlightActive    = core.FlightActive
+	FlightLanded    = core.FlightLanded
+	FlightCancelled = core.FlightCancelled
+	FlightDiverted  = core.FlightDiverted
+	FlightIncident  = core.FlightIncident
+
+	BoardRoomOnly     = core.BoardRoomOnly
+	BoardBreakfast    = core.BoardBreakfast
+	BoardHalfBoard    = core.BoardHalfBoard
+	BoardFullBoard    = core.BoardFullBoard
+	BoardAllInclusive = core.BoardAllInclusive
+
+	ChildBandInfant  = core.ChildBandInfant
+	ChildBandUnder6  = core.ChildBandUnder6
+	ChildBandUnder12 = core.ChildBandUnder12
+	ChildBandTeen    = core.ChildBandTeen
+	// MaxChildAge is the oldest age priced as a child.
+	MaxChildAge = core.MaxChildAge
+)
+
+// ErrInvalidCredentials is returned by CredentialValidator when the
+// provider rejects the keys.
+var ErrInvalidCredentials = core.ErrInvalidCredentials
+
+// ErrFlightNotFound is returned by FlightStatusAdapter when the provider
+// doesn't know the flight on the date.
+var ErrFlightNotFound = core.ErrFlightNotFound
+
+// StableFlightID derives an offer ID from the fields that identify a
+// flight, so the same flight gets the same ID on every search. Set
+// Source first.
+func StableFlightID(o FlightOffer) string {
+	return core.StableFlightID(core.FlightOffer{
+		Source:       o.Source,
+		Airline:      o.Airline,
+		FlightNumber: o.FlightNumber,
+		From:         o.From,
+		To:           o.To,
+		DepartTime:   o.DepartTime,
+		CabinClass:   o.CabinClass,
+	})
+}
+
+// StableStayID is the stay counterpart of StableFlightID.
+func StableStayID(o StayOffer) string {
+	return core.StableStayID(core.StayOffer{
+		Source:     o.Source,
+		Name:       o.Name,
+		PropertyID: o.PropertyID,
+		Type:       o.Type,
+		City:       o.City,
+		Address:    o.Address,
+		CheckIn:    o.CheckIn,
+		CheckOut:   o.CheckOut,
+	})
+}
+
+// NormalizeBoard maps a provider's board code or name, such as BB or
+// "Half Board", to a board type, or "" for one it doesn't know.
+func NormalizeBoard(code string) string { return core.NormalizeBoard(code) }
+
+// ChildAgeBand returns the pricing band for a child of the given age.
+func ChildAgeBand(age int) string { return core.ChildAgeBand(age) }
+
+// IsMaintenanceStatus reports whether an HTTP status code means the
+// provider is down for maintenance, to be returned as a
+// MaintenanceError.
+func IsMaintenanceStatus(code int) bool { return core.IsMaintenanceStatus(code) }
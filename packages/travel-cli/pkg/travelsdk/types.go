@@ -0,0 +1,250 @@
+package travelsdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/beetlebot/travel-cli/internal/core"
+)
+
+// Capability is something an adapter can do for the router.
+type Capability string
+
+// ProviderTier is how hard a provider's API is to get access to.
+type ProviderTier string
+
+type FlightSearchRequest struct {
+	From       string `json:"from"`
+	To         string `json:"to"`
+	DepartDate string `json:"departDate"`
+	ReturnDate string `json:"returnDate,omitempty"`
+	Adults     int    `json:"adults,omitempty"`
+	CabinClass string `json:"cabinClass,omitempty"`
+	MaxResults int    `json:"maxResults,omitempty"`
+	// Alliance, Wifi, MaxStops and MaxPriceUSD are filters the router
+	// applies to the offers it gets back; adapters may pass them on to
+	// the provider to fetch fewer. MaxStops is nil for any number.
+	Alliance    string `json:"alliance,omitempty"`
+	Wifi        bool   `json:"wifi,omitempty"`
+	MaxStops    *int   `json:"maxStops,omitempty"`
+	MaxPriceUSD int    `json:"maxPriceUSD,omitempty"`
+}
+
+// ApplyDefaults fills in what the router would: one adult in economy and
+// ten results.
+func (r *FlightSearchRequest) ApplyDefaults() {
+	if r.Adults == 0 {
+		r.Adults = 1
+	}
+	if r.CabinClass == "" {
+		r.CabinClass = "economy"
+	}
+	if r.MaxResults == 0 {
+		r.MaxResults = 10
+	}
+}
+
+type StaySearchRequest struct {
+	City     string `json:"city"`
+	CheckIn  string `json:"checkIn"`
+	CheckOut string `json:"checkOut"`
+	// Guests counts adults; children are listed by age in ChildrenAges
+	// so providers can price them by age band.
+	Guests       int    `json:"guests,omitempty"`
+	ChildrenAges []int  `json:"childrenAges,omitempty"`
+	Rooms        int    `json:"rooms,omitempty"`
+	MaxResults   int    `json:"maxResults,omitempty"`
+	StayType     string `json:"stayType,omitempty"`
+	MaxPriceUSD  int    `json:"maxPriceUSD,omitempty"`
+	// Near, RadiusKm, Neighborhood, MinRating, MinReviews and Breakfast
+	// are filters the router applies to the offers it gets back, like
+	// the flight ones.
+	Near         string  `json:"near,omitempty"`
+	RadiusKm     float64 `json:"radiusKm,omitempty"`
+	Neighborhood string  `json:"neighborhood,omitempty"`
+	MinRating    float64 `json:"minRating,omitempty"`
+	MinReviews   int     `json:"minReviews,omitempty"`
+	Breakfast    bool    `json:"breakfast,omitempty"`
+}
+
+// ApplyDefaults fills in what the router would: two guests in one room,
+// ten results of any type, and a search radius when Near is set.
+func (r *StaySearchRequest) ApplyDefaults() {
+	if r.Guests == 0 {
+		r.Guests = 2
+	}
+	if r.Rooms == 0 {
+		r.Rooms = 1
+	}
+	if r.MaxResults == 0 {
+		r.MaxResults = 10
+	}
+	if r.StayType == "" {
+		r.StayType = "any"
+	}
+	if r.Near != "" && r.RadiusKm == 0 {
+		r.RadiusKm = core.DefaultNearRadiusKm
+	}
+}
+
+// StayLookupRequest prices a known property directly, either by provider
+// property ID or by hotel chain within a city.
+type StayLookupRequest struct {
+	PropertyID string `json:"propertyId,omitempty"`
+	Chain      string `json:"chain,omitempty"`
+	City       string `json:"city,omitempty"`
+	CheckIn    string `json:"checkIn"`
+	CheckOut   string `json:"checkOut"`
+	Guests     int    `json:"guests,omitempty"`
+	Rooms      int    `json:"rooms,omitempty"`
+	MaxResults int    `json:"maxResults,omitempty"`
+}
+
+// ApplyDefaults fills in what the router would: two guests in one room.
+func (r *StayLookupRequest) ApplyDefaults() {
+	if r.Guests == 0 {
+		r.Guests = 2
+	}
+	if r.Rooms == 0 {
+		r.Rooms = 1
+	}
+}
+
+type FlightStatusRequest struct {
+	Flight string `json:"flight"`
+	Date   string `json:"date"`
+}
+
+// FlightOffer is a flight as an adapter returns it. The router adds what
+// it knows itself, such as the marketing carrier and the local times.
+type FlightOffer struct {
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Airline         string    `json:"airline"`
+	FlightNumber    string    `json:"flightNumber"`
+	From            string    `json:"from"`
+	To              string    `json:"to"`
+	DepartTime      time.Time `json:"departTime"`
+	ArriveTime      time.Time `json:"arriveTime"`
+	DurationMinutes int       `json:"durationMinutes"`
+	Stops           int       `json:"stops"`
+	CabinClass      string    `json:"cabinClass"`
+	// PriceUSD is in Currency when the provider quotes another; the
+	// router converts it.
+	PriceUSD        float64   `json:"priceUSD"`
+	Currency        string    `json:"currency"`
+	DeepLink        string    `json:"deepLink,omitempty"`
+	Confidence      float64   `json:"confidence"`
+	IsBookable      bool      `json:"isBookable"`
+	RepriceRequired bool      `json:"repriceRequired"`
+	FetchedAt       time.Time `json:"fetchedAt"`
+	// OperatedBy is the airline flying the flight when it isn't the one
+	// selling it.
+	OperatedBy *Carrier `json:"operatedBy,omitempty"`
+	// ExpiresAt is when the provider stops honouring the offer, and
+	// PriceGuaranteedUntil when its price may start to change. Zero
+	// when the provider does not say.
+	ExpiresAt            time.Time `json:"expiresAt,omitzero"`
+	PriceGuaranteedUntil time.Time `json:"priceGuaranteedUntil,omitzero"`
+	// Aircraft is the type flown, by code or name. WifiAvailable is nil
+	// when the provider doesn't say. SeatPitch is in inches.
+	Aircraft      *Aircraft `json:"aircraft,omitempty"`
+	WifiAvailable *bool     `json:"wifiAvailable,omitempty"`
+	SeatPitch     int       `json:"seatPitch,omitempty"`
+}
+
+// Carrier is an airline an offer names.
+type Carrier struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// Aircraft is an aircraft type an offer names. Code is the IATA type
+// code.
+type Aircraft struct {
+	Code string `json:"code,omitempty"`
+	Name string `json:"name"`
+}
+
+// StayOffer is a stay as an adapter returns it.
+type StayOffer struct {
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Name            string    `json:"name"`
+	PropertyID      string    `json:"propertyId,omitempty"`
+	Chain           string    `json:"chain,omitempty"`
+	Type            string    `json:"type"`
+	City            string    `json:"city"`
+	Address         string    `json:"address,omitempty"`
+	Latitude        float64   `json:"latitude,omitempty"`
+	Longitude       float64   `json:"longitude,omitempty"`
+	Neighborhood    string    `json:"neighborhood,omitempty"`
+	CheckIn         string    `json:"checkIn"`
+	CheckOut        string    `json:"checkOut"`
+	NightsCount     int       `json:"nightsCount"`
+	PricePerNight   float64   `json:"pricePerNight"`
+	TotalPriceUSD   float64   `json:"totalPriceUSD"`
+	Currency        string    `json:"currency"`
+	Rating          float64   `json:"rating,omitempty"`
+	ReviewCount     int       `json:"reviewCount,omitempty"`
+	Amenities       []string  `json:"amenities,omitempty"`
+	DeepLink        string    `json:"deepLink,omitempty"`
+	Confidence      float64   `json:"confidence"`
+	IsBookable      bool      `json:"isBookable"`
+	RepriceRequired bool      `json:"repriceRequired"`
+	FetchedAt       time.Time `json:"fetchedAt"`
+	// Board is the meals the rate includes, as a Board* type or the
+	// provider's own code, which the router maps with NormalizeBoard.
+	Board string `json:"board,omitempty"`
+	// ChildrenPriceUSD is the part of TotalPriceUSD charged for the
+	// children in StaySearchRequest.ChildrenAges.
+	ChildrenPriceUSD float64 `json:"childrenPriceUSD,omitempty"`
+	// See FlightOffer.
+	ExpiresAt            time.Time `json:"expiresAt,omitzero"`
+	PriceGuaranteedUntil time.Time `json:"priceGuaranteedUntil,omitzero"`
+}
+
+// FlightStatus is how a flight stands on the day: one of the Flight*
+// statuses, its gates and delays, and the aircraft flying it.
+type FlightStatus struct {
+	Flight       string          `json:"flight"`
+	Date         string          `json:"date"`
+	Source       string          `json:"source"`
+	Airline      string          `json:"airline,omitempty"`
+	Status       string          `json:"status"`
+	Departure    FlightStatusEnd `json:"departure"`
+	Arrival      FlightStatusEnd `json:"arrival"`
+	Aircraft     *Aircraft       `json:"aircraft,omitempty"`
+	Registration string          `json:"registration,omitempty"`
+	FetchedAt    time.Time       `json:"fetchedAt"`
+}
+
+// FlightStatusEnd is one end of a flight. Estimated and Actual are zero
+// until the provider has them.
+type FlightStatusEnd struct {
+	Airport      string    `json:"airport"`
+	Terminal     string    `json:"terminal,omitempty"`
+	Gate         string    `json:"gate,omitempty"`
+	Baggage      string    `json:"baggage,omitempty"`
+	Scheduled    time.Time `json:"scheduled,omitzero"`
+	Estimated    time.Time `json:"estimated,omitzero"`
+	Actual       time.Time `json:"actual,omitzero"`
+	DelayMinutes int       `json:"delayMinutes,omitempty"`
+}
+
+// MaintenanceError reports that the provider answered with a maintenance
+// status; the router skips it until RetryAt, or for a while when RetryAt
+// is zero.
+type MaintenanceError struct {
+	Provider   string
+	StatusCode int
+	RetryAt    time.Time
+}
+
+func (e *MaintenanceError) Error() string {
+	msg := fmt.Sprintf("%s is down for maintenance (HTTP %d)", e.Provider, e.StatusCode)
+	if !e.RetryAt.IsZero() {
+		msg += ", retry after " + e.RetryAt.UTC().Format(time.RFC3339)
+	}
+	return msg
+}
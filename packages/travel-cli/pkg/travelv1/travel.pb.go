@@ -0,0 +1,1685 @@
+// The travel search service travel serve --grpc exposes, so other
+// services can search with typed clients instead of parsing CLI JSON.
+// Messages mirror the CLI's JSON output; see travel schema for field
+// meanings. Regenerate the Go code in pkg/travelv1 by running
+// `buf generate` in packages/travel-cli after changing this file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: travel/v1/travel.proto
+
+package travelv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchFlightsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// Dates are YYYY-MM-DD.
+	DepartDate    string `protobuf:"bytes,3,opt,name=depart_date,json=departDate,proto3" json:"depart_date,omitempty"`
+	ReturnDate    string `protobuf:"bytes,4,opt,name=return_date,json=returnDate,proto3" json:"return_date,omitempty"`
+	Adults        int32  `protobuf:"varint,5,opt,name=adults,proto3" json:"adults,omitempty"`
+	CabinClass    string `protobuf:"bytes,6,opt,name=cabin_class,json=cabinClass,proto3" json:"cabin_class,omitempty"`
+	MaxResults    int32  `protobuf:"varint,7,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	GroupBy       string `protobuf:"bytes,8,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsRequest) Reset() {
+	*x = SearchFlightsRequest{}
+	mi := &file_travel_v1_travel_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsRequest) ProtoMessage() {}
+
+func (x *SearchFlightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsRequest.ProtoReflect.Descriptor instead.
+func (*SearchFlightsRequest) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchFlightsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetDepartDate() string {
+	if x != nil {
+		return x.DepartDate
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetReturnDate() string {
+	if x != nil {
+		return x.ReturnDate
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetAdults() int32 {
+	if x != nil {
+		return x.Adults
+	}
+	return 0
+}
+
+func (x *SearchFlightsRequest) GetCabinClass() string {
+	if x != nil {
+		return x.CabinClass
+	}
+	return ""
+}
+
+func (x *SearchFlightsRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchFlightsRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+type SearchStaysRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	City     string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	CheckIn  string                 `protobuf:"bytes,2,opt,name=check_in,json=checkIn,proto3" json:"check_in,omitempty"`
+	CheckOut string                 `protobuf:"bytes,3,opt,name=check_out,json=checkOut,proto3" json:"check_out,omitempty"`
+	// Guests counts adults; children are listed by age.
+	Guests        int32   `protobuf:"varint,4,opt,name=guests,proto3" json:"guests,omitempty"`
+	ChildrenAges  []int32 `protobuf:"varint,5,rep,packed,name=children_ages,json=childrenAges,proto3" json:"children_ages,omitempty"`
+	Rooms         int32   `protobuf:"varint,6,opt,name=rooms,proto3" json:"rooms,omitempty"`
+	MaxResults    int32   `protobuf:"varint,7,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+	StayType      string  `protobuf:"bytes,8,opt,name=stay_type,json=stayType,proto3" json:"stay_type,omitempty"`
+	MaxPriceUsd   int32   `protobuf:"varint,9,opt,name=max_price_usd,json=maxPriceUsd,proto3" json:"max_price_usd,omitempty"`
+	GroupBy       string  `protobuf:"bytes,10,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStaysRequest) Reset() {
+	*x = SearchStaysRequest{}
+	mi := &file_travel_v1_travel_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaysRequest) ProtoMessage() {}
+
+func (x *SearchStaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaysRequest.ProtoReflect.Descriptor instead.
+func (*SearchStaysRequest) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchStaysRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchStaysRequest) GetCheckIn() string {
+	if x != nil {
+		return x.CheckIn
+	}
+	return ""
+}
+
+func (x *SearchStaysRequest) GetCheckOut() string {
+	if x != nil {
+		return x.CheckOut
+	}
+	return ""
+}
+
+func (x *SearchStaysRequest) GetGuests() int32 {
+	if x != nil {
+		return x.Guests
+	}
+	return 0
+}
+
+func (x *SearchStaysRequest) GetChildrenAges() []int32 {
+	if x != nil {
+		return x.ChildrenAges
+	}
+	return nil
+}
+
+func (x *SearchStaysRequest) GetRooms() int32 {
+	if x != nil {
+		return x.Rooms
+	}
+	return 0
+}
+
+func (x *SearchStaysRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchStaysRequest) GetStayType() string {
+	if x != nil {
+		return x.StayType
+	}
+	return ""
+}
+
+func (x *SearchStaysRequest) GetMaxPriceUsd() int32 {
+	if x != nil {
+		return x.MaxPriceUsd
+	}
+	return 0
+}
+
+func (x *SearchStaysRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return ""
+}
+
+type SearchFlightsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *SearchResult          `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFlightsResponse) Reset() {
+	*x = SearchFlightsResponse{}
+	mi := &file_travel_v1_travel_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFlightsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFlightsResponse) ProtoMessage() {}
+
+func (x *SearchFlightsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFlightsResponse.ProtoReflect.Descriptor instead.
+func (*SearchFlightsResponse) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchFlightsResponse) GetResult() *SearchResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SearchStaysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *SearchResult          `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStaysResponse) Reset() {
+	*x = SearchStaysResponse{}
+	mi := &file_travel_v1_travel_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaysResponse) ProtoMessage() {}
+
+func (x *SearchStaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaysResponse.ProtoReflect.Descriptor instead.
+func (*SearchStaysResponse) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchStaysResponse) GetResult() *SearchResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Providers     []string               `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"`
+	Flights       []*FlightOffer         `protobuf:"bytes,3,rep,name=flights,proto3" json:"flights,omitempty"`
+	Stays         []*StayOffer           `protobuf:"bytes,4,rep,name=stays,proto3" json:"stays,omitempty"`
+	TotalFound    int32                  `protobuf:"varint,5,opt,name=total_found,json=totalFound,proto3" json:"total_found,omitempty"`
+	Errors        []*ProviderError       `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	Partial       bool                   `protobuf:"varint,7,opt,name=partial,proto3" json:"partial,omitempty"`
+	FetchedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	FromCache     bool                   `protobuf:"varint,9,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_travel_v1_travel_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchResult) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SearchResult) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *SearchResult) GetFlights() []*FlightOffer {
+	if x != nil {
+		return x.Flights
+	}
+	return nil
+}
+
+func (x *SearchResult) GetStays() []*StayOffer {
+	if x != nil {
+		return x.Stays
+	}
+	return nil
+}
+
+func (x *SearchResult) GetTotalFound() int32 {
+	if x != nil {
+		return x.TotalFound
+	}
+	return 0
+}
+
+func (x *SearchResult) GetErrors() []*ProviderError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *SearchResult) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *SearchResult) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *SearchResult) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+type FlightOffer struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source               string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Airline              string                 `protobuf:"bytes,3,opt,name=airline,proto3" json:"airline,omitempty"`
+	FlightNumber         string                 `protobuf:"bytes,4,opt,name=flight_number,json=flightNumber,proto3" json:"flight_number,omitempty"`
+	From                 string                 `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string                 `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	DepartTime           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=depart_time,json=departTime,proto3" json:"depart_time,omitempty"`
+	ArriveTime           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=arrive_time,json=arriveTime,proto3" json:"arrive_time,omitempty"`
+	DurationMinutes      int32                  `protobuf:"varint,9,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	Stops                int32                  `protobuf:"varint,10,opt,name=stops,proto3" json:"stops,omitempty"`
+	CabinClass           string                 `protobuf:"bytes,11,opt,name=cabin_class,json=cabinClass,proto3" json:"cabin_class,omitempty"`
+	PriceUsd             float64                `protobuf:"fixed64,12,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	Currency             string                 `protobuf:"bytes,13,opt,name=currency,proto3" json:"currency,omitempty"`
+	Prices               []*SourcePrice         `protobuf:"bytes,14,rep,name=prices,proto3" json:"prices,omitempty"`
+	DeepLink             string                 `protobuf:"bytes,15,opt,name=deep_link,json=deepLink,proto3" json:"deep_link,omitempty"`
+	Confidence           float64                `protobuf:"fixed64,16,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	IsBookable           bool                   `protobuf:"varint,17,opt,name=is_bookable,json=isBookable,proto3" json:"is_bookable,omitempty"`
+	RepriceRequired      bool                   `protobuf:"varint,18,opt,name=reprice_required,json=repriceRequired,proto3" json:"reprice_required,omitempty"`
+	FetchedAt            *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	ExpiresAt            *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PriceGuaranteedUntil *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=price_guaranteed_until,json=priceGuaranteedUntil,proto3" json:"price_guaranteed_until,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *FlightOffer) Reset() {
+	*x = FlightOffer{}
+	mi := &file_travel_v1_travel_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlightOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlightOffer) ProtoMessage() {}
+
+func (x *FlightOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlightOffer.ProtoReflect.Descriptor instead.
+func (*FlightOffer) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FlightOffer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetAirline() string {
+	if x != nil {
+		return x.Airline
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetFlightNumber() string {
+	if x != nil {
+		return x.FlightNumber
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetDepartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DepartTime
+	}
+	return nil
+}
+
+func (x *FlightOffer) GetArriveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArriveTime
+	}
+	return nil
+}
+
+func (x *FlightOffer) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *FlightOffer) GetStops() int32 {
+	if x != nil {
+		return x.Stops
+	}
+	return 0
+}
+
+func (x *FlightOffer) GetCabinClass() string {
+	if x != nil {
+		return x.CabinClass
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetPriceUsd() float64 {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return 0
+}
+
+func (x *FlightOffer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetPrices() []*SourcePrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *FlightOffer) GetDeepLink() string {
+	if x != nil {
+		return x.DeepLink
+	}
+	return ""
+}
+
+func (x *FlightOffer) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *FlightOffer) GetIsBookable() bool {
+	if x != nil {
+		return x.IsBookable
+	}
+	return false
+}
+
+func (x *FlightOffer) GetRepriceRequired() bool {
+	if x != nil {
+		return x.RepriceRequired
+	}
+	return false
+}
+
+func (x *FlightOffer) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *FlightOffer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *FlightOffer) GetPriceGuaranteedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceGuaranteedUntil
+	}
+	return nil
+}
+
+type SourcePrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	PriceUsd      float64                `protobuf:"fixed64,3,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	DeepLink      string                 `protobuf:"bytes,4,opt,name=deep_link,json=deepLink,proto3" json:"deep_link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourcePrice) Reset() {
+	*x = SourcePrice{}
+	mi := &file_travel_v1_travel_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourcePrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourcePrice) ProtoMessage() {}
+
+func (x *SourcePrice) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourcePrice.ProtoReflect.Descriptor instead.
+func (*SourcePrice) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SourcePrice) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SourcePrice) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *SourcePrice) GetPriceUsd() float64 {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return 0
+}
+
+func (x *SourcePrice) GetDeepLink() string {
+	if x != nil {
+		return x.DeepLink
+	}
+	return ""
+}
+
+type StayOffer struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Source               string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Sources              []string               `protobuf:"bytes,3,rep,name=sources,proto3" json:"sources,omitempty"`
+	Name                 string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	PropertyId           string                 `protobuf:"bytes,5,opt,name=property_id,json=propertyId,proto3" json:"property_id,omitempty"`
+	Chain                string                 `protobuf:"bytes,6,opt,name=chain,proto3" json:"chain,omitempty"`
+	Type                 string                 `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	City                 string                 `protobuf:"bytes,8,opt,name=city,proto3" json:"city,omitempty"`
+	Address              string                 `protobuf:"bytes,9,opt,name=address,proto3" json:"address,omitempty"`
+	Latitude             float64                `protobuf:"fixed64,10,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64                `protobuf:"fixed64,11,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Neighborhood         string                 `protobuf:"bytes,12,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	CheckIn              string                 `protobuf:"bytes,13,opt,name=check_in,json=checkIn,proto3" json:"check_in,omitempty"`
+	CheckOut             string                 `protobuf:"bytes,14,opt,name=check_out,json=checkOut,proto3" json:"check_out,omitempty"`
+	NightsCount          int32                  `protobuf:"varint,15,opt,name=nights_count,json=nightsCount,proto3" json:"nights_count,omitempty"`
+	PricePerNight        float64                `protobuf:"fixed64,16,opt,name=price_per_night,json=pricePerNight,proto3" json:"price_per_night,omitempty"`
+	TotalPriceUsd        float64                `protobuf:"fixed64,17,opt,name=total_price_usd,json=totalPriceUsd,proto3" json:"total_price_usd,omitempty"`
+	Currency             string                 `protobuf:"bytes,18,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rating               float64                `protobuf:"fixed64,19,opt,name=rating,proto3" json:"rating,omitempty"`
+	ReviewCount          int32                  `protobuf:"varint,20,opt,name=review_count,json=reviewCount,proto3" json:"review_count,omitempty"`
+	Amenities            []string               `protobuf:"bytes,21,rep,name=amenities,proto3" json:"amenities,omitempty"`
+	DeepLink             string                 `protobuf:"bytes,22,opt,name=deep_link,json=deepLink,proto3" json:"deep_link,omitempty"`
+	Confidence           float64                `protobuf:"fixed64,23,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	IsBookable           bool                   `protobuf:"varint,24,opt,name=is_bookable,json=isBookable,proto3" json:"is_bookable,omitempty"`
+	RepriceRequired      bool                   `protobuf:"varint,25,opt,name=reprice_required,json=repriceRequired,proto3" json:"reprice_required,omitempty"`
+	FetchedAt            *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	ChildrenPriceUsd     float64                `protobuf:"fixed64,27,opt,name=children_price_usd,json=childrenPriceUsd,proto3" json:"children_price_usd,omitempty"`
+	ExpiresAt            *timestamppb.Timestamp `protobuf:"bytes,28,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	PriceGuaranteedUntil *timestamppb.Timestamp `protobuf:"bytes,29,opt,name=price_guaranteed_until,json=priceGuaranteedUntil,proto3" json:"price_guaranteed_until,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *StayOffer) Reset() {
+	*x = StayOffer{}
+	mi := &file_travel_v1_travel_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StayOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StayOffer) ProtoMessage() {}
+
+func (x *StayOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StayOffer.ProtoReflect.Descriptor instead.
+func (*StayOffer) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StayOffer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StayOffer) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *StayOffer) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *StayOffer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StayOffer) GetPropertyId() string {
+	if x != nil {
+		return x.PropertyId
+	}
+	return ""
+}
+
+func (x *StayOffer) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *StayOffer) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StayOffer) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *StayOffer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *StayOffer) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *StayOffer) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *StayOffer) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *StayOffer) GetCheckIn() string {
+	if x != nil {
+		return x.CheckIn
+	}
+	return ""
+}
+
+func (x *StayOffer) GetCheckOut() string {
+	if x != nil {
+		return x.CheckOut
+	}
+	return ""
+}
+
+func (x *StayOffer) GetNightsCount() int32 {
+	if x != nil {
+		return x.NightsCount
+	}
+	return 0
+}
+
+func (x *StayOffer) GetPricePerNight() float64 {
+	if x != nil {
+		return x.PricePerNight
+	}
+	return 0
+}
+
+func (x *StayOffer) GetTotalPriceUsd() float64 {
+	if x != nil {
+		return x.TotalPriceUsd
+	}
+	return 0
+}
+
+func (x *StayOffer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *StayOffer) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *StayOffer) GetReviewCount() int32 {
+	if x != nil {
+		return x.ReviewCount
+	}
+	return 0
+}
+
+func (x *StayOffer) GetAmenities() []string {
+	if x != nil {
+		return x.Amenities
+	}
+	return nil
+}
+
+func (x *StayOffer) GetDeepLink() string {
+	if x != nil {
+		return x.DeepLink
+	}
+	return ""
+}
+
+func (x *StayOffer) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *StayOffer) GetIsBookable() bool {
+	if x != nil {
+		return x.IsBookable
+	}
+	return false
+}
+
+func (x *StayOffer) GetRepriceRequired() bool {
+	if x != nil {
+		return x.RepriceRequired
+	}
+	return false
+}
+
+func (x *StayOffer) GetFetchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return nil
+}
+
+func (x *StayOffer) GetChildrenPriceUsd() float64 {
+	if x != nil {
+		return x.ChildrenPriceUsd
+	}
+	return 0
+}
+
+func (x *StayOffer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StayOffer) GetPriceGuaranteedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PriceGuaranteedUntil
+	}
+	return nil
+}
+
+type ProviderError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Fallback      string                 `protobuf:"bytes,3,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	Attempts      int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderError) Reset() {
+	*x = ProviderError{}
+	mi := &file_travel_v1_travel_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderError) ProtoMessage() {}
+
+func (x *ProviderError) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderError.ProtoReflect.Descriptor instead.
+func (*ProviderError) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProviderError) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ProviderError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ProviderError) GetFallback() string {
+	if x != nil {
+		return x.Fallback
+	}
+	return ""
+}
+
+func (x *ProviderError) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type RepriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepriceRequest) Reset() {
+	*x = RepriceRequest{}
+	mi := &file_travel_v1_travel_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepriceRequest) ProtoMessage() {}
+
+func (x *RepriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepriceRequest.ProtoReflect.Descriptor instead.
+func (*RepriceRequest) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RepriceRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+type RepriceResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OfferId string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Status  string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Types that are valid to be assigned to Offer:
+	//
+	//	*RepriceResponse_Flight
+	//	*RepriceResponse_Stay
+	Offer         isRepriceResponse_Offer `protobuf_oneof:"offer"`
+	Warnings      []*OfferWarning         `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepriceResponse) Reset() {
+	*x = RepriceResponse{}
+	mi := &file_travel_v1_travel_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepriceResponse) ProtoMessage() {}
+
+func (x *RepriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepriceResponse.ProtoReflect.Descriptor instead.
+func (*RepriceResponse) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RepriceResponse) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *RepriceResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RepriceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RepriceResponse) GetOffer() isRepriceResponse_Offer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *RepriceResponse) GetFlight() *FlightOffer {
+	if x != nil {
+		if x, ok := x.Offer.(*RepriceResponse_Flight); ok {
+			return x.Flight
+		}
+	}
+	return nil
+}
+
+func (x *RepriceResponse) GetStay() *StayOffer {
+	if x != nil {
+		if x, ok := x.Offer.(*RepriceResponse_Stay); ok {
+			return x.Stay
+		}
+	}
+	return nil
+}
+
+func (x *RepriceResponse) GetWarnings() []*OfferWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type isRepriceResponse_Offer interface {
+	isRepriceResponse_Offer()
+}
+
+type RepriceResponse_Flight struct {
+	Flight *FlightOffer `protobuf:"bytes,4,opt,name=flight,proto3,oneof"`
+}
+
+type RepriceResponse_Stay struct {
+	Stay *StayOffer `protobuf:"bytes,5,opt,name=stay,proto3,oneof"`
+}
+
+func (*RepriceResponse_Flight) isRepriceResponse_Offer() {}
+
+func (*RepriceResponse_Stay) isRepriceResponse_Offer() {}
+
+type OfferWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	OfferId       string                 `protobuf:"bytes,2,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferWarning) Reset() {
+	*x = OfferWarning{}
+	mi := &file_travel_v1_travel_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferWarning) ProtoMessage() {}
+
+func (x *OfferWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferWarning.ProtoReflect.Descriptor instead.
+func (*OfferWarning) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OfferWarning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OfferWarning) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *OfferWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersRequest) Reset() {
+	*x = ListProvidersRequest{}
+	mi := &file_travel_v1_travel_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersRequest) ProtoMessage() {}
+
+func (x *ListProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{12}
+}
+
+type ListProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*Provider            `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersResponse) Reset() {
+	*x = ListProvidersResponse{}
+	mi := &file_travel_v1_travel_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersResponse) ProtoMessage() {}
+
+func (x *ListProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListProvidersResponse) GetProviders() []*Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type Provider struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Capabilities  []string               `protobuf:"bytes,2,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Tier          string                 `protobuf:"bytes,3,opt,name=tier,proto3" json:"tier,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReenableAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reenable_at,json=reenableAt,proto3" json:"reenable_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Provider) Reset() {
+	*x = Provider{}
+	mi := &file_travel_v1_travel_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provider) ProtoMessage() {}
+
+func (x *Provider) ProtoReflect() protoreflect.Message {
+	mi := &file_travel_v1_travel_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provider.ProtoReflect.Descriptor instead.
+func (*Provider) Descriptor() ([]byte, []int) {
+	return file_travel_v1_travel_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Provider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Provider) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Provider) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *Provider) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Provider) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Provider) GetReenableAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReenableAt
+	}
+	return nil
+}
+
+var File_travel_v1_travel_proto protoreflect.FileDescriptor
+
+const file_travel_v1_travel_proto_rawDesc = "" +
+	"\n" +
+	"\x16travel/v1/travel.proto\x12\ttravel.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf1\x01\n" +
+	"\x14SearchFlightsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x1f\n" +
+	"\vdepart_date\x18\x03 \x01(\tR\n" +
+	"departDate\x12\x1f\n" +
+	"\vreturn_date\x18\x04 \x01(\tR\n" +
+	"returnDate\x12\x16\n" +
+	"\x06adults\x18\x05 \x01(\x05R\x06adults\x12\x1f\n" +
+	"\vcabin_class\x18\x06 \x01(\tR\n" +
+	"cabinClass\x12\x1f\n" +
+	"\vmax_results\x18\a \x01(\x05R\n" +
+	"maxResults\x12\x19\n" +
+	"\bgroup_by\x18\b \x01(\tR\agroupBy\"\xb0\x02\n" +
+	"\x12SearchStaysRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x19\n" +
+	"\bcheck_in\x18\x02 \x01(\tR\acheckIn\x12\x1b\n" +
+	"\tcheck_out\x18\x03 \x01(\tR\bcheckOut\x12\x16\n" +
+	"\x06guests\x18\x04 \x01(\x05R\x06guests\x12#\n" +
+	"\rchildren_ages\x18\x05 \x03(\x05R\fchildrenAges\x12\x14\n" +
+	"\x05rooms\x18\x06 \x01(\x05R\x05rooms\x12\x1f\n" +
+	"\vmax_results\x18\a \x01(\x05R\n" +
+	"maxResults\x12\x1b\n" +
+	"\tstay_type\x18\b \x01(\tR\bstayType\x12\"\n" +
+	"\rmax_price_usd\x18\t \x01(\x05R\vmaxPriceUsd\x12\x19\n" +
+	"\bgroup_by\x18\n" +
+	" \x01(\tR\agroupBy\"H\n" +
+	"\x15SearchFlightsResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.travel.v1.SearchResultR\x06result\"F\n" +
+	"\x13SearchStaysResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.travel.v1.SearchResultR\x06result\"\xe5\x02\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x1c\n" +
+	"\tproviders\x18\x02 \x03(\tR\tproviders\x120\n" +
+	"\aflights\x18\x03 \x03(\v2\x16.travel.v1.FlightOfferR\aflights\x12*\n" +
+	"\x05stays\x18\x04 \x03(\v2\x14.travel.v1.StayOfferR\x05stays\x12\x1f\n" +
+	"\vtotal_found\x18\x05 \x01(\x05R\n" +
+	"totalFound\x120\n" +
+	"\x06errors\x18\x06 \x03(\v2\x18.travel.v1.ProviderErrorR\x06errors\x12\x18\n" +
+	"\apartial\x18\a \x01(\bR\apartial\x129\n" +
+	"\n" +
+	"fetched_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\t \x01(\bR\tfromCache\"\xae\x06\n" +
+	"\vFlightOffer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\aairline\x18\x03 \x01(\tR\aairline\x12#\n" +
+	"\rflight_number\x18\x04 \x01(\tR\fflightNumber\x12\x12\n" +
+	"\x04from\x18\x05 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x06 \x01(\tR\x02to\x12;\n" +
+	"\vdepart_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"departTime\x12;\n" +
+	"\varrive_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"arriveTime\x12)\n" +
+	"\x10duration_minutes\x18\t \x01(\x05R\x0fdurationMinutes\x12\x14\n" +
+	"\x05stops\x18\n" +
+	" \x01(\x05R\x05stops\x12\x1f\n" +
+	"\vcabin_class\x18\v \x01(\tR\n" +
+	"cabinClass\x12\x1b\n" +
+	"\tprice_usd\x18\f \x01(\x01R\bpriceUsd\x12\x1a\n" +
+	"\bcurrency\x18\r \x01(\tR\bcurrency\x12.\n" +
+	"\x06prices\x18\x0e \x03(\v2\x16.travel.v1.SourcePriceR\x06prices\x12\x1b\n" +
+	"\tdeep_link\x18\x0f \x01(\tR\bdeepLink\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x10 \x01(\x01R\n" +
+	"confidence\x12\x1f\n" +
+	"\vis_bookable\x18\x11 \x01(\bR\n" +
+	"isBookable\x12)\n" +
+	"\x10reprice_required\x18\x12 \x01(\bR\x0frepriceRequired\x129\n" +
+	"\n" +
+	"fetched_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12P\n" +
+	"\x16price_guaranteed_until\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\x14priceGuaranteedUntil\"z\n" +
+	"\vSourcePrice\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tprice_usd\x18\x03 \x01(\x01R\bpriceUsd\x12\x1b\n" +
+	"\tdeep_link\x18\x04 \x01(\tR\bdeepLink\"\xd7\a\n" +
+	"\tStayOffer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x18\n" +
+	"\asources\x18\x03 \x03(\tR\asources\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1f\n" +
+	"\vproperty_id\x18\x05 \x01(\tR\n" +
+	"propertyId\x12\x14\n" +
+	"\x05chain\x18\x06 \x01(\tR\x05chain\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\x12\x12\n" +
+	"\x04city\x18\b \x01(\tR\x04city\x12\x18\n" +
+	"\aaddress\x18\t \x01(\tR\aaddress\x12\x1a\n" +
+	"\blatitude\x18\n" +
+	" \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\v \x01(\x01R\tlongitude\x12\"\n" +
+	"\fneighborhood\x18\f \x01(\tR\fneighborhood\x12\x19\n" +
+	"\bcheck_in\x18\r \x01(\tR\acheckIn\x12\x1b\n" +
+	"\tcheck_out\x18\x0e \x01(\tR\bcheckOut\x12!\n" +
+	"\fnights_count\x18\x0f \x01(\x05R\vnightsCount\x12&\n" +
+	"\x0fprice_per_night\x18\x10 \x01(\x01R\rpricePerNight\x12&\n" +
+	"\x0ftotal_price_usd\x18\x11 \x01(\x01R\rtotalPriceUsd\x12\x1a\n" +
+	"\bcurrency\x18\x12 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06rating\x18\x13 \x01(\x01R\x06rating\x12!\n" +
+	"\freview_count\x18\x14 \x01(\x05R\vreviewCount\x12\x1c\n" +
+	"\tamenities\x18\x15 \x03(\tR\tamenities\x12\x1b\n" +
+	"\tdeep_link\x18\x16 \x01(\tR\bdeepLink\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x17 \x01(\x01R\n" +
+	"confidence\x12\x1f\n" +
+	"\vis_bookable\x18\x18 \x01(\bR\n" +
+	"isBookable\x12)\n" +
+	"\x10reprice_required\x18\x19 \x01(\bR\x0frepriceRequired\x129\n" +
+	"\n" +
+	"fetched_at\x18\x1a \x01(\v2\x1a.google.protobuf.TimestampR\tfetchedAt\x12,\n" +
+	"\x12children_price_usd\x18\x1b \x01(\x01R\x10childrenPriceUsd\x129\n" +
+	"\n" +
+	"expires_at\x18\x1c \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12P\n" +
+	"\x16price_guaranteed_until\x18\x1d \x01(\v2\x1a.google.protobuf.TimestampR\x14priceGuaranteedUntil\"{\n" +
+	"\rProviderError\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1a\n" +
+	"\bfallback\x18\x03 \x01(\tR\bfallback\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\"+\n" +
+	"\x0eRepriceRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\"\xfa\x01\n" +
+	"\x0fRepriceResponse\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x120\n" +
+	"\x06flight\x18\x04 \x01(\v2\x16.travel.v1.FlightOfferH\x00R\x06flight\x12*\n" +
+	"\x04stay\x18\x05 \x01(\v2\x14.travel.v1.StayOfferH\x00R\x04stay\x123\n" +
+	"\bwarnings\x18\x06 \x03(\v2\x17.travel.v1.OfferWarningR\bwarningsB\a\n" +
+	"\x05offer\"W\n" +
+	"\fOfferWarning\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x19\n" +
+	"\boffer_id\x18\x02 \x01(\tR\aofferId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x16\n" +
+	"\x14ListProvidersRequest\"J\n" +
+	"\x15ListProvidersResponse\x121\n" +
+	"\tproviders\x18\x01 \x03(\v2\x13.travel.v1.ProviderR\tproviders\"\xc3\x01\n" +
+	"\bProvider\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\fcapabilities\x18\x02 \x03(\tR\fcapabilities\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\tR\x04tier\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12;\n" +
+	"\vreenable_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reenableAt2\xc7\x02\n" +
+	"\rTravelService\x12R\n" +
+	"\rSearchFlights\x12\x1f.travel.v1.SearchFlightsRequest\x1a .travel.v1.SearchFlightsResponse\x12L\n" +
+	"\vSearchStays\x12\x1d.travel.v1.SearchStaysRequest\x1a\x1e.travel.v1.SearchStaysResponse\x12@\n" +
+	"\aReprice\x12\x19.travel.v1.RepriceRequest\x1a\x1a.travel.v1.RepriceResponse\x12R\n" +
+	"\rListProviders\x12\x1f.travel.v1.ListProvidersRequest\x1a .travel.v1.ListProvidersResponseB7Z5github.com/beetlebot/travel-cli/pkg/travelv1;travelv1b\x06proto3"
+
+var (
+	file_travel_v1_travel_proto_rawDescOnce sync.Once
+	file_travel_v1_travel_proto_rawDescData []byte
+)
+
+func file_travel_v1_travel_proto_rawDescGZIP() []byte {
+	file_travel_v1_travel_proto_rawDescOnce.Do(func() {
+		file_travel_v1_travel_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_travel_v1_travel_proto_rawDesc), len(file_travel_v1_travel_proto_rawDesc)))
+	})
+	return file_travel_v1_travel_proto_rawDescData
+}
+
+var file_travel_v1_travel_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_travel_v1_travel_proto_goTypes = []any{
+	(*SearchFlightsRequest)(nil),  // 0: travel.v1.SearchFlightsRequest
+	(*SearchStaysRequest)(nil),    // 1: travel.v1.SearchStaysRequest
+	(*SearchFlightsResponse)(nil), // 2: travel.v1.SearchFlightsResponse
+	(*SearchStaysResponse)(nil),   // 3: travel.v1.SearchStaysResponse
+	(*SearchResult)(nil),          // 4: travel.v1.SearchResult
+	(*FlightOffer)(nil),           // 5: travel.v1.FlightOffer
+	(*SourcePrice)(nil),           // 6: travel.v1.SourcePrice
+	(*StayOffer)(nil),             // 7: travel.v1.StayOffer
+	(*ProviderError)(nil),         // 8: travel.v1.ProviderError
+	(*RepriceRequest)(nil),        // 9: travel.v1.RepriceRequest
+	(*RepriceResponse)(nil),       // 10: travel.v1.RepriceResponse
+	(*OfferWarning)(nil),          // 11: travel.v1.OfferWarning
+	(*ListProvidersRequest)(nil),  // 12: travel.v1.ListProvidersRequest
+	(*ListProvidersResponse)(nil), // 13: travel.v1.ListProvidersResponse
+	(*Provider)(nil),              // 14: travel.v1.Provider
+	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
+}
+var file_travel_v1_travel_proto_depIdxs = []int32{
+	4,  // 0: travel.v1.SearchFlightsResponse.result:type_name -> travel.v1.SearchResult
+	4,  // 1: travel.v1.SearchStaysResponse.result:type_name -> travel.v1.SearchResult
+	5,  // 2: travel.v1.SearchResult.flights:type_name -> travel.v1.FlightOffer
+	7,  // 3: travel.v1.SearchResult.stays:type_name -> travel.v1.StayOffer
+	8,  // 4: travel.v1.SearchResult.errors:type_name -> travel.v1.ProviderError
+	15, // 5: travel.v1.SearchResult.fetched_at:type_name -> google.protobuf.Timestamp
+	15, // 6: travel.v1.FlightOffer.depart_time:type_name -> google.protobuf.Timestamp
+	15, // 7: travel.v1.FlightOffer.arrive_time:type_name -> google.protobuf.Timestamp
+	6,  // 8: travel.v1.FlightOffer.prices:type_name -> travel.v1.SourcePrice
+	15, // 9: travel.v1.FlightOffer.fetched_at:type_name -> google.protobuf.Timestamp
+	15, // 10: travel.v1.FlightOffer.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 11: travel.v1.FlightOffer.price_guaranteed_until:type_name -> google.protobuf.Timestamp
+	15, // 12: travel.v1.StayOffer.fetched_at:type_name -> google.protobuf.Timestamp
+	15, // 13: travel.v1.StayOffer.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 14: travel.v1.StayOffer.price_guaranteed_until:type_name -> google.protobuf.Timestamp
+	5,  // 15: travel.v1.RepriceResponse.flight:type_name -> travel.v1.FlightOffer
+	7,  // 16: travel.v1.RepriceResponse.stay:type_name -> travel.v1.StayOffer
+	11, // 17: travel.v1.RepriceResponse.warnings:type_name -> travel.v1.OfferWarning
+	14, // 18: travel.v1.ListProvidersResponse.providers:type_name -> travel.v1.Provider
+	15, // 19: travel.v1.Provider.reenable_at:type_name -> google.protobuf.Timestamp
+	0,  // 20: travel.v1.TravelService.SearchFlights:input_type -> travel.v1.SearchFlightsRequest
+	1,  // 21: travel.v1.TravelService.SearchStays:input_type -> travel.v1.SearchStaysRequest
+	9,  // 22: travel.v1.TravelService.Reprice:input_type -> travel.v1.RepriceRequest
+	12, // 23: travel.v1.TravelService.ListProviders:input_type -> travel.v1.ListProvidersRequest
+	2,  // 24: travel.v1.TravelService.SearchFlights:output_type -> travel.v1.SearchFlightsResponse
+	3,  // 25: travel.v1.TravelService.SearchStays:output_type -> travel.v1.SearchStaysResponse
+	10, // 26: travel.v1.TravelService.Reprice:output_type -> travel.v1.RepriceResponse
+	13, // 27: travel.v1.TravelService.ListProviders:output_type -> travel.v1.ListProvidersResponse
+	24, // [24:28] is the sub-list for method output_type
+	20, // [20:24] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_travel_v1_travel_proto_init() }
+func file_travel_v1_travel_proto_init() {
+	if File_travel_v1_travel_proto != nil {
+		return
+	}
+	file_travel_v1_travel_proto_msgTypes[10].OneofWrappers = []any{
+		(*RepriceResponse_Flight)(nil),
+		(*RepriceResponse_Stay)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_travel_v1_travel_proto_rawDesc), len(file_travel_v1_travel_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_travel_v1_travel_proto_goTypes,
+		DependencyIndexes: file_travel_v1_travel_proto_depIdxs,
+		MessageInfos:      file_travel_v1_travel_proto_msgTypes,
+	}.Build()
+	File_travel_v1_travel_proto = out.File
+	file_travel_v1_travel_proto_goTypes = nil
+	file_travel_v1_travel_proto_depIdxs = nil
+}
@@ -0,0 +1,255 @@
+// The travel search service travel serve --grpc exposes, so other
+// services can search with typed clients instead of parsing CLI JSON.
+// Messages mirror the CLI's JSON output; see travel schema for field
+// meanings. Regenerate the Go code in pkg/travelv1 by running
+// `buf generate` in packages/travel-cli after changing this file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: travel/v1/travel.proto
+
+package travelv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TravelService_SearchFlights_FullMethodName = "/travel.v1.TravelService/SearchFlights"
+	TravelService_SearchStays_FullMethodName   = "/travel.v1.TravelService/SearchStays"
+	TravelService_Reprice_FullMethodName       = "/travel.v1.TravelService/Reprice"
+	TravelService_ListProviders_FullMethodName = "/travel.v1.TravelService/ListProviders"
+)
+
+// TravelServiceClient is the client API for TravelService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TravelServiceClient interface {
+	// SearchFlights searches every active flight provider and returns the
+	// deduped, ranked offers.
+	SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error)
+	// SearchStays searches every active stay provider.
+	SearchStays(ctx context.Context, in *SearchStaysRequest, opts ...grpc.CallOption) (*SearchStaysResponse, error)
+	// Reprice checks an offer a recent search returned. Live repricing is
+	// not implemented yet, so the stored offer is returned with any expiry
+	// warnings.
+	Reprice(ctx context.Context, in *RepriceRequest, opts ...grpc.CallOption) (*RepriceResponse, error)
+	// ListProviders lists the registered providers and their status.
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+}
+
+type travelServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTravelServiceClient(cc grpc.ClientConnInterface) TravelServiceClient {
+	return &travelServiceClient{cc}
+}
+
+func (c *travelServiceClient) SearchFlights(ctx context.Context, in *SearchFlightsRequest, opts ...grpc.CallOption) (*SearchFlightsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchFlightsResponse)
+	err := c.cc.Invoke(ctx, TravelService_SearchFlights_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *travelServiceClient) SearchStays(ctx context.Context, in *SearchStaysRequest, opts ...grpc.CallOption) (*SearchStaysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchStaysResponse)
+	err := c.cc.Invoke(ctx, TravelService_SearchStays_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *travelServiceClient) Reprice(ctx context.Context, in *RepriceRequest, opts ...grpc.CallOption) (*RepriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RepriceResponse)
+	err := c.cc.Invoke(ctx, TravelService_Reprice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *travelServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, TravelService_ListProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TravelServiceServer is the server API for TravelService service.
+// All implementations must embed UnimplementedTravelServiceServer
+// for forward compatibility.
+type TravelServiceServer interface {
+	// SearchFlights searches every active flight provider and returns the
+	// deduped, ranked offers.
+	SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error)
+	// SearchStays searches every active stay provider.
+	SearchStays(context.Context, *SearchStaysRequest) (*SearchStaysResponse, error)
+	// Reprice checks an offer a recent search returned. Live repricing is
+	// not implemented yet, so the stored offer is returned with any expiry
+	// warnings.
+	Reprice(context.Context, *RepriceRequest) (*RepriceResponse, error)
+	// ListProviders lists the registered providers and their status.
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	mustEmbedUnimplementedTravelServiceServer()
+}
+
+// UnimplementedTravelServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTravelServiceServer struct{}
+
+func (UnimplementedTravelServiceServer) SearchFlights(context.Context, *SearchFlightsRequest) (*SearchFlightsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchFlights not implemented")
+}
+func (UnimplementedTravelServiceServer) SearchStays(context.Context, *SearchStaysRequest) (*SearchStaysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchStays not implemented")
+}
+func (UnimplementedTravelServiceServer) Reprice(context.Context, *RepriceRequest) (*RepriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reprice not implemented")
+}
+func (UnimplementedTravelServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedTravelServiceServer) mustEmbedUnimplementedTravelServiceServer() {}
+func (UnimplementedTravelServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeTravelServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TravelServiceServer will
+// result in compilation errors.
+type UnsafeTravelServiceServer interface {
+	mustEmbedUnimplementedTravelServiceServer()
+}
+
+func RegisterTravelServiceServer(s grpc.ServiceRegistrar, srv TravelServiceServer) {
+	// If the following call panics, it indicates UnimplementedTravelServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TravelService_ServiceDesc, srv)
+}
+
+func _TravelService_SearchFlights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchFlightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TravelServiceServer).SearchFlights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TravelService_SearchFlights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TravelServiceServer).SearchFlights(ctx, req.(*SearchFlightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TravelService_SearchStays_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStaysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TravelServiceServer).SearchStays(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TravelService_SearchStays_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TravelServiceServer).SearchStays(ctx, req.(*SearchStaysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TravelService_Reprice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TravelServiceServer).Reprice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TravelService_Reprice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TravelServiceServer).Reprice(ctx, req.(*RepriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TravelService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TravelServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TravelService_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TravelServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TravelService_ServiceDesc is the grpc.ServiceDesc for TravelService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TravelService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "travel.v1.TravelService",
+	HandlerType: (*TravelServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchFlights",
+			Handler:    _TravelService_SearchFlights_Handler,
+		},
+		{
+			MethodName: "SearchStays",
+			Handler:    _TravelService_SearchStays_Handler,
+		},
+		{
+			MethodName: "Reprice",
+			Handler:    _TravelService_Reprice_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _TravelService_ListProviders_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "travel/v1/travel.proto",
+}